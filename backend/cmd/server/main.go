@@ -5,6 +5,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"log/slog"
@@ -69,6 +70,22 @@ func main() {
 	// IsOrganizer is exposed for cross-service use by RoundService and ScoreService.
 	eventService := services.NewEventService(db)
 
+	// Waitlist offers that lapse pass the spot to the next player in line. The
+	// sweep runs once a minute; accepting an offer also checks its expiry, so a
+	// late sweep never lets a lapsed offer be taken.
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := eventService.ExpireWaitlistOffers(context.Background()); err != nil {
+				slog.Warn("Waitlist offer sweep failed",
+					"event_type_label", "event.waitlist_sweep_failed",
+					"error", err.Error(),
+				)
+			}
+		}
+	}()
+
 	// RoundService owns round scheduling, group management, and member assignment.
 	// Depends on EventService for the shared IsOrganizer permission check.
 	roundService := services.NewRoundService(db, eventService)
@@ -80,6 +97,100 @@ func main() {
 	// UserService owns profile lookup, follow/unfollow, career stats, and scorecard settings.
 	userService := services.NewUserService(db)
 
+	// LeaderboardService ranks round leaderboards and event standings (applying the
+	// event's tiebreak policy) and finalizes/reopens event results.
+	// Depends on EventService for the organizer check on finalize/reopen.
+	leaderboardService := services.NewLeaderboardService(db, eventService)
+
+	// BracketService owns match play brackets for tournament events. Depends on
+	// RoundService to schedule each match as a match_play round.
+	bracketService := services.NewBracketService(db, eventService, roundService)
+
+	// CupService owns event teams and Ryder Cup–style sessions of matches.
+	cupService := services.NewCupService(db, eventService)
+
+	// EventScheduleService owns league schedule templates. Depends on
+	// RoundService to schedule the season's rounds.
+	eventScheduleService := services.NewEventScheduleService(db, eventService, roundService)
+
+	// PurseService owns entry fees, payout schedules and the paid/owed ledger for
+	// events and rounds. Depends on LeaderboardService for finishing positions.
+	purseService := services.NewPurseService(db, eventService, leaderboardService)
+
+	// LedgerService owns the side-bet ledger: round side-game results, balances
+	// netted between players, and settle-ups. Depends on RoundService for the
+	// round organizer check.
+	ledgerService := services.NewLedgerService(db, roundService)
+
+	// NotificationService reads and manages each user's in-app inbox. The other
+	// services write notifications themselves as things happen.
+	notificationService := services.NewNotificationService(db)
+
+	// Groups teeing off within the hour get a reminder; each player hears once
+	// per round, so the sweep can run every minute.
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := notificationService.SendTeeTimeReminders(context.Background()); err != nil {
+				slog.Warn("Tee time reminder sweep failed",
+					"event_type_label", "notification.tee_time_sweep_failed",
+					"error", err.Error(),
+				)
+			}
+		}
+	}()
+
+	// PushService registers devices and delivers the push outbox that notify
+	// fills alongside the in-app inbox. The worker drains due pushes every few
+	// seconds; failed sends back off exponentially and dead tokens are pruned.
+	pushService := services.NewPushService(db, services.NewExpoPushSender(cfg.ExpoAccessToken))
+	go func() {
+		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := pushService.DeliverDue(context.Background()); err != nil {
+				slog.Warn("Push delivery failed",
+					"event_type_label", "push.delivery_failed",
+					"error", err.Error(),
+				)
+			}
+		}
+	}()
+
+	// FeedService lists the activity feed. Items are written by the round,
+	// score and leaderboard services as rounds complete and events finalize.
+	feedService := services.NewFeedService(db)
+
+	// PrivacyService owns privacy settings and blocks; the user, score and feed
+	// services apply them.
+	privacyService := services.NewPrivacyService(db)
+
+	// CommentService owns comments and emoji reactions on completed rounds.
+	commentService := services.NewCommentService(db, roundService)
+
+	// DigestService emails each event member a weekly digest of results,
+	// standings movement and upcoming tee times. Mail goes through SMTP when
+	// SMTP_HOST is set, otherwise to MAIL_DIR (or the log) for local dev. The
+	// job runs hourly; each user is due a week after their last digest.
+	var mailer services.Mailer = services.NewLogMailer(cfg.MailDir, cfg.MailFrom)
+	if cfg.SMTPHost != "" {
+		mailer = services.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	}
+	digestService := services.NewDigestService(db, leaderboardService, mailer, cfg.PublicBaseURL+"/digest/unsubscribe?token=")
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := digestService.SendDueDigests(context.Background()); err != nil {
+				slog.Warn("Digest run failed",
+					"event_type_label", "digest.run_failed",
+					"error", err.Error(),
+				)
+			}
+		}
+	}()
+
 	app := fiber.New(fiber.Config{
 		AppName: "Golf League API",
 	})
@@ -110,6 +221,12 @@ func main() {
 	// GET /health — liveness check for Railway and load balancers; no auth, no DB.
 	app.Get("/health", handlers.HealthCheck)
 
+	// Digest unsubscribe links are opened from email, without signing in, so
+	// they sit outside the authenticated /api/v1 group. GET only shows a
+	// confirm page; POST is what unsubscribes.
+	app.Get("/digest/unsubscribe", handlers.ConfirmUnsubscribeDigest())
+	app.Post("/digest/unsubscribe", handlers.UnsubscribeDigest(digestService))
+
 	// All routes under /api/v1 require a valid Supabase JWT.
 	// app.Group applies the middleware to every route registered on the returned group.
 	api := app.Group("/api/v1", middleware.Auth(cfg, db))
@@ -140,13 +257,72 @@ func main() {
 	api.Post("/events/:id/members", durableIdempotency, handlers.AddEventMember(eventService))
 	api.Delete("/events/:id/members/:userId", handlers.RemoveEventMember(eventService))
 	api.Patch("/events/:id/members/:userId/role", handlers.UpdateMemberRole(eventService))
+	api.Patch("/events/:id/members/:userId/flight", handlers.SetMemberFlight(eventService))
+
+	// Substitutes — a roster of subs outside the regular field; replace swaps one
+	// into a round in a registered player's spot (group, team and flight kept).
+	api.Get("/events/:id/substitutes", handlers.GetEventSubstitutes(eventService))
+	api.Post("/events/:id/substitutes", durableIdempotency, handlers.AddEventSubstitute(eventService))
+	api.Delete("/events/:id/substitutes/:userId", handlers.RemoveEventSubstitute(eventService))
+
+	// Flights — A/B/C divisions ranked separately on leaderboards, standings and points.
+	api.Get("/events/:id/flights", handlers.GetEventFlights(eventService))
+	api.Put("/events/:id/flights", replayLog, handlers.SetEventFlights(eventService))
+	api.Post("/events/:id/flights/recompute", handlers.RecomputeEventFlights(eventService))
 
 	api.Get("/events/:id/rounds", handlers.GetEventRounds(eventService))
 	api.Post("/events/:id/rounds", durableIdempotency, handlers.ScheduleEventRound(roundService))
 
+	// Brackets — match play elimination trees for tournament events. Completing a
+	// match's round advances the winner; the result route decides halved matches.
+	api.Get("/events/:id/bracket", handlers.GetEventBracket(bracketService))
+	api.Post("/events/:id/bracket", durableIdempotency, handlers.CreateEventBracket(bracketService))
+	api.Delete("/events/:id/bracket", handlers.DeleteEventBracket(bracketService))
+	api.Post("/events/:id/bracket/matches/:matchId/round", durableIdempotency, handlers.ScheduleBracketMatch(bracketService))
+	api.Post("/events/:id/bracket/matches/:matchId/result", handlers.RecordBracketResult(bracketService))
+
+	// Cup — two event teams playing sessions of four-ball, foursomes and singles
+	// matches for a point each. Completing a session's round scores its matches.
+	api.Get("/events/:id/cup", handlers.GetEventCup(cupService))
+	api.Put("/events/:id/cup/teams", replayLog, handlers.SetCupTeams(cupService))
+	api.Patch("/events/:id/members/:userId/cup-team", handlers.SetMemberCupTeam(cupService))
+	api.Post("/events/:id/cup/sessions", durableIdempotency, handlers.CreateCupSession(cupService))
+	api.Delete("/events/:id/cup/sessions/:sessionId", handlers.DeleteCupSession(cupService))
+	api.Post("/events/:id/cup/sessions/:sessionId/matches", durableIdempotency, handlers.CreateCupMatch(cupService))
+	api.Delete("/events/:id/cup/matches/:matchId", handlers.DeleteCupMatch(cupService))
+	api.Post("/events/:id/cup/matches/:matchId/result", handlers.RecordCupResult(cupService))
+
+	// League schedule — a weekly template that generates the season's rounds.
+	// Saving it again regenerates future rounds; played rounds are never touched.
+	api.Get("/events/:id/schedule", handlers.GetEventSchedule(eventScheduleService))
+	api.Put("/events/:id/schedule", replayLog, handlers.SaveEventSchedule(eventScheduleService))
+
 	api.Post("/events/:id/request-join", handlers.RequestJoinEvent(eventService))
 	api.Get("/events/:id/join-requests", handlers.GetJoinRequests(eventService))
 	api.Patch("/events/:id/join-requests/:userId", handlers.HandleJoinRequest(eventService))
+	// Capacity and waitlist — a full event queues join requests; an opened spot goes to
+	// the next player in line, outright or as a timed offer (waitlist_offer_hours).
+	api.Get("/events/:id/waitlist", handlers.GetEventWaitlist(eventService))
+	api.Post("/events/:id/waitlist/accept", handlers.AcceptWaitlistOffer(eventService))
+	api.Post("/events/:id/withdraw", handlers.WithdrawFromEvent(eventService))
+	// Invitations — shareable codes (optionally capped / single-use, expiring) or invites
+	// addressed to an email, which resolve to an "invited" membership at first sign-in.
+	api.Get("/events/:id/invites", handlers.GetEventInvites(eventService, cfg.InviteLinkBase))
+	api.Post("/events/:id/invites", durableIdempotency, handlers.CreateEventInvite(eventService, cfg.InviteLinkBase))
+	api.Delete("/events/:id/invites/:inviteId", handlers.RevokeEventInvite(eventService))
+	api.Get("/invites/:code", handlers.GetInvite(eventService, cfg.InviteLinkBase))
+	api.Post("/invites/:code/accept", handlers.AcceptInvite(eventService))
+	api.Post("/invites/:code/decline", handlers.DeclineInvite(eventService))
+	// Event standings — totals across completed rounds, card-off per the event's tiebreak policy.
+	api.Get("/events/:id/standings", handlers.GetEventStandings(leaderboardService))
+	// Finalize writes final positions/points and locks scores; reopen (with a reason) undoes it.
+	api.Post("/events/:id/finalize", handlers.FinalizeEvent(leaderboardService))
+	api.Post("/events/:id/reopen", handlers.ReopenEvent(leaderboardService))
+	// Entry fees and purse — organizers set the fee and payout schedule and mark who has paid.
+	api.Get("/events/:id/purse", handlers.GetEventPurse(purseService))
+	api.Put("/events/:id/purse", replayLog, handlers.SetEventPurse(purseService))
+	api.Put("/events/:id/purse/payments/:userId", replayLog, handlers.RecordEventPayment(purseService))
+	api.Delete("/events/:id/purse/payments/:userId", handlers.ClearEventPayment(purseService))
 
 	// Round routes — round IDs are globally unique, so these are top-level.
 	// GET and POST /rounds must be registered before /rounds/:roundId so Fiber's
@@ -162,10 +338,15 @@ func main() {
 	api.Post("/rounds/:roundId/groups/:groupId/members", durableIdempotency, handlers.AddGroupMember(roundService))
 	api.Post("/rounds/:roundId/groups/:groupId/guests", durableIdempotency, handlers.AddGuestToGroup(roundService))
 	api.Delete("/rounds/:roundId/groups/:groupId/members/:userId", handlers.RemoveGroupMember(roundService))
+	api.Post("/rounds/:roundId/pairings/generate", durableIdempotency, handlers.GeneratePairings(roundService))
+	api.Post("/rounds/:roundId/tee-times/generate", handlers.GenerateTeeTimes(roundService))
+	api.Post("/rounds/:roundId/players/:roundPlayerId/replace", handlers.ReplaceRoundPlayer(roundService))
 
 	// Las Vegas team routes — organizer-only partner assignment for las_vegas rounds.
+	// teams/generate splits every group into handicap-balanced teams (best_ball too).
 	api.Get("/rounds/:roundId/teams", handlers.ListTeams(roundService))
 	api.Post("/rounds/:roundId/teams", durableIdempotency, handlers.CreateTeam(roundService))
+	api.Post("/rounds/:roundId/teams/generate", durableIdempotency, handlers.GenerateTeams(roundService))
 	api.Put("/rounds/:roundId/teams/:teamId/members", replayLog, handlers.AssignTeamMembers(roundService))
 	api.Delete("/rounds/:roundId/teams/:teamId", handlers.DeleteTeam(roundService))
 
@@ -173,9 +354,41 @@ func main() {
 	// replayLog (constructed above) turns a client retry that lands on an already-committed
 	// (idempotent) save into a server-side phantom-save signal.
 	api.Get("/rounds/:roundId/scorecard", handlers.GetRoundScorecard(scoreService))
+	api.Get("/rounds/:roundId/leaderboard", handlers.GetRoundLeaderboard(leaderboardService))
+	// Per-round entry fees (skins pots, weekly games) — same ledger as the event purse.
+	api.Get("/rounds/:roundId/purse", handlers.GetRoundPurse(purseService))
+	api.Put("/rounds/:roundId/purse", replayLog, handlers.SetRoundPurse(purseService))
+	api.Put("/rounds/:roundId/purse/payments/:userId", replayLog, handlers.RecordRoundPayment(purseService))
+	api.Delete("/rounds/:roundId/purse/payments/:userId", handlers.ClearRoundPayment(purseService))
+	// Side-game results (Vegas, skins, Nassau) posted once the round is completed;
+	// they feed each player's ledger under /users/me/ledger.
+	api.Get("/rounds/:roundId/side-games", handlers.GetRoundSideGames(ledgerService))
+	api.Put("/rounds/:roundId/side-games/:game", replayLog, handlers.PostSideGameResults(ledgerService))
 	api.Put("/rounds/:roundId/players/:roundPlayerId/handicap", handlers.SetPlayerHandicap(scoreService))
 	api.Put("/rounds/:roundId/players/:roundPlayerId/scores", replayLog, handlers.UpsertPlayerScores(scoreService, hub))
 	api.Put("/rounds/:roundId/players/:roundPlayerId/hole-stats", replayLog, handlers.UpsertHoleStats(scoreService, hub))
+	// Player + marker sign-off; a fully attested card is closed to group-member edits.
+	api.Post("/rounds/:roundId/players/:roundPlayerId/attest", handlers.AttestScorecard(scoreService, hub))
+	// Absent players — the card is generated per the event's absence_policy and labeled.
+	api.Post("/rounds/:roundId/players/:roundPlayerId/absent", handlers.MarkPlayerAbsent(scoreService, hub))
+	api.Delete("/rounds/:roundId/players/:roundPlayerId/absent", handlers.ClearPlayerAbsence(scoreService, hub))
+	// WD / DQ / NC — out of the results (no position, no points, below the field) until reinstated.
+	api.Put("/rounds/:roundId/players/:roundPlayerId/status", replayLog, handlers.WithdrawPlayer(scoreService, hub))
+	api.Delete("/rounds/:roundId/players/:roundPlayerId/status", handlers.ReinstatePlayer(scoreService, hub))
+	// Append-only change history for a card; organizers can revert a hole-score change.
+	api.Get("/rounds/:roundId/players/:roundPlayerId/history", handlers.GetScoreHistory(scoreService))
+	api.Post("/rounds/:roundId/players/:roundPlayerId/history/:changeId/revert", handlers.RevertScoreChange(scoreService, hub))
+	// Score disputes: any player in the round raises; organizers list and resolve.
+	api.Post("/rounds/:roundId/players/:roundPlayerId/disputes", handlers.RaiseDispute(scoreService, hub))
+	api.Get("/rounds/:roundId/disputes", handlers.ListRoundDisputes(scoreService))
+	api.Patch("/rounds/:roundId/disputes/:disputeId", handlers.ResolveDispute(scoreService, hub))
+	// Comments and reactions on a completed round and its holes; organizers moderate.
+	api.Get("/rounds/:roundId/comments", handlers.GetRoundComments(commentService))
+	api.Post("/rounds/:roundId/comments", durableIdempotency, handlers.AddRoundComment(commentService, hub))
+	api.Delete("/rounds/:roundId/comments/:commentId", handlers.DeleteRoundComment(commentService, hub))
+	api.Get("/rounds/:roundId/reactions", handlers.GetRoundReactions(commentService))
+	api.Put("/rounds/:roundId/reactions", replayLog, handlers.ReactToRound(commentService, hub))
+	api.Delete("/rounds/:roundId/reactions", handlers.UnreactToRound(commentService, hub))
 
 	// Live-score WebSocket. Registered on `app` (not the `api` group) because it uses
 	// query-param auth — a browser can't set an Authorization header on a WS upgrade.
@@ -207,15 +420,42 @@ func main() {
 	api.Get("/users/following", handlers.GetFollowing(userService))
 	api.Get("/users/me/scorecard-settings", handlers.GetScorecardSettings(userService))
 	api.Patch("/users/me/scorecard-settings", handlers.UpsertScorecardSettings(userService))
+	api.Get("/users/me/invites", handlers.GetMyInvites(eventService, cfg.InviteLinkBase))
+	api.Get("/users/me/waitlist", handlers.GetMyWaitlists(eventService))
+	// Weekly email digest subscription.
+	api.Get("/users/me/digest", handlers.GetDigestSettings(digestService))
+	api.Put("/users/me/digest", handlers.UpdateDigestSettings(digestService))
+	// Push notification devices.
+	api.Post("/users/me/devices", handlers.RegisterDevice(pushService))
+	api.Delete("/users/me/devices/:id", handlers.UnregisterDevice(pushService))
+	// Notification inbox and per-type preferences.
+	api.Get("/users/me/notifications", handlers.GetMyNotifications(notificationService))
+	api.Post("/users/me/notifications/read-all", handlers.MarkAllNotificationsRead(notificationService))
+	api.Get("/users/me/notifications/preferences", handlers.GetNotificationPreferences(notificationService))
+	api.Patch("/users/me/notifications/preferences", handlers.UpdateNotificationPreferences(notificationService))
+	api.Post("/users/me/notifications/:id/read", handlers.MarkNotificationRead(notificationService))
+	// Privacy settings and blocked users.
+	api.Get("/users/me/privacy", handlers.GetPrivacySettings(privacyService))
+	api.Patch("/users/me/privacy", handlers.UpdatePrivacySettings(privacyService))
+	api.Get("/users/me/blocks", handlers.GetBlockedUsers(privacyService))
+	// Side-bet ledger — balances net per pair of players across all rounds and events.
+	api.Get("/users/me/ledger", handlers.GetMyLedger(ledgerService))
+	api.Get("/users/me/ledger/:userId", handlers.GetPairLedger(ledgerService))
+	api.Post("/users/me/ledger/:userId/settle", durableIdempotency, handlers.SettleUp(ledgerService))
+	api.Delete("/users/me/ledger/entries/:entryId", handlers.DeleteSettleUp(ledgerService))
 	api.Get("/users/:userId", handlers.GetUserProfile(userService))
 	api.Get("/users/:userId/stats", handlers.GetUserStats(userService))
 	api.Get("/users/:userId/rounds", handlers.GetUserRounds(userService))
+	// Activity feed — rounds, personal bests, eagles, aces and wins from the people you follow.
+	api.Get("/feed", handlers.GetFeed(feedService))
 	// Batched scorecards for a user's last-N completed rounds in one response — the stats
 	// screen feeds these to the client-side stat math instead of fanning out one
 	// /rounds/:id/scorecard per round (removes the FRONTEND-2 N+1).
 	api.Get("/users/:userId/scorecards", handlers.GetUserScorecards(scoreService))
 	api.Post("/users/:userId/follow", handlers.FollowUser(userService))
 	api.Delete("/users/:userId/follow", handlers.UnfollowUser(userService))
+	api.Post("/users/:userId/block", handlers.BlockUser(privacyService))
+	api.Delete("/users/:userId/block", handlers.UnblockUser(privacyService))
 	api.Get("/users", handlers.SearchUsers(userService))
 
 	// Start the server in a goroutine so we can listen for OS signals below.
@@ -6,6 +6,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	// godotenv reads .env files in development; in production real env vars take precedence.
 	"github.com/joho/godotenv"
@@ -18,6 +19,19 @@ type Config struct {
 	SupabaseJWKSURL  string // Supabase JWKS endpoint for RS256 JWT signature verification
 	Env              string // Runtime environment: "development", "staging", or "production"
 	GolfCourseAPIKey string // API key for GolfCourseAPI.com — enables external course search/import
+	InviteLinkBase   string // Prefix for shareable event invite links; the invite code is appended
+	ExpoAccessToken  string // Expo push access token; only needed when the Expo project enforces push security
+	PublicBaseURL    string // Public URL of this API, for links in emails (e.g. "https://api.example.com")
+
+	// Email — the weekly digest goes through SMTP when SMTPHost is set. Without
+	// it, digests are written to MailDir as .eml files, or logged when that's
+	// empty too, so local dev needs no mail server.
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string // sender address, e.g. "Golf League <digest@example.com>"
+	MailDir      string
 
 	// Logging — structured slog output at or above this level (debug|info|warn|error, default: info)
 	LogLevel string
@@ -79,12 +93,45 @@ func Load() *Config {
 		}
 	}
 
+	// Invite links open the app by default; point INVITE_LINK_BASE at the web
+	// app (e.g. "https://example.com/invite/") to share browser links instead.
+	inviteLinkBase := os.Getenv("INVITE_LINK_BASE")
+	if inviteLinkBase == "" {
+		inviteLinkBase = "golfstuffinhere://invite/"
+	}
+
+	// Digest emails link back to the API (the unsubscribe link), so they need
+	// its public address; local dev falls back to the listening port.
+	publicBaseURL := os.Getenv("PUBLIC_BASE_URL")
+	if publicBaseURL == "" {
+		publicBaseURL = "http://localhost:" + port
+	}
+
+	smtpPort := os.Getenv("SMTP_PORT")
+	if smtpPort == "" {
+		smtpPort = "587"
+	}
+
+	mailFrom := os.Getenv("MAIL_FROM")
+	if mailFrom == "" {
+		mailFrom = "Golf League <no-reply@localhost>"
+	}
+
 	return &Config{
 		Port:                   port,
 		DatabaseURL:            os.Getenv("DATABASE_URL"),
 		SupabaseJWKSURL:        os.Getenv("SUPABASE_JWKS_URL"),
 		Env:                    env,
 		GolfCourseAPIKey:       os.Getenv("GOLF_COURSE_API_KEY"),
+		InviteLinkBase:         inviteLinkBase,
+		ExpoAccessToken:        os.Getenv("EXPO_ACCESS_TOKEN"),
+		PublicBaseURL:          strings.TrimRight(publicBaseURL, "/"),
+		SMTPHost:               os.Getenv("SMTP_HOST"),
+		SMTPPort:               smtpPort,
+		SMTPUsername:           os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:           os.Getenv("SMTP_PASSWORD"),
+		MailFrom:               mailFrom,
+		MailDir:                os.Getenv("MAIL_DIR"),
 		LogLevel:               logLevel,
 		SentryDSN:              os.Getenv("SENTRY_DSN"),
 		SentryRelease:          sentryRelease,
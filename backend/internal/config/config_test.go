@@ -63,3 +63,21 @@ func TestLoad_SentryReleaseFallback(t *testing.T) {
 		}
 	})
 }
+
+// TestLoad_PublicBaseURL covers the address digest emails link back to.
+func TestLoad_PublicBaseURL(t *testing.T) {
+	t.Run("defaults to the local port", func(t *testing.T) {
+		t.Setenv("PUBLIC_BASE_URL", "")
+		t.Setenv("PORT", "9090")
+		if got := Load().PublicBaseURL; got != "http://localhost:9090" {
+			t.Errorf("PublicBaseURL = %q, want http://localhost:9090", got)
+		}
+	})
+
+	t.Run("trailing slash trimmed", func(t *testing.T) {
+		t.Setenv("PUBLIC_BASE_URL", "https://api.example.com/")
+		if got := Load().PublicBaseURL; got != "https://api.example.com" {
+			t.Errorf("PublicBaseURL = %q, want https://api.example.com", got)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+// handlers/absences.go
+// HTTP handlers for absent players. All business logic lives in
+// internal/services.ScoreService (score_absence.go); errors map through
+// writeScoreError, shared with the score handlers.
+//
+// Marking or clearing an absence rewrites the player's card, so both push a
+// "scores_updated" message to the round's WebSocket subscribers.
+//
+// Endpoints:
+//
+//	POST   /api/v1/rounds/:roundId/players/:roundPlayerId/absent → mark absent and generate the card (organizer only)
+//	DELETE /api/v1/rounds/:roundId/players/:roundPlayerId/absent → mark present again, removing the generated card (organizer only)
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// parseRoundPlayerPath parses the ":roundId" and ":roundPlayerId" path params.
+// Writes 400 + returns false on failure.
+func parseRoundPlayerPath(c *fiber.Ctx) (uuid.UUID, uuid.UUID, bool) {
+	roundID, ok := parseRoundID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	roundPlayerID, err := uuid.Parse(c.Params("roundPlayerId"))
+	if err != nil {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round player ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return roundID, roundPlayerID, true
+}
+
+// MarkPlayerAbsent returns a handler for POST .../players/:roundPlayerId/absent.
+// The card is generated per the event's absence_policy; marking again
+// regenerates it (a blind draw redraws).
+func MarkPlayerAbsent(svc *services.ScoreService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, roundPlayerID, ok := parseRoundPlayerPath(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.MarkAbsent(c.UserContext(), roundID, roundPlayerID, userID, userRole)
+		if err != nil {
+			return writeScoreError(c, err, "score.mark_absent", "failed to mark player absent")
+		}
+		slog.InfoContext(c.UserContext(), "Player marked absent",
+			"event_type_label", "round.player_absent",
+			"round_id", roundID.String(),
+			"round_player_id", roundPlayerID.String(),
+			"policy", data.Policy,
+		)
+		broadcastScoresUpdated(bc, roundID)
+		return c.JSON(data)
+	}
+}
+
+// ClearPlayerAbsence returns a handler for DELETE .../players/:roundPlayerId/absent.
+func ClearPlayerAbsence(svc *services.ScoreService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, roundPlayerID, ok := parseRoundPlayerPath(c)
+		if !ok {
+			return nil
+		}
+		if err := svc.ClearAbsence(c.UserContext(), roundID, roundPlayerID, userID, userRole); err != nil {
+			return writeScoreError(c, err, "score.clear_absence", "failed to clear absence")
+		}
+		broadcastScoresUpdated(bc, roundID)
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
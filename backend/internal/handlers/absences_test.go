@@ -0,0 +1,45 @@
+// absences_test.go
+// Unit tests for the absent-player handlers in absences.go.
+//
+// Strategy: Tier 1 only — auth and path-param validation return before any
+// service call, so a nil-DB ScoreService is safe. Card generation and the
+// leaderboard are covered in services/score_absence_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Absen -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+)
+
+const absentRoute = "/rounds/:roundId/players/:roundPlayerId/absent"
+
+func TestMarkPlayerAbsent_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, absentRoute, handlers.MarkPlayerAbsent(nilScoreSvc(), nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/rounds/"+validUUID+"/players/"+validUUID+"/absent", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestMarkPlayerAbsent_InvalidRoundPlayerID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, absentRoute, handlers.MarkPlayerAbsent(nilScoreSvc(), nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/rounds/"+validUUID+"/players/carol/absent", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestClearPlayerAbsence_InvalidRoundID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodDelete, absentRoute, handlers.ClearPlayerAbsence(nilScoreSvc(), nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/rounds/not-a-uuid/players/"+validUUID+"/absent", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
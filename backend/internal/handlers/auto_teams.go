@@ -0,0 +1,124 @@
+// handlers/auto_teams.go
+// HTTP handler for the automatic team builder. The split itself lives in
+// internal/services.RoundService.GenerateTeams; errors map through
+// writeRoundError.
+//
+// Endpoints:
+//
+//	POST /api/v1/rounds/:roundId/teams/generate → split every group into handicap-balanced teams (organizer only)
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request / response types ─────────────────────────────────────────────────
+
+// GenerateTeamsRequest is the body for POST /api/v1/rounds/:roundId/teams/generate.
+type GenerateTeamsRequest struct {
+	Preview bool `json:"preview"`
+}
+
+// AutoTeamPlayerResponse is one player on a generated team. course_handicap is
+// the handicap the split used; null counts as 0.
+type AutoTeamPlayerResponse struct {
+	RoundPlayerID  string `json:"round_player_id"`
+	UserID         string `json:"user_id"`
+	DisplayName    string `json:"display_name"`
+	IsGuest        bool   `json:"is_guest"`
+	CourseHandicap *int   `json:"course_handicap"`
+}
+
+// AutoTeamResponse is one generated team. id is null in a preview.
+type AutoTeamResponse struct {
+	ID               *string                  `json:"id"`
+	Name             string                   `json:"name"`
+	CombinedHandicap int                      `json:"combined_handicap"`
+	Players          []AutoTeamPlayerResponse `json:"players"`
+}
+
+// AutoTeamGroupResponse is one group's two teams.
+type AutoTeamGroupResponse struct {
+	GroupID     string             `json:"group_id"`
+	GroupNumber int                `json:"group_number"`
+	HandicapGap int                `json:"handicap_gap"`
+	Teams       []AutoTeamResponse `json:"teams"`
+}
+
+// AutoTeamsResponse is the response for POST /api/v1/rounds/:roundId/teams/generate.
+type AutoTeamsResponse struct {
+	Preview bool                    `json:"preview"`
+	Groups  []AutoTeamGroupResponse `json:"groups"`
+}
+
+// toAutoTeamsResponse maps the service result to its JSON shape.
+func toAutoTeamsResponse(r services.AutoTeamsResult) AutoTeamsResponse {
+	out := AutoTeamsResponse{Preview: r.Preview, Groups: make([]AutoTeamGroupResponse, len(r.Groups))}
+	for i, g := range r.Groups {
+		group := AutoTeamGroupResponse{
+			GroupID: g.GroupID.String(), GroupNumber: g.GroupNumber,
+			HandicapGap: g.HandicapGap, Teams: make([]AutoTeamResponse, len(g.Teams)),
+		}
+		for j, t := range g.Teams {
+			team := AutoTeamResponse{
+				Name: t.Name, CombinedHandicap: t.CombinedHandicap,
+				Players: make([]AutoTeamPlayerResponse, len(t.Players)),
+			}
+			if t.TeamID != nil {
+				id := t.TeamID.String()
+				team.ID = &id
+			}
+			for k, p := range t.Players {
+				team.Players[k] = AutoTeamPlayerResponse{
+					RoundPlayerID: p.RoundPlayerID.String(), UserID: p.UserID.String(),
+					DisplayName: p.DisplayName, IsGuest: p.IsGuest, CourseHandicap: p.CourseHandicap,
+				}
+			}
+			group.Teams[j] = team
+		}
+		out.Groups[i] = group
+	}
+	return out
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// GenerateTeams returns a handler for POST /api/v1/rounds/:roundId/teams/generate.
+// Organizer-only, las_vegas and best_ball rounds only, and only while the round
+// is scheduled. A preview responds 200; a commit replaces the round's teams and
+// responds 201.
+func GenerateTeams(svc *services.RoundService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		callerID, callerRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		var req GenerateTeamsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+
+		result, err := svc.GenerateTeams(c.UserContext(), roundID, callerID, callerRole, services.GenerateTeamsInput{
+			Preview: req.Preview,
+		})
+		if err != nil {
+			return writeRoundError(c, err, "round.generate_teams", "failed to generate teams")
+		}
+		if result.Preview {
+			return c.JSON(toAutoTeamsResponse(result))
+		}
+		slog.InfoContext(c.UserContext(), "Teams generated",
+			"event_type_label", "round.teams_generated",
+			"round_id", roundID.String(),
+			"group_count", len(result.Groups),
+		)
+		return c.Status(fiber.StatusCreated).JSON(toAutoTeamsResponse(result))
+	}
+}
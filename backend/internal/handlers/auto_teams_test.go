@@ -0,0 +1,47 @@
+// auto_teams_test.go
+// Unit tests for the team builder handler in auto_teams.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation return before
+// any DB call, so a nil-DB RoundService is safe. The splits themselves are
+// covered in services/round_auto_teams_internal_test.go and round_auto_teams_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run GenerateTeams -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+)
+
+const autoTeamsRoute = "/rounds/:roundId/teams/generate"
+
+func TestGenerateTeams_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, autoTeamsRoute, handlers.GenerateTeams(nilRoundSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/rounds/"+validUUID+"/teams/generate", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGenerateTeams_InvalidRoundID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, autoTeamsRoute, handlers.GenerateTeams(nilRoundSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/not-a-uuid/teams/generate", map[string]any{})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGenerateTeams_MalformedBody_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, autoTeamsRoute, handlers.GenerateTeams(nilRoundSvc()))
+	req := httptest.NewRequest(http.MethodPost, "/rounds/"+validUUID+"/teams/generate", strings.NewReader(`{"preview":`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,237 @@
+// handlers/brackets.go
+// HTTP handlers for match play brackets on tournament events. All business
+// logic lives in internal/services.BracketService; errors map through
+// writeBracketError, which falls back to writeEventError.
+//
+// Endpoints:
+//
+//	GET    /api/v1/events/:id/bracket                           → bracket tree (members only)
+//	POST   /api/v1/events/:id/bracket                           → seed a bracket (organizer only)
+//	DELETE /api/v1/events/:id/bracket                           → delete an unstarted bracket (organizer only)
+//	POST   /api/v1/events/:id/bracket/matches/:matchId/round    → schedule the match as a match_play round (organizer only)
+//	POST   /api/v1/events/:id/bracket/matches/:matchId/result   → record the winner by hand (organizer only)
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request types ────────────────────────────────────────────────────────────
+
+// CreateBracketRequest is the body for POST /api/v1/events/:id/bracket.
+// PlayerIDs are user IDs: the field in seed order under manual seeding; under
+// handicap seeding an optional subset of members (empty = every registered member).
+type CreateBracketRequest struct {
+	Format    string   `json:"format"`  // single_elimination (default) | double_elimination
+	Seeding   string   `json:"seeding"` // handicap (default) | manual
+	PlayerIDs []string `json:"player_ids"`
+}
+
+// ScheduleBracketMatchRequest is the body for
+// POST /api/v1/events/:id/bracket/matches/:matchId/round. The round is always
+// match_play; a blank name defaults to the match's place in the bracket.
+type ScheduleBracketMatchRequest struct {
+	Name              string  `json:"name"`
+	ScheduledDate     string  `json:"scheduled_date"`
+	CourseID          *string `json:"course_id"`
+	DefaultTeeID      *string `json:"default_tee_id"`
+	NineHoleSelection *string `json:"nine_hole_selection"`
+	TeeTime           *string `json:"tee_time"`
+}
+
+// RecordBracketResultRequest is the body for
+// POST /api/v1/events/:id/bracket/matches/:matchId/result.
+type RecordBracketResultRequest struct {
+	WinnerEventPlayerID string  `json:"winner_event_player_id"`
+	Result              *string `json:"result"` // optional, e.g. "won at the 19th"
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// writeBracketError maps bracket and match-scheduling errors to HTTP responses,
+// deferring to writeEventError for everything else.
+func writeBracketError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	switch {
+	case errors.Is(err, services.ErrBracketNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "event has no bracket"})
+	case errors.Is(err, services.ErrBracketMatchNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "bracket match not found"})
+	case errors.Is(err, services.ErrBracketExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "event already has a bracket"})
+	case errors.Is(err, services.ErrBracketStarted):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "bracket has already started"})
+	case errors.Is(err, services.ErrBracketMatchNotReady):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "match is not awaiting a result"})
+	case errors.Is(err, services.ErrBracketMatchScheduled):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "match already has a round"})
+	case errors.Is(err, services.ErrCourseNotFound), errors.Is(err, services.ErrTeeNotFound),
+		errors.Is(err, services.ErrRoundForbidden):
+		return writeRoundError(c, err, tag, fallbackMsg)
+	}
+	return writeEventError(c, err, tag, fallbackMsg)
+}
+
+// parseMatchID reads :matchId, writing a 400 and returning ok=false when invalid.
+func parseMatchID(c *fiber.Ctx) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Params("matchId"))
+	if err != nil {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid match ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// GetEventBracket returns a handler for GET /api/v1/events/:id/bracket.
+// Non-admins must be members of the event.
+func GetEventBracket(svc *services.BracketService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		bracket, err := svc.Get(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writeBracketError(c, err, "event.get_bracket", "failed to load bracket")
+		}
+		return c.JSON(bracket)
+	}
+}
+
+// CreateEventBracket returns a handler for POST /api/v1/events/:id/bracket.
+// Organizer-only; responds 201 with the seeded bracket.
+func CreateEventBracket(svc *services.BracketService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		var req CreateBracketRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		in := services.CreateBracketInput{Format: req.Format, Seeding: req.Seeding}
+		for _, raw := range req.PlayerIDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid player_ids"})
+			}
+			in.PlayerIDs = append(in.PlayerIDs, id)
+		}
+		bracket, err := svc.Create(c.UserContext(), eventID, userID, userRole, in)
+		if err != nil {
+			return writeBracketError(c, err, "event.create_bracket", "failed to create bracket")
+		}
+		slog.InfoContext(c.UserContext(), "Bracket created",
+			"event_type_label", "bracket.created",
+			"event_id", eventID.String(),
+			"bracket_id", bracket.ID,
+		)
+		return c.Status(fiber.StatusCreated).JSON(bracket)
+	}
+}
+
+// DeleteEventBracket returns a handler for DELETE /api/v1/events/:id/bracket.
+// Organizer-only; responds 204. Refused once a match is scheduled or played.
+func DeleteEventBracket(svc *services.BracketService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		if err := svc.Delete(c.UserContext(), eventID, userID, userRole); err != nil {
+			return writeBracketError(c, err, "event.delete_bracket", "failed to delete bracket")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// ScheduleBracketMatch returns a handler for
+// POST /api/v1/events/:id/bracket/matches/:matchId/round. Organizer-only;
+// responds 201 with the match, now linked to its round.
+func ScheduleBracketMatch(svc *services.BracketService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		matchID, ok := parseMatchID(c)
+		if !ok {
+			return nil
+		}
+		var req ScheduleBracketMatchRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		if req.CourseID == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "course_id is required"})
+		}
+		match, err := svc.ScheduleMatch(c.UserContext(), eventID, matchID, userID, userRole, services.ScheduleRoundInput{
+			Name:              req.Name,
+			ScheduledDate:     req.ScheduledDate,
+			CourseID:          req.CourseID,
+			DefaultTeeID:      req.DefaultTeeID,
+			NineHoleSelection: req.NineHoleSelection,
+			Groups:            []services.GroupScheduleInput{{TeeTime: req.TeeTime}},
+		})
+		if err != nil {
+			return writeBracketError(c, err, "event.schedule_bracket_match", "failed to schedule match")
+		}
+		return c.Status(fiber.StatusCreated).JSON(match)
+	}
+}
+
+// RecordBracketResult returns a handler for
+// POST /api/v1/events/:id/bracket/matches/:matchId/result. Organizer-only;
+// returns the decided match.
+func RecordBracketResult(svc *services.BracketService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		matchID, ok := parseMatchID(c)
+		if !ok {
+			return nil
+		}
+		var req RecordBracketResultRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		winnerID, err := uuid.Parse(req.WinnerEventPlayerID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid winner_event_player_id"})
+		}
+		match, err := svc.RecordResult(c.UserContext(), eventID, matchID, userID, userRole, winnerID, req.Result)
+		if err != nil {
+			return writeBracketError(c, err, "event.record_bracket_result", "failed to record result")
+		}
+		return c.JSON(match)
+	}
+}
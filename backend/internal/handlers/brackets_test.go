@@ -0,0 +1,96 @@
+// brackets_test.go
+// Unit tests for the match play bracket handlers in brackets.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation return before
+// any DB call, so a nil-DB BracketService is safe. Seeding, byes and
+// advancement are covered in services/bracket_service_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Bracket -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const (
+	bracketRoute      = "/events/:id/bracket"
+	bracketMatchRoute = "/events/:id/bracket/matches/:matchId"
+)
+
+// nilBracketSvc returns a BracketService with no DB; only safe on paths that
+// fail validation first.
+func nilBracketSvc() *services.BracketService {
+	return services.NewBracketService(nil, nilEventSvc(), nil)
+}
+
+func TestGetEventBracket_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, bracketRoute, handlers.GetEventBracket(nilBracketSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/"+validUUID+"/bracket", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetEventBracket_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, bracketRoute, handlers.GetEventBracket(nilBracketSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/not-a-uuid/bracket", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateEventBracket_InvalidFormat_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, bracketRoute, handlers.CreateEventBracket(nilBracketSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/bracket", map[string]any{"format": "round_robin"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateEventBracket_ManualWithoutPlayers_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, bracketRoute, handlers.CreateEventBracket(nilBracketSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/bracket", map[string]any{"seeding": "manual"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateEventBracket_DuplicatePlayer_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, bracketRoute, handlers.CreateEventBracket(nilBracketSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/bracket", map[string]any{
+		"seeding": "manual", "player_ids": []string{validUUID, validUUID},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateEventBracket_InvalidPlayerID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, bracketRoute, handlers.CreateEventBracket(nilBracketSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/bracket", map[string]any{"player_ids": []string{"bob"}})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestScheduleBracketMatch_InvalidMatchID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, bracketMatchRoute+"/round", handlers.ScheduleBracketMatch(nilBracketSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/bracket/matches/not-a-uuid/round", map[string]any{})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestScheduleBracketMatch_MissingCourse_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, bracketMatchRoute+"/round", handlers.ScheduleBracketMatch(nilBracketSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/bracket/matches/"+validUUID+"/round", map[string]any{
+		"scheduled_date": "2026-06-01",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRecordBracketResult_InvalidWinner_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, bracketMatchRoute+"/result", handlers.RecordBracketResult(nilBracketSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/bracket/matches/"+validUUID+"/result", map[string]any{
+		"winner_event_player_id": "alice",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,230 @@
+// handlers/comments.go
+// HTTP handlers for comments and emoji reactions on completed rounds and their
+// holes. Business logic lives in internal/services.CommentService
+// (comment_service.go); errors map through writeCommentError, which falls back
+// to writeRoundError. Every change is broadcast to the round's WebSocket
+// ("comments_updated" / "reactions_updated") so people watching see it.
+//
+// Endpoints:
+//
+//	GET    /api/v1/rounds/:roundId/comments            → comment threads (?hole= narrows to one hole)
+//	POST   /api/v1/rounds/:roundId/comments            → comment or reply (completed rounds)
+//	DELETE /api/v1/rounds/:roundId/comments/:commentId → soft-delete (author or round organizer)
+//	GET    /api/v1/rounds/:roundId/reactions           → reaction counts for the round and each hole
+//	PUT    /api/v1/rounds/:roundId/reactions           → leave a reaction (completed rounds)
+//	DELETE /api/v1/rounds/:roundId/reactions           → take yours back (?emoji=, ?hole=)
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// AddCommentRequest is the body for POST .../comments. hole_number omitted
+// comments on the whole round; parent_id replies to a comment.
+type AddCommentRequest struct {
+	Body       string  `json:"body"`
+	HoleNumber *int    `json:"hole_number"`
+	ParentID   *string `json:"parent_id"`
+}
+
+// ReactRequest is the body for PUT .../reactions.
+type ReactRequest struct {
+	Emoji      string `json:"emoji"`
+	HoleNumber *int   `json:"hole_number"` // omitted = the whole round
+}
+
+// writeCommentError maps comment errors to HTTP responses, deferring to
+// writeRoundError for everything else.
+func writeCommentError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	switch {
+	case errors.Is(err, services.ErrRoundNotCompleted):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "comments and reactions open once the round is completed"})
+	case errors.Is(err, services.ErrCommentNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "comment not found"})
+	case errors.Is(err, services.ErrCommentForbidden):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: err.Error()})
+	}
+	return writeRoundError(c, err, tag, fallbackMsg)
+}
+
+// parseHoleQuery parses the optional ?hole= query param. Writes 400 + returns
+// false on failure.
+func parseHoleQuery(c *fiber.Ctx) (*int, bool) {
+	raw := c.Query("hole")
+	if raw == "" {
+		return nil, true
+	}
+	hole, err := strconv.Atoi(raw)
+	if err != nil {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid hole"})
+		return nil, false
+	}
+	return &hole, true
+}
+
+// GetRoundComments returns a handler for GET /api/v1/rounds/:roundId/comments.
+func GetRoundComments(svc *services.CommentService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		hole, ok := parseHoleQuery(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.ListComments(c.UserContext(), roundID, userID, userRole, hole)
+		if err != nil {
+			return writeCommentError(c, err, "comment.list", "failed to load comments")
+		}
+		return c.JSON(data)
+	}
+}
+
+// AddRoundComment returns a handler for POST /api/v1/rounds/:roundId/comments.
+func AddRoundComment(svc *services.CommentService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		var req AddCommentRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		in := services.AddCommentInput{Body: req.Body, HoleNumber: req.HoleNumber}
+		if req.ParentID != nil {
+			parentID, err := uuid.Parse(*req.ParentID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid parent_id"})
+			}
+			in.ParentID = &parentID
+		}
+		comment, err := svc.AddComment(c.UserContext(), roundID, userID, in)
+		if err != nil {
+			return writeCommentError(c, err, "comment.add", "failed to add comment")
+		}
+		slog.InfoContext(c.UserContext(), "Round comment added",
+			"event_type_label", "comment.added",
+			"round_id", roundID.String(),
+			"comment_id", comment.ID,
+		)
+		broadcastRoundMessage(bc, "comments_updated", roundID)
+		return c.Status(fiber.StatusCreated).JSON(comment)
+	}
+}
+
+// DeleteRoundComment returns a handler for
+// DELETE /api/v1/rounds/:roundId/comments/:commentId.
+func DeleteRoundComment(svc *services.CommentService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		commentID, err := uuid.Parse(c.Params("commentId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid comment ID"})
+		}
+		if err := svc.DeleteComment(c.UserContext(), roundID, commentID, userID, userRole); err != nil {
+			return writeCommentError(c, err, "comment.delete", "failed to delete comment")
+		}
+		slog.InfoContext(c.UserContext(), "Round comment deleted",
+			"event_type_label", "comment.deleted",
+			"round_id", roundID.String(),
+			"comment_id", commentID.String(),
+		)
+		broadcastRoundMessage(bc, "comments_updated", roundID)
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// GetRoundReactions returns a handler for GET /api/v1/rounds/:roundId/reactions.
+func GetRoundReactions(svc *services.CommentService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.Reactions(c.UserContext(), roundID, userID)
+		if err != nil {
+			return writeCommentError(c, err, "reaction.list", "failed to load reactions")
+		}
+		return c.JSON(data)
+	}
+}
+
+// ReactToRound returns a handler for PUT /api/v1/rounds/:roundId/reactions.
+// Leaving the same reaction twice is a no-op.
+func ReactToRound(svc *services.CommentService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		var req ReactRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		data, err := svc.React(c.UserContext(), roundID, userID, req.HoleNumber, req.Emoji)
+		if err != nil {
+			return writeCommentError(c, err, "reaction.add", "failed to add reaction")
+		}
+		broadcastRoundMessage(bc, "reactions_updated", roundID)
+		return c.JSON(data)
+	}
+}
+
+// UnreactToRound returns a handler for DELETE /api/v1/rounds/:roundId/reactions.
+func UnreactToRound(svc *services.CommentService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		hole, ok := parseHoleQuery(c)
+		if !ok {
+			return nil
+		}
+		emoji := c.Query("emoji")
+		if emoji == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "emoji is required"})
+		}
+		data, err := svc.Unreact(c.UserContext(), roundID, userID, hole, emoji)
+		if err != nil {
+			return writeCommentError(c, err, "reaction.remove", "failed to remove reaction")
+		}
+		broadcastRoundMessage(bc, "reactions_updated", roundID)
+		return c.JSON(data)
+	}
+}
@@ -0,0 +1,88 @@
+// comments_test.go
+// Unit tests for the round comment and reaction handlers in comments.go.
+//
+// Strategy: Tier 1 only — auth, path/query parsing and body validation return
+// before any DB call, so a nil-DB CommentService is safe. Threads, moderation
+// and counts are covered in services/comment_service_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run 'Comment|React' -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const (
+	commentsRoute  = "/rounds/:roundId/comments"
+	commentRoute   = "/rounds/:roundId/comments/:commentId"
+	reactionsRoute = "/rounds/:roundId/reactions"
+)
+
+// nilCommentSvc returns a CommentService with no DB; only safe on paths that
+// fail validation first.
+func nilCommentSvc() *services.CommentService {
+	return services.NewCommentService(nil, nil)
+}
+
+func TestGetRoundComments_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, commentsRoute, handlers.GetRoundComments(nilCommentSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/rounds/"+validUUID+"/comments", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetRoundComments_BadHole_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, commentsRoute, handlers.GetRoundComments(nilCommentSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/rounds/"+validUUID+"/comments?hole=seven", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAddRoundComment_InvalidRoundID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, commentsRoute, handlers.AddRoundComment(nilCommentSvc(), nil))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/not-a-uuid/comments", map[string]any{"body": "nice"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAddRoundComment_BlankBody_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, commentsRoute, handlers.AddRoundComment(nilCommentSvc(), nil))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/comments", map[string]any{"body": "   "})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAddRoundComment_InvalidParentID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, commentsRoute, handlers.AddRoundComment(nilCommentSvc(), nil))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/comments",
+		map[string]any{"body": "what a shot", "parent_id": "nope"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestDeleteRoundComment_InvalidCommentID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodDelete, commentRoute, handlers.DeleteRoundComment(nilCommentSvc(), nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/rounds/"+validUUID+"/comments/nope", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestReactToRound_UnknownEmoji_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, reactionsRoute, handlers.ReactToRound(nilCommentSvc(), nil))
+	resp := doJSON(t, app, http.MethodPut, "/rounds/"+validUUID+"/reactions", map[string]any{"emoji": "🍕"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestUnreactToRound_MissingEmoji_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodDelete, reactionsRoute, handlers.UnreactToRound(nilCommentSvc(), nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/rounds/"+validUUID+"/reactions?hole=3", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,352 @@
+// handlers/cup.go
+// HTTP handlers for Ryder Cup–style team competitions within an event. All
+// business logic lives in internal/services.CupService; errors map through
+// writeCupError, which falls back to writeEventError.
+//
+// Endpoints:
+//
+//	GET    /api/v1/events/:id/cup                               → teams, sessions and cup score (members only)
+//	PUT    /api/v1/events/:id/cup/teams                         → name the two teams (organizer only)
+//	PATCH  /api/v1/events/:id/members/:userId/cup-team          → put a member on a team (organizer only)
+//	POST   /api/v1/events/:id/cup/sessions                      → add a session (organizer only)
+//	DELETE /api/v1/events/:id/cup/sessions/:sessionId           → delete a session and its matches (organizer only)
+//	POST   /api/v1/events/:id/cup/sessions/:sessionId/matches   → pair a match (organizer only)
+//	DELETE /api/v1/events/:id/cup/matches/:matchId              → delete a match (organizer only)
+//	POST   /api/v1/events/:id/cup/matches/:matchId/result       → record or correct a result (organizer only)
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request types ────────────────────────────────────────────────────────────
+
+// CupTeamRequest is one team in SetCupTeamsRequest.
+type CupTeamRequest struct {
+	Name  string  `json:"name"`
+	Color *string `json:"color"` // optional, e.g. "#003087"
+}
+
+// SetCupTeamsRequest is the body for PUT /api/v1/events/:id/cup/teams.
+// Exactly two teams; repeat calls rename them in place.
+type SetCupTeamsRequest struct {
+	Teams []CupTeamRequest `json:"teams"`
+}
+
+// SetMemberCupTeamRequest is the body for
+// PATCH /api/v1/events/:id/members/:userId/cup-team. A null team_id takes the
+// member off their team.
+type SetMemberCupTeamRequest struct {
+	TeamID *string `json:"team_id"`
+}
+
+// CreateCupSessionRequest is the body for POST /api/v1/events/:id/cup/sessions.
+type CreateCupSessionRequest struct {
+	Name    string  `json:"name"`     // optional; defaults to "Session N"
+	Format  string  `json:"format"`   // four_ball | foursomes | singles
+	RoundID *string `json:"round_id"` // optional; completing the round scores the session
+}
+
+// CreateCupMatchRequest is the body for
+// POST /api/v1/events/:id/cup/sessions/:sessionId/matches. Both lists hold
+// user IDs; team1 is the first team in the cup payload.
+type CreateCupMatchRequest struct {
+	Team1UserIDs []string `json:"team1_user_ids"`
+	Team2UserIDs []string `json:"team2_user_ids"`
+}
+
+// RecordCupResultRequest is the body for
+// POST /api/v1/events/:id/cup/matches/:matchId/result. A null winner_team_id
+// records a halved match.
+type RecordCupResultRequest struct {
+	WinnerTeamID *string `json:"winner_team_id"`
+	Result       *string `json:"result"` // optional, e.g. "3&2"
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// writeCupError maps cup errors to HTTP responses, deferring to
+// writeEventError for everything else.
+func writeCupError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	switch {
+	case errors.Is(err, services.ErrCupTeamsNotSet):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "event has no cup teams"})
+	case errors.Is(err, services.ErrCupTeamNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "cup team not found"})
+	case errors.Is(err, services.ErrCupSessionNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "cup session not found"})
+	case errors.Is(err, services.ErrCupMatchNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "cup match not found"})
+	case errors.Is(err, services.ErrCupPlayerBusy):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "player already has a match in this session"})
+	}
+	return writeEventError(c, err, tag, fallbackMsg)
+}
+
+// parseUUIDList parses a list of IDs, returning ok=false on the first bad one.
+func parseUUIDList(raw []string) ([]uuid.UUID, bool) {
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, r := range raw {
+		id, err := uuid.Parse(r)
+		if err != nil {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// GetEventCup returns a handler for GET /api/v1/events/:id/cup.
+// Non-admins must be members of the event.
+func GetEventCup(svc *services.CupService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		cup, err := svc.Get(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writeCupError(c, err, "event.get_cup", "failed to load cup")
+		}
+		return c.JSON(cup)
+	}
+}
+
+// SetCupTeams returns a handler for PUT /api/v1/events/:id/cup/teams.
+// Organizer-only; returns the updated cup.
+func SetCupTeams(svc *services.CupService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		var req SetCupTeamsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		if len(req.Teams) != 2 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "exactly 2 teams are required"})
+		}
+		teams := make([]services.CupTeamInput, len(req.Teams))
+		for i, t := range req.Teams {
+			teams[i] = services.CupTeamInput{Name: t.Name, Color: t.Color}
+		}
+		cup, err := svc.SetTeams(c.UserContext(), eventID, userID, userRole, teams)
+		if err != nil {
+			return writeCupError(c, err, "event.set_cup_teams", "failed to set cup teams")
+		}
+		return c.JSON(cup)
+	}
+}
+
+// SetMemberCupTeam returns a handler for
+// PATCH /api/v1/events/:id/members/:userId/cup-team. Organizer-only; responds 204.
+func SetMemberCupTeam(svc *services.CupService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		targetUserID, err := uuid.Parse(c.Params("userId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid user ID in path"})
+		}
+		var req SetMemberCupTeamRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		var teamID *uuid.UUID
+		if req.TeamID != nil {
+			parsed, err := uuid.Parse(*req.TeamID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid team_id"})
+			}
+			teamID = &parsed
+		}
+		if err := svc.SetMemberTeam(c.UserContext(), eventID, userID, userRole, targetUserID, teamID); err != nil {
+			return writeCupError(c, err, "event.set_member_cup_team", "failed to set member team")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// CreateCupSession returns a handler for POST /api/v1/events/:id/cup/sessions.
+// Organizer-only; responds 201 with the updated cup.
+func CreateCupSession(svc *services.CupService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		var req CreateCupSessionRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		in := services.CreateCupSessionInput{Name: req.Name, Format: req.Format}
+		if req.RoundID != nil {
+			parsed, err := uuid.Parse(*req.RoundID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round_id"})
+			}
+			in.RoundID = &parsed
+		}
+		cup, err := svc.CreateSession(c.UserContext(), eventID, userID, userRole, in)
+		if err != nil {
+			return writeCupError(c, err, "event.create_cup_session", "failed to create session")
+		}
+		slog.InfoContext(c.UserContext(), "Cup session created",
+			"event_type_label", "cup.session_created",
+			"event_id", eventID.String(),
+			"format", req.Format,
+		)
+		return c.Status(fiber.StatusCreated).JSON(cup)
+	}
+}
+
+// DeleteCupSession returns a handler for
+// DELETE /api/v1/events/:id/cup/sessions/:sessionId. Organizer-only; responds 204.
+func DeleteCupSession(svc *services.CupService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		sessionID, err := uuid.Parse(c.Params("sessionId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid session ID"})
+		}
+		if err := svc.DeleteSession(c.UserContext(), eventID, sessionID, userID, userRole); err != nil {
+			return writeCupError(c, err, "event.delete_cup_session", "failed to delete session")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// CreateCupMatch returns a handler for
+// POST /api/v1/events/:id/cup/sessions/:sessionId/matches. Organizer-only;
+// responds 201 with the updated cup.
+func CreateCupMatch(svc *services.CupService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		sessionID, err := uuid.Parse(c.Params("sessionId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid session ID"})
+		}
+		var req CreateCupMatchRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		team1, ok := parseUUIDList(req.Team1UserIDs)
+		if !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid team1_user_ids"})
+		}
+		team2, ok := parseUUIDList(req.Team2UserIDs)
+		if !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid team2_user_ids"})
+		}
+		if len(team1) == 0 || len(team2) == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "both sides need players"})
+		}
+		cup, err := svc.AddMatch(c.UserContext(), eventID, sessionID, userID, userRole, services.CreateCupMatchInput{
+			Team1UserIDs: team1, Team2UserIDs: team2,
+		})
+		if err != nil {
+			return writeCupError(c, err, "event.create_cup_match", "failed to create match")
+		}
+		return c.Status(fiber.StatusCreated).JSON(cup)
+	}
+}
+
+// DeleteCupMatch returns a handler for
+// DELETE /api/v1/events/:id/cup/matches/:matchId. Organizer-only; responds 204.
+func DeleteCupMatch(svc *services.CupService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		matchID, ok := parseMatchID(c)
+		if !ok {
+			return nil
+		}
+		if err := svc.DeleteMatch(c.UserContext(), eventID, matchID, userID, userRole); err != nil {
+			return writeCupError(c, err, "event.delete_cup_match", "failed to delete match")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// RecordCupResult returns a handler for
+// POST /api/v1/events/:id/cup/matches/:matchId/result. Organizer-only;
+// returns the updated cup.
+func RecordCupResult(svc *services.CupService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		matchID, ok := parseMatchID(c)
+		if !ok {
+			return nil
+		}
+		var req RecordCupResultRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		var winnerID *uuid.UUID
+		if req.WinnerTeamID != nil {
+			parsed, err := uuid.Parse(*req.WinnerTeamID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid winner_team_id"})
+			}
+			winnerID = &parsed
+		}
+		cup, err := svc.RecordMatchResult(c.UserContext(), eventID, matchID, userID, userRole, winnerID, req.Result)
+		if err != nil {
+			return writeCupError(c, err, "event.record_cup_result", "failed to record result")
+		}
+		return c.JSON(cup)
+	}
+}
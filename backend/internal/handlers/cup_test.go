@@ -0,0 +1,95 @@
+// cup_test.go
+// Unit tests for the cup team and session handlers in cup.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation return before
+// any DB call, so a nil-DB CupService is safe. Scoring and pairing rules are
+// covered in services/cup_service_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Cup -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const (
+	cupRoute        = "/events/:id/cup"
+	cupSessionRoute = "/events/:id/cup/sessions/:sessionId"
+	cupMatchRoute   = "/events/:id/cup/matches/:matchId"
+)
+
+// nilCupSvc returns a CupService with no DB; only safe on paths that fail
+// validation first.
+func nilCupSvc() *services.CupService {
+	return services.NewCupService(nil, nilEventSvc())
+}
+
+func TestGetEventCup_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, cupRoute, handlers.GetEventCup(nilCupSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/"+validUUID+"/cup", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetEventCup_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, cupRoute, handlers.GetEventCup(nilCupSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/not-a-uuid/cup", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetCupTeams_OneTeam_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, cupRoute+"/teams", handlers.SetCupTeams(nilCupSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/events/"+validUUID+"/cup/teams", map[string]any{
+		"teams": []map[string]any{{"name": "Blue"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetMemberCupTeam_InvalidTeamID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, "/events/:id/members/:userId/cup-team", handlers.SetMemberCupTeam(nilCupSvc()))
+	resp := doJSON(t, app, http.MethodPatch, "/events/"+validUUID+"/members/"+validUUID+"/cup-team", map[string]any{
+		"team_id": "blue",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateCupSession_InvalidRoundID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, cupRoute+"/sessions", handlers.CreateCupSession(nilCupSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/cup/sessions", map[string]any{
+		"format": "singles", "round_id": "friday",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateCupMatch_InvalidSessionID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, cupSessionRoute+"/matches", handlers.CreateCupMatch(nilCupSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/cup/sessions/not-a-uuid/matches", map[string]any{})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateCupMatch_EmptySide_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, cupSessionRoute+"/matches", handlers.CreateCupMatch(nilCupSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/cup/sessions/"+validUUID+"/matches", map[string]any{
+		"team1_user_ids": []string{validUUID},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRecordCupResult_InvalidWinner_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, cupMatchRoute+"/result", handlers.RecordCupResult(nilCupSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/cup/matches/"+validUUID+"/result", map[string]any{
+		"winner_team_id": "europe",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
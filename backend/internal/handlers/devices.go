@@ -0,0 +1,84 @@
+// handlers/devices.go
+// HTTP handlers for registering the mobile app's devices for push
+// notifications. Business logic lives in internal/services.PushService
+// (push_service.go); delivery runs in the background from main.go. Errors map
+// through writeDeviceError.
+//
+// Endpoints:
+//
+//	POST   /api/v1/users/me/devices     → register this device's push token
+//	DELETE /api/v1/users/me/devices/:id → stop pushes to a device (e.g. on sign out)
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// RegisterDeviceRequest is the body for POST /users/me/devices.
+type RegisterDeviceRequest struct {
+	Token    string `json:"token"`    // Expo push token
+	Platform string `json:"platform"` // "ios" or "android"
+}
+
+// writeDeviceError maps device errors to HTTP responses.
+func writeDeviceError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	var ve *services.ValidationError
+	if errors.As(err, &ve) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: ve.Message})
+	}
+	if errors.Is(err, services.ErrDeviceNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "device not found"})
+	}
+	c.Locals("error_detail", tag+": "+err.Error())
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{jsonKeyError: fallbackMsg})
+}
+
+// RegisterDevice returns a handler for POST /api/v1/users/me/devices.
+func RegisterDevice(svc *services.PushService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		var req RegisterDeviceRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		device, err := svc.RegisterDevice(c.UserContext(), userID, services.RegisterDeviceInput{
+			Token: req.Token, Platform: req.Platform,
+		})
+		if err != nil {
+			return writeDeviceError(c, err, "device.register", "failed to register device")
+		}
+		slog.InfoContext(c.UserContext(), "Device registered for push",
+			"event_type_label", "device.registered",
+			"user_id", userID.String(),
+			"device_id", device.ID,
+			"platform", device.Platform,
+		)
+		return c.Status(fiber.StatusCreated).JSON(device)
+	}
+}
+
+// UnregisterDevice returns a handler for DELETE /api/v1/users/me/devices/:id.
+func UnregisterDevice(svc *services.PushService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		deviceID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid device ID"})
+		}
+		if err := svc.UnregisterDevice(c.UserContext(), userID, deviceID); err != nil {
+			return writeDeviceError(c, err, "device.unregister", "failed to unregister device")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
@@ -0,0 +1,66 @@
+// devices_test.go
+// Unit tests for the push device handlers in devices.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation return before
+// any DB call, so a nil-DB PushService is safe. Registration and delivery are
+// covered in services/push_service_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run 'Device' -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const (
+	devicesRoute = "/users/me/devices"
+	deviceRoute  = "/users/me/devices/:id"
+)
+
+// nilPushSvc returns a PushService with no DB or sender; only safe on paths
+// that fail validation first.
+func nilPushSvc() *services.PushService {
+	return services.NewPushService(nil, nil)
+}
+
+func TestRegisterDevice_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, devicesRoute, handlers.RegisterDevice(nilPushSvc()))
+	resp := doJSON(t, app, http.MethodPost, devicesRoute, map[string]string{"token": "ExponentPushToken[abc]", "platform": "ios"})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestRegisterDevice_MissingToken_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, devicesRoute, handlers.RegisterDevice(nilPushSvc()))
+	resp := doJSON(t, app, http.MethodPost, devicesRoute, map[string]string{"token": "  ", "platform": "ios"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRegisterDevice_UnknownPlatform_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, devicesRoute, handlers.RegisterDevice(nilPushSvc()))
+	resp := doJSON(t, app, http.MethodPost, devicesRoute, map[string]string{"token": "ExponentPushToken[abc]", "platform": "windows"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestUnregisterDevice_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodDelete, deviceRoute, handlers.UnregisterDevice(nilPushSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/users/me/devices/"+validUUID, nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestUnregisterDevice_InvalidID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodDelete, deviceRoute, handlers.UnregisterDevice(nilPushSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/users/me/devices/not-a-uuid", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,126 @@
+// handlers/digest.go
+// HTTP handlers for the weekly email digest: the signed-in user's
+// subscription, and the unsubscribe link in every digest. Business logic lives
+// in internal/services.DigestService (digest_service.go); sending runs in the
+// background from main.go. Errors map through writeDigestError.
+//
+// Endpoints:
+//
+//	GET  /api/v1/users/me/digest         → whether you get the weekly digest
+//	PUT  /api/v1/users/me/digest         → subscribe or unsubscribe
+//	GET  /digest/unsubscribe?token=      → unsubscribe link (no sign-in): a confirm page, changes nothing
+//	POST /digest/unsubscribe?token=      → unsubscribe: the confirm button, or one-click from mail clients (RFC 8058)
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// UpdateDigestSettingsRequest is the body for PUT /users/me/digest.
+type UpdateDigestSettingsRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// unsubscribePage is the page the unsubscribe link lands on; %s is the message.
+const unsubscribePage = `<!DOCTYPE html><html><body style="font-family: -apple-system, Helvetica, Arial, sans-serif;"><p>%s</p></body></html>`
+
+// unsubscribeConfirmPage asks before unsubscribing; %s is the token. Link
+// scanners and mail-client prefetch follow GETs but don't submit forms.
+const unsubscribeConfirmPage = `<!DOCTYPE html><html><body style="font-family: -apple-system, Helvetica, Arial, sans-serif;">` +
+	`<p>Stop getting the weekly digest?</p>` +
+	`<form method="post" action="?token=%s"><button type="submit">Unsubscribe</button></form>` +
+	`</body></html>`
+
+// writeDigestError maps digest errors to HTTP responses. Settings have no
+// expected failures, so everything is a 500.
+func writeDigestError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	c.Locals("error_detail", tag+": "+err.Error())
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{jsonKeyError: fallbackMsg})
+}
+
+// GetDigestSettings returns a handler for GET /api/v1/users/me/digest.
+func GetDigestSettings(svc *services.DigestService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		settings, err := svc.Settings(c.UserContext(), userID)
+		if err != nil {
+			return writeDigestError(c, err, "digest.settings", "failed to load digest settings")
+		}
+		return c.JSON(settings)
+	}
+}
+
+// UpdateDigestSettings returns a handler for PUT /api/v1/users/me/digest.
+func UpdateDigestSettings(svc *services.DigestService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		var req UpdateDigestSettingsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		if req.Enabled == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "enabled is required"})
+		}
+		settings, err := svc.UpdateSettings(c.UserContext(), userID, *req.Enabled)
+		if err != nil {
+			return writeDigestError(c, err, "digest.update_settings", "failed to save digest settings")
+		}
+		return c.JSON(settings)
+	}
+}
+
+// ConfirmUnsubscribeDigest returns a handler for GET /digest/unsubscribe: the
+// page the link in a digest opens. It only renders a confirm button that POSTs
+// back, so a link scanner opening the URL doesn't unsubscribe anyone.
+func ConfirmUnsubscribeDigest() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Type("html", "utf-8")
+		token, err := uuid.Parse(c.Query("token"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).SendString(fmtUnsubscribePage("This unsubscribe link isn't valid."))
+		}
+		return c.SendString(fmt.Sprintf(unsubscribeConfirmPage, token.String()))
+	}
+}
+
+// UnsubscribeDigest returns a handler for POST /digest/unsubscribe, sent by the
+// confirm page or by a mail client's one-click unsubscribe. It sits outside
+// /api/v1 because the link is opened from an email, without signing in; the
+// token in the link identifies the subscriber.
+func UnsubscribeDigest(svc *services.DigestService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Type("html", "utf-8")
+		token, err := uuid.Parse(c.Query("token"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).SendString(fmtUnsubscribePage("This unsubscribe link isn't valid."))
+		}
+		if err := svc.Unsubscribe(c.UserContext(), token); err != nil {
+			if errors.Is(err, services.ErrDigestTokenNotFound) {
+				return c.Status(fiber.StatusNotFound).SendString(fmtUnsubscribePage("This unsubscribe link isn't valid."))
+			}
+			c.Locals("error_detail", "digest.unsubscribe: "+err.Error())
+			return c.Status(fiber.StatusInternalServerError).SendString(fmtUnsubscribePage("Something went wrong. Please try again."))
+		}
+		slog.InfoContext(c.UserContext(), "Digest unsubscribed",
+			"event_type_label", "digest.unsubscribed",
+		)
+		return c.SendString(fmtUnsubscribePage("You've been unsubscribed from the weekly digest. You can turn it back on in the app's settings."))
+	}
+}
+
+// fmtUnsubscribePage fills in unsubscribePage.
+func fmtUnsubscribePage(msg string) string {
+	return fmt.Sprintf(unsubscribePage, msg)
+}
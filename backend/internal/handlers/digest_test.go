@@ -0,0 +1,83 @@
+// digest_test.go
+// Unit tests for the email digest handlers in digest.go.
+//
+// Strategy: Tier 1 only — auth, body and token validation return before any DB
+// call, so a nil-DB DigestService is safe. Building and sending digests is
+// covered in services/digest_service_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run 'Digest' -v
+package handlers_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const (
+	digestRoute      = "/users/me/digest"
+	unsubscribeRoute = "/digest/unsubscribe"
+)
+
+// nilDigestSvc returns a DigestService with no DB or mailer; only safe on
+// paths that fail validation first.
+func nilDigestSvc() *services.DigestService {
+	return services.NewDigestService(nil, nil, nil, "")
+}
+
+func TestGetDigestSettings_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, digestRoute, handlers.GetDigestSettings(nilDigestSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, digestRoute, nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestUpdateDigestSettings_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPut, digestRoute, handlers.UpdateDigestSettings(nilDigestSvc()))
+	resp := doJSON(t, app, http.MethodPut, digestRoute, map[string]bool{"enabled": false})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestUpdateDigestSettings_MissingEnabled_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, digestRoute, handlers.UpdateDigestSettings(nilDigestSvc()))
+	resp := doJSON(t, app, http.MethodPut, digestRoute, map[string]any{})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// The unsubscribe link needs no sign-in, but a malformed token never reaches the DB.
+func TestUnsubscribeDigest_InvalidToken_BadRequest(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, unsubscribeRoute, handlers.UnsubscribeDigest(nilDigestSvc()))
+	for _, target := range []string{unsubscribeRoute, unsubscribeRoute + "?token=nope"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, target, nil), -1)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, target)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+	}
+}
+
+// Opening the link only renders a confirm form; nothing is unsubscribed.
+func TestConfirmUnsubscribeDigest_RendersFormWithoutUnsubscribing(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, unsubscribeRoute, handlers.ConfirmUnsubscribeDigest())
+	token := uuid.NewString()
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, unsubscribeRoute+"?token="+token, nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `method="post"`)
+	assert.Contains(t, string(body), token)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, unsubscribeRoute+"?token=nope", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
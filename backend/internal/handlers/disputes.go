@@ -0,0 +1,134 @@
+// handlers/disputes.go
+// HTTP handlers for score disputes. All business logic lives in
+// internal/services.ScoreService (score_dispute.go); errors map through
+// writeScoreError, shared with the score handlers.
+//
+// Raising or resolving a dispute pushes a "disputes_updated" message to the
+// round's WebSocket subscribers; a correction also pushes "scores_updated".
+//
+// Endpoints:
+//
+//	POST  /api/v1/rounds/:roundId/players/:roundPlayerId/disputes → flag a hole score
+//	GET   /api/v1/rounds/:roundId/disputes?status=                → list disputes (organizer only)
+//	PATCH /api/v1/rounds/:roundId/disputes/:disputeId             → accept, correct, or dismiss
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request types ────────────────────────────────────────────────────────────
+
+// RaiseDisputeRequest is the JSON body for POST .../disputes.
+type RaiseDisputeRequest struct {
+	HoleNumber int    `json:"hole_number"`
+	Comment    string `json:"comment"`
+}
+
+// ResolveDisputeRequest is the JSON body for PATCH /rounds/:roundId/disputes/:disputeId.
+type ResolveDisputeRequest struct {
+	Action     string  `json:"action"`      // accept | correct | dismiss
+	GrossScore *int    `json:"gross_score"` // required for correct
+	Note       *string `json:"note"`
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// RaiseDispute returns a handler for POST .../players/:roundPlayerId/disputes.
+// Any player in the round may flag a hole score on any card in it.
+func RaiseDispute(svc *services.ScoreService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roundID, err := uuid.Parse(c.Params("roundId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round ID"})
+		}
+		roundPlayerID, err := uuid.Parse(c.Params("roundPlayerId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round player ID"})
+		}
+
+		var req RaiseDisputeRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+
+		userIDStr, _ := c.Locals("userID").(string)
+		userRole, _ := c.Locals("userRole").(string)
+		callerID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{jsonKeyError: "invalid user ID"})
+		}
+
+		dispute, err := svc.RaiseDispute(c.UserContext(), roundID, roundPlayerID, callerID, userRole, req.HoleNumber, req.Comment)
+		if err != nil {
+			return writeScoreError(c, err, "score.raise_dispute", "failed to raise dispute")
+		}
+		broadcastRoundMessage(bc, "disputes_updated", roundID)
+		return c.Status(fiber.StatusCreated).JSON(dispute)
+	}
+}
+
+// ListRoundDisputes returns a handler for GET /rounds/:roundId/disputes.
+// Organizer-only. ?status= filters (default open; "all" for every dispute).
+func ListRoundDisputes(svc *services.ScoreService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roundID, err := uuid.Parse(c.Params("roundId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round ID"})
+		}
+
+		userIDStr, _ := c.Locals("userID").(string)
+		userRole, _ := c.Locals("userRole").(string)
+		callerID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{jsonKeyError: "invalid user ID"})
+		}
+
+		disputes, err := svc.ListDisputes(c.UserContext(), roundID, callerID, userRole, c.Query("status"))
+		if err != nil {
+			return writeScoreError(c, err, "score.list_disputes", "failed to load disputes")
+		}
+		return c.JSON(disputes)
+	}
+}
+
+// ResolveDispute returns a handler for PATCH /rounds/:roundId/disputes/:disputeId.
+// Organizer-only. A "correct" ruling rescores the hole before closing the dispute.
+func ResolveDispute(svc *services.ScoreService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roundID, err := uuid.Parse(c.Params("roundId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round ID"})
+		}
+		disputeID, err := uuid.Parse(c.Params("disputeId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid dispute ID"})
+		}
+
+		var req ResolveDisputeRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+
+		userIDStr, _ := c.Locals("userID").(string)
+		userRole, _ := c.Locals("userRole").(string)
+		callerID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{jsonKeyError: "invalid user ID"})
+		}
+
+		dispute, err := svc.ResolveDispute(c.UserContext(), roundID, disputeID, callerID, userRole, services.ResolveDisputeInput{
+			Action: req.Action, GrossScore: req.GrossScore, Note: req.Note,
+		})
+		if err != nil {
+			return writeScoreError(c, err, "score.resolve_dispute", "failed to resolve dispute")
+		}
+		if req.Action == services.DisputeActionCorrect {
+			broadcastScoresUpdated(bc, roundID)
+		}
+		broadcastRoundMessage(bc, "disputes_updated", roundID)
+		return c.JSON(dispute)
+	}
+}
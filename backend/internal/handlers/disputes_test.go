@@ -0,0 +1,93 @@
+// disputes_test.go
+// Unit tests for the score dispute handlers in disputes.go.
+//
+// Strategy: Tier 1 only — invalid UUIDs, bad bodies, and missing auth all return
+// before any DB call. Service-side validation (empty comment, unknown action)
+// runs before DB access too, so nilScoreSvc() with auth injected reaches it.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Dispute -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+)
+
+const (
+	raiseDisputeRoute   = "/rounds/:roundId/players/:roundPlayerId/disputes"
+	resolveDisputeRoute = "/rounds/:roundId/disputes/:disputeId"
+)
+
+// ─── RaiseDispute ─────────────────────────────────────────────────────────────
+
+func TestRaiseDispute_InvalidPlayerUUID(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, raiseDisputeRoute, handlers.RaiseDispute(nil, nil))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/players/not-a-uuid/disputes",
+		map[string]any{"hole_number": 3, "comment": "was a 5"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRaiseDispute_NoUserID(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, raiseDisputeRoute, handlers.RaiseDispute(nil, nil))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/players/"+validUUID+"/disputes",
+		map[string]any{"hole_number": 3, "comment": "was a 5"})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+// TestRaiseDispute_EmptyComment_BadRequest verifies the comment is required
+// before any DB access (nil DB is safe).
+func TestRaiseDispute_EmptyComment_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, raiseDisputeRoute, handlers.RaiseDispute(nilScoreSvc(), nil))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/players/"+validUUID+"/disputes",
+		map[string]any{"hole_number": 3, "comment": "  "})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// ─── ListRoundDisputes ────────────────────────────────────────────────────────
+
+func TestListRoundDisputes_InvalidRoundUUID(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, "/rounds/:roundId/disputes", handlers.ListRoundDisputes(nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/rounds/not-a-uuid/disputes", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestListRoundDisputes_InvalidStatus_BadRequest verifies the status filter is
+// validated before any DB access.
+func TestListRoundDisputes_InvalidStatus_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, "/rounds/:roundId/disputes", handlers.ListRoundDisputes(nilScoreSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/rounds/"+validUUID+"/disputes?status=bogus", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// ─── ResolveDispute ───────────────────────────────────────────────────────────
+
+func TestResolveDispute_InvalidDisputeUUID(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPatch, resolveDisputeRoute, handlers.ResolveDispute(nil, nil))
+	resp := doJSON(t, app, http.MethodPatch, "/rounds/"+validUUID+"/disputes/not-a-uuid",
+		map[string]any{"action": "accept"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestResolveDispute_UnknownAction_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, resolveDisputeRoute, handlers.ResolveDispute(nilScoreSvc(), nil))
+	resp := doJSON(t, app, http.MethodPatch, "/rounds/"+validUUID+"/disputes/"+validUUID,
+		map[string]any{"action": "overrule"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestResolveDispute_CorrectWithoutScore_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, resolveDisputeRoute, handlers.ResolveDispute(nilScoreSvc(), nil))
+	resp := doJSON(t, app, http.MethodPatch, "/rounds/"+validUUID+"/disputes/"+validUUID,
+		map[string]any{"action": "correct"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -13,7 +13,7 @@
 //	PATCH  /events/:id/members/:userId/role — promote/demote a member (organizer ↔ player)
 //	GET    /events/:id/rounds               — list rounds for an event
 //	POST   /events/:id/rounds               — schedule a new round
-//	POST   /events/:id/request-join         — submit a join request (public events)
+//	POST   /events/:id/request-join         — submit a join request (public events; waitlisted once full)
 //	GET    /events/:id/join-requests        — list pending join requests (organizer only)
 //	PATCH  /events/:id/join-requests/:userId — approve or deny a join request
 //
@@ -38,18 +38,24 @@ import (
 
 // EventResponse is the JSON shape returned for individual events and list rows.
 type EventResponse struct {
-	ID                string   `json:"id"`
-	Name              string   `json:"name"`
-	Description       *string  `json:"description"`
-	EventType         string   `json:"event_type"`
-	Status            string   `json:"status"`
-	StartDate         *string  `json:"start_date"`
-	EndDate           *string  `json:"end_date"`
-	HandicapAllowance *float64 `json:"handicap_allowance"`
-	IsPublic          bool     `json:"is_public"`
-	CreatorName       string   `json:"creator_name"`
-	MemberCount       int64    `json:"member_count"`
-	CreatedAt         string   `json:"created_at"`
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Description        *string  `json:"description"`
+	EventType          string   `json:"event_type"`
+	Status             string   `json:"status"`
+	StartDate          *string  `json:"start_date"`
+	EndDate            *string  `json:"end_date"`
+	HandicapAllowance  *float64 `json:"handicap_allowance"`
+	TiebreakPolicy     string   `json:"tiebreak_policy"`
+	SubPolicy          string   `json:"sub_policy"`
+	AbsencePolicy      string   `json:"absence_policy"`
+	MaxPlayers         *int     `json:"max_players"`          // null = no limit
+	WaitlistOfferHours *int     `json:"waitlist_offer_hours"` // null = waitlist promotes automatically
+	IsPublic           bool     `json:"is_public"`
+	FinalizedAt        *string  `json:"finalized_at"` // RFC 3339; nil until results are finalized
+	CreatorName        string   `json:"creator_name"`
+	MemberCount        int64    `json:"member_count"`
+	CreatedAt          string   `json:"created_at"`
 }
 
 // EventDetailResponse extends EventResponse with the full members list.
@@ -59,14 +65,16 @@ type EventDetailResponse struct {
 }
 
 // MemberResponse describes a single event_player row with the user's display info.
+// Email is null when the member hides it from the caller.
 type MemberResponse struct {
-	UserID      string  `json:"user_id"`
-	DisplayName string  `json:"display_name"`
-	Email       string  `json:"email"`
-	AvatarURL   *string `json:"avatar_url"`
-	Role        string  `json:"role"`
-	Status      string  `json:"status"`
-	JoinedAt    string  `json:"joined_at"`
+	UserID       string  `json:"user_id"`
+	DisplayName  string  `json:"display_name"`
+	Email        *string `json:"email"`
+	AvatarURL    *string `json:"avatar_url"`
+	Role         string  `json:"role"`
+	Status       string  `json:"status"`
+	IsSubstitute bool    `json:"is_substitute"`
+	JoinedAt     string  `json:"joined_at"`
 }
 
 // RoundSummaryResponse is one row in the rounds-for-event list.
@@ -79,31 +87,50 @@ type RoundSummaryResponse struct {
 	ScoringFormat string `json:"scoring_format"`
 	RoundNumber   int    `json:"round_number"`
 	GroupCount    int    `json:"group_count"`
+	CommentCount  int    `json:"comment_count"`
+	ReactionCount int    `json:"reaction_count"`
 }
 
 // ─── Request types ─────────────────────────────────────────────────────────────
 
 // CreateEventRequest is the body for POST /api/v1/events.
 type CreateEventRequest struct {
-	Name              string   `json:"name"`
-	Description       *string  `json:"description"`
-	EventType         string   `json:"event_type"`
-	StartDate         *string  `json:"start_date"`
-	EndDate           *string  `json:"end_date"`
-	HandicapAllowance *float64 `json:"handicap_allowance"`
-	IsPublic          bool     `json:"is_public"`
+	Name               string   `json:"name"`
+	Description        *string  `json:"description"`
+	EventType          string   `json:"event_type"`
+	StartDate          *string  `json:"start_date"`
+	EndDate            *string  `json:"end_date"`
+	HandicapAllowance  *float64 `json:"handicap_allowance"`
+	TiebreakPolicy     *string  `json:"tiebreak_policy"`
+	SubPolicy          *string  `json:"sub_policy"`           // team (default) | self | none
+	AbsencePolicy      *string  `json:"absence_policy"`       // phantom_par (default) | phantom_average | blind_draw
+	MaxPlayers         *int     `json:"max_players"`          // 0 or omitted = no limit
+	WaitlistOfferHours *int     `json:"waitlist_offer_hours"` // 0 or omitted = promote automatically
+	IsPublic           bool     `json:"is_public"`
 }
 
 // UpdateEventRequest is the body for PATCH /api/v1/events/:id.
 // All fields are optional pointers — only present fields are applied.
 type UpdateEventRequest struct {
-	Name              *string  `json:"name"`
-	Description       *string  `json:"description"`
-	StartDate         *string  `json:"start_date"`
-	EndDate           *string  `json:"end_date"`
-	Status            *string  `json:"status"`
-	HandicapAllowance *float64 `json:"handicap_allowance"`
-	IsPublic          *bool    `json:"is_public"`
+	Name               *string  `json:"name"`
+	Description        *string  `json:"description"`
+	StartDate          *string  `json:"start_date"`
+	EndDate            *string  `json:"end_date"`
+	Status             *string  `json:"status"`
+	HandicapAllowance  *float64 `json:"handicap_allowance"`
+	TiebreakPolicy     *string  `json:"tiebreak_policy"`
+	SubPolicy          *string  `json:"sub_policy"`
+	AbsencePolicy      *string  `json:"absence_policy"`
+	MaxPlayers         *int     `json:"max_players"`          // 0 = no limit
+	WaitlistOfferHours *int     `json:"waitlist_offer_hours"` // 0 = promote automatically
+	IsPublic           *bool    `json:"is_public"`
+}
+
+// JoinRequestResponse is returned by POST /api/v1/events/:id/request-join.
+// Status is "pending" (awaiting organizer approval) or "waitlisted" (the event
+// is full).
+type JoinRequestResponse struct {
+	Status string `json:"status"`
 }
 
 // JoinRequestActionRequest is the body for PATCH /api/v1/events/:id/join-requests/:userId.
@@ -199,12 +226,22 @@ func writeEventError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "member not found"})
 	case errors.Is(err, services.ErrJoinRequestNotFound):
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "join request not found"})
+	case errors.Is(err, services.ErrFlightNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "flight not found"})
 	case errors.Is(err, services.ErrEventForbidden):
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: "not authorized"})
 	case errors.Is(err, services.ErrEventNotMember):
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: "not a member of this event"})
 	case errors.Is(err, services.ErrEventNotPublic):
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: "event is not open for join requests"})
+	case errors.Is(err, services.ErrEventFinalized):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "event results are finalized; reopen the event first"})
+	case errors.Is(err, services.ErrFinalizeRequired):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			jsonKeyError: "complete an event with POST /events/:id/finalize",
+		})
+	case errors.Is(err, services.ErrEventFull):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "event is full"})
 	case errors.Is(err, services.ErrMemberAlreadyExists):
 		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "user is already a member"})
 	case errors.Is(err, services.ErrLastOrganizer):
@@ -221,30 +258,41 @@ func writeEventError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
 // buildEventResponse converts a service-layer EventListItem into the JSON shape.
 func buildEventResponse(item services.EventListItem) EventResponse {
 	return EventResponse{
-		ID:                item.Event.ID.String(),
-		Name:              item.Event.Name,
-		Description:       item.Event.Description,
-		EventType:         string(item.Event.EventType),
-		Status:            string(item.Event.Status),
-		StartDate:         formatOptionalDate(item.Event.StartDate),
-		EndDate:           formatOptionalDate(item.Event.EndDate),
-		HandicapAllowance: item.Event.HandicapAllowance,
-		IsPublic:          item.Event.IsPublic,
-		CreatorName:       item.Creator.DisplayName,
-		MemberCount:       item.MemberCount,
-		CreatedAt:         item.Event.CreatedAt.UTC().Format(time.RFC3339),
+		ID:                 item.Event.ID.String(),
+		Name:               item.Event.Name,
+		Description:        item.Event.Description,
+		EventType:          string(item.Event.EventType),
+		Status:             string(item.Event.Status),
+		StartDate:          formatOptionalDate(item.Event.StartDate),
+		EndDate:            formatOptionalDate(item.Event.EndDate),
+		HandicapAllowance:  item.Event.HandicapAllowance,
+		TiebreakPolicy:     item.Event.TiebreakPolicy,
+		SubPolicy:          item.Event.SubPolicy,
+		AbsencePolicy:      item.Event.AbsencePolicy,
+		MaxPlayers:         item.Event.MaxPlayers,
+		WaitlistOfferHours: item.Event.WaitlistOfferHours,
+		IsPublic:           item.Event.IsPublic,
+		FinalizedAt:        services.FormatTimestamp(item.Event.FinalizedAt),
+		CreatorName:        item.Creator.DisplayName,
+		MemberCount:        item.MemberCount,
+		CreatedAt:          item.Event.CreatedAt.UTC().Format(time.RFC3339),
 	}
 }
 
 func buildMemberResponse(m services.EventMemberItem) MemberResponse {
+	email := &m.User.Email
+	if m.EmailHidden {
+		email = nil
+	}
 	return MemberResponse{
-		UserID:      m.User.ID.String(),
-		DisplayName: m.User.DisplayName,
-		Email:       m.User.Email,
-		AvatarURL:   m.User.AvatarURL,
-		Role:        string(m.Player.Role),
-		Status:      string(m.Player.Status),
-		JoinedAt:    m.Player.CreatedAt.UTC().Format(time.RFC3339),
+		UserID:       m.User.ID.String(),
+		DisplayName:  m.User.DisplayName,
+		Email:        email,
+		AvatarURL:    m.User.AvatarURL,
+		Role:         string(m.Player.Role),
+		Status:       string(m.Player.Status),
+		IsSubstitute: m.Player.IsSubstitute,
+		JoinedAt:     m.Player.CreatedAt.UTC().Format(time.RFC3339),
 	}
 }
 
@@ -286,14 +334,19 @@ func CreateEvent(svc *services.EventService) fiber.Handler {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
 		}
 		item, err := svc.Create(c.UserContext(), services.CreateEventInput{
-			Name:              req.Name,
-			Description:       req.Description,
-			EventType:         req.EventType,
-			StartDate:         req.StartDate,
-			EndDate:           req.EndDate,
-			HandicapAllowance: req.HandicapAllowance,
-			IsPublic:          req.IsPublic,
-			CreatedBy:         userID,
+			Name:               req.Name,
+			Description:        req.Description,
+			EventType:          req.EventType,
+			StartDate:          req.StartDate,
+			EndDate:            req.EndDate,
+			HandicapAllowance:  req.HandicapAllowance,
+			TiebreakPolicy:     req.TiebreakPolicy,
+			SubPolicy:          req.SubPolicy,
+			AbsencePolicy:      req.AbsencePolicy,
+			MaxPlayers:         req.MaxPlayers,
+			WaitlistOfferHours: req.WaitlistOfferHours,
+			IsPublic:           req.IsPublic,
+			CreatedBy:          userID,
 		})
 		if err != nil {
 			return writeEventError(c, err, "event.create", "failed to create event")
@@ -356,13 +409,18 @@ func UpdateEvent(svc *services.EventService) fiber.Handler {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
 		}
 		result, err := svc.Update(c.UserContext(), eventID, userID, userRole, services.UpdateEventInput{
-			Name:              req.Name,
-			Description:       req.Description,
-			StartDate:         req.StartDate,
-			EndDate:           req.EndDate,
-			Status:            req.Status,
-			HandicapAllowance: req.HandicapAllowance,
-			IsPublic:          req.IsPublic,
+			Name:               req.Name,
+			Description:        req.Description,
+			StartDate:          req.StartDate,
+			EndDate:            req.EndDate,
+			Status:             req.Status,
+			HandicapAllowance:  req.HandicapAllowance,
+			TiebreakPolicy:     req.TiebreakPolicy,
+			SubPolicy:          req.SubPolicy,
+			AbsencePolicy:      req.AbsencePolicy,
+			MaxPlayers:         req.MaxPlayers,
+			WaitlistOfferHours: req.WaitlistOfferHours,
+			IsPublic:           req.IsPublic,
 		})
 		if err != nil {
 			return writeEventError(c, err, "event.update", "failed to update event")
@@ -377,7 +435,7 @@ func UpdateEvent(svc *services.EventService) fiber.Handler {
 		}
 
 		if result.AllowanceChanged {
-			if err := services.RecalculateEventScores(c.UserContext(), svc.DB, eventID, result.Event.HandicapAllowance); err != nil {
+			if err := services.RecalculateEventScores(c.UserContext(), svc.DB, eventID, userID, result.Event.HandicapAllowance); err != nil {
 				// ErrorContext (not InfoContext) so this lands in Sentry Issues — recalc
 				// failure leaves event scores out of sync with the new allowance.
 				slog.ErrorContext(c.UserContext(), "Failed to recalculate scores after allowance change",
@@ -420,13 +478,18 @@ func DeleteEvent(svc *services.EventService) fiber.Handler {
 }
 
 // GetEventMembers returns a handler for GET /api/v1/events/:id/members.
+// Emails the members hide from the caller come back null.
 func GetEventMembers(svc *services.EventService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		eventID, ok := parseEventID(c)
 		if !ok {
 			return nil
 		}
-		members, err := svc.GetMembers(c.UserContext(), eventID)
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		members, err := svc.GetMembers(c.UserContext(), eventID, userID)
 		if err != nil {
 			return writeEventError(c, err, "event.get_members", "failed to load members")
 		}
@@ -509,6 +572,8 @@ func GetEventRounds(svc *services.EventService) fiber.Handler {
 				ScoringFormat: string(item.Round.ScoringFormat),
 				RoundNumber:   item.Round.RoundNumber,
 				GroupCount:    item.GroupCount,
+				CommentCount:  item.Engagement.CommentCount,
+				ReactionCount: item.Engagement.ReactionCount,
 			}
 		}
 		return c.JSON(out)
@@ -546,10 +611,11 @@ func RequestJoinEvent(svc *services.EventService) fiber.Handler {
 		if !ok {
 			return nil
 		}
-		if err := svc.RequestJoin(c.UserContext(), eventID, userID); err != nil {
+		status, err := svc.RequestJoin(c.UserContext(), eventID, userID)
+		if err != nil {
 			return writeEventError(c, err, "event.request_join", "failed to submit join request")
 		}
-		return c.SendStatus(fiber.StatusNoContent)
+		return c.JSON(JoinRequestResponse{Status: string(status)})
 	}
 }
 
@@ -217,6 +217,84 @@ func TestCreateEvent_HandicapAllowanceTooHigh_BadRequest(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
 
+// TestUpdateEvent_InvalidTiebreakPolicy verifies that an unknown tiebreak_policy
+// is rejected before any DB call.
+func TestUpdateEvent_InvalidTiebreakPolicy_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, "/events/:id", handlers.UpdateEvent(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPatch, "/events/"+validUUID, map[string]any{
+		"tiebreak_policy": "coin_flip",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestCreateEvent_InvalidTiebreakPolicy verifies that an unknown tiebreak_policy
+// on event creation is rejected before any DB call.
+func TestCreateEvent_InvalidTiebreakPolicy_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, "/events", handlers.CreateEvent(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events", map[string]any{
+		"name": "Test League", "event_type": "league", "tiebreak_policy": "coin_flip",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestCreateEvent_InvalidSubPolicy verifies that an unknown sub_policy is
+// rejected before any DB call.
+func TestCreateEvent_InvalidSubPolicy_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, "/events", handlers.CreateEvent(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events", map[string]any{
+		"name": "Test League", "event_type": "league", "sub_policy": "captain",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestUpdateEvent_InvalidSubPolicy verifies the same check on update.
+func TestUpdateEvent_InvalidSubPolicy_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, "/events/:id", handlers.UpdateEvent(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPatch, "/events/"+validUUID, map[string]any{
+		"sub_policy": "captain",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestCreateEvent_InvalidAbsencePolicy verifies that an unknown absence_policy
+// is rejected before any DB call.
+func TestCreateEvent_InvalidAbsencePolicy_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, "/events", handlers.CreateEvent(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events", map[string]any{
+		"name": "Test League", "event_type": "league", "absence_policy": "forfeit",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestUpdateEvent_InvalidAbsencePolicy verifies the same check on update.
+func TestUpdateEvent_InvalidAbsencePolicy_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, "/events/:id", handlers.UpdateEvent(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPatch, "/events/"+validUUID, map[string]any{
+		"absence_policy": "forfeit",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestCreateEvent_NegativeMaxPlayers verifies that max_players below 0 is
+// rejected before any DB call.
+func TestCreateEvent_NegativeMaxPlayers_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, "/events", handlers.CreateEvent(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events", map[string]any{
+		"name": "Test League", "event_type": "league", "max_players": -1,
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestUpdateEvent_WaitlistOfferTooLong verifies that waitlist_offer_hours over
+// a week is rejected before any DB call.
+func TestUpdateEvent_WaitlistOfferTooLong_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, "/events/:id", handlers.UpdateEvent(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPatch, "/events/"+validUUID, map[string]any{
+		"waitlist_offer_hours": 24 * 8,
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
 // ─── DeleteEvent ──────────────────────────────────────────────────────────────
 
 func TestDeleteEvent_MissingAuth_Unauthorized(t *testing.T) {
@@ -235,7 +313,7 @@ func TestDeleteEvent_InvalidEventID_BadRequest(t *testing.T) {
 
 // ─── GetEventMembers ──────────────────────────────────────────────────────────
 
-// GetEventMembers has no userID check — it validates only the eventID param.
+// GetEventMembers validates the eventID param before the caller.
 func TestGetEventMembers_InvalidEventID_BadRequest(t *testing.T) {
 	app := newSingleRouteApp(http.MethodGet, "/events/:id/members", handlers.GetEventMembers(nilEventSvc()))
 	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/not-a-uuid/members", nil), -1)
@@ -430,6 +508,7 @@ func TestWriteEventError_StatusMapping(t *testing.T) {
 		{"event not member", services.ErrEventNotMember, http.StatusForbidden},
 		{"event not public", services.ErrEventNotPublic, http.StatusForbidden},
 		{"member already exists", services.ErrMemberAlreadyExists, http.StatusConflict},
+		{"finalize required", services.ErrFinalizeRequired, http.StatusConflict},
 		{"unrecognised → 500", errors.New("unexpected"), http.StatusInternalServerError},
 	}
 	for _, tc := range cases {
@@ -11,6 +11,7 @@ var WriteScoreErrorExported = writeScoreError
 var WriteRoundErrorExported = writeRoundError
 var WriteEventErrorExported = writeEventError
 var WriteUserErrorExported = writeUserError
+var WriteLeaderboardErrorExported = writeLeaderboardError
 var UUIDPtrStrExported = uuidPtrStr
 
 // Pure helper functions — no fiber context required.
@@ -0,0 +1,47 @@
+// handlers/feed.go
+// HTTP handler for the activity feed: completed rounds, personal bests,
+// eagles, aces and event wins from the people the caller follows. Business
+// logic lives in internal/services.FeedService (feed_service.go); items are
+// written by the services that complete rounds and finalize events. Errors map
+// through writeFeedError.
+//
+// Endpoints:
+//
+//	GET /api/v1/feed → activity from people you follow, newest first (?limit=, ?cursor=)
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// writeFeedError maps feed errors to HTTP responses.
+func writeFeedError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	var ve *services.ValidationError
+	if errors.As(err, &ve) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: ve.Message})
+	}
+	c.Locals("error_detail", tag+": "+err.Error())
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{jsonKeyError: fallbackMsg})
+}
+
+// GetFeed returns a handler for GET /api/v1/feed. cursor is the next_cursor
+// from the previous page.
+func GetFeed(svc *services.FeedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		page, err := svc.List(c.UserContext(), userID, services.ListFeedInput{
+			Limit:  c.QueryInt("limit", 0),
+			Cursor: c.Query("cursor"),
+		})
+		if err != nil {
+			return writeFeedError(c, err, "feed.list", "failed to load feed")
+		}
+		return c.JSON(page)
+	}
+}
@@ -0,0 +1,52 @@
+// feed_test.go
+// Unit tests for the activity feed handler in feed.go.
+//
+// Strategy: Tier 1 only — auth and query validation return before any DB
+// call, so a nil-DB FeedService is safe. Listing and how items are written are
+// covered in services/feed_service_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run 'Feed' -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const feedRoute = "/feed"
+
+// nilFeedSvc returns a FeedService with no DB; only safe on paths that fail
+// validation first.
+func nilFeedSvc() *services.FeedService {
+	return services.NewFeedService(nil)
+}
+
+func TestGetFeed_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, feedRoute, handlers.GetFeed(nilFeedSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, feedRoute, nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetFeed_LimitTooLarge_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, feedRoute, handlers.GetFeed(nilFeedSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, feedRoute+"?limit=500", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetFeed_BadCursor_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, feedRoute, handlers.GetFeed(nilFeedSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, feedRoute+"?cursor=not-a-cursor", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
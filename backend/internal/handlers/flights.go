@@ -0,0 +1,147 @@
+// handlers/flights.go
+// HTTP handlers for event flights (A/B/C divisions). All business logic lives
+// in internal/services.EventService (event_flights.go); errors map through
+// writeEventError, shared with the other event handlers.
+//
+// Endpoints:
+//
+//	GET   /api/v1/events/:id/flights                 → flights with member counts
+//	PUT   /api/v1/events/:id/flights                 → replace flight definitions (organizer only)
+//	POST  /api/v1/events/:id/flights/recompute       → re-flight members by handicap band (organizer only)
+//	PATCH /api/v1/events/:id/members/:userId/flight  → place one member in a flight (organizer only)
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request types ────────────────────────────────────────────────────────────
+
+// FlightRequest is one flight in a PUT /events/:id/flights body.
+type FlightRequest struct {
+	Name             string   `json:"name"`
+	MinHandicapIndex *float64 `json:"min_handicap_index"`
+	MaxHandicapIndex *float64 `json:"max_handicap_index"`
+}
+
+// SetFlightsRequest is the body for PUT /api/v1/events/:id/flights.
+// Flights are listed top flight first.
+type SetFlightsRequest struct {
+	Assignment *string         `json:"assignment"` // manual | handicap_season | handicap_round
+	Flights    []FlightRequest `json:"flights"`
+}
+
+// SetMemberFlightRequest is the body for PATCH /api/v1/events/:id/members/:userId/flight.
+// A null flight_id unassigns the member.
+type SetMemberFlightRequest struct {
+	FlightID *string `json:"flight_id"`
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// GetEventFlights returns a handler for GET /api/v1/events/:id/flights.
+// Non-admins must be members of the event.
+func GetEventFlights(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		flights, err := svc.GetFlights(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writeEventError(c, err, "event.get_flights", "failed to load flights")
+		}
+		return c.JSON(flights)
+	}
+}
+
+// SetEventFlights returns a handler for PUT /api/v1/events/:id/flights.
+// Organizer-only; returns the updated flights.
+func SetEventFlights(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		var req SetFlightsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		in := services.SetFlightsInput{Assignment: req.Assignment, Flights: make([]services.FlightInput, 0, len(req.Flights))}
+		for _, f := range req.Flights {
+			in.Flights = append(in.Flights, services.FlightInput{
+				Name: f.Name, MinHandicapIndex: f.MinHandicapIndex, MaxHandicapIndex: f.MaxHandicapIndex,
+			})
+		}
+		flights, err := svc.SetFlights(c.UserContext(), eventID, userID, userRole, in)
+		if err != nil {
+			return writeEventError(c, err, "event.set_flights", "failed to save flights")
+		}
+		return c.JSON(flights)
+	}
+}
+
+// RecomputeEventFlights returns a handler for POST /api/v1/events/:id/flights/recompute.
+// Organizer-only; the event must use a handicap assignment mode.
+func RecomputeEventFlights(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		flights, err := svc.RecomputeFlights(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writeEventError(c, err, "event.recompute_flights", "failed to recompute flights")
+		}
+		return c.JSON(flights)
+	}
+}
+
+// SetMemberFlight returns a handler for PATCH /api/v1/events/:id/members/:userId/flight.
+// Organizer-only; responds 204 on success.
+func SetMemberFlight(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		targetUserID, err := uuid.Parse(c.Params("userId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid user ID in path"})
+		}
+		var req SetMemberFlightRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		var flightID *uuid.UUID
+		if req.FlightID != nil {
+			parsed, err := uuid.Parse(*req.FlightID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid flight_id"})
+			}
+			flightID = &parsed
+		}
+		if err := svc.SetMemberFlight(c.UserContext(), eventID, userID, userRole, targetUserID, flightID); err != nil {
+			return writeEventError(c, err, "event.set_member_flight", "failed to set member flight")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
@@ -0,0 +1,82 @@
+// flights_test.go
+// Unit tests for the event flight handlers in flights.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation return before
+// any DB call, so a nil-DB EventService is safe. Flight assignment and
+// flight-aware ranking are covered in services/event_flights_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Flight -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+)
+
+const flightsRoute = "/events/:id/flights"
+
+func TestGetEventFlights_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, flightsRoute, handlers.GetEventFlights(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/"+validUUID+"/flights", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetEventFlights_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, flightsRoute, handlers.GetEventFlights(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/not-a-uuid/flights", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetEventFlights_InvalidAssignment_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, flightsRoute, handlers.SetEventFlights(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/events/"+validUUID+"/flights", map[string]any{
+		"assignment": "by_age",
+		"flights":    []map[string]any{{"name": "A"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetEventFlights_InvertedBand_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, flightsRoute, handlers.SetEventFlights(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/events/"+validUUID+"/flights", map[string]any{
+		"flights": []map[string]any{{"name": "A", "min_handicap_index": 12.0, "max_handicap_index": 8.0}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetEventFlights_DuplicateName_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, flightsRoute, handlers.SetEventFlights(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/events/"+validUUID+"/flights", map[string]any{
+		"flights": []map[string]any{{"name": "A"}, {"name": " A "}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRecomputeEventFlights_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, flightsRoute+"/recompute", handlers.RecomputeEventFlights(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/events/"+validUUID+"/flights/recompute", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestSetMemberFlight_InvalidTargetUserID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, "/events/:id/members/:userId/flight", handlers.SetMemberFlight(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPatch, "/events/"+validUUID+"/members/not-a-uuid/flight", map[string]any{"flight_id": nil})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetMemberFlight_InvalidFlightID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, "/events/:id/members/:userId/flight", handlers.SetMemberFlight(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPatch, "/events/"+validUUID+"/members/"+validUUID+"/flight", map[string]any{"flight_id": "A"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
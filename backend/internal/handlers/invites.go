@@ -0,0 +1,257 @@
+// handlers/invites.go
+// HTTP handlers for event invitations: organizer-managed invite codes (shareable
+// links, or addressed to an email) and the invitee's accept / decline. Business
+// logic lives in internal/services (event_invites.go); errors map through
+// writeInviteError, which falls back to writeEventError.
+//
+// Each invite response carries a ready-to-share link: the configured
+// INVITE_LINK_BASE with the code appended.
+//
+// Endpoints:
+//
+//	POST   /api/v1/events/:id/invites            → create an invite code (organizer only)
+//	GET    /api/v1/events/:id/invites            → every invite on the event, any state (organizer only)
+//	DELETE /api/v1/events/:id/invites/:inviteId  → revoke an invite (organizer only)
+//	GET    /api/v1/invites/:code                 → preview the invite behind a code
+//	POST   /api/v1/invites/:code/accept          → join the event with a code
+//	POST   /api/v1/invites/:code/decline         → turn down an invite addressed to you
+//	GET    /api/v1/users/me/invites              → active invites addressed to you
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request / response types ─────────────────────────────────────────────────
+
+// CreateInviteRequest is the body for POST /api/v1/events/:id/invites. All
+// fields are optional: an empty body makes a shareable code, unlimited uses,
+// expiring in 14 days. expires_in_hours 0 never expires.
+type CreateInviteRequest struct {
+	Email          *string `json:"email"`
+	MaxUses        *int    `json:"max_uses"`
+	ExpiresInHours *int    `json:"expires_in_hours"`
+}
+
+// InviteResponse is one invite. email is null for a shareable code; max_uses
+// null means unlimited and expires_at null means it never expires. status is
+// "active", "accepted", "declined", "revoked", "expired" or "used_up".
+type InviteResponse struct {
+	ID        string  `json:"id"`
+	EventID   string  `json:"event_id"`
+	EventName string  `json:"event_name"`
+	EventType string  `json:"event_type"`
+	Code      string  `json:"code"`
+	Link      string  `json:"link"`
+	Email     *string `json:"email"`
+	MaxUses   *int    `json:"max_uses"`
+	UseCount  int     `json:"use_count"`
+	ExpiresAt *string `json:"expires_at"` // RFC 3339
+	Status    string  `json:"status"`
+	CreatedAt string  `json:"created_at"` // RFC 3339
+}
+
+// AcceptInviteResponse is the caller's new membership.
+type AcceptInviteResponse struct {
+	EventID string         `json:"event_id"`
+	Member  MemberResponse `json:"member"`
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// writeInviteError maps invite errors to HTTP responses, deferring to
+// writeEventError for everything else.
+func writeInviteError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	switch {
+	case errors.Is(err, services.ErrInviteNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "invite not found"})
+	case errors.Is(err, services.ErrInviteUnavailable):
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{jsonKeyError: "invite is no longer valid"})
+	case errors.Is(err, services.ErrInvitePending):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "an invite is already pending for this email"})
+	}
+	return writeEventError(c, err, tag, fallbackMsg)
+}
+
+// buildInviteResponse converts an invite item into its JSON shape.
+func buildInviteResponse(item services.EventInviteItem, linkBase string) InviteResponse {
+	inv := item.Invite
+	out := InviteResponse{
+		ID:        inv.ID.String(),
+		EventID:   inv.EventID.String(),
+		EventName: inv.Event.Name,
+		EventType: string(inv.Event.EventType),
+		Code:      inv.Code,
+		Link:      linkBase + inv.Code,
+		Email:     inv.Email,
+		MaxUses:   inv.MaxUses,
+		UseCount:  inv.UseCount,
+		Status:    item.Status,
+		CreatedAt: inv.CreatedAt.Format(time.RFC3339),
+	}
+	if inv.ExpiresAt != nil {
+		s := inv.ExpiresAt.Format(time.RFC3339)
+		out.ExpiresAt = &s
+	}
+	return out
+}
+
+// buildInviteResponses converts a list of invite items.
+func buildInviteResponses(items []services.EventInviteItem, linkBase string) []InviteResponse {
+	out := make([]InviteResponse, len(items))
+	for i, item := range items {
+		out[i] = buildInviteResponse(item, linkBase)
+	}
+	return out
+}
+
+// ─── Organizer handlers ───────────────────────────────────────────────────────
+
+// CreateEventInvite returns a handler for POST /api/v1/events/:id/invites.
+func CreateEventInvite(svc *services.EventService, linkBase string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		var req CreateInviteRequest
+		if len(c.Body()) > 0 {
+			if err := c.BodyParser(&req); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+			}
+		}
+		item, err := svc.CreateInvite(c.UserContext(), eventID, userID, userRole, services.CreateInviteInput{
+			Email: req.Email, MaxUses: req.MaxUses, ExpiresInHours: req.ExpiresInHours,
+		})
+		if err != nil {
+			return writeInviteError(c, err, "event.create_invite", "failed to create invite")
+		}
+		slog.InfoContext(c.UserContext(), "Event invite created",
+			"event_type_label", "event.invite_created",
+			"event_id", eventID.String(),
+			"invite_id", item.Invite.ID.String(),
+			"addressed", item.Invite.Email != nil,
+		)
+		return c.Status(fiber.StatusCreated).JSON(buildInviteResponse(item, linkBase))
+	}
+}
+
+// GetEventInvites returns a handler for GET /api/v1/events/:id/invites.
+func GetEventInvites(svc *services.EventService, linkBase string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		items, err := svc.ListInvites(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writeInviteError(c, err, "event.list_invites", "failed to load invites")
+		}
+		return c.JSON(buildInviteResponses(items, linkBase))
+	}
+}
+
+// RevokeEventInvite returns a handler for DELETE /api/v1/events/:id/invites/:inviteId.
+func RevokeEventInvite(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		inviteID, err := uuid.Parse(c.Params("inviteId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid invite ID"})
+		}
+		if err := svc.RevokeInvite(c.UserContext(), eventID, inviteID, userID, userRole); err != nil {
+			return writeInviteError(c, err, "event.revoke_invite", "failed to revoke invite")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// ─── Invitee handlers ─────────────────────────────────────────────────────────
+
+// GetInvite returns a handler for GET /api/v1/invites/:code.
+func GetInvite(svc *services.EventService, linkBase string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		item, err := svc.GetInvite(c.UserContext(), c.Params("code"), userID)
+		if err != nil {
+			return writeInviteError(c, err, "invite.get", "failed to load invite")
+		}
+		return c.JSON(buildInviteResponse(item, linkBase))
+	}
+}
+
+// AcceptInvite returns a handler for POST /api/v1/invites/:code/accept.
+func AcceptInvite(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		member, err := svc.AcceptInvite(c.UserContext(), c.Params("code"), userID)
+		if err != nil {
+			return writeInviteError(c, err, "invite.accept", "failed to accept invite")
+		}
+		slog.InfoContext(c.UserContext(), "Event invite accepted",
+			"event_type_label", "event.invite_accepted",
+			"event_id", member.Player.EventID.String(),
+			"user_id", userID.String(),
+		)
+		return c.JSON(AcceptInviteResponse{
+			EventID: member.Player.EventID.String(),
+			Member:  buildMemberResponse(member),
+		})
+	}
+}
+
+// DeclineInvite returns a handler for POST /api/v1/invites/:code/decline.
+func DeclineInvite(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		if err := svc.DeclineInvite(c.UserContext(), c.Params("code"), userID); err != nil {
+			return writeInviteError(c, err, "invite.decline", "failed to decline invite")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// GetMyInvites returns a handler for GET /api/v1/users/me/invites.
+func GetMyInvites(svc *services.EventService, linkBase string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		items, err := svc.ListMyInvites(c.UserContext(), userID)
+		if err != nil {
+			return writeInviteError(c, err, "invite.list_mine", "failed to load invites")
+		}
+		return c.JSON(buildInviteResponses(items, linkBase))
+	}
+}
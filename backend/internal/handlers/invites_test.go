@@ -0,0 +1,67 @@
+// invites_test.go
+// Unit tests for the invitation handlers in invites.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation return before
+// any DB access, so nil-DB services are safe. Codes, caps, addressed invites
+// and sign-in resolution are covered in services/event_invites_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Invite -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+)
+
+const (
+	invitesRoute     = "/events/:id/invites"
+	inviteRoute      = "/events/:id/invites/:inviteId"
+	acceptRoute      = "/invites/:code/accept"
+	testInviteLinkTo = "golf://invite/"
+)
+
+func TestCreateEventInvite_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, invitesRoute, handlers.CreateEventInvite(nilEventSvc(), testInviteLinkTo))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/invites", map[string]any{})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestCreateEventInvite_InvalidEmail_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, invitesRoute, handlers.CreateEventInvite(nilEventSvc(), testInviteLinkTo))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/invites", map[string]any{"email": "not an email"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateEventInvite_ZeroMaxUses_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, invitesRoute, handlers.CreateEventInvite(nilEventSvc(), testInviteLinkTo))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/invites", map[string]any{"max_uses": 0})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateEventInvite_ExpiryTooLong_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, invitesRoute, handlers.CreateEventInvite(nilEventSvc(), testInviteLinkTo))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/invites", map[string]any{"expires_in_hours": 100000})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRevokeEventInvite_InvalidInviteID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodDelete, inviteRoute, handlers.RevokeEventInvite(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/events/"+validUUID+"/invites/abc", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAcceptInvite_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, acceptRoute, handlers.AcceptInvite(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/invites/ABCDEFGH23/accept", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,160 @@
+// handlers/leaderboard.go
+// HTTP handlers for the round leaderboard, event standings, and the event
+// finalize/reopen workflow.
+// All ranking logic lives in internal/services.LeaderboardService; these
+// handlers parse the path, call the service, and map errors via
+// writeLeaderboardError. The service payloads are returned directly as JSON.
+//
+// Endpoints:
+//
+//	GET  /api/v1/rounds/:roundId/leaderboard → ranked round leaderboard
+//	GET  /api/v1/events/:id/standings        → ranked event standings
+//	POST /api/v1/events/:id/finalize         → write final results, lock scores
+//	POST /api/v1/events/:id/reopen           → unlock a finalized event (reason required)
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request / response types ─────────────────────────────────────────────────
+
+// ReopenEventRequest is the body for POST /api/v1/events/:id/reopen.
+type ReopenEventRequest struct {
+	Reason string `json:"reason"`
+}
+
+// EventReopeningResponse is the recorded reopening returned by POST .../reopen.
+type EventReopeningResponse struct {
+	ID         string `json:"id"`
+	EventID    string `json:"event_id"`
+	ReopenedBy string `json:"reopened_by"`
+	Reason     string `json:"reason"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// ─── Error helper ─────────────────────────────────────────────────────────────
+
+// writeLeaderboardError translates a service error to an HTTP response.
+// For 5xx it sets c.Locals("error_detail") so the http.error log line
+// (emitted by middleware.ErrorLogger to Sentry) includes the root cause.
+// Always returns nil.
+func writeLeaderboardError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	var ve *services.ValidationError
+	if errors.As(err, &ve) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: ve.Message})
+	}
+	switch {
+	case errors.Is(err, services.ErrRoundNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "round not found"})
+	case errors.Is(err, services.ErrEventNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "event not found"})
+	case errors.Is(err, services.ErrEventNotMember):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: "not a member of this event"})
+	case errors.Is(err, services.ErrEventForbidden):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: "not authorized"})
+	case errors.Is(err, services.ErrEventFinalized):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "event results are already finalized"})
+	case errors.Is(err, services.ErrEventNotFinalized):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "event is not finalized"})
+	case errors.Is(err, services.ErrRoundsIncomplete):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "all rounds must be completed before the event can be finalized"})
+	}
+	c.Locals("error_detail", tag+": "+err.Error())
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{jsonKeyError: fallbackMsg})
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// GetRoundLeaderboard returns a handler for GET /api/v1/rounds/:roundId/leaderboard.
+// Any authenticated user may view it, matching the scorecard.
+func GetRoundLeaderboard(svc *services.LeaderboardService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, _, ok := authUser(c); !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		board, err := svc.RoundLeaderboard(c.UserContext(), roundID)
+		if err != nil {
+			return writeLeaderboardError(c, err, "leaderboard.round", "failed to load leaderboard")
+		}
+		return c.JSON(board)
+	}
+}
+
+// GetEventStandings returns a handler for GET /api/v1/events/:id/standings.
+// Non-admins must be members of the event.
+func GetEventStandings(svc *services.LeaderboardService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		standings, err := svc.EventStandings(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writeLeaderboardError(c, err, "leaderboard.event_standings", "failed to load standings")
+		}
+		return c.JSON(standings)
+	}
+}
+
+// FinalizeEvent returns a handler for POST /api/v1/events/:id/finalize.
+// Organizer-only; every round must be completed. Returns the final standings.
+func FinalizeEvent(svc *services.LeaderboardService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		standings, err := svc.FinalizeEvent(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writeLeaderboardError(c, err, "leaderboard.finalize", "failed to finalize event")
+		}
+		return c.JSON(standings)
+	}
+}
+
+// ReopenEvent returns a handler for POST /api/v1/events/:id/reopen.
+// Organizer-only; the reason is required and recorded.
+func ReopenEvent(svc *services.LeaderboardService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		var req ReopenEventRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		reopening, err := svc.ReopenEvent(c.UserContext(), eventID, userID, userRole, req.Reason)
+		if err != nil {
+			return writeLeaderboardError(c, err, "leaderboard.reopen", "failed to reopen event")
+		}
+		return c.Status(fiber.StatusCreated).JSON(EventReopeningResponse{
+			ID:         reopening.ID.String(),
+			EventID:    reopening.EventID.String(),
+			ReopenedBy: reopening.ReopenedBy.String(),
+			Reason:     reopening.Reason,
+			CreatedAt:  reopening.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+}
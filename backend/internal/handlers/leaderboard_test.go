@@ -0,0 +1,113 @@
+// leaderboard_test.go
+// Unit tests for the leaderboard handlers in leaderboard.go.
+//
+// Strategy: Tier 1 only — auth and path-param validation return before any DB
+// call, so a nil service is safe. Ranking itself is covered white-box in
+// services/tiebreak_internal_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Leaderboard -v
+//	go test ./internal/handlers/ -run Standings -v
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── GetRoundLeaderboard ──────────────────────────────────────────────────────
+
+func TestGetRoundLeaderboard_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, "/rounds/:roundId/leaderboard", handlers.GetRoundLeaderboard(nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/rounds/"+validUUID+"/leaderboard", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetRoundLeaderboard_InvalidRoundID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, "/rounds/:roundId/leaderboard", handlers.GetRoundLeaderboard(nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/rounds/not-a-uuid/leaderboard", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// ─── GetEventStandings ────────────────────────────────────────────────────────
+
+func TestGetEventStandings_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, "/events/:id/standings", handlers.GetEventStandings(nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/"+validUUID+"/standings", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetEventStandings_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, "/events/:id/standings", handlers.GetEventStandings(nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/not-a-uuid/standings", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// ─── FinalizeEvent / ReopenEvent ──────────────────────────────────────────────
+
+func TestFinalizeEvent_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, "/events/:id/finalize", handlers.FinalizeEvent(nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/events/"+validUUID+"/finalize", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestFinalizeEvent_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, "/events/:id/finalize", handlers.FinalizeEvent(nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/events/not-a-uuid/finalize", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestReopenEvent_MissingReason_BadRequest verifies the reason is required before
+// any DB access (the service validates it first, so a nil DB is safe).
+func TestReopenEvent_MissingReason_BadRequest(t *testing.T) {
+	svc := services.NewLeaderboardService(nil, nil)
+	app := newEventAppWithAuth(http.MethodPost, "/events/:id/reopen", handlers.ReopenEvent(svc))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/reopen", map[string]any{"reason": "   "})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// ─── writeLeaderboardError ────────────────────────────────────────────────────
+
+func TestWriteLeaderboardError_StatusMapping(t *testing.T) {
+	cases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"round not found", services.ErrRoundNotFound, http.StatusNotFound},
+		{"event not found", services.ErrEventNotFound, http.StatusNotFound},
+		{"not member", services.ErrEventNotMember, http.StatusForbidden},
+		{"forbidden", services.ErrEventForbidden, http.StatusForbidden},
+		{"already finalized", services.ErrEventFinalized, http.StatusConflict},
+		{"not finalized", services.ErrEventNotFinalized, http.StatusConflict},
+		{"rounds incomplete", services.ErrRoundsIncomplete, http.StatusConflict},
+		{"validation", &services.ValidationError{Field: "reason", Message: "reason is required"}, http.StatusBadRequest},
+		{"unknown", errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			app, _ := captureErrorDetail(http.MethodGet, "/x", func(c *fiber.Ctx) error {
+				return handlers.WriteLeaderboardErrorExported(c, tc.err, "test.tag", "fallback")
+			})
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/x", nil), -1)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+		})
+	}
+}
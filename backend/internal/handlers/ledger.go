@@ -0,0 +1,216 @@
+// handlers/ledger.go
+// HTTP handlers for the side-bet ledger: posting a round's side-game results,
+// each player's net balances with everyone they've played, and settling up.
+// Business logic lives in internal/services.LedgerService (ledger_service.go);
+// errors map through writeLedgerError, which falls back to writeRoundError.
+//
+// Endpoints:
+//
+//	GET    /api/v1/rounds/:roundId/side-games       → the round's posted results (players and organizer)
+//	PUT    /api/v1/rounds/:roundId/side-games/:game → replace one game's results (organizer; completed rounds)
+//	GET    /api/v1/users/me/ledger                  → your open balances, netted per player
+//	GET    /api/v1/users/me/ledger/:userId          → your balance with one player and the entries behind it
+//	POST   /api/v1/users/me/ledger/:userId/settle   → record a settle-up of that balance
+//	DELETE /api/v1/users/me/ledger/entries/:entryId → remove a settle-up you're party to
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// SideGameResultRequest is one line of PUT .../side-games/:game: from_user_id
+// lost amount_cents to to_user_id.
+type SideGameResultRequest struct {
+	FromUserID  string  `json:"from_user_id"`
+	ToUserID    string  `json:"to_user_id"`
+	AmountCents int     `json:"amount_cents"`
+	Note        *string `json:"note"`
+}
+
+// PostSideGameResultsRequest is the body for PUT .../side-games/:game. It
+// replaces the game's results, whoever posted them; an empty list clears them.
+type PostSideGameResultsRequest struct {
+	Results []SideGameResultRequest `json:"results"`
+}
+
+// SettleUpRequest is the optional body for POST .../ledger/:userId/settle.
+// amount_cents defaults to the whole balance.
+type SettleUpRequest struct {
+	AmountCents *int    `json:"amount_cents"`
+	Note        *string `json:"note"`
+}
+
+// writeLedgerError maps ledger errors to HTTP responses, deferring to
+// writeRoundError for everything else.
+func writeLedgerError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	switch {
+	case errors.Is(err, services.ErrRoundNotCompleted):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "side games can be settled once the round is completed"})
+	case errors.Is(err, services.ErrNothingToSettle):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "nothing to settle; the balance is zero"})
+	case errors.Is(err, services.ErrLedgerEntryNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "ledger entry not found"})
+	case errors.Is(err, services.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "user not found"})
+	}
+	return writeRoundError(c, err, tag, fallbackMsg)
+}
+
+// parseLedgerUserID parses the ":userId" path param. Writes 400 + returns false on failure.
+func parseLedgerUserID(c *fiber.Ctx) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid user ID in path"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// GetRoundSideGames returns a handler for GET /api/v1/rounds/:roundId/side-games.
+func GetRoundSideGames(svc *services.LedgerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.RoundSideGames(c.UserContext(), roundID, userID, userRole)
+		if err != nil {
+			return writeLedgerError(c, err, "ledger.round_side_games", "failed to load side games")
+		}
+		return c.JSON(data)
+	}
+}
+
+// PostSideGameResults returns a handler for PUT /api/v1/rounds/:roundId/side-games/:game.
+func PostSideGameResults(svc *services.LedgerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		var req PostSideGameResultsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		lines := make([]services.SideGameResultInput, len(req.Results))
+		for i, r := range req.Results {
+			fromID, err := uuid.Parse(r.FromUserID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid from_user_id"})
+			}
+			toID, err := uuid.Parse(r.ToUserID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid to_user_id"})
+			}
+			lines[i] = services.SideGameResultInput{FromUserID: fromID, ToUserID: toID, AmountCents: r.AmountCents, Note: r.Note}
+		}
+		game := c.Params("game")
+		data, err := svc.PostSideGameResults(c.UserContext(), roundID, userID, userRole, game, lines)
+		if err != nil {
+			return writeLedgerError(c, err, "ledger.post_side_game", "failed to save side-game results")
+		}
+		slog.InfoContext(c.UserContext(), "Side-game results posted",
+			"event_type_label", "ledger.side_game_posted",
+			"round_id", roundID.String(),
+			"game", game,
+			"results", len(lines),
+		)
+		return c.JSON(data)
+	}
+}
+
+// GetMyLedger returns a handler for GET /api/v1/users/me/ledger.
+func GetMyLedger(svc *services.LedgerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.MyLedger(c.UserContext(), userID)
+		if err != nil {
+			return writeLedgerError(c, err, "ledger.mine", "failed to load ledger")
+		}
+		return c.JSON(data)
+	}
+}
+
+// GetPairLedger returns a handler for GET /api/v1/users/me/ledger/:userId.
+func GetPairLedger(svc *services.LedgerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		otherID, ok := parseLedgerUserID(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.PairLedger(c.UserContext(), userID, otherID)
+		if err != nil {
+			return writeLedgerError(c, err, "ledger.pair", "failed to load ledger")
+		}
+		return c.JSON(data)
+	}
+}
+
+// SettleUp returns a handler for POST /api/v1/users/me/ledger/:userId/settle.
+func SettleUp(svc *services.LedgerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		otherID, ok := parseLedgerUserID(c)
+		if !ok {
+			return nil
+		}
+		var req SettleUpRequest
+		if len(c.Body()) > 0 {
+			if err := c.BodyParser(&req); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+			}
+		}
+		data, err := svc.SettleUp(c.UserContext(), userID, otherID, req.AmountCents, req.Note)
+		if err != nil {
+			return writeLedgerError(c, err, "ledger.settle_up", "failed to settle up")
+		}
+		slog.InfoContext(c.UserContext(), "Ledger settled up",
+			"event_type_label", "ledger.settled_up",
+			"user_id", userID.String(),
+			"other_user_id", otherID.String(),
+			"balance_cents", data.BalanceCents,
+		)
+		return c.Status(fiber.StatusCreated).JSON(data)
+	}
+}
+
+// DeleteSettleUp returns a handler for DELETE /api/v1/users/me/ledger/entries/:entryId.
+func DeleteSettleUp(svc *services.LedgerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		entryID, err := uuid.Parse(c.Params("entryId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid entry ID"})
+		}
+		if err := svc.DeleteSettleUp(c.UserContext(), userID, entryID); err != nil {
+			return writeLedgerError(c, err, "ledger.delete_settle_up", "failed to delete settle-up")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
@@ -0,0 +1,127 @@
+// ledger_test.go
+// Unit tests for the side-bet ledger handlers in ledger.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation return before
+// any DB call, so a nil-DB LedgerService is safe. Netting and settle-ups are
+// covered in services/ledger_service_test.go and services/ledger_internal_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run 'SideGame|Ledger|SettleUp' -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const (
+	sideGamesRoute   = "/rounds/:roundId/side-games"
+	sideGameRoute    = "/rounds/:roundId/side-games/:game"
+	pairLedgerRoute  = "/users/me/ledger/:userId"
+	settleUpRoute    = "/users/me/ledger/:userId/settle"
+	ledgerEntryRoute = "/users/me/ledger/entries/:entryId"
+
+	// otherUUID is a second well-formed user ID (validUUID is the caller).
+	otherUUID = "11111111-1111-1111-1111-111111111111"
+)
+
+// nilLedgerSvc returns a LedgerService with no DB; only safe on paths that fail
+// validation first.
+func nilLedgerSvc() *services.LedgerService {
+	return services.NewLedgerService(nil, services.NewRoundService(nil, nilEventSvc()))
+}
+
+func TestGetRoundSideGames_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, sideGamesRoute, handlers.GetRoundSideGames(nilLedgerSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/rounds/"+validUUID+"/side-games", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetRoundSideGames_InvalidRoundID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, sideGamesRoute, handlers.GetRoundSideGames(nilLedgerSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/rounds/not-a-uuid/side-games", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestPostSideGameResults_UnknownGame_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, sideGameRoute, handlers.PostSideGameResults(nilLedgerSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/rounds/"+validUUID+"/side-games/poker", map[string]any{"results": []any{}})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestPostSideGameResults_InvalidUserID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, sideGameRoute, handlers.PostSideGameResults(nilLedgerSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/rounds/"+validUUID+"/side-games/skins", map[string]any{
+		"results": []map[string]any{{"from_user_id": "nope", "to_user_id": validUUID, "amount_cents": 500}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestPostSideGameResults_ZeroAmount_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, sideGameRoute, handlers.PostSideGameResults(nilLedgerSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/rounds/"+validUUID+"/side-games/nassau", map[string]any{
+		"results": []map[string]any{{
+			"from_user_id": otherUUID, "to_user_id": validUUID, "amount_cents": 0,
+		}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestPostSideGameResults_SelfOwed_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, sideGameRoute, handlers.PostSideGameResults(nilLedgerSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/rounds/"+validUUID+"/side-games/las_vegas", map[string]any{
+		"results": []map[string]any{{"from_user_id": validUUID, "to_user_id": validUUID, "amount_cents": 500}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetMyLedger_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, "/users/me/ledger", handlers.GetMyLedger(nilLedgerSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users/me/ledger", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetPairLedger_InvalidUserID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, pairLedgerRoute, handlers.GetPairLedger(nilLedgerSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users/me/ledger/abc", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSettleUp_InvalidUserID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, settleUpRoute, handlers.SettleUp(nilLedgerSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users/me/ledger/abc/settle", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSettleUp_NegativeAmount_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, settleUpRoute, handlers.SettleUp(nilLedgerSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/users/me/ledger/"+otherUUID+"/settle", map[string]any{"amount_cents": -100})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSettleUp_WithSelf_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, settleUpRoute, handlers.SettleUp(nilLedgerSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users/me/ledger/"+validUUID+"/settle", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestDeleteSettleUp_InvalidEntryID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodDelete, ledgerEntryRoute, handlers.DeleteSettleUp(nilLedgerSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/users/me/ledger/entries/xyz", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,138 @@
+// handlers/notifications.go
+// HTTP handlers for the in-app notification inbox and per-type preferences.
+// Business logic lives in internal/services.NotificationService
+// (notification_service.go); notifications themselves are written by the
+// services whose changes they report. Errors map through
+// writeNotificationError.
+//
+// Endpoints:
+//
+//	GET   /api/v1/users/me/notifications             → your inbox, newest first (?unread=true, ?limit=, ?before=)
+//	POST  /api/v1/users/me/notifications/:id/read    → mark one read
+//	POST  /api/v1/users/me/notifications/read-all    → mark everything read
+//	GET   /api/v1/users/me/notifications/preferences → every type and whether it's on
+//	PATCH /api/v1/users/me/notifications/preferences → turn types on or off
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// MarkAllReadResponse is the result of POST .../notifications/read-all.
+type MarkAllReadResponse struct {
+	Marked int64 `json:"marked"`
+}
+
+// writeNotificationError maps notification errors to HTTP responses.
+func writeNotificationError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	var ve *services.ValidationError
+	if errors.As(err, &ve) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: ve.Message})
+	}
+	if errors.Is(err, services.ErrNotificationNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "notification not found"})
+	}
+	c.Locals("error_detail", tag+": "+err.Error())
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{jsonKeyError: fallbackMsg})
+}
+
+// GetMyNotifications returns a handler for GET /api/v1/users/me/notifications.
+// before is the next_before cursor from the previous page.
+func GetMyNotifications(svc *services.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		in := services.ListNotificationsInput{
+			UnreadOnly: c.QueryBool("unread", false),
+			Limit:      c.QueryInt("limit", 0),
+		}
+		if raw := c.Query("before"); raw != "" {
+			before, err := time.Parse(time.RFC3339Nano, raw)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "before must be an RFC 3339 timestamp"})
+			}
+			in.Before = &before
+		}
+		page, err := svc.List(c.UserContext(), userID, in)
+		if err != nil {
+			return writeNotificationError(c, err, "notification.list", "failed to load notifications")
+		}
+		return c.JSON(page)
+	}
+}
+
+// MarkNotificationRead returns a handler for POST /api/v1/users/me/notifications/:id/read.
+func MarkNotificationRead(svc *services.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		notificationID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid notification ID"})
+		}
+		if err := svc.MarkRead(c.UserContext(), userID, notificationID); err != nil {
+			return writeNotificationError(c, err, "notification.mark_read", "failed to mark notification read")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// MarkAllNotificationsRead returns a handler for POST /api/v1/users/me/notifications/read-all.
+func MarkAllNotificationsRead(svc *services.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		marked, err := svc.MarkAllRead(c.UserContext(), userID)
+		if err != nil {
+			return writeNotificationError(c, err, "notification.mark_all_read", "failed to mark notifications read")
+		}
+		return c.JSON(MarkAllReadResponse{Marked: marked})
+	}
+}
+
+// GetNotificationPreferences returns a handler for GET /api/v1/users/me/notifications/preferences.
+func GetNotificationPreferences(svc *services.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		prefs, err := svc.Preferences(c.UserContext(), userID)
+		if err != nil {
+			return writeNotificationError(c, err, "notification.preferences", "failed to load preferences")
+		}
+		return c.JSON(prefs)
+	}
+}
+
+// UpdateNotificationPreferences returns a handler for PATCH
+// /api/v1/users/me/notifications/preferences. The body maps types to on/off;
+// types left out are unchanged.
+func UpdateNotificationPreferences(svc *services.NotificationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		var body map[string]bool
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		prefs, err := svc.UpdatePreferences(c.UserContext(), userID, body)
+		if err != nil {
+			return writeNotificationError(c, err, "notification.update_preferences", "failed to save preferences")
+		}
+		return c.JSON(prefs)
+	}
+}
@@ -0,0 +1,95 @@
+// notifications_test.go
+// Unit tests for the notification inbox handlers in notifications.go.
+//
+// Strategy: Tier 1 only — auth, query/path-param and body validation return
+// before any DB call, so a nil-DB NotificationService is safe. Listing, marking
+// read and the triggers are covered in services/notification_service_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run 'Notification' -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const (
+	notificationsRoute     = "/users/me/notifications"
+	notificationReadRoute  = "/users/me/notifications/:id/read"
+	notificationPrefsRoute = "/users/me/notifications/preferences"
+)
+
+// nilNotificationSvc returns a NotificationService with no DB; only safe on
+// paths that fail validation first.
+func nilNotificationSvc() *services.NotificationService {
+	return services.NewNotificationService(nil)
+}
+
+func TestGetMyNotifications_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, notificationsRoute, handlers.GetMyNotifications(nilNotificationSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, notificationsRoute, nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetMyNotifications_BadBefore_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, notificationsRoute, handlers.GetMyNotifications(nilNotificationSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, notificationsRoute+"?before=yesterday", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetMyNotifications_LimitTooLarge_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, notificationsRoute, handlers.GetMyNotifications(nilNotificationSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, notificationsRoute+"?limit=500", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestMarkNotificationRead_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, notificationReadRoute, handlers.MarkNotificationRead(nilNotificationSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users/me/notifications/"+validUUID+"/read", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestMarkNotificationRead_InvalidID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, notificationReadRoute, handlers.MarkNotificationRead(nilNotificationSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users/me/notifications/not-a-uuid/read", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestMarkAllNotificationsRead_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, "/users/me/notifications/read-all", handlers.MarkAllNotificationsRead(nilNotificationSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users/me/notifications/read-all", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestUpdateNotificationPreferences_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPatch, notificationPrefsRoute, handlers.UpdateNotificationPreferences(nilNotificationSvc()))
+	resp := doJSON(t, app, http.MethodPatch, notificationPrefsRoute, map[string]bool{"new_follower": false})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestUpdateNotificationPreferences_UnknownType_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, notificationPrefsRoute, handlers.UpdateNotificationPreferences(nilNotificationSvc()))
+	resp := doJSON(t, app, http.MethodPatch, notificationPrefsRoute, map[string]bool{"spam": false})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestUpdateNotificationPreferences_NonBoolValue_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, notificationPrefsRoute, handlers.UpdateNotificationPreferences(nilNotificationSvc()))
+	resp := doJSON(t, app, http.MethodPatch, notificationPrefsRoute, map[string]any{"new_follower": "off"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,136 @@
+// handlers/pairings.go
+// HTTP handler for automatic round pairings. The draw itself lives in
+// internal/services.RoundService.GeneratePairings; errors map through
+// writeRoundError.
+//
+// Endpoints:
+//
+//	POST /api/v1/rounds/:roundId/pairings/generate → draw groups, as a preview or committed (organizer only)
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request / response types ─────────────────────────────────────────────────
+
+// GeneratePairingsRequest is the body for POST /api/v1/rounds/:roundId/pairings/generate.
+// With preview=true nothing is saved; send the returned seed back with
+// preview=false to commit the groups that were previewed.
+type GeneratePairingsRequest struct {
+	Strategy       string `json:"strategy"`        // random (default) | handicap_balanced | abcd | minimize_repeats
+	GroupSize      int    `json:"group_size"`      // largest group, 2–4; default 4
+	LookbackRounds int    `json:"lookback_rounds"` // minimize_repeats only; default 5
+	Seed           *int64 `json:"seed"`
+	Preview        bool   `json:"preview"`
+}
+
+// PairingPlayerResponse is one player in a generated group. round_player_id is
+// null in a preview for members who aren't registered for the round yet.
+type PairingPlayerResponse struct {
+	UserID        string   `json:"user_id"`
+	RoundPlayerID *string  `json:"round_player_id"`
+	DisplayName   string   `json:"display_name"`
+	IsGuest       bool     `json:"is_guest"`
+	HandicapIndex *float64 `json:"handicap_index"`
+	Band          *string  `json:"band"` // "A"–"D" for the abcd strategy, else null
+}
+
+// PairingGroupResponse is one generated group. id is null in a preview.
+type PairingGroupResponse struct {
+	ID              *string                 `json:"id"`
+	GroupNumber     int                     `json:"group_number"`
+	AverageHandicap *float64                `json:"average_handicap"`
+	RepeatPairings  int                     `json:"repeat_pairings"`
+	Players         []PairingPlayerResponse `json:"players"`
+}
+
+// PairingsResponse is the response for POST /api/v1/rounds/:roundId/pairings/generate.
+type PairingsResponse struct {
+	Strategy       string                 `json:"strategy"`
+	Seed           int64                  `json:"seed"`
+	Preview        bool                   `json:"preview"`
+	RepeatPairings int                    `json:"repeat_pairings"`
+	Groups         []PairingGroupResponse `json:"groups"`
+}
+
+// toPairingsResponse maps the service result to its JSON shape.
+func toPairingsResponse(r services.PairingsResult) PairingsResponse {
+	out := PairingsResponse{
+		Strategy: r.Strategy, Seed: r.Seed, Preview: r.Preview,
+		RepeatPairings: r.RepeatPairings, Groups: make([]PairingGroupResponse, len(r.Groups)),
+	}
+	for i, g := range r.Groups {
+		group := PairingGroupResponse{
+			GroupNumber: g.GroupNumber, AverageHandicap: g.AverageHandicap,
+			RepeatPairings: g.RepeatPairings, Players: make([]PairingPlayerResponse, len(g.Players)),
+		}
+		if g.GroupID != nil {
+			id := g.GroupID.String()
+			group.ID = &id
+		}
+		for j, p := range g.Players {
+			player := PairingPlayerResponse{
+				UserID: p.UserID.String(), DisplayName: p.DisplayName,
+				IsGuest: p.IsGuest, HandicapIndex: p.HandicapIndex,
+			}
+			if p.RoundPlayerID != nil {
+				id := p.RoundPlayerID.String()
+				player.RoundPlayerID = &id
+			}
+			if p.Band != "" {
+				band := p.Band
+				player.Band = &band
+			}
+			group.Players[j] = player
+		}
+		out.Groups[i] = group
+	}
+	return out
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// GeneratePairings returns a handler for POST /api/v1/rounds/:roundId/pairings/generate.
+// Organizer-only, and only while the round is scheduled. A preview responds 200;
+// a commit replaces the round's groups and responds 201.
+func GeneratePairings(svc *services.RoundService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		callerID, callerRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		var req GeneratePairingsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+
+		result, err := svc.GeneratePairings(c.UserContext(), roundID, callerID, callerRole, services.GeneratePairingsInput{
+			Strategy:       req.Strategy,
+			GroupSize:      req.GroupSize,
+			LookbackRounds: req.LookbackRounds,
+			Seed:           req.Seed,
+			Preview:        req.Preview,
+		})
+		if err != nil {
+			return writeRoundError(c, err, "round.generate_pairings", "failed to generate pairings")
+		}
+		if result.Preview {
+			return c.JSON(toPairingsResponse(result))
+		}
+		slog.InfoContext(c.UserContext(), "Pairings generated",
+			"event_type_label", "round.pairings_generated",
+			"round_id", roundID.String(),
+			"strategy", result.Strategy,
+			"group_count", len(result.Groups),
+		)
+		return c.Status(fiber.StatusCreated).JSON(toPairingsResponse(result))
+	}
+}
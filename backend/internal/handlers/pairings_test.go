@@ -0,0 +1,55 @@
+// pairings_test.go
+// Unit tests for the pairings handler in pairings.go.
+//
+// Strategy: Tier 1 only — auth, path-param and input validation return before
+// any DB call, so a nil-DB RoundService is safe. The draws themselves are
+// covered in services/round_pairings_internal_test.go and round_pairings_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Pairings -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+)
+
+const pairingsRoute = "/rounds/:roundId/pairings/generate"
+
+func TestGeneratePairings_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, pairingsRoute, handlers.GeneratePairings(nilRoundSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/rounds/"+validUUID+"/pairings/generate", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGeneratePairings_InvalidRoundID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, pairingsRoute, handlers.GeneratePairings(nilRoundSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/not-a-uuid/pairings/generate", map[string]any{})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGeneratePairings_UnknownStrategy_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, pairingsRoute, handlers.GeneratePairings(nilRoundSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/pairings/generate", map[string]any{"strategy": "alphabetical"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGeneratePairings_GroupOfFive_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, pairingsRoute, handlers.GeneratePairings(nilRoundSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/pairings/generate", map[string]any{"group_size": 5})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGeneratePairings_NegativeSeed_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, pairingsRoute, handlers.GeneratePairings(nilRoundSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/pairings/generate", map[string]any{"seed": -1, "preview": true})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,127 @@
+// handlers/privacy.go
+// HTTP handlers for the signed-in user's privacy settings and the users they've
+// blocked. Business logic lives in internal/services.PrivacyService
+// (privacy_service.go); search, profiles, stats, scorecards and the feed apply
+// the settings in their own services. Errors map through writePrivacyError,
+// which falls back to writeUserError.
+//
+// Endpoints:
+//
+//	GET    /api/v1/users/me/privacy     → your privacy settings
+//	PATCH  /api/v1/users/me/privacy     → change some of them
+//	GET    /api/v1/users/me/blocks      → users you've blocked
+//	POST   /api/v1/users/:userId/block  → block a user (also removes follows both ways)
+//	DELETE /api/v1/users/:userId/block  → unblock a user
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// writePrivacyError maps privacy errors to HTTP responses, deferring to
+// writeUserError for everything else.
+func writePrivacyError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	if errors.Is(err, services.ErrBlockSelf) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "cannot block yourself"})
+	}
+	return writeUserError(c, err, tag, fallbackMsg)
+}
+
+// GetPrivacySettings returns a handler for GET /api/v1/users/me/privacy.
+func GetPrivacySettings(svc *services.PrivacyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		settings, err := svc.GetSettings(c.UserContext(), userID)
+		if err != nil {
+			return writePrivacyError(c, err, "privacy.get_settings", "failed to load privacy settings")
+		}
+		return c.JSON(settings)
+	}
+}
+
+// UpdatePrivacySettings returns a handler for PATCH /api/v1/users/me/privacy.
+// Only the fields present in the body change.
+func UpdatePrivacySettings(svc *services.PrivacyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		var req services.PrivacySettingsInput
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		settings, err := svc.UpdateSettings(c.UserContext(), userID, req)
+		if err != nil {
+			return writePrivacyError(c, err, "privacy.update_settings", "failed to save privacy settings")
+		}
+		slog.InfoContext(c.UserContext(), "Privacy settings updated",
+			"event_type_label", "privacy.settings_updated",
+			"profile_visibility", settings.ProfileVisibility,
+		)
+		return c.JSON(settings)
+	}
+}
+
+// GetBlockedUsers returns a handler for GET /api/v1/users/me/blocks.
+func GetBlockedUsers(svc *services.PrivacyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		blocked, err := svc.ListBlocked(c.UserContext(), userID)
+		if err != nil {
+			return writePrivacyError(c, err, "privacy.list_blocked", "failed to load blocked users")
+		}
+		return c.JSON(blocked)
+	}
+}
+
+// BlockUser returns a handler for POST /api/v1/users/:userId/block.
+func BlockUser(svc *services.PrivacyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		targetID, err := uuid.Parse(c.Params("userId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid user ID"})
+		}
+		if err := svc.Block(c.UserContext(), userID, targetID); err != nil {
+			return writePrivacyError(c, err, "privacy.block", "failed to block user")
+		}
+		slog.InfoContext(c.UserContext(), "User blocked",
+			"event_type_label", "privacy.user_blocked",
+			"blocked_id", targetID.String(),
+		)
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// UnblockUser returns a handler for DELETE /api/v1/users/:userId/block.
+func UnblockUser(svc *services.PrivacyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		targetID, err := uuid.Parse(c.Params("userId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid user ID"})
+		}
+		if err := svc.Unblock(c.UserContext(), userID, targetID); err != nil {
+			return writePrivacyError(c, err, "privacy.unblock", "failed to unblock user")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
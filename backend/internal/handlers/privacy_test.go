@@ -0,0 +1,68 @@
+// privacy_test.go
+// Unit tests for the privacy settings and blocking handlers in privacy.go.
+//
+// Strategy: Tier 1 only — auth, path parsing and input validation return
+// before any DB call, so a nil-DB PrivacyService is safe. How the settings are
+// enforced is covered in services/privacy_service_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run 'Privacy|Block' -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const (
+	privacyRoute = "/users/me/privacy"
+	blockRoute   = "/users/:userId/block"
+)
+
+// nilPrivacySvc returns a PrivacyService with no DB; only safe on paths that
+// fail validation first.
+func nilPrivacySvc() *services.PrivacyService {
+	return services.NewPrivacyService(nil)
+}
+
+func TestGetPrivacySettings_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, privacyRoute, handlers.GetPrivacySettings(nilPrivacySvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, privacyRoute, nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestUpdatePrivacySettings_BadVisibility_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPatch, privacyRoute, handlers.UpdatePrivacySettings(nilPrivacySvc()))
+	resp := doJSON(t, app, http.MethodPatch, privacyRoute, map[string]any{"profile_visibility": "friends"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestBlockUser_InvalidUserID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, blockRoute, handlers.BlockUser(nilPrivacySvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users/nope/block", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestBlockUser_Self_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, blockRoute, handlers.BlockUser(nilPrivacySvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users/"+validUUID+"/block", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestUnblockUser_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodDelete, blockRoute, handlers.UnblockUser(nilPrivacySvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/users/"+validUUID+"/block", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,287 @@
+// handlers/purse.go
+// HTTP handlers for the money ledger: entry fees, who has paid, the purse and
+// payouts by finishing place, for an event and for each of its rounds. Business
+// logic lives in internal/services.PurseService (purse_service.go); errors map
+// through writePurseError, which falls back to writeEventError.
+//
+// Every endpoint responds with the full, updated ledger.
+//
+// Endpoints:
+//
+//	GET    /api/v1/events/:id/purse                       → the event ledger (members)
+//	PUT    /api/v1/events/:id/purse                       → set the entry fee and payout schedule (organizer only)
+//	PUT    /api/v1/events/:id/purse/payments/:userId      → mark a member's fee paid (organizer only)
+//	DELETE /api/v1/events/:id/purse/payments/:userId      → unmark it (organizer only)
+//	GET    /api/v1/rounds/:roundId/purse                  → a round's ledger (event members)
+//	PUT    /api/v1/rounds/:roundId/purse                  → set the round's fee and schedule (organizer only)
+//	PUT    /api/v1/rounds/:roundId/purse/payments/:userId → mark a player's round fee paid (organizer only)
+//	DELETE /api/v1/rounds/:roundId/purse/payments/:userId → unmark it (organizer only)
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// SetPurseRequest is the body for PUT .../purse. It replaces the whole setup:
+// an omitted entry_fee_cents is 0, an omitted payouts list clears the schedule.
+type SetPurseRequest struct {
+	EntryFeeCents int                         `json:"entry_fee_cents"`
+	Payouts       []services.PayoutPlaceInput `json:"payouts"`
+}
+
+// RecordPaymentRequest is the optional body for PUT .../purse/payments/:userId.
+// amount_cents defaults to the current entry fee.
+type RecordPaymentRequest struct {
+	AmountCents *int `json:"amount_cents"`
+}
+
+// writePurseError maps round lookups to HTTP responses, deferring to
+// writeEventError for everything else.
+func writePurseError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	switch {
+	case errors.Is(err, services.ErrRoundNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "round not found"})
+	case errors.Is(err, services.ErrPlayerNotInRound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "player is not registered for this round"})
+	}
+	return writeEventError(c, err, tag, fallbackMsg)
+}
+
+// parsePurseUserID parses the ":userId" path param. Writes 400 + returns false on failure.
+func parsePurseUserID(c *fiber.Ctx) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid user ID in path"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parsePaymentBody reads the optional RecordPaymentRequest body.
+func parsePaymentBody(c *fiber.Ctx) (RecordPaymentRequest, bool) {
+	var req RecordPaymentRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			_ = c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+			return req, false
+		}
+	}
+	return req, true
+}
+
+// ─── Event purse ──────────────────────────────────────────────────────────────
+
+// GetEventPurse returns a handler for GET /api/v1/events/:id/purse.
+func GetEventPurse(svc *services.PurseService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.EventPurse(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writePurseError(c, err, "purse.get_event", "failed to load purse")
+		}
+		return c.JSON(data)
+	}
+}
+
+// SetEventPurse returns a handler for PUT /api/v1/events/:id/purse.
+func SetEventPurse(svc *services.PurseService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		var req SetPurseRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		data, err := svc.SetEventPurse(c.UserContext(), eventID, userID, userRole,
+			services.SetPurseInput{EntryFeeCents: req.EntryFeeCents, Payouts: req.Payouts})
+		if err != nil {
+			return writePurseError(c, err, "purse.set_event", "failed to save purse")
+		}
+		slog.InfoContext(c.UserContext(), "Event purse set",
+			"event_type_label", "purse.event_set",
+			"event_id", eventID.String(),
+			"entry_fee_cents", req.EntryFeeCents,
+			"payout_places", len(req.Payouts),
+		)
+		return c.JSON(data)
+	}
+}
+
+// RecordEventPayment returns a handler for PUT /api/v1/events/:id/purse/payments/:userId.
+func RecordEventPayment(svc *services.PurseService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		targetID, ok := parsePurseUserID(c)
+		if !ok {
+			return nil
+		}
+		req, ok := parsePaymentBody(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.RecordEventPayment(c.UserContext(), eventID, targetID, userID, userRole, req.AmountCents)
+		if err != nil {
+			return writePurseError(c, err, "purse.record_event_payment", "failed to record payment")
+		}
+		slog.InfoContext(c.UserContext(), "Entry fee marked paid",
+			"event_type_label", "purse.payment_recorded",
+			"event_id", eventID.String(),
+			"user_id", targetID.String(),
+		)
+		return c.JSON(data)
+	}
+}
+
+// ClearEventPayment returns a handler for DELETE /api/v1/events/:id/purse/payments/:userId.
+func ClearEventPayment(svc *services.PurseService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		targetID, ok := parsePurseUserID(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.ClearEventPayment(c.UserContext(), eventID, targetID, userID, userRole)
+		if err != nil {
+			return writePurseError(c, err, "purse.clear_event_payment", "failed to clear payment")
+		}
+		return c.JSON(data)
+	}
+}
+
+// ─── Round purse ──────────────────────────────────────────────────────────────
+
+// GetRoundPurse returns a handler for GET /api/v1/rounds/:roundId/purse.
+func GetRoundPurse(svc *services.PurseService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.RoundPurse(c.UserContext(), roundID, userID, userRole)
+		if err != nil {
+			return writePurseError(c, err, "purse.get_round", "failed to load purse")
+		}
+		return c.JSON(data)
+	}
+}
+
+// SetRoundPurse returns a handler for PUT /api/v1/rounds/:roundId/purse.
+func SetRoundPurse(svc *services.PurseService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		var req SetPurseRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		data, err := svc.SetRoundPurse(c.UserContext(), roundID, userID, userRole,
+			services.SetPurseInput{EntryFeeCents: req.EntryFeeCents, Payouts: req.Payouts})
+		if err != nil {
+			return writePurseError(c, err, "purse.set_round", "failed to save purse")
+		}
+		slog.InfoContext(c.UserContext(), "Round purse set",
+			"event_type_label", "purse.round_set",
+			"round_id", roundID.String(),
+			"entry_fee_cents", req.EntryFeeCents,
+			"payout_places", len(req.Payouts),
+		)
+		return c.JSON(data)
+	}
+}
+
+// RecordRoundPayment returns a handler for PUT /api/v1/rounds/:roundId/purse/payments/:userId.
+func RecordRoundPayment(svc *services.PurseService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		targetID, ok := parsePurseUserID(c)
+		if !ok {
+			return nil
+		}
+		req, ok := parsePaymentBody(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.RecordRoundPayment(c.UserContext(), roundID, targetID, userID, userRole, req.AmountCents)
+		if err != nil {
+			return writePurseError(c, err, "purse.record_round_payment", "failed to record payment")
+		}
+		slog.InfoContext(c.UserContext(), "Round fee marked paid",
+			"event_type_label", "purse.payment_recorded",
+			"round_id", roundID.String(),
+			"user_id", targetID.String(),
+		)
+		return c.JSON(data)
+	}
+}
+
+// ClearRoundPayment returns a handler for DELETE /api/v1/rounds/:roundId/purse/payments/:userId.
+func ClearRoundPayment(svc *services.PurseService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		targetID, ok := parsePurseUserID(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.ClearRoundPayment(c.UserContext(), roundID, targetID, userID, userRole)
+		if err != nil {
+			return writePurseError(c, err, "purse.clear_round_payment", "failed to clear payment")
+		}
+		return c.JSON(data)
+	}
+}
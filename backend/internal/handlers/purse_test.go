@@ -0,0 +1,124 @@
+// purse_test.go
+// Unit tests for the entry fee and purse handlers in purse.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation return before
+// any DB call, so a nil-DB PurseService is safe. Ledger totals and payout
+// splits are covered in services/purse_service_test.go and
+// services/purse_internal_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Purse -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const (
+	eventPurseRoute   = "/events/:id/purse"
+	eventPaymentRoute = "/events/:id/purse/payments/:userId"
+	roundPurseRoute   = "/rounds/:roundId/purse"
+	roundPaymentRoute = "/rounds/:roundId/purse/payments/:userId"
+)
+
+// nilPurseSvc returns a PurseService with no DB; only safe on paths that fail
+// validation first.
+func nilPurseSvc() *services.PurseService {
+	return services.NewPurseService(nil, nilEventSvc(), nil)
+}
+
+func TestGetEventPurse_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, eventPurseRoute, handlers.GetEventPurse(nilPurseSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/"+validUUID+"/purse", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetEventPurse_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, eventPurseRoute, handlers.GetEventPurse(nilPurseSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/not-a-uuid/purse", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetEventPurse_NegativeFee_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, eventPurseRoute, handlers.SetEventPurse(nilPurseSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/events/"+validUUID+"/purse", map[string]any{"entry_fee_cents": -500})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetEventPurse_OverHundredPercent_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, eventPurseRoute, handlers.SetEventPurse(nilPurseSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/events/"+validUUID+"/purse", map[string]any{
+		"entry_fee_cents": 2000,
+		"payouts":         []map[string]any{{"place": 1, "percent": 70}, {"place": 2, "percent": 40}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetEventPurse_DuplicatePlace_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, eventPurseRoute, handlers.SetEventPurse(nilPurseSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/events/"+validUUID+"/purse", map[string]any{
+		"payouts": []map[string]any{{"place": 1, "percent": 50}, {"place": 1, "percent": 20}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRecordEventPayment_InvalidUserID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, eventPaymentRoute, handlers.RecordEventPayment(nilPurseSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPut, "/events/"+validUUID+"/purse/payments/abc", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRecordEventPayment_NegativeAmount_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, eventPaymentRoute, handlers.RecordEventPayment(nilPurseSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/events/"+validUUID+"/purse/payments/"+validUUID, map[string]any{"amount_cents": -1})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestClearEventPayment_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodDelete, eventPaymentRoute, handlers.ClearEventPayment(nilPurseSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/events/"+validUUID+"/purse/payments/"+validUUID, nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetRoundPurse_InvalidRoundID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, roundPurseRoute, handlers.GetRoundPurse(nilPurseSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/rounds/not-a-uuid/purse", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetRoundPurse_ZeroPercent_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, roundPurseRoute, handlers.SetRoundPurse(nilPurseSvc()))
+	resp := doJSON(t, app, http.MethodPut, "/rounds/"+validUUID+"/purse", map[string]any{
+		"entry_fee_cents": 500,
+		"payouts":         []map[string]any{{"place": 1, "percent": 0}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRecordRoundPayment_InvalidUserID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, roundPaymentRoute, handlers.RecordRoundPayment(nilPurseSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPut, "/rounds/"+validUUID+"/purse/payments/abc", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestClearRoundPayment_InvalidRoundID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodDelete, roundPaymentRoute, handlers.ClearRoundPayment(nilPurseSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/rounds/nope/purse/payments/"+validUUID, nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
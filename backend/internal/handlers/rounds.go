@@ -17,8 +17,11 @@
 //	DELETE /api/v1/rounds/:roundId/groups/:groupId            → delete group
 //	POST   /api/v1/rounds/:roundId/groups/:groupId/members    → add member
 //	DELETE /api/v1/rounds/:roundId/groups/:groupId/members/:userId → remove member
+//	POST   /api/v1/rounds/:roundId/pairings/generate          → draw all groups (pairings.go)
+//	POST   /api/v1/rounds/:roundId/tee-times/generate         → tee times / shotgun holes for all groups (tee_sheet.go)
 //	GET    /api/v1/rounds/:roundId/teams                      → list Las Vegas teams
 //	POST   /api/v1/rounds/:roundId/teams                      → create team
+//	POST   /api/v1/rounds/:roundId/teams/generate             → handicap-balanced teams for every group (auto_teams.go)
 //	PUT    /api/v1/rounds/:roundId/teams/:teamId/members      → assign team members
 //	DELETE /api/v1/rounds/:roundId/teams/:teamId             → delete team
 package handlers
@@ -39,7 +42,7 @@ type GroupMemberResponse struct {
 	UserID        string  `json:"user_id"`
 	RoundPlayerID string  `json:"round_player_id"` // used for removal operations
 	DisplayName   string  `json:"display_name"`
-	Email         string  `json:"email"`
+	Email         *string `json:"email"` // null when the player hides it from the caller
 	AvatarURL     *string `json:"avatar_url"`
 	IsGuest       bool    `json:"is_guest"` // score-only guest player (no account); UI hides synthetic email
 }
@@ -79,8 +82,10 @@ type RoundDetailResponse struct {
 	// Best Ball toggle — only meaningful when ScoringFormat is "best_ball".
 	BestBallScoringBasis string `json:"best_ball_scoring_basis"`
 	// IsOrganizer is computed server-side so the client skips a separate permission query.
-	IsOrganizer bool            `json:"is_organizer"`
-	Groups      []GroupResponse `json:"groups"`
+	IsOrganizer   bool            `json:"is_organizer"`
+	Groups        []GroupResponse `json:"groups"`
+	CommentCount  int             `json:"comment_count"`
+	ReactionCount int             `json:"reaction_count"`
 }
 
 // MyRoundResponse extends a round summary with event context so the Rounds tab
@@ -101,6 +106,8 @@ type MyRoundResponse struct {
 	ScoringFormat string  `json:"scoring_format"`
 	RoundNumber   int     `json:"round_number"`
 	GroupCount    int     `json:"group_count"`
+	CommentCount  int     `json:"comment_count"`
+	ReactionCount int     `json:"reaction_count"`
 }
 
 // ─── Helpers ──────────────────────────────────────────────────────────────────
@@ -248,6 +255,8 @@ func writeRoundError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
 		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "player is already assigned to a group in this round"})
 	case errors.Is(err, services.ErrTeamFull):
 		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "team is full (max 2 players)"})
+	case errors.Is(err, services.ErrRoundStarted):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "round has already started"})
 	}
 	c.Locals("error_detail", tag+": "+err.Error())
 	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{jsonKeyError: fallbackMsg})
@@ -337,6 +346,8 @@ func GetMyRounds(svc *services.RoundService) fiber.Handler {
 				ScoringFormat: string(r.Round.ScoringFormat),
 				RoundNumber:   r.Round.RoundNumber,
 				GroupCount:    r.GroupCount,
+				CommentCount:  r.Engagement.CommentCount,
+				ReactionCount: r.Engagement.ReactionCount,
 			}
 		}
 		return c.JSON(out)
@@ -383,6 +394,8 @@ func GetRound(svc *services.RoundService) fiber.Handler {
 			BestBallScoringBasis: result.Round.BestBallScoringBasis,
 			IsOrganizer:          result.IsOrganizer,
 			Groups:               groupResponses,
+			CommentCount:         result.Engagement.CommentCount,
+			ReactionCount:        result.Engagement.ReactionCount,
 		})
 	}
 }
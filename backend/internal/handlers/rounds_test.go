@@ -369,21 +369,26 @@ func TestToGroupResponse_Empty(t *testing.T) {
 }
 
 func TestToGroupResponse_WithPlayers(t *testing.T) {
+	email := "a@example.com"
 	players := []services.GroupPlayerResult{
-		{UserID: "u1", RoundPlayerID: "rp1", DisplayName: "Alice", Email: "a@example.com"},
+		{UserID: "u1", RoundPlayerID: "rp1", DisplayName: "Alice", Email: &email},
+		{UserID: "u2", RoundPlayerID: "rp2", DisplayName: "Bob"},
 	}
 	got := handlers.ToGroupResponseExported("id-2", 2, nil, nil, 1, players)
-	require.Len(t, got.Players, 1)
+	require.Len(t, got.Players, 2)
 	assert.Equal(t, "Alice", got.Players[0].DisplayName)
+	assert.Equal(t, &email, got.Players[0].Email)
+	assert.Nil(t, got.Players[1].Email, "a hidden email stays null")
 }
 
 func TestToTeamResponse_WithMembers(t *testing.T) {
 	tid := uuid.MustParse(validUUID)
+	email := "a@example.com"
 	got := handlers.ToTeamResponseExported(services.TeamResult{
 		Team: models.Team{ID: tid, Name: "Team A"},
 		Members: []services.GroupPlayerResult{
-			{UserID: "u1", RoundPlayerID: "rp1", DisplayName: "Alice", Email: "a@example.com"},
-			{UserID: "u2", RoundPlayerID: "rp2", DisplayName: "Bob", Email: "b@example.com"},
+			{UserID: "u1", RoundPlayerID: "rp1", DisplayName: "Alice", Email: &email},
+			{UserID: "u2", RoundPlayerID: "rp2", DisplayName: "Bob"},
 		},
 	})
 	assert.Equal(t, validUUID, got.ID)
@@ -0,0 +1,123 @@
+// handlers/schedule.go
+// HTTP handlers for recurring league schedules. All business logic lives in
+// internal/services.EventScheduleService; errors map through writeScheduleError,
+// which falls back to writeEventError.
+//
+// Endpoints:
+//
+//	GET /api/v1/events/:id/schedule → the schedule template and its weeks (members only)
+//	PUT /api/v1/events/:id/schedule → save the template and regenerate future rounds (organizer only)
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request types ────────────────────────────────────────────────────────────
+
+// SaveEventScheduleRequest is the body for PUT /api/v1/events/:id/schedule.
+// Saving again regenerates the future rounds the schedule created; past,
+// started and completed rounds are left alone.
+type SaveEventScheduleRequest struct {
+	Weekday       *int     `json:"weekday"`    // 0 = Sunday … 6 = Saturday
+	StartDate     string   `json:"start_date"` // "YYYY-MM-DD"
+	EndDate       string   `json:"end_date"`   // "YYYY-MM-DD"
+	CourseID      string   `json:"course_id"`
+	TeeID         string   `json:"tee_id"`
+	Nines         string   `json:"nines"` // full (default) | front | back | alternate_front | alternate_back
+	ScoringFormat *string  `json:"scoring_format"`
+	SkipDates     []string `json:"skip_dates"` // "YYYY-MM-DD"; holidays, rain-outs booked ahead
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// writeScheduleError maps schedule errors to HTTP responses, deferring to
+// writeEventError for everything else.
+func writeScheduleError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	switch {
+	case errors.Is(err, services.ErrScheduleNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "event has no schedule"})
+	case errors.Is(err, services.ErrCourseNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "course not found"})
+	case errors.Is(err, services.ErrTeeNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "tee not found for this course"})
+	}
+	return writeEventError(c, err, tag, fallbackMsg)
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// GetEventSchedule returns a handler for GET /api/v1/events/:id/schedule.
+// Non-admins must be members of the event.
+func GetEventSchedule(svc *services.EventScheduleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		schedule, err := svc.Get(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writeScheduleError(c, err, "event.get_schedule", "failed to load schedule")
+		}
+		return c.JSON(schedule)
+	}
+}
+
+// SaveEventSchedule returns a handler for PUT /api/v1/events/:id/schedule.
+// Organizer-only; returns the saved schedule with its weeks.
+func SaveEventSchedule(svc *services.EventScheduleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		var req SaveEventScheduleRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		if req.Weekday == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "weekday is required"})
+		}
+		courseID, err := uuid.Parse(req.CourseID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid course_id"})
+		}
+		teeID, err := uuid.Parse(req.TeeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid tee_id"})
+		}
+
+		schedule, err := svc.Save(c.UserContext(), eventID, userID, userRole, services.EventScheduleInput{
+			Weekday:       *req.Weekday,
+			StartDate:     req.StartDate,
+			EndDate:       req.EndDate,
+			CourseID:      courseID,
+			TeeID:         teeID,
+			Nines:         req.Nines,
+			ScoringFormat: req.ScoringFormat,
+			SkipDates:     req.SkipDates,
+		})
+		if err != nil {
+			return writeScheduleError(c, err, "event.save_schedule", "failed to save schedule")
+		}
+		slog.InfoContext(c.UserContext(), "Event schedule saved",
+			"event_type_label", "event.schedule_saved",
+			"event_id", eventID.String(),
+			"week_count", len(schedule.Weeks),
+		)
+		return c.JSON(schedule)
+	}
+}
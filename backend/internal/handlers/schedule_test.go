@@ -0,0 +1,92 @@
+// schedule_test.go
+// Unit tests for the league schedule handlers in schedule.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation (including the
+// service's own input checks, which run before any DB call) return early, so a
+// nil-DB EventScheduleService is safe. Round generation is covered in
+// services/event_schedule_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Schedule -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+const scheduleRoute = "/events/:id/schedule"
+
+// nilScheduleSvc returns an EventScheduleService with no DB; only safe on paths
+// that fail validation first.
+func nilScheduleSvc() *services.EventScheduleService {
+	return services.NewEventScheduleService(nil, nilEventSvc(), nilRoundSvc())
+}
+
+// validScheduleBody is a schedule body that passes validation; tests override
+// one field at a time.
+func validScheduleBody() map[string]any {
+	return map[string]any{
+		"weekday":    3,
+		"start_date": "2027-04-07",
+		"end_date":   "2027-08-25",
+		"course_id":  validUUID,
+		"tee_id":     validUUID,
+		"nines":      "alternate_front",
+	}
+}
+
+func TestGetEventSchedule_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, scheduleRoute, handlers.GetEventSchedule(nilScheduleSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/"+validUUID+"/schedule", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetEventSchedule_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, scheduleRoute, handlers.GetEventSchedule(nilScheduleSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/not-a-uuid/schedule", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSaveEventSchedule_InvalidBody_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, scheduleRoute, handlers.SaveEventSchedule(nilScheduleSvc()))
+	req := httptest.NewRequest(http.MethodPut, "/events/"+validUUID+"/schedule", nil)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSaveEventSchedule_InvalidFields_BadRequest(t *testing.T) {
+	cases := map[string]func(body map[string]any){
+		"missing weekday":  func(b map[string]any) { delete(b, "weekday") },
+		"weekday too high": func(b map[string]any) { b["weekday"] = 7 },
+		"bad course_id":    func(b map[string]any) { b["course_id"] = "pebble" },
+		"bad tee_id":       func(b map[string]any) { b["tee_id"] = "blue" },
+		"bad start_date":   func(b map[string]any) { b["start_date"] = "04/07/2027" },
+		"end before start": func(b map[string]any) { b["end_date"] = "2027-03-01" },
+		"bad nines":        func(b map[string]any) { b["nines"] = "middle" },
+		"bad skip date":    func(b map[string]any) { b["skip_dates"] = []string{"July 4"} },
+		"no playing weeks": func(b map[string]any) { b["end_date"] = "2027-04-07"; b["weekday"] = 2 },
+		"season too long":  func(b map[string]any) { b["end_date"] = "2028-08-25" },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			app := newEventAppWithAuth(http.MethodPut, scheduleRoute, handlers.SaveEventSchedule(nilScheduleSvc()))
+			body := validScheduleBody()
+			mutate(body)
+			resp := doJSON(t, app, http.MethodPut, "/events/"+validUUID+"/schedule", body)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
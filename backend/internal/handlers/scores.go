@@ -6,10 +6,13 @@
 //
 // Endpoints:
 //
-//	GET /api/v1/rounds/:roundId/scorecard
-//	PUT /api/v1/rounds/:roundId/players/:roundPlayerId/handicap
-//	PUT /api/v1/rounds/:roundId/players/:roundPlayerId/scores
-//	PUT /api/v1/rounds/:roundId/players/:roundPlayerId/hole-stats
+//	GET  /api/v1/rounds/:roundId/scorecard
+//	PUT  /api/v1/rounds/:roundId/players/:roundPlayerId/handicap
+//	PUT  /api/v1/rounds/:roundId/players/:roundPlayerId/scores
+//	PUT  /api/v1/rounds/:roundId/players/:roundPlayerId/hole-stats
+//	POST /api/v1/rounds/:roundId/players/:roundPlayerId/attest
+//	GET  /api/v1/rounds/:roundId/players/:roundPlayerId/history
+//	POST /api/v1/rounds/:roundId/players/:roundPlayerId/history/:changeId/revert
 package handlers
 
 import (
@@ -41,11 +44,17 @@ type liveScoreMessage struct {
 // or a saturated hub simply means clients fall back to the 60s poll — a broadcast
 // never affects the save result and is called only after a successful save.
 func broadcastScoresUpdated(bc Broadcaster, roundID uuid.UUID) {
+	broadcastRoundMessage(bc, "scores_updated", roundID)
+}
+
+// broadcastRoundMessage pushes a liveScoreMessage of the given type to the
+// round's subscribers. Same best-effort contract as broadcastScoresUpdated.
+func broadcastRoundMessage(bc Broadcaster, msgType string, roundID uuid.UUID) {
 	if bc == nil {
 		return
 	}
 	// Marshal of this fixed struct cannot fail; the error is ignored deliberately.
-	data, _ := json.Marshal(liveScoreMessage{Type: "scores_updated", RoundID: roundID.String()})
+	data, _ := json.Marshal(liveScoreMessage{Type: msgType, RoundID: roundID.String()})
 	bc.BroadcastToRound(roundID.String(), data)
 }
 
@@ -80,12 +89,36 @@ func writeScoreError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
 	switch {
 	case errors.Is(err, services.ErrRoundNotFound):
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "round not found"})
+	case errors.Is(err, services.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "user not found"})
+	case errors.Is(err, services.ErrProfilePrivate), errors.Is(err, services.ErrStatsHidden):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: err.Error()})
 	case errors.Is(err, services.ErrRoundPlayerNotFound):
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "round player not found"})
+	case errors.Is(err, services.ErrScoreChangeNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "score change not found"})
+	case errors.Is(err, services.ErrDisputeNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "dispute not found"})
+	case errors.Is(err, services.ErrDisputeForbidden):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: "only players in this round can dispute a score"})
+	case errors.Is(err, services.ErrDisputeAlreadyOpen):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "this hole already has an open dispute"})
+	case errors.Is(err, services.ErrDisputeResolved):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "dispute is already resolved"})
 	case errors.Is(err, services.ErrScoreForbidden):
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: "not authorized to modify scores for this player"})
 	case errors.Is(err, services.ErrRoundNotActive):
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: "round is not active — scores can only be entered while the round is in progress"})
+	case errors.Is(err, services.ErrScoresLocked):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "event results are finalized — scores are locked"})
+	case errors.Is(err, services.ErrScorecardAttested):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "scorecard is attested — only an organizer can correct it"})
+	case errors.Is(err, services.ErrAttestForbidden):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: "only the player or a marker from their group can attest this card"})
+	case errors.Is(err, services.ErrScorecardIncomplete):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{jsonKeyError: "every hole must be scored before the card can be attested"})
+	case errors.Is(err, services.ErrPlayerHasScores):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "player has already entered scores"})
 	case errors.Is(err, services.ErrHandicapRequired):
 		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{jsonKeyError: "handicap must be set before entering scores for this round"})
 	}
@@ -222,3 +255,95 @@ func UpsertHoleStats(svc *services.ScoreService, bc Broadcaster) fiber.Handler {
 		return c.JSON(fiber.Map{"saved": saved})
 	}
 }
+
+// AttestScorecard returns a handler for POST .../attest.
+// Records the caller's signature on a complete card — as the player, or as a
+// marker from the same group. Once both have signed the card is completed.
+// Broadcasts "scores_updated" on success so scorecards refresh (bc may be nil).
+func AttestScorecard(svc *services.ScoreService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roundID, err := uuid.Parse(c.Params("roundId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round ID"})
+		}
+		roundPlayerID, err := uuid.Parse(c.Params("roundPlayerId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round player ID"})
+		}
+
+		userIDStr, _ := c.Locals("userID").(string)
+		callerID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{jsonKeyError: "invalid user ID"})
+		}
+
+		data, err := svc.AttestScorecard(c.UserContext(), roundID, roundPlayerID, callerID)
+		if err != nil {
+			return writeScoreError(c, err, "score.attest", "failed to attest scorecard")
+		}
+		broadcastScoresUpdated(bc, roundID)
+		return c.JSON(data)
+	}
+}
+
+// GetScoreHistory returns a handler for GET .../history.
+// Lists every recorded change to the player's scores, hole stats and handicap,
+// newest first. Privacy applies as on the scorecard: outside the round, a
+// player who hides their stats has their history withheld.
+func GetScoreHistory(svc *services.ScoreService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roundID, err := uuid.Parse(c.Params("roundId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round ID"})
+		}
+		roundPlayerID, err := uuid.Parse(c.Params("roundPlayerId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round player ID"})
+		}
+
+		// Same fallback as the scorecard: uuid.Nil only sees shared cards.
+		userIDStr, _ := c.Locals("userID").(string)
+		userRole, _ := c.Locals("userRole").(string)
+		callerID, _ := uuid.Parse(userIDStr)
+
+		history, err := svc.GetScoreHistory(c.UserContext(), roundID, roundPlayerID, callerID, userRole)
+		if err != nil {
+			return writeScoreError(c, err, "score.history", "failed to load score history")
+		}
+		return c.JSON(history)
+	}
+}
+
+// RevertScoreChange returns a handler for POST .../history/:changeId/revert.
+// Organizer-only: restores the hole to the value it held before the change and
+// returns the history entry recorded for the revert. Broadcasts on success.
+func RevertScoreChange(svc *services.ScoreService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roundID, err := uuid.Parse(c.Params("roundId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round ID"})
+		}
+		roundPlayerID, err := uuid.Parse(c.Params("roundPlayerId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round player ID"})
+		}
+		changeID, err := uuid.Parse(c.Params("changeId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid change ID"})
+		}
+
+		userIDStr, _ := c.Locals("userID").(string)
+		userRole, _ := c.Locals("userRole").(string)
+		callerID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{jsonKeyError: "invalid user ID"})
+		}
+
+		entry, err := svc.RevertScoreChange(c.UserContext(), roundID, roundPlayerID, changeID, callerID, userRole)
+		if err != nil {
+			return writeScoreError(c, err, "score.revert", "failed to revert score")
+		}
+		broadcastScoresUpdated(bc, roundID)
+		return c.JSON(entry)
+	}
+}
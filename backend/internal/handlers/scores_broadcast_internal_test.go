@@ -58,3 +58,18 @@ func TestBroadcastScoresUpdated_NilBroadcaster(t *testing.T) {
 	// Must not panic.
 	broadcastScoresUpdated(nil, uuid.New())
 }
+
+// TestBroadcastRoundMessage_Type verifies the generic helper carries the given
+// message type (used for "disputes_updated").
+func TestBroadcastRoundMessage_Type(t *testing.T) {
+	bc := &fakeBroadcaster{}
+	broadcastRoundMessage(bc, "disputes_updated", uuid.New())
+
+	var msg liveScoreMessage
+	if err := json.Unmarshal(bc.data, &msg); err != nil {
+		t.Fatalf("payload is not valid JSON: %v", err)
+	}
+	if msg.Type != "disputes_updated" {
+		t.Errorf("type = %q, want disputes_updated", msg.Type)
+	}
+}
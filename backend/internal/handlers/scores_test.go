@@ -9,6 +9,7 @@
 //   - Empty/missing request body → 400 (handler checks before service call)
 //   - Invalid enum fields (GIR, direction, club) → 400 via nilScoreSvc() with auth injected
 //   - Missing auth context → 401 (uuid.Parse on empty userID local)
+//   - AttestScorecard, GetScoreHistory, RevertScoreChange: invalid UUIDs → 400, missing auth → 401
 //
 // Handicap unit tests (HandicapStrokes, EffectiveCourseHandicap) moved to
 // services/handicap_test.go — they have no dependency on handlers or HTTP.
@@ -279,6 +280,78 @@ func TestUpsertHoleStats_NoUserID(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 }
 
+// ─── AttestScorecard ──────────────────────────────────────────────────────────
+
+func TestAttestScorecard_InvalidRoundUUID(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost,
+		"/rounds/:roundId/players/:roundPlayerId/attest",
+		handlers.AttestScorecard(nil, nil))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost,
+		"/rounds/not-a-uuid/players/"+validUUID+"/attest", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAttestScorecard_InvalidPlayerUUID(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost,
+		"/rounds/:roundId/players/:roundPlayerId/attest",
+		handlers.AttestScorecard(nil, nil))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost,
+		"/rounds/"+validUUID+"/players/not-a-uuid/attest", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestAttestScorecard_NoUserID verifies that missing auth returns 401.
+func TestAttestScorecard_NoUserID(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost,
+		"/rounds/:roundId/players/:roundPlayerId/attest",
+		handlers.AttestScorecard(nil, nil))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost,
+		"/rounds/"+validUUID+"/players/"+validUUID+"/attest", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+// ─── GetScoreHistory / RevertScoreChange ──────────────────────────────────────
+
+func TestGetScoreHistory_InvalidPlayerUUID(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet,
+		"/rounds/:roundId/players/:roundPlayerId/history",
+		handlers.GetScoreHistory(nil))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet,
+		"/rounds/"+validUUID+"/players/not-a-uuid/history", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRevertScoreChange_InvalidChangeUUID(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost,
+		"/rounds/:roundId/players/:roundPlayerId/history/:changeId/revert",
+		handlers.RevertScoreChange(nil, nil))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost,
+		"/rounds/"+validUUID+"/players/"+validUUID+"/history/not-a-uuid/revert", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestRevertScoreChange_NoUserID verifies that missing auth returns 401.
+func TestRevertScoreChange_NoUserID(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost,
+		"/rounds/:roundId/players/:roundPlayerId/history/:changeId/revert",
+		handlers.RevertScoreChange(nil, nil))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost,
+		"/rounds/"+validUUID+"/players/"+validUUID+"/history/"+validUUID+"/revert", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
 // ─── writeScoreError status mapping ──────────────────────────────────────────
 
 // TestWriteScoreError_StatusMapping locks in the status code each known service
@@ -292,8 +365,17 @@ func TestWriteScoreError_StatusMapping(t *testing.T) {
 		{"validation error", &services.ValidationError{Field: "hole_number", Message: "bad"}, http.StatusBadRequest},
 		{"round not found", services.ErrRoundNotFound, http.StatusNotFound},
 		{"round player not found", services.ErrRoundPlayerNotFound, http.StatusNotFound},
+		{"score change not found", services.ErrScoreChangeNotFound, http.StatusNotFound},
+		{"dispute not found", services.ErrDisputeNotFound, http.StatusNotFound},
+		{"dispute forbidden", services.ErrDisputeForbidden, http.StatusForbidden},
+		{"dispute already open", services.ErrDisputeAlreadyOpen, http.StatusConflict},
+		{"dispute resolved", services.ErrDisputeResolved, http.StatusConflict},
 		{"score forbidden", services.ErrScoreForbidden, http.StatusForbidden},
 		{"round not active", services.ErrRoundNotActive, http.StatusForbidden},
+		{"scores locked", services.ErrScoresLocked, http.StatusConflict},
+		{"scorecard attested", services.ErrScorecardAttested, http.StatusConflict},
+		{"attest forbidden", services.ErrAttestForbidden, http.StatusForbidden},
+		{"scorecard incomplete", services.ErrScorecardIncomplete, http.StatusUnprocessableEntity},
 		{"handicap required", services.ErrHandicapRequired, http.StatusUnprocessableEntity},
 		{"unrecognised → 500", errors.New("database exploded"), http.StatusInternalServerError},
 	}
@@ -327,8 +409,17 @@ func TestWriteScoreError_NoDetailFor4xx(t *testing.T) {
 		&services.ValidationError{Field: "x", Message: "bad"},
 		services.ErrRoundNotFound,
 		services.ErrRoundPlayerNotFound,
+		services.ErrScoreChangeNotFound,
+		services.ErrDisputeNotFound,
+		services.ErrDisputeForbidden,
+		services.ErrDisputeAlreadyOpen,
+		services.ErrDisputeResolved,
 		services.ErrScoreForbidden,
 		services.ErrRoundNotActive,
+		services.ErrScoresLocked,
+		services.ErrScorecardAttested,
+		services.ErrAttestForbidden,
+		services.ErrScorecardIncomplete,
 		services.ErrHandicapRequired,
 	}
 	for _, e := range errs {
@@ -0,0 +1,199 @@
+// handlers/substitutes.go
+// HTTP handlers for league substitutes: the event's substitute roster and the
+// in-round swap of a registered player for a sub. Business logic lives in
+// internal/services (EventService roster methods, RoundService.ReplacePlayer);
+// errors map through writeSubstituteError, which falls back to writeEventError
+// or writeRoundError.
+//
+// Endpoints:
+//
+//	GET    /api/v1/events/:id/substitutes                              → the substitute roster (members only)
+//	POST   /api/v1/events/:id/substitutes                              → add a user to the roster (organizer only)
+//	DELETE /api/v1/events/:id/substitutes/:userId                      → take a user off the roster (organizer only)
+//	POST   /api/v1/rounds/:roundId/players/:roundPlayerId/replace      → swap a round player for a sub (organizer only)
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request / response types ─────────────────────────────────────────────────
+
+// AddSubstituteRequest is the body for POST /api/v1/events/:id/substitutes.
+type AddSubstituteRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// ReplacePlayerRequest is the body for POST .../players/:roundPlayerId/replace.
+// Passing the user the spot belongs to swaps them back in.
+type ReplacePlayerRequest struct {
+	SubstituteUserID string `json:"substitute_user_id"`
+}
+
+// ReplacePlayerResponse is the replaced round player. group_id is null when
+// the player isn't in a group yet; replaced_user_id is the member whose spot
+// the sub is playing, null once the original player is back in.
+type ReplacePlayerResponse struct {
+	GroupMemberResponse
+	GroupID        *string `json:"group_id"`
+	ReplacedUserID *string `json:"replaced_user_id"`
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// writeSubstituteError maps substitute errors to HTTP responses, deferring to
+// fallback (writeEventError or writeRoundError) for everything else.
+func writeSubstituteError(c *fiber.Ctx, err error, tag, fallbackMsg string, fallback func(*fiber.Ctx, error, string, string) error) error {
+	switch {
+	case errors.Is(err, services.ErrNotSubstitute):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "user is not a substitute for this event"})
+	case errors.Is(err, services.ErrRoundPlayerNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "round player not found"})
+	case errors.Is(err, services.ErrSubstituteHasRounds):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "substitute is playing in a round; swap the original player back first"})
+	case errors.Is(err, services.ErrSubstituteInRound):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "substitute is already playing in this round"})
+	case errors.Is(err, services.ErrPlayerHasScores):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "player has already entered scores"})
+	case errors.Is(err, services.ErrRoundCompleted):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "round is completed"})
+	}
+	return fallback(c, err, tag, fallbackMsg)
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// GetEventSubstitutes returns a handler for GET /api/v1/events/:id/substitutes.
+func GetEventSubstitutes(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		subs, err := svc.ListSubstitutes(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writeSubstituteError(c, err, "event.list_substitutes", "failed to load substitutes", writeEventError)
+		}
+		out := make([]MemberResponse, len(subs))
+		for i, m := range subs {
+			out[i] = buildMemberResponse(m)
+		}
+		return c.JSON(out)
+	}
+}
+
+// AddEventSubstitute returns a handler for POST /api/v1/events/:id/substitutes.
+func AddEventSubstitute(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		var req AddSubstituteRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		targetUserID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid user_id"})
+		}
+		sub, err := svc.AddSubstitute(c.UserContext(), eventID, userID, userRole, targetUserID)
+		if err != nil {
+			return writeSubstituteError(c, err, "event.add_substitute", "failed to add substitute", writeEventError)
+		}
+		slog.InfoContext(c.UserContext(), "Substitute added",
+			"event_type_label", "event.substitute_added",
+			"event_id", eventID.String(),
+			"user_id", targetUserID.String(),
+		)
+		return c.Status(fiber.StatusCreated).JSON(buildMemberResponse(sub))
+	}
+}
+
+// RemoveEventSubstitute returns a handler for DELETE /api/v1/events/:id/substitutes/:userId.
+func RemoveEventSubstitute(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		targetUserID, err := uuid.Parse(c.Params("userId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid user ID in path"})
+		}
+		if err := svc.RemoveSubstitute(c.UserContext(), eventID, userID, userRole, targetUserID); err != nil {
+			return writeSubstituteError(c, err, "event.remove_substitute", "failed to remove substitute", writeEventError)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// ReplaceRoundPlayer returns a handler for POST
+// /api/v1/rounds/:roundId/players/:roundPlayerId/replace. The sub keeps the
+// player's group slot and team.
+func ReplaceRoundPlayer(svc *services.RoundService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		roundPlayerID, err := uuid.Parse(c.Params("roundPlayerId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid round player ID"})
+		}
+		var req ReplacePlayerRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		subID, err := uuid.Parse(req.SubstituteUserID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid substitute_user_id"})
+		}
+
+		res, err := svc.ReplacePlayer(c.UserContext(), roundID, roundPlayerID, userID, userRole, subID)
+		if err != nil {
+			return writeSubstituteError(c, err, "round.replace_player", "failed to replace player", writeRoundError)
+		}
+		out := ReplacePlayerResponse{GroupMemberResponse: GroupMemberResponse{
+			UserID: res.Player.UserID, RoundPlayerID: res.Player.RoundPlayerID,
+			DisplayName: res.Player.DisplayName, Email: res.Player.Email,
+			AvatarURL: res.Player.AvatarURL, IsGuest: res.Player.IsGuest,
+		}}
+		if res.GroupID != nil {
+			id := res.GroupID.String()
+			out.GroupID = &id
+		}
+		if res.ReplacedUserID != nil {
+			id := res.ReplacedUserID.String()
+			out.ReplacedUserID = &id
+		}
+		slog.InfoContext(c.UserContext(), "Round player replaced",
+			"event_type_label", "round.player_replaced",
+			"round_id", roundID.String(),
+			"round_player_id", roundPlayerID.String(),
+			"user_id", res.Player.UserID,
+		)
+		return c.JSON(out)
+	}
+}
@@ -0,0 +1,81 @@
+// substitutes_test.go
+// Unit tests for the substitute handlers in substitutes.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation return before
+// any service call, so nil-DB services are safe. The roster, replacement and
+// standings crediting are covered in services/event_substitutes_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Substitute -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+)
+
+const (
+	substitutesRoute   = "/events/:id/substitutes"
+	substituteRoute    = "/events/:id/substitutes/:userId"
+	replacePlayerRoute = "/rounds/:roundId/players/:roundPlayerId/replace"
+)
+
+func TestGetEventSubstitutes_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, substitutesRoute, handlers.GetEventSubstitutes(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/"+validUUID+"/substitutes", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetEventSubstitutes_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, substitutesRoute, handlers.GetEventSubstitutes(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/not-a-uuid/substitutes", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAddEventSubstitute_InvalidUserID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, substitutesRoute, handlers.AddEventSubstitute(nilEventSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/events/"+validUUID+"/substitutes", map[string]any{"user_id": "sam"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRemoveEventSubstitute_InvalidUserID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodDelete, substituteRoute, handlers.RemoveEventSubstitute(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/events/"+validUUID+"/substitutes/sam", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestReplaceRoundPlayer_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, replacePlayerRoute, handlers.ReplaceRoundPlayer(nilRoundSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/players/"+validUUID+"/replace",
+		map[string]any{"substitute_user_id": validUUID})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestReplaceRoundPlayer_InvalidParams_BadRequest(t *testing.T) {
+	cases := map[string]struct {
+		path string
+		body map[string]any
+	}{
+		"bad round id":        {"/rounds/nope/players/" + validUUID + "/replace", map[string]any{"substitute_user_id": validUUID}},
+		"bad round player id": {"/rounds/" + validUUID + "/players/nope/replace", map[string]any{"substitute_user_id": validUUID}},
+		"bad substitute id":   {"/rounds/" + validUUID + "/players/" + validUUID + "/replace", map[string]any{"substitute_user_id": "sam"}},
+		"missing substitute":  {"/rounds/" + validUUID + "/players/" + validUUID + "/replace", map[string]any{}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			app := newEventAppWithAuth(http.MethodPost, replacePlayerRoute, handlers.ReplaceRoundPlayer(nilRoundSvc()))
+			resp := doJSON(t, app, http.MethodPost, tc.path, tc.body)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
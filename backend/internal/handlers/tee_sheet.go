@@ -0,0 +1,92 @@
+// handlers/tee_sheet.go
+// HTTP handler for the tee sheet generator. The layout itself lives in
+// internal/services.RoundService.GenerateTeeTimes; errors map through
+// writeRoundError.
+//
+// Endpoints:
+//
+//	POST /api/v1/rounds/:roundId/tee-times/generate → assign every group a tee time and starting hole (organizer only)
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Request / response types ─────────────────────────────────────────────────
+
+// GenerateTeeTimesRequest is the body for POST /api/v1/rounds/:roundId/tee-times/generate.
+type GenerateTeeTimesRequest struct {
+	Mode            string `json:"mode"`             // interval (default) | shotgun
+	FirstTeeTime    string `json:"first_tee_time"`   // "HH:MM" or "H:MM AM/PM"; the shotgun time in shotgun mode
+	IntervalMinutes int    `json:"interval_minutes"` // interval only; default 10
+	SplitTee        bool   `json:"split_tee"`        // interval only; alternate the 1st and 10th tees
+	ShotgunHoles    []int  `json:"shotgun_holes"`    // shotgun only; one hole per group, each used at most twice
+	Preview         bool   `json:"preview"`
+}
+
+// TeeSheetGroupResponse is a group with its start label ("1", "10", "4A", "4B").
+type TeeSheetGroupResponse struct {
+	GroupResponse
+	Label string `json:"label"`
+}
+
+// TeeSheetResponse is the response for POST /api/v1/rounds/:roundId/tee-times/generate.
+type TeeSheetResponse struct {
+	Mode    string                  `json:"mode"`
+	Preview bool                    `json:"preview"`
+	Groups  []TeeSheetGroupResponse `json:"groups"`
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// GenerateTeeTimes returns a handler for POST /api/v1/rounds/:roundId/tee-times/generate.
+// Organizer-only, and only while the round is scheduled. Responds 200 with the
+// tee sheet, saved unless preview is set.
+func GenerateTeeTimes(svc *services.RoundService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		callerID, callerRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, ok := parseRoundID(c)
+		if !ok {
+			return nil
+		}
+		var req GenerateTeeTimesRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+
+		result, err := svc.GenerateTeeTimes(c.UserContext(), roundID, callerID, callerRole, services.GenerateTeeTimesInput{
+			Mode:            req.Mode,
+			FirstTeeTime:    req.FirstTeeTime,
+			IntervalMinutes: req.IntervalMinutes,
+			SplitTee:        req.SplitTee,
+			ShotgunHoles:    req.ShotgunHoles,
+			Preview:         req.Preview,
+		})
+		if err != nil {
+			return writeRoundError(c, err, "round.generate_tee_times", "failed to generate tee times")
+		}
+
+		out := TeeSheetResponse{Mode: result.Mode, Preview: result.Preview, Groups: make([]TeeSheetGroupResponse, len(result.Groups))}
+		for i, g := range result.Groups {
+			out.Groups[i] = TeeSheetGroupResponse{
+				GroupResponse: toGroupResponse(g.Group.ID.String(), g.Group.GroupNumber, g.Group.Name, g.Group.TeeTime, g.Group.StartingHole, g.Players),
+				Label:         g.Label,
+			}
+		}
+		if !result.Preview {
+			slog.InfoContext(c.UserContext(), "Tee times generated",
+				"event_type_label", "round.tee_times_generated",
+				"round_id", roundID.String(),
+				"mode", result.Mode,
+				"group_count", len(result.Groups),
+			)
+		}
+		return c.JSON(out)
+	}
+}
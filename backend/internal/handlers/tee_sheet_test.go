@@ -0,0 +1,59 @@
+// tee_sheet_test.go
+// Unit tests for the tee sheet handler in tee_sheet.go.
+//
+// Strategy: Tier 1 only — auth, path-param and input validation return before
+// any DB call, so a nil-DB RoundService is safe. Layouts are covered in
+// services/round_tee_times_internal_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run TeeTimes -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+)
+
+const teeTimesRoute = "/rounds/:roundId/tee-times/generate"
+
+func TestGenerateTeeTimes_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, teeTimesRoute, handlers.GenerateTeeTimes(nilRoundSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/rounds/"+validUUID+"/tee-times/generate", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGenerateTeeTimes_InvalidRoundID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, teeTimesRoute, handlers.GenerateTeeTimes(nilRoundSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/not-a-uuid/tee-times/generate", map[string]any{"first_tee_time": "08:00"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGenerateTeeTimes_UnknownMode_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, teeTimesRoute, handlers.GenerateTeeTimes(nilRoundSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/tee-times/generate", map[string]any{
+		"mode": "crossover", "first_tee_time": "08:00",
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGenerateTeeTimes_MissingFirstTeeTime_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, teeTimesRoute, handlers.GenerateTeeTimes(nilRoundSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/tee-times/generate", map[string]any{"mode": "shotgun"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGenerateTeeTimes_IntervalTooLong_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, teeTimesRoute, handlers.GenerateTeeTimes(nilRoundSvc()))
+	resp := doJSON(t, app, http.MethodPost, "/rounds/"+validUUID+"/tee-times/generate", map[string]any{
+		"first_tee_time": "7:30 AM", "interval_minutes": 90,
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
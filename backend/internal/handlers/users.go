@@ -6,13 +6,13 @@
 // Endpoints:
 //
 //	GET    /api/v1/me                                — caller's own profile (includes role)
-//	GET    /api/v1/users?q=                          — search users by name or email
+//	GET    /api/v1/users?q=                          — search users by name or email (privacy applies)
 //	GET    /api/v1/users/following                   — list users the caller follows
 //	GET    /api/v1/users/me/scorecard-settings       — caller's stat visibility preferences
 //	PATCH  /api/v1/users/me/scorecard-settings       — update stat visibility preferences
-//	GET    /api/v1/users/:userId                     — public profile for any user
-//	GET    /api/v1/users/:userId/stats               — computed career stats for any user
-//	GET    /api/v1/users/:userId/rounds              — last 20 completed rounds for a user
+//	GET    /api/v1/users/:userId                     — profile for any user (restricted per their privacy settings)
+//	GET    /api/v1/users/:userId/stats               — computed career stats, if the user shares them
+//	GET    /api/v1/users/:userId/rounds              — last 20 completed rounds for a user, if their profile is visible
 //	GET    /api/v1/users/:userId/scorecards          — batched scorecards for those rounds (stats screen)
 //	POST   /api/v1/users/:userId/follow              — follow a user
 //	DELETE /api/v1/users/:userId/follow              — unfollow a user
@@ -44,6 +44,8 @@ func writeUserError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "cannot follow yourself"})
 	case errors.Is(err, services.ErrAlreadyFollowing):
 		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "already following"})
+	case errors.Is(err, services.ErrProfilePrivate), errors.Is(err, services.ErrStatsHidden):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{jsonKeyError: err.Error()})
 	}
 	c.Locals("error_detail", tag+": "+err.Error())
 	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{jsonKeyError: fallbackMsg})
@@ -174,7 +176,8 @@ func GetFollowing(svc *services.UserService) fiber.Handler {
 func GetUserStats(svc *services.UserService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		callerIDStr, _ := c.Locals("userID").(string)
-		if _, err := uuid.Parse(callerIDStr); err != nil {
+		callerID, err := uuid.Parse(callerIDStr)
+		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{jsonKeyError: msgUnauthorized})
 		}
 
@@ -183,7 +186,7 @@ func GetUserStats(svc *services.UserService) fiber.Handler {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid user ID"})
 		}
 
-		data, err := svc.GetUserStats(c.UserContext(), targetID, c.Query("filter", "all_time"))
+		data, err := svc.GetUserStats(c.UserContext(), callerID, targetID, c.Query("filter", "all_time"))
 		if err != nil {
 			return writeUserError(c, err, "user.get_stats", "failed to load user stats")
 		}
@@ -195,7 +198,8 @@ func GetUserStats(svc *services.UserService) fiber.Handler {
 func GetUserRounds(svc *services.UserService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		callerIDStr, _ := c.Locals("userID").(string)
-		if _, err := uuid.Parse(callerIDStr); err != nil {
+		callerID, err := uuid.Parse(callerIDStr)
+		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{jsonKeyError: msgUnauthorized})
 		}
 
@@ -204,7 +208,7 @@ func GetUserRounds(svc *services.UserService) fiber.Handler {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid user ID"})
 		}
 
-		results, err := svc.GetUserRounds(c.UserContext(), targetID)
+		results, err := svc.GetUserRounds(c.UserContext(), callerID, targetID)
 		if err != nil {
 			return writeUserError(c, err, "user.get_rounds", "failed to load user rounds")
 		}
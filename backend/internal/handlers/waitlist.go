@@ -0,0 +1,150 @@
+// handlers/waitlist.go
+// HTTP handlers for event capacity and the waitlist. Business logic lives in
+// internal/services (event_waitlist.go); errors map through writeWaitlistError,
+// which falls back to writeEventError.
+//
+// Players join the waitlist through POST /events/:id/request-join once an
+// event is at max_players; when a spot opens they are registered, or offered
+// the spot to accept within the event's waitlist_offer_hours.
+//
+// Endpoints:
+//
+//	GET    /api/v1/events/:id/waitlist         → the waitlist in queue order (organizer only)
+//	POST   /api/v1/events/:id/waitlist/accept  → take the spot you were offered
+//	POST   /api/v1/events/:id/withdraw         → leave the event, its waitlist, or your join request
+//	GET    /api/v1/users/me/waitlist           → events you're waitlisted for, with your position
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// ─── Response types ───────────────────────────────────────────────────────────
+
+// WaitlistEntryResponse is one player on a waitlist. offer_expires_at is set
+// while a spot is being held for them.
+type WaitlistEntryResponse struct {
+	EventID        string         `json:"event_id"`
+	EventName      string         `json:"event_name"`
+	Position       int            `json:"position"` // 1 = next in line
+	Member         MemberResponse `json:"member"`
+	WaitlistedAt   *string        `json:"waitlisted_at"`    // RFC 3339
+	OfferExpiresAt *string        `json:"offer_expires_at"` // RFC 3339
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// writeWaitlistError maps waitlist errors to HTTP responses, deferring to
+// writeEventError for everything else.
+func writeWaitlistError(c *fiber.Ctx, err error, tag, fallbackMsg string) error {
+	switch {
+	case errors.Is(err, services.ErrNotWaitlisted):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{jsonKeyError: "not on the waitlist for this event"})
+	case errors.Is(err, services.ErrNoWaitlistOffer):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{jsonKeyError: "no spot has been offered"})
+	}
+	return writeEventError(c, err, tag, fallbackMsg)
+}
+
+// buildWaitlistResponses converts waitlist entries into their JSON shape.
+func buildWaitlistResponses(entries []services.WaitlistEntry) []WaitlistEntryResponse {
+	out := make([]WaitlistEntryResponse, len(entries))
+	for i, e := range entries {
+		out[i] = WaitlistEntryResponse{
+			EventID:        e.Player.EventID.String(),
+			EventName:      e.Event.Name,
+			Position:       e.Position,
+			Member:         buildMemberResponse(services.EventMemberItem{Player: e.Player, User: e.User}),
+			WaitlistedAt:   services.FormatTimestamp(e.Player.WaitlistedAt),
+			OfferExpiresAt: services.FormatTimestamp(e.Player.OfferExpiresAt),
+		}
+	}
+	return out
+}
+
+// ─── Handlers ─────────────────────────────────────────────────────────────────
+
+// GetEventWaitlist returns a handler for GET /api/v1/events/:id/waitlist.
+func GetEventWaitlist(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		entries, err := svc.ListWaitlist(c.UserContext(), eventID, userID, userRole)
+		if err != nil {
+			return writeWaitlistError(c, err, "event.list_waitlist", "failed to load waitlist")
+		}
+		return c.JSON(buildWaitlistResponses(entries))
+	}
+}
+
+// AcceptWaitlistOffer returns a handler for POST /api/v1/events/:id/waitlist/accept.
+func AcceptWaitlistOffer(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		member, err := svc.AcceptWaitlistOffer(c.UserContext(), eventID, userID)
+		if err != nil {
+			return writeWaitlistError(c, err, "event.accept_waitlist_offer", "failed to accept spot")
+		}
+		slog.InfoContext(c.UserContext(), "Waitlist offer accepted",
+			"event_type_label", "event.waitlist_accepted",
+			"event_id", eventID.String(),
+			"user_id", userID.String(),
+		)
+		return c.JSON(buildMemberResponse(member))
+	}
+}
+
+// WithdrawFromEvent returns a handler for POST /api/v1/events/:id/withdraw.
+func WithdrawFromEvent(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		eventID, ok := parseEventID(c)
+		if !ok {
+			return nil
+		}
+		if err := svc.WithdrawFromEvent(c.UserContext(), eventID, userID); err != nil {
+			return writeWaitlistError(c, err, "event.withdraw", "failed to withdraw from event")
+		}
+		slog.InfoContext(c.UserContext(), "Member withdrew from event",
+			"event_type_label", "event.member_withdrawn",
+			"event_id", eventID.String(),
+			"user_id", userID.String(),
+		)
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// GetMyWaitlists returns a handler for GET /api/v1/users/me/waitlist.
+func GetMyWaitlists(svc *services.EventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		entries, err := svc.ListMyWaitlists(c.UserContext(), userID)
+		if err != nil {
+			return writeWaitlistError(c, err, "event.list_my_waitlists", "failed to load waitlists")
+		}
+		return c.JSON(buildWaitlistResponses(entries))
+	}
+}
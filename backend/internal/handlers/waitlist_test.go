@@ -0,0 +1,64 @@
+// waitlist_test.go
+// Unit tests for the waitlist handlers in waitlist.go.
+//
+// Strategy: Tier 1 only — auth and path-param validation return before any DB
+// access, so nil-DB services are safe. Capacity, queueing and promotion are
+// covered in services/event_waitlist_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run Waitlist -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+)
+
+func TestGetEventWaitlist_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, "/events/:id/waitlist", handlers.GetEventWaitlist(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/"+validUUID+"/waitlist", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetEventWaitlist_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodGet, "/events/:id/waitlist", handlers.GetEventWaitlist(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/events/not-a-uuid/waitlist", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAcceptWaitlistOffer_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, "/events/:id/waitlist/accept", handlers.AcceptWaitlistOffer(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/events/not-a-uuid/waitlist/accept", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestWithdrawFromEvent_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPost, "/events/:id/withdraw", handlers.WithdrawFromEvent(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/events/"+validUUID+"/withdraw", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestWithdrawFromEvent_InvalidEventID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPost, "/events/:id/withdraw", handlers.WithdrawFromEvent(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/events/not-a-uuid/withdraw", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetMyWaitlists_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodGet, "/users/me/waitlist", handlers.GetMyWaitlists(nilEventSvc()))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users/me/waitlist", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
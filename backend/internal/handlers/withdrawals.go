@@ -0,0 +1,78 @@
+// handlers/withdrawals.go
+// HTTP handlers for taking a player out of a round's results (WD, DQ, NC) and
+// reinstating them. All business logic lives in internal/services.ScoreService
+// (score_withdrawals.go); errors map through writeScoreError, shared with the
+// score handlers.
+//
+// Either change moves the player on the leaderboard, so both push a
+// "scores_updated" message to the round's WebSocket subscribers.
+//
+// Endpoints:
+//
+//	PUT    /api/v1/rounds/:roundId/players/:roundPlayerId/status → withdraw, disqualify or mark no card, with a reason (organizer only)
+//	DELETE /api/v1/rounds/:roundId/players/:roundPlayerId/status → reinstate the player in the results (organizer only)
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// WithdrawPlayerRequest is the body for PUT .../players/:roundPlayerId/status.
+// Status is "withdrawn", "disqualified" or "no_card"; Reason is required.
+type WithdrawPlayerRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// WithdrawPlayer returns a handler for PUT .../players/:roundPlayerId/status.
+func WithdrawPlayer(svc *services.ScoreService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, roundPlayerID, ok := parseRoundPlayerPath(c)
+		if !ok {
+			return nil
+		}
+		var req WithdrawPlayerRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{jsonKeyError: "invalid request body"})
+		}
+		data, err := svc.WithdrawPlayer(c.UserContext(), roundID, roundPlayerID, userID, userRole, req.Status, req.Reason)
+		if err != nil {
+			return writeScoreError(c, err, "score.withdraw_player", "failed to update player status")
+		}
+		slog.InfoContext(c.UserContext(), "Player out of results",
+			"event_type_label", "round.player_withdrawn",
+			"round_id", roundID.String(),
+			"round_player_id", roundPlayerID.String(),
+			"status", data.Status,
+		)
+		broadcastScoresUpdated(bc, roundID)
+		return c.JSON(data)
+	}
+}
+
+// ReinstatePlayer returns a handler for DELETE .../players/:roundPlayerId/status.
+func ReinstatePlayer(svc *services.ScoreService, bc Broadcaster) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, userRole, ok := authUser(c)
+		if !ok {
+			return nil
+		}
+		roundID, roundPlayerID, ok := parseRoundPlayerPath(c)
+		if !ok {
+			return nil
+		}
+		data, err := svc.ReinstatePlayer(c.UserContext(), roundID, roundPlayerID, userID, userRole)
+		if err != nil {
+			return writeScoreError(c, err, "score.reinstate_player", "failed to reinstate player")
+		}
+		broadcastScoresUpdated(bc, roundID)
+		return c.JSON(data)
+	}
+}
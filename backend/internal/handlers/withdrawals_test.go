@@ -0,0 +1,52 @@
+// withdrawals_test.go
+// Unit tests for the WD/DQ/NC handlers in withdrawals.go.
+//
+// Strategy: Tier 1 only — auth, path-param and body validation return before
+// any service call, so a nil-DB ScoreService is safe. Ranking and handicap
+// effects are covered in services/score_withdrawals_test.go.
+//
+// Run:
+//
+//	go test ./internal/handlers/ -run 'WithdrawPlayer|ReinstatePlayer' -v
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/handlers"
+)
+
+const playerStatusRoute = "/rounds/:roundId/players/:roundPlayerId/status"
+
+func TestWithdrawPlayer_MissingAuth_Unauthorized(t *testing.T) {
+	app := newSingleRouteApp(http.MethodPut, playerStatusRoute, handlers.WithdrawPlayer(nilScoreSvc(), nil))
+	resp := doJSON(t, app, http.MethodPut, "/rounds/"+validUUID+"/players/"+validUUID+"/status",
+		map[string]any{"status": "withdrawn", "reason": "injury"})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestWithdrawPlayer_InvalidStatus_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, playerStatusRoute, handlers.WithdrawPlayer(nilScoreSvc(), nil))
+	resp := doJSON(t, app, http.MethodPut, "/rounds/"+validUUID+"/players/"+validUUID+"/status",
+		map[string]any{"status": "completed", "reason": "done"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestWithdrawPlayer_MissingReason_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodPut, playerStatusRoute, handlers.WithdrawPlayer(nilScoreSvc(), nil))
+	resp := doJSON(t, app, http.MethodPut, "/rounds/"+validUUID+"/players/"+validUUID+"/status",
+		map[string]any{"status": "disqualified", "reason": "  "})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestReinstatePlayer_InvalidRoundPlayerID_BadRequest(t *testing.T) {
+	app := newEventAppWithAuth(http.MethodDelete, playerStatusRoute, handlers.ReinstatePlayer(nilScoreSvc(), nil))
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/rounds/"+validUUID+"/players/bob/status", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
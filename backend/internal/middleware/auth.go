@@ -20,6 +20,7 @@ import (
 	"github.com/MicahParks/keyfunc/v3"
 	"github.com/trentd187/golf-league/internal/config"
 	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
 
 	"gorm.io/gorm"
 )
@@ -74,7 +75,8 @@ func validateToken(tokenStr string, keyfn jwt.Keyfunc) (*Claims, error) {
 // Auth returns a Fiber middleware handler that:
 //  1. Validates the JWT from the "Authorization: Bearer <token>" header.
 //  2. Finds the matching user in our database (or creates one on first visit).
-//  3. Syncs the user's email from the JWT into the database.
+//  3. Syncs the user's email from the JWT into the database, then resolves any
+//     event invites addressed to a new or changed email (services.ResolveEmailInvites).
 //  4. Stores the user's internal UUID and role in c.Locals for downstream handlers.
 //
 // The JWKS key function is initialized once here (at server startup) via a closure
@@ -181,6 +183,17 @@ func MakeAuthHandler(keyfn jwt.Keyfunc, db *gorm.DB) fiber.Handler {
 			}
 		}
 
+		// Invites addressed to this email become "invited" event rows. Only a
+		// first sign-in or an email change costs a query; a failure shouldn't
+		// block the request and is retried on the next one.
+		if err := services.ResolveEmailInvites(c.UserContext(), db, user); err != nil {
+			slog.WarnContext(c.UserContext(), "Email invite resolution failed",
+				"event_type_label", "event.invites_resolve_failed",
+				"user_id", user.ID.String(),
+				"error", err.Error(),
+			)
+		}
+
 		// Store user info in request-scoped locals for downstream handlers.
 		c.Locals("userID", user.ID.String())
 		c.Locals("userRole", string(user.Role))
@@ -65,10 +65,11 @@ type EventPlayerStatus string
 
 const (
 	EventPlayerStatusPending    EventPlayerStatus = "pending" // Join request awaiting organizer approval
-	EventPlayerStatusInvited    EventPlayerStatus = "invited"
+	EventPlayerStatusInvited    EventPlayerStatus = "invited" // Addressed invite awaiting the player's accept or decline
 	EventPlayerStatusRegistered EventPlayerStatus = "registered"
 	EventPlayerStatusWithdrawn  EventPlayerStatus = "withdrawn"
 	EventPlayerStatusCompleted  EventPlayerStatus = "completed"
+	EventPlayerStatusWaitlisted EventPlayerStatus = "waitlisted" // Queued for a spot in a full event
 )
 
 // RoundStatus tracks the lifecycle of a single round within an event.
@@ -113,14 +114,245 @@ const (
 	VegasScoringBasisNet   VegasScoringBasis = "net"
 )
 
+// TiebreakPolicy selects how the leaderboard and standings resolve players who
+// finish level on net. Stored as TEXT on events, not a Postgres enum.
+type TiebreakPolicy string
+
+const (
+	// TiebreakPolicyCardOff breaks ties by matching cards: back 9, back 6, back 3,
+	// then the 18th hole, each on net with a prorated share of the handicap.
+	TiebreakPolicyCardOff TiebreakPolicy = "card_off"
+	// TiebreakPolicyShared leaves ties standing — tied players share the position.
+	TiebreakPolicyShared TiebreakPolicy = "shared"
+)
+
+// FlightAssignment selects how an event's members are placed into flights.
+// Stored as TEXT on events, not a Postgres enum.
+type FlightAssignment string
+
+const (
+	// FlightAssignmentManual — organizers assign each member's flight.
+	FlightAssignmentManual FlightAssignment = "manual"
+	// FlightAssignmentHandicapSeason — members are placed by handicap-index band
+	// whenever an organizer recomputes flights (typically once per season).
+	FlightAssignmentHandicapSeason FlightAssignment = "handicap_season"
+	// FlightAssignmentHandicapRound — as handicap_season, but recomputed
+	// automatically each time one of the event's rounds goes active.
+	FlightAssignmentHandicapRound FlightAssignment = "handicap_round"
+)
+
+// SubPolicy selects who a substitute's round points count for in the event
+// standings. Stored as TEXT on events, not a Postgres enum.
+type SubPolicy string
+
+const (
+	// SubPolicyTeam credits the member the sub replaced, so the roster spot
+	// keeps its points.
+	SubPolicyTeam SubPolicy = "team"
+	// SubPolicySelf credits the sub, who gets a standings line of their own.
+	SubPolicySelf SubPolicy = "self"
+	// SubPolicyNone credits nobody; the sub's round still places.
+	SubPolicyNone SubPolicy = "none"
+)
+
+// AbsencePolicy selects how an absent player's card is filled in. Stored as
+// TEXT on events (and on each generated score), not a Postgres enum.
+type AbsencePolicy string
+
+const (
+	// AbsencePolicyBlindDraw copies a randomly drawn player's net score.
+	AbsencePolicyBlindDraw AbsencePolicy = "blind_draw"
+	// AbsencePolicyPhantomPar scores par plus handicap strokes (net par).
+	AbsencePolicyPhantomPar AbsencePolicy = "phantom_par"
+	// AbsencePolicyPhantomAverage scores par plus the player's average over par.
+	AbsencePolicyPhantomAverage AbsencePolicy = "phantom_average"
+)
+
+// BracketFormat selects a match play bracket's elimination style.
+// Stored as TEXT on brackets, not a Postgres enum.
+type BracketFormat string
+
+const (
+	// BracketFormatSingleElimination — one loss and a player is out.
+	BracketFormatSingleElimination BracketFormat = "single_elimination"
+	// BracketFormatDoubleElimination — first-time losers drop into a losers
+	// bracket whose champion meets the winners bracket champion in a grand final.
+	BracketFormatDoubleElimination BracketFormat = "double_elimination"
+)
+
+// BracketSeeding selects how a bracket's field is seeded.
+type BracketSeeding string
+
+const (
+	// BracketSeedingHandicap — lowest handicap index is seed 1.
+	BracketSeedingHandicap BracketSeeding = "handicap"
+	// BracketSeedingManual — seeds follow the order the organizer supplied.
+	BracketSeedingManual BracketSeeding = "manual"
+)
+
+// BracketSide is the part of the tree a match belongs to.
+type BracketSide string
+
+const (
+	BracketSideWinners BracketSide = "winners"
+	BracketSideLosers  BracketSide = "losers"
+	BracketSideFinal   BracketSide = "final" // double elimination grand final
+)
+
+// BracketMatchStatus is a bracket match's stored state. Whether a pending match
+// is waiting, ready or scheduled is derived from its players and round.
+type BracketMatchStatus string
+
+const (
+	BracketMatchStatusPending   BracketMatchStatus = "pending"
+	BracketMatchStatusCompleted BracketMatchStatus = "completed"
+	BracketMatchStatusBye       BracketMatchStatus = "bye"
+)
+
+// CupSessionFormat is the match format for every match in a cup session.
+// Stored as TEXT on cup_sessions, not a Postgres enum.
+type CupSessionFormat string
+
+const (
+	// CupSessionFormatFourBall — 2 v 2, each plays their own ball; the side's
+	// best net score counts on each hole.
+	CupSessionFormatFourBall CupSessionFormat = "four_ball"
+	// CupSessionFormatFoursomes — 2 v 2 alternate shot, one ball per side.
+	CupSessionFormatFoursomes CupSessionFormat = "foursomes"
+	// CupSessionFormatSingles — 1 v 1.
+	CupSessionFormatSingles CupSessionFormat = "singles"
+)
+
+// CupMatchStatus is a cup match's state. A completed match with no winner was halved.
+type CupMatchStatus string
+
+const (
+	CupMatchStatusPending   CupMatchStatus = "pending"
+	CupMatchStatusCompleted CupMatchStatus = "completed"
+)
+
+// ScheduleNines selects which nine a league schedule's rounds play. Stored as
+// TEXT on event_schedules, not a Postgres enum.
+type ScheduleNines string
+
+const (
+	ScheduleNinesFull  ScheduleNines = "full"
+	ScheduleNinesFront ScheduleNines = "front"
+	ScheduleNinesBack  ScheduleNines = "back"
+	// ScheduleNinesAlternateFront alternates nines week to week, front first.
+	ScheduleNinesAlternateFront ScheduleNines = "alternate_front"
+	// ScheduleNinesAlternateBack alternates nines week to week, back first.
+	ScheduleNinesAlternateBack ScheduleNines = "alternate_back"
+)
+
 // RoundPlayerStatus tracks a player's state in a single round.
+// Withdrawn, disqualified and no_card take the player out of the round's
+// results (see RoundPlayerOutStatuses).
 type RoundPlayerStatus string
 
 const (
-	RoundPlayerStatusRegistered RoundPlayerStatus = "registered"
-	RoundPlayerStatusActive     RoundPlayerStatus = "active"
-	RoundPlayerStatusWithdrawn  RoundPlayerStatus = "withdrawn"
-	RoundPlayerStatusCompleted  RoundPlayerStatus = "completed"
+	RoundPlayerStatusRegistered   RoundPlayerStatus = "registered"
+	RoundPlayerStatusActive       RoundPlayerStatus = "active"
+	RoundPlayerStatusWithdrawn    RoundPlayerStatus = "withdrawn"
+	RoundPlayerStatusCompleted    RoundPlayerStatus = "completed"
+	RoundPlayerStatusDisqualified RoundPlayerStatus = "disqualified"
+	RoundPlayerStatusNoCard       RoundPlayerStatus = "no_card"
+)
+
+// RoundPlayerOutStatuses are the statuses that take a player out of a round's
+// results: not ranked, no points, and left out of their handicap.
+var RoundPlayerOutStatuses = []RoundPlayerStatus{
+	RoundPlayerStatusWithdrawn, RoundPlayerStatusDisqualified, RoundPlayerStatusNoCard,
+}
+
+// ScoreChangeKind identifies what a ScoreChange row records.
+// Stored as TEXT on score_changes, not a Postgres enum.
+type ScoreChangeKind string
+
+const (
+	ScoreChangeKindScore    ScoreChangeKind = "score"
+	ScoreChangeKindHoleStat ScoreChangeKind = "hole_stat"
+	ScoreChangeKindHandicap ScoreChangeKind = "handicap"
+)
+
+// ScoreDisputeStatus tracks a score dispute from raised to resolved.
+// Stored as TEXT on score_disputes, not a Postgres enum.
+type ScoreDisputeStatus string
+
+const (
+	ScoreDisputeStatusOpen      ScoreDisputeStatus = "open"
+	ScoreDisputeStatusAccepted  ScoreDisputeStatus = "accepted"  // recorded score stands
+	ScoreDisputeStatusCorrected ScoreDisputeStatus = "corrected" // organizer changed the score
+	ScoreDisputeStatusDismissed ScoreDisputeStatus = "dismissed" // withdrawn or invalid
+)
+
+// LedgerEntryKind distinguishes a side-game result from a settle-up payment.
+// Stored as TEXT on ledger_entries, not a Postgres enum.
+type LedgerEntryKind string
+
+const (
+	LedgerEntryKindResult   LedgerEntryKind = "result"    // FromUser lost the amount to ToUser in a round's side game
+	LedgerEntryKindSettleUp LedgerEntryKind = "settle_up" // FromUser paid ToUser to square up
+)
+
+// SideGame names the betting game a ledger result came from. Stored as TEXT on
+// ledger_entries; scoring for these games is derived client-side.
+type SideGame string
+
+const (
+	SideGameLasVegas SideGame = "las_vegas"
+	SideGameSkins    SideGame = "skins"
+	SideGameNassau   SideGame = "nassau"
+	SideGameOther    SideGame = "other" // anything else; describe it in the note
+)
+
+// NotificationType is what an in-app notification reports. Stored as TEXT on
+// notifications and notification_preferences, not a Postgres enum.
+type NotificationType string
+
+const (
+	NotificationTypeJoinRequest      NotificationType = "join_request"      // to organizers: someone asked to join
+	NotificationTypeJoinApproved     NotificationType = "join_approved"     // to the requester
+	NotificationTypeJoinDeclined     NotificationType = "join_declined"     // to the requester
+	NotificationTypeWaitlistJoined   NotificationType = "waitlist_joined"   // moved off the waitlist into the event
+	NotificationTypeWaitlistOffer    NotificationType = "waitlist_offer"    // offered a spot, to accept before it lapses
+	NotificationTypeGroupAssigned    NotificationType = "group_assigned"    // added to a round's group
+	NotificationTypeRoundStarted     NotificationType = "round_started"     // a round you're in went active
+	NotificationTypeRoundCompleted   NotificationType = "round_completed"   // a round you're in was completed
+	NotificationTypeNewFollower      NotificationType = "new_follower"      // someone followed you
+	NotificationTypeResultsFinalized NotificationType = "results_finalized" // an event you're in posted final results
+	NotificationTypeTeeTimeSoon      NotificationType = "tee_time_soon"     // your group tees off within the hour
+)
+
+// ActivityKind is what an activity feed item reports. Stored as TEXT on
+// activity_items.
+type ActivityKind string
+
+const (
+	ActivityKindRoundCompleted ActivityKind = "round_completed" // finished a round with a full card
+	ActivityKindPersonalBest   ActivityKind = "personal_best"   // lowest 18-hole gross they've posted
+	ActivityKindEagle          ActivityKind = "eagle"           // two or more under par on a hole
+	ActivityKindAce            ActivityKind = "ace"             // a hole in one
+	ActivityKindEventWon       ActivityKind = "event_won"       // first in the final standings (or their flight's)
+)
+
+// ProfileVisibility is who can see a user's profile details, stats and feed
+// items. Stored as TEXT on user_privacy_settings.
+type ProfileVisibility string
+
+const (
+	ProfileVisibilityPublic    ProfileVisibility = "public"    // any signed-in user
+	ProfileVisibilityFollowers ProfileVisibility = "followers" // people who follow the user
+	ProfileVisibilityPrivate   ProfileVisibility = "private"   // only the user
+)
+
+// DevicePlatform is the OS of a device registered for push notifications.
+// Stored as TEXT on device_tokens.
+type DevicePlatform string
+
+const (
+	DevicePlatformIOS     DevicePlatform = "ios"
+	DevicePlatformAndroid DevicePlatform = "android"
 )
 
 // TeeGender indicates which gender a set of tees is rated for.
@@ -148,9 +380,13 @@ type User struct {
 	Role        UserRole  `gorm:"type:user_role;not null;default:'user'"`
 	// IsGuest marks a score-only participant created per-round (no account, no auth_id,
 	// synthetic email). Guests track scores for team games but carry no advanced stats.
-	IsGuest   bool `gorm:"column:is_guest;not null;default:false"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	IsGuest bool `gorm:"column:is_guest;not null;default:false"`
+	// InvitesResolvedEmail is the lowercased email whose addressed event invites
+	// have been resolved for this user; nil until the first resolution
+	// (migration 000049).
+	InvitesResolvedEmail *string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
 }
 
 // Follow records a directed follow relationship: FollowerID follows FolloweeID.
@@ -164,6 +400,30 @@ type Follow struct {
 	CreatedAt  time.Time
 }
 
+// UserPrivacySettings controls who sees a user's profile, stats and email, and
+// whether search finds them (migration 000047). One row per user; a missing row
+// means the column defaults (everything visible).
+type UserPrivacySettings struct {
+	UserID            uuid.UUID         `gorm:"type:uuid;primaryKey"`
+	ProfileVisibility ProfileVisibility `gorm:"type:text;not null;default:'public'"`
+	HideEmail         bool              `gorm:"not null;default:false"`
+	HideStats         bool              `gorm:"not null;default:false"`
+	HideFromSearch    bool              `gorm:"not null;default:false"`
+	UpdatedAt         time.Time         `gorm:"autoUpdateTime"`
+}
+
+// TableName pins the table name; GORM would otherwise pluralise "settings".
+func (UserPrivacySettings) TableName() string { return "user_privacy_settings" }
+
+// UserBlock records that BlockerID blocked BlockedID. The block applies in
+// both directions; the composite primary key makes blocking idempotent.
+type UserBlock struct {
+	BlockerID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	BlockedID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Blocked   User      `gorm:"foreignKey:BlockedID"`
+	CreatedAt time.Time
+}
+
 // Event is the top-level container for any golf competition.
 // There is no separate "League" model — an Event with EventType = "league" IS the league.
 // Who can manage an event is controlled by EventPlayer.Role = "organizer".
@@ -177,25 +437,218 @@ type Event struct {
 	EndDate     *time.Time  // Pointer = nullable
 	// HandicapAllowance is the percentage of each player's course_handicap applied when
 	// calculating net scores (e.g. 90 = 90%). NULL means full handicap (no allowance set).
-	HandicapAllowance *float64  `gorm:"type:decimal(5,2)"`
-	IsPublic          bool      `gorm:"not null;default:false"` // Public events are discoverable and joinable by any user
-	CreatedBy         uuid.UUID `gorm:"type:uuid;not null"`
-	Creator           User      `gorm:"foreignKey:CreatedBy"`
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
-	PointsRules       []EventPointsRule `gorm:"foreignKey:EventID"`
-	Players           []EventPlayer     `gorm:"foreignKey:EventID"`
-	Rounds            []Round           `gorm:"foreignKey:EventID"`
+	HandicapAllowance *float64 `gorm:"type:decimal(5,2)"`
+	IsPublic          bool     `gorm:"not null;default:false"` // Public events are discoverable and joinable by any user
+	// TiebreakPolicy is "card_off" or "shared" (see TiebreakPolicy); migration 000026.
+	TiebreakPolicy string `gorm:"column:tiebreak_policy;type:text;not null;default:'card_off'"`
+	// FlightAssignment is "manual", "handicap_season" or "handicap_round" (see
+	// FlightAssignment); migration 000031.
+	FlightAssignment string `gorm:"column:flight_assignment;type:text;not null;default:'manual'"`
+	// SubPolicy is "team", "self" or "none" (see SubPolicy); migration 000035.
+	SubPolicy string `gorm:"column:sub_policy;type:text;not null;default:'team'"`
+	// AbsencePolicy is "blind_draw", "phantom_par" or "phantom_average" (see
+	// AbsencePolicy); migration 000036.
+	AbsencePolicy string `gorm:"column:absence_policy;type:text;not null;default:'phantom_par'"`
+	// MaxPlayers caps the registered field (substitutes aside); nil = no limit.
+	// WaitlistOfferHours nil promotes waitlisted players automatically; otherwise
+	// a promoted player has that long to accept the spot. Migration 000039.
+	MaxPlayers         *int
+	WaitlistOfferHours *int
+	// EntryFeeCents is what each member of the field owes; 0 = free (migration 000040).
+	EntryFeeCents int `gorm:"not null;default:0"`
+	// FinalizedAt/FinalizedBy are set by LeaderboardService.FinalizeEvent and cleared
+	// by ReopenEvent. While set, score edits are locked for everyone but admins.
+	FinalizedAt *time.Time
+	FinalizedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedBy   uuid.UUID  `gorm:"type:uuid;not null"`
+	Creator     User       `gorm:"foreignKey:CreatedBy"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	PointsRules []EventPointsRule `gorm:"foreignKey:EventID"`
+	Players     []EventPlayer     `gorm:"foreignKey:EventID"`
+	Rounds      []Round           `gorm:"foreignKey:EventID"`
+	Flights     []EventFlight     `gorm:"foreignKey:EventID"`
+}
+
+// EventFlight is one division (A/B/C...) within an event. Each flight is ranked
+// separately on leaderboards and standings. MinHandicapIndex/MaxHandicapIndex
+// form an inclusive band used for automatic assignment; nil means open-ended.
+type EventFlight struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID          uuid.UUID `gorm:"type:uuid;not null"`
+	Event            Event     `gorm:"foreignKey:EventID"`
+	Name             string    `gorm:"type:text;not null"`
+	SortOrder        int       `gorm:"not null"`
+	MinHandicapIndex *float64  `gorm:"type:decimal(4,1)"`
+	MaxHandicapIndex *float64  `gorm:"type:decimal(4,1)"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// EventReopening records an organizer reopening a finalized event, and why.
+// Append-only: one row per reopen, kept as the audit trail for changed results.
+type EventReopening struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID    uuid.UUID `gorm:"type:uuid;not null"`
+	Event      Event     `gorm:"foreignKey:EventID"`
+	ReopenedBy uuid.UUID `gorm:"type:uuid;not null"`
+	Reopener   User      `gorm:"foreignKey:ReopenedBy"`
+	Reason     string    `gorm:"type:text;not null"`
+	CreatedAt  time.Time
+}
+
+// PayoutPlace is one finishing place's share of a purse (migration 000040).
+// RoundID nil is the event's schedule, paid on final standings; otherwise the
+// round's, paid on its leaderboard.
+type PayoutPlace struct {
+	ID      uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID uuid.UUID  `gorm:"type:uuid;not null"`
+	RoundID *uuid.UUID `gorm:"type:uuid"`
+	Place   int        `gorm:"not null"`
+	Percent float64    `gorm:"type:decimal(5,2);not null"`
+}
+
+// FeePayment is an entry fee marked paid: one per event player for the event
+// (RoundID nil) and for each round. AmountCents is what was actually paid.
+type FeePayment struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID       uuid.UUID  `gorm:"type:uuid;not null"`
+	RoundID       *uuid.UUID `gorm:"type:uuid"`
+	EventPlayerID uuid.UUID  `gorm:"type:uuid;not null"`
+	AmountCents   int        `gorm:"not null"`
+	RecordedBy    uuid.UUID  `gorm:"type:uuid;not null"`
+	PaidAt        time.Time  `gorm:"not null;default:now()"`
+}
+
+// Bracket is a tournament event's match play bracket (migration 000032). Size is
+// the field rounded up to a power of two; the missing seeds are byes.
+type Bracket struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID   uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex"`
+	Event     Event          `gorm:"foreignKey:EventID"`
+	Format    string         `gorm:"type:text;not null"` // see BracketFormat
+	Seeding   string         `gorm:"type:text;not null"` // see BracketSeeding
+	Size      int            `gorm:"not null"`
+	CreatedBy uuid.UUID      `gorm:"type:uuid;not null"`
+	Entries   []BracketEntry `gorm:"foreignKey:BracketID"`
+	Matches   []BracketMatch `gorm:"foreignKey:BracketID"`
+	CreatedAt time.Time
+}
+
+// BracketEntry is one seeded player in a bracket.
+type BracketEntry struct {
+	BracketID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EventPlayerID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Seed          int       `gorm:"not null"`
+}
+
+// BracketMatch is one match in a bracket tree. Player1ID/Player2ID fill in as
+// earlier matches finish; NextMatchID/NextSlot route the winner and
+// LoserNextMatchID/LoserNextSlot route the loser (double elimination). RoundID
+// is the match_play round scheduled for the match, if any.
+type BracketMatch struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	BracketID        uuid.UUID  `gorm:"type:uuid;not null"`
+	Side             string     `gorm:"type:text;not null"` // see BracketSide
+	RoundNumber      int        `gorm:"not null"`
+	MatchNumber      int        `gorm:"not null"`
+	Player1ID        *uuid.UUID `gorm:"type:uuid"` // event_players.id
+	Player2ID        *uuid.UUID `gorm:"type:uuid"`
+	Status           string     `gorm:"type:text;not null;default:'pending'"` // see BracketMatchStatus
+	WinnerID         *uuid.UUID `gorm:"type:uuid"`
+	Result           *string    `gorm:"type:text"` // e.g. "3&2", "1 up"
+	RoundID          *uuid.UUID `gorm:"type:uuid"`
+	NextMatchID      *uuid.UUID `gorm:"type:uuid"`
+	NextSlot         *int
+	LoserNextMatchID *uuid.UUID `gorm:"type:uuid"`
+	LoserNextSlot    *int
+	CompletedAt      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// EventTeam is one side of an event's Ryder Cup–style competition (migration
+// 000033). Distinct from Team, which groups round players for a single round.
+type EventTeam struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID   uuid.UUID `gorm:"type:uuid;not null"`
+	Name      string    `gorm:"type:text;not null"`
+	Color     *string   `gorm:"type:text"`
+	SortOrder int       `gorm:"not null;default:0"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CupSession is a block of cup matches in one format. RoundID is the round the
+// session is played in; completing that round scores the session's matches.
+type CupSession struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID       uuid.UUID  `gorm:"type:uuid;not null"`
+	RoundID       *uuid.UUID `gorm:"type:uuid"`
+	SessionNumber int        `gorm:"not null"`
+	Name          string     `gorm:"type:text;not null"`
+	Format        string     `gorm:"type:text;not null"` // see CupSessionFormat
+	Matches       []CupMatch `gorm:"foreignKey:SessionID"`
+	CreatedAt     time.Time
+}
+
+// CupMatch is one match in a cup session, worth one point (half each if halved).
+type CupMatch struct {
+	ID           uuid.UUID        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	SessionID    uuid.UUID        `gorm:"type:uuid;not null"`
+	MatchNumber  int              `gorm:"not null"`
+	Status       string           `gorm:"type:text;not null;default:'pending'"` // see CupMatchStatus
+	WinnerTeamID *uuid.UUID       `gorm:"type:uuid"`                            // nil on a completed match = halved
+	Result       *string          `gorm:"type:text"`                            // e.g. "3&2", "A/S"
+	Players      []CupMatchPlayer `gorm:"foreignKey:MatchID"`
+	CompletedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// CupMatchPlayer puts an event player on one side of a cup match. EventTeamID
+// is the side, fixed when the match is made.
+type CupMatchPlayer struct {
+	MatchID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EventPlayerID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EventTeamID   uuid.UUID `gorm:"type:uuid;not null"`
+}
+
+// EventSchedule is an event's recurring league schedule: one round every week
+// on Weekday from StartDate to EndDate, minus SkipDates. Saving it generates the
+// season's rounds (Round.ScheduleWeek).
+type EventSchedule struct {
+	EventID       uuid.UUID           `gorm:"type:uuid;primaryKey"`
+	Weekday       int                 `gorm:"not null"` // 0 = Sunday, as time.Weekday
+	StartDate     time.Time           `gorm:"type:date;not null"`
+	EndDate       time.Time           `gorm:"type:date;not null"`
+	CourseID      uuid.UUID           `gorm:"type:uuid;not null"`
+	TeeID         uuid.UUID           `gorm:"type:uuid;not null"`
+	Nines         string              `gorm:"type:text;not null;default:'full'"` // see ScheduleNines
+	ScoringFormat *string             `gorm:"type:text"`                         // nil = stroke
+	SkipDates     []EventScheduleSkip `gorm:"foreignKey:EventID"`
+	CreatedBy     uuid.UUID           `gorm:"type:uuid;not null"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// EventScheduleSkip is a date the league doesn't play (a holiday, the club
+// championship).
+type EventScheduleSkip struct {
+	EventID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	SkipDate time.Time `gorm:"type:date;primaryKey"`
 }
 
 // EventPointsRule defines how many league points a player earns for a given finishing position.
-// The uniqueIndex:idx_event_position tag creates a composite unique constraint on (EventID, FinishPosition).
+// FlightID nil is the event's default table; a flight with rules of its own uses those
+// instead. (EventID, FlightID, FinishPosition) is unique, NULLs not distinct (migration 000031).
 type EventPointsRule struct {
-	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	EventID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_event_position"`
-	Event          Event     `gorm:"foreignKey:EventID"`
-	FinishPosition int       `gorm:"not null;uniqueIndex:idx_event_position"`
-	Points         int       `gorm:"not null"`
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID        uuid.UUID  `gorm:"type:uuid;not null"`
+	Event          Event      `gorm:"foreignKey:EventID"`
+	FlightID       *uuid.UUID `gorm:"type:uuid"`
+	FinishPosition int        `gorm:"not null"`
+	Points         int        `gorm:"not null"`
 }
 
 // EventPlayer links a User to an Event and records their role and status within it.
@@ -212,8 +665,41 @@ type EventPlayer struct {
 	TotalGrossScore *int
 	TotalNetScore   *int
 	TotalPoints     *int
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	// FlightID is the member's current flight; nil = unflighted (migration 000031).
+	FlightID *uuid.UUID   `gorm:"type:uuid"`
+	Flight   *EventFlight `gorm:"foreignKey:FlightID"`
+	// EventTeamID is the member's cup team; nil = not on a team (migration 000033).
+	EventTeamID *uuid.UUID `gorm:"type:uuid"`
+	// IsSubstitute marks a member of the sub roster, left out of the regular
+	// field (migration 000035).
+	IsSubstitute bool `gorm:"not null;default:false"`
+	// WaitlistedAt orders a waitlisted member in the queue; OfferExpiresAt is set
+	// while a spot is offered to them (migration 000039).
+	WaitlistedAt   *time.Time
+	OfferExpiresAt *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// EventInvite is an invitation to join an event (migration 000038): a shareable
+// code, or one addressed to Email. An addressed invite resolves to
+// InvitedUserID — and an "invited" EventPlayer row — once the email belongs to
+// a user. MaxUses nil = unlimited; ExpiresAt nil = never expires.
+type EventInvite struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EventID       uuid.UUID  `gorm:"type:uuid;not null"`
+	Event         Event      `gorm:"foreignKey:EventID"`
+	Code          string     `gorm:"type:text;not null;uniqueIndex"`
+	Email         *string    `gorm:"type:text"` // lowercased
+	InvitedUserID *uuid.UUID `gorm:"type:uuid"`
+	MaxUses       *int
+	UseCount      int `gorm:"not null;default:0"`
+	ExpiresAt     *time.Time
+	RevokedAt     *time.Time
+	DeclinedAt    *time.Time
+	CreatedBy     uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 // Round represents a single round of play. It may belong to an Event (event_id set)
@@ -253,8 +739,13 @@ type Round struct {
 	// or "net"). Only meaningful when ScoringFormat is best_ball. DB column keeps
 	// DEFAULT 'gross' (migration 000022); set explicitly via applyBestBallToggles.
 	BestBallScoringBasis string `gorm:"column:best_ball_scoring_basis;type:text;not null"`
-	CreatedAt            time.Time
-	UpdatedAt            time.Time
+	// ScheduleWeek is the week of the event's league schedule this round was
+	// generated for; nil for rounds scheduled by hand.
+	ScheduleWeek *int
+	// EntryFeeCents is what each player in the round owes; 0 = free (migration 000040).
+	EntryFeeCents int `gorm:"not null;default:0"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 // RoundPlayer links a player to a specific Round and stores per-round results.
@@ -276,8 +767,29 @@ type RoundPlayer struct {
 	FinishPosition *int
 	PointsEarned   *int
 	Status         RoundPlayerStatus `gorm:"type:round_player_status;not null;default:'registered'"`
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	// StatusReason is the organizer's reason for a withdrawn, disqualified or
+	// no_card status; nil otherwise (migration 000037).
+	StatusReason *string `gorm:"type:text"`
+	// Attestation: the player and a marker from the same group sign a complete
+	// card, which sets Status to completed. An organizer correction afterwards
+	// clears both signatures and sets NeedsReattestation.
+	PlayerAttestedAt   *time.Time
+	MarkerAttestedAt   *time.Time
+	MarkerID           *uuid.UUID `gorm:"type:uuid"`
+	NeedsReattestation bool       `gorm:"not null"`
+	// FlightID is the event flight this player competed in, snapshotted when the
+	// round goes active; nil falls back to EventPlayer.FlightID (migration 000031).
+	FlightID *uuid.UUID `gorm:"type:uuid"`
+	// ReplacedEventPlayerID is the member a substitute is playing for; nil when
+	// the player is playing their own spot (migration 000035).
+	ReplacedEventPlayerID *uuid.UUID `gorm:"type:uuid"`
+	// AbsentAt is set when an organizer marks the player absent; their card is
+	// then generated (see Score.Generated) and the round is left out of their
+	// stats. BlindDrawRoundPlayerID is the card a blind draw copied (migration 000036).
+	AbsentAt               *time.Time
+	BlindDrawRoundPlayerID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
 }
 
 // Score records the strokes a player took on a single hole during a round.
@@ -293,6 +805,9 @@ type Score struct {
 	Enterer       User        `gorm:"foreignKey:EnteredBy"`
 	EnteredAt     time.Time   `gorm:"autoCreateTime"`
 	UpdatedAt     time.Time   `gorm:"autoUpdateTime"`
+	// Generated is the AbsencePolicy that produced the score for an absent
+	// player; nil for a score someone entered (migration 000036).
+	Generated *string `gorm:"type:text"`
 }
 
 // HoleStat records advanced per-hole statistics for one player during a round.
@@ -327,6 +842,177 @@ type HoleStat struct {
 	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
 }
 
+// ScoreChange is one append-only audit entry for a scorecard edit: a hole score,
+// a hole stat, or a course handicap. OldValue/NewValue hold the JSON value before
+// and after (OldValue nil on first entry, NewValue nil when a revert removed the
+// row). RevertOf points at the change an organizer revert undid.
+type ScoreChange struct {
+	ID            uuid.UUID   `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RoundPlayerID uuid.UUID   `gorm:"type:uuid;not null"`
+	RoundPlayer   RoundPlayer `gorm:"foreignKey:RoundPlayerID"`
+	Kind          string      `gorm:"type:text;not null"`
+	HoleNumber    *int        // nil for handicap changes
+	OldValue      *string     `gorm:"type:jsonb"`
+	NewValue      *string     `gorm:"type:jsonb"`
+	ChangedBy     uuid.UUID   `gorm:"type:uuid;not null"`
+	Changer       User        `gorm:"foreignKey:ChangedBy"`
+	RevertOf      *uuid.UUID  `gorm:"type:uuid"`
+	CreatedAt     time.Time
+}
+
+// ScoreDispute is a player's challenge to one hole score on a card. At most one
+// dispute per hole is open at a time (partial unique index); resolved disputes
+// stay as history with the organizer's ruling.
+type ScoreDispute struct {
+	ID             uuid.UUID   `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RoundPlayerID  uuid.UUID   `gorm:"type:uuid;not null"`
+	RoundPlayer    RoundPlayer `gorm:"foreignKey:RoundPlayerID"`
+	HoleNumber     int         `gorm:"not null"`
+	RaisedBy       uuid.UUID   `gorm:"type:uuid;not null"`
+	Raiser         User        `gorm:"foreignKey:RaisedBy"`
+	Comment        string      `gorm:"type:text;not null"`
+	Status         string      `gorm:"type:text;not null;default:'open'"`
+	ResolutionNote *string     `gorm:"type:text"`
+	ResolvedBy     *uuid.UUID  `gorm:"type:uuid"`
+	ResolvedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// LedgerEntry is one amount between two players in the side-bet ledger
+// (migration 000041): a round's side-game result (RoundID and Game set) or a
+// settle-up. Balances are never stored; they net from these rows.
+type LedgerEntry struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Kind        LedgerEntryKind `gorm:"type:text;not null"`
+	RoundID     *uuid.UUID      `gorm:"type:uuid"`
+	Round       *Round          `gorm:"foreignKey:RoundID"`
+	Game        *SideGame       `gorm:"type:text"`
+	FromUserID  uuid.UUID       `gorm:"type:uuid;not null"`
+	FromUser    User            `gorm:"foreignKey:FromUserID"`
+	ToUserID    uuid.UUID       `gorm:"type:uuid;not null"`
+	ToUser      User            `gorm:"foreignKey:ToUserID"`
+	AmountCents int             `gorm:"not null"`
+	Note        *string         `gorm:"type:text"`
+	RecordedBy  uuid.UUID       `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time
+}
+
+// Notification is one in-app inbox entry for UserID (migration 000042). The
+// message is rendered from Type and the referenced actor, event and round when
+// listed. ReadAt nil = unread.
+type Notification struct {
+	ID        uuid.UUID        `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID    uuid.UUID        `gorm:"type:uuid;not null"`
+	Type      NotificationType `gorm:"type:text;not null"`
+	ActorID   *uuid.UUID       `gorm:"type:uuid"`
+	Actor     *User            `gorm:"foreignKey:ActorID"`
+	EventID   *uuid.UUID       `gorm:"type:uuid"`
+	Event     *Event           `gorm:"foreignKey:EventID"`
+	RoundID   *uuid.UUID       `gorm:"type:uuid"`
+	Round     *Round           `gorm:"foreignKey:RoundID"`
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NotificationPreference turns one notification type on or off for a user.
+// A missing row means on.
+type NotificationPreference struct {
+	UserID  uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	Type    NotificationType `gorm:"type:text;primaryKey"`
+	Enabled bool             `gorm:"not null"`
+}
+
+// ActivityItem is one entry in the activity feed (migration 000045): a
+// completed round, personal best, eagle, ace or event win.
+type ActivityItem struct {
+	ID         uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID     uuid.UUID    `gorm:"type:uuid;not null"`
+	User       User         `gorm:"foreignKey:UserID"`
+	Kind       ActivityKind `gorm:"type:text;not null"`
+	RoundID    *uuid.UUID   `gorm:"type:uuid"`
+	Round      *Round       `gorm:"foreignKey:RoundID"`
+	EventID    *uuid.UUID   `gorm:"type:uuid"`
+	Event      *Event       `gorm:"foreignKey:EventID"`
+	HoleNumber *int
+	Score      *int
+	ToPar      *int
+	CreatedAt  time.Time
+}
+
+// RoundComment is a comment on a completed round, or on one of its holes when
+// HoleNumber is set (migration 000046). ParentID points at the comment that
+// started the thread; DeletedAt is set when the author or an organizer
+// removes it.
+type RoundComment struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RoundID    uuid.UUID  `gorm:"type:uuid;not null"`
+	HoleNumber *int       // nil = about the whole round
+	ParentID   *uuid.UUID `gorm:"type:uuid"`
+	AuthorID   uuid.UUID  `gorm:"type:uuid;not null"`
+	Author     User       `gorm:"foreignKey:AuthorID"`
+	Body       string     `gorm:"type:text;not null"`
+	DeletedAt  *time.Time // soft delete; not gorm.DeletedAt so moderators can still load it
+	DeletedBy  *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt  time.Time
+}
+
+// RoundReaction is one user's emoji on a completed round, or on one of its
+// holes when HoleNumber is set (migration 000046).
+type RoundReaction struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RoundID    uuid.UUID `gorm:"type:uuid;not null"`
+	HoleNumber *int
+	UserID     uuid.UUID `gorm:"type:uuid;not null"`
+	Emoji      string    `gorm:"type:text;not null"`
+	CreatedAt  time.Time
+}
+
+// DeviceToken is a device registered to receive push notifications
+// (migration 000043). Token is unique across users: registering a token moves
+// it to the caller.
+type DeviceToken struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID    uuid.UUID      `gorm:"type:uuid;not null"`
+	Token     string         `gorm:"type:text;not null;uniqueIndex"`
+	Platform  DevicePlatform `gorm:"type:text;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PushOutbox is a notification queued for delivery to one device. Delivered
+// rows are deleted; FailedAt is set once retries run out.
+type PushOutbox struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	DeviceTokenID  uuid.UUID    `gorm:"type:uuid;not null"`
+	DeviceToken    DeviceToken  `gorm:"foreignKey:DeviceTokenID"`
+	NotificationID uuid.UUID    `gorm:"type:uuid;not null"`
+	Notification   Notification `gorm:"foreignKey:NotificationID"`
+	Attempts       int          `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time    `gorm:"not null;default:now()"`
+	LastError      *string      `gorm:"type:text"`
+	FailedAt       *time.Time
+	CreatedAt      time.Time
+}
+
+// TableName overrides GORM's default pluralisation ("push_outboxes").
+func (PushOutbox) TableName() string { return "push_outbox" }
+
+// EmailDigest is a user's weekly digest subscription (migration 000044). The
+// row is created the first time the digest job considers the user; missing
+// row = subscribed. FailedAttempts and NextAttemptAt back off a digest whose
+// send failed (migration 000048).
+type EmailDigest struct {
+	UserID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Enabled          bool      `gorm:"not null"`
+	UnsubscribeToken uuid.UUID `gorm:"type:uuid;not null;uniqueIndex;default:gen_random_uuid()"`
+	LastSentAt       *time.Time
+	FailedAttempts   int `gorm:"not null;default:0"`
+	NextAttemptAt    *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
 // ScorecardSettings stores per-user toggles controlling which supplemental stats are
 // displayed on the active scorecard. One row per user; missing row = server defaults.
 // Existing stats (FIR, GIR, putts, approach) default true to preserve current behaviour.
@@ -0,0 +1,98 @@
+// services/bracket_internal_test.go
+// White-box tests for the unexported bracket layout helpers in
+// bracket_service.go. Uses package services (not services_test) so unexported
+// functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestSeedOrder|TestBracket|TestSeededPlayer' -v
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+// ─── Seeding ──────────────────────────────────────────────────────────────────
+
+func TestSeedOrder_TopSeedsMeetLast(t *testing.T) {
+	assert.Equal(t, []int{1, 2}, seedOrder(2))
+	assert.Equal(t, []int{1, 4, 2, 3}, seedOrder(4))
+	assert.Equal(t, []int{1, 8, 4, 5, 2, 7, 3, 6}, seedOrder(8))
+}
+
+func TestBracketSize_RoundsUpToPowerOfTwo(t *testing.T) {
+	for n, want := range map[int]int{2: 2, 3: 4, 5: 8, 8: 8, 9: 16} {
+		assert.Equal(t, want, bracketSize(n), "n=%d", n)
+	}
+}
+
+func TestSeededPlayer_MissingSeedIsBye(t *testing.T) {
+	field := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	require.NotNil(t, seededPlayer(field, 3))
+	assert.Equal(t, field[2], *seededPlayer(field, 3))
+	assert.Nil(t, seededPlayer(field, 4))
+}
+
+// ─── Layout ───────────────────────────────────────────────────────────────────
+
+func TestBracketMatches_SingleEliminationOfEight(t *testing.T) {
+	matches := buildBracketMatches(uuid.New(), models.BracketFormatSingleElimination, 8)
+	require.Len(t, matches, 7)
+
+	byID := make(map[uuid.UUID]models.BracketMatch, len(matches))
+	for _, m := range matches {
+		byID[m.ID] = m
+		assert.Nil(t, m.LoserNextMatchID)
+	}
+	// Round-1 matches 1 and 2 feed semi-final 1 in slots 1 and 2.
+	for i, slot := range []int{1, 2} {
+		next := byID[*matches[i].NextMatchID]
+		assert.Equal(t, 2, next.RoundNumber)
+		assert.Equal(t, 1, next.MatchNumber)
+		assert.Equal(t, slot, *matches[i].NextSlot)
+	}
+	final := matches[len(matches)-1]
+	assert.Equal(t, 3, final.RoundNumber)
+	assert.Nil(t, final.NextMatchID)
+}
+
+func TestBracketMatches_DoubleEliminationOfFour(t *testing.T) {
+	matches := buildBracketMatches(uuid.New(), models.BracketFormatDoubleElimination, 4)
+	// 3 winners matches, 2 losers matches, 1 grand final.
+	require.Len(t, matches, 6)
+
+	byID := make(map[uuid.UUID]models.BracketMatch, len(matches))
+	sides := make(map[string]int)
+	for _, m := range matches {
+		byID[m.ID] = m
+		sides[m.Side]++
+	}
+	assert.Equal(t, map[string]int{"winners": 3, "losers": 2, "final": 1}, sides)
+
+	// Both round-1 losers meet in losers round 1.
+	l1a, l1b := byID[*matches[0].LoserNextMatchID], byID[*matches[1].LoserNextMatchID]
+	assert.Equal(t, l1a.ID, l1b.ID)
+	assert.Equal(t, string(models.BracketSideLosers), l1a.Side)
+	assert.Equal(t, 1, l1a.RoundNumber)
+
+	// The winners final's loser drops to losers round 2; its winner to the final.
+	wf := matches[2]
+	assert.Equal(t, 2, byID[*wf.LoserNextMatchID].RoundNumber)
+	assert.Equal(t, 2, *wf.LoserNextSlot)
+	gf := byID[*wf.NextMatchID]
+	assert.Equal(t, string(models.BracketSideFinal), gf.Side)
+	assert.Nil(t, gf.NextMatchID)
+
+	// Every match but the grand final routes its winner somewhere.
+	for _, m := range matches {
+		if m.ID != gf.ID {
+			assert.NotNil(t, m.NextMatchID, "%s R%d M%d", m.Side, m.RoundNumber, m.MatchNumber)
+		}
+	}
+}
@@ -0,0 +1,853 @@
+// services/bracket_service.go
+// Match play brackets for tournament events. An organizer seeds the field (by
+// handicap index or by hand) into a single- or double-elimination tree; every
+// match is created up front and players flow into later matches as results
+// come in. Missing seeds are byes, which the top seeds receive.
+//
+// Each match can be scheduled as a match_play round between its two players.
+// When that round is marked completed (RoundService.Update), the match is
+// scored hole by hole on net and the winner advances automatically. A match
+// that finishes all square waits for the organizer to record the winner.
+//
+// Double elimination uses the standard layout: first-time losers drop into a
+// losers bracket whose champion meets the winners bracket champion in a single
+// grand final (no reset match).
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrBracketNotFound — the event has no bracket.
+	ErrBracketNotFound = errors.New("bracket not found")
+	// ErrBracketExists — the event already has a bracket; delete it first.
+	ErrBracketExists = errors.New("event already has a bracket")
+	// ErrBracketStarted — a match has been scheduled or played, so the bracket
+	// can no longer be deleted.
+	ErrBracketStarted = errors.New("bracket has already started")
+	// ErrBracketMatchNotFound — the match does not exist or belongs to another bracket.
+	ErrBracketMatchNotFound = errors.New("bracket match not found")
+	// ErrBracketMatchNotReady — the match is decided or still waiting for a player.
+	ErrBracketMatchNotReady = errors.New("bracket match is not awaiting a result")
+	// ErrBracketMatchScheduled — a round is already scheduled for the match.
+	ErrBracketMatchScheduled = errors.New("bracket match already has a round")
+)
+
+// maxBracketPlayers bounds the field; 128 players is seven rounds of match play.
+const maxBracketPlayers = 128
+
+// CreateBracketInput seeds a new bracket. PlayerIDs are user IDs: under manual
+// seeding they are the field in seed order (required); under handicap seeding
+// they limit the field, and an empty list means every registered member.
+type CreateBracketInput struct {
+	Format    string // "single_elimination" (default) or "double_elimination"
+	Seeding   string // "handicap" (default) or "manual"
+	PlayerIDs []uuid.UUID
+}
+
+// BracketPlayer is one seeded player, returned as JSON inside BracketData.
+type BracketPlayer struct {
+	EventPlayerID string  `json:"event_player_id"`
+	UserID        string  `json:"user_id"`
+	DisplayName   string  `json:"display_name"`
+	AvatarURL     *string `json:"avatar_url"`
+	Seed          int     `json:"seed"`
+}
+
+// BracketMatchData is one match. Status is "waiting" (a player slot is still
+// to be decided), "ready", "scheduled" (a round exists), "completed" or "bye".
+type BracketMatchData struct {
+	ID                  string         `json:"id"`
+	Side                string         `json:"side"`
+	RoundNumber         int            `json:"round_number"`
+	MatchNumber         int            `json:"match_number"`
+	Status              string         `json:"status"`
+	Player1             *BracketPlayer `json:"player1"`
+	Player2             *BracketPlayer `json:"player2"`
+	WinnerEventPlayerID *string        `json:"winner_event_player_id"`
+	Result              *string        `json:"result"`
+	RoundID             *string        `json:"round_id"`
+	NextMatchID         *string        `json:"next_match_id"`
+	LoserNextMatchID    *string        `json:"loser_next_match_id"`
+	CompletedAt         *time.Time     `json:"completed_at"`
+}
+
+// BracketRoundData is one column of the tree: every match of one side's round.
+type BracketRoundData struct {
+	Side        string             `json:"side"`
+	RoundNumber int                `json:"round_number"`
+	Matches     []BracketMatchData `json:"matches"`
+}
+
+// BracketData is the payload for GET /events/:id/bracket. Rounds list the
+// winners bracket first, then the losers bracket, then the grand final.
+type BracketData struct {
+	ID       string             `json:"id"`
+	EventID  string             `json:"event_id"`
+	Format   string             `json:"format"`
+	Seeding  string             `json:"seeding"`
+	Size     int                `json:"size"`
+	Entries  []BracketPlayer    `json:"entries"`
+	Rounds   []BracketRoundData `json:"rounds"`
+	Champion *BracketPlayer     `json:"champion"`
+}
+
+// BracketService owns match play brackets.
+// Construct once in main.go and inject into the bracket handler factories.
+type BracketService struct {
+	DB       *gorm.DB
+	EventSvc *EventService
+	RoundSvc *RoundService
+}
+
+// NewBracketService builds a BracketService. RoundSvc schedules match rounds;
+// EventSvc provides the organizer checks.
+func NewBracketService(db *gorm.DB, eventSvc *EventService, roundSvc *RoundService) *BracketService {
+	return &BracketService{DB: db, EventSvc: eventSvc, RoundSvc: roundSvc}
+}
+
+// ─── Queries ──────────────────────────────────────────────────────────────────
+
+// Get returns an event's bracket. Non-admins must be members of the event.
+func (s *BracketService) Get(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string) (*BracketData, error) {
+	if models.UserRole(requesterRole) != models.UserRoleAdmin {
+		var count int64
+		if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+			Where("event_id = ? AND user_id = ?", eventID, requesterID).
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("check membership: %w", err)
+		}
+		if count == 0 {
+			var events int64
+			if err := s.DB.WithContext(ctx).Model(&models.Event{}).Where("id = ?", eventID).Count(&events).Error; err != nil {
+				return nil, fmt.Errorf("load event: %w", err)
+			}
+			if events == 0 {
+				return nil, ErrEventNotFound
+			}
+			return nil, ErrEventNotMember
+		}
+	}
+	var bracket models.Bracket
+	if err := s.DB.WithContext(ctx).First(&bracket, "event_id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBracketNotFound
+		}
+		return nil, fmt.Errorf("load bracket: %w", err)
+	}
+	return s.loadBracketData(ctx, &bracket)
+}
+
+// ─── Mutations ────────────────────────────────────────────────────────────────
+
+// Create seeds a bracket for a tournament event. Caller must be an organizer
+// (or admin); the event must not be finalized or already have a bracket.
+func (s *BracketService) Create(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, in CreateBracketInput) (*BracketData, error) {
+	in, err := normalizeBracketInput(in)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if event.EventType != models.EventTypeTournament {
+		return nil, &ValidationError{Field: "event_type", Message: "brackets are only available for tournament events"}
+	}
+	var existing int64
+	if err := s.DB.WithContext(ctx).Model(&models.Bracket{}).Where("event_id = ?", eventID).Count(&existing).Error; err != nil {
+		return nil, fmt.Errorf("check bracket: %w", err)
+	}
+	if existing > 0 {
+		return nil, ErrBracketExists
+	}
+
+	field, err := s.seedField(ctx, eventID, in)
+	if err != nil {
+		return nil, err
+	}
+	if len(field) < 2 {
+		return nil, &ValidationError{Field: "players", Message: "a bracket needs at least 2 players"}
+	}
+	if len(field) > maxBracketPlayers {
+		return nil, &ValidationError{Field: "players", Message: fmt.Sprintf("a bracket holds at most %d players", maxBracketPlayers)}
+	}
+	if models.BracketFormat(in.Format) == models.BracketFormatDoubleElimination && len(field) < 4 {
+		return nil, &ValidationError{Field: "format", Message: "double elimination needs at least 4 players"}
+	}
+
+	size := bracketSize(len(field))
+	bracket := models.Bracket{
+		ID: uuid.New(), EventID: eventID, Format: in.Format, Seeding: in.Seeding,
+		Size: size, CreatedBy: callerID,
+	}
+	matches := buildBracketMatches(bracket.ID, models.BracketFormat(in.Format), size)
+	order := seedOrder(size)
+	for m := 0; m < size/2; m++ {
+		matches[m].Player1ID = seededPlayer(field, order[2*m])
+		matches[m].Player2ID = seededPlayer(field, order[2*m+1])
+	}
+
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&bracket).Error; err != nil {
+			return fmt.Errorf("create bracket: %w", err)
+		}
+		entries := make([]models.BracketEntry, len(field))
+		for i, epID := range field {
+			entries[i] = models.BracketEntry{BracketID: bracket.ID, EventPlayerID: epID, Seed: i + 1}
+		}
+		if err := tx.Create(&entries).Error; err != nil {
+			return fmt.Errorf("create bracket entries: %w", err)
+		}
+		if err := tx.Create(&matches).Error; err != nil {
+			return fmt.Errorf("create bracket matches: %w", err)
+		}
+		return settleBracket(tx, bracket.ID)
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("create bracket: %w", txErr)
+	}
+
+	return s.loadBracketData(ctx, &bracket)
+}
+
+// Delete removes an event's bracket. Caller must be an organizer (or admin);
+// only allowed before any match has been scheduled or played (ErrBracketStarted).
+func (s *BracketService) Delete(ctx context.Context, eventID, callerID uuid.UUID, callerRole string) error {
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return err
+	}
+	var bracket models.Bracket
+	if err := s.DB.WithContext(ctx).First(&bracket, "event_id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrBracketNotFound
+		}
+		return fmt.Errorf("load bracket: %w", err)
+	}
+	var started int64
+	if err := s.DB.WithContext(ctx).Model(&models.BracketMatch{}).
+		Where("bracket_id = ? AND (status = ? OR round_id IS NOT NULL)", bracket.ID, models.BracketMatchStatusCompleted).
+		Count(&started).Error; err != nil {
+		return fmt.Errorf("check bracket progress: %w", err)
+	}
+	if started > 0 {
+		return ErrBracketStarted
+	}
+	if err := s.DB.WithContext(ctx).Delete(&models.Bracket{}, "id = ?", bracket.ID).Error; err != nil {
+		return fmt.Errorf("delete bracket: %w", err)
+	}
+	return nil
+}
+
+// ScheduleMatch creates a match_play round for a ready match and puts both
+// players in its first group. in.ScoringFormat is ignored; a blank name
+// defaults to the match's place in the bracket. Caller must be an organizer
+// (or admin).
+func (s *BracketService) ScheduleMatch(ctx context.Context, eventID, matchID, callerID uuid.UUID, callerRole string, in ScheduleRoundInput) (*BracketMatchData, error) {
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	match, err := s.loadEventMatch(ctx, s.DB, eventID, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.Status != string(models.BracketMatchStatusPending) || match.Player1ID == nil || match.Player2ID == nil {
+		return nil, ErrBracketMatchNotReady
+	}
+	if match.RoundID != nil {
+		return nil, ErrBracketMatchScheduled
+	}
+
+	var players []models.EventPlayer
+	if err := s.DB.WithContext(ctx).Where("id IN ?", []uuid.UUID{*match.Player1ID, *match.Player2ID}).
+		Find(&players).Error; err != nil {
+		return nil, fmt.Errorf("load match players: %w", err)
+	}
+
+	format := string(models.ScoringFormatMatchPlay)
+	in.ScoringFormat = &format
+	if in.Name == "" {
+		in.Name = bracketMatchName(match)
+	}
+	scheduled, err := s.RoundSvc.Schedule(ctx, eventID, callerID, callerRole, in)
+	if err != nil {
+		return nil, err
+	}
+	roundID := scheduled.Round.ID
+
+	var group models.Group
+	if err := s.DB.WithContext(ctx).Where("round_id = ?", roundID).Order("group_number ASC").First(&group).Error; err != nil {
+		return nil, fmt.Errorf("load match group: %w", err)
+	}
+	for _, p := range players {
+		if _, err := s.RoundSvc.AddGroupMember(ctx, roundID, group.ID, callerID, p.UserID, callerRole); err != nil {
+			return nil, fmt.Errorf("add match player: %w", err)
+		}
+	}
+
+	res := s.DB.WithContext(ctx).Model(&models.BracketMatch{}).
+		Where("id = ? AND round_id IS NULL", match.ID).
+		Updates(map[string]any{"round_id": roundID, "updated_at": time.Now()})
+	if res.Error != nil {
+		return nil, fmt.Errorf("link match round: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		// Lost a race with another scheduler; drop the round we just made.
+		s.DB.WithContext(ctx).Delete(&models.Round{}, "id = ?", roundID)
+		return nil, ErrBracketMatchScheduled
+	}
+	return s.loadMatchData(ctx, eventID, match.ID)
+}
+
+// RecordResult decides a match by hand — for halved matches, concessions and
+// matches played outside the app — and advances the winner. result is free
+// text (e.g. "won at the 19th"); nil leaves it blank. Caller must be an
+// organizer (or admin).
+func (s *BracketService) RecordResult(ctx context.Context, eventID, matchID, callerID uuid.UUID, callerRole string, winnerID uuid.UUID, result *string) (*BracketMatchData, error) {
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		match, err := s.loadEventMatch(ctx, tx.Clauses(clause.Locking{Strength: "UPDATE"}), eventID, matchID)
+		if err != nil {
+			return err
+		}
+		if match.Status != string(models.BracketMatchStatusPending) || match.Player1ID == nil || match.Player2ID == nil {
+			return ErrBracketMatchNotReady
+		}
+		var loserID uuid.UUID
+		switch winnerID {
+		case *match.Player1ID:
+			loserID = *match.Player2ID
+		case *match.Player2ID:
+			loserID = *match.Player1ID
+		default:
+			return &ValidationError{Field: "winner_event_player_id", Message: "winner must be one of the match's players"}
+		}
+		if err := finishBracketMatch(tx, match, winnerID, loserID, result); err != nil {
+			return err
+		}
+		return settleBracket(tx, match.BracketID)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return s.loadMatchData(ctx, eventID, matchID)
+}
+
+// advanceBracketRound scores the bracket match played in roundID, if any, and
+// advances its winner. Called by RoundService.Update inside the transaction
+// that completes the round, so the match is decided exactly when the round is.
+// Halved matches and matches without both cards stay pending for the organizer.
+func advanceBracketRound(ctx context.Context, tx *gorm.DB, roundID uuid.UUID) error {
+	var match models.BracketMatch
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("round_id = ? AND status = ? AND player1_id IS NOT NULL AND player2_id IS NOT NULL",
+			roundID, models.BracketMatchStatusPending).
+		First(&match).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load bracket match: %w", err)
+	}
+
+	holes, err := matchHoles(ctx, tx, roundID)
+	if err != nil {
+		return err
+	}
+	nets, err := matchNetScores(ctx, tx, roundID, []uuid.UUID{*match.Player1ID, *match.Player2ID})
+	if err != nil {
+		return err
+	}
+	winnerSlot, result, ok := matchPlayResult(holes, nets[*match.Player1ID], nets[*match.Player2ID])
+	if !ok || winnerSlot == 0 {
+		return nil
+	}
+	winnerID, loserID := *match.Player1ID, *match.Player2ID
+	if winnerSlot == 2 {
+		winnerID, loserID = loserID, winnerID
+	}
+	if err := finishBracketMatch(tx, &match, winnerID, loserID, &result); err != nil {
+		if errors.Is(err, ErrBracketMatchNotReady) {
+			return nil // decided by hand in the meantime
+		}
+		return err
+	}
+	return settleBracket(tx, match.BracketID)
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// normalizeBracketInput applies defaults and validates a Create payload before
+// any DB access.
+func normalizeBracketInput(in CreateBracketInput) (CreateBracketInput, error) {
+	if in.Format == "" {
+		in.Format = string(models.BracketFormatSingleElimination)
+	}
+	if in.Seeding == "" {
+		in.Seeding = string(models.BracketSeedingHandicap)
+	}
+	switch models.BracketFormat(in.Format) {
+	case models.BracketFormatSingleElimination, models.BracketFormatDoubleElimination:
+	default:
+		return in, &ValidationError{Field: "format", Message: "format must be 'single_elimination' or 'double_elimination'"}
+	}
+	switch models.BracketSeeding(in.Seeding) {
+	case models.BracketSeedingHandicap, models.BracketSeedingManual:
+	default:
+		return in, &ValidationError{Field: "seeding", Message: "seeding must be 'handicap' or 'manual'"}
+	}
+	if models.BracketSeeding(in.Seeding) == models.BracketSeedingManual && len(in.PlayerIDs) == 0 {
+		return in, &ValidationError{Field: "players", Message: "manual seeding needs the players in seed order"}
+	}
+	seen := make(map[uuid.UUID]bool, len(in.PlayerIDs))
+	for _, id := range in.PlayerIDs {
+		if seen[id] {
+			return in, &ValidationError{Field: "players", Message: "a player can only be seeded once"}
+		}
+		seen[id] = true
+	}
+	return in, nil
+}
+
+// seedField returns the field's event_player IDs in seed order. Substitutes
+// are left out unless the organizer names them.
+func (s *BracketService) seedField(ctx context.Context, eventID uuid.UUID, in CreateBracketInput) ([]uuid.UUID, error) {
+	q := s.DB.WithContext(ctx).Where("event_id = ? AND status IN ?", eventID, flightedStatuses)
+	if len(in.PlayerIDs) > 0 {
+		q = q.Where("user_id IN ?", in.PlayerIDs)
+	} else {
+		q = q.Where("NOT is_substitute")
+	}
+	var members []models.EventPlayer
+	if err := q.Order("created_at ASC").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("load members: %w", err)
+	}
+	if len(in.PlayerIDs) > 0 && len(members) != len(in.PlayerIDs) {
+		return nil, ErrMemberNotFound
+	}
+
+	if models.BracketSeeding(in.Seeding) == models.BracketSeedingManual {
+		byUser := make(map[uuid.UUID]uuid.UUID, len(members))
+		for _, m := range members {
+			byUser[m.UserID] = m.ID
+		}
+		field := make([]uuid.UUID, len(in.PlayerIDs))
+		for i, userID := range in.PlayerIDs {
+			field[i] = byUser[userID]
+		}
+		return field, nil
+	}
+
+	userIDs := make([]uuid.UUID, len(members))
+	for i, m := range members {
+		userIDs[i] = m.UserID
+	}
+	byUser, err := latestHandicapIndexes(ctx, s.DB, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	// Lowest index first; players without an index seed last, in join order.
+	sort.SliceStable(members, func(i, j int) bool {
+		hi, iok := byUser[members[i].UserID]
+		hj, jok := byUser[members[j].UserID]
+		if iok != jok {
+			return iok
+		}
+		return iok && hi < hj
+	})
+	field := make([]uuid.UUID, len(members))
+	for i, m := range members {
+		field[i] = m.ID
+	}
+	return field, nil
+}
+
+// bracketSize rounds n up to the next power of two (minimum 2).
+func bracketSize(n int) int {
+	size := 2
+	for size < n {
+		size *= 2
+	}
+	return size
+}
+
+// seedOrder returns the seeds in bracket-line order for a power-of-two size:
+// adjacent pairs meet in round 1, and seeds 1 and 2 can only meet in the final.
+// seedOrder(8) = [1 8 4 5 2 7 3 6].
+func seedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, 2*len(order))
+		for _, s := range order {
+			next = append(next, s, 2*len(order)+1-s)
+		}
+		order = next
+	}
+	return order
+}
+
+// seededPlayer returns the player holding seed (1-based), or nil for a bye.
+func seededPlayer(field []uuid.UUID, seed int) *uuid.UUID {
+	if seed > len(field) {
+		return nil
+	}
+	id := field[seed-1]
+	return &id
+}
+
+// buildBracketMatches lays out every match for a bracket of the given size
+// with winner and loser routing filled in. The winners bracket's first round
+// comes first, in bracket-line order, so callers can seed matches[0:size/2].
+//
+// Double elimination routing, with k = log2(size):
+//   - winners round 1 losers pair off in losers round 1;
+//   - winners round r > 1 losers enter losers round 2(r-1) in reverse order,
+//     which keeps early rematches apart;
+//   - losers odd rounds feed the next round one-to-one, even rounds halve;
+//   - the winners final and losers final meet in the grand final.
+func buildBracketMatches(bracketID uuid.UUID, format models.BracketFormat, size int) []models.BracketMatch {
+	k := 0
+	for 1<<k < size {
+		k++
+	}
+	var matches []models.BracketMatch
+	newMatch := func(side models.BracketSide, round, number int) models.BracketMatch {
+		return models.BracketMatch{
+			ID: uuid.New(), BracketID: bracketID, Side: string(side),
+			RoundNumber: round, MatchNumber: number, Status: string(models.BracketMatchStatusPending),
+		}
+	}
+
+	// Index the matches by (side, round) while building; slices hold indexes
+	// into matches so the routing pointers can be filled in afterwards.
+	winners := make([][]int, k+1)
+	for r := 1; r <= k; r++ {
+		for m := 0; m < size>>r; m++ {
+			winners[r] = append(winners[r], len(matches))
+			matches = append(matches, newMatch(models.BracketSideWinners, r, m+1))
+		}
+	}
+	link := func(from int, to int, slot int, loser bool) {
+		id, s := matches[to].ID, slot
+		if loser {
+			matches[from].LoserNextMatchID, matches[from].LoserNextSlot = &id, &s
+			return
+		}
+		matches[from].NextMatchID, matches[from].NextSlot = &id, &s
+	}
+	for r := 1; r < k; r++ {
+		for m, idx := range winners[r] {
+			link(idx, winners[r+1][m/2], m%2+1, false)
+		}
+	}
+	if format != models.BracketFormatDoubleElimination {
+		return matches
+	}
+
+	lastLosers := 2 * (k - 1)
+	losers := make([][]int, lastLosers+1)
+	for l := 1; l <= lastLosers; l++ {
+		for j := 0; j < size>>((l+1)/2+1); j++ {
+			losers[l] = append(losers[l], len(matches))
+			matches = append(matches, newMatch(models.BracketSideLosers, l, j+1))
+		}
+	}
+	final := len(matches)
+	matches = append(matches, newMatch(models.BracketSideFinal, 1, 1))
+
+	link(winners[k][0], final, 1, false)
+	for r := 1; r <= k; r++ {
+		count := len(winners[r])
+		for m, idx := range winners[r] {
+			if r == 1 {
+				link(idx, losers[1][m/2], m%2+1, true)
+			} else {
+				link(idx, losers[2*(r-1)][count-1-m], 2, true)
+			}
+		}
+	}
+	for l := 1; l <= lastLosers; l++ {
+		for j, idx := range losers[l] {
+			switch {
+			case l == lastLosers:
+				link(idx, final, 2, false)
+			case l%2 == 1:
+				link(idx, losers[l+1][j], 1, false)
+			default:
+				link(idx, losers[l+1][j/2], j%2+1, false)
+			}
+		}
+	}
+	return matches
+}
+
+// finishBracketMatch records a decided match and routes both players onward.
+// Returns ErrBracketMatchNotReady if the match was no longer pending, so a
+// match is never decided (and its players routed) twice.
+func finishBracketMatch(tx *gorm.DB, match *models.BracketMatch, winnerID, loserID uuid.UUID, result *string) error {
+	now := time.Now()
+	res := tx.Model(&models.BracketMatch{}).
+		Where("id = ? AND status = ?", match.ID, models.BracketMatchStatusPending).
+		Updates(map[string]any{
+			"status":       models.BracketMatchStatusCompleted,
+			"winner_id":    winnerID,
+			"result":       result,
+			"completed_at": now,
+			"updated_at":   now,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("record match result: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrBracketMatchNotReady
+	}
+	if err := routeBracketPlayer(tx, match.NextMatchID, match.NextSlot, &winnerID); err != nil {
+		return err
+	}
+	return routeBracketPlayer(tx, match.LoserNextMatchID, match.LoserNextSlot, &loserID)
+}
+
+// routeBracketPlayer places a player into a slot of a later match. A nil
+// target (the final, or no losers bracket) is a no-op.
+func routeBracketPlayer(tx *gorm.DB, matchID *uuid.UUID, slot *int, playerID *uuid.UUID) error {
+	if matchID == nil || slot == nil || playerID == nil {
+		return nil
+	}
+	column := "player1_id"
+	if *slot == 2 {
+		column = "player2_id"
+	}
+	if err := tx.Model(&models.BracketMatch{}).Where("id = ?", *matchID).
+		Updates(map[string]any{column: *playerID, "updated_at": time.Now()}).Error; err != nil {
+		return fmt.Errorf("advance player: %w", err)
+	}
+	return nil
+}
+
+// settleBracket resolves byes until the bracket is stable: a pending match
+// whose feeder matches have all finished but which is still missing a player
+// becomes a bye, and whoever it has (if anyone) advances.
+func settleBracket(tx *gorm.DB, bracketID uuid.UUID) error {
+	for {
+		var matches []models.BracketMatch
+		if err := tx.Where("bracket_id = ?", bracketID).Find(&matches).Error; err != nil {
+			return fmt.Errorf("load bracket matches: %w", err)
+		}
+		// open counts, per match, the feeders that have not finished yet.
+		open := make(map[uuid.UUID]int, len(matches))
+		for _, m := range matches {
+			if m.Status != string(models.BracketMatchStatusPending) {
+				continue
+			}
+			for _, next := range []*uuid.UUID{m.NextMatchID, m.LoserNextMatchID} {
+				if next != nil {
+					open[*next]++
+				}
+			}
+		}
+		changed := false
+		for i := range matches {
+			m := &matches[i]
+			if m.Status != string(models.BracketMatchStatusPending) || open[m.ID] > 0 {
+				continue
+			}
+			if m.Player1ID != nil && m.Player2ID != nil {
+				continue
+			}
+			winner := m.Player1ID
+			if winner == nil {
+				winner = m.Player2ID
+			}
+			now := time.Now()
+			if err := tx.Model(&models.BracketMatch{}).Where("id = ?", m.ID).Updates(map[string]any{
+				"status":       models.BracketMatchStatusBye,
+				"winner_id":    winner,
+				"completed_at": now,
+				"updated_at":   now,
+			}).Error; err != nil {
+				return fmt.Errorf("record bye: %w", err)
+			}
+			if err := routeBracketPlayer(tx, m.NextMatchID, m.NextSlot, winner); err != nil {
+				return err
+			}
+			changed = true
+		}
+		if !changed {
+			return nil
+		}
+	}
+}
+
+// loadEventMatch loads a match that belongs to the event's bracket.
+func (s *BracketService) loadEventMatch(ctx context.Context, db *gorm.DB, eventID, matchID uuid.UUID) (*models.BracketMatch, error) {
+	var match models.BracketMatch
+	err := db.WithContext(ctx).
+		Joins("JOIN brackets b ON b.id = bracket_matches.bracket_id").
+		Where("bracket_matches.id = ? AND b.event_id = ?", matchID, eventID).
+		First(&match).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBracketMatchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load bracket match: %w", err)
+	}
+	return &match, nil
+}
+
+// loadMatchData returns one match as it appears in the bracket payload.
+func (s *BracketService) loadMatchData(ctx context.Context, eventID, matchID uuid.UUID) (*BracketMatchData, error) {
+	var bracket models.Bracket
+	if err := s.DB.WithContext(ctx).First(&bracket, "event_id = ?", eventID).Error; err != nil {
+		return nil, fmt.Errorf("load bracket: %w", err)
+	}
+	data, err := s.loadBracketData(ctx, &bracket)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range data.Rounds {
+		for _, m := range r.Matches {
+			if m.ID == matchID.String() {
+				return &m, nil
+			}
+		}
+	}
+	return nil, ErrBracketMatchNotFound
+}
+
+// loadBracketData builds the full bracket payload.
+func (s *BracketService) loadBracketData(ctx context.Context, bracket *models.Bracket) (*BracketData, error) {
+	type entryRow struct {
+		EventPlayerID uuid.UUID
+		UserID        uuid.UUID
+		DisplayName   string
+		AvatarURL     *string
+		Seed          int
+	}
+	var entries []entryRow
+	if err := s.DB.WithContext(ctx).Table("bracket_entries be").
+		Select("be.event_player_id, ep.user_id, u.display_name, u.avatar_url, be.seed").
+		Joins("JOIN event_players ep ON ep.id = be.event_player_id").
+		Joins("JOIN users u ON u.id = ep.user_id").
+		Where("be.bracket_id = ?", bracket.ID).
+		Order("be.seed ASC").
+		Scan(&entries).Error; err != nil {
+		return nil, fmt.Errorf("load bracket entries: %w", err)
+	}
+	var matches []models.BracketMatch
+	if err := s.DB.WithContext(ctx).Where("bracket_id = ?", bracket.ID).Find(&matches).Error; err != nil {
+		return nil, fmt.Errorf("load bracket matches: %w", err)
+	}
+
+	out := &BracketData{
+		ID: bracket.ID.String(), EventID: bracket.EventID.String(),
+		Format: bracket.Format, Seeding: bracket.Seeding, Size: bracket.Size,
+		Entries: make([]BracketPlayer, 0, len(entries)),
+	}
+	players := make(map[uuid.UUID]*BracketPlayer, len(entries))
+	for _, e := range entries {
+		out.Entries = append(out.Entries, BracketPlayer{
+			EventPlayerID: e.EventPlayerID.String(), UserID: e.UserID.String(),
+			DisplayName: e.DisplayName, AvatarURL: e.AvatarURL, Seed: e.Seed,
+		})
+	}
+	for i := range out.Entries {
+		players[entries[i].EventPlayerID] = &out.Entries[i]
+	}
+	player := func(id *uuid.UUID) *BracketPlayer {
+		if id == nil {
+			return nil
+		}
+		p, ok := players[*id]
+		if !ok {
+			return nil
+		}
+		cp := *p
+		return &cp
+	}
+
+	sideOrder := map[string]int{
+		string(models.BracketSideWinners): 0, string(models.BracketSideLosers): 1, string(models.BracketSideFinal): 2,
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Side != b.Side {
+			return sideOrder[a.Side] < sideOrder[b.Side]
+		}
+		if a.RoundNumber != b.RoundNumber {
+			return a.RoundNumber < b.RoundNumber
+		}
+		return a.MatchNumber < b.MatchNumber
+	})
+	for _, m := range matches {
+		n := len(out.Rounds)
+		if n == 0 || out.Rounds[n-1].Side != m.Side || out.Rounds[n-1].RoundNumber != m.RoundNumber {
+			out.Rounds = append(out.Rounds, BracketRoundData{Side: m.Side, RoundNumber: m.RoundNumber})
+			n++
+		}
+		out.Rounds[n-1].Matches = append(out.Rounds[n-1].Matches, BracketMatchData{
+			ID: m.ID.String(), Side: m.Side, RoundNumber: m.RoundNumber, MatchNumber: m.MatchNumber,
+			Status:              bracketMatchStatus(m),
+			Player1:             player(m.Player1ID),
+			Player2:             player(m.Player2ID),
+			WinnerEventPlayerID: uuidString(m.WinnerID),
+			Result:              m.Result,
+			RoundID:             uuidString(m.RoundID),
+			NextMatchID:         uuidString(m.NextMatchID),
+			LoserNextMatchID:    uuidString(m.LoserNextMatchID),
+			CompletedAt:         m.CompletedAt,
+		})
+		// The only match without a next match is the final; its winner takes the bracket.
+		if m.NextMatchID == nil {
+			out.Champion = player(m.WinnerID)
+		}
+	}
+	return out, nil
+}
+
+// bracketMatchStatus derives the display status of a stored match.
+func bracketMatchStatus(m models.BracketMatch) string {
+	switch {
+	case m.Status != string(models.BracketMatchStatusPending):
+		return m.Status
+	case m.Player1ID == nil || m.Player2ID == nil:
+		return "waiting"
+	case m.RoundID != nil:
+		return "scheduled"
+	default:
+		return "ready"
+	}
+}
+
+// bracketMatchName is the default round name for a scheduled match.
+func bracketMatchName(m *models.BracketMatch) string {
+	switch models.BracketSide(m.Side) {
+	case models.BracketSideFinal:
+		return "Bracket: Grand Final"
+	case models.BracketSideLosers:
+		return fmt.Sprintf("Bracket: Losers R%d M%d", m.RoundNumber, m.MatchNumber)
+	default:
+		return fmt.Sprintf("Bracket: R%d M%d", m.RoundNumber, m.MatchNumber)
+	}
+}
+
+// uuidString formats an optional UUID for JSON.
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
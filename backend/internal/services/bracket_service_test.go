@@ -0,0 +1,296 @@
+// services/bracket_service_test.go
+// Integration tests for BracketService (bracket_service.go) and the bracket
+// advancement hook in RoundService.Update. Uses testutil.NewTestDB to spin up
+// an ephemeral Postgres container — Docker must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// tournamentField creates a tournament event with n registered players and
+// returns the event, its organizer, and the players' user IDs in seed order.
+func tournamentField(t *testing.T, db *gorm.DB, n int) (models.Event, uuid.UUID, []uuid.UUID) {
+	t.Helper()
+	organizer := seedUser(t, db, "bracketOrganizer")
+	item, err := services.NewEventService(db).Create(context.Background(), services.CreateEventInput{
+		Name: "Match Play Championship", EventType: "tournament", CreatedBy: organizer.ID,
+	})
+	require.NoError(t, err)
+	players := make([]uuid.UUID, n)
+	for i := range players {
+		u := seedUser(t, db, fmt.Sprintf("seed%d", i+1))
+		addEventMember(t, db, item.Event.ID, u.ID)
+		players[i] = u.ID
+	}
+	return item.Event, organizer.ID, players
+}
+
+// newBracketSvc wires a BracketService with its dependencies.
+func newBracketSvc(db *gorm.DB) *services.BracketService {
+	eventSvc := services.NewEventService(db)
+	return services.NewBracketService(db, eventSvc, services.NewRoundService(db, eventSvc))
+}
+
+// manualBracket seeds players in the given order.
+func manualBracket(t *testing.T, svc *services.BracketService, event models.Event, orgID uuid.UUID, format string, players []uuid.UUID) *services.BracketData {
+	t.Helper()
+	bracket, err := svc.Create(context.Background(), event.ID, orgID, "user", services.CreateBracketInput{
+		Format: format, Seeding: string(models.BracketSeedingManual), PlayerIDs: players,
+	})
+	require.NoError(t, err)
+	return bracket
+}
+
+// decideRound records player1 as the winner of every ready match in a round
+// column and returns the refreshed bracket.
+func decideRound(t *testing.T, svc *services.BracketService, event models.Event, orgID uuid.UUID, side string, round int) *services.BracketData {
+	t.Helper()
+	ctx := context.Background()
+	bracket, err := svc.Get(ctx, event.ID, orgID, "user")
+	require.NoError(t, err)
+	for _, r := range bracket.Rounds {
+		if r.Side != side || r.RoundNumber != round {
+			continue
+		}
+		for _, m := range r.Matches {
+			if m.Status != "ready" {
+				continue
+			}
+			_, err := svc.RecordResult(ctx, event.ID, uuid.MustParse(m.ID), orgID, "user",
+				uuid.MustParse(m.Player1.EventPlayerID), nil)
+			require.NoError(t, err)
+		}
+	}
+	bracket, err = svc.Get(ctx, event.ID, orgID, "user")
+	require.NoError(t, err)
+	return bracket
+}
+
+// ─── Create ───────────────────────────────────────────────────────────────────
+
+func TestBracketService_Create_RejectsNonTournament(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	eventSvc := services.NewEventService(db)
+	organizer := seedUser(t, db, "casualOrganizer")
+	event := seedEvent(t, eventSvc, organizer.ID)
+
+	_, err := newBracketSvc(db).Create(context.Background(), event.ID, organizer.ID, "user", services.CreateBracketInput{})
+	var ve *services.ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestBracketService_Create_TopSeedGetsBye(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	event, orgID, players := tournamentField(t, db, 3)
+
+	bracket := manualBracket(t, newBracketSvc(db), event, orgID, "", players)
+	assert.Equal(t, 4, bracket.Size)
+	require.Len(t, bracket.Entries, 3)
+	assert.Equal(t, players[0].String(), bracket.Entries[0].UserID)
+
+	round1 := bracket.Rounds[0].Matches
+	require.Len(t, round1, 2)
+	assert.Equal(t, "bye", round1[0].Status)
+	assert.Equal(t, "ready", round1[1].Status) // seeds 2 v 3
+
+	// Seed 1 is already waiting in the final.
+	final := bracket.Rounds[1].Matches[0]
+	assert.Equal(t, "waiting", final.Status)
+	require.NotNil(t, final.Player1)
+	assert.Equal(t, 1, final.Player1.Seed)
+}
+
+func TestBracketService_Create_SecondBracketConflicts(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newBracketSvc(db)
+	event, orgID, players := tournamentField(t, db, 2)
+	manualBracket(t, svc, event, orgID, "", players)
+
+	_, err := svc.Create(context.Background(), event.ID, orgID, "user", services.CreateBracketInput{PlayerIDs: players})
+	assert.ErrorIs(t, err, services.ErrBracketExists)
+}
+
+func TestBracketService_Get_NonMemberForbidden(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newBracketSvc(db)
+	event, orgID, players := tournamentField(t, db, 2)
+	manualBracket(t, svc, event, orgID, "", players)
+	stranger := seedUser(t, db, "stranger")
+
+	_, err := svc.Get(context.Background(), event.ID, stranger.ID, "user")
+	assert.ErrorIs(t, err, services.ErrEventNotMember)
+}
+
+// ─── Results ──────────────────────────────────────────────────────────────────
+
+func TestBracketService_RecordResult_CrownsChampion(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newBracketSvc(db)
+	event, orgID, players := tournamentField(t, db, 3)
+	manualBracket(t, svc, event, orgID, "", players)
+
+	bracket := decideRound(t, svc, event, orgID, "winners", 1)
+	final := bracket.Rounds[1].Matches[0]
+	require.Equal(t, "ready", final.Status)
+	assert.Equal(t, 2, final.Player2.Seed)
+
+	bracket = decideRound(t, svc, event, orgID, "winners", 2)
+	require.NotNil(t, bracket.Champion)
+	assert.Equal(t, 1, bracket.Champion.Seed)
+}
+
+func TestBracketService_RecordResult_WinnerMustBeInMatch(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newBracketSvc(db)
+	event, orgID, players := tournamentField(t, db, 2)
+	bracket := manualBracket(t, svc, event, orgID, "", players)
+
+	_, err := svc.RecordResult(context.Background(), event.ID, uuid.MustParse(bracket.Rounds[0].Matches[0].ID),
+		orgID, "user", uuid.New(), nil)
+	var ve *services.ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestBracketService_DoubleElimination_LoserGetsSecondLife(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newBracketSvc(db)
+	event, orgID, players := tournamentField(t, db, 4)
+	manualBracket(t, svc, event, orgID, string(models.BracketFormatDoubleElimination), players)
+
+	decideRound(t, svc, event, orgID, "winners", 1) // seeds 1 and 2 win
+	decideRound(t, svc, event, orgID, "losers", 1)  // seed 4 beats seed 3
+	decideRound(t, svc, event, orgID, "winners", 2) // seed 1 beats seed 2
+	bracket := decideRound(t, svc, event, orgID, "losers", 2)
+
+	// Seed 4 lost in round 1 but came through the losers bracket (beating seeds
+	// 3 and 2) to meet unbeaten seed 1 in the grand final.
+	final := bracket.Rounds[len(bracket.Rounds)-1]
+	require.Equal(t, "final", final.Side)
+	gf := final.Matches[0]
+	assert.Equal(t, "ready", gf.Status)
+	assert.Equal(t, 1, gf.Player1.Seed)
+	assert.Equal(t, 4, gf.Player2.Seed)
+	assert.Nil(t, bracket.Champion)
+}
+
+// ─── Scheduling and automatic advancement ─────────────────────────────────────
+
+func TestBracketService_CompletedMatchRoundAdvancesWinner(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	svc := newBracketSvc(db)
+	event, orgID, players := tournamentField(t, db, 2)
+	bracket := manualBracket(t, svc, event, orgID, "", players)
+	course, tee := seedCourseWithTee(t, db, "Bracket Course")
+	seedHoles(t, db, tee.ID)
+
+	courseID, teeID := course.ID.String(), tee.ID.String()
+	match, err := svc.ScheduleMatch(ctx, event.ID, uuid.MustParse(bracket.Rounds[0].Matches[0].ID), orgID, "user",
+		services.ScheduleRoundInput{ScheduledDate: "2026-06-01", CourseID: &courseID, DefaultTeeID: &teeID})
+	require.NoError(t, err)
+	assert.Equal(t, "scheduled", match.Status)
+	require.NotNil(t, match.RoundID)
+	roundID := uuid.MustParse(*match.RoundID)
+
+	var round models.Round
+	require.NoError(t, db.First(&round, "id = ?", roundID).Error)
+	assert.Equal(t, models.ScoringFormatMatchPlay, round.ScoringFormat)
+
+	var rps []models.RoundPlayer
+	require.NoError(t, db.Where("round_id = ?", roundID).Find(&rps).Error)
+	require.Len(t, rps, 2)
+	for _, rp := range rps {
+		overrides := map[int]int{}
+		if rp.UserID == players[1] {
+			overrides = map[int]int{1: 3, 2: 3} // seed 2 wins the first two holes
+		}
+		enterCard(t, db, rp.ID, orgID, overrides)
+	}
+
+	completed := string(models.RoundStatusCompleted)
+	_, err = services.NewRoundService(db, services.NewEventService(db)).
+		Update(ctx, roundID, orgID, "user", services.UpdateRoundInput{Status: &completed})
+	require.NoError(t, err)
+
+	bracket, err = svc.Get(ctx, event.ID, orgID, "user")
+	require.NoError(t, err)
+	final := bracket.Rounds[0].Matches[0]
+	assert.Equal(t, "completed", final.Status)
+	require.NotNil(t, final.Result)
+	assert.Equal(t, "2&1", *final.Result)
+	require.NotNil(t, bracket.Champion)
+	assert.Equal(t, players[1].String(), bracket.Champion.UserID)
+
+	// A bracket with a played match can't be thrown away.
+	assert.ErrorIs(t, svc.Delete(ctx, event.ID, orgID, "user"), services.ErrBracketStarted)
+
+	// Reopening and completing the round again doesn't decide the match twice.
+	var decided models.BracketMatch
+	require.NoError(t, db.First(&decided, "id = ?", final.ID).Error)
+	roundSvc := services.NewRoundService(db, services.NewEventService(db))
+	active := string(models.RoundStatusActive)
+	_, err = roundSvc.Update(ctx, roundID, orgID, "user", services.UpdateRoundInput{Status: &active})
+	require.NoError(t, err)
+	_, err = roundSvc.Update(ctx, roundID, orgID, "user", services.UpdateRoundInput{Status: &completed})
+	require.NoError(t, err)
+	var stored models.BracketMatch
+	require.NoError(t, db.First(&stored, "id = ?", final.ID).Error)
+	require.NotNil(t, stored.CompletedAt)
+	assert.True(t, stored.CompletedAt.Equal(*decided.CompletedAt), "completed once")
+}
+
+func TestBracketService_HandDecidedMatchKeptWhenRoundCompletes(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	svc := newBracketSvc(db)
+	event, orgID, players := tournamentField(t, db, 2)
+	bracket := manualBracket(t, svc, event, orgID, "", players)
+	course, tee := seedCourseWithTee(t, db, "Bracket Course")
+	seedHoles(t, db, tee.ID)
+
+	courseID, teeID := course.ID.String(), tee.ID.String()
+	matchID := uuid.MustParse(bracket.Rounds[0].Matches[0].ID)
+	match, err := svc.ScheduleMatch(ctx, event.ID, matchID, orgID, "user",
+		services.ScheduleRoundInput{ScheduledDate: "2026-06-01", CourseID: &courseID, DefaultTeeID: &teeID})
+	require.NoError(t, err)
+	roundID := uuid.MustParse(*match.RoundID)
+	var rps []models.RoundPlayer
+	require.NoError(t, db.Where("round_id = ?", roundID).Find(&rps).Error)
+	for _, rp := range rps {
+		overrides := map[int]int{}
+		if rp.UserID == players[1] {
+			overrides = map[int]int{1: 3} // seed 2 would win on the cards
+		}
+		enterCard(t, db, rp.ID, orgID, overrides)
+	}
+
+	// Seed 2 conceded before the round was closed out.
+	var seed1 models.EventPlayer
+	require.NoError(t, db.First(&seed1, "event_id = ? AND user_id = ?", event.ID, players[0]).Error)
+	_, err = svc.RecordResult(ctx, event.ID, matchID, orgID, "user", seed1.ID, nil)
+	require.NoError(t, err)
+
+	completed := string(models.RoundStatusCompleted)
+	_, err = services.NewRoundService(db, services.NewEventService(db)).
+		Update(ctx, roundID, orgID, "user", services.UpdateRoundInput{Status: &completed})
+	require.NoError(t, err)
+
+	bracket, err = svc.Get(ctx, event.ID, orgID, "user")
+	require.NoError(t, err)
+	require.NotNil(t, bracket.Champion)
+	assert.Equal(t, players[0].String(), bracket.Champion.UserID, "the hand-recorded result stands")
+}
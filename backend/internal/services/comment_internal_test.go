@@ -0,0 +1,56 @@
+// services/comment_internal_test.go
+// White-box tests for the unexported comment helpers in comment_service.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run TestBuildCommentThreads -v
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+func TestBuildCommentThreads(t *testing.T) {
+	caller, other := uuid.New(), uuid.New()
+	now := time.Now()
+	deleted := now
+	comment := func(author uuid.UUID, parent *uuid.UUID, gone bool) models.RoundComment {
+		c := models.RoundComment{ID: uuid.New(), AuthorID: author, ParentID: parent, Body: "x", CreatedAt: now,
+			Author: models.User{ID: author, DisplayName: "Player"}}
+		if gone {
+			c.DeletedAt = &deleted
+		}
+		return c
+	}
+	kept := comment(other, nil, true)
+	gone := comment(other, nil, true)
+	live := comment(other, nil, false)
+	rows := []models.RoundComment{
+		kept, gone, live,
+		comment(caller, &kept.ID, false),
+		comment(other, &kept.ID, true),
+	}
+
+	data := buildCommentThreads(uuid.New(), rows, caller, false)
+	assert.Equal(t, 2, data.CommentCount)
+	require.Len(t, data.Threads, 2, "a deleted starter with no live replies is dropped")
+
+	placeholder := data.Threads[0]
+	assert.Equal(t, kept.ID.String(), placeholder.ID)
+	assert.True(t, placeholder.Deleted)
+	assert.Empty(t, placeholder.Body)
+	require.Len(t, placeholder.Replies, 1)
+	assert.True(t, placeholder.Replies[0].CanDelete, "the caller wrote it")
+
+	assert.False(t, data.Threads[1].CanDelete)
+	assert.NotNil(t, data.Threads[1].Replies, "an empty thread lists [] replies")
+	assert.True(t, buildCommentThreads(uuid.New(), rows, caller, true).Threads[1].CanDelete, "organizers moderate")
+}
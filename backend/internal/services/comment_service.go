@@ -0,0 +1,420 @@
+// services/comment_service.go
+// CommentService owns comments and emoji reactions on completed rounds, on the
+// round as a whole or on one of its holes.
+//
+// Like the scorecard, a completed round's comments and reactions are open to
+// any signed-in user, so people who follow a player from the feed can join
+// in. Comments thread one level deep: replying to a reply joins the thread it
+// belongs to. Authors delete their own comments and the round's organizers
+// moderate anyone's. Deletes are soft: a removed comment that has replies
+// stays as a placeholder so the thread keeps its shape, otherwise it's no
+// longer listed.
+//
+// Round lists and the round detail carry comment and reaction counts from
+// roundEngagementCounts.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrCommentNotFound is returned when a comment doesn't exist on the round
+	// or has been deleted.
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrCommentForbidden is returned when deleting someone else's comment
+	// without being a round organizer.
+	ErrCommentForbidden = errors.New("only the author or a round organizer can delete this comment")
+)
+
+// maxCommentLen caps a comment's body, in characters.
+const maxCommentLen = 1000
+
+// reactionEmoji lists the accepted reactions, in the order the app offers
+// them.
+var reactionEmoji = []string{"👍", "👏", "🔥", "😂", "😮", "😬", "🎯", "🦅", "⛳", "💀"}
+
+// ─── Inputs and DTOs ──────────────────────────────────────────────────────────
+
+// AddCommentInput is a new comment. HoleNumber nil means the whole round;
+// ParentID replies to a comment, and the reply takes the parent's hole.
+type AddCommentInput struct {
+	Body       string
+	HoleNumber *int
+	ParentID   *uuid.UUID
+}
+
+// CommentData is one comment. A deleted comment listed as a thread's
+// placeholder has Deleted set and no author or body.
+type CommentData struct {
+	ID         string  `json:"id"`
+	HoleNumber *int    `json:"hole_number"`
+	ParentID   *string `json:"parent_id"`
+	AuthorID   *string `json:"author_id"`
+	AuthorName *string `json:"author_name"`
+	AvatarURL  *string `json:"avatar_url"`
+	Body       string  `json:"body"`
+	Deleted    bool    `json:"deleted"`
+	CanDelete  bool    `json:"can_delete"`
+	CreatedAt  string  `json:"created_at"` // RFC 3339
+}
+
+// CommentThread is a thread-starting comment and its replies, oldest first.
+type CommentThread struct {
+	CommentData
+	Replies []CommentData `json:"replies"`
+}
+
+// RoundCommentsData is a round's comment threads, oldest first.
+type RoundCommentsData struct {
+	RoundID      string          `json:"round_id"`
+	CommentCount int             `json:"comment_count"` // comments not deleted
+	CanModerate  bool            `json:"can_moderate"`
+	Threads      []CommentThread `json:"threads"`
+}
+
+// ReactionCount is how many people left one emoji on the round or a hole, and
+// whether the caller is one of them.
+type ReactionCount struct {
+	HoleNumber *int   `json:"hole_number"` // nil = the whole round
+	Emoji      string `json:"emoji"`
+	Count      int    `json:"count"`
+	Reacted    bool   `json:"reacted"`
+}
+
+// RoundReactionsData is every reaction on a round: the round's own first,
+// then by hole, most popular first within each.
+type RoundReactionsData struct {
+	RoundID   string          `json:"round_id"`
+	Emoji     []string        `json:"emoji"` // the accepted reactions
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+// RoundEngagement is a round's comment and reaction totals, holes included.
+type RoundEngagement struct {
+	CommentCount  int
+	ReactionCount int
+}
+
+// ─── Service ──────────────────────────────────────────────────────────────────
+
+// CommentService owns round comments and reactions.
+type CommentService struct {
+	DB       *gorm.DB
+	RoundSvc *RoundService
+}
+
+// NewCommentService constructs a CommentService.
+func NewCommentService(db *gorm.DB, roundSvc *RoundService) *CommentService {
+	return &CommentService{DB: db, RoundSvc: roundSvc}
+}
+
+// ListComments returns a round's comment threads. hole narrows them to one
+// hole; nil lists every thread, round and holes alike.
+func (s *CommentService) ListComments(ctx context.Context, roundID, callerID uuid.UUID, callerRole string, hole *int) (*RoundCommentsData, error) {
+	isOrg, err := s.RoundSvc.IsRoundOrganizer(ctx, roundID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	q := s.DB.WithContext(ctx).Preload("Author").Where("round_id = ?", roundID)
+	if hole != nil {
+		q = q.Where("hole_number = ?", *hole)
+	}
+	var rows []models.RoundComment
+	if err := q.Order("created_at ASC, id ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load comments: %w", err)
+	}
+	return buildCommentThreads(roundID, rows, callerID, isOrg), nil
+}
+
+// AddComment posts a comment on a completed round or one of its holes.
+func (s *CommentService) AddComment(ctx context.Context, roundID, callerID uuid.UUID, in AddCommentInput) (*CommentData, error) {
+	in.Body = strings.TrimSpace(in.Body)
+	if in.Body == "" {
+		return nil, &ValidationError{Field: "body", Message: "body is required"}
+	}
+	if utf8.RuneCountInString(in.Body) > maxCommentLen {
+		return nil, &ValidationError{Field: "body", Message: fmt.Sprintf("body must be at most %d characters", maxCommentLen)}
+	}
+	round, err := s.loadCompletedRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := models.RoundComment{RoundID: roundID, AuthorID: callerID, Body: in.Body, HoleNumber: in.HoleNumber}
+	if in.ParentID != nil {
+		var parent models.RoundComment
+		if err := s.DB.WithContext(ctx).
+			First(&parent, "id = ? AND round_id = ? AND deleted_at IS NULL", *in.ParentID, roundID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrCommentNotFound
+			}
+			return nil, fmt.Errorf("load parent comment: %w", err)
+		}
+		if in.HoleNumber != nil && !sameHole(in.HoleNumber, parent.HoleNumber) {
+			return nil, &ValidationError{Field: "hole_number", Message: "a reply is on the same hole as its thread"}
+		}
+		comment.HoleNumber = parent.HoleNumber
+		comment.ParentID = &parent.ID
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID // join the thread, not a deeper one
+		}
+	} else if err := validateRoundHole(round, in.HoleNumber); err != nil {
+		return nil, err
+	}
+
+	if err := s.DB.WithContext(ctx).Omit(clause.Associations).Create(&comment).Error; err != nil {
+		return nil, fmt.Errorf("create comment: %w", err)
+	}
+	if err := s.DB.WithContext(ctx).First(&comment.Author, "id = ?", callerID).Error; err != nil {
+		return nil, fmt.Errorf("load author: %w", err)
+	}
+	data := buildCommentData(comment, callerID, false)
+	return &data, nil
+}
+
+// DeleteComment soft-deletes a comment. The author or a round organizer may
+// delete it. Idempotent.
+func (s *CommentService) DeleteComment(ctx context.Context, roundID, commentID, callerID uuid.UUID, callerRole string) error {
+	var comment models.RoundComment
+	if err := s.DB.WithContext(ctx).First(&comment, "id = ? AND round_id = ?", commentID, roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCommentNotFound
+		}
+		return fmt.Errorf("load comment: %w", err)
+	}
+	if comment.AuthorID != callerID {
+		isOrg, err := s.RoundSvc.IsRoundOrganizer(ctx, roundID, callerID, callerRole)
+		if err != nil {
+			return err
+		}
+		if !isOrg {
+			return ErrCommentForbidden
+		}
+	}
+	if comment.DeletedAt != nil {
+		return nil
+	}
+	if err := s.DB.WithContext(ctx).Model(&comment).Updates(map[string]any{
+		"deleted_at": time.Now().UTC(),
+		"deleted_by": callerID,
+	}).Error; err != nil {
+		return fmt.Errorf("delete comment: %w", err)
+	}
+	return nil
+}
+
+// Reactions returns every reaction on a round.
+func (s *CommentService) Reactions(ctx context.Context, roundID, callerID uuid.UUID) (*RoundReactionsData, error) {
+	var exists int64
+	if err := s.DB.WithContext(ctx).Model(&models.Round{}).Where("id = ?", roundID).Count(&exists).Error; err != nil {
+		return nil, fmt.Errorf("load round: %w", err)
+	}
+	if exists == 0 {
+		return nil, ErrRoundNotFound
+	}
+	return s.loadReactions(ctx, roundID, callerID)
+}
+
+// React leaves the caller's emoji on a completed round or one of its holes.
+// Leaving the same one twice is a no-op.
+func (s *CommentService) React(ctx context.Context, roundID, callerID uuid.UUID, hole *int, emoji string) (*RoundReactionsData, error) {
+	if !slices.Contains(reactionEmoji, emoji) {
+		return nil, &ValidationError{Field: "emoji", Message: "emoji must be one of " + strings.Join(reactionEmoji, " ")}
+	}
+	round, err := s.loadCompletedRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRoundHole(round, hole); err != nil {
+		return nil, err
+	}
+	reaction := models.RoundReaction{RoundID: roundID, HoleNumber: hole, UserID: callerID, Emoji: emoji}
+	if err := s.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&reaction).Error; err != nil {
+		return nil, fmt.Errorf("create reaction: %w", err)
+	}
+	return s.loadReactions(ctx, roundID, callerID)
+}
+
+// Unreact removes the caller's emoji from a round or hole. Idempotent.
+func (s *CommentService) Unreact(ctx context.Context, roundID, callerID uuid.UUID, hole *int, emoji string) (*RoundReactionsData, error) {
+	q := s.DB.WithContext(ctx).Where("round_id = ? AND user_id = ? AND emoji = ?", roundID, callerID, emoji)
+	if hole == nil {
+		q = q.Where("hole_number IS NULL")
+	} else {
+		q = q.Where("hole_number = ?", *hole)
+	}
+	if err := q.Delete(&models.RoundReaction{}).Error; err != nil {
+		return nil, fmt.Errorf("delete reaction: %w", err)
+	}
+	return s.Reactions(ctx, roundID, callerID)
+}
+
+// ─── Internals ────────────────────────────────────────────────────────────────
+
+// loadCompletedRound loads a round with its played holes, or returns
+// ErrRoundNotCompleted while it's still open.
+func (s *CommentService) loadCompletedRound(ctx context.Context, roundID uuid.UUID) (*models.Round, error) {
+	var round models.Round
+	if err := s.DB.WithContext(ctx).Preload("DefaultTee.Holes").First(&round, "id = ?", roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundNotFound
+		}
+		return nil, fmt.Errorf("load round: %w", err)
+	}
+	if round.Status != models.RoundStatusCompleted {
+		return nil, ErrRoundNotCompleted
+	}
+	return &round, nil
+}
+
+// loadReactions counts a round's reactions per hole and emoji.
+func (s *CommentService) loadReactions(ctx context.Context, roundID, callerID uuid.UUID) (*RoundReactionsData, error) {
+	type countRow struct {
+		HoleNumber *int
+		Emoji      string
+		Count      int
+		Reacted    bool
+	}
+	var rows []countRow
+	if err := s.DB.WithContext(ctx).Model(&models.RoundReaction{}).
+		Select("hole_number, emoji, COUNT(*) AS count, BOOL_OR(user_id = ?) AS reacted", callerID).
+		Where("round_id = ?", roundID).
+		Group("hole_number, emoji").
+		Order("hole_number ASC NULLS FIRST, count DESC, MIN(created_at) ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("count reactions: %w", err)
+	}
+	out := &RoundReactionsData{RoundID: roundID.String(), Emoji: reactionEmoji, Reactions: make([]ReactionCount, 0, len(rows))}
+	for _, r := range rows {
+		out.Reactions = append(out.Reactions, ReactionCount(r))
+	}
+	return out, nil
+}
+
+// roundEngagementCounts returns the comment (not deleted) and reaction totals
+// for each round; rounds with neither are absent from the map.
+func roundEngagementCounts(ctx context.Context, db *gorm.DB, roundIDs []uuid.UUID) (map[uuid.UUID]RoundEngagement, error) {
+	out := make(map[uuid.UUID]RoundEngagement)
+	if len(roundIDs) == 0 {
+		return out, nil
+	}
+	type countRow struct {
+		RoundID uuid.UUID
+		Count   int
+	}
+	var comments, reactions []countRow
+	if err := db.WithContext(ctx).Model(&models.RoundComment{}).
+		Select("round_id, COUNT(*) AS count").
+		Where("round_id IN ? AND deleted_at IS NULL", roundIDs).
+		Group("round_id").Scan(&comments).Error; err != nil {
+		return nil, fmt.Errorf("count comments: %w", err)
+	}
+	if err := db.WithContext(ctx).Model(&models.RoundReaction{}).
+		Select("round_id, COUNT(*) AS count").
+		Where("round_id IN ?", roundIDs).
+		Group("round_id").Scan(&reactions).Error; err != nil {
+		return nil, fmt.Errorf("count reactions: %w", err)
+	}
+	for _, c := range comments {
+		e := out[c.RoundID]
+		e.CommentCount = c.Count
+		out[c.RoundID] = e
+	}
+	for _, r := range reactions {
+		e := out[r.RoundID]
+		e.ReactionCount = r.Count
+		out[r.RoundID] = e
+	}
+	return out, nil
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// validateRoundHole rejects a hole the round doesn't play. nil (the whole
+// round) is always fine.
+func validateRoundHole(round *models.Round, hole *int) error {
+	if hole == nil {
+		return nil
+	}
+	for _, h := range filterPlayedHoles(round.DefaultTee.Holes, round.NineHoleSelection) {
+		if h.HoleNumber == *hole {
+			return nil
+		}
+	}
+	return &ValidationError{Field: "hole_number", Message: "hole_number is not a hole played in this round"}
+}
+
+// sameHole reports whether two optional hole numbers are equal.
+func sameHole(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// buildCommentThreads groups a round's comments (oldest first, Author
+// loaded) into threads. Deleted replies are dropped; a deleted thread starter
+// stays as a placeholder only while it has replies.
+func buildCommentThreads(roundID uuid.UUID, rows []models.RoundComment, callerID uuid.UUID, isOrg bool) *RoundCommentsData {
+	out := &RoundCommentsData{RoundID: roundID.String(), CanModerate: isOrg, Threads: []CommentThread{}}
+	replies := make(map[uuid.UUID][]CommentData)
+	for _, c := range rows {
+		if c.ParentID != nil && c.DeletedAt == nil {
+			replies[*c.ParentID] = append(replies[*c.ParentID], buildCommentData(c, callerID, isOrg))
+		}
+		if c.DeletedAt == nil {
+			out.CommentCount++
+		}
+	}
+	for _, c := range rows {
+		if c.ParentID != nil {
+			continue
+		}
+		thread := CommentThread{CommentData: buildCommentData(c, callerID, isOrg), Replies: replies[c.ID]}
+		if thread.Replies == nil {
+			if c.DeletedAt != nil {
+				continue
+			}
+			thread.Replies = []CommentData{}
+		}
+		out.Threads = append(out.Threads, thread)
+	}
+	return out
+}
+
+// buildCommentData converts a comment (Author loaded) into its JSON shape,
+// blanking a deleted one.
+func buildCommentData(c models.RoundComment, callerID uuid.UUID, isOrg bool) CommentData {
+	out := CommentData{
+		ID:         c.ID.String(),
+		HoleNumber: c.HoleNumber,
+		CreatedAt:  c.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if c.ParentID != nil {
+		id := c.ParentID.String()
+		out.ParentID = &id
+	}
+	if c.DeletedAt != nil {
+		out.Deleted = true
+		return out
+	}
+	authorID := c.AuthorID.String()
+	out.AuthorID = &authorID
+	out.AuthorName = &c.Author.DisplayName
+	out.AvatarURL = c.Author.AvatarURL
+	out.Body = c.Body
+	out.CanDelete = isOrg || c.AuthorID == callerID
+	return out
+}
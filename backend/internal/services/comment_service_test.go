@@ -0,0 +1,129 @@
+// services/comment_service_test.go
+// Integration tests for CommentService: threads, moderation, soft delete,
+// reactions and the counts on round summaries. Docker must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run CommentService -v
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+func newCommentSvc(db *gorm.DB) *services.CommentService {
+	return services.NewCommentService(db, services.NewRoundService(db, services.NewEventService(db)))
+}
+
+func TestCommentService_OpensWhenCompleted(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newCommentSvc(db)
+	ctx := context.Background()
+	roundID, _, aliceRP, _ := tiedRound(t, db)
+
+	_, err := svc.AddComment(ctx, roundID, aliceRP.UserID, services.AddCommentInput{Body: "great day"})
+	assert.ErrorIs(t, err, services.ErrRoundNotCompleted)
+	_, err = svc.React(ctx, roundID, aliceRP.UserID, nil, "🔥")
+	assert.ErrorIs(t, err, services.ErrRoundNotCompleted)
+
+	completeRound(t, db, roundID)
+	hole := 19
+	_, err = svc.AddComment(ctx, roundID, aliceRP.UserID, services.AddCommentInput{Body: "what?", HoleNumber: &hole})
+	var ve *services.ValidationError
+	assert.True(t, errors.As(err, &ve), "hole 19 isn't played")
+}
+
+func TestCommentService_ThreadsAndModeration(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newCommentSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	organizer := organizerOf(t, db, event.ID)
+	completeRound(t, db, roundID)
+	stranger := seedUser(t, db, "commentStranger").ID
+
+	hole := 7
+	root, err := svc.AddComment(ctx, roundID, stranger, services.AddCommentInput{Body: "  the 11 on 7  ", HoleNumber: &hole})
+	require.NoError(t, err)
+	assert.Equal(t, "the 11 on 7", root.Body)
+	rootID := uuid.MustParse(root.ID)
+
+	reply, err := svc.AddComment(ctx, roundID, aliceRP.UserID, services.AddCommentInput{Body: "don't", ParentID: &rootID})
+	require.NoError(t, err)
+	assert.Equal(t, 7, *reply.HoleNumber, "a reply takes its thread's hole")
+	replyID := uuid.MustParse(reply.ID)
+	deeper, err := svc.AddComment(ctx, roundID, bobRP.UserID, services.AddCommentInput{Body: "ha", ParentID: &replyID})
+	require.NoError(t, err)
+	assert.Equal(t, root.ID, *deeper.ParentID, "replying to a reply joins the thread")
+
+	assert.ErrorIs(t, svc.DeleteComment(ctx, roundID, rootID, bobRP.UserID, "user"), services.ErrCommentForbidden)
+	require.NoError(t, svc.DeleteComment(ctx, roundID, rootID, organizer, "user"))
+	require.NoError(t, svc.DeleteComment(ctx, roundID, rootID, organizer, "user"), "idempotent")
+	require.NoError(t, svc.DeleteComment(ctx, roundID, uuid.MustParse(deeper.ID), bobRP.UserID, "user"))
+
+	data, err := svc.ListComments(ctx, roundID, aliceRP.UserID, "user", &hole)
+	require.NoError(t, err)
+	assert.False(t, data.CanModerate)
+	assert.Equal(t, 1, data.CommentCount)
+	require.Len(t, data.Threads, 1)
+	thread := data.Threads[0]
+	assert.True(t, thread.Deleted, "kept as a placeholder for its reply")
+	assert.Empty(t, thread.Body)
+	assert.Nil(t, thread.AuthorID)
+	require.Len(t, thread.Replies, 1, "the deleted reply isn't listed")
+	assert.True(t, thread.Replies[0].CanDelete)
+
+	_, err = svc.AddComment(ctx, roundID, aliceRP.UserID, services.AddCommentInput{Body: "late", ParentID: &rootID})
+	assert.ErrorIs(t, err, services.ErrCommentNotFound, "can't reply to a deleted comment")
+}
+
+func TestCommentService_ReactionsAndCounts(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newCommentSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	completeRound(t, db, roundID)
+
+	hole := 3
+	_, err := svc.React(ctx, roundID, aliceRP.UserID, &hole, "🎯")
+	require.NoError(t, err)
+	_, err = svc.React(ctx, roundID, aliceRP.UserID, &hole, "🎯")
+	require.NoError(t, err, "reacting twice is a no-op")
+	_, err = svc.React(ctx, roundID, bobRP.UserID, &hole, "🎯")
+	require.NoError(t, err)
+	data, err := svc.React(ctx, roundID, bobRP.UserID, nil, "👏")
+	require.NoError(t, err)
+	_, err = svc.React(ctx, roundID, bobRP.UserID, nil, "🍕")
+	var ve *services.ValidationError
+	assert.True(t, errors.As(err, &ve))
+
+	require.Len(t, data.Reactions, 2)
+	assert.Nil(t, data.Reactions[0].HoleNumber, "the round's own reactions come first")
+	assert.Equal(t, 2, data.Reactions[1].Count)
+	assert.True(t, data.Reactions[1].Reacted)
+
+	_, err = svc.AddComment(ctx, roundID, bobRP.UserID, services.AddCommentInput{Body: "good round"})
+	require.NoError(t, err)
+	items, err := services.NewEventService(db).GetRounds(ctx, event.ID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 1, items[0].Engagement.CommentCount)
+	assert.Equal(t, 3, items[0].Engagement.ReactionCount)
+
+	data, err = svc.Unreact(ctx, roundID, aliceRP.UserID, &hole, "🎯")
+	require.NoError(t, err)
+	assert.Equal(t, 1, data.Reactions[1].Count)
+	assert.False(t, data.Reactions[1].Reacted)
+}
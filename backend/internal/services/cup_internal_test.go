@@ -0,0 +1,36 @@
+// services/cup_internal_test.go
+// White-box tests for the unexported cup scoring helpers in cup_service.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestCup|TestMatchPoints' -v
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+func TestCupPointsToWin_MoreThanHalf(t *testing.T) {
+	assert.Equal(t, 14.5, cupPointsToWin(28))
+	assert.Equal(t, 3.0, cupPointsToWin(5))
+}
+
+func TestMatchPoints_WinHalveAndPending(t *testing.T) {
+	teams := []models.EventTeam{{ID: uuid.New()}, {ID: uuid.New()}}
+	completed := string(models.CupMatchStatusCompleted)
+
+	p1, p2 := matchPoints(models.CupMatch{Status: completed, WinnerTeamID: &teams[1].ID}, teams)
+	assert.Equal(t, [2]float64{0, 1}, [2]float64{p1, p2})
+
+	p1, p2 = matchPoints(models.CupMatch{Status: completed}, teams)
+	assert.Equal(t, [2]float64{0.5, 0.5}, [2]float64{p1, p2})
+
+	p1, p2 = matchPoints(models.CupMatch{Status: string(models.CupMatchStatusPending)}, teams)
+	assert.Equal(t, [2]float64{0, 0}, [2]float64{p1, p2})
+}
@@ -0,0 +1,667 @@
+// services/cup_service.go
+// Ryder Cup–style team competition within an event. An organizer names the
+// event's two teams, places members on them, and sets up sessions of four-ball,
+// foursomes or singles matches. Every match is worth one point to the winner,
+// or half a point to each team when halved; the cup score is the running total
+// across all sessions, reported with the points each team still needs to win.
+//
+// A session may be tied to one of the event's rounds. When that round is marked
+// completed (RoundService.Update), each pending match in the session is played
+// out hole by hole on net (match_play.go); four-ball sides count their better
+// ball. Foursomes partners share one ball, which per-player cards can't
+// represent, so those matches stay pending for the organizer to record by hand,
+// as do matches without enough scores. An organizer can always record or
+// correct a result by hand.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrCupTeamsNotSet — the event's two cup teams have not been named yet.
+	ErrCupTeamsNotSet = errors.New("event has no cup teams")
+	// ErrCupTeamNotFound — the team does not exist or belongs to another event.
+	ErrCupTeamNotFound = errors.New("cup team not found")
+	// ErrCupSessionNotFound — the session does not exist or belongs to another event.
+	ErrCupSessionNotFound = errors.New("cup session not found")
+	// ErrCupMatchNotFound — the match does not exist or belongs to another event.
+	ErrCupMatchNotFound = errors.New("cup match not found")
+	// ErrCupPlayerBusy — a player is already in another match in the session.
+	ErrCupPlayerBusy = errors.New("player already has a match in this session")
+)
+
+// CupTeamInput names one of the two teams in a SetTeams call.
+type CupTeamInput struct {
+	Name  string
+	Color *string
+}
+
+// CreateCupSessionInput adds a session. A blank Name defaults to "Session N".
+type CreateCupSessionInput struct {
+	Name    string
+	Format  string     // "four_ball", "foursomes" or "singles"
+	RoundID *uuid.UUID // optional; must be one of the event's rounds
+}
+
+// CreateCupMatchInput pairs players for a match. Team1UserIDs play for the
+// first team (sort order 0) and Team2UserIDs for the second; two per side for
+// four-ball and foursomes, one for singles.
+type CreateCupMatchInput struct {
+	Team1UserIDs []uuid.UUID
+	Team2UserIDs []uuid.UUID
+}
+
+// CupPlayer is one player in the cup payload.
+type CupPlayer struct {
+	EventPlayerID string  `json:"event_player_id"`
+	UserID        string  `json:"user_id"`
+	DisplayName   string  `json:"display_name"`
+	AvatarURL     *string `json:"avatar_url"`
+}
+
+// CupTeamData is one team with its score. PointsNeeded is how many more points
+// the team needs to win the cup outright (0 once it has).
+type CupTeamData struct {
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`
+	Color        *string     `json:"color"`
+	Points       float64     `json:"points"`
+	PointsNeeded float64     `json:"points_needed"`
+	Members      []CupPlayer `json:"members"`
+}
+
+// CupMatchData is one match. Team1Players play for Teams[0] of the payload.
+type CupMatchData struct {
+	ID           string      `json:"id"`
+	MatchNumber  int         `json:"match_number"`
+	Status       string      `json:"status"`
+	Team1Players []CupPlayer `json:"team1_players"`
+	Team2Players []CupPlayer `json:"team2_players"`
+	WinnerTeamID *string     `json:"winner_team_id"`
+	Halved       bool        `json:"halved"`
+	Result       *string     `json:"result"`
+	CompletedAt  *time.Time  `json:"completed_at"`
+}
+
+// CupSessionData is one session with the points each team took from it.
+type CupSessionData struct {
+	ID            string         `json:"id"`
+	SessionNumber int            `json:"session_number"`
+	Name          string         `json:"name"`
+	Format        string         `json:"format"`
+	RoundID       *string        `json:"round_id"`
+	Team1Points   float64        `json:"team1_points"`
+	Team2Points   float64        `json:"team2_points"`
+	Matches       []CupMatchData `json:"matches"`
+}
+
+// EventCup is the payload for GET /events/:id/cup. PointsToWin is more than
+// half of PointsAvailable (one point per match across every session);
+// WinnerTeamID is set once a team reaches it.
+type EventCup struct {
+	EventID         string           `json:"event_id"`
+	Teams           []CupTeamData    `json:"teams"`
+	Sessions        []CupSessionData `json:"sessions"`
+	PointsAvailable float64          `json:"points_available"`
+	PointsPlayed    float64          `json:"points_played"`
+	PointsToWin     float64          `json:"points_to_win"`
+	WinnerTeamID    *string          `json:"winner_team_id"`
+}
+
+// CupService owns event teams and cup sessions.
+// Construct once in main.go and inject into the cup handler factories.
+type CupService struct {
+	DB       *gorm.DB
+	EventSvc *EventService
+}
+
+// NewCupService builds a CupService. EventSvc provides the organizer checks.
+func NewCupService(db *gorm.DB, eventSvc *EventService) *CupService {
+	return &CupService{DB: db, EventSvc: eventSvc}
+}
+
+// ─── Queries ──────────────────────────────────────────────────────────────────
+
+// Get returns an event's teams, sessions and cup score. Non-admins must be
+// members of the event. An event without teams returns an empty cup.
+func (s *CupService) Get(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string) (*EventCup, error) {
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("load event: %w", err)
+	}
+	if models.UserRole(requesterRole) != models.UserRoleAdmin {
+		var count int64
+		if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+			Where("event_id = ? AND user_id = ?", eventID, requesterID).
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("check membership: %w", err)
+		}
+		if count == 0 {
+			return nil, ErrEventNotMember
+		}
+	}
+	return s.loadEventCup(ctx, eventID)
+}
+
+// ─── Mutations ────────────────────────────────────────────────────────────────
+
+// SetTeams names the event's two teams; the first call creates them and later
+// calls rename them in place. Caller must be an organizer (or admin) of an
+// event that is not finalized.
+func (s *CupService) SetTeams(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, teams []CupTeamInput) (*EventCup, error) {
+	if len(teams) != 2 {
+		return nil, &ValidationError{Field: "teams", Message: "a cup needs exactly 2 teams"}
+	}
+	names := make([]string, 2)
+	for i, t := range teams {
+		names[i] = strings.TrimSpace(t.Name)
+		if names[i] == "" {
+			return nil, &ValidationError{Field: "teams", Message: "every team needs a name"}
+		}
+	}
+	if strings.EqualFold(names[0], names[1]) {
+		return nil, &ValidationError{Field: "teams", Message: "team names must differ"}
+	}
+
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, t := range teams {
+			team := models.EventTeam{EventID: eventID, Name: names[i], Color: t.Color, SortOrder: i}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "event_id"}, {Name: "sort_order"}},
+				DoUpdates: clause.Assignments(map[string]any{"name": names[i], "color": t.Color, "updated_at": time.Now()}),
+			}).Create(&team).Error; err != nil {
+				return fmt.Errorf("save team %q: %w", names[i], err)
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("set cup teams: %w", txErr)
+	}
+	return s.loadEventCup(ctx, eventID)
+}
+
+// SetMemberTeam puts one member on a cup team (nil teamID takes them off).
+// Caller must be an organizer (or admin). Matches already made keep the side
+// the player was on at the time.
+func (s *CupService) SetMemberTeam(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, targetUserID uuid.UUID, teamID *uuid.UUID) error {
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return err
+	}
+	if teamID != nil {
+		var count int64
+		if err := s.DB.WithContext(ctx).Model(&models.EventTeam{}).
+			Where("id = ? AND event_id = ?", *teamID, eventID).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("check team: %w", err)
+		}
+		if count == 0 {
+			return ErrCupTeamNotFound
+		}
+	}
+	res := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+		Where("event_id = ? AND user_id = ?", eventID, targetUserID).
+		Update("event_team_id", teamID)
+	if res.Error != nil {
+		return fmt.Errorf("set member team: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+// CreateSession adds a session after the existing ones. Caller must be an
+// organizer (or admin).
+func (s *CupService) CreateSession(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, in CreateCupSessionInput) (*EventCup, error) {
+	switch models.CupSessionFormat(in.Format) {
+	case models.CupSessionFormatFourBall, models.CupSessionFormatFoursomes, models.CupSessionFormatSingles:
+	default:
+		return nil, &ValidationError{Field: "format", Message: "format must be 'four_ball', 'foursomes' or 'singles'"}
+	}
+
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	if in.RoundID != nil {
+		var count int64
+		if err := s.DB.WithContext(ctx).Model(&models.Round{}).
+			Where("id = ? AND event_id = ?", *in.RoundID, eventID).
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("check round: %w", err)
+		}
+		if count == 0 {
+			return nil, &ValidationError{Field: "round_id", Message: "round does not belong to this event"}
+		}
+	}
+
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&models.CupSession{}).Where("event_id = ?", eventID).
+			Select("COALESCE(MAX(session_number), 0)").Scan(&last).Error; err != nil {
+			return fmt.Errorf("number session: %w", err)
+		}
+		name := strings.TrimSpace(in.Name)
+		if name == "" {
+			name = fmt.Sprintf("Session %d", last+1)
+		}
+		session := models.CupSession{
+			EventID: eventID, RoundID: in.RoundID, SessionNumber: last + 1,
+			Name: name, Format: in.Format,
+		}
+		if err := tx.Omit(clause.Associations).Create(&session).Error; err != nil {
+			return fmt.Errorf("create session: %w", err)
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("create cup session: %w", txErr)
+	}
+	return s.loadEventCup(ctx, eventID)
+}
+
+// DeleteSession removes a session and its matches (and their points). Caller
+// must be an organizer (or admin).
+func (s *CupService) DeleteSession(ctx context.Context, eventID, sessionID, callerID uuid.UUID, callerRole string) error {
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return err
+	}
+	res := s.DB.WithContext(ctx).Delete(&models.CupSession{}, "id = ? AND event_id = ?", sessionID, eventID)
+	if res.Error != nil {
+		return fmt.Errorf("delete cup session: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrCupSessionNotFound
+	}
+	return nil
+}
+
+// AddMatch pairs players for a new match in a session. Each player must be a
+// member of the side's team and not already playing in the session. Caller
+// must be an organizer (or admin).
+func (s *CupService) AddMatch(ctx context.Context, eventID, sessionID, callerID uuid.UUID, callerRole string, in CreateCupMatchInput) (*EventCup, error) {
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	var session models.CupSession
+	if err := s.DB.WithContext(ctx).First(&session, "id = ? AND event_id = ?", sessionID, eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCupSessionNotFound
+		}
+		return nil, fmt.Errorf("load session: %w", err)
+	}
+	perSide := 2
+	if models.CupSessionFormat(session.Format) == models.CupSessionFormatSingles {
+		perSide = 1
+	}
+	if len(in.Team1UserIDs) != perSide || len(in.Team2UserIDs) != perSide {
+		return nil, &ValidationError{
+			Field:   "players",
+			Message: fmt.Sprintf("%s matches need %d player(s) per side", session.Format, perSide),
+		}
+	}
+	teams, err := loadCupTeams(ctx, s.DB, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var players []models.CupMatchPlayer
+	for side, userIDs := range [][]uuid.UUID{in.Team1UserIDs, in.Team2UserIDs} {
+		var members []models.EventPlayer
+		if err := s.DB.WithContext(ctx).
+			Where("event_id = ? AND user_id IN ?", eventID, userIDs).
+			Find(&members).Error; err != nil {
+			return nil, fmt.Errorf("load players: %w", err)
+		}
+		if len(members) != len(userIDs) {
+			return nil, ErrMemberNotFound
+		}
+		for _, m := range members {
+			if m.EventTeamID == nil || *m.EventTeamID != teams[side].ID {
+				return nil, &ValidationError{
+					Field:   "players",
+					Message: fmt.Sprintf("every team%d player must be on %s", side+1, teams[side].Name),
+				}
+			}
+			players = append(players, models.CupMatchPlayer{EventPlayerID: m.ID, EventTeamID: teams[side].ID})
+		}
+	}
+
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		epIDs := make([]uuid.UUID, len(players))
+		for i, p := range players {
+			epIDs[i] = p.EventPlayerID
+		}
+		var busy int64
+		if err := tx.Table("cup_match_players cmp").
+			Joins("JOIN cup_matches cm ON cm.id = cmp.match_id").
+			Where("cm.session_id = ? AND cmp.event_player_id IN ?", sessionID, epIDs).
+			Count(&busy).Error; err != nil {
+			return fmt.Errorf("check players: %w", err)
+		}
+		if busy > 0 {
+			return ErrCupPlayerBusy
+		}
+		var last int
+		if err := tx.Model(&models.CupMatch{}).Where("session_id = ?", sessionID).
+			Select("COALESCE(MAX(match_number), 0)").Scan(&last).Error; err != nil {
+			return fmt.Errorf("number match: %w", err)
+		}
+		match := models.CupMatch{SessionID: sessionID, MatchNumber: last + 1, Status: string(models.CupMatchStatusPending)}
+		if err := tx.Omit(clause.Associations).Create(&match).Error; err != nil {
+			return fmt.Errorf("create match: %w", err)
+		}
+		for i := range players {
+			players[i].MatchID = match.ID
+		}
+		if err := tx.Create(&players).Error; err != nil {
+			return fmt.Errorf("create match players: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(txErr, ErrCupPlayerBusy) {
+		return nil, txErr
+	}
+	if txErr != nil {
+		return nil, fmt.Errorf("add cup match: %w", txErr)
+	}
+	return s.loadEventCup(ctx, eventID)
+}
+
+// DeleteMatch removes a match (and its points). Caller must be an organizer
+// (or admin).
+func (s *CupService) DeleteMatch(ctx context.Context, eventID, matchID, callerID uuid.UUID, callerRole string) error {
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return err
+	}
+	if _, err := loadEventCupMatch(ctx, s.DB, eventID, matchID); err != nil {
+		return err
+	}
+	if err := s.DB.WithContext(ctx).Delete(&models.CupMatch{}, "id = ?", matchID).Error; err != nil {
+		return fmt.Errorf("delete cup match: %w", err)
+	}
+	return nil
+}
+
+// RecordMatchResult sets a match's result by hand: winnerTeamID is the winning
+// team, or nil for a halved match. Also corrects an automatic result. Caller
+// must be an organizer (or admin).
+func (s *CupService) RecordMatchResult(ctx context.Context, eventID, matchID, callerID uuid.UUID, callerRole string, winnerTeamID *uuid.UUID, result *string) (*EventCup, error) {
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	if _, err := loadEventCupMatch(ctx, s.DB, eventID, matchID); err != nil {
+		return nil, err
+	}
+	if winnerTeamID != nil {
+		var count int64
+		if err := s.DB.WithContext(ctx).Model(&models.EventTeam{}).
+			Where("id = ? AND event_id = ?", *winnerTeamID, eventID).
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("check team: %w", err)
+		}
+		if count == 0 {
+			return nil, ErrCupTeamNotFound
+		}
+	}
+	if err := completeCupMatch(s.DB.WithContext(ctx), matchID, winnerTeamID, result, false); err != nil {
+		return nil, err
+	}
+	return s.loadEventCup(ctx, eventID)
+}
+
+// scoreCupRound plays out every pending cup match in four-ball and singles
+// sessions tied to roundID. Called by RoundService.Update inside the
+// transaction that completes the round. Foursomes matches and matches missing
+// scores stay pending for the organizer.
+func scoreCupRound(ctx context.Context, db *gorm.DB, roundID uuid.UUID) error {
+	var sessions []models.CupSession
+	if err := db.WithContext(ctx).Preload("Matches.Players").
+		Where("round_id = ? AND format <> ?", roundID, models.CupSessionFormatFoursomes).
+		Find(&sessions).Error; err != nil {
+		return fmt.Errorf("load cup sessions: %w", err)
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+	teams, err := loadCupTeams(ctx, db, sessions[0].EventID)
+	if errors.Is(err, ErrCupTeamsNotSet) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	holes, err := matchHoles(ctx, db, roundID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		for _, match := range session.Matches {
+			if match.Status != string(models.CupMatchStatusPending) {
+				continue
+			}
+			epIDs := make([]uuid.UUID, len(match.Players))
+			for i, p := range match.Players {
+				epIDs[i] = p.EventPlayerID
+			}
+			nets, err := matchNetScores(ctx, db, roundID, epIDs)
+			if err != nil {
+				return err
+			}
+			var side1, side2 []map[int]int
+			for _, p := range match.Players {
+				if p.EventTeamID == teams[0].ID {
+					side1 = append(side1, nets[p.EventPlayerID])
+				} else {
+					side2 = append(side2, nets[p.EventPlayerID])
+				}
+			}
+			winner, result, ok := matchPlayResult(holes, bestBall(side1...), bestBall(side2...))
+			if !ok {
+				continue
+			}
+			var winnerTeamID *uuid.UUID
+			if winner > 0 {
+				id := teams[winner-1].ID
+				winnerTeamID = &id
+			}
+			if err := completeCupMatch(db.WithContext(ctx), match.ID, winnerTeamID, &result, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// loadCupTeams returns the event's two teams in sort order, or ErrCupTeamsNotSet.
+func loadCupTeams(ctx context.Context, db *gorm.DB, eventID uuid.UUID) ([]models.EventTeam, error) {
+	var teams []models.EventTeam
+	if err := db.WithContext(ctx).Where("event_id = ?", eventID).
+		Order("sort_order ASC").Find(&teams).Error; err != nil {
+		return nil, fmt.Errorf("load cup teams: %w", err)
+	}
+	if len(teams) != 2 {
+		return nil, ErrCupTeamsNotSet
+	}
+	return teams, nil
+}
+
+// loadEventCupMatch loads a match that belongs to one of the event's sessions.
+func loadEventCupMatch(ctx context.Context, db *gorm.DB, eventID, matchID uuid.UUID) (*models.CupMatch, error) {
+	var match models.CupMatch
+	err := db.WithContext(ctx).
+		Joins("JOIN cup_sessions cs ON cs.id = cup_matches.session_id").
+		Where("cup_matches.id = ? AND cs.event_id = ?", matchID, eventID).
+		First(&match).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCupMatchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load cup match: %w", err)
+	}
+	return &match, nil
+}
+
+// completeCupMatch records a match result; a nil winner is a half. With
+// pendingOnly a match that has already been decided is left alone, so an
+// automatic result never overwrites one recorded by hand or scores a match
+// twice.
+func completeCupMatch(db *gorm.DB, matchID uuid.UUID, winnerTeamID *uuid.UUID, result *string, pendingOnly bool) error {
+	now := time.Now()
+	q := db.Model(&models.CupMatch{}).Where("id = ?", matchID)
+	if pendingOnly {
+		q = q.Where("status = ?", models.CupMatchStatusPending)
+	}
+	if err := q.Updates(map[string]any{
+		"status":         models.CupMatchStatusCompleted,
+		"winner_team_id": winnerTeamID,
+		"result":         result,
+		"completed_at":   now,
+		"updated_at":     now,
+	}).Error; err != nil {
+		return fmt.Errorf("record cup match result: %w", err)
+	}
+	return nil
+}
+
+// cupPointsToWin is the score that wins outright: more than half the points
+// available, i.e. 14½ of 28.
+func cupPointsToWin(available float64) float64 {
+	return available/2 + 0.5
+}
+
+// matchPoints returns the points a completed match gives each side.
+func matchPoints(match models.CupMatch, teams []models.EventTeam) (float64, float64) {
+	if match.Status != string(models.CupMatchStatusCompleted) {
+		return 0, 0
+	}
+	switch {
+	case match.WinnerTeamID == nil:
+		return 0.5, 0.5
+	case *match.WinnerTeamID == teams[0].ID:
+		return 1, 0
+	default:
+		return 0, 1
+	}
+}
+
+// loadEventCup builds the EventCup payload.
+func (s *CupService) loadEventCup(ctx context.Context, eventID uuid.UUID) (*EventCup, error) {
+	out := &EventCup{EventID: eventID.String(), Teams: []CupTeamData{}, Sessions: []CupSessionData{}}
+
+	type playerRow struct {
+		EventPlayerID uuid.UUID
+		UserID        uuid.UUID
+		DisplayName   string
+		AvatarURL     *string
+		EventTeamID   *uuid.UUID
+	}
+	var rows []playerRow
+	if err := s.DB.WithContext(ctx).Table("event_players ep").
+		Select("ep.id AS event_player_id, ep.user_id, u.display_name, u.avatar_url, ep.event_team_id").
+		Joins("JOIN users u ON u.id = ep.user_id").
+		Where("ep.event_id = ?", eventID).
+		Order("u.display_name ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load members: %w", err)
+	}
+	players := make(map[uuid.UUID]CupPlayer, len(rows))
+	for _, r := range rows {
+		players[r.EventPlayerID] = CupPlayer{
+			EventPlayerID: r.EventPlayerID.String(), UserID: r.UserID.String(),
+			DisplayName: r.DisplayName, AvatarURL: r.AvatarURL,
+		}
+	}
+
+	teams, err := loadCupTeams(ctx, s.DB, eventID)
+	if errors.Is(err, ErrCupTeamsNotSet) {
+		return out, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range teams {
+		data := CupTeamData{ID: t.ID.String(), Name: t.Name, Color: t.Color, Members: []CupPlayer{}}
+		for _, r := range rows {
+			if r.EventTeamID != nil && *r.EventTeamID == t.ID {
+				data.Members = append(data.Members, players[r.EventPlayerID])
+			}
+		}
+		out.Teams = append(out.Teams, data)
+	}
+
+	var sessions []models.CupSession
+	if err := s.DB.WithContext(ctx).
+		Preload("Matches", func(db *gorm.DB) *gorm.DB { return db.Order("match_number ASC") }).
+		Preload("Matches.Players").
+		Where("event_id = ?", eventID).
+		Order("session_number ASC").
+		Find(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("load cup sessions: %w", err)
+	}
+	for _, session := range sessions {
+		sd := CupSessionData{
+			ID: session.ID.String(), SessionNumber: session.SessionNumber,
+			Name: session.Name, Format: session.Format,
+			RoundID: uuidString(session.RoundID), Matches: []CupMatchData{},
+		}
+		for _, m := range session.Matches {
+			md := CupMatchData{
+				ID: m.ID.String(), MatchNumber: m.MatchNumber, Status: m.Status,
+				Team1Players: []CupPlayer{}, Team2Players: []CupPlayer{},
+				WinnerTeamID: uuidString(m.WinnerTeamID),
+				Halved:       m.Status == string(models.CupMatchStatusCompleted) && m.WinnerTeamID == nil,
+				Result:       m.Result, CompletedAt: m.CompletedAt,
+			}
+			for _, p := range m.Players {
+				if p.EventTeamID == teams[0].ID {
+					md.Team1Players = append(md.Team1Players, players[p.EventPlayerID])
+				} else {
+					md.Team2Players = append(md.Team2Players, players[p.EventPlayerID])
+				}
+			}
+			p1, p2 := matchPoints(m, teams)
+			sd.Team1Points += p1
+			sd.Team2Points += p2
+			out.PointsAvailable++
+			if m.Status == string(models.CupMatchStatusCompleted) {
+				out.PointsPlayed++
+			}
+			sd.Matches = append(sd.Matches, md)
+		}
+		out.Teams[0].Points += sd.Team1Points
+		out.Teams[1].Points += sd.Team2Points
+		out.Sessions = append(out.Sessions, sd)
+	}
+
+	out.PointsToWin = cupPointsToWin(out.PointsAvailable)
+	for i := range out.Teams {
+		if need := out.PointsToWin - out.Teams[i].Points; need > 0 {
+			out.Teams[i].PointsNeeded = need
+		}
+		if out.PointsAvailable > 0 && out.Teams[i].Points >= out.PointsToWin {
+			id := out.Teams[i].ID
+			out.WinnerTeamID = &id
+		}
+	}
+	return out, nil
+}
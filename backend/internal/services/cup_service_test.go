@@ -0,0 +1,240 @@
+// services/cup_service_test.go
+// Integration tests for CupService (cup_service.go) and the cup scoring hook in
+// RoundService.Update. Uses testutil.NewTestDB to spin up an ephemeral Postgres
+// container — Docker must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// cupField creates an event with two named teams of n members each and returns
+// the event, its organizer, the cup, and each team's user IDs.
+func cupField(t *testing.T, db *gorm.DB, n int) (models.Event, uuid.UUID, *services.EventCup, [2][]uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+	eventSvc := services.NewEventService(db)
+	organizer := seedUser(t, db, "captain")
+	event := seedEvent(t, eventSvc, organizer.ID)
+	svc := services.NewCupService(db, eventSvc)
+
+	cup, err := svc.SetTeams(ctx, event.ID, organizer.ID, "user", []services.CupTeamInput{{Name: "Blue"}, {Name: "Red"}})
+	require.NoError(t, err)
+	var members [2][]uuid.UUID
+	for side := range members {
+		teamID := uuid.MustParse(cup.Teams[side].ID)
+		for i := 0; i < n; i++ {
+			u := seedUser(t, db, fmt.Sprintf("team%dplayer%d", side+1, i+1))
+			addEventMember(t, db, event.ID, u.ID)
+			require.NoError(t, svc.SetMemberTeam(ctx, event.ID, organizer.ID, "user", u.ID, &teamID))
+			members[side] = append(members[side], u.ID)
+		}
+	}
+	cup, err = svc.Get(ctx, event.ID, organizer.ID, "user")
+	require.NoError(t, err)
+	return event, organizer.ID, cup, members
+}
+
+// ─── Teams and pairings ───────────────────────────────────────────────────────
+
+func TestCupService_SetTeams_RenamesInPlace(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	event, orgID, cup, _ := cupField(t, db, 1)
+
+	renamed, err := services.NewCupService(db, services.NewEventService(db)).SetTeams(context.Background(),
+		event.ID, orgID, "user", []services.CupTeamInput{{Name: "USA"}, {Name: "Europe"}})
+	require.NoError(t, err)
+	require.Len(t, renamed.Teams, 2)
+	assert.Equal(t, cup.Teams[0].ID, renamed.Teams[0].ID)
+	assert.Equal(t, "USA", renamed.Teams[0].Name)
+	assert.Len(t, renamed.Teams[0].Members, 1)
+}
+
+func TestCupService_AddMatch_PlayerMustBeOnSide(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	svc := services.NewCupService(db, services.NewEventService(db))
+	event, orgID, _, members := cupField(t, db, 1)
+	cup, err := svc.CreateSession(ctx, event.ID, orgID, "user", services.CreateCupSessionInput{Format: "singles"})
+	require.NoError(t, err)
+	sessionID := uuid.MustParse(cup.Sessions[0].ID)
+
+	// Sides swapped: the Red player can't play for Blue.
+	_, err = svc.AddMatch(ctx, event.ID, sessionID, orgID, "user", services.CreateCupMatchInput{
+		Team1UserIDs: members[1], Team2UserIDs: members[0],
+	})
+	var ve *services.ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestCupService_AddMatch_PlayerOncePerSession(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	svc := services.NewCupService(db, services.NewEventService(db))
+	event, orgID, _, members := cupField(t, db, 2)
+	cup, err := svc.CreateSession(ctx, event.ID, orgID, "user", services.CreateCupSessionInput{Format: "singles"})
+	require.NoError(t, err)
+	assert.Equal(t, "Session 1", cup.Sessions[0].Name)
+	sessionID := uuid.MustParse(cup.Sessions[0].ID)
+
+	_, err = svc.AddMatch(ctx, event.ID, sessionID, orgID, "user", services.CreateCupMatchInput{
+		Team1UserIDs: members[0][:1], Team2UserIDs: members[1][:1],
+	})
+	require.NoError(t, err)
+	_, err = svc.AddMatch(ctx, event.ID, sessionID, orgID, "user", services.CreateCupMatchInput{
+		Team1UserIDs: members[0][:1], Team2UserIDs: members[1][1:],
+	})
+	assert.ErrorIs(t, err, services.ErrCupPlayerBusy)
+}
+
+// ─── Scoring ──────────────────────────────────────────────────────────────────
+
+func TestCupService_RecordMatchResult_TalliesPointsAndNeeded(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	svc := services.NewCupService(db, services.NewEventService(db))
+	event, orgID, cup, members := cupField(t, db, 2)
+	blue := uuid.MustParse(cup.Teams[0].ID)
+
+	cup, err := svc.CreateSession(ctx, event.ID, orgID, "user", services.CreateCupSessionInput{Format: "singles"})
+	require.NoError(t, err)
+	sessionID := uuid.MustParse(cup.Sessions[0].ID)
+	for i := 0; i < 2; i++ {
+		cup, err = svc.AddMatch(ctx, event.ID, sessionID, orgID, "user", services.CreateCupMatchInput{
+			Team1UserIDs: members[0][i : i+1], Team2UserIDs: members[1][i : i+1],
+		})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2.0, cup.PointsAvailable)
+	assert.Equal(t, 1.5, cup.PointsToWin)
+
+	matches := cup.Sessions[0].Matches
+	_, err = svc.RecordMatchResult(ctx, event.ID, uuid.MustParse(matches[0].ID), orgID, "user", nil, nil)
+	require.NoError(t, err)
+	cup, err = svc.RecordMatchResult(ctx, event.ID, uuid.MustParse(matches[1].ID), orgID, "user", &blue, nil)
+	require.NoError(t, err)
+
+	assert.True(t, cup.Sessions[0].Matches[0].Halved)
+	assert.Equal(t, 1.5, cup.Teams[0].Points)
+	assert.Equal(t, 0.5, cup.Teams[1].Points)
+	assert.Zero(t, cup.Teams[0].PointsNeeded)
+	assert.Equal(t, 1.0, cup.Teams[1].PointsNeeded)
+	require.NotNil(t, cup.WinnerTeamID)
+	assert.Equal(t, blue.String(), *cup.WinnerTeamID)
+}
+
+// playCupRound sets up a two-a-side match in a session of format tied to a
+// round where Red's players each win a hole, then completes the round.
+func playCupRound(t *testing.T, db *gorm.DB, format string) (eventID, orgID uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+	eventSvc := services.NewEventService(db)
+	roundSvc := services.NewRoundService(db, eventSvc)
+	svc := services.NewCupService(db, eventSvc)
+	event, orgID, cup, members := cupField(t, db, 2)
+	course, tee := seedCourseWithTee(t, db, "Cup Course")
+	seedHoles(t, db, tee.ID)
+	round := scheduleRound(t, roundSvc, event.ID, orgID, course.ID.String(), tee.ID.String()).Round
+
+	cup, err := svc.CreateSession(ctx, event.ID, orgID, "user", services.CreateCupSessionInput{
+		Format: format, RoundID: &round.ID,
+	})
+	require.NoError(t, err)
+	_, err = svc.AddMatch(ctx, event.ID, uuid.MustParse(cup.Sessions[0].ID), orgID, "user", services.CreateCupMatchInput{
+		Team1UserIDs: members[0], Team2UserIDs: members[1],
+	})
+	require.NoError(t, err)
+
+	var eps []models.EventPlayer
+	require.NoError(t, db.Where("event_id = ? AND event_team_id IS NOT NULL", event.ID).Find(&eps).Error)
+	for _, ep := range eps {
+		rp := addRoundPlayer(t, db, round.ID, ep.ID)
+		overrides := map[int]int{}
+		switch ep.UserID {
+		case members[1][0]:
+			overrides = map[int]int{1: 3} // Red wins the 1st…
+		case members[1][1]:
+			overrides = map[int]int{2: 3} // …and its other player the 2nd
+		}
+		enterCard(t, db, rp.ID, orgID, overrides)
+	}
+
+	completed := string(models.RoundStatusCompleted)
+	_, err = roundSvc.Update(ctx, round.ID, orgID, "user", services.UpdateRoundInput{Status: &completed})
+	require.NoError(t, err)
+
+	return event.ID, orgID
+}
+
+func TestCupService_CompletedRoundScoresFourBall(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	svc := services.NewCupService(db, services.NewEventService(db))
+	eventID, orgID := playCupRound(t, db, "four_ball")
+
+	cup, err := svc.Get(ctx, eventID, orgID, "user")
+	require.NoError(t, err)
+	match := cup.Sessions[0].Matches[0]
+	assert.Equal(t, "completed", match.Status)
+	require.NotNil(t, match.Result)
+	assert.Equal(t, "2 up", *match.Result)
+	assert.Equal(t, cup.Teams[1].ID, *match.WinnerTeamID)
+	assert.Equal(t, 1.0, cup.Teams[1].Points)
+}
+
+func TestCupService_RecompletedRoundKeepsHandResult(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	eventSvc := services.NewEventService(db)
+	svc := services.NewCupService(db, eventSvc)
+	eventID, orgID := playCupRound(t, db, "four_ball")
+	cup, err := svc.Get(ctx, eventID, orgID, "user")
+	require.NoError(t, err)
+
+	// The organizer halves the match by hand, then the round is reopened and
+	// completed again.
+	halved := "conceded putt"
+	_, err = svc.RecordMatchResult(ctx, eventID, uuid.MustParse(cup.Sessions[0].Matches[0].ID), orgID, "user", nil, &halved)
+	require.NoError(t, err)
+	var session models.CupSession
+	require.NoError(t, db.First(&session, "event_id = ?", eventID).Error)
+	roundSvc := services.NewRoundService(db, eventSvc)
+	active, completed := string(models.RoundStatusActive), string(models.RoundStatusCompleted)
+	_, err = roundSvc.Update(ctx, *session.RoundID, orgID, "user", services.UpdateRoundInput{Status: &active})
+	require.NoError(t, err)
+	_, err = roundSvc.Update(ctx, *session.RoundID, orgID, "user", services.UpdateRoundInput{Status: &completed})
+	require.NoError(t, err)
+
+	cup, err = svc.Get(ctx, eventID, orgID, "user")
+	require.NoError(t, err)
+	match := cup.Sessions[0].Matches[0]
+	assert.True(t, match.Halved, "the automatic result doesn't overwrite the organizer's")
+	require.NotNil(t, match.Result)
+	assert.Equal(t, halved, *match.Result)
+}
+
+func TestCupService_CompletedRoundLeavesFoursomesPending(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	svc := services.NewCupService(db, services.NewEventService(db))
+	eventID, orgID := playCupRound(t, db, "foursomes")
+
+	cup, err := svc.Get(ctx, eventID, orgID, "user")
+	require.NoError(t, err)
+	match := cup.Sessions[0].Matches[0]
+	assert.Equal(t, "pending", match.Status, "partners' separate cards aren't a shared ball")
+	assert.Nil(t, match.WinnerTeamID)
+}
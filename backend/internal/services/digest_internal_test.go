@@ -0,0 +1,110 @@
+// services/digest_internal_test.go
+// White-box tests for the unexported digest helpers in digest_service.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestOrdinal|TestFormatToPar|TestDigestStanding|TestBuildDigestEvent|TestRenderDigest|TestDigestBackoff' -v
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+func TestOrdinal(t *testing.T) {
+	cases := map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 101: "101st", 111: "111th"}
+	for n, want := range cases {
+		assert.Equal(t, want, ordinal(n), n)
+	}
+}
+
+func TestFormatToPar(t *testing.T) {
+	assert.Equal(t, "E", formatToPar(0))
+	assert.Equal(t, "+3", formatToPar(3))
+	assert.Equal(t, "-2", formatToPar(-2))
+}
+
+func TestDigestStanding_Movement(t *testing.T) {
+	assert.Equal(t, "new this week", digestStanding{Position: 4}.Movement())
+	assert.Equal(t, "up 3", digestStanding{Position: 2, Previous: intPtr(5)}.Movement())
+	assert.Equal(t, "down 1", digestStanding{Position: 3, Previous: intPtr(2)}.Movement())
+	assert.Equal(t, "no change", digestStanding{Position: 1, Previous: intPtr(1)}.Movement())
+}
+
+func TestBuildDigestEvent(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	aFlight := "A Flight"
+	ec := &digestEventCache{
+		Event: models.Event{Name: "Spring League"},
+		Rounds: []digestRoundBoard{{
+			Round: models.Round{Name: "Week 3", ScheduledDate: time.Date(2026, 6, 6, 0, 0, 0, 0, time.UTC)},
+			Board: &RoundLeaderboard{Entries: []LeaderboardEntry{
+				{UserID: bob.String(), DisplayName: "Bob", Position: intPtr(1), FlightName: &aFlight},
+				{UserID: alice.String(), DisplayName: "Alice", Position: intPtr(2), NetToPar: 3},
+			}},
+		}},
+		Now:    &EventStandings{Entries: []StandingsEntry{{UserID: alice.String(), Position: intPtr(2)}}},
+		Before: &EventStandings{Entries: []StandingsEntry{{UserID: alice.String(), Position: intPtr(4)}}},
+	}
+
+	section := buildDigestEvent(ec, alice)
+	require.NotNil(t, section)
+	require.Len(t, section.Results, 1)
+	r := section.Results[0]
+	assert.Equal(t, "Sat Jun 6", r.Date)
+	assert.Equal(t, []string{"Bob (A Flight)"}, r.Winners)
+	assert.Equal(t, 2, *r.YourPosition)
+	assert.Equal(t, "+3", *r.YourNetToPar)
+	require.NotNil(t, section.Standing)
+	assert.Equal(t, "up 2", section.Standing.Movement())
+
+	// Someone who didn't play still sees the results, without a line of their own.
+	other := buildDigestEvent(ec, uuid.New())
+	require.NotNil(t, other)
+	assert.Nil(t, other.Results[0].YourPosition)
+	assert.Nil(t, other.Standing)
+
+	assert.Nil(t, buildDigestEvent(&digestEventCache{Event: ec.Event}, alice), "no rounds this week")
+}
+
+func TestRenderDigest(t *testing.T) {
+	at := "3:40 PM UTC"
+	data := digestData{
+		DisplayName: "Alice <3",
+		Events: []digestEvent{{
+			Name:     "Spring League",
+			Results:  []digestResult{{RoundName: "Week 3", Date: "Sat Jun 6", Winners: []string{"Bob", "Cara"}, YourPosition: intPtr(2), YourNetToPar: strPtr("E")}},
+			Standing: &digestStanding{Position: 2, Previous: intPtr(4)},
+		}},
+		TeeTimes:       []digestTeeTime{{RoundName: "Week 4", EventName: "Spring League", Date: "Sat Jun 13", TeeTime: &at}},
+		UnsubscribeURL: "https://api.example.com/digest/unsubscribe?token=abc",
+	}
+	require.True(t, data.hasContent())
+
+	msg, err := renderDigest("alice@example.com", data)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", msg.To)
+	assert.Contains(t, msg.Text, "Winners: Bob, Cara")
+	assert.Contains(t, msg.Text, "You: 2nd, E net")
+	assert.Contains(t, msg.Text, "Standings: 2nd (up 2)")
+	assert.Contains(t, msg.Text, "Week 4 - Spring League: Sat Jun 13 at 3:40 PM UTC")
+	assert.Contains(t, msg.Text, "Unsubscribe: https://api.example.com/digest/unsubscribe?token=abc")
+	assert.Contains(t, msg.HTML, "Alice &lt;3", "HTML escapes user content")
+	assert.Contains(t, msg.HTML, `href="https://api.example.com/digest/unsubscribe?token=abc"`)
+	assert.Equal(t, "<https://api.example.com/digest/unsubscribe?token=abc>", msg.Headers["List-Unsubscribe"])
+
+	assert.False(t, digestData{DisplayName: "Bob"}.hasContent())
+}
+
+func TestDigestBackoff(t *testing.T) {
+	assert.Equal(t, time.Hour, digestBackoff(1))
+	assert.Equal(t, 2*time.Hour, digestBackoff(2))
+	assert.Equal(t, 4*time.Hour, digestBackoff(3))
+}
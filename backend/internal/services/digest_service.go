@@ -0,0 +1,514 @@
+// services/digest_service.go
+// DigestService owns the weekly email digest: each event member gets the past
+// week's round results, how their place in the standings moved, and their
+// tee times for the week ahead.
+//
+// SendDueDigests runs hourly from main.go. A user is due a week after their
+// last digest was considered; users with nothing to report that week are
+// skipped but still wait another week. Rendering uses the embedded templates in
+// templates/, and delivery goes through a Mailer (mailer.go). Every digest
+// carries an unsubscribe link holding the user's email_digests token, which
+// works without signing in.
+package services
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log/slog"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDigestTokenNotFound is returned when an unsubscribe link's token matches
+// no one.
+var ErrDigestTokenNotFound = errors.New("unsubscribe token not found")
+
+const (
+	// digestInterval is how often each user gets a digest, and how far back
+	// (and ahead) it looks.
+	digestInterval = 7 * 24 * time.Hour
+	// digestBatchSize caps how many users one SendDueDigests call considers;
+	// the rest wait for the next run.
+	digestBatchSize = 200
+	// digestSubject is the subject line of every digest.
+	digestSubject = "Your week in golf"
+	// maxDigestAttempts is how many failed sends a digest gets before that
+	// week's digest is given up on.
+	maxDigestAttempts = 4
+	// digestRetryBase is the wait after the first failed send; it doubles
+	// with each further failure.
+	digestRetryBase = time.Hour
+)
+
+//go:embed templates/digest.txt.tmpl templates/digest.html.tmpl
+var digestTemplateFS embed.FS
+
+// digestFuncs are the helpers the digest templates use.
+var digestFuncs = map[string]any{
+	"join":    strings.Join,
+	"ordinal": ordinal,
+	"deref":   func(p *int) int { return *p },
+}
+
+var (
+	digestText = texttemplate.Must(texttemplate.New("digest.txt.tmpl").Funcs(digestFuncs).
+			ParseFS(digestTemplateFS, "templates/digest.txt.tmpl"))
+	digestHTML = htmltemplate.Must(htmltemplate.New("digest.html.tmpl").Funcs(digestFuncs).
+			ParseFS(digestTemplateFS, "templates/digest.html.tmpl"))
+)
+
+// ─── DTOs ─────────────────────────────────────────────────────────────────────
+
+// DigestSettings is the payload for GET/PUT /users/me/digest.
+type DigestSettings struct {
+	Enabled bool `json:"enabled"`
+}
+
+// digestData is what the digest templates render.
+type digestData struct {
+	DisplayName    string
+	Events         []digestEvent
+	TeeTimes       []digestTeeTime
+	UnsubscribeURL string
+}
+
+// hasContent reports whether there's anything worth emailing.
+func (d digestData) hasContent() bool {
+	return len(d.Events) > 0 || len(d.TeeTimes) > 0
+}
+
+// digestEvent is one event's section: the week's rounds and, when the user is
+// ranked, their place in the standings.
+type digestEvent struct {
+	Name     string
+	Results  []digestResult
+	Standing *digestStanding
+}
+
+// digestResult is one round completed this week.
+type digestResult struct {
+	RoundName    string
+	Date         string
+	Winners      []string // position 1 in each flight, labelled with the flight
+	YourPosition *int     // nil when the user didn't play or wasn't ranked
+	YourNetToPar *string  // "E", "+3", "-2"
+}
+
+// digestStanding is the user's place in the event standings now and before
+// this week's rounds.
+type digestStanding struct {
+	Position int
+	Previous *int // nil when the user wasn't ranked a week ago
+}
+
+// Movement describes the change since last week.
+func (s digestStanding) Movement() string {
+	switch {
+	case s.Previous == nil:
+		return "new this week"
+	case *s.Previous > s.Position:
+		return fmt.Sprintf("up %d", *s.Previous-s.Position)
+	case *s.Previous < s.Position:
+		return fmt.Sprintf("down %d", s.Position-*s.Previous)
+	}
+	return "no change"
+}
+
+// digestTeeTime is one of the user's rounds in the week ahead.
+type digestTeeTime struct {
+	RoundName string
+	EventName string  // empty for eventless rounds
+	Date      string  // "Sat Jun 6"
+	TeeTime   *string // "3:40 PM UTC"; nil when no group tee time is set
+}
+
+// digestRoundBoard is a round completed this week with its leaderboard.
+type digestRoundBoard struct {
+	Round models.Round
+	Board *RoundLeaderboard
+}
+
+// digestEventCache holds what one event contributes to every member's digest,
+// so a run computes it once.
+type digestEventCache struct {
+	Event  models.Event
+	Rounds []digestRoundBoard
+	Now    *EventStandings // nil when no rounds were completed this week
+	Before *EventStandings
+}
+
+// ─── Service ──────────────────────────────────────────────────────────────────
+
+// DigestService builds and sends the weekly digest and manages subscriptions.
+type DigestService struct {
+	DB             *gorm.DB
+	LeaderboardSvc *LeaderboardService
+	Mailer         Mailer
+	// UnsubscribeBase is the public URL the unsubscribe token is appended to,
+	// e.g. "https://api.example.com/digest/unsubscribe?token=".
+	UnsubscribeBase string
+}
+
+// NewDigestService constructs a DigestService. LeaderboardSvc supplies round
+// results and standings.
+func NewDigestService(db *gorm.DB, leaderboardSvc *LeaderboardService, mailer Mailer, unsubscribeBase string) *DigestService {
+	return &DigestService{DB: db, LeaderboardSvc: leaderboardSvc, Mailer: mailer, UnsubscribeBase: unsubscribeBase}
+}
+
+// Settings returns whether the user gets the digest. Users are subscribed
+// until they opt out.
+func (s *DigestService) Settings(ctx context.Context, userID uuid.UUID) (*DigestSettings, error) {
+	var row models.EmailDigest
+	err := s.DB.WithContext(ctx).First(&row, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &DigestSettings{Enabled: true}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load digest settings: %w", err)
+	}
+	return &DigestSettings{Enabled: row.Enabled}, nil
+}
+
+// UpdateSettings subscribes or unsubscribes the user.
+func (s *DigestService) UpdateSettings(ctx context.Context, userID uuid.UUID, enabled bool) (*DigestSettings, error) {
+	row := models.EmailDigest{UserID: userID, Enabled: enabled}
+	if err := s.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&row).Error; err != nil {
+		return nil, fmt.Errorf("save digest settings: %w", err)
+	}
+	return &DigestSettings{Enabled: enabled}, nil
+}
+
+// Unsubscribe turns the digest off for whoever holds token, from the link in
+// a digest. Idempotent.
+func (s *DigestService) Unsubscribe(ctx context.Context, token uuid.UUID) error {
+	res := s.DB.WithContext(ctx).Model(&models.EmailDigest{}).
+		Where("unsubscribe_token = ?", token).
+		Updates(map[string]any{"enabled": false, "updated_at": time.Now().UTC()})
+	if res.Error != nil {
+		return fmt.Errorf("unsubscribe: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrDigestTokenNotFound
+	}
+	return nil
+}
+
+// SendDueDigests emails every subscribed event member whose digest is due
+// and returns how many were sent. A failed send is logged and retried after
+// digestBackoff, so failing addresses don't hold the head of the queue; after
+// maxDigestAttempts failures the week's digest is skipped. Run periodically
+// from main.go.
+func (s *DigestService) SendDueDigests(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	var users []models.User
+	if err := s.DB.WithContext(ctx).
+		Where("NOT is_guest").
+		Where(`EXISTS (SELECT 1 FROM event_players ep JOIN events e ON e.id = ep.event_id
+		       WHERE ep.user_id = users.id AND ep.status IN ? AND e.status <> ?)`,
+			flightedStatuses, models.EventStatusCancelled).
+		Where(`NOT EXISTS (SELECT 1 FROM email_digests d
+		       WHERE d.user_id = users.id
+		         AND (NOT d.enabled OR d.last_sent_at > ? OR d.next_attempt_at > ?))`,
+			now.Add(-digestInterval), now).
+		Order("id").Limit(digestBatchSize).
+		Find(&users).Error; err != nil {
+		return 0, fmt.Errorf("find due digests: %w", err)
+	}
+
+	cache := make(map[uuid.UUID]*digestEventCache)
+	sent := 0
+	for _, u := range users {
+		row := models.EmailDigest{UserID: u.ID, Enabled: true}
+		if err := s.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+			return sent, fmt.Errorf("create digest subscription: %w", err)
+		}
+		if err := s.DB.WithContext(ctx).First(&row, "user_id = ?", u.ID).Error; err != nil {
+			return sent, fmt.Errorf("load digest subscription: %w", err)
+		}
+
+		data, err := s.buildDigest(ctx, u, now, cache)
+		if err != nil {
+			return sent, err
+		}
+		if data.hasContent() {
+			data.UnsubscribeURL = s.UnsubscribeBase + row.UnsubscribeToken.String()
+			msg, err := renderDigest(u.Email, data)
+			if err != nil {
+				return sent, err
+			}
+			if err := s.Mailer.Send(ctx, msg); err != nil {
+				slog.WarnContext(ctx, "Digest not sent",
+					"event_type_label", "digest.send_failed",
+					"user_id", u.ID.String(),
+					"error", err.Error(),
+				)
+				if err := s.scheduleDigestRetry(ctx, row, now); err != nil {
+					return sent, err
+				}
+				continue
+			}
+			sent++
+		}
+		if err := s.DB.WithContext(ctx).Model(&row).Updates(map[string]any{
+			"last_sent_at":    now,
+			"failed_attempts": 0,
+			"next_attempt_at": nil,
+		}).Error; err != nil {
+			return sent, fmt.Errorf("mark digest sent: %w", err)
+		}
+	}
+	return sent, nil
+}
+
+// digestBackoff is how long to wait before retrying a digest that has failed
+// attempts times.
+func digestBackoff(attempts int) time.Duration {
+	return digestRetryBase << (attempts - 1)
+}
+
+// scheduleDigestRetry records a failed send on row: it's retried after
+// digestBackoff, or once maxDigestAttempts is reached the week's digest is
+// treated as considered and the user waits for next week's.
+func (s *DigestService) scheduleDigestRetry(ctx context.Context, row models.EmailDigest, now time.Time) error {
+	attempts := row.FailedAttempts + 1
+	updates := map[string]any{
+		"failed_attempts": attempts,
+		"next_attempt_at": now.Add(digestBackoff(attempts)),
+	}
+	if attempts >= maxDigestAttempts {
+		updates = map[string]any{
+			"last_sent_at":    now,
+			"failed_attempts": 0,
+			"next_attempt_at": nil,
+		}
+	}
+	if err := s.DB.WithContext(ctx).Model(&models.EmailDigest{}).
+		Where("user_id = ?", row.UserID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("schedule digest retry: %w", err)
+	}
+	return nil
+}
+
+// ─── Building ─────────────────────────────────────────────────────────────────
+
+// buildDigest assembles one user's digest for the week ending now.
+func (s *DigestService) buildDigest(ctx context.Context, user models.User, now time.Time, cache map[uuid.UUID]*digestEventCache) (digestData, error) {
+	data := digestData{DisplayName: user.DisplayName}
+
+	var eventIDs []uuid.UUID
+	if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+		Joins("JOIN events e ON e.id = event_players.event_id").
+		Where("event_players.user_id = ? AND event_players.status IN ? AND e.status <> ?",
+			user.ID, flightedStatuses, models.EventStatusCancelled).
+		Order("e.name").
+		Pluck("event_players.event_id", &eventIDs).Error; err != nil {
+		return data, fmt.Errorf("load digest events: %w", err)
+	}
+	for _, id := range eventIDs {
+		ec, ok := cache[id]
+		if !ok {
+			var err error
+			if ec, err = s.loadDigestEvent(ctx, id, now); err != nil {
+				return data, err
+			}
+			cache[id] = ec
+		}
+		if section := buildDigestEvent(ec, user.ID); section != nil {
+			data.Events = append(data.Events, *section)
+		}
+	}
+
+	teeTimes, err := s.upcomingTeeTimes(ctx, user.ID, now)
+	if err != nil {
+		return data, err
+	}
+	data.TeeTimes = teeTimes
+	return data, nil
+}
+
+// loadDigestEvent loads an event's rounds completed in the week before now,
+// with their leaderboards, and its standings with and without them.
+func (s *DigestService) loadDigestEvent(ctx context.Context, eventID uuid.UUID, now time.Time) (*digestEventCache, error) {
+	ec := &digestEventCache{}
+	if err := s.DB.WithContext(ctx).First(&ec.Event, "id = ?", eventID).Error; err != nil {
+		return nil, fmt.Errorf("load digest event: %w", err)
+	}
+	since := now.Add(-digestInterval)
+	var rounds []models.Round
+	if err := s.DB.WithContext(ctx).
+		Where("event_id = ? AND status = ? AND scheduled_date >= ?",
+			eventID, models.RoundStatusCompleted, since.Format("2006-01-02")).
+		Order("scheduled_date ASC, round_number ASC").
+		Find(&rounds).Error; err != nil {
+		return nil, fmt.Errorf("load digest rounds: %w", err)
+	}
+	if len(rounds) == 0 {
+		return ec, nil
+	}
+	for _, r := range rounds {
+		board, err := s.LeaderboardSvc.RoundLeaderboard(ctx, r.ID)
+		if err != nil {
+			return nil, err
+		}
+		ec.Rounds = append(ec.Rounds, digestRoundBoard{Round: r, Board: board})
+	}
+	current, err := s.LeaderboardSvc.computeStandings(ctx, &ec.Event, nil)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.LeaderboardSvc.computeStandings(ctx, &ec.Event, &since)
+	if err != nil {
+		return nil, err
+	}
+	ec.Now, ec.Before = current.Standings, previous.Standings
+	return ec, nil
+}
+
+// upcomingTeeTimes lists the user's scheduled rounds in the week after now,
+// with their group's tee time when set.
+func (s *DigestService) upcomingTeeTimes(ctx context.Context, userID uuid.UUID, now time.Time) ([]digestTeeTime, error) {
+	var rows []struct {
+		RoundName     string
+		EventName     *string
+		ScheduledDate time.Time
+		TeeTime       *time.Time
+	}
+	if err := s.DB.WithContext(ctx).Table("round_players rp").
+		Select("r.name AS round_name, e.name AS event_name, r.scheduled_date, g.tee_time").
+		Joins("JOIN rounds r ON r.id = rp.round_id").
+		Joins("LEFT JOIN events e ON e.id = r.event_id").
+		Joins("LEFT JOIN group_players gp ON gp.round_player_id = rp.id").
+		Joins("LEFT JOIN groups g ON g.id = gp.group_id").
+		Where("rp.user_id = ? AND rp.status NOT IN ? AND r.status = ?",
+			userID, models.RoundPlayerOutStatuses, models.RoundStatusScheduled).
+		Where("r.scheduled_date >= ? AND r.scheduled_date < ?",
+			now.Format("2006-01-02"), now.Add(digestInterval).Format("2006-01-02")).
+		Order("r.scheduled_date ASC, g.tee_time ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load upcoming tee times: %w", err)
+	}
+	out := make([]digestTeeTime, 0, len(rows))
+	for _, r := range rows {
+		t := digestTeeTime{RoundName: r.RoundName, Date: r.ScheduledDate.Format("Mon Jan 2")}
+		if r.EventName != nil {
+			t.EventName = *r.EventName
+		}
+		if r.TeeTime != nil {
+			at := r.TeeTime.UTC().Format("3:04 PM") + " UTC"
+			t.TeeTime = &at
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// buildDigestEvent renders one event's section for userID, or nil when the
+// event had no rounds this week.
+func buildDigestEvent(ec *digestEventCache, userID uuid.UUID) *digestEvent {
+	if len(ec.Rounds) == 0 {
+		return nil
+	}
+	uid := userID.String()
+	section := &digestEvent{Name: ec.Event.Name}
+	for _, rb := range ec.Rounds {
+		result := digestResult{RoundName: rb.Round.Name, Date: rb.Round.ScheduledDate.Format("Mon Jan 2")}
+		for _, e := range rb.Board.Entries {
+			if e.Position != nil && *e.Position == 1 {
+				name := e.DisplayName
+				if e.FlightName != nil {
+					name += " (" + *e.FlightName + ")"
+				}
+				result.Winners = append(result.Winners, name)
+			}
+			if e.UserID == uid && e.Position != nil {
+				result.YourPosition = e.Position
+				toPar := formatToPar(e.NetToPar)
+				result.YourNetToPar = &toPar
+			}
+		}
+		section.Results = append(section.Results, result)
+	}
+	if now := standingPosition(ec.Now, uid); now != nil {
+		section.Standing = &digestStanding{Position: *now, Previous: standingPosition(ec.Before, uid)}
+	}
+	return section
+}
+
+// standingPosition returns userID's position in standings, or nil when
+// they're unranked.
+func standingPosition(standings *EventStandings, userID string) *int {
+	if standings == nil {
+		return nil
+	}
+	for _, e := range standings.Entries {
+		if e.UserID == userID {
+			return e.Position
+		}
+	}
+	return nil
+}
+
+// renderDigest renders data into the email sent to address.
+func renderDigest(address string, data digestData) (MailMessage, error) {
+	var text, html bytes.Buffer
+	if err := digestText.Execute(&text, data); err != nil {
+		return MailMessage{}, fmt.Errorf("render digest text: %w", err)
+	}
+	if err := digestHTML.Execute(&html, data); err != nil {
+		return MailMessage{}, fmt.Errorf("render digest html: %w", err)
+	}
+	return MailMessage{
+		To:      address,
+		Subject: digestSubject,
+		Text:    text.String(),
+		HTML:    html.String(),
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + data.UnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}, nil
+}
+
+// formatToPar renders a score relative to par: "E", "+3" or "-2".
+func formatToPar(n int) string {
+	switch {
+	case n == 0:
+		return "E"
+	case n > 0:
+		return fmt.Sprintf("+%d", n)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// ordinal renders a finishing position: 1st, 2nd, 3rd, 4th, 11th, 22nd.
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
@@ -0,0 +1,226 @@
+// services/digest_service_test.go
+// Integration tests for DigestService: who gets the weekly digest, what's in
+// it, the weekly cadence, retrying failed sends and unsubscribing. Docker
+// must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run DigestService -v
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// recordingMailer is a Mailer that keeps every message, keyed by recipient.
+// Sends to failFor fail and are counted in attempts instead.
+type recordingMailer struct {
+	sent     map[string]services.MailMessage
+	failFor  string
+	attempts int
+}
+
+func (m *recordingMailer) Send(_ context.Context, msg services.MailMessage) error {
+	if msg.To == m.failFor {
+		m.attempts++
+		return errors.New("mailbox unavailable")
+	}
+	if m.sent == nil {
+		m.sent = make(map[string]services.MailMessage)
+	}
+	m.sent[msg.To] = msg
+	return nil
+}
+
+// newDigestSvc builds a DigestService backed by the test DB and mailer.
+func newDigestSvc(db *gorm.DB, mailer services.Mailer) *services.DigestService {
+	return services.NewDigestService(db, newLeaderboardSvc(db), mailer, "https://api.example.com/digest/unsubscribe?token=")
+}
+
+// emailOf returns a user's email address.
+func emailOf(t *testing.T, db *gorm.DB, userID uuid.UUID) string {
+	t.Helper()
+	var u models.User
+	require.NoError(t, db.First(&u, "id = ?", userID).Error)
+	return u.Email
+}
+
+func TestDigestService_SendsWeeklyResults(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	mailer := &recordingMailer{}
+	svc := newDigestSvc(db, mailer)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	completeRound(t, db, roundID)
+
+	sent, err := svc.SendDueDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sent, "organizer, alice and bob")
+
+	msg, ok := mailer.sent[emailOf(t, db, aliceRP.UserID)]
+	require.True(t, ok)
+	assert.Equal(t, "Your week in golf", msg.Subject)
+	assert.Contains(t, msg.Text, event.Name)
+	assert.Contains(t, msg.Text, "You: 2nd", "bob won the card-off")
+	assert.Contains(t, msg.Text, "Standings: 2nd (new this week)")
+	assert.Contains(t, msg.Headers["List-Unsubscribe"], "https://api.example.com/digest/unsubscribe?token=")
+	assert.Contains(t, mailer.sent[emailOf(t, db, bobRP.UserID)].Text, "You: 1st")
+
+	// Nobody is due again for a week.
+	mailer.sent = nil
+	sent, err = svc.SendDueDigests(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	require.NoError(t, db.Model(&models.EmailDigest{}).Where("user_id = ?", aliceRP.UserID).
+		Update("last_sent_at", time.Now().Add(-8*24*time.Hour)).Error)
+	sent, err = svc.SendDueDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Contains(t, mailer.sent, emailOf(t, db, aliceRP.UserID))
+}
+
+func TestDigestService_FailedSend_BacksOff(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	mailer := &recordingMailer{}
+	svc := newDigestSvc(db, mailer)
+	ctx := context.Background()
+	roundID, _, aliceRP, _ := tiedRound(t, db)
+	completeRound(t, db, roundID)
+	mailer.failFor = emailOf(t, db, aliceRP.UserID)
+
+	sent, err := svc.SendDueDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent, "everyone but alice")
+	assert.Equal(t, 1, mailer.attempts)
+
+	var row models.EmailDigest
+	require.NoError(t, db.First(&row, "user_id = ?", aliceRP.UserID).Error)
+	assert.Nil(t, row.LastSentAt)
+	assert.Equal(t, 1, row.FailedAttempts)
+	require.NotNil(t, row.NextAttemptAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *row.NextAttemptAt, time.Minute)
+
+	// Not retried until the backoff passes.
+	sent, err = svc.SendDueDigests(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Equal(t, 1, mailer.attempts)
+
+	// Once it does, a success clears the failure count.
+	mailer.failFor = ""
+	require.NoError(t, db.Model(&row).Update("next_attempt_at", time.Now().Add(-time.Minute)).Error)
+	sent, err = svc.SendDueDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.NoError(t, db.First(&row, "user_id = ?", aliceRP.UserID).Error)
+	assert.NotNil(t, row.LastSentAt)
+	assert.Zero(t, row.FailedAttempts)
+	assert.Nil(t, row.NextAttemptAt)
+}
+
+func TestDigestService_FailedSend_GivesUpForTheWeek(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	mailer := &recordingMailer{}
+	svc := newDigestSvc(db, mailer)
+	ctx := context.Background()
+	roundID, _, aliceRP, _ := tiedRound(t, db)
+	completeRound(t, db, roundID)
+	mailer.failFor = emailOf(t, db, aliceRP.UserID)
+
+	for i := 0; i < 4; i++ {
+		_, err := svc.SendDueDigests(ctx)
+		require.NoError(t, err)
+		require.NoError(t, db.Model(&models.EmailDigest{}).Where("user_id = ?", aliceRP.UserID).
+			Update("next_attempt_at", time.Now().Add(-time.Minute)).Error)
+	}
+	assert.Equal(t, 4, mailer.attempts)
+
+	var row models.EmailDigest
+	require.NoError(t, db.First(&row, "user_id = ?", aliceRP.UserID).Error)
+	assert.NotNil(t, row.LastSentAt, "skipped until next week")
+	assert.Zero(t, row.FailedAttempts)
+}
+
+func TestDigestService_NothingToReport_Skipped(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	mailer := &recordingMailer{}
+	svc := newDigestSvc(db, mailer)
+	owner := seedUser(t, db, "quiet")
+	seedEvent(t, services.NewEventService(db), owner.ID)
+
+	sent, err := svc.SendDueDigests(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Empty(t, mailer.sent)
+
+	var row models.EmailDigest
+	require.NoError(t, db.First(&row, "user_id = ?", owner.ID).Error)
+	assert.NotNil(t, row.LastSentAt, "considered, so not reconsidered until next week")
+}
+
+func TestDigestService_UpcomingTeeTimes(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	mailer := &recordingMailer{}
+	svc := newDigestSvc(db, mailer)
+	roundID, _, aliceRP, _ := tiedRound(t, db) // scheduled today, still open
+	group := addGroupWithPlayer(t, db, roundID, 2, aliceRP.ID)
+	teeTime := time.Now().UTC().Truncate(24 * time.Hour).Add(23 * time.Hour)
+	require.NoError(t, db.Model(&group).Update("tee_time", teeTime).Error)
+
+	_, err := svc.SendDueDigests(context.Background())
+	require.NoError(t, err)
+	msg, ok := mailer.sent[emailOf(t, db, aliceRP.UserID)]
+	require.True(t, ok)
+	assert.Contains(t, msg.Text, "Coming up")
+	assert.Contains(t, msg.Text, "at 11:00 PM UTC")
+}
+
+func TestDigestService_SettingsAndUnsubscribe(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	mailer := &recordingMailer{}
+	svc := newDigestSvc(db, mailer)
+	ctx := context.Background()
+	roundID, _, aliceRP, _ := tiedRound(t, db)
+	completeRound(t, db, roundID)
+	alice := aliceRP.UserID
+
+	settings, err := svc.Settings(ctx, alice)
+	require.NoError(t, err)
+	assert.True(t, settings.Enabled, "subscribed by default")
+
+	_, err = svc.UpdateSettings(ctx, alice, false)
+	require.NoError(t, err)
+	_, err = svc.SendDueDigests(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, mailer.sent, emailOf(t, db, alice))
+
+	_, err = svc.UpdateSettings(ctx, alice, true)
+	require.NoError(t, err)
+	_, err = svc.SendDueDigests(ctx)
+	require.NoError(t, err)
+	require.Contains(t, mailer.sent, emailOf(t, db, alice))
+
+	var row models.EmailDigest
+	require.NoError(t, db.First(&row, "user_id = ?", alice).Error)
+	require.NoError(t, svc.Unsubscribe(ctx, row.UnsubscribeToken))
+	require.NoError(t, svc.Unsubscribe(ctx, row.UnsubscribeToken), "idempotent")
+	settings, err = svc.Settings(ctx, alice)
+	require.NoError(t, err)
+	assert.False(t, settings.Enabled)
+	assert.ErrorIs(t, svc.Unsubscribe(ctx, uuid.New()), services.ErrDigestTokenNotFound)
+}
@@ -8,10 +8,22 @@
 // # Service catalog
 //
 //   - CourseService  — courses, tees, holes, external GolfCourseAPI import/refresh
-//   - EventService   — events, event members, round list within an event
-//   - RoundService   — round scheduling, groups, group-member assignment
-//   - ScoreService   — scorecard assembly, score entry, handicap gate, hole stats
+//   - EventService   — events, event members, round list within an event, flights, substitute roster, invitations, capacity and waitlist
+//   - RoundService   — round scheduling, groups, group-member assignment, generated pairings, tee sheets, balanced teams and substitute replacement
+//   - ScoreService   — scorecard assembly, score entry, handicap gate, hole stats, attestation, change history, disputes, absent players, WD/DQ/NC
 //   - UserService    — profile lookup, follow/unfollow, career stats, scorecard settings
+//   - LeaderboardService — round leaderboard, event standings (per flight), card-off tiebreaks, event finalize/reopen
+//   - BracketService — match play brackets for tournament events: seeding, byes, match rounds, advancement
+//   - CupService — Ryder Cup–style event teams, sessions of four-ball/foursomes/singles matches, cup score
+//   - EventScheduleService — weekly league schedule templates that generate and regenerate a season's rounds
+//   - PurseService — event and round entry fees, payout schedules, paid/owed ledger and payouts by finishing position
+//   - LedgerService — side-bet ledger: round side-game results, balances netted per pair of players, settle-ups
+//   - NotificationService — in-app inbox, read state, per-type preferences and tee-time reminders; notify() writes entries (and queues pushes) for the other services
+//   - PushService — device registration and delivery of the push outbox through a PushSender (ExpoPushSender in production)
+//   - DigestService — weekly email digest of results, standings movement and tee times, sent through a Mailer; subscriptions and unsubscribe links
+//   - FeedService — activity feed of completed rounds, personal bests, eagles, aces and event wins from followed players; recordRoundActivity() and recordEventWins() write it for the other services
+//   - CommentService — threaded comments and emoji reactions on completed rounds and holes, organizer moderation, soft delete; roundEngagementCounts() feeds round summaries
+//   - PrivacyService — privacy settings (profile visibility, hidden email/stats, hidden from search) and blocks; loadProfileAccess() and notBlockedSQL() enforce them for the user, score and feed services
 //
 // # Sentinel errors
 //
@@ -31,17 +43,25 @@
 //	ErrFollowSelf       — caller and target are the same user
 //	ErrAlreadyFollowing — follow row already exists
 //
+// PrivacyService-specific (also returned by UserService and ScoreService reads):
+//
+//	ErrProfilePrivate   — the user's profile visibility doesn't include the caller
+//	ErrStatsHidden      — the user keeps their stats and scorecards to themself
+//	ErrBlockSelf        — caller and block target are the same user
+//
 // EventService-specific:
 //
-//	ErrEventNotFound, ErrNotOrganizer, ErrAlreadyMember, ErrMemberNotFound
+//	ErrEventNotFound, ErrNotOrganizer, ErrAlreadyMember, ErrMemberNotFound,
+//	ErrInviteNotFound, ErrInviteUnavailable, ErrInvitePending,
+//	ErrEventFull, ErrNotWaitlisted, ErrNoWaitlistOffer
 //
 // RoundService-specific:
 //
-//	ErrRoundNotFound, ErrGroupNotFound, ErrNotRoundOrganizer, ErrGroupMemberNotFound
+//	ErrRoundNotFound, ErrGroupNotFound, ErrNotRoundOrganizer, ErrGroupMemberNotFound, ErrRoundStarted
 //
 // ScoreService-specific:
 //
-//	ErrRoundPlayerNotFound, ErrHandicapRequired, ErrNotInSameGroup, ErrRoundNotOpen
+//	ErrRoundPlayerNotFound, ErrHandicapRequired, ErrNotInSameGroup, ErrRoundNotOpen, ErrPlayerHasScores
 //
 // # Permission model
 //
@@ -0,0 +1,470 @@
+// services/event_flights.go
+// Flights: A/B/C divisions within an event. Organizers define the flights (and
+// optional handicap-index bands) with SetFlights, then place members either by
+// hand (SetMemberFlight) or automatically from their handicap index
+// (RecomputeFlights). LeaderboardService ranks each flight separately.
+//
+// A member's handicap index for automatic assignment is the most recent one
+// recorded on any of their round_players rows, falling back to the index the app
+// computes from their completed rounds (as in GetUserStats). Members with
+// neither keep whatever flight they already have, so an organizer can place
+// them by hand.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrFlightNotFound — the flight does not exist or belongs to another event.
+var ErrFlightNotFound = errors.New("flight not found")
+
+// FlightInput is one flight in a SetFlights call. Flights are ordered as given
+// (the first is the top flight). Either bound may be nil for an open-ended band.
+type FlightInput struct {
+	Name             string
+	MinHandicapIndex *float64
+	MaxHandicapIndex *float64
+}
+
+// SetFlightsInput replaces an event's flight definitions.
+type SetFlightsInput struct {
+	Assignment *string // "manual", "handicap_season" or "handicap_round"; nil = leave alone
+	Flights    []FlightInput
+}
+
+// EventFlightData is one flight, returned directly as JSON by the handler.
+type EventFlightData struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	SortOrder        int      `json:"sort_order"`
+	MinHandicapIndex *float64 `json:"min_handicap_index"`
+	MaxHandicapIndex *float64 `json:"max_handicap_index"`
+	PlayerCount      int      `json:"player_count"`
+}
+
+// EventFlights is the payload for GET/PUT /events/:id/flights.
+type EventFlights struct {
+	EventID    string            `json:"event_id"`
+	Assignment string            `json:"assignment"`
+	Flights    []EventFlightData `json:"flights"`
+	Unassigned int               `json:"unassigned"` // registered members without a flight
+}
+
+// GetFlights returns an event's flights with their member counts. Non-admins
+// must be members of the event (ErrEventNotMember otherwise).
+func (s *EventService) GetFlights(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string) (*EventFlights, error) {
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("load event: %w", err)
+	}
+	if models.UserRole(requesterRole) != models.UserRoleAdmin {
+		var count int64
+		if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+			Where("event_id = ? AND user_id = ?", eventID, requesterID).
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("check membership: %w", err)
+		}
+		if count == 0 {
+			return nil, ErrEventNotMember
+		}
+	}
+	return s.loadEventFlights(ctx, &event)
+}
+
+// loadEventFlights builds the EventFlights payload for an already-loaded event.
+func (s *EventService) loadEventFlights(ctx context.Context, event *models.Event) (*EventFlights, error) {
+	flights, err := loadFlights(ctx, s.DB, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	type countRow struct {
+		FlightID *uuid.UUID
+		Count    int
+	}
+	var counts []countRow
+	if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+		Select("flight_id, COUNT(*) AS count").
+		Where("event_id = ? AND status IN ?", event.ID, flightedStatuses).
+		Group("flight_id").
+		Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("count flight members: %w", err)
+	}
+	byFlight := make(map[uuid.UUID]int, len(counts))
+	unassigned := 0
+	for _, c := range counts {
+		if c.FlightID == nil {
+			unassigned = c.Count
+			continue
+		}
+		byFlight[*c.FlightID] = c.Count
+	}
+
+	out := &EventFlights{
+		EventID:    event.ID.String(),
+		Assignment: flightAssignmentFor(event),
+		Flights:    make([]EventFlightData, 0, len(flights)),
+		Unassigned: unassigned,
+	}
+	for _, f := range flights {
+		out.Flights = append(out.Flights, EventFlightData{
+			ID: f.ID.String(), Name: f.Name, SortOrder: f.SortOrder,
+			MinHandicapIndex: f.MinHandicapIndex, MaxHandicapIndex: f.MaxHandicapIndex,
+			PlayerCount: byFlight[f.ID],
+		})
+	}
+	return out, nil
+}
+
+// SetFlights replaces an event's flight definitions. Caller must be an organizer
+// (or admin), and the event must not be finalized (ErrEventFinalized).
+//
+// Flights are matched to existing ones by name, so changing bands or reordering
+// keeps members in place; flights left out are deleted and their members become
+// unflighted. An empty list removes flights from the event. Under a handicap
+// assignment mode, members are re-flighted immediately (see RecomputeFlights).
+func (s *EventService) SetFlights(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, in SetFlightsInput) (*EventFlights, error) {
+	if err := validateFlightInputs(in); err != nil {
+		return nil, err
+	}
+
+	event, err := s.loadOrganizedEvent(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if in.Assignment != nil {
+		event.FlightAssignment = *in.Assignment
+	}
+
+	existing, err := loadFlights(ctx, s.DB, eventID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]models.EventFlight, len(existing))
+	for _, f := range existing {
+		byName[f.Name] = f
+	}
+
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		kept := make(map[uuid.UUID]bool, len(in.Flights))
+		for i, fi := range in.Flights {
+			name := strings.TrimSpace(fi.Name)
+			if f, ok := byName[name]; ok {
+				kept[f.ID] = true
+				if err := tx.Model(&models.EventFlight{}).Where("id = ?", f.ID).Updates(map[string]any{
+					"sort_order":         i,
+					"min_handicap_index": fi.MinHandicapIndex,
+					"max_handicap_index": fi.MaxHandicapIndex,
+				}).Error; err != nil {
+					return fmt.Errorf("update flight %q: %w", name, err)
+				}
+				continue
+			}
+			flight := models.EventFlight{
+				EventID: eventID, Name: name, SortOrder: i,
+				MinHandicapIndex: fi.MinHandicapIndex, MaxHandicapIndex: fi.MaxHandicapIndex,
+			}
+			if err := tx.Omit(clause.Associations).Create(&flight).Error; err != nil {
+				return fmt.Errorf("create flight %q: %w", name, err)
+			}
+		}
+		for _, f := range existing {
+			if kept[f.ID] {
+				continue
+			}
+			// ON DELETE SET NULL unassigns members; flight points rules cascade.
+			if err := tx.Delete(&models.EventFlight{}, "id = ?", f.ID).Error; err != nil {
+				return fmt.Errorf("delete flight %q: %w", f.Name, err)
+			}
+		}
+		if err := tx.Model(&models.Event{}).Where("id = ?", eventID).
+			Update("flight_assignment", event.FlightAssignment).Error; err != nil {
+			return fmt.Errorf("save flight assignment: %w", err)
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("set flights: %w", txErr)
+	}
+
+	if models.FlightAssignment(flightAssignmentFor(event)) != models.FlightAssignmentManual {
+		if err := s.assignFlightsByHandicap(ctx, eventID); err != nil {
+			return nil, err
+		}
+	}
+	return s.loadEventFlights(ctx, event)
+}
+
+// RecomputeFlights re-flights every member with a known handicap index from
+// the event's bands — the "start of season" recompute. Caller must be an
+// organizer (or admin); the event must use a handicap assignment mode and must
+// not be finalized.
+func (s *EventService) RecomputeFlights(ctx context.Context, eventID, callerID uuid.UUID, callerRole string) (*EventFlights, error) {
+	event, err := s.loadOrganizedEvent(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if models.FlightAssignment(flightAssignmentFor(event)) == models.FlightAssignmentManual {
+		return nil, &ValidationError{Field: "assignment", Message: "flights are assigned manually for this event"}
+	}
+	if err := s.assignFlightsByHandicap(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return s.loadEventFlights(ctx, event)
+}
+
+// SetMemberFlight places one member in a flight (nil flightID unassigns them).
+// Caller must be an organizer (or admin). Allowed under any assignment mode — a
+// later handicap recompute overwrites it only if the member has an index.
+func (s *EventService) SetMemberFlight(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, targetUserID uuid.UUID, flightID *uuid.UUID) error {
+	if _, err := s.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return err
+	}
+	if flightID != nil {
+		var count int64
+		if err := s.DB.WithContext(ctx).Model(&models.EventFlight{}).
+			Where("id = ? AND event_id = ?", *flightID, eventID).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("check flight: %w", err)
+		}
+		if count == 0 {
+			return ErrFlightNotFound
+		}
+	}
+
+	res := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+		Where("event_id = ? AND user_id = ?", eventID, targetUserID).
+		Update("flight_id", flightID)
+	if res.Error != nil {
+		return fmt.Errorf("set member flight: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+// SnapshotRoundFlights records on each round_players row the flight the player
+// competes in for this round. Called when an event round goes active; under
+// handicap_round assignment the event's members are re-flighted first. A
+// substitute plays in the flight of the member they replaced. A no-op for
+// events without flights.
+func (s *EventService) SnapshotRoundFlights(ctx context.Context, roundID, eventID uuid.UUID) error {
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		return fmt.Errorf("load event: %w", err)
+	}
+	var count int64
+	if err := s.DB.WithContext(ctx).Model(&models.EventFlight{}).
+		Where("event_id = ?", eventID).Count(&count).Error; err != nil {
+		return fmt.Errorf("count flights: %w", err)
+	}
+	if count == 0 {
+		return nil
+	}
+	if models.FlightAssignment(flightAssignmentFor(&event)) == models.FlightAssignmentHandicapRound {
+		if err := s.assignFlightsByHandicap(ctx, eventID); err != nil {
+			return err
+		}
+	}
+	if err := s.DB.WithContext(ctx).Exec(`
+		UPDATE round_players rp SET flight_id = ep.flight_id
+		FROM event_players ep
+		WHERE ep.id = COALESCE(rp.replaced_event_player_id, rp.event_player_id) AND rp.round_id = ?
+	`, roundID).Error; err != nil {
+		return fmt.Errorf("snapshot round flights: %w", err)
+	}
+	return nil
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// flightedStatuses are the event_player statuses that take part in flights.
+var flightedStatuses = []models.EventPlayerStatus{
+	models.EventPlayerStatusRegistered, models.EventPlayerStatusCompleted,
+}
+
+// loadOrganizedEvent loads an event the caller may manage and that is not
+// finalized — the common gate for flight mutations.
+func (s *EventService) loadOrganizedEvent(ctx context.Context, eventID, callerID uuid.UUID, callerRole string) (*models.Event, error) {
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("load event: %w", err)
+	}
+	isOrg, err := s.IsOrganizer(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if !isOrg {
+		return nil, ErrEventForbidden
+	}
+	if event.FinalizedAt != nil {
+		return nil, ErrEventFinalized
+	}
+	return &event, nil
+}
+
+// assignFlightsByHandicap sets flight_id for every flighted member with a
+// recorded handicap index. Members without an index are left as they are.
+func (s *EventService) assignFlightsByHandicap(ctx context.Context, eventID uuid.UUID) error {
+	flights, err := loadFlights(ctx, s.DB, eventID)
+	if err != nil {
+		return err
+	}
+	var members []models.EventPlayer
+	if err := s.DB.WithContext(ctx).
+		Where("event_id = ? AND status IN ?", eventID, flightedStatuses).
+		Find(&members).Error; err != nil {
+		return fmt.Errorf("load members: %w", err)
+	}
+	if len(members) == 0 {
+		return nil
+	}
+	userIDs := make([]uuid.UUID, len(members))
+	for i, m := range members {
+		userIDs[i] = m.UserID
+	}
+	indexes, err := latestHandicapIndexes(ctx, s.DB, userIDs)
+	if err != nil {
+		return err
+	}
+
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, m := range members {
+			hi, ok := indexes[m.UserID]
+			if !ok {
+				continue
+			}
+			if err := tx.Model(&models.EventPlayer{}).Where("id = ?", m.ID).
+				Update("flight_id", flightForIndex(flights, hi)).Error; err != nil {
+				return fmt.Errorf("assign flight for %s: %w", m.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// loadFlights returns an event's flights in sort order.
+func loadFlights(ctx context.Context, db *gorm.DB, eventID uuid.UUID) ([]models.EventFlight, error) {
+	var flights []models.EventFlight
+	if err := db.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Order("sort_order ASC, name ASC").
+		Find(&flights).Error; err != nil {
+		return nil, fmt.Errorf("load flights: %w", err)
+	}
+	return flights, nil
+}
+
+// latestHandicapIndexes returns each user's handicap index: the most recently
+// recorded round_players.handicap_index, else the index computed from their
+// score differentials. Users with neither are absent from the map. The
+// query count is fixed, however many users there are.
+func latestHandicapIndexes(ctx context.Context, db *gorm.DB, userIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	type indexRow struct {
+		UserID        uuid.UUID
+		HandicapIndex float64
+	}
+	var rows []indexRow
+	if err := db.WithContext(ctx).Table("round_players rp").
+		Select("DISTINCT ON (rp.user_id) rp.user_id, rp.handicap_index").
+		Joins("JOIN rounds r ON r.id = rp.round_id").
+		Where("rp.user_id IN ? AND rp.handicap_index IS NOT NULL", userIDs).
+		Order("rp.user_id, r.scheduled_date DESC, rp.created_at DESC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load handicap indexes: %w", err)
+	}
+	out := make(map[uuid.UUID]float64, len(userIDs))
+	for _, r := range rows {
+		out[r.UserID] = r.HandicapIndex
+	}
+	var missing []uuid.UUID
+	for _, id := range userIDs {
+		if _, ok := out[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	diffsByUser, err := handicapDifferentialsByUser(ctx, db, missing)
+	if err != nil {
+		return nil, err
+	}
+	for id, diffs := range diffsByUser {
+		if hi, _ := ComputeHandicapPair(diffs); hi != nil {
+			out[id] = *hi
+		}
+	}
+	return out, nil
+}
+
+// flightForIndex returns the first flight (in sort order) whose band contains
+// hi, or nil when none does. Both bounds are inclusive; nil bounds are open.
+func flightForIndex(flights []models.EventFlight, hi float64) *uuid.UUID {
+	for _, f := range flights {
+		if f.MinHandicapIndex != nil && hi < *f.MinHandicapIndex {
+			continue
+		}
+		if f.MaxHandicapIndex != nil && hi > *f.MaxHandicapIndex {
+			continue
+		}
+		id := f.ID
+		return &id
+	}
+	return nil
+}
+
+// flightAssignmentFor returns the event's assignment mode, defaulting to manual
+// for legacy rows with an empty value.
+func flightAssignmentFor(event *models.Event) string {
+	if event == nil || event.FlightAssignment == "" {
+		return string(models.FlightAssignmentManual)
+	}
+	return event.FlightAssignment
+}
+
+// validateFlightInputs checks a SetFlights payload before any DB access.
+func validateFlightInputs(in SetFlightsInput) error {
+	if in.Assignment != nil {
+		switch models.FlightAssignment(*in.Assignment) {
+		case models.FlightAssignmentManual, models.FlightAssignmentHandicapSeason, models.FlightAssignmentHandicapRound:
+		default:
+			return &ValidationError{
+				Field:   "assignment",
+				Message: "assignment must be 'manual', 'handicap_season' or 'handicap_round'",
+			}
+		}
+	}
+	seen := make(map[string]bool, len(in.Flights))
+	for _, f := range in.Flights {
+		name := strings.TrimSpace(f.Name)
+		if name == "" {
+			return &ValidationError{Field: "flights", Message: "every flight needs a name"}
+		}
+		if seen[name] {
+			return &ValidationError{Field: "flights", Message: fmt.Sprintf("duplicate flight name %q", name)}
+		}
+		seen[name] = true
+		for _, b := range []*float64{f.MinHandicapIndex, f.MaxHandicapIndex} {
+			if b != nil && (*b < -10 || *b > 54) {
+				return &ValidationError{Field: "flights", Message: "handicap index bounds must be between -10 and 54"}
+			}
+		}
+		if f.MinHandicapIndex != nil && f.MaxHandicapIndex != nil && *f.MinHandicapIndex > *f.MaxHandicapIndex {
+			return &ValidationError{Field: "flights", Message: fmt.Sprintf("flight %q: min handicap index exceeds max", name)}
+		}
+	}
+	return nil
+}
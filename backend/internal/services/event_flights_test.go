@@ -0,0 +1,261 @@
+// services/event_flights_test.go
+// Integration tests for event flights (event_flights.go) and flight-aware
+// ranking in LeaderboardService. Uses testutil.NewTestDB to spin up an
+// ephemeral Postgres container — Docker must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// floatPtr returns a pointer to a copy of f.
+func floatPtr(f float64) *float64 { return &f }
+
+// flightsFor returns the event's flight IDs keyed by name.
+func flightsFor(t *testing.T, flights *services.EventFlights) map[string]uuid.UUID {
+	t.Helper()
+	out := make(map[string]uuid.UUID, len(flights.Flights))
+	for _, f := range flights.Flights {
+		out[f.Name] = uuid.MustParse(f.ID)
+	}
+	return out
+}
+
+// splitFlights builds tiedRound's event with flights A and B, alice in A and
+// bob in B. Returns the round ID, event, both round players and the flight IDs.
+func splitFlights(t *testing.T, db *gorm.DB) (uuid.UUID, models.Event, models.RoundPlayer, models.RoundPlayer, map[string]uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+	eventSvc := services.NewEventService(db)
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+
+	flights, err := eventSvc.SetFlights(ctx, event.ID, orgID, "user", services.SetFlightsInput{
+		Flights: []services.FlightInput{{Name: "A"}, {Name: "B"}},
+	})
+	require.NoError(t, err)
+	ids := flightsFor(t, flights)
+	require.NoError(t, eventSvc.SetMemberFlight(ctx, event.ID, orgID, "user", aliceRP.UserID, ptrUUID(ids["A"])))
+	require.NoError(t, eventSvc.SetMemberFlight(ctx, event.ID, orgID, "user", bobRP.UserID, ptrUUID(ids["B"])))
+	return roundID, event, aliceRP, bobRP, ids
+}
+
+// ptrUUID returns a pointer to a copy of id.
+func ptrUUID(id uuid.UUID) *uuid.UUID { return &id }
+
+// ─── Flight definitions ───────────────────────────────────────────────────────
+
+func TestEventService_SetFlights_PlayerForbidden(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	_, event, aliceRP, _ := tiedRound(t, db)
+
+	_, err := services.NewEventService(db).SetFlights(context.Background(), event.ID, aliceRP.UserID, "user",
+		services.SetFlightsInput{Flights: []services.FlightInput{{Name: "A"}}})
+	assert.ErrorIs(t, err, services.ErrEventForbidden)
+}
+
+func TestEventService_SetFlights_MatchesByNameAndKeepsMembers(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	eventSvc := services.NewEventService(db)
+	_, event, aliceRP, _, ids := splitFlights(t, db)
+	orgID := organizerOf(t, db, event.ID)
+
+	// Reorder; A keeps its ID and its member. Dropping B unassigns bob.
+	flights, err := eventSvc.SetFlights(ctx, event.ID, orgID, "user", services.SetFlightsInput{
+		Flights: []services.FlightInput{{Name: "Champ"}, {Name: "A", MaxHandicapIndex: floatPtr(18)}},
+	})
+	require.NoError(t, err)
+	require.Len(t, flights.Flights, 2)
+	assert.Equal(t, "Champ", flights.Flights[0].Name)
+	assert.Equal(t, ids["A"].String(), flights.Flights[1].ID)
+	assert.Equal(t, 1, flights.Flights[1].PlayerCount)
+
+	var ep models.EventPlayer
+	require.NoError(t, db.First(&ep, "id = ?", *aliceRP.EventPlayerID).Error)
+	require.NotNil(t, ep.FlightID)
+	assert.Equal(t, ids["A"], *ep.FlightID)
+}
+
+func TestEventService_SetFlights_InvalidBand(t *testing.T) {
+	_, err := services.NewEventService(nil).SetFlights(context.Background(), uuid.New(), uuid.New(), "user",
+		services.SetFlightsInput{Flights: []services.FlightInput{{Name: "A", MinHandicapIndex: floatPtr(10), MaxHandicapIndex: floatPtr(5)}}})
+	var ve *services.ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestEventService_SetFlights_HandicapBandsAssignMembers(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	eventSvc := services.NewEventService(db)
+	_, event, aliceRP, bobRP := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+	require.NoError(t, db.Model(&models.RoundPlayer{}).Where("id = ?", aliceRP.ID).Update("handicap_index", 6.2).Error)
+	require.NoError(t, db.Model(&models.RoundPlayer{}).Where("id = ?", bobRP.ID).Update("handicap_index", 17.4).Error)
+
+	mode := string(models.FlightAssignmentHandicapSeason)
+	flights, err := eventSvc.SetFlights(ctx, event.ID, orgID, "user", services.SetFlightsInput{
+		Assignment: &mode,
+		Flights: []services.FlightInput{
+			{Name: "A", MaxHandicapIndex: floatPtr(9.9)},
+			{Name: "B", MinHandicapIndex: floatPtr(10)},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, mode, flights.Assignment)
+	ids := flightsFor(t, flights)
+
+	for rp, want := range map[uuid.UUID]uuid.UUID{*aliceRP.EventPlayerID: ids["A"], *bobRP.EventPlayerID: ids["B"]} {
+		var ep models.EventPlayer
+		require.NoError(t, db.First(&ep, "id = ?", rp).Error)
+		require.NotNil(t, ep.FlightID)
+		assert.Equal(t, want, *ep.FlightID)
+	}
+	// The organizer has no index and stays unflighted.
+	assert.Equal(t, 1, flights.Unassigned)
+}
+
+func TestEventService_SetFlights_HandicapBandsFromDifferentials(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	eventSvc := services.NewEventService(db)
+	roundSvc := services.NewRoundService(db, eventSvc)
+	organizer := seedUser(t, db, "flDiffOrg")
+	event := seedEvent(t, eventSvc, organizer.ID)
+	course, tee := seedCourseWithTee(t, db, "Differentials Course")
+	seedHoles(t, db, tee.ID)
+	alice := addEventMember(t, db, event.ID, seedUser(t, db, "flDiffAlice").ID)
+	bob := addEventMember(t, db, event.ID, seedUser(t, db, "flDiffBob").ID)
+
+	// Neither has a recorded index: three completed rounds each give alice a
+	// 0.0 index (level par) and bob 1.9 (two over), so both come from the
+	// differentials fallback.
+	for i := 0; i < 3; i++ {
+		round := scheduleRound(t, roundSvc, event.ID, organizer.ID, course.ID.String(), tee.ID.String())
+		enterCard(t, db, addRoundPlayer(t, db, round.Round.ID, alice.ID).ID, organizer.ID, nil)
+		enterCard(t, db, addRoundPlayer(t, db, round.Round.ID, bob.ID).ID, organizer.ID, map[int]int{1: 6})
+		completeRound(t, db, round.Round.ID)
+	}
+
+	mode := string(models.FlightAssignmentHandicapSeason)
+	flights, err := eventSvc.SetFlights(ctx, event.ID, organizer.ID, "user", services.SetFlightsInput{
+		Assignment: &mode,
+		Flights: []services.FlightInput{
+			{Name: "A", MaxHandicapIndex: floatPtr(0.9)},
+			{Name: "B", MinHandicapIndex: floatPtr(1)},
+		},
+	})
+	require.NoError(t, err)
+	ids := flightsFor(t, flights)
+
+	for epID, want := range map[uuid.UUID]uuid.UUID{alice.ID: ids["A"], bob.ID: ids["B"]} {
+		var ep models.EventPlayer
+		require.NoError(t, db.First(&ep, "id = ?", epID).Error)
+		require.NotNil(t, ep.FlightID)
+		assert.Equal(t, want, *ep.FlightID)
+	}
+	assert.Equal(t, 1, flights.Unassigned)
+}
+
+func TestEventService_RecomputeFlights_ManualEventRejected(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	_, event, _, _, _ := splitFlights(t, db)
+
+	_, err := services.NewEventService(db).RecomputeFlights(context.Background(), event.ID, organizerOf(t, db, event.ID), "user")
+	var ve *services.ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestEventService_SetMemberFlight_OtherEventsFlight(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	eventSvc := services.NewEventService(db)
+	_, event, aliceRP, _ := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+	other := seedEvent(t, eventSvc, orgID)
+	flights, err := eventSvc.SetFlights(context.Background(), other.ID, orgID, "user",
+		services.SetFlightsInput{Flights: []services.FlightInput{{Name: "A"}}})
+	require.NoError(t, err)
+
+	err = eventSvc.SetMemberFlight(context.Background(), event.ID, orgID, "user", aliceRP.UserID, ptrUUID(flightsFor(t, flights)["A"]))
+	assert.ErrorIs(t, err, services.ErrFlightNotFound)
+}
+
+// ─── Flight-aware ranking ─────────────────────────────────────────────────────
+
+func TestLeaderboardService_RoundLeaderboard_RanksEachFlight(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	roundID, _, aliceRP, bobRP, ids := splitFlights(t, db)
+
+	board, err := newLeaderboardSvc(db).RoundLeaderboard(context.Background(), roundID)
+	require.NoError(t, err)
+	require.Len(t, board.Flights, 2)
+	require.Len(t, board.Entries, 2)
+
+	// Flight A lists first; both players win their flight outright.
+	assert.Equal(t, aliceRP.ID.String(), board.Entries[0].RoundPlayerID)
+	assert.Equal(t, ids["A"].String(), *board.Entries[0].FlightID)
+	assert.Equal(t, bobRP.ID.String(), board.Entries[1].RoundPlayerID)
+	assert.Equal(t, "B", *board.Entries[1].FlightName)
+	for _, e := range board.Entries {
+		require.NotNil(t, e.Position)
+		assert.Equal(t, 1, *e.Position)
+		assert.Nil(t, e.Tiebreak, "no one to tie with inside a one-player flight")
+	}
+}
+
+func TestLeaderboardService_EventStandings_FlightPointsTable(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	roundID, event, aliceRP, _, ids := splitFlights(t, db)
+	completeRound(t, db, roundID)
+	rules := []models.EventPointsRule{
+		{EventID: event.ID, FinishPosition: 1, Points: 10},                             // default table
+		{EventID: event.ID, FlightID: ptrUUID(ids["B"]), FinishPosition: 1, Points: 4}, // B's own table
+	}
+	require.NoError(t, db.Omit(clause.Associations).Create(&rules).Error)
+
+	standings, err := newLeaderboardSvc(db).EventStandings(context.Background(), event.ID, uuid.Nil, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, services.StandingsRankedByPoints, standings.RankedBy)
+	require.Len(t, standings.Entries, 2)
+
+	points := make(map[string]int)
+	for _, e := range standings.Entries {
+		require.NotNil(t, e.Position)
+		assert.Equal(t, 1, *e.Position)
+		points[*e.FlightName] = *e.TotalPoints
+	}
+	assert.Equal(t, map[string]int{"A": 10, "B": 4}, points)
+	assert.Equal(t, *aliceRP.EventPlayerID, uuid.MustParse(standings.Entries[0].EventPlayerID))
+}
+
+func TestRoundService_Update_ActivationSnapshotsFlights(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	eventSvc := services.NewEventService(db)
+	roundID, event, aliceRP, _, ids := splitFlights(t, db)
+	orgID := organizerOf(t, db, event.ID)
+
+	active := string(models.RoundStatusActive)
+	_, err := services.NewRoundService(db, eventSvc).Update(ctx, roundID, orgID, "user", services.UpdateRoundInput{Status: &active})
+	require.NoError(t, err)
+
+	// Moving alice afterwards leaves the round's flight where it was.
+	require.NoError(t, eventSvc.SetMemberFlight(ctx, event.ID, orgID, "user", aliceRP.UserID, ptrUUID(ids["B"])))
+	var rp models.RoundPlayer
+	require.NoError(t, db.First(&rp, "id = ?", aliceRP.ID).Error)
+	require.NotNil(t, rp.FlightID)
+	assert.Equal(t, ids["A"], *rp.FlightID)
+}
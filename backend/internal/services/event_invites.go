@@ -0,0 +1,508 @@
+// services/event_invites.go
+// Event invitations. An organizer creates an invite code to share as a link —
+// optionally single-use or capped at a number of uses, and expiring — or an
+// invite addressed to an email address.
+//
+// Redeeming a shareable code joins the event as a registered player. An
+// addressed invite is single-use and only its addressee can see or redeem it;
+// once the email belongs to a user (immediately if they already have an
+// account, else when they next sign in — see ResolveEmailInvites) they get an
+// "invited" event_players row, which they accept or decline.
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInviteNotFound — no invite with that code or ID, or it is addressed to
+	// someone else.
+	ErrInviteNotFound = errors.New("invite not found")
+	// ErrInviteUnavailable — the invite has expired, been revoked, declined or
+	// used up.
+	ErrInviteUnavailable = errors.New("invite is no longer valid")
+	// ErrInvitePending — an open invite is already addressed to that email.
+	ErrInvitePending = errors.New("an invite is already pending for this email")
+)
+
+// Invite states reported in EventInviteItem.Status.
+const (
+	InviteStatusActive   = "active"
+	InviteStatusAccepted = "accepted" // addressed invite the addressee accepted
+	InviteStatusDeclined = "declined"
+	InviteStatusRevoked  = "revoked"
+	InviteStatusExpired  = "expired"
+	InviteStatusUsedUp   = "used_up"
+)
+
+const (
+	// defaultInviteTTL applies when CreateInviteInput.ExpiresInHours is nil.
+	defaultInviteTTL = 14 * 24 * time.Hour
+	// maxInviteHours caps ExpiresInHours (90 days).
+	maxInviteHours = 90 * 24
+	// inviteCodeLength is long enough that codes can't be guessed (32^10).
+	inviteCodeLength = 10
+	// inviteCodeAlphabet leaves out look-alikes (0/O, 1/I) so codes survive
+	// being read aloud or typed.
+	inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// CreateInviteInput is what CreateInvite accepts. Email addresses the invite to
+// one person (and forces MaxUses to 1); without it the code is shareable.
+type CreateInviteInput struct {
+	Email          *string
+	MaxUses        *int // nil = unlimited
+	ExpiresInHours *int // nil = 14 days; 0 = never expires
+}
+
+// EventInviteItem is an invite (with its Event loaded) and its current state.
+type EventInviteItem struct {
+	Invite models.EventInvite
+	Status string
+}
+
+// inviteStatus derives an invite's state at now.
+func inviteStatus(inv models.EventInvite, now time.Time) string {
+	switch {
+	case inv.RevokedAt != nil:
+		return InviteStatusRevoked
+	case inv.DeclinedAt != nil:
+		return InviteStatusDeclined
+	case inv.Email != nil && inv.UseCount > 0:
+		return InviteStatusAccepted
+	case inv.MaxUses != nil && inv.UseCount >= *inv.MaxUses:
+		return InviteStatusUsedUp
+	case inv.ExpiresAt != nil && !now.Before(*inv.ExpiresAt):
+		return InviteStatusExpired
+	}
+	return InviteStatusActive
+}
+
+// newInviteCode returns a random code from inviteCodeAlphabet.
+func newInviteCode() (string, error) {
+	buf := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate invite code: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = inviteCodeAlphabet[int(b)%len(inviteCodeAlphabet)]
+	}
+	return string(buf), nil
+}
+
+// normalizeInviteCode makes code lookups case- and whitespace-insensitive.
+func normalizeInviteCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// addressedTo reports whether an addressed invite belongs to user.
+func addressedTo(inv models.EventInvite, user models.User) bool {
+	if inv.InvitedUserID != nil {
+		return *inv.InvitedUserID == user.ID
+	}
+	return inv.Email != nil && strings.EqualFold(*inv.Email, user.Email)
+}
+
+// ─── Organizer actions ────────────────────────────────────────────────────────
+
+// CreateInvite creates an invite code for the event. An addressed invite to an
+// existing user resolves at once: they get an "invited" event_players row.
+// Returns ErrMemberAlreadyExists when that user already has a row on the event,
+// and ErrInvitePending when an open invite is already addressed to the email.
+// Organizer-only.
+func (s *EventService) CreateInvite(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, in CreateInviteInput) (EventInviteItem, error) {
+	var email *string
+	if in.Email != nil {
+		addr := strings.ToLower(strings.TrimSpace(*in.Email))
+		if parsed, err := mail.ParseAddress(addr); err != nil || parsed.Address != addr {
+			return EventInviteItem{}, &ValidationError{Field: "email", Message: "email must be a valid email address"}
+		}
+		email = &addr
+	}
+	if in.MaxUses != nil && *in.MaxUses < 1 {
+		return EventInviteItem{}, &ValidationError{Field: "max_uses", Message: "max_uses must be at least 1"}
+	}
+	if in.ExpiresInHours != nil && (*in.ExpiresInHours < 0 || *in.ExpiresInHours > maxInviteHours) {
+		return EventInviteItem{}, &ValidationError{Field: "expires_in_hours", Message: fmt.Sprintf("expires_in_hours must be between 0 and %d", maxInviteHours)}
+	}
+	event, err := s.loadOrganizedEvent(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return EventInviteItem{}, err
+	}
+
+	now := time.Now().UTC()
+	code, err := newInviteCode()
+	if err != nil {
+		return EventInviteItem{}, err
+	}
+	invite := models.EventInvite{EventID: eventID, Code: code, Email: email, MaxUses: in.MaxUses, CreatedBy: callerID}
+	switch {
+	case in.ExpiresInHours == nil:
+		expires := now.Add(defaultInviteTTL)
+		invite.ExpiresAt = &expires
+	case *in.ExpiresInHours > 0:
+		expires := now.Add(time.Duration(*in.ExpiresInHours) * time.Hour)
+		invite.ExpiresAt = &expires
+	}
+
+	var invitee *models.User
+	if email != nil {
+		one := 1
+		invite.MaxUses = &one
+		if invitee, err = s.checkInviteAddressee(ctx, eventID, *email, now); err != nil {
+			return EventInviteItem{}, err
+		}
+		if invitee != nil {
+			invite.InvitedUserID = &invitee.ID
+		}
+	}
+
+	if err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&invite).Error; err != nil {
+			return fmt.Errorf("create invite: %w", err)
+		}
+		if invitee == nil {
+			return nil
+		}
+		player := models.EventPlayer{
+			EventID: eventID,
+			UserID:  invitee.ID,
+			Role:    models.EventPlayerRolePlayer,
+			Status:  models.EventPlayerStatusInvited,
+		}
+		if err := tx.Omit(clause.Associations).Create(&player).Error; err != nil {
+			return fmt.Errorf("create invited player: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return EventInviteItem{}, err
+	}
+	invite.Event = *event
+	return EventInviteItem{Invite: invite, Status: inviteStatus(invite, now)}, nil
+}
+
+// checkInviteAddressee refuses a second open invite to the same email and an
+// invite to someone already on the event, and returns the user the email
+// belongs to (nil when nobody has signed up with it yet).
+func (s *EventService) checkInviteAddressee(ctx context.Context, eventID uuid.UUID, email string, now time.Time) (*models.User, error) {
+	var open int64
+	if err := s.DB.WithContext(ctx).Model(&models.EventInvite{}).
+		Where("event_id = ? AND email = ? AND revoked_at IS NULL AND declined_at IS NULL AND use_count = 0", eventID, email).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Count(&open).Error; err != nil {
+		return nil, fmt.Errorf("check pending invites: %w", err)
+	}
+	if open > 0 {
+		return nil, ErrInvitePending
+	}
+
+	var user models.User
+	err := s.DB.WithContext(ctx).Where("LOWER(email) = ? AND NOT is_guest", email).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("look up invitee: %w", err)
+	}
+	var count int64
+	if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+		Where("event_id = ? AND user_id = ?", eventID, user.ID).
+		Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("dup-check: %w", err)
+	}
+	if count > 0 {
+		return nil, ErrMemberAlreadyExists
+	}
+	return &user, nil
+}
+
+// ListInvites returns every invite on the event, newest first, in any state.
+// Organizer-only.
+func (s *EventService) ListInvites(ctx context.Context, eventID, callerID uuid.UUID, callerRole string) ([]EventInviteItem, error) {
+	authorized, err := s.IsOrganizer(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if !authorized {
+		return nil, ErrEventForbidden
+	}
+	var invites []models.EventInvite
+	if err := s.DB.WithContext(ctx).Preload("Event").
+		Where("event_id = ?", eventID).
+		Order("created_at DESC").Find(&invites).Error; err != nil {
+		return nil, fmt.Errorf("list invites: %w", err)
+	}
+	return inviteItems(invites, time.Now().UTC()), nil
+}
+
+// RevokeInvite stops an invite from being redeemed. Revoking an addressed invite
+// that hasn't been answered also removes the addressee's "invited" row.
+// Revoking twice is a no-op. Organizer-only.
+func (s *EventService) RevokeInvite(ctx context.Context, eventID, inviteID, callerID uuid.UUID, callerRole string) error {
+	authorized, err := s.IsOrganizer(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return err
+	}
+	if !authorized {
+		return ErrEventForbidden
+	}
+	var invite models.EventInvite
+	if err := s.DB.WithContext(ctx).First(&invite, "id = ? AND event_id = ?", inviteID, eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInviteNotFound
+		}
+		return fmt.Errorf("load invite: %w", err)
+	}
+	if invite.RevokedAt != nil {
+		return nil
+	}
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&invite).Update("revoked_at", time.Now().UTC()).Error; err != nil {
+			return fmt.Errorf("revoke invite: %w", err)
+		}
+		if invite.InvitedUserID == nil {
+			return nil
+		}
+		if err := tx.Where("event_id = ? AND user_id = ? AND status = ?",
+			eventID, *invite.InvitedUserID, models.EventPlayerStatusInvited).
+			Delete(&models.EventPlayer{}).Error; err != nil {
+			return fmt.Errorf("remove invited player: %w", err)
+		}
+		return nil
+	})
+}
+
+// ─── Invitee actions ──────────────────────────────────────────────────────────
+
+// GetInvite returns the invite behind a code, in any state, so the app can
+// show what it's for before the caller accepts. Addressed invites are only
+// visible to their addressee.
+func (s *EventService) GetInvite(ctx context.Context, code string, callerID uuid.UUID) (EventInviteItem, error) {
+	var invite models.EventInvite
+	if err := s.DB.WithContext(ctx).Preload("Event").
+		First(&invite, "code = ?", normalizeInviteCode(code)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return EventInviteItem{}, ErrInviteNotFound
+		}
+		return EventInviteItem{}, fmt.Errorf("load invite: %w", err)
+	}
+	if invite.Email != nil {
+		var caller models.User
+		if err := s.DB.WithContext(ctx).First(&caller, "id = ?", callerID).Error; err != nil {
+			return EventInviteItem{}, fmt.Errorf("load caller: %w", err)
+		}
+		if !addressedTo(invite, caller) {
+			return EventInviteItem{}, ErrInviteNotFound
+		}
+	}
+	return EventInviteItem{Invite: invite, Status: inviteStatus(invite, time.Now().UTC())}, nil
+}
+
+// ListMyInvites returns the active invites addressed to the caller, newest first.
+func (s *EventService) ListMyInvites(ctx context.Context, callerID uuid.UUID) ([]EventInviteItem, error) {
+	var caller models.User
+	if err := s.DB.WithContext(ctx).First(&caller, "id = ?", callerID).Error; err != nil {
+		return nil, fmt.Errorf("load caller: %w", err)
+	}
+	var invites []models.EventInvite
+	if err := s.DB.WithContext(ctx).Preload("Event").
+		Where("invited_user_id = ? AND email IS NOT NULL", callerID).
+		Order("created_at DESC").Find(&invites).Error; err != nil {
+		return nil, fmt.Errorf("list my invites: %w", err)
+	}
+	now := time.Now().UTC()
+	out := make([]EventInviteItem, 0, len(invites))
+	for _, inv := range invites {
+		if status := inviteStatus(inv, now); status == InviteStatusActive {
+			out = append(out, EventInviteItem{Invite: inv, Status: status})
+		}
+	}
+	return out, nil
+}
+
+// AcceptInvite redeems a code: the caller joins the event as a registered
+// player (an "invited" row or a pending join request is promoted) and the
+// invite's use count goes up. Returns ErrInviteUnavailable when the invite
+// can't be used any more, ErrMemberAlreadyExists for an existing member and
+// ErrEventFull when the event is at max_players.
+func (s *EventService) AcceptInvite(ctx context.Context, code string, callerID uuid.UUID) (EventMemberItem, error) {
+	var item EventMemberItem
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invite, caller, err := lockInviteFor(tx, code, callerID)
+		if err != nil {
+			return err
+		}
+		if inviteStatus(*invite, time.Now().UTC()) != InviteStatusActive {
+			return ErrInviteUnavailable
+		}
+
+		var player models.EventPlayer
+		err = tx.Where("event_id = ? AND user_id = ?", invite.EventID, callerID).First(&player).Error
+		switch {
+		case err == nil:
+			if player.Status != models.EventPlayerStatusInvited && player.Status != models.EventPlayerStatusPending {
+				return ErrMemberAlreadyExists
+			}
+			if err := requireOpenSpot(tx, invite.EventID); err != nil {
+				return err
+			}
+			if err := tx.Model(&player).Update("status", models.EventPlayerStatusRegistered).Error; err != nil {
+				return fmt.Errorf("register invited player: %w", err)
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := requireOpenSpot(tx, invite.EventID); err != nil {
+				return err
+			}
+			player = models.EventPlayer{
+				EventID: invite.EventID,
+				UserID:  callerID,
+				Role:    models.EventPlayerRolePlayer,
+				Status:  models.EventPlayerStatusRegistered,
+			}
+			if err := tx.Omit(clause.Associations).Create(&player).Error; err != nil {
+				return fmt.Errorf("create member: %w", err)
+			}
+		default:
+			return fmt.Errorf("load member: %w", err)
+		}
+
+		updates := map[string]any{"use_count": gorm.Expr("use_count + 1")}
+		if invite.Email != nil {
+			updates["invited_user_id"] = callerID
+		}
+		if err := tx.Model(invite).Updates(updates).Error; err != nil {
+			return fmt.Errorf("count invite use: %w", err)
+		}
+		item = EventMemberItem{Player: player, User: *caller}
+		return nil
+	})
+	if err != nil {
+		return EventMemberItem{}, err
+	}
+	return item, nil
+}
+
+// DeclineInvite turns down an invite addressed to the caller and removes their
+// "invited" row. An expired invite can still be declined, to clear the row.
+// Shareable codes aren't addressed to anyone, so there's nothing to decline.
+func (s *EventService) DeclineInvite(ctx context.Context, code string, callerID uuid.UUID) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invite, _, err := lockInviteFor(tx, code, callerID)
+		if err != nil {
+			return err
+		}
+		if invite.Email == nil {
+			return &ValidationError{Field: "code", Message: "only an invite addressed to you can be declined"}
+		}
+		if status := inviteStatus(*invite, time.Now().UTC()); status != InviteStatusActive && status != InviteStatusExpired {
+			return ErrInviteUnavailable
+		}
+		if err := tx.Model(invite).Updates(map[string]any{
+			"declined_at":     time.Now().UTC(),
+			"invited_user_id": callerID,
+		}).Error; err != nil {
+			return fmt.Errorf("decline invite: %w", err)
+		}
+		if err := tx.Where("event_id = ? AND user_id = ? AND status = ?",
+			invite.EventID, callerID, models.EventPlayerStatusInvited).
+			Delete(&models.EventPlayer{}).Error; err != nil {
+			return fmt.Errorf("remove invited player: %w", err)
+		}
+		return nil
+	})
+}
+
+// lockInviteFor loads the invite behind code FOR UPDATE, plus the caller.
+// An addressed invite that isn't the caller's is ErrInviteNotFound.
+func lockInviteFor(tx *gorm.DB, code string, callerID uuid.UUID) (*models.EventInvite, *models.User, error) {
+	var invite models.EventInvite
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&invite, "code = ?", normalizeInviteCode(code)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInviteNotFound
+		}
+		return nil, nil, fmt.Errorf("load invite: %w", err)
+	}
+	var caller models.User
+	if err := tx.First(&caller, "id = ?", callerID).Error; err != nil {
+		return nil, nil, fmt.Errorf("load caller: %w", err)
+	}
+	if invite.Email != nil && !addressedTo(invite, caller) {
+		return nil, nil, ErrInviteNotFound
+	}
+	return &invite, &caller, nil
+}
+
+// inviteItems pairs each invite with its state at now.
+func inviteItems(invites []models.EventInvite, now time.Time) []EventInviteItem {
+	out := make([]EventInviteItem, len(invites))
+	for i, inv := range invites {
+		out[i] = EventInviteItem{Invite: inv, Status: inviteStatus(inv, now)}
+	}
+	return out
+}
+
+// ─── Sign-in resolution ───────────────────────────────────────────────────────
+
+// ResolveEmailInvites gives a user an "invited" event_players row for every
+// open invite addressed to their email, so the events show up for them to
+// accept or decline. middleware.Auth runs it on every authenticated request,
+// but it only does any work once per address: an invite made after the email
+// is someone's resolves in CreateInvite, so users.invites_resolved_email is
+// enough to skip it, without a query, until the email changes. A failure
+// leaves the marker unset and is retried on the next request. Events the user
+// is already on are skipped. Idempotent.
+func ResolveEmailInvites(ctx context.Context, db *gorm.DB, user models.User) error {
+	if user.Email == "" || user.IsGuest {
+		return nil
+	}
+	email := strings.ToLower(user.Email)
+	if user.InvitesResolvedEmail != nil && *user.InvitesResolvedEmail == email {
+		return nil
+	}
+	var invites []models.EventInvite
+	if err := db.WithContext(ctx).
+		Where("email = ? AND invited_user_id IS NULL AND revoked_at IS NULL AND declined_at IS NULL AND use_count = 0",
+			email).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now().UTC()).
+		Find(&invites).Error; err != nil {
+		return fmt.Errorf("load email invites: %w", err)
+	}
+	for _, inv := range invites {
+		if err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&inv).Update("invited_user_id", user.ID).Error; err != nil {
+				return fmt.Errorf("resolve invite: %w", err)
+			}
+			player := models.EventPlayer{
+				EventID: inv.EventID,
+				UserID:  user.ID,
+				Role:    models.EventPlayerRolePlayer,
+				Status:  models.EventPlayerStatusInvited,
+			}
+			if err := tx.Omit(clause.Associations).
+				Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_id"}, {Name: "user_id"}}, DoNothing: true}).
+				Create(&player).Error; err != nil {
+				return fmt.Errorf("create invited player: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	if err := db.WithContext(ctx).Model(&models.User{}).Where("id = ?", user.ID).
+		Update("invites_resolved_email", email).Error; err != nil {
+		return fmt.Errorf("mark invites resolved: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// services/event_invites_internal_test.go
+// White-box tests for the unexported invite helpers in event_invites.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestInviteStatus|TestNewInviteCode|TestAddressedTo' -v
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+func TestInviteStatus(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	two := 2
+	email := "ann@example.com"
+	cases := map[string]struct {
+		invite models.EventInvite
+		want   string
+	}{
+		"open code":          {models.EventInvite{}, InviteStatusActive},
+		"not yet expired":    {models.EventInvite{ExpiresAt: &future}, InviteStatusActive},
+		"expired":            {models.EventInvite{ExpiresAt: &past}, InviteStatusExpired},
+		"expires right now":  {models.EventInvite{ExpiresAt: &now}, InviteStatusExpired},
+		"uses left":          {models.EventInvite{MaxUses: &two, UseCount: 1}, InviteStatusActive},
+		"used up":            {models.EventInvite{MaxUses: &two, UseCount: 2}, InviteStatusUsedUp},
+		"revoked wins":       {models.EventInvite{RevokedAt: &past, ExpiresAt: &past}, InviteStatusRevoked},
+		"addressed accepted": {models.EventInvite{Email: &email, UseCount: 1}, InviteStatusAccepted},
+		"addressed declined": {models.EventInvite{Email: &email, DeclinedAt: &past}, InviteStatusDeclined},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, inviteStatus(tc.invite, now))
+		})
+	}
+}
+
+func TestNewInviteCode(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		code, err := newInviteCode()
+		require.NoError(t, err)
+		require.Len(t, code, inviteCodeLength)
+		for _, r := range code {
+			assert.True(t, strings.ContainsRune(inviteCodeAlphabet, r), "unexpected %q", r)
+		}
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+	assert.Equal(t, "AB12CD", normalizeInviteCode(" ab12cd "))
+}
+
+func TestAddressedTo(t *testing.T) {
+	email := "ann@example.com"
+	ann := models.User{ID: uuid.New(), Email: "Ann@Example.com"}
+	other := models.User{ID: uuid.New(), Email: "bob@example.com"}
+
+	unresolved := models.EventInvite{Email: &email}
+	assert.True(t, addressedTo(unresolved, ann), "email match is case-insensitive")
+	assert.False(t, addressedTo(unresolved, other))
+
+	resolved := models.EventInvite{Email: &email, InvitedUserID: &other.ID}
+	assert.True(t, addressedTo(resolved, other), "a resolved invite follows the user, not the email")
+	assert.False(t, addressedTo(resolved, ann))
+}
@@ -0,0 +1,191 @@
+// services/event_invites_test.go
+// Integration tests for event invitations (event_invites.go): shareable codes
+// with use caps, addressed invites resolving to "invited" memberships (at once
+// or at first sign-in), accept / decline, and revocation. Docker must be running.
+//
+// The invite state machine and code generation are covered without a DB by
+// event_invites_internal_test.go.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run Invite -v
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+func TestEventService_Invite_CappedCode(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	organizer := seedUser(t, db, "invOrg")
+	event := seedEvent(t, svc, organizer.ID)
+
+	two := 2
+	item, err := svc.CreateInvite(ctx, event.ID, organizer.ID, "user", services.CreateInviteInput{MaxUses: &two})
+	require.NoError(t, err)
+	assert.Equal(t, services.InviteStatusActive, item.Status)
+	require.NotNil(t, item.Invite.ExpiresAt, "codes expire by default")
+	code := strings.ToLower(item.Invite.Code) // codes are case-insensitive
+
+	ann := seedUser(t, db, "invAnn")
+	member, err := svc.AcceptInvite(ctx, code, ann.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EventPlayerStatusRegistered, member.Player.Status)
+	_, err = svc.AcceptInvite(ctx, code, ann.ID)
+	assert.ErrorIs(t, err, services.ErrMemberAlreadyExists)
+
+	_, err = svc.AcceptInvite(ctx, code, seedUser(t, db, "invBob").ID)
+	require.NoError(t, err)
+	_, err = svc.AcceptInvite(ctx, code, seedUser(t, db, "invCat").ID)
+	assert.ErrorIs(t, err, services.ErrInviteUnavailable)
+
+	items, err := svc.ListInvites(ctx, event.ID, organizer.ID, "user")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, services.InviteStatusUsedUp, items[0].Status)
+	assert.Equal(t, 2, items[0].Invite.UseCount)
+
+	var ve *services.ValidationError
+	assert.ErrorAs(t, svc.DeclineInvite(ctx, code, ann.ID), &ve, "a shareable code can't be declined")
+}
+
+func TestEventService_Invite_AddressedToExistingUser(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	organizer := seedUser(t, db, "invOrg2")
+	event := seedEvent(t, svc, organizer.ID)
+	ann := seedUser(t, db, "invAnn2")
+	outsider := seedUser(t, db, "invOut2")
+
+	email := "  InvAnn2@test.local "
+	item, err := svc.CreateInvite(ctx, event.ID, organizer.ID, "user", services.CreateInviteInput{Email: &email})
+	require.NoError(t, err)
+	require.NotNil(t, item.Invite.InvitedUserID)
+	assert.Equal(t, ann.ID, *item.Invite.InvitedUserID)
+	_, err = svc.CreateInvite(ctx, event.ID, organizer.ID, "user", services.CreateInviteInput{Email: &email})
+	assert.ErrorIs(t, err, services.ErrInvitePending)
+
+	var player models.EventPlayer
+	require.NoError(t, db.First(&player, "event_id = ? AND user_id = ?", event.ID, ann.ID).Error)
+	assert.Equal(t, models.EventPlayerStatusInvited, player.Status)
+
+	mine, err := svc.ListMyInvites(ctx, ann.ID)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	assert.Equal(t, event.Name, mine[0].Invite.Event.Name)
+
+	_, err = svc.GetInvite(ctx, item.Invite.Code, outsider.ID)
+	assert.ErrorIs(t, err, services.ErrInviteNotFound, "addressed invites are private")
+	_, err = svc.AcceptInvite(ctx, item.Invite.Code, outsider.ID)
+	assert.ErrorIs(t, err, services.ErrInviteNotFound)
+
+	require.NoError(t, svc.DeclineInvite(ctx, item.Invite.Code, ann.ID))
+	var count int64
+	require.NoError(t, db.Model(&models.EventPlayer{}).Where("event_id = ? AND user_id = ?", event.ID, ann.ID).Count(&count).Error)
+	assert.Zero(t, count)
+	_, err = svc.AcceptInvite(ctx, item.Invite.Code, ann.ID)
+	assert.ErrorIs(t, err, services.ErrInviteUnavailable)
+}
+
+// signIn runs what middleware.Auth does for an authenticated request once the
+// user row is synced: resolve the invites addressed to their email.
+func signIn(t *testing.T, db *gorm.DB, userID uuid.UUID) {
+	t.Helper()
+	var user models.User
+	require.NoError(t, db.First(&user, "id = ?", userID).Error)
+	require.NoError(t, services.ResolveEmailInvites(context.Background(), db, user))
+}
+
+func TestEventService_Invite_ResolvesAtFirstSignIn(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	organizer := seedUser(t, db, "invOrg3")
+	event := seedEvent(t, svc, organizer.ID)
+
+	email := "newcomer@test.local"
+	item, err := svc.CreateInvite(ctx, event.ID, organizer.ID, "user", services.CreateInviteInput{Email: &email})
+	require.NoError(t, err)
+	assert.Nil(t, item.Invite.InvitedUserID)
+
+	// middleware.Auth resolves on every request; the second is a no-op.
+	newcomer := seedUser(t, db, "Newcomer")
+	signIn(t, db, newcomer.ID)
+	signIn(t, db, newcomer.ID)
+	mine, err := svc.ListMyInvites(ctx, newcomer.ID)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+
+	var player models.EventPlayer
+	require.NoError(t, db.First(&player, "event_id = ? AND user_id = ?", event.ID, newcomer.ID).Error)
+	assert.Equal(t, models.EventPlayerStatusInvited, player.Status)
+	var stored models.User
+	require.NoError(t, db.First(&stored, "id = ?", newcomer.ID).Error)
+	require.NotNil(t, stored.InvitesResolvedEmail, "later sign-ins skip the lookup")
+	assert.Equal(t, email, *stored.InvitesResolvedEmail)
+
+	member, err := svc.AcceptInvite(ctx, item.Invite.Code, newcomer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, player.ID, member.Player.ID)
+	assert.Equal(t, models.EventPlayerStatusRegistered, member.Player.Status)
+}
+
+func TestEventService_Invite_ResolvesAgainAfterEmailChange(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	organizer := seedUser(t, db, "invOrg5")
+	event := seedEvent(t, svc, organizer.ID)
+	mover := seedUser(t, db, "Mover")
+	signIn(t, db, mover.ID)
+
+	email := "moved@test.local"
+	_, err := svc.CreateInvite(ctx, event.ID, organizer.ID, "user", services.CreateInviteInput{Email: &email})
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&models.User{}).Where("id = ?", mover.ID).Update("email", email).Error)
+
+	signIn(t, db, mover.ID)
+	mine, err := svc.ListMyInvites(ctx, mover.ID)
+	require.NoError(t, err)
+	require.Len(t, mine, 1, "the new address's invite resolves")
+}
+
+func TestEventService_Invite_Revoke(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	organizer := seedUser(t, db, "invOrg4")
+	event := seedEvent(t, svc, organizer.ID)
+	ann := seedUser(t, db, "invAnn4")
+
+	_, err := svc.CreateInvite(ctx, event.ID, ann.ID, "user", services.CreateInviteInput{})
+	assert.ErrorIs(t, err, services.ErrEventForbidden)
+
+	email := ann.Email
+	item, err := svc.CreateInvite(ctx, event.ID, organizer.ID, "user", services.CreateInviteInput{Email: &email})
+	require.NoError(t, err)
+	require.NoError(t, svc.RevokeInvite(ctx, event.ID, item.Invite.ID, organizer.ID, "user"))
+	require.NoError(t, svc.RevokeInvite(ctx, event.ID, item.Invite.ID, organizer.ID, "user"))
+
+	var count int64
+	require.NoError(t, db.Model(&models.EventPlayer{}).Where("event_id = ? AND user_id = ?", event.ID, ann.ID).Count(&count).Error)
+	assert.Zero(t, count, "revoking removes the invited row")
+	_, err = svc.AcceptInvite(ctx, item.Invite.Code, ann.ID)
+	assert.ErrorIs(t, err, services.ErrInviteUnavailable)
+}
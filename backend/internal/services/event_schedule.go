@@ -0,0 +1,405 @@
+// services/event_schedule.go
+// Recurring league schedules. Leagues play the same night every week, so rather
+// than scheduling each round by hand an organizer saves a template — weekday,
+// season dates, course and tee, which nine, dates to skip — and the season's
+// rounds are generated from it through RoundService.Schedule, one per week,
+// named "Week N".
+//
+// Saving the template again regenerates the season. Only future rounds that the
+// schedule generated and that are still scheduled are touched: one on a date
+// the new template still plays is updated in place (keeping its groups and
+// pairings), one on a date it no longer plays is deleted, and new dates get new
+// rounds. Past, active and completed rounds, and rounds scheduled by hand, are
+// never changed.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrScheduleNotFound — the event has no league schedule.
+var ErrScheduleNotFound = errors.New("event has no schedule")
+
+// maxScheduleWeeks bounds a season at a year of weekly rounds.
+const maxScheduleWeeks = 53
+
+// EventScheduleInput is the template saved by EventScheduleService.Save.
+type EventScheduleInput struct {
+	Weekday       int    // 0 = Sunday … 6 = Saturday
+	StartDate     string // "YYYY-MM-DD"
+	EndDate       string // "YYYY-MM-DD"
+	CourseID      uuid.UUID
+	TeeID         uuid.UUID
+	Nines         string   // see models.ScheduleNines; blank = full
+	ScoringFormat *string  // nil = stroke
+	SkipDates     []string // "YYYY-MM-DD"
+}
+
+// ScheduleWeekData is one week of the season. RoundID is nil for a past week
+// that was never generated.
+type ScheduleWeekData struct {
+	Week              int     `json:"week"`
+	Date              string  `json:"date"`
+	NineHoleSelection *string `json:"nine_hole_selection"`
+	RoundID           *string `json:"round_id"`
+	RoundName         *string `json:"round_name"`
+	Status            *string `json:"status"`
+}
+
+// EventScheduleData is the payload for GET/PUT /events/:id/schedule.
+type EventScheduleData struct {
+	EventID       string             `json:"event_id"`
+	Weekday       int                `json:"weekday"`
+	WeekdayName   string             `json:"weekday_name"`
+	StartDate     string             `json:"start_date"`
+	EndDate       string             `json:"end_date"`
+	CourseID      string             `json:"course_id"`
+	TeeID         string             `json:"tee_id"`
+	Nines         string             `json:"nines"`
+	ScoringFormat *string            `json:"scoring_format"`
+	SkipDates     []string           `json:"skip_dates"`
+	Weeks         []ScheduleWeekData `json:"weeks"`
+}
+
+// seasonWeek is one computed week of a season.
+type seasonWeek struct {
+	Week int
+	Date time.Time
+	Nine *string
+}
+
+// EventScheduleService owns league schedule templates and the rounds they generate.
+// Construct once in main.go and inject into the schedule handler factories.
+type EventScheduleService struct {
+	DB       *gorm.DB
+	EventSvc *EventService
+	RoundSvc *RoundService
+	// Now returns the current time; the boundary between past and future weeks.
+	Now func() time.Time
+}
+
+// NewEventScheduleService builds an EventScheduleService. RoundSvc schedules
+// the generated rounds.
+func NewEventScheduleService(db *gorm.DB, eventSvc *EventService, roundSvc *RoundService) *EventScheduleService {
+	return &EventScheduleService{DB: db, EventSvc: eventSvc, RoundSvc: roundSvc, Now: time.Now}
+}
+
+// Get returns an event's schedule and its weeks. Non-admins must be members.
+func (s *EventScheduleService) Get(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string) (*EventScheduleData, error) {
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("load event: %w", err)
+	}
+	if models.UserRole(requesterRole) != models.UserRoleAdmin {
+		var count int64
+		if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+			Where("event_id = ? AND user_id = ?", eventID, requesterID).
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("check membership: %w", err)
+		}
+		if count == 0 {
+			return nil, ErrEventNotMember
+		}
+	}
+	return s.loadSchedule(ctx, eventID)
+}
+
+// Save stores the event's schedule template and (re)generates its rounds.
+// Caller must be an organizer (or admin) of an event that is not finalized.
+func (s *EventScheduleService) Save(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, in EventScheduleInput) (*EventScheduleData, error) {
+	schedule, err := normalizeScheduleInput(in)
+	if err != nil {
+		return nil, err
+	}
+	schedule.EventID = eventID
+	schedule.CreatedBy = callerID
+	// The upsert omits associations, so the skips need their event set here.
+	for i := range schedule.SkipDates {
+		schedule.SkipDates[i].EventID = eventID
+	}
+	weeks := seasonWeeks(schedule)
+	if len(weeks) == 0 {
+		return nil, &ValidationError{Field: "start_date", Message: "the season has no playing weeks"}
+	}
+	if len(weeks) > maxScheduleWeeks {
+		return nil, &ValidationError{Field: "end_date", Message: fmt.Sprintf("a season can have at most %d weeks", maxScheduleWeeks)}
+	}
+
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Rounds first: RoundService.Schedule reports a bad course or tee as
+		// ErrCourseNotFound / ErrTeeNotFound rather than a foreign key violation.
+		if err := s.generateRounds(ctx, tx, schedule, weeks, callerID, callerRole); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "event_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"weekday", "start_date", "end_date", "course_id", "tee_id", "nines", "scoring_format", "updated_at",
+			}),
+		}).Create(&schedule).Error; err != nil {
+			return fmt.Errorf("save schedule: %w", err)
+		}
+		if err := tx.Where("event_id = ?", eventID).Delete(&models.EventScheduleSkip{}).Error; err != nil {
+			return fmt.Errorf("clear skip dates: %w", err)
+		}
+		if len(schedule.SkipDates) > 0 {
+			if err := tx.Create(&schedule.SkipDates).Error; err != nil {
+				return fmt.Errorf("save skip dates: %w", err)
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		var ve *ValidationError
+		if errors.As(txErr, &ve) || errors.Is(txErr, ErrCourseNotFound) || errors.Is(txErr, ErrTeeNotFound) {
+			return nil, txErr
+		}
+		return nil, fmt.Errorf("save event schedule: %w", txErr)
+	}
+	return s.loadSchedule(ctx, eventID)
+}
+
+// generateRounds reconciles the event's generated rounds with the season's
+// weeks; see the file comment for what is kept, updated, deleted and created.
+func (s *EventScheduleService) generateRounds(ctx context.Context, tx *gorm.DB, schedule models.EventSchedule, weeks []seasonWeek, callerID uuid.UUID, callerRole string) error {
+	now := s.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var generated []models.Round
+	if err := tx.Where("event_id = ? AND schedule_week IS NOT NULL", schedule.EventID).
+		Find(&generated).Error; err != nil {
+		return fmt.Errorf("load generated rounds: %w", err)
+	}
+	taken := make(map[string]bool)            // dates held by rounds that stay as they are
+	editable := make(map[string]models.Round) // future, still-scheduled rounds by date
+	for _, r := range generated {
+		day := r.ScheduledDate.Format("2006-01-02")
+		if r.Status != models.RoundStatusScheduled || r.ScheduledDate.Before(today) {
+			taken[day] = true
+			continue
+		}
+		if _, dup := editable[day]; dup {
+			// Two editable rounds on a day can only come from hand edits; keep one.
+			if err := tx.Delete(&models.Round{}, "id = ?", r.ID).Error; err != nil {
+				return fmt.Errorf("delete round: %w", err)
+			}
+			continue
+		}
+		editable[day] = r
+	}
+
+	// The rounds are scheduled inside this transaction.
+	roundSvc := &RoundService{DB: tx, EventSvc: s.RoundSvc.EventSvc}
+	courseID, teeID := schedule.CourseID.String(), schedule.TeeID.String()
+	for _, w := range weeks {
+		day := w.Date.Format("2006-01-02")
+		if taken[day] || w.Date.Before(today) {
+			continue
+		}
+		name := fmt.Sprintf("Week %d", w.Week)
+		if r, ok := editable[day]; ok {
+			delete(editable, day)
+			updates := map[string]any{
+				"course_id":           schedule.CourseID,
+				"default_tee_id":      schedule.TeeID,
+				"nine_hole_selection": w.Nine,
+				"schedule_week":       w.Week,
+			}
+			if r.ScheduleWeek != nil && r.Name == fmt.Sprintf("Week %d", *r.ScheduleWeek) {
+				updates["name"] = name
+			}
+			if schedule.ScoringFormat != nil {
+				updates["scoring_format"] = *schedule.ScoringFormat
+			}
+			if err := tx.Model(&models.Round{}).Where("id = ?", r.ID).Updates(updates).Error; err != nil {
+				return fmt.Errorf("update %s: %w", name, err)
+			}
+			continue
+		}
+		result, err := roundSvc.Schedule(ctx, schedule.EventID, callerID, callerRole, ScheduleRoundInput{
+			Name:              name,
+			ScheduledDate:     day,
+			ScoringFormat:     schedule.ScoringFormat,
+			CourseID:          &courseID,
+			DefaultTeeID:      &teeID,
+			NineHoleSelection: w.Nine,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Round{}).Where("id = ?", result.Round.ID).
+			Update("schedule_week", w.Week).Error; err != nil {
+			return fmt.Errorf("tag %s: %w", name, err)
+		}
+	}
+
+	// Whatever is left is on a date the season no longer plays.
+	for _, r := range editable {
+		if err := tx.Delete(&models.Round{}, "id = ?", r.ID).Error; err != nil {
+			return fmt.Errorf("delete round: %w", err)
+		}
+	}
+	return nil
+}
+
+// normalizeScheduleInput validates the input into an EventSchedule (without
+// EventID and CreatedBy).
+func normalizeScheduleInput(in EventScheduleInput) (models.EventSchedule, error) {
+	var out models.EventSchedule
+	if in.Weekday < 0 || in.Weekday > 6 {
+		return out, &ValidationError{Field: "weekday", Message: "weekday must be 0 (Sunday) to 6 (Saturday)"}
+	}
+	start, err := time.Parse("2006-01-02", in.StartDate)
+	if err != nil {
+		return out, &ValidationError{Field: "start_date", Message: "start_date must be YYYY-MM-DD"}
+	}
+	end, err := time.Parse("2006-01-02", in.EndDate)
+	if err != nil {
+		return out, &ValidationError{Field: "end_date", Message: "end_date must be YYYY-MM-DD"}
+	}
+	if end.Before(start) {
+		return out, &ValidationError{Field: "end_date", Message: "end_date must not be before start_date"}
+	}
+	nines := models.ScheduleNines(in.Nines)
+	switch nines {
+	case "":
+		nines = models.ScheduleNinesFull
+	case models.ScheduleNinesFull, models.ScheduleNinesFront, models.ScheduleNinesBack,
+		models.ScheduleNinesAlternateFront, models.ScheduleNinesAlternateBack:
+	default:
+		return out, &ValidationError{
+			Field:   "nines",
+			Message: "nines must be 'full', 'front', 'back', 'alternate_front' or 'alternate_back'",
+		}
+	}
+	if in.ScoringFormat != nil && *in.ScoringFormat == "" {
+		in.ScoringFormat = nil
+	}
+
+	seen := make(map[time.Time]bool)
+	var skips []models.EventScheduleSkip
+	for _, raw := range in.SkipDates {
+		d, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return out, &ValidationError{Field: "skip_dates", Message: "skip_dates must be YYYY-MM-DD"}
+		}
+		if !seen[d] {
+			seen[d] = true
+			skips = append(skips, models.EventScheduleSkip{SkipDate: d})
+		}
+	}
+	return models.EventSchedule{
+		Weekday: in.Weekday, StartDate: start, EndDate: end,
+		CourseID: in.CourseID, TeeID: in.TeeID, Nines: string(nines),
+		ScoringFormat: in.ScoringFormat, SkipDates: skips,
+	}, nil
+}
+
+// seasonWeeks lists the season's playing dates — every Weekday from StartDate
+// to EndDate that isn't skipped — numbered from week 1, with the nine each
+// week plays. Alternating nines alternate by playing week, so a skipped date
+// doesn't play the same nine twice in a row.
+func seasonWeeks(schedule models.EventSchedule) []seasonWeek {
+	skipped := make(map[string]bool, len(schedule.SkipDates))
+	for _, sk := range schedule.SkipDates {
+		skipped[sk.SkipDate.Format("2006-01-02")] = true
+	}
+	first := schedule.StartDate
+	for first.Weekday() != time.Weekday(schedule.Weekday) {
+		first = first.AddDate(0, 0, 1)
+	}
+	var weeks []seasonWeek
+	for d := first; !d.After(schedule.EndDate); d = d.AddDate(0, 0, 7) {
+		if skipped[d.Format("2006-01-02")] {
+			continue
+		}
+		w := seasonWeek{Week: len(weeks) + 1, Date: d}
+		w.Nine = scheduleNine(models.ScheduleNines(schedule.Nines), w.Week)
+		weeks = append(weeks, w)
+	}
+	return weeks
+}
+
+// scheduleNine returns the nine_hole_selection for a week (nil = full round).
+func scheduleNine(nines models.ScheduleNines, week int) *string {
+	front, back := nineHoleFront, nineHoleBack
+	switch nines {
+	case models.ScheduleNinesFront:
+		return &front
+	case models.ScheduleNinesBack:
+		return &back
+	case models.ScheduleNinesAlternateFront:
+		if week%2 == 1 {
+			return &front
+		}
+		return &back
+	case models.ScheduleNinesAlternateBack:
+		if week%2 == 1 {
+			return &back
+		}
+		return &front
+	}
+	return nil
+}
+
+// loadSchedule builds the EventScheduleData payload.
+func (s *EventScheduleService) loadSchedule(ctx context.Context, eventID uuid.UUID) (*EventScheduleData, error) {
+	var schedule models.EventSchedule
+	if err := s.DB.WithContext(ctx).
+		Preload("SkipDates", func(db *gorm.DB) *gorm.DB { return db.Order("skip_date ASC") }).
+		First(&schedule, "event_id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, fmt.Errorf("load schedule: %w", err)
+	}
+	var rounds []models.Round
+	if err := s.DB.WithContext(ctx).
+		Where("event_id = ? AND schedule_week IS NOT NULL", eventID).
+		Find(&rounds).Error; err != nil {
+		return nil, fmt.Errorf("load generated rounds: %w", err)
+	}
+	byDate := make(map[string]models.Round, len(rounds))
+	for _, r := range rounds {
+		byDate[r.ScheduledDate.Format("2006-01-02")] = r
+	}
+
+	out := &EventScheduleData{
+		EventID: eventID.String(), Weekday: schedule.Weekday,
+		WeekdayName: time.Weekday(schedule.Weekday).String(),
+		StartDate:   schedule.StartDate.Format("2006-01-02"),
+		EndDate:     schedule.EndDate.Format("2006-01-02"),
+		CourseID:    schedule.CourseID.String(), TeeID: schedule.TeeID.String(),
+		Nines: schedule.Nines, ScoringFormat: schedule.ScoringFormat,
+		SkipDates: []string{}, Weeks: []ScheduleWeekData{},
+	}
+	for _, sk := range schedule.SkipDates {
+		out.SkipDates = append(out.SkipDates, sk.SkipDate.Format("2006-01-02"))
+	}
+	for _, w := range seasonWeeks(schedule) {
+		day := w.Date.Format("2006-01-02")
+		week := ScheduleWeekData{Week: w.Week, Date: day, NineHoleSelection: w.Nine}
+		if r, ok := byDate[day]; ok {
+			id, name, status := r.ID.String(), r.Name, string(r.Status)
+			week.RoundID, week.RoundName, week.Status = &id, &name, &status
+			week.NineHoleSelection = r.NineHoleSelection
+		}
+		out.Weeks = append(out.Weeks, week)
+	}
+	return out, nil
+}
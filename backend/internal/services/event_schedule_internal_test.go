@@ -0,0 +1,81 @@
+// services/event_schedule_internal_test.go
+// White-box tests for the unexported season helpers in event_schedule.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestSeasonWeeks|TestScheduleNine|TestNormalizeScheduleInput' -v
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+// day builds a UTC date.
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSeasonWeeks_EveryWeekdayMinusSkips(t *testing.T) {
+	weeks := seasonWeeks(models.EventSchedule{
+		Weekday:   int(time.Wednesday),
+		StartDate: day(2027, time.May, 1), // a Saturday
+		EndDate:   day(2027, time.June, 2),
+		Nines:     string(models.ScheduleNinesAlternateFront),
+		SkipDates: []models.EventScheduleSkip{{SkipDate: day(2027, time.May, 19)}},
+	})
+
+	require.Len(t, weeks, 4)
+	wantDates := []time.Time{day(2027, time.May, 5), day(2027, time.May, 12), day(2027, time.May, 26), day(2027, time.June, 2)}
+	wantNines := []string{"front", "back", "front", "back"}
+	for i, w := range weeks {
+		assert.Equal(t, i+1, w.Week)
+		assert.Equal(t, wantDates[i], w.Date)
+		require.NotNil(t, w.Nine)
+		assert.Equal(t, wantNines[i], *w.Nine, "week %d", w.Week)
+	}
+}
+
+func TestSeasonWeeks_EndDateInclusive(t *testing.T) {
+	weeks := seasonWeeks(models.EventSchedule{
+		Weekday:   int(time.Tuesday),
+		StartDate: day(2027, time.May, 4),
+		EndDate:   day(2027, time.May, 4),
+		Nines:     string(models.ScheduleNinesFull),
+	})
+	require.Len(t, weeks, 1)
+	assert.Nil(t, weeks[0].Nine)
+}
+
+func TestScheduleNine(t *testing.T) {
+	str := func(p *string) string {
+		if p == nil {
+			return "full"
+		}
+		return *p
+	}
+	assert.Equal(t, "full", str(scheduleNine(models.ScheduleNinesFull, 1)))
+	assert.Equal(t, "front", str(scheduleNine(models.ScheduleNinesFront, 2)))
+	assert.Equal(t, "back", str(scheduleNine(models.ScheduleNinesBack, 1)))
+	assert.Equal(t, "back", str(scheduleNine(models.ScheduleNinesAlternateBack, 1)))
+	assert.Equal(t, "front", str(scheduleNine(models.ScheduleNinesAlternateBack, 2)))
+}
+
+func TestNormalizeScheduleInput_DefaultsAndDedupesSkips(t *testing.T) {
+	blank := ""
+	out, err := normalizeScheduleInput(EventScheduleInput{
+		Weekday: 4, StartDate: "2027-05-06", EndDate: "2027-08-26",
+		ScoringFormat: &blank,
+		SkipDates:     []string{"2027-07-01", "2027-07-01"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(models.ScheduleNinesFull), out.Nines)
+	assert.Nil(t, out.ScoringFormat)
+	assert.Len(t, out.SkipDates, 1)
+}
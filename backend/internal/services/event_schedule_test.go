@@ -0,0 +1,150 @@
+// services/event_schedule_test.go
+// Integration tests for EventScheduleService (event_schedule.go).
+// Uses testutil.NewTestDB to spin up an ephemeral Postgres container — Docker
+// must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// scheduleFixture builds an event with a course and an EventScheduleService
+// whose clock reads now.
+func scheduleFixture(t *testing.T, db *gorm.DB, now time.Time) (*services.EventScheduleService, models.Event, models.User, services.EventScheduleInput) {
+	t.Helper()
+	eventSvc := services.NewEventService(db)
+	svc := services.NewEventScheduleService(db, eventSvc, services.NewRoundService(db, eventSvc))
+	svc.Now = func() time.Time { return now }
+	organizer := seedUser(t, db, "commish")
+	event := seedEvent(t, eventSvc, organizer.ID)
+	course, tee := seedCourseWithTee(t, db, "League Course")
+	in := services.EventScheduleInput{
+		Weekday:   int(time.Wednesday),
+		StartDate: "2027-05-05",
+		EndDate:   "2027-06-02",
+		CourseID:  course.ID,
+		TeeID:     tee.ID,
+	}
+	return svc, event, organizer, in
+}
+
+// scheduledRounds returns the event's generated rounds by date.
+func scheduledRounds(t *testing.T, db *gorm.DB, eventID uuid.UUID) map[string]models.Round {
+	t.Helper()
+	var rounds []models.Round
+	require.NoError(t, db.Where("event_id = ? AND schedule_week IS NOT NULL", eventID).Find(&rounds).Error)
+	out := make(map[string]models.Round, len(rounds))
+	for _, r := range rounds {
+		out[r.ScheduledDate.Format("2006-01-02")] = r
+	}
+	return out
+}
+
+func TestEventScheduleService_Save_GeneratesSeason(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc, event, organizer, in := scheduleFixture(t, db, time.Date(2027, 4, 1, 12, 0, 0, 0, time.UTC))
+	in.Nines = "alternate_back"
+
+	schedule, err := svc.Save(context.Background(), event.ID, organizer.ID, "user", in)
+	require.NoError(t, err)
+	require.Len(t, schedule.Weeks, 5)
+	assert.Equal(t, "Wednesday", schedule.WeekdayName)
+
+	rounds := scheduledRounds(t, db, event.ID)
+	require.Len(t, rounds, 5)
+	first := rounds["2027-05-05"]
+	assert.Equal(t, "Week 1", first.Name)
+	require.NotNil(t, first.NineHoleSelection)
+	assert.Equal(t, "back", *first.NineHoleSelection)
+	require.NotNil(t, first.ScheduleWeek)
+	assert.Equal(t, 1, *first.ScheduleWeek)
+	require.NotNil(t, rounds["2027-05-12"].NineHoleSelection)
+	assert.Equal(t, "front", *rounds["2027-05-12"].NineHoleSelection)
+	for _, w := range schedule.Weeks {
+		assert.NotNil(t, w.RoundID, "week %d", w.Week)
+	}
+}
+
+func TestEventScheduleService_Save_RegeneratesOnlyFutureRounds(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc, event, organizer, in := scheduleFixture(t, db, time.Date(2027, 4, 1, 12, 0, 0, 0, time.UTC))
+	_, err := svc.Save(context.Background(), event.ID, organizer.ID, "user", in)
+	require.NoError(t, err)
+	before := scheduledRounds(t, db, event.ID)
+
+	// Week 1 has been played; rain is forecast for May 19.
+	require.NoError(t, db.Model(&models.Round{}).Where("id = ?", before["2027-05-05"].ID).
+		Update("status", models.RoundStatusCompleted).Error)
+	svc.Now = func() time.Time { return time.Date(2027, 5, 10, 12, 0, 0, 0, time.UTC) }
+	in.Nines = "front"
+	in.SkipDates = []string{"2027-05-19"}
+	saved, err := svc.Save(context.Background(), event.ID, organizer.ID, "user", in)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2027-05-19"}, saved.SkipDates)
+
+	after := scheduledRounds(t, db, event.ID)
+	require.Len(t, after, 4)
+
+	played := after["2027-05-05"]
+	assert.Equal(t, before["2027-05-05"].ID, played.ID)
+	assert.Nil(t, played.NineHoleSelection, "completed round is not regenerated")
+
+	_, ok := after["2027-05-19"]
+	assert.False(t, ok, "skipped week's round is deleted")
+
+	moved := after["2027-05-26"]
+	assert.Equal(t, before["2027-05-26"].ID, moved.ID, "future round is updated in place")
+	assert.Equal(t, "Week 3", moved.Name)
+	require.NotNil(t, moved.NineHoleSelection)
+	assert.Equal(t, "front", *moved.NineHoleSelection)
+}
+
+func TestEventScheduleService_Save_StoresSkipDates(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc, event, organizer, in := scheduleFixture(t, db, time.Date(2027, 4, 1, 12, 0, 0, 0, time.UTC))
+	in.SkipDates = []string{"2027-05-26", "2027-05-12", "2027-05-26"}
+	_, err := svc.Save(context.Background(), event.ID, organizer.ID, "user", in)
+	require.NoError(t, err)
+
+	got, err := svc.Get(context.Background(), event.ID, organizer.ID, "user")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2027-05-12", "2027-05-26"}, got.SkipDates)
+
+	var stored []models.EventScheduleSkip
+	require.NoError(t, db.Find(&stored).Error)
+	require.Len(t, stored, 2)
+	for _, sk := range stored {
+		assert.Equal(t, event.ID, sk.EventID)
+	}
+}
+
+func TestEventScheduleService_Save_NotOrganizer(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc, event, _, in := scheduleFixture(t, db, time.Date(2027, 4, 1, 12, 0, 0, 0, time.UTC))
+	member := seedUser(t, db, "member")
+	addEventMember(t, db, event.ID, member.ID)
+
+	_, err := svc.Save(context.Background(), event.ID, member.ID, "user", in)
+	assert.ErrorIs(t, err, services.ErrEventForbidden)
+}
+
+func TestEventScheduleService_Get_NoSchedule(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc, event, organizer, _ := scheduleFixture(t, db, time.Date(2027, 4, 1, 12, 0, 0, 0, time.UTC))
+
+	_, err := svc.Get(context.Background(), event.ID, organizer.ID, "user")
+	assert.ErrorIs(t, err, services.ErrScheduleNotFound)
+}
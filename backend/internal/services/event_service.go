@@ -51,6 +51,13 @@ var (
 	ErrJoinRequestNotFound = errors.New("join request not found")
 	// ErrInvalidRole — role string is not "organizer" or "player".
 	ErrInvalidRole = errors.New("role must be 'organizer' or 'player'")
+	// ErrEventFinalized — the event's results are finalized; reopen it first.
+	ErrEventFinalized = errors.New("event results are finalized; reopen the event first")
+	// ErrEventNotFinalized — reopen attempted on an event that was never finalized.
+	ErrEventNotFinalized = errors.New("event is not finalized")
+	// ErrFinalizeRequired — Update asked to complete an event; only FinalizeEvent
+	// (POST /events/:id/finalize) does that, since it writes the results.
+	ErrFinalizeRequired = errors.New("finalize the event to complete it")
 )
 
 // ─── Inputs and DTOs ───────────────────────────────────────────────────────────
@@ -58,14 +65,19 @@ var (
 // CreateEventInput is the validated payload accepted by Create.
 // Date strings are "YYYY-MM-DD"; the service parses them.
 type CreateEventInput struct {
-	Name              string
-	Description       *string
-	EventType         string  // "league", "tournament", "casual"
-	StartDate         *string // optional, "" or nil = no start date
-	EndDate           *string
-	HandicapAllowance *float64 // 0..100; nil = full handicap
-	IsPublic          bool
-	CreatedBy         uuid.UUID
+	Name               string
+	Description        *string
+	EventType          string  // "league", "tournament", "casual"
+	StartDate          *string // optional, "" or nil = no start date
+	EndDate            *string
+	HandicapAllowance  *float64 // 0..100; nil = full handicap
+	TiebreakPolicy     *string  // "card_off" or "shared"; nil = card_off
+	SubPolicy          *string  // "team", "self" or "none"; nil = team
+	AbsencePolicy      *string  // "blind_draw", "phantom_par" or "phantom_average"; nil = phantom_par
+	MaxPlayers         *int     // field size; nil or 0 = no limit
+	WaitlistOfferHours *int     // nil or 0 = promote waitlisted players automatically
+	IsPublic           bool
+	CreatedBy          uuid.UUID
 }
 
 // UpdateEventInput is the optional-fields payload for Update.
@@ -75,14 +87,21 @@ type CreateEventInput struct {
 //   - non-nil pointer   → apply that value
 //   - StartDate/EndDate: nil = leave alone; pointer to "" = clear; pointer to
 //     "YYYY-MM-DD" = set.
+//   - MaxPlayers/WaitlistOfferHours: pointer to 0 = clear (no limit / promote
+//     automatically).
 type UpdateEventInput struct {
-	Name              *string
-	Description       *string
-	StartDate         *string
-	EndDate           *string
-	Status            *string  // "active", "completed", "cancelled"
-	HandicapAllowance *float64 // 0..100
-	IsPublic          *bool
+	Name               *string
+	Description        *string
+	StartDate          *string
+	EndDate            *string
+	Status             *string  // "active", "completed", "cancelled"
+	HandicapAllowance  *float64 // 0..100
+	TiebreakPolicy     *string  // "card_off" or "shared"
+	SubPolicy          *string  // "team", "self" or "none"
+	AbsencePolicy      *string  // "blind_draw", "phantom_par" or "phantom_average"
+	MaxPlayers         *int
+	WaitlistOfferHours *int
+	IsPublic           *bool
 }
 
 // ListEventsFilters scopes a List query to a single user's view.
@@ -108,9 +127,11 @@ type EventDetail struct {
 }
 
 // EventMemberItem is one row in a members list: the EventPlayer + the joined User.
+// EmailHidden means the user keeps their email from the caller.
 type EventMemberItem struct {
-	Player models.EventPlayer
-	User   models.User
+	Player      models.EventPlayer
+	User        models.User
+	EmailHidden bool
 }
 
 // UpdateEventResult is what Update returns: the saved event row + a flag
@@ -123,11 +144,13 @@ type UpdateEventResult struct {
 	StatusChanged    bool
 }
 
-// RoundsListItem is one row in GetRounds: the round + its course name + group count.
+// RoundsListItem is one row in GetRounds: the round + its course name + group,
+// comment and reaction counts.
 type RoundsListItem struct {
 	Round      models.Round
 	CourseName string
 	GroupCount int
+	Engagement RoundEngagement
 }
 
 // ─── Constructor ───────────────────────────────────────────────────────────────
@@ -263,6 +286,9 @@ func (s *EventService) Get(ctx context.Context, eventID, requesterID uuid.UUID,
 	for i, p := range players {
 		members[i] = EventMemberItem{Player: p, User: p.User}
 	}
+	if err := withholdMemberEmails(ctx, s.DB, requesterID, members); err != nil {
+		return EventDetail{}, err
+	}
 
 	return EventDetail{
 		Event:       event,
@@ -274,7 +300,8 @@ func (s *EventService) Get(ctx context.Context, eventID, requesterID uuid.UUID,
 
 // GetMembers returns the members list for an event. No membership check —
 // the route is open to any authenticated user, matching legacy behavior.
-func (s *EventService) GetMembers(ctx context.Context, eventID uuid.UUID) ([]EventMemberItem, error) {
+// Emails the members hide from callerID are withheld.
+func (s *EventService) GetMembers(ctx context.Context, eventID, callerID uuid.UUID) ([]EventMemberItem, error) {
 	var players []models.EventPlayer
 	if err := s.DB.WithContext(ctx).Preload("User").
 		Where("event_id = ?", eventID).Find(&players).Error; err != nil {
@@ -284,6 +311,9 @@ func (s *EventService) GetMembers(ctx context.Context, eventID uuid.UUID) ([]Eve
 	for i, p := range players {
 		out[i] = EventMemberItem{Player: p, User: p.User}
 	}
+	if err := withholdMemberEmails(ctx, s.DB, callerID, out); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
@@ -323,6 +353,10 @@ func (s *EventService) GetRounds(ctx context.Context, eventID uuid.UUID) ([]Roun
 	for _, row := range rows {
 		counts[row.RoundID] = row.Count
 	}
+	engagement, err := roundEngagementCounts(ctx, s.DB, ids)
+	if err != nil {
+		return nil, err
+	}
 
 	out := make([]RoundsListItem, len(rounds))
 	for i, r := range rounds {
@@ -330,6 +364,7 @@ func (s *EventService) GetRounds(ctx context.Context, eventID uuid.UUID) ([]Roun
 			Round:      r,
 			CourseName: r.Course.Name,
 			GroupCount: counts[r.ID.String()],
+			Engagement: engagement[r.ID],
 		}
 	}
 	return out, nil
@@ -362,19 +397,48 @@ func (s *EventService) Create(ctx context.Context, in CreateEventInput) (EventLi
 	if err := validateAllowance(in.HandicapAllowance); err != nil {
 		return EventListItem{}, err
 	}
+	if err := validateTiebreakPolicy(in.TiebreakPolicy); err != nil {
+		return EventListItem{}, err
+	}
+	if err := validateSubPolicy(in.SubPolicy); err != nil {
+		return EventListItem{}, err
+	}
+	if err := validateAbsencePolicy(in.AbsencePolicy); err != nil {
+		return EventListItem{}, err
+	}
+	if err := validateCapacity(in.MaxPlayers, in.WaitlistOfferHours); err != nil {
+		return EventListItem{}, err
+	}
+	tiebreak := string(models.TiebreakPolicyCardOff)
+	if in.TiebreakPolicy != nil {
+		tiebreak = *in.TiebreakPolicy
+	}
+	subPolicy := string(models.SubPolicyTeam)
+	if in.SubPolicy != nil {
+		subPolicy = *in.SubPolicy
+	}
+	absencePolicy := string(models.AbsencePolicyPhantomPar)
+	if in.AbsencePolicy != nil {
+		absencePolicy = *in.AbsencePolicy
+	}
 
 	var created models.Event
 	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		event := models.Event{
-			Name:              in.Name,
-			Description:       in.Description,
-			EventType:         models.EventType(in.EventType),
-			Status:            models.EventStatusActive, // "upcoming" was removed from the enum
-			StartDate:         startDate,
-			EndDate:           endDate,
-			HandicapAllowance: in.HandicapAllowance,
-			IsPublic:          in.IsPublic,
-			CreatedBy:         in.CreatedBy,
+			Name:               in.Name,
+			Description:        in.Description,
+			EventType:          models.EventType(in.EventType),
+			Status:             models.EventStatusActive, // "upcoming" was removed from the enum
+			StartDate:          startDate,
+			EndDate:            endDate,
+			HandicapAllowance:  in.HandicapAllowance,
+			TiebreakPolicy:     tiebreak,
+			SubPolicy:          subPolicy,
+			AbsencePolicy:      absencePolicy,
+			MaxPlayers:         positiveOrNil(in.MaxPlayers),
+			WaitlistOfferHours: positiveOrNil(in.WaitlistOfferHours),
+			IsPublic:           in.IsPublic,
+			CreatedBy:          in.CreatedBy,
 		}
 		if err := tx.Create(&event).Error; err != nil {
 			return fmt.Errorf("create event row: %w", err)
@@ -411,7 +475,8 @@ func (s *EventService) Create(ctx context.Context, in CreateEventInput) (EventLi
 // Update applies a partial patch to an event. Caller must have organizer role.
 //
 // Returns the saved event plus an AllowanceChanged flag — handlers use that
-// to decide whether to call RecalculateEventScores.
+// to decide whether to call RecalculateEventScores. Returns ErrEventFinalized
+// when a finalized event's allowance or result policies would change.
 func (s *EventService) Update(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string, in UpdateEventInput) (UpdateEventResult, error) {
 	// Validate body BEFORE the DB load so Tier-1 tests can reach validation
 	// without a real database (matches the legacy handler's order).
@@ -421,6 +486,18 @@ func (s *EventService) Update(ctx context.Context, eventID, requesterID uuid.UUI
 	if err := validateAllowance(in.HandicapAllowance); err != nil {
 		return UpdateEventResult{}, err
 	}
+	if err := validateTiebreakPolicy(in.TiebreakPolicy); err != nil {
+		return UpdateEventResult{}, err
+	}
+	if err := validateSubPolicy(in.SubPolicy); err != nil {
+		return UpdateEventResult{}, err
+	}
+	if err := validateAbsencePolicy(in.AbsencePolicy); err != nil {
+		return UpdateEventResult{}, err
+	}
+	if err := validateCapacity(in.MaxPlayers, in.WaitlistOfferHours); err != nil {
+		return UpdateEventResult{}, err
+	}
 
 	var event models.Event
 	if err := s.DB.WithContext(ctx).Preload("Creator").First(&event, "id = ?", eventID).Error; err != nil {
@@ -458,8 +535,25 @@ func (s *EventService) Update(ctx context.Context, eventID, requesterID uuid.UUI
 		}
 		event.EndDate = t
 	}
+	// Allowance and the policies feed net scores and positions, which the
+	// finalize step froze; they can only change after ReopenEvent.
+	if event.FinalizedAt != nil &&
+		(in.HandicapAllowance != nil || in.TiebreakPolicy != nil || in.SubPolicy != nil || in.AbsencePolicy != nil) {
+		return UpdateEventResult{}, ErrEventFinalized
+	}
 	statusChanged := false
 	if in.Status != nil {
+		// A finalized event can only leave "completed" through ReopenEvent, which
+		// records why; a bare status flip would silently unlock the scores.
+		if event.FinalizedAt != nil && models.EventStatus(*in.Status) != models.EventStatusCompleted {
+			return UpdateEventResult{}, ErrEventFinalized
+		}
+		// Completing is the finalize step: it writes positions and points and
+		// locks the scores, so a bare status flip can't stand in for it.
+		if event.FinalizedAt == nil && event.Status != models.EventStatusCompleted &&
+			models.EventStatus(*in.Status) == models.EventStatusCompleted {
+			return UpdateEventResult{}, ErrFinalizeRequired
+		}
 		switch models.EventStatus(*in.Status) {
 		case models.EventStatusActive, models.EventStatusCompleted, models.EventStatusCancelled:
 			if event.Status != models.EventStatus(*in.Status) {
@@ -477,6 +571,21 @@ func (s *EventService) Update(ctx context.Context, eventID, requesterID uuid.UUI
 	if allowanceChanged {
 		event.HandicapAllowance = in.HandicapAllowance
 	}
+	if in.TiebreakPolicy != nil {
+		event.TiebreakPolicy = *in.TiebreakPolicy
+	}
+	if in.SubPolicy != nil {
+		event.SubPolicy = *in.SubPolicy
+	}
+	if in.AbsencePolicy != nil {
+		event.AbsencePolicy = *in.AbsencePolicy
+	}
+	if in.MaxPlayers != nil {
+		event.MaxPlayers = positiveOrNil(in.MaxPlayers)
+	}
+	if in.WaitlistOfferHours != nil {
+		event.WaitlistOfferHours = positiveOrNil(in.WaitlistOfferHours)
+	}
 	if in.IsPublic != nil {
 		event.IsPublic = *in.IsPublic
 	}
@@ -484,6 +593,12 @@ func (s *EventService) Update(ctx context.Context, eventID, requesterID uuid.UUI
 	if err := s.DB.WithContext(ctx).Save(&event).Error; err != nil {
 		return UpdateEventResult{}, fmt.Errorf("save event: %w", err)
 	}
+	// A raised (or lifted) cap opens spots for the waitlist.
+	if in.MaxPlayers != nil {
+		if err := s.promoteWaitlist(ctx, event.ID); err != nil {
+			return UpdateEventResult{}, err
+		}
+	}
 
 	var memberCount int64
 	if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
@@ -525,6 +640,7 @@ func (s *EventService) Delete(ctx context.Context, eventID, requesterID uuid.UUI
 
 // AddMember adds a new player to an event. Caller must be organizer.
 // New members are added with role=player and status=registered.
+// Returns ErrEventFull when the event is at max_players.
 func (s *EventService) AddMember(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string, targetUserID uuid.UUID) (EventMemberItem, error) {
 	authorized, err := s.IsOrganizer(ctx, eventID, requesterID, requesterRole)
 	if err != nil {
@@ -560,14 +676,28 @@ func (s *EventService) AddMember(ctx context.Context, eventID, requesterID uuid.
 		Role:    models.EventPlayerRolePlayer,
 		Status:  models.EventPlayerStatusRegistered,
 	}
-	if err := s.DB.WithContext(ctx).Create(&player).Error; err != nil {
-		return EventMemberItem{}, fmt.Errorf("create event_player: %w", err)
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireOpenSpot(tx, eventID); err != nil {
+			return err
+		}
+		if err := tx.Create(&player).Error; err != nil {
+			return fmt.Errorf("create event_player: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return EventMemberItem{}, err
+	}
+	item := []EventMemberItem{{Player: player, User: target}}
+	if err := withholdMemberEmails(ctx, s.DB, requesterID, item); err != nil {
+		return EventMemberItem{}, err
 	}
-	return EventMemberItem{Player: player, User: target}, nil
+	return item[0], nil
 }
 
 // RemoveMember removes a player from an event. Caller must be organizer.
 // Refuses to remove the LAST organizer — every event must remain manageable.
+// The freed spot goes to the next player on the waitlist.
 func (s *EventService) RemoveMember(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string, targetUserID uuid.UUID) error {
 	authorized, err := s.IsOrganizer(ctx, eventID, requesterID, requesterRole)
 	if err != nil {
@@ -603,7 +733,7 @@ func (s *EventService) RemoveMember(ctx context.Context, eventID, requesterID uu
 	if err := s.DB.WithContext(ctx).Delete(&player).Error; err != nil {
 		return fmt.Errorf("delete event_player: %w", err)
 	}
-	return nil
+	return s.promoteWaitlist(ctx, eventID)
 }
 
 // ─── Public-event / join-request methods ───────────────────────────────────────
@@ -653,41 +783,59 @@ func (s *EventService) ListPublic(ctx context.Context, requesterID uuid.UUID) ([
 	return out, nil
 }
 
-// RequestJoin creates a pending event_player row for the requester on a public event.
+// RequestJoin creates a pending event_player row for the requester on a public event,
+// or a waitlisted one when the event is full. Returns the new row's status. A
+// pending request notifies the event's organizers.
 // Returns ErrEventNotFound, ErrEventNotPublic, or ErrMemberAlreadyExists as appropriate.
-func (s *EventService) RequestJoin(ctx context.Context, eventID, requesterID uuid.UUID) error {
-	var event models.Event
-	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrEventNotFound
+func (s *EventService) RequestJoin(ctx context.Context, eventID, requesterID uuid.UUID) (models.EventPlayerStatus, error) {
+	var player models.EventPlayer
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		event, err := lockEvent(tx, eventID)
+		if err != nil {
+			return err
+		}
+		if !event.IsPublic {
+			return ErrEventNotPublic
 		}
-		return fmt.Errorf("load event: %w", err)
-	}
-	if !event.IsPublic {
-		return ErrEventNotPublic
-	}
 
-	var existing models.EventPlayer
-	dupErr := s.DB.WithContext(ctx).
-		Where("event_id = ? AND user_id = ?", eventID, requesterID).
-		First(&existing).Error
-	if dupErr == nil {
-		return ErrMemberAlreadyExists
-	}
-	if !errors.Is(dupErr, gorm.ErrRecordNotFound) {
-		return fmt.Errorf("dup-check: %w", dupErr)
-	}
+		var existing models.EventPlayer
+		dupErr := tx.Where("event_id = ? AND user_id = ?", eventID, requesterID).First(&existing).Error
+		if dupErr == nil {
+			return ErrMemberAlreadyExists
+		}
+		if !errors.Is(dupErr, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("dup-check: %w", dupErr)
+		}
 
-	player := models.EventPlayer{
-		EventID: eventID,
-		UserID:  requesterID,
-		Role:    models.EventPlayerRolePlayer,
-		Status:  models.EventPlayerStatusPending,
+		player = models.EventPlayer{
+			EventID: eventID,
+			UserID:  requesterID,
+			Role:    models.EventPlayerRolePlayer,
+			Status:  models.EventPlayerStatusPending,
+		}
+		open, err := hasOpenSpot(tx, event)
+		if err != nil {
+			return err
+		}
+		if !open {
+			now := time.Now().UTC()
+			player.Status = models.EventPlayerStatusWaitlisted
+			player.WaitlistedAt = &now
+		}
+		if err := tx.Create(&player).Error; err != nil {
+			return fmt.Errorf("create join request: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
-	if err := s.DB.WithContext(ctx).Create(&player).Error; err != nil {
-		return fmt.Errorf("create join request: %w", err)
+	if player.Status == models.EventPlayerStatusPending {
+		notify(ctx, s.DB, models.Notification{
+			Type: models.NotificationTypeJoinRequest, ActorID: &requesterID, EventID: &eventID,
+		}, eventOrganizerIDs(s.DB, eventID))
 	}
-	return nil
+	return player.Status, nil
 }
 
 // ListJoinRequests returns all pending event_players for an event. Organizer-only.
@@ -710,12 +858,17 @@ func (s *EventService) ListJoinRequests(ctx context.Context, eventID, requesterI
 	for i, p := range players {
 		out[i] = EventMemberItem{Player: p, User: p.User}
 	}
+	if err := withholdMemberEmails(ctx, s.DB, requesterID, out); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
-// HandleJoinRequest approves or denies a pending join request.
+// HandleJoinRequest approves or denies a pending join request, and notifies
+// the requester either way.
 // approve=true → set status to registered; approve=false → delete the row.
-// Returns ErrJoinRequestNotFound if there is no pending row for targetUserID.
+// Returns ErrJoinRequestNotFound if there is no pending row for targetUserID,
+// and ErrEventFull when approving into a full event.
 func (s *EventService) HandleJoinRequest(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string, targetUserID uuid.UUID, approve bool) error {
 	authorized, err := s.IsOrganizer(ctx, eventID, requesterID, requesterRole)
 	if err != nil {
@@ -736,15 +889,28 @@ func (s *EventService) HandleJoinRequest(ctx context.Context, eventID, requester
 		return fmt.Errorf("load join request: %w", err)
 	}
 
-	if approve {
-		if err := s.DB.WithContext(ctx).Model(&player).Update("status", models.EventPlayerStatusRegistered).Error; err != nil {
-			return fmt.Errorf("approve join request: %w", err)
-		}
-	} else {
+	answer := models.Notification{Type: models.NotificationTypeJoinDeclined, ActorID: &requesterID, EventID: &eventID}
+	if !approve {
 		if err := s.DB.WithContext(ctx).Delete(&player).Error; err != nil {
 			return fmt.Errorf("deny join request: %w", err)
 		}
+		notify(ctx, s.DB, answer, []uuid.UUID{targetUserID})
+		return nil
 	}
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireOpenSpot(tx, eventID); err != nil {
+			return err
+		}
+		if err := tx.Model(&player).Update("status", models.EventPlayerStatusRegistered).Error; err != nil {
+			return fmt.Errorf("approve join request: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	answer.Type = models.NotificationTypeJoinApproved
+	notify(ctx, s.DB, answer, []uuid.UUID{targetUserID})
 	return nil
 }
 
@@ -830,3 +996,51 @@ func validateAllowance(allowance *float64) error {
 	}
 	return nil
 }
+
+// validateTiebreakPolicy accepts nil (leave alone / default) or one of the
+// TiebreakPolicy values.
+func validateTiebreakPolicy(policy *string) error {
+	if policy == nil {
+		return nil
+	}
+	switch models.TiebreakPolicy(*policy) {
+	case models.TiebreakPolicyCardOff, models.TiebreakPolicyShared:
+		return nil
+	}
+	return &ValidationError{
+		Field:   "tiebreak_policy",
+		Message: "tiebreak_policy must be 'card_off' or 'shared'",
+	}
+}
+
+// validateSubPolicy accepts nil (leave alone / default) or one of the
+// SubPolicy values.
+func validateSubPolicy(policy *string) error {
+	if policy == nil {
+		return nil
+	}
+	switch models.SubPolicy(*policy) {
+	case models.SubPolicyTeam, models.SubPolicySelf, models.SubPolicyNone:
+		return nil
+	}
+	return &ValidationError{
+		Field:   "sub_policy",
+		Message: "sub_policy must be 'team', 'self' or 'none'",
+	}
+}
+
+// validateAbsencePolicy accepts nil (leave alone / default) or one of the
+// AbsencePolicy values.
+func validateAbsencePolicy(policy *string) error {
+	if policy == nil {
+		return nil
+	}
+	switch models.AbsencePolicy(*policy) {
+	case models.AbsencePolicyBlindDraw, models.AbsencePolicyPhantomPar, models.AbsencePolicyPhantomAverage:
+		return nil
+	}
+	return &ValidationError{
+		Field:   "absence_policy",
+		Message: "absence_policy must be 'blind_draw', 'phantom_par' or 'phantom_average'",
+	}
+}
@@ -304,7 +304,7 @@ func TestEventService_Update_PatchesFields(t *testing.T) {
 	event := createEventViaService(t, svc, creator.ID, "L")
 
 	newName := "Renamed"
-	newStatus := "completed"
+	newStatus := "cancelled"
 	allowance := 90.0
 	startDate := "2026-06-01"
 
@@ -316,7 +316,7 @@ func TestEventService_Update_PatchesFields(t *testing.T) {
 	})
 	require.NoError(t, err)
 	assert.Equal(t, "Renamed", result.Event.Name)
-	assert.Equal(t, models.EventStatusCompleted, result.Event.Status)
+	assert.Equal(t, models.EventStatusCancelled, result.Event.Status)
 	require.NotNil(t, result.Event.HandicapAllowance)
 	assert.Equal(t, 90.0, *result.Event.HandicapAllowance)
 	require.NotNil(t, result.Event.StartDate)
@@ -324,6 +324,21 @@ func TestEventService_Update_PatchesFields(t *testing.T) {
 	assert.True(t, result.StatusChanged)
 }
 
+func TestEventService_Update_CompleteRequiresFinalize(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	creator := seedUser(t, db, "creator")
+	event := createEventViaService(t, svc, creator.ID, "L")
+
+	completed := "completed"
+	_, err := svc.Update(context.Background(), event.ID, creator.ID, "user", services.UpdateEventInput{Status: &completed})
+	assert.ErrorIs(t, err, services.ErrFinalizeRequired)
+
+	var stored models.Event
+	require.NoError(t, db.First(&stored, "id = ?", event.ID).Error)
+	assert.NotEqual(t, models.EventStatusCompleted, stored.Status)
+}
+
 func TestEventService_Update_ClearStartDate(t *testing.T) {
 	db := testutil.NewTestDB(t)
 	svc := services.NewEventService(db)
@@ -523,7 +538,7 @@ func TestEventService_GetMembers_OrderingAndShape(t *testing.T) {
 	_, err := svc.AddMember(context.Background(), event.ID, creator.ID, "user", target.ID)
 	require.NoError(t, err)
 
-	got, err := svc.GetMembers(context.Background(), event.ID)
+	got, err := svc.GetMembers(context.Background(), event.ID, creator.ID)
 	require.NoError(t, err)
 	assert.Len(t, got, 2)
 	// Each member item carries the joined User row (display name etc.) for the handler.
@@ -630,8 +645,9 @@ func TestEventService_RequestJoin_CreatesRow(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	err = svc.RequestJoin(context.Background(), event.Event.ID, requester.ID)
+	status, err := svc.RequestJoin(context.Background(), event.Event.ID, requester.ID)
 	require.NoError(t, err)
+	assert.Equal(t, models.EventPlayerStatusPending, status)
 
 	var player models.EventPlayer
 	err = db.Where("event_id = ? AND user_id = ?", event.Event.ID, requester.ID).First(&player).Error
@@ -651,7 +667,7 @@ func TestEventService_RequestJoin_PrivateEventFails(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	err = svc.RequestJoin(context.Background(), event.Event.ID, requester.ID)
+	_, err = svc.RequestJoin(context.Background(), event.Event.ID, requester.ID)
 	assert.ErrorIs(t, err, services.ErrEventNotPublic)
 }
 
@@ -666,7 +682,7 @@ func TestEventService_RequestJoin_AlreadyMemberFails(t *testing.T) {
 	require.NoError(t, err)
 
 	// Creator is already a member — requesting join should fail.
-	err = svc.RequestJoin(context.Background(), event.Event.ID, creator.ID)
+	_, err = svc.RequestJoin(context.Background(), event.Event.ID, creator.ID)
 	assert.ErrorIs(t, err, services.ErrMemberAlreadyExists)
 }
 
@@ -694,7 +710,7 @@ func TestEventService_ListJoinRequests_ReturnsPending(t *testing.T) {
 		Name: "Open League", EventType: "league", IsPublic: true, CreatedBy: creator.ID,
 	})
 	require.NoError(t, err)
-	err = svc.RequestJoin(context.Background(), event.Event.ID, requester.ID)
+	_, err = svc.RequestJoin(context.Background(), event.Event.ID, requester.ID)
 	require.NoError(t, err)
 
 	items, err := svc.ListJoinRequests(context.Background(), event.Event.ID, creator.ID, "user")
@@ -716,7 +732,8 @@ func TestEventService_HandleJoinRequest_Approve(t *testing.T) {
 		Name: "Open League", EventType: "league", IsPublic: true, CreatedBy: creator.ID,
 	})
 	require.NoError(t, err)
-	require.NoError(t, svc.RequestJoin(context.Background(), event.Event.ID, requester.ID))
+	_, err = svc.RequestJoin(context.Background(), event.Event.ID, requester.ID)
+	require.NoError(t, err)
 
 	err = svc.HandleJoinRequest(context.Background(), event.Event.ID, creator.ID, "user", requester.ID, true)
 	require.NoError(t, err)
@@ -736,7 +753,8 @@ func TestEventService_HandleJoinRequest_Deny(t *testing.T) {
 		Name: "Open League", EventType: "league", IsPublic: true, CreatedBy: creator.ID,
 	})
 	require.NoError(t, err)
-	require.NoError(t, svc.RequestJoin(context.Background(), event.Event.ID, requester.ID))
+	_, err = svc.RequestJoin(context.Background(), event.Event.ID, requester.ID)
+	require.NoError(t, err)
 
 	err = svc.HandleJoinRequest(context.Background(), event.Event.ID, creator.ID, "user", requester.ID, false)
 	require.NoError(t, err)
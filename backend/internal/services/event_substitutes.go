@@ -0,0 +1,318 @@
+// services/event_substitutes.go
+// League substitutes. Each event keeps a roster of subs: event members flagged
+// is_substitute, who aren't part of the regular field (generated pairings and
+// bracket seeding leave them out) but can be swapped into a round in place of
+// a registered player.
+//
+// A replacement reuses the round_players row — only the user changes — so the
+// sub inherits the group slot, team membership and flight of the player they
+// replaced. The row records the member whose spot the sub took, and the
+// event's sub_policy decides who the round's points count for in the
+// standings (see LeaderboardService.computeStandings).
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrNotSubstitute — the user is not on the event's substitute roster.
+	ErrNotSubstitute = errors.New("user is not a substitute for this event")
+	// ErrSubstituteHasRounds — a sub who is in one of the event's rounds can't
+	// be taken off the roster (their round_players rows would go with them).
+	ErrSubstituteHasRounds = errors.New("substitute is playing in a round of this event")
+	// ErrSubstituteInRound — the sub is already playing in this round.
+	ErrSubstituteInRound = errors.New("substitute is already playing in this round")
+	// ErrPlayerHasScores — the player being replaced has already entered scores.
+	ErrPlayerHasScores = errors.New("player has already entered scores for this round")
+	// ErrRoundCompleted — the round is over, so its players can't change.
+	ErrRoundCompleted = errors.New("round is completed")
+)
+
+// subPolicyFor returns the event's sub policy, defaulting to team for legacy
+// rows with an empty value.
+func subPolicyFor(event *models.Event) models.SubPolicy {
+	if event == nil || event.SubPolicy == "" {
+		return models.SubPolicyTeam
+	}
+	return models.SubPolicy(event.SubPolicy)
+}
+
+// ─── Substitute roster ────────────────────────────────────────────────────────
+
+// ListSubstitutes returns the event's substitute roster, oldest first.
+// Non-admins must be members of the event.
+func (s *EventService) ListSubstitutes(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string) ([]EventMemberItem, error) {
+	if err := s.requireEventMember(ctx, eventID, requesterID, requesterRole); err != nil {
+		return nil, err
+	}
+	var players []models.EventPlayer
+	if err := s.DB.WithContext(ctx).Preload("User").
+		Where("event_id = ? AND is_substitute", eventID).
+		Order("created_at ASC").Find(&players).Error; err != nil {
+		return nil, fmt.Errorf("load substitutes: %w", err)
+	}
+	out := make([]EventMemberItem, len(players))
+	for i, p := range players {
+		out[i] = EventMemberItem{Player: p, User: p.User}
+	}
+	if err := withholdMemberEmails(ctx, s.DB, requesterID, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddSubstitute puts a user on the event's substitute roster as a registered
+// player member. A user who is already a member can't also be a sub
+// (ErrMemberAlreadyExists). Organizer-only.
+func (s *EventService) AddSubstitute(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, targetUserID uuid.UUID) (EventMemberItem, error) {
+	if _, err := s.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return EventMemberItem{}, err
+	}
+
+	var target models.User
+	if err := s.DB.WithContext(ctx).First(&target, "id = ?", targetUserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return EventMemberItem{}, ErrUserNotFound
+		}
+		return EventMemberItem{}, fmt.Errorf("load target user: %w", err)
+	}
+	var count int64
+	if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+		Where("event_id = ? AND user_id = ?", eventID, targetUserID).
+		Count(&count).Error; err != nil {
+		return EventMemberItem{}, fmt.Errorf("dup-check: %w", err)
+	}
+	if count > 0 {
+		return EventMemberItem{}, ErrMemberAlreadyExists
+	}
+
+	player := models.EventPlayer{
+		EventID:      eventID,
+		UserID:       targetUserID,
+		Role:         models.EventPlayerRolePlayer,
+		Status:       models.EventPlayerStatusRegistered,
+		IsSubstitute: true,
+	}
+	if err := s.DB.WithContext(ctx).Create(&player).Error; err != nil {
+		return EventMemberItem{}, fmt.Errorf("create substitute: %w", err)
+	}
+	item := []EventMemberItem{{Player: player, User: target}}
+	if err := withholdMemberEmails(ctx, s.DB, callerID, item); err != nil {
+		return EventMemberItem{}, err
+	}
+	return item[0], nil
+}
+
+// RemoveSubstitute takes a user off the substitute roster (and out of the
+// event). Refused while the sub is in any of the event's rounds — swap the
+// original player back first. Organizer-only.
+func (s *EventService) RemoveSubstitute(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, targetUserID uuid.UUID) error {
+	if _, err := s.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return err
+	}
+	var player models.EventPlayer
+	if err := s.DB.WithContext(ctx).
+		Where("event_id = ? AND user_id = ? AND is_substitute", eventID, targetUserID).
+		First(&player).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotSubstitute
+		}
+		return fmt.Errorf("load substitute: %w", err)
+	}
+	var rounds int64
+	if err := s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).
+		Where("event_player_id = ?", player.ID).Count(&rounds).Error; err != nil {
+		return fmt.Errorf("count substitute rounds: %w", err)
+	}
+	if rounds > 0 {
+		return ErrSubstituteHasRounds
+	}
+	if err := s.DB.WithContext(ctx).Delete(&player).Error; err != nil {
+		return fmt.Errorf("delete substitute: %w", err)
+	}
+	return nil
+}
+
+// requireEventMember returns ErrEventNotFound for a missing event and
+// ErrEventNotMember when a non-admin requester isn't a member.
+func (s *EventService) requireEventMember(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string) error {
+	var event models.Event
+	if err := s.DB.WithContext(ctx).Select("id").First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrEventNotFound
+		}
+		return fmt.Errorf("load event: %w", err)
+	}
+	if models.UserRole(requesterRole) == models.UserRoleAdmin {
+		return nil
+	}
+	var count int64
+	if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+		Where("event_id = ? AND user_id = ?", eventID, requesterID).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("check membership: %w", err)
+	}
+	if count == 0 {
+		return ErrEventNotMember
+	}
+	return nil
+}
+
+// ─── Replacement ──────────────────────────────────────────────────────────────
+
+// ReplacePlayerResult is returned by ReplacePlayer: the round player as it now
+// stands, and the member whose spot they are playing (nil once the original
+// player is swapped back in).
+type ReplacePlayerResult struct {
+	Player         GroupPlayerResult
+	GroupID        *uuid.UUID
+	ReplacedUserID *uuid.UUID
+}
+
+// ReplacePlayer swaps a round player for a substitute in a scheduled or active
+// event round. The round_players row is kept, so the sub takes over the group
+// slot, team membership and flight; their handicap and attestation start
+// fresh. Passing the original member's user ID instead of a sub swaps them
+// back; passing whoever already holds the spot is a no-op, so retries are
+// safe. Refused once the player being replaced has entered a score.
+// Organizer-only.
+func (s *RoundService) ReplacePlayer(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole string, substituteUserID uuid.UUID) (ReplacePlayerResult, error) {
+	isOrg, err := s.requireRoundOrganizer(ctx, roundID, callerID, callerRole)
+	if err != nil {
+		return ReplacePlayerResult{}, err
+	}
+	if !isOrg {
+		return ReplacePlayerResult{}, ErrRoundForbidden
+	}
+	var round models.Round
+	if err := s.DB.WithContext(ctx).Select("id, event_id, status").First(&round, "id = ?", roundID).Error; err != nil {
+		return ReplacePlayerResult{}, fmt.Errorf("load round: %w", err)
+	}
+	if round.EventID == nil {
+		return ReplacePlayerResult{}, &ValidationError{Field: "round", Message: "substitutes can only play in event rounds"}
+	}
+	if round.Status == models.RoundStatusCompleted {
+		return ReplacePlayerResult{}, ErrRoundCompleted
+	}
+
+	// The spot is checked and swapped in one transaction, holding the round
+	// player row: a score's foreign key check waits on the lock, so no score
+	// can be entered between "no scores yet" and the swap.
+	var rp models.RoundPlayer
+	var incoming models.EventPlayer
+	var replaced *uuid.UUID
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&rp, "id = ? AND round_id = ?", roundPlayerID, roundID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRoundPlayerNotFound
+			}
+			return fmt.Errorf("load round player: %w", err)
+		}
+		if rp.EventPlayerID == nil {
+			return &ValidationError{Field: "round_player_id", Message: "guests can't be replaced; remove them from the group instead"}
+		}
+		// The spot belongs to the member who was replaced first; a sub replacing a
+		// sub plays for the same member.
+		spot := *rp.EventPlayerID
+		if rp.ReplacedEventPlayerID != nil {
+			spot = *rp.ReplacedEventPlayerID
+		}
+		if err := tx.Where("event_id = ? AND user_id = ?", *round.EventID, substituteUserID).
+			First(&incoming).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotSubstitute
+			}
+			return fmt.Errorf("load substitute: %w", err)
+		}
+		swapBack := incoming.ID == spot
+		if !swapBack && !incoming.IsSubstitute {
+			return ErrNotSubstitute
+		}
+
+		replaced = rp.ReplacedEventPlayerID
+		// Already in the spot (a retried request): nothing to change.
+		if incoming.ID == *rp.EventPlayerID {
+			return nil
+		}
+		var scored int64
+		if err := tx.Model(&models.Score{}).
+			Where("round_player_id = ?", rp.ID).Count(&scored).Error; err != nil {
+			return fmt.Errorf("count scores: %w", err)
+		}
+		if scored > 0 {
+			return ErrPlayerHasScores
+		}
+		var busy int64
+		if err := tx.Model(&models.RoundPlayer{}).
+			Where("round_id = ? AND event_player_id = ?", roundID, incoming.ID).Count(&busy).Error; err != nil {
+			return fmt.Errorf("check round players: %w", err)
+		}
+		if busy > 0 {
+			return ErrSubstituteInRound
+		}
+
+		replaced = nil
+		if !swapBack {
+			replaced = &spot
+		}
+		if err := tx.Model(&models.RoundPlayer{}).Where("id = ?", rp.ID).Updates(map[string]any{
+			"user_id":                  incoming.UserID,
+			"event_player_id":          incoming.ID,
+			"replaced_event_player_id": replaced,
+			"tee_id":                   nil,
+			"handicap_index":           nil,
+			"course_handicap":          nil,
+			"player_attested_at":       nil,
+			"marker_attested_at":       nil,
+			"marker_id":                nil,
+			"needs_reattestation":      false,
+			"status":                   models.RoundPlayerStatusRegistered,
+		}).Error; err != nil {
+			return fmt.Errorf("replace round player: %w", err)
+		}
+		return nil
+	})
+	if txErr != nil {
+		return ReplacePlayerResult{}, txErr
+	}
+
+	var user models.User
+	if err := s.DB.WithContext(ctx).First(&user, "id = ?", incoming.UserID).Error; err != nil {
+		return ReplacePlayerResult{}, fmt.Errorf("load substitute user: %w", err)
+	}
+	hidden, err := hiddenEmails(ctx, s.DB, callerID, []uuid.UUID{user.ID})
+	if err != nil {
+		return ReplacePlayerResult{}, err
+	}
+	out := ReplacePlayerResult{Player: GroupPlayerResult{
+		RoundPlayerID: rp.ID.String(), UserID: user.ID.String(), DisplayName: user.DisplayName,
+		Email: &user.Email, AvatarURL: user.AvatarURL, IsGuest: user.IsGuest,
+	}}
+	if hidden[user.ID] {
+		out.Player.Email = nil
+	}
+	var gp models.GroupPlayer
+	if err := s.DB.WithContext(ctx).Where("round_player_id = ?", rp.ID).Limit(1).Find(&gp).Error; err != nil {
+		return ReplacePlayerResult{}, fmt.Errorf("load group slot: %w", err)
+	}
+	if gp.GroupID != uuid.Nil {
+		out.GroupID = &gp.GroupID
+	}
+	if replaced != nil {
+		var member models.EventPlayer
+		if err := s.DB.WithContext(ctx).Select("user_id").First(&member, "id = ?", *replaced).Error; err != nil {
+			return ReplacePlayerResult{}, fmt.Errorf("load replaced member: %w", err)
+		}
+		out.ReplacedUserID = &member.UserID
+	}
+	return out, nil
+}
@@ -0,0 +1,193 @@
+// services/event_substitutes_test.go
+// Integration tests for league substitutes: the roster on EventService,
+// RoundService.ReplacePlayer, and how standings credit a sub's round under
+// each sub policy. Docker must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run Substitute -v
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// addSub puts a fresh user on the event's substitute roster.
+func addSub(t *testing.T, eventSvc *services.EventService, db *gorm.DB, eventID, organizerID uuid.UUID, name string) models.User {
+	t.Helper()
+	u := seedUser(t, db, name)
+	_, err := eventSvc.AddSubstitute(context.Background(), eventID, organizerID, "user", u.ID)
+	require.NoError(t, err)
+	return u
+}
+
+func TestEventService_Substitutes_Roster(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	eventSvc := services.NewEventService(db)
+	ctx := context.Background()
+	organizer := seedUser(t, db, "subOrg")
+	event := seedEvent(t, eventSvc, organizer.ID)
+	member := seedUser(t, db, "subMember")
+	addEventMember(t, db, event.ID, member.ID)
+
+	sub := addSub(t, eventSvc, db, event.ID, organizer.ID, "subSam")
+
+	subs, err := eventSvc.ListSubstitutes(ctx, event.ID, member.ID, "user")
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, sub.ID, subs[0].User.ID)
+	assert.True(t, subs[0].Player.IsSubstitute)
+
+	_, err = eventSvc.AddSubstitute(ctx, event.ID, organizer.ID, "user", member.ID)
+	assert.ErrorIs(t, err, services.ErrMemberAlreadyExists)
+	_, err = eventSvc.AddSubstitute(ctx, event.ID, member.ID, "user", seedUser(t, db, "subOther").ID)
+	assert.ErrorIs(t, err, services.ErrEventForbidden)
+	assert.ErrorIs(t, eventSvc.RemoveSubstitute(ctx, event.ID, organizer.ID, "user", member.ID), services.ErrNotSubstitute)
+
+	require.NoError(t, eventSvc.RemoveSubstitute(ctx, event.ID, organizer.ID, "user", sub.ID))
+	subs, err = eventSvc.ListSubstitutes(ctx, event.ID, member.ID, "user")
+	require.NoError(t, err)
+	assert.Empty(t, subs)
+}
+
+func TestRoundService_ReplacePlayer_KeepsGroupAndTeam(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	eventSvc := services.NewEventService(db)
+	svc := services.NewRoundService(db, eventSvc)
+	ctx := context.Background()
+
+	roundID, groupID, event, organizer := vegasRoundWithGroup(t, svc, eventSvc, db, "rep1")
+	alice, aliceRP := addVegasPlayer(t, svc, db, roundID, groupID, event.ID, organizer.ID, "rep1Alice")
+	_, bobRP := addVegasPlayer(t, svc, db, roundID, groupID, event.ID, organizer.ID, "rep1Bob")
+	team, err := svc.CreateTeam(ctx, roundID, organizer.ID, "user", "Team A")
+	require.NoError(t, err)
+	_, err = svc.AssignTeamMembers(ctx, roundID, team.Team.ID, organizer.ID, "user", []uuid.UUID{aliceRP, bobRP})
+	require.NoError(t, err)
+	sub := addSub(t, eventSvc, db, event.ID, organizer.ID, "rep1Sub")
+
+	res, err := svc.ReplacePlayer(ctx, roundID, aliceRP, organizer.ID, "user", sub.ID)
+	require.NoError(t, err)
+	assert.Equal(t, sub.ID.String(), res.Player.UserID)
+	assert.Equal(t, aliceRP.String(), res.Player.RoundPlayerID)
+	require.NotNil(t, res.GroupID)
+	assert.Equal(t, groupID, *res.GroupID)
+	require.NotNil(t, res.ReplacedUserID)
+	assert.Equal(t, alice.ID, *res.ReplacedUserID)
+
+	var members int64
+	require.NoError(t, db.Model(&models.TeamMember{}).
+		Where("team_id = ? AND round_player_id = ?", team.Team.ID, aliceRP).Count(&members).Error)
+	assert.Equal(t, int64(1), members, "the sub keeps alice's team slot")
+
+	// A retry is a no-op; naming alice swaps her back in.
+	_, err = svc.ReplacePlayer(ctx, roundID, aliceRP, organizer.ID, "user", sub.ID)
+	require.NoError(t, err)
+	res, err = svc.ReplacePlayer(ctx, roundID, aliceRP, organizer.ID, "user", alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID.String(), res.Player.UserID)
+	assert.Nil(t, res.ReplacedUserID)
+	var stored models.RoundPlayer
+	require.NoError(t, db.First(&stored, "id = ?", aliceRP).Error)
+	assert.Nil(t, stored.ReplacedEventPlayerID)
+}
+
+func TestRoundService_ReplacePlayer_Rejections(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	eventSvc := services.NewEventService(db)
+	svc := services.NewRoundService(db, eventSvc)
+	ctx := context.Background()
+
+	roundID, groupID, event, organizer := vegasRoundWithGroup(t, svc, eventSvc, db, "rep2")
+	_, aliceRP := addVegasPlayer(t, svc, db, roundID, groupID, event.ID, organizer.ID, "rep2Alice")
+	bob, bobRP := addVegasPlayer(t, svc, db, roundID, groupID, event.ID, organizer.ID, "rep2Bob")
+	sub := addSub(t, eventSvc, db, event.ID, organizer.ID, "rep2Sub")
+
+	_, err := svc.ReplacePlayer(ctx, roundID, aliceRP, organizer.ID, "user", bob.ID)
+	assert.ErrorIs(t, err, services.ErrNotSubstitute, "a regular member isn't a sub")
+	_, err = svc.ReplacePlayer(ctx, roundID, aliceRP, bob.ID, "user", sub.ID)
+	assert.ErrorIs(t, err, services.ErrRoundForbidden)
+	_, err = svc.ReplacePlayer(ctx, roundID, uuid.New(), organizer.ID, "user", sub.ID)
+	assert.ErrorIs(t, err, services.ErrRoundPlayerNotFound)
+
+	_, err = svc.ReplacePlayer(ctx, roundID, aliceRP, organizer.ID, "user", sub.ID)
+	require.NoError(t, err)
+	_, err = svc.ReplacePlayer(ctx, roundID, bobRP, organizer.ID, "user", sub.ID)
+	assert.ErrorIs(t, err, services.ErrSubstituteInRound)
+	assert.ErrorIs(t, eventSvc.RemoveSubstitute(ctx, event.ID, organizer.ID, "user", sub.ID), services.ErrSubstituteHasRounds)
+
+	other := addSub(t, eventSvc, db, event.ID, organizer.ID, "rep2Other")
+	sc := models.Score{RoundPlayerID: bobRP, HoleNumber: 1, GrossScore: 4, NetScore: 4, EnteredBy: organizer.ID}
+	require.NoError(t, db.Omit(clause.Associations).Create(&sc).Error)
+	_, err = svc.ReplacePlayer(ctx, roundID, bobRP, organizer.ID, "user", other.ID)
+	assert.ErrorIs(t, err, services.ErrPlayerHasScores)
+}
+
+func TestLeaderboardService_EventStandings_SubPolicy(t *testing.T) {
+	cases := map[models.SubPolicy]struct {
+		bobPoints int // 0 = bob has no standings line
+		subPoints int // 0 = the sub has no standings line
+	}{
+		models.SubPolicyTeam: {bobPoints: 10},
+		models.SubPolicySelf: {subPoints: 10},
+		models.SubPolicyNone: {},
+	}
+	for policy, want := range cases {
+		t.Run(string(policy), func(t *testing.T) {
+			db := testutil.NewTestDB(t)
+			svc := newLeaderboardSvc(db)
+			eventSvc := services.NewEventService(db)
+			roundID, event, _, bobRP := tiedRound(t, db)
+			orgID := organizerOf(t, db, event.ID)
+			require.NoError(t, db.Model(&models.Event{}).Where("id = ?", event.ID).
+				Update("sub_policy", string(policy)).Error)
+			for pos, pts := range map[int]int{1: 10, 2: 6} {
+				rule := models.EventPointsRule{EventID: event.ID, FinishPosition: pos, Points: pts}
+				require.NoError(t, db.Omit(clause.Associations).Create(&rule).Error)
+			}
+
+			// The sub played bob's card (the winning one): swap the row over
+			// directly, since ReplacePlayer refuses once scores exist.
+			sub := addSub(t, eventSvc, db, event.ID, orgID, "lbSub")
+			var subEP models.EventPlayer
+			require.NoError(t, db.First(&subEP, "event_id = ? AND user_id = ?", event.ID, sub.ID).Error)
+			require.NoError(t, db.Model(&models.RoundPlayer{}).Where("id = ?", bobRP.ID).Updates(map[string]any{
+				"user_id": sub.ID, "event_player_id": subEP.ID, "replaced_event_player_id": *bobRP.EventPlayerID,
+			}).Error)
+			completeRound(t, db, roundID)
+
+			standings, err := svc.EventStandings(context.Background(), event.ID, orgID, "user")
+			require.NoError(t, err)
+			points := map[string]*int{}
+			for _, e := range standings.Entries {
+				points[e.EventPlayerID] = e.TotalPoints
+			}
+			bobEntry, bobListed := points[bobRP.EventPlayerID.String()]
+			subEntry, subListed := points[subEP.ID.String()]
+			if want.bobPoints > 0 {
+				require.True(t, bobListed)
+				assert.Equal(t, want.bobPoints, *bobEntry)
+			} else {
+				assert.False(t, bobListed)
+			}
+			if want.subPoints > 0 {
+				require.True(t, subListed)
+				assert.Equal(t, want.subPoints, *subEntry)
+			} else {
+				assert.False(t, subListed)
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+// services/event_waitlist.go
+// Event capacity and the waitlist. An organizer caps an event's field with
+// max_players: registered and completed members, substitutes aside, plus any
+// waitlisted player currently holding an offer. Once the field is full a join
+// request is queued as "waitlisted" rather than pending, and adding a member
+// directly (AddMember, approving a request, accepting an invite) is refused
+// with ErrEventFull.
+//
+// When a spot opens — a member withdraws or is removed, the cap goes up, or an
+// offer is declined or lapses — the longest-waiting player is promoted:
+//
+//   - waitlist_offer_hours NULL: straight to registered.
+//   - waitlist_offer_hours set:  offered the spot until offer_expires_at. They
+//     accept it with AcceptWaitlistOffer; if they let it lapse they drop off
+//     the list and the spot passes to the next player (ExpireWaitlistOffers,
+//     run on a ticker from main.go).
+//
+// Promotions are logged with event_type_label "event.waitlist_promoted" /
+// "event.waitlist_offered" so the promoted player can be told.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrEventFull — the event's field is at max_players.
+	ErrEventFull = errors.New("event is full")
+	// ErrNotWaitlisted — the caller is not on the event's waitlist.
+	ErrNotWaitlisted = errors.New("not on the waitlist for this event")
+	// ErrNoWaitlistOffer — the caller is waitlisted but holds no open offer,
+	// either because none was made or because it lapsed.
+	ErrNoWaitlistOffer = errors.New("no spot has been offered")
+)
+
+// maxWaitlistOfferHours caps waitlist_offer_hours (one week).
+const maxWaitlistOfferHours = 7 * 24
+
+// WaitlistEntry is one waitlisted member with their place in the queue
+// (1 = next in line). Event is loaded for ListMyWaitlists.
+type WaitlistEntry struct {
+	Player   models.EventPlayer
+	User     models.User
+	Event    models.Event
+	Position int
+}
+
+// WaitlistPromotion records one player moved up from the waitlist.
+// OfferExpiresAt is nil when they were registered outright.
+type WaitlistPromotion struct {
+	EventID        uuid.UUID
+	UserID         uuid.UUID
+	OfferExpiresAt *time.Time
+}
+
+// validateCapacity checks max_players and waitlist_offer_hours. 0 clears
+// either one (no limit / promote automatically).
+func validateCapacity(maxPlayers, offerHours *int) error {
+	if maxPlayers != nil && *maxPlayers < 0 {
+		return &ValidationError{Field: "max_players", Message: "max_players must be 0 (no limit) or more"}
+	}
+	if offerHours != nil && (*offerHours < 0 || *offerHours > maxWaitlistOfferHours) {
+		return &ValidationError{
+			Field:   "waitlist_offer_hours",
+			Message: fmt.Sprintf("waitlist_offer_hours must be between 0 and %d", maxWaitlistOfferHours),
+		}
+	}
+	return nil
+}
+
+// positiveOrNil maps the "0 clears" convention onto a nullable column.
+func positiveOrNil(v *int) *int {
+	if v == nil || *v == 0 {
+		return nil
+	}
+	return v
+}
+
+// ─── Member-facing methods ────────────────────────────────────────────────────
+
+// AcceptWaitlistOffer takes the spot offered to the caller: their waitlisted
+// row becomes registered. Returns ErrNotWaitlisted when they aren't on the list
+// and ErrNoWaitlistOffer when no offer is open.
+func (s *EventService) AcceptWaitlistOffer(ctx context.Context, eventID, callerID uuid.UUID) (EventMemberItem, error) {
+	var item EventMemberItem
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockEvent(tx, eventID); err != nil {
+			return err
+		}
+		var player models.EventPlayer
+		err := tx.Preload("User").
+			Where("event_id = ? AND user_id = ? AND status = ?", eventID, callerID, models.EventPlayerStatusWaitlisted).
+			First(&player).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotWaitlisted
+		}
+		if err != nil {
+			return fmt.Errorf("load waitlisted member: %w", err)
+		}
+		if player.OfferExpiresAt == nil || !player.OfferExpiresAt.After(time.Now().UTC()) {
+			return ErrNoWaitlistOffer
+		}
+		if err := tx.Model(&player).Updates(map[string]any{
+			"status":           models.EventPlayerStatusRegistered,
+			"waitlisted_at":    nil,
+			"offer_expires_at": nil,
+		}).Error; err != nil {
+			return fmt.Errorf("accept waitlist offer: %w", err)
+		}
+		player.Status = models.EventPlayerStatusRegistered
+		player.WaitlistedAt = nil
+		player.OfferExpiresAt = nil
+		item = EventMemberItem{Player: player, User: player.User}
+		return nil
+	})
+	if err != nil {
+		return EventMemberItem{}, err
+	}
+	return item, nil
+}
+
+// WithdrawFromEvent lets the caller leave an event. A registered member is
+// marked withdrawn (their rounds and scores stay); a waitlisted player leaves
+// the list, declining any offer they hold; a pending join request is dropped.
+// A spot freed by the caller goes to the next player on the waitlist, in the
+// same transaction, with the event locked so a concurrent finalize, join or
+// withdrawal can't interleave. Withdrawing again is a no-op.
+func (s *EventService) WithdrawFromEvent(ctx context.Context, eventID, callerID uuid.UUID) error {
+	var promoted []WaitlistPromotion
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		event, err := lockEvent(tx, eventID)
+		if err != nil {
+			return err
+		}
+		if event.FinalizedAt != nil {
+			return ErrEventFinalized
+		}
+
+		var player models.EventPlayer
+		err = tx.Where("event_id = ? AND user_id = ?", eventID, callerID).First(&player).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMemberNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("load member: %w", err)
+		}
+
+		switch player.Status {
+		case models.EventPlayerStatusWithdrawn:
+			return nil
+		case models.EventPlayerStatusWaitlisted, models.EventPlayerStatusPending:
+			if err := tx.Delete(&player).Error; err != nil {
+				return fmt.Errorf("leave waitlist: %w", err)
+			}
+		case models.EventPlayerStatusInvited:
+			// Invites are declined through the invite code, which records it.
+			return &ValidationError{Field: "status", Message: "decline the invite instead"}
+		default:
+			if err := tx.Model(&player).
+				Update("status", models.EventPlayerStatusWithdrawn).Error; err != nil {
+				return fmt.Errorf("withdraw member: %w", err)
+			}
+		}
+		promoted, err = fillOpenSpots(tx, event, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.announcePromotions(ctx, promoted)
+	return nil
+}
+
+// ListMyWaitlists returns every event the caller is waitlisted for, with
+// their position and any open offer, soonest-expiring offer first.
+func (s *EventService) ListMyWaitlists(ctx context.Context, callerID uuid.UUID) ([]WaitlistEntry, error) {
+	var players []models.EventPlayer
+	if err := s.DB.WithContext(ctx).Preload("User").Preload("Event").
+		Where("user_id = ? AND status = ?", callerID, models.EventPlayerStatusWaitlisted).
+		Order("offer_expires_at ASC NULLS LAST, waitlisted_at ASC").
+		Find(&players).Error; err != nil {
+		return nil, fmt.Errorf("list my waitlists: %w", err)
+	}
+	if len(players) == 0 {
+		return []WaitlistEntry{}, nil
+	}
+
+	// Every queue the caller is in, numbered in one pass.
+	type positionRow struct {
+		ID       uuid.UUID
+		Position int
+	}
+	var rows []positionRow
+	if err := s.DB.WithContext(ctx).Raw(`
+		SELECT id, position FROM (
+			SELECT id, user_id,
+			       ROW_NUMBER() OVER (PARTITION BY event_id ORDER BY waitlisted_at, created_at) AS position
+			FROM event_players
+			WHERE status = ? AND event_id IN (
+				SELECT event_id FROM event_players WHERE user_id = ? AND status = ?)
+		) q
+		WHERE user_id = ?
+	`, models.EventPlayerStatusWaitlisted, callerID, models.EventPlayerStatusWaitlisted, callerID).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("waitlist positions: %w", err)
+	}
+	positions := make(map[uuid.UUID]int, len(rows))
+	for _, r := range rows {
+		positions[r.ID] = r.Position
+	}
+
+	out := make([]WaitlistEntry, len(players))
+	for i, p := range players {
+		out[i] = WaitlistEntry{Player: p, User: p.User, Event: p.Event, Position: positions[p.ID]}
+	}
+	return out, nil
+}
+
+// ─── Organizer methods ────────────────────────────────────────────────────────
+
+// ListWaitlist returns the event's waitlist in queue order. Organizer-only.
+func (s *EventService) ListWaitlist(ctx context.Context, eventID, callerID uuid.UUID, callerRole string) ([]WaitlistEntry, error) {
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("load event: %w", err)
+	}
+	authorized, err := s.IsOrganizer(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if !authorized {
+		return nil, ErrEventForbidden
+	}
+
+	var players []models.EventPlayer
+	if err := s.DB.WithContext(ctx).Preload("User").
+		Where("event_id = ? AND status = ?", eventID, models.EventPlayerStatusWaitlisted).
+		Order("waitlisted_at ASC, created_at ASC").
+		Find(&players).Error; err != nil {
+		return nil, fmt.Errorf("list waitlist: %w", err)
+	}
+	out := make([]WaitlistEntry, len(players))
+	for i, p := range players {
+		out[i] = WaitlistEntry{Player: p, User: p.User, Event: event, Position: i + 1}
+	}
+	return out, nil
+}
+
+// ExpireWaitlistOffers drops every waitlisted player whose offer has lapsed
+// and passes their spots down the list. Run periodically from main.go.
+func (s *EventService) ExpireWaitlistOffers(ctx context.Context) error {
+	var eventIDs []uuid.UUID
+	if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+		Distinct("event_id").
+		Where("status = ? AND offer_expires_at <= ?", models.EventPlayerStatusWaitlisted, time.Now().UTC()).
+		Pluck("event_id", &eventIDs).Error; err != nil {
+		return fmt.Errorf("find lapsed waitlist offers: %w", err)
+	}
+	for _, id := range eventIDs {
+		if err := s.promoteWaitlist(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// lockEvent loads an event FOR UPDATE, serializing capacity checks and
+// promotions on it.
+func lockEvent(tx *gorm.DB, eventID uuid.UUID) (*models.Event, error) {
+	var event models.Event
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("lock event: %w", err)
+	}
+	return &event, nil
+}
+
+// fieldSize counts the spots taken in an event: registered and completed
+// members who aren't substitutes, plus waitlisted players holding an offer.
+func fieldSize(tx *gorm.DB, eventID uuid.UUID, now time.Time) (int, error) {
+	var n int64
+	if err := tx.Model(&models.EventPlayer{}).
+		Where("event_id = ? AND NOT is_substitute", eventID).
+		Where("status IN ? OR (status = ? AND offer_expires_at > ?)",
+			[]models.EventPlayerStatus{models.EventPlayerStatusRegistered, models.EventPlayerStatusCompleted},
+			models.EventPlayerStatusWaitlisted, now).
+		Count(&n).Error; err != nil {
+		return 0, fmt.Errorf("count field: %w", err)
+	}
+	return int(n), nil
+}
+
+// hasOpenSpot reports whether the event has room for one more member.
+func hasOpenSpot(tx *gorm.DB, event *models.Event) (bool, error) {
+	if event.MaxPlayers == nil {
+		return true, nil
+	}
+	n, err := fieldSize(tx, event.ID, time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+	return n < *event.MaxPlayers, nil
+}
+
+// requireOpenSpot locks the event and returns ErrEventFull when its field is
+// at capacity. Call it inside the transaction that adds the member.
+func requireOpenSpot(tx *gorm.DB, eventID uuid.UUID) error {
+	event, err := lockEvent(tx, eventID)
+	if err != nil {
+		return err
+	}
+	open, err := hasOpenSpot(tx, event)
+	if err != nil {
+		return err
+	}
+	if !open {
+		return ErrEventFull
+	}
+	return nil
+}
+
+// promoteWaitlist fills the event's open spots from the waitlist and, once the
+// transaction commits, logs and notifies each promoted player.
+func (s *EventService) promoteWaitlist(ctx context.Context, eventID uuid.UUID) error {
+	var promoted []WaitlistPromotion
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		event, err := lockEvent(tx, eventID)
+		if err != nil {
+			return err
+		}
+		promoted, err = fillOpenSpots(tx, event, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("promote waitlist: %w", err)
+	}
+	s.announcePromotions(ctx, promoted)
+	return nil
+}
+
+// announcePromotions logs and notifies each promoted player. Call it once the
+// transaction that promoted them has committed.
+func (s *EventService) announcePromotions(ctx context.Context, promoted []WaitlistPromotion) {
+	for _, p := range promoted {
+		label, msg := "event.waitlist_promoted", "Waitlisted player registered"
+		kind := models.NotificationTypeWaitlistJoined
+		if p.OfferExpiresAt != nil {
+			label, msg = "event.waitlist_offered", "Waitlisted player offered a spot"
+			kind = models.NotificationTypeWaitlistOffer
+		}
+		slog.InfoContext(ctx, msg,
+			"event_type_label", label,
+			"event_id", p.EventID.String(),
+			"user_id", p.UserID.String(),
+		)
+		notify(ctx, s.DB, models.Notification{Type: kind, EventID: &p.EventID}, []uuid.UUID{p.UserID})
+	}
+}
+
+// fillOpenSpots drops lapsed offers, then promotes waitlisted players — in
+// queue order, skipping those already holding an offer — until the field is
+// full. event must be locked by the caller.
+func fillOpenSpots(tx *gorm.DB, event *models.Event, now time.Time) ([]WaitlistPromotion, error) {
+	if err := tx.Where("event_id = ? AND status = ? AND offer_expires_at <= ?",
+		event.ID, models.EventPlayerStatusWaitlisted, now).
+		Delete(&models.EventPlayer{}).Error; err != nil {
+		return nil, fmt.Errorf("drop lapsed offers: %w", err)
+	}
+
+	q := tx.Where("event_id = ? AND status = ? AND offer_expires_at IS NULL", event.ID, models.EventPlayerStatusWaitlisted).
+		Order("waitlisted_at ASC, created_at ASC")
+	if event.MaxPlayers != nil {
+		taken, err := fieldSize(tx, event.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		open := *event.MaxPlayers - taken
+		if open <= 0 {
+			return nil, nil
+		}
+		q = q.Limit(open)
+	}
+	var next []models.EventPlayer
+	if err := q.Find(&next).Error; err != nil {
+		return nil, fmt.Errorf("load waitlist: %w", err)
+	}
+
+	promoted := make([]WaitlistPromotion, 0, len(next))
+	for _, p := range next {
+		promotion := WaitlistPromotion{EventID: event.ID, UserID: p.UserID}
+		updates := map[string]any{"status": models.EventPlayerStatusRegistered, "waitlisted_at": nil}
+		if event.WaitlistOfferHours != nil {
+			expires := now.Add(time.Duration(*event.WaitlistOfferHours) * time.Hour)
+			promotion.OfferExpiresAt = &expires
+			updates = map[string]any{"offer_expires_at": expires}
+		}
+		if err := tx.Model(&models.EventPlayer{}).Where("id = ?", p.ID).Updates(updates).Error; err != nil {
+			return nil, fmt.Errorf("promote waitlisted player: %w", err)
+		}
+		promoted = append(promoted, promotion)
+	}
+	return promoted, nil
+}
@@ -0,0 +1,51 @@
+// services/event_waitlist_internal_test.go
+// White-box tests for the unexported capacity helpers in event_waitlist.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestValidateCapacity|TestPositiveOrNil' -v
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCapacity(t *testing.T) {
+	ptr := func(v int) *int { return &v }
+	cases := map[string]struct {
+		maxPlayers, offerHours *int
+		wantField              string
+	}{
+		"both unset":        {nil, nil, ""},
+		"zero clears":       {ptr(0), ptr(0), ""},
+		"cap and offer":     {ptr(24), ptr(48), ""},
+		"week-long offer":   {nil, ptr(maxWaitlistOfferHours), ""},
+		"negative cap":      {ptr(-1), nil, "max_players"},
+		"negative offer":    {nil, ptr(-1), "waitlist_offer_hours"},
+		"offer over a week": {nil, ptr(maxWaitlistOfferHours + 1), "waitlist_offer_hours"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateCapacity(tc.maxPlayers, tc.offerHours)
+			if tc.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var ve *ValidationError
+			require.ErrorAs(t, err, &ve)
+			assert.Equal(t, tc.wantField, ve.Field)
+		})
+	}
+}
+
+func TestPositiveOrNil(t *testing.T) {
+	zero, five := 0, 5
+	assert.Nil(t, positiveOrNil(nil))
+	assert.Nil(t, positiveOrNil(&zero))
+	require.NotNil(t, positiveOrNil(&five))
+	assert.Equal(t, 5, *positiveOrNil(&five))
+}
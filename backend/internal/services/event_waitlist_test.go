@@ -0,0 +1,228 @@
+// services/event_waitlist_test.go
+// Integration tests for event capacity and the waitlist (event_waitlist.go):
+// queueing join requests once an event is full, refusing direct adds, and
+// promoting the next player outright or with a timed offer. Docker must be
+// running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run Waitlist -v
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// fullEvent creates a public event capped at two players (the organizer and
+// one member) with offerHours as its waitlist_offer_hours.
+func fullEvent(t *testing.T, db *gorm.DB, svc *services.EventService, offerHours *int) (eventID uuid.UUID, organizer, member models.User) {
+	t.Helper()
+	organizer = seedUser(t, db, "wlOrg")
+	member = seedUser(t, db, "wlMember")
+	two := 2
+	created, err := svc.Create(context.Background(), services.CreateEventInput{
+		Name: "Capped League", EventType: "league", IsPublic: true, CreatedBy: organizer.ID,
+		MaxPlayers: &two, WaitlistOfferHours: offerHours,
+	})
+	require.NoError(t, err)
+	_, err = svc.AddMember(context.Background(), created.Event.ID, organizer.ID, "user", member.ID)
+	require.NoError(t, err)
+	return created.Event.ID, organizer, member
+}
+
+func waitlistJoin(t *testing.T, svc *services.EventService, eventID uuid.UUID, user models.User) {
+	t.Helper()
+	status, err := svc.RequestJoin(context.Background(), eventID, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.EventPlayerStatusWaitlisted, status)
+}
+
+func memberStatus(t *testing.T, db *gorm.DB, eventID, userID uuid.UUID) models.EventPlayerStatus {
+	t.Helper()
+	var p models.EventPlayer
+	require.NoError(t, db.Where("event_id = ? AND user_id = ?", eventID, userID).First(&p).Error)
+	return p.Status
+}
+
+func TestEventService_Waitlist_FullEventQueuesAndRefusesAdds(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	eventID, organizer, _ := fullEvent(t, db, svc, nil)
+
+	ann, bob := seedUser(t, db, "wlAnn"), seedUser(t, db, "wlBob")
+	waitlistJoin(t, svc, eventID, ann)
+	waitlistJoin(t, svc, eventID, bob)
+
+	_, err := svc.AddMember(ctx, eventID, organizer.ID, "user", seedUser(t, db, "wlCat").ID)
+	assert.ErrorIs(t, err, services.ErrEventFull)
+
+	list, err := svc.ListWaitlist(ctx, eventID, organizer.ID, "user")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, ann.ID, list[0].User.ID)
+	assert.Equal(t, 2, list[1].Position)
+
+	mine, err := svc.ListMyWaitlists(ctx, bob.ID)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	assert.Equal(t, 2, mine[0].Position)
+	assert.Equal(t, "Capped League", mine[0].Event.Name)
+
+	_, err = svc.ListWaitlist(ctx, eventID, ann.ID, "user")
+	assert.ErrorIs(t, err, services.ErrEventForbidden)
+}
+
+func TestEventService_Waitlist_MyPositionsAcrossEvents(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	eventID, organizer, _ := fullEvent(t, db, svc, nil)
+	one := 1
+	solo, err := svc.Create(ctx, services.CreateEventInput{
+		Name: "Solo League", EventType: "league", IsPublic: true, CreatedBy: organizer.ID, MaxPlayers: &one,
+	})
+	require.NoError(t, err)
+	ann, bob := seedUser(t, db, "wlAnn"), seedUser(t, db, "wlBob")
+	waitlistJoin(t, svc, eventID, ann)
+	waitlistJoin(t, svc, eventID, bob)
+	waitlistJoin(t, svc, solo.Event.ID, bob)
+
+	mine, err := svc.ListMyWaitlists(ctx, bob.ID)
+	require.NoError(t, err)
+	require.Len(t, mine, 2)
+	positions := map[uuid.UUID]int{}
+	for _, e := range mine {
+		positions[e.Event.ID] = e.Position
+	}
+	assert.Equal(t, 2, positions[eventID])
+	assert.Equal(t, 1, positions[solo.Event.ID])
+}
+
+func TestEventService_Waitlist_RemoveMemberPromotesNext(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	eventID, organizer, member := fullEvent(t, db, svc, nil)
+	ann, bob := seedUser(t, db, "wlAnn"), seedUser(t, db, "wlBob")
+	waitlistJoin(t, svc, eventID, ann)
+	waitlistJoin(t, svc, eventID, bob)
+
+	require.NoError(t, svc.RemoveMember(ctx, eventID, organizer.ID, "user", member.ID))
+	assert.Equal(t, models.EventPlayerStatusRegistered, memberStatus(t, db, eventID, ann.ID))
+	assert.Equal(t, models.EventPlayerStatusWaitlisted, memberStatus(t, db, eventID, bob.ID))
+
+	mine, err := svc.ListMyWaitlists(ctx, bob.ID)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	assert.Equal(t, 1, mine[0].Position, "bob moves to the front")
+}
+
+func TestEventService_Waitlist_RaisingCapPromotes(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	eventID, organizer, _ := fullEvent(t, db, svc, nil)
+	ann, bob := seedUser(t, db, "wlAnn"), seedUser(t, db, "wlBob")
+	waitlistJoin(t, svc, eventID, ann)
+	waitlistJoin(t, svc, eventID, bob)
+
+	noLimit := 0
+	res, err := svc.Update(context.Background(), eventID, organizer.ID, "user", services.UpdateEventInput{MaxPlayers: &noLimit})
+	require.NoError(t, err)
+	assert.Nil(t, res.Event.MaxPlayers)
+	assert.Equal(t, models.EventPlayerStatusRegistered, memberStatus(t, db, eventID, ann.ID))
+	assert.Equal(t, models.EventPlayerStatusRegistered, memberStatus(t, db, eventID, bob.ID))
+}
+
+func TestEventService_Waitlist_WithdrawOffersSpot(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	hours := 24
+	eventID, _, member := fullEvent(t, db, svc, &hours)
+	ann := seedUser(t, db, "wlAnn")
+	waitlistJoin(t, svc, eventID, ann)
+
+	_, err := svc.AcceptWaitlistOffer(ctx, eventID, ann.ID)
+	assert.ErrorIs(t, err, services.ErrNoWaitlistOffer)
+
+	require.NoError(t, svc.WithdrawFromEvent(ctx, eventID, member.ID))
+	assert.Equal(t, models.EventPlayerStatusWithdrawn, memberStatus(t, db, eventID, member.ID))
+
+	mine, err := svc.ListMyWaitlists(ctx, ann.ID)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	require.NotNil(t, mine[0].Player.OfferExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), *mine[0].Player.OfferExpiresAt, time.Minute)
+
+	// The held spot counts toward the field.
+	_, err = svc.RequestJoin(ctx, eventID, seedUser(t, db, "wlBob").ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EventPlayerStatusWaitlisted, memberStatus(t, db, eventID, ann.ID))
+
+	accepted, err := svc.AcceptWaitlistOffer(ctx, eventID, ann.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EventPlayerStatusRegistered, accepted.Player.Status)
+	assert.Nil(t, accepted.Player.OfferExpiresAt)
+
+	_, err = svc.AcceptWaitlistOffer(ctx, eventID, ann.ID)
+	assert.ErrorIs(t, err, services.ErrNotWaitlisted)
+}
+
+func TestEventService_Waitlist_LapsedOfferPassesDown(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	hours := 2
+	eventID, organizer, member := fullEvent(t, db, svc, &hours)
+	ann, bob := seedUser(t, db, "wlAnn"), seedUser(t, db, "wlBob")
+	waitlistJoin(t, svc, eventID, ann)
+	waitlistJoin(t, svc, eventID, bob)
+	require.NoError(t, svc.RemoveMember(ctx, eventID, organizer.ID, "user", member.ID))
+
+	require.NoError(t, db.Model(&models.EventPlayer{}).
+		Where("event_id = ? AND user_id = ?", eventID, ann.ID).
+		Update("offer_expires_at", time.Now().Add(-time.Minute)).Error)
+	_, err := svc.AcceptWaitlistOffer(ctx, eventID, ann.ID)
+	assert.ErrorIs(t, err, services.ErrNoWaitlistOffer)
+
+	require.NoError(t, svc.ExpireWaitlistOffers(ctx))
+	var count int64
+	db.Model(&models.EventPlayer{}).Where("event_id = ? AND user_id = ?", eventID, ann.ID).Count(&count)
+	assert.Zero(t, count, "a lapsed offer drops the player from the list")
+
+	mine, err := svc.ListMyWaitlists(ctx, bob.ID)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	assert.NotNil(t, mine[0].Player.OfferExpiresAt, "the spot passes to bob")
+}
+
+func TestEventService_Waitlist_ApproveIntoFullEvent(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewEventService(db)
+	ctx := context.Background()
+	eventID, organizer, _ := fullEvent(t, db, svc, nil)
+
+	// A request made before the cap filled up stays pending.
+	ann := seedUser(t, db, "wlAnn")
+	require.NoError(t, db.Create(&models.EventPlayer{
+		EventID: eventID, UserID: ann.ID, Role: models.EventPlayerRolePlayer, Status: models.EventPlayerStatusPending,
+	}).Error)
+
+	err := svc.HandleJoinRequest(ctx, eventID, organizer.ID, "user", ann.ID, true)
+	assert.ErrorIs(t, err, services.ErrEventFull)
+	assert.Equal(t, models.EventPlayerStatusPending, memberStatus(t, db, eventID, ann.ID))
+}
@@ -0,0 +1,141 @@
+// services/expo_push.go
+// ExpoPushSender delivers pushes through Expo's push service, which forwards
+// them to APNs and FCM for the mobile app.
+//
+// Verified against Expo's push API docs:
+//   - POST /--/api/v2/push/send takes a JSON array of at most 100 messages
+//   - The response's data[] holds one ticket per message, in order
+//   - A ticket with status "error" and details.error "DeviceNotRegistered"
+//     means the token is dead; other ticket errors are worth retrying
+//
+// Push receipts (the second, delayed delivery report) aren't polled: a token
+// that goes dead is caught by the next push's ticket instead.
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultExpoPushURL is Expo's production send endpoint. Tests override it via
+// SetURL to point at an httptest server.
+const DefaultExpoPushURL = "https://exp.host/--/api/v2/push/send"
+
+// expoBatchSize is the most messages Expo accepts in one request.
+const expoBatchSize = 100
+
+// ExpoPushSender is a PushSender backed by Expo's push API.
+type ExpoPushSender struct {
+	accessToken string
+	url         string
+	http        *http.Client // explicit timeout — never use http.DefaultClient for external calls
+}
+
+// NewExpoPushSender returns a sender ready to use. accessToken (EXPO_ACCESS_TOKEN)
+// is only needed when the Expo project enforces push security; it may be empty.
+func NewExpoPushSender(accessToken string) *ExpoPushSender {
+	return &ExpoPushSender{
+		accessToken: accessToken,
+		url:         DefaultExpoPushURL,
+		http:        &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SetURL overrides the send endpoint — used by tests to point the sender at an
+// httptest server. No-op for production code.
+func (e *ExpoPushSender) SetURL(url string) { e.url = url }
+
+// expoMessage is one message in Expo's request format.
+type expoMessage struct {
+	To    string            `json:"to"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data,omitempty"`
+	Sound string            `json:"sound"`
+}
+
+// expoTicket is Expo's per-message response.
+type expoTicket struct {
+	Status  string `json:"status"` // "ok" or "error"
+	Message string `json:"message"`
+	Details struct {
+		Error string `json:"error"`
+	} `json:"details"`
+}
+
+// Send implements PushSender, sending msgs in batches of expoBatchSize.
+func (e *ExpoPushSender) Send(ctx context.Context, msgs []PushMessage) []PushResult {
+	results := make([]PushResult, len(msgs))
+	for start := 0; start < len(msgs); start += expoBatchSize {
+		end := min(start+expoBatchSize, len(msgs))
+		e.sendBatch(ctx, msgs[start:end], results[start:end])
+	}
+	return results
+}
+
+// sendBatch sends one request and fills in results for its messages.
+func (e *ExpoPushSender) sendBatch(ctx context.Context, msgs []PushMessage, results []PushResult) {
+	fail := func(err error) {
+		for i := range results {
+			results[i] = PushResult{Err: err}
+		}
+	}
+
+	body := make([]expoMessage, len(msgs))
+	for i, m := range msgs {
+		body[i] = expoMessage{To: m.Token, Body: m.Body, Data: m.Data, Sound: "default"}
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		fail(fmt.Errorf("encode request: %w", err))
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(payload))
+	if err != nil {
+		fail(fmt.Errorf("build request: %w", err))
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if e.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+e.accessToken)
+	}
+
+	resp, err := e.http.Do(req)
+	if err != nil {
+		fail(fmt.Errorf("request failed: %w", err))
+		return
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(resp.Body)
+		fail(fmt.Errorf("expo returned %d: %s", resp.StatusCode, string(raw)))
+		return
+	}
+	var wrapper struct {
+		Data []expoTicket `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&wrapper); err != nil {
+		fail(fmt.Errorf("decode response: %w", err))
+		return
+	}
+	if len(wrapper.Data) != len(msgs) {
+		fail(fmt.Errorf("expo returned %d tickets for %d messages", len(wrapper.Data), len(msgs)))
+		return
+	}
+	for i, t := range wrapper.Data {
+		switch {
+		case t.Status == "ok":
+			results[i] = PushResult{}
+		case t.Details.Error == "DeviceNotRegistered":
+			results[i] = PushResult{DeadToken: true}
+		default:
+			results[i] = PushResult{Err: fmt.Errorf("expo: %s: %s", t.Details.Error, t.Message)}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// services/expo_push_test.go
+// Tests for ExpoPushSender against an httptest server standing in for Expo's
+// push API. No database needed.
+//
+// Run:
+//
+//	go test ./internal/services/ -run ExpoPushSender -v
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+// fakeExpo answers each request with ticket(token) for every message, or with
+// status when it's non-zero. It records the size of each request.
+type fakeExpo struct {
+	server   *httptest.Server
+	batches  []int
+	authz    string
+	status   int
+	ticketFn func(token string) map[string]any
+}
+
+func newFakeExpo(t *testing.T) *fakeExpo {
+	t.Helper()
+	f := &fakeExpo{ticketFn: func(string) map[string]any { return map[string]any{"status": "ok", "id": "t"} }}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.authz = r.Header.Get("Authorization")
+		var msgs []struct {
+			To string `json:"to"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msgs))
+		f.batches = append(f.batches, len(msgs))
+		if f.status != 0 {
+			w.WriteHeader(f.status)
+			return
+		}
+		tickets := make([]map[string]any, len(msgs))
+		for i, m := range msgs {
+			tickets[i] = f.ticketFn(m.To)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": tickets}))
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// sender returns an ExpoPushSender pointed at the fake.
+func (f *fakeExpo) sender(accessToken string) *services.ExpoPushSender {
+	s := services.NewExpoPushSender(accessToken)
+	s.SetURL(f.server.URL)
+	return s
+}
+
+func TestExpoPushSender_MapsTickets(t *testing.T) {
+	f := newFakeExpo(t)
+	f.ticketFn = func(token string) map[string]any {
+		switch token {
+		case "dead":
+			return map[string]any{"status": "error", "message": "not registered", "details": map[string]any{"error": "DeviceNotRegistered"}}
+		case "busy":
+			return map[string]any{"status": "error", "message": "slow down", "details": map[string]any{"error": "MessageRateExceeded"}}
+		}
+		return map[string]any{"status": "ok", "id": "t"}
+	}
+
+	results := f.sender("secret").Send(context.Background(), []services.PushMessage{
+		{Token: "live", Body: "hi"}, {Token: "dead", Body: "hi"}, {Token: "busy", Body: "hi"},
+	})
+	require.Len(t, results, 3)
+	assert.Equal(t, services.PushResult{}, results[0])
+	assert.True(t, results[1].DeadToken)
+	assert.NoError(t, results[1].Err)
+	require.Error(t, results[2].Err)
+	assert.Contains(t, results[2].Err.Error(), "MessageRateExceeded")
+	assert.Equal(t, "Bearer secret", f.authz)
+}
+
+func TestExpoPushSender_ServerError_FailsWholeBatch(t *testing.T) {
+	f := newFakeExpo(t)
+	f.status = http.StatusServiceUnavailable
+
+	results := f.sender("").Send(context.Background(), []services.PushMessage{{Token: "a"}, {Token: "b"}})
+	require.Len(t, results, 2)
+	for _, r := range results {
+		assert.False(t, r.DeadToken)
+		assert.Error(t, r.Err)
+	}
+	assert.Empty(t, f.authz, "no access token, no Authorization header")
+}
+
+func TestExpoPushSender_SplitsIntoBatchesOf100(t *testing.T) {
+	f := newFakeExpo(t)
+	msgs := make([]services.PushMessage, 150)
+	for i := range msgs {
+		msgs[i] = services.PushMessage{Token: fmt.Sprintf("t%d", i)}
+	}
+
+	results := f.sender("").Send(context.Background(), msgs)
+	assert.Len(t, results, 150)
+	assert.Equal(t, []int{100, 50}, f.batches)
+}
@@ -0,0 +1,59 @@
+// services/feed_internal_test.go
+// White-box tests for the unexported feed helpers in feed_service.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestCardActivity|TestFeedCursor' -v
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+func TestCardActivity(t *testing.T) {
+	user := uuid.New()
+	round := &models.Round{ID: uuid.New()}
+	par := map[int]int{1: 3, 2: 5, 3: 4}
+	card := []models.Score{
+		{HoleNumber: 3, GrossScore: 5},
+		{HoleNumber: 1, GrossScore: 1},
+		{HoleNumber: 2, GrossScore: 3},
+	}
+
+	items := cardActivity(user, round, card, par)
+	require.Len(t, items, 3)
+	assert.Equal(t, models.ActivityKindRoundCompleted, items[0].Kind)
+	assert.Equal(t, 9, *items[0].Score)
+	assert.Equal(t, -3, *items[0].ToPar)
+	assert.Nil(t, items[0].HoleNumber)
+
+	assert.Equal(t, models.ActivityKindAce, items[1].Kind)
+	assert.Equal(t, 1, *items[1].HoleNumber)
+	assert.Equal(t, -2, *items[1].ToPar, "an ace on a par 3 is an ace, not an eagle")
+	assert.Equal(t, models.ActivityKindEagle, items[2].Kind)
+	assert.Equal(t, 2, *items[2].HoleNumber)
+
+	birdies := cardActivity(user, round, []models.Score{{HoleNumber: 2, GrossScore: 4}}, map[int]int{2: 5})
+	assert.Len(t, birdies, 1, "a birdie isn't a highlight")
+}
+
+func TestFeedCursor_RoundTrip(t *testing.T) {
+	c := feedCursor{CreatedAt: time.Date(2026, 6, 6, 15, 4, 5, 123456000, time.UTC), ID: uuid.New()}
+	got, err := decodeFeedCursor(encodeFeedCursor(c))
+	require.NoError(t, err)
+	assert.True(t, c.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, c.ID, got.ID)
+
+	for _, bad := range []string{"???", "bm8tc2VwYXJhdG9y", encodeFeedCursor(c)[:10]} {
+		_, err := decodeFeedCursor(bad)
+		assert.Error(t, err, bad)
+	}
+}
@@ -0,0 +1,431 @@
+// services/feed_service.go
+// FeedService lists the activity feed: completed rounds, personal bests,
+// eagles, aces and event wins from the people the caller follows.
+//
+// Items are written ahead of time rather than derived when listed. Completing
+// a round, or correcting a score on a completed round, calls
+// recordRoundActivity; FinalizeEvent calls recordEventWins. Both rewrite the
+// items for that round or event, so the feed always matches the current
+// scores, and both are best-effort like notify: a failure is logged, never
+// returned.
+//
+// Privacy settings apply when listing (see privacy_service.go): blocked and
+// private players drop out, and players who hide their stats only appear for
+// event wins. Guests never get items.
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultFeedLimit is the page size when none is given.
+	defaultFeedLimit = 30
+	// maxFeedLimit caps the page size.
+	maxFeedLimit = 100
+	// personalBestHoles is the card length personal bests are kept for.
+	personalBestHoles = 18
+)
+
+// ─── Inputs and DTOs ──────────────────────────────────────────────────────────
+
+// ListFeedInput pages the feed. Cursor is the next_cursor from the previous
+// page.
+type ListFeedInput struct {
+	Limit  int
+	Cursor string
+}
+
+// FeedItem is one entry in the feed. Round fields are nil for event wins;
+// HoleNumber is set for eagles and aces; Score and ToPar are nil for wins.
+type FeedItem struct {
+	ID          string  `json:"id"`
+	Kind        string  `json:"kind"`
+	UserID      string  `json:"user_id"`
+	DisplayName string  `json:"display_name"`
+	AvatarURL   *string `json:"avatar_url"`
+	RoundID     *string `json:"round_id"`
+	RoundName   *string `json:"round_name"`
+	CourseName  *string `json:"course_name"`
+	EventID     *string `json:"event_id"`
+	EventName   *string `json:"event_name"`
+	HoleNumber  *int    `json:"hole_number"`
+	Score       *int    `json:"score"`
+	ToPar       *int    `json:"to_par"`
+	CreatedAt   string  `json:"created_at"` // RFC 3339
+}
+
+// FeedPage is one page of the feed, newest first. NextCursor is nil on the
+// last page.
+type FeedPage struct {
+	Items      []FeedItem `json:"items"`
+	NextCursor *string    `json:"next_cursor"`
+}
+
+// ─── Service ──────────────────────────────────────────────────────────────────
+
+// FeedService reads the activity feed.
+type FeedService struct {
+	DB *gorm.DB
+}
+
+// NewFeedService constructs a FeedService.
+func NewFeedService(db *gorm.DB) *FeedService {
+	return &FeedService{DB: db}
+}
+
+// List returns a page of activity from the people the caller follows, newest
+// first.
+func (s *FeedService) List(ctx context.Context, callerID uuid.UUID, in ListFeedInput) (*FeedPage, error) {
+	if in.Limit < 0 || in.Limit > maxFeedLimit {
+		return nil, &ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 1 and %d", maxFeedLimit)}
+	}
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultFeedLimit
+	}
+	var cursor *feedCursor
+	if in.Cursor != "" {
+		c, err := decodeFeedCursor(in.Cursor)
+		if err != nil {
+			return nil, &ValidationError{Field: "cursor", Message: "cursor is not valid"}
+		}
+		cursor = &c
+	}
+
+	q := s.DB.WithContext(ctx).Preload("User").Preload("Round.Course").Preload("Event").
+		Where("user_id IN (?)", feedAuthorIDs(s.DB, callerID)).
+		// Players who hide their stats only show up for event wins.
+		Where("kind = ? OR user_id NOT IN (?)", models.ActivityKindEventWon, statsHiddenUserIDs(s.DB))
+	if cursor != nil {
+		// Items written together share created_at, so id breaks the tie.
+		q = q.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+	var rows []models.ActivityItem
+	if err := q.Order("created_at DESC, id DESC").Limit(limit + 1).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list feed: %w", err)
+	}
+
+	page := &FeedPage{Items: []FeedItem{}}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[limit-1]
+		next := encodeFeedCursor(feedCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		page.NextCursor = &next
+	}
+	for _, item := range rows {
+		page.Items = append(page.Items, buildFeedItem(item))
+	}
+	return page, nil
+}
+
+// ─── Recording ────────────────────────────────────────────────────────────────
+
+// recordRoundActivity rewrites the activity items for a completed round: for
+// each player with a full card, a completed round, a personal best when it
+// beats every earlier 18-hole card, and an item per eagle or ace. Players out
+// of the results, absent players and guests get none. Rewrites keep the
+// round's original timestamp so a correction doesn't bump it up the feed.
+// Call it after the change has committed; failures are only logged.
+func recordRoundActivity(ctx context.Context, db *gorm.DB, roundID uuid.UUID) {
+	if err := writeRoundActivity(ctx, db, roundID); err != nil {
+		slog.WarnContext(ctx, "Round activity not written",
+			"event_type_label", "feed.round_activity_failed",
+			"round_id", roundID.String(),
+			"error", err.Error(),
+		)
+	}
+}
+
+// writeRoundActivity does the work for recordRoundActivity.
+func writeRoundActivity(ctx context.Context, db *gorm.DB, roundID uuid.UUID) error {
+	var round models.Round
+	if err := db.WithContext(ctx).Preload("DefaultTee.Holes").First(&round, "id = ?", roundID).Error; err != nil {
+		return fmt.Errorf("load round: %w", err)
+	}
+	played := filterPlayedHoles(round.DefaultTee.Holes, round.NineHoleSelection)
+	par := make(map[int]int, len(played))
+	for _, h := range played {
+		par[h.HoleNumber] = h.Par
+	}
+
+	type playerRow struct {
+		RoundPlayerID uuid.UUID
+		UserID        uuid.UUID
+		Status        models.RoundPlayerStatus
+	}
+	var players []playerRow
+	if err := db.WithContext(ctx).Table("round_players rp").
+		Select("rp.id AS round_player_id, rp.user_id, rp.status").
+		Joins("JOIN users u ON u.id = rp.user_id").
+		Where("rp.round_id = ? AND rp.absent_at IS NULL AND NOT u.is_guest", roundID).
+		Scan(&players).Error; err != nil {
+		return fmt.Errorf("load round players: %w", err)
+	}
+	var scores []models.Score
+	if err := db.WithContext(ctx).
+		Joins("JOIN round_players rp ON rp.id = scores.round_player_id").
+		Where("rp.round_id = ?", roundID).
+		Find(&scores).Error; err != nil {
+		return fmt.Errorf("load scores: %w", err)
+	}
+	byPlayer := make(map[uuid.UUID][]models.Score, len(players))
+	for _, sc := range scores {
+		if _, ok := par[sc.HoleNumber]; ok {
+			byPlayer[sc.RoundPlayerID] = append(byPlayer[sc.RoundPlayerID], sc)
+		}
+	}
+
+	var items []models.ActivityItem
+	for _, p := range players {
+		card := byPlayer[p.RoundPlayerID]
+		if len(par) == 0 || len(card) != len(par) || slices.Contains(models.RoundPlayerOutStatuses, p.Status) {
+			continue
+		}
+		items = append(items, cardActivity(p.UserID, &round, card, par)...)
+	}
+	items, err := withPersonalBests(ctx, db, &round, len(par), items)
+	if err != nil {
+		return err
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		createdAt := time.Now().UTC()
+		var first models.ActivityItem
+		err := tx.Where("round_id = ?", roundID).Order("created_at ASC").Limit(1).Find(&first).Error
+		if err != nil {
+			return fmt.Errorf("load earlier activity: %w", err)
+		}
+		if first.ID != uuid.Nil {
+			createdAt = first.CreatedAt
+		}
+		if err := tx.Where("round_id = ?", roundID).Delete(&models.ActivityItem{}).Error; err != nil {
+			return fmt.Errorf("clear round activity: %w", err)
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		for i := range items {
+			items[i].CreatedAt = createdAt
+		}
+		if err := tx.Omit("User", "Round", "Event").Create(&items).Error; err != nil {
+			return fmt.Errorf("write round activity: %w", err)
+		}
+		return nil
+	})
+}
+
+// cardActivity returns the items one full card earns: a completed round, then
+// an ace or eagle per hole that made one.
+func cardActivity(userID uuid.UUID, round *models.Round, card []models.Score, par map[int]int) []models.ActivityItem {
+	slices.SortFunc(card, func(a, b models.Score) int { return a.HoleNumber - b.HoleNumber })
+	total, toPar := 0, 0
+	var highlights []models.ActivityItem
+	for _, sc := range card {
+		diff := sc.GrossScore - par[sc.HoleNumber]
+		total += sc.GrossScore
+		toPar += diff
+		kind := models.ActivityKind("")
+		switch {
+		case sc.GrossScore == 1:
+			kind = models.ActivityKindAce
+		case diff <= -2:
+			kind = models.ActivityKindEagle
+		}
+		if kind != "" {
+			highlights = append(highlights, models.ActivityItem{
+				UserID: userID, Kind: kind, RoundID: &round.ID, EventID: round.EventID,
+				HoleNumber: intPtr(sc.HoleNumber), Score: intPtr(sc.GrossScore), ToPar: intPtr(diff),
+			})
+		}
+	}
+	completed := models.ActivityItem{
+		UserID: userID, Kind: models.ActivityKindRoundCompleted, RoundID: &round.ID, EventID: round.EventID,
+		Score: intPtr(total), ToPar: intPtr(toPar),
+	}
+	return append([]models.ActivityItem{completed}, highlights...)
+}
+
+// withPersonalBests returns items plus a personal best for each completed
+// round in it that beats the player's lowest gross on every earlier completed
+// 18-hole card — scheduled before it, or the same day and created first — so
+// re-recording an older round doesn't compare it with later ones. A first
+// 18-hole card isn't a personal best; there's nothing to beat.
+func withPersonalBests(ctx context.Context, db *gorm.DB, round *models.Round, holes int, items []models.ActivityItem) ([]models.ActivityItem, error) {
+	if holes != personalBestHoles {
+		return items, nil
+	}
+	var userIDs []uuid.UUID
+	for _, it := range items {
+		if it.Kind == models.ActivityKindRoundCompleted {
+			userIDs = append(userIDs, it.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return items, nil
+	}
+
+	type bestRow struct {
+		UserID uuid.UUID
+		Best   int
+	}
+	var rows []bestRow
+	if err := db.WithContext(ctx).Raw(`
+		SELECT user_id, MIN(total) AS best
+		FROM (
+		    SELECT rp.user_id, SUM(s.gross_score) AS total
+		    FROM round_players rp
+		    JOIN rounds r ON r.id = rp.round_id
+		    JOIN scores s ON s.round_player_id = rp.id
+		    WHERE rp.user_id IN ? AND (r.scheduled_date, r.created_at) < (?, ?) AND r.status = ?
+		      AND r.nine_hole_selection IS NULL
+		      AND rp.absent_at IS NULL AND rp.status NOT IN ?
+		    GROUP BY rp.id, rp.user_id
+		    HAVING COUNT(*) = ?
+		) cards
+		GROUP BY user_id`,
+		userIDs, round.ScheduledDate, round.CreatedAt, models.RoundStatusCompleted, models.RoundPlayerOutStatuses, personalBestHoles,
+	).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load personal bests: %w", err)
+	}
+	best := make(map[uuid.UUID]int, len(rows))
+	for _, r := range rows {
+		best[r.UserID] = r.Best
+	}
+	out := items
+	for _, it := range items {
+		if it.Kind != models.ActivityKindRoundCompleted {
+			continue
+		}
+		if prev, ok := best[it.UserID]; ok && *it.Score < prev {
+			it.Kind = models.ActivityKindPersonalBest
+			out = append(out, it)
+		}
+	}
+	return out, nil
+}
+
+// recordEventWins rewrites an event's wins from its final standings: one for
+// each member in first place overall, or in their flight when the event has
+// flights. Guests get none. Call it after FinalizeEvent has committed;
+// failures are only logged.
+func recordEventWins(ctx context.Context, db *gorm.DB, eventID uuid.UUID, standings *EventStandings) {
+	var winners []uuid.UUID
+	for _, e := range standings.Entries {
+		if e.Position == nil || *e.Position != 1 {
+			continue
+		}
+		if id, err := uuid.Parse(e.UserID); err == nil {
+			winners = append(winners, id)
+		}
+	}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ? AND kind = ?", eventID, models.ActivityKindEventWon).
+			Delete(&models.ActivityItem{}).Error; err != nil {
+			return fmt.Errorf("clear event wins: %w", err)
+		}
+		if len(winners) == 0 {
+			return nil
+		}
+		return tx.Exec(`
+			INSERT INTO activity_items (user_id, kind, event_id)
+			SELECT u.id, ?, ? FROM users u WHERE u.id IN ? AND NOT u.is_guest`,
+			models.ActivityKindEventWon, eventID, winners,
+		).Error
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Event wins not written",
+			"event_type_label", "feed.event_wins_failed",
+			"event_id", eventID.String(),
+			"error", err.Error(),
+		)
+	}
+}
+
+// feedAuthorIDs is a subquery selecting the users whose activity the caller
+// sees: the people they follow, less anyone blocked either way or whose
+// profile is private. Followers-only profiles are in by definition.
+func feedAuthorIDs(db *gorm.DB, callerID uuid.UUID) *gorm.DB {
+	return db.Model(&models.Follow{}).Select("followee_id").
+		Where("follower_id = ?", callerID).
+		Where(notBlockedSQL("follows.followee_id"), callerID, callerID).
+		Where("NOT EXISTS (SELECT 1 FROM user_privacy_settings ps WHERE ps.user_id = follows.followee_id AND ps.profile_visibility = ?)",
+			models.ProfileVisibilityPrivate)
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// feedCursor is the position of the last item on a page.
+type feedCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// encodeFeedCursor renders c as an opaque cursor string.
+func encodeFeedCursor(c feedCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeFeedCursor parses a cursor from encodeFeedCursor.
+func decodeFeedCursor(s string) (feedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return feedCursor{}, err
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return feedCursor{}, fmt.Errorf("malformed cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return feedCursor{}, err
+	}
+	itemID, err := uuid.Parse(id)
+	if err != nil {
+		return feedCursor{}, err
+	}
+	return feedCursor{CreatedAt: createdAt, ID: itemID}, nil
+}
+
+// buildFeedItem converts an item (User, Round.Course and Event loaded) into
+// its JSON shape.
+func buildFeedItem(it models.ActivityItem) FeedItem {
+	out := FeedItem{
+		ID:          it.ID.String(),
+		Kind:        string(it.Kind),
+		UserID:      it.UserID.String(),
+		DisplayName: it.User.DisplayName,
+		AvatarURL:   it.User.AvatarURL,
+		HoleNumber:  it.HoleNumber,
+		Score:       it.Score,
+		ToPar:       it.ToPar,
+		CreatedAt:   it.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if it.RoundID != nil {
+		id := it.RoundID.String()
+		out.RoundID = &id
+	}
+	if it.Round != nil {
+		out.RoundName = &it.Round.Name
+		out.CourseName = &it.Round.Course.Name
+	}
+	if it.EventID != nil {
+		id := it.EventID.String()
+		out.EventID = &id
+	}
+	if it.Event != nil {
+		out.EventName = &it.Event.Name
+	}
+	return out
+}
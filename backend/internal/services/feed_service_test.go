@@ -0,0 +1,197 @@
+// services/feed_service_test.go
+// Integration tests for FeedService and the activity items written as rounds
+// complete, scores are corrected and events finalize. Docker must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run FeedService -v
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// completeViaService completes a round through RoundService.Update, which
+// writes its activity.
+func completeViaService(t *testing.T, db *gorm.DB, roundID, organizerID uuid.UUID) {
+	t.Helper()
+	completed := "completed"
+	_, err := services.NewRoundService(db, services.NewEventService(db)).
+		Update(context.Background(), roundID, organizerID, "user", services.UpdateRoundInput{Status: &completed})
+	require.NoError(t, err)
+}
+
+// follower seeds a user who follows each of targets.
+func follower(t *testing.T, db *gorm.DB, targets ...uuid.UUID) uuid.UUID {
+	t.Helper()
+	viewer := seedUser(t, db, "feedViewer")
+	for _, id := range targets {
+		require.NoError(t, services.NewUserService(db).FollowUser(context.Background(), viewer.ID, id))
+	}
+	return viewer.ID
+}
+
+// feedKinds returns the kinds on one page of the viewer's feed.
+func feedKinds(t *testing.T, svc *services.FeedService, viewer uuid.UUID) map[string]services.FeedItem {
+	t.Helper()
+	page, err := svc.List(context.Background(), viewer, services.ListFeedInput{})
+	require.NoError(t, err)
+	out := make(map[string]services.FeedItem, len(page.Items))
+	for _, it := range page.Items {
+		out[it.Kind] = it
+	}
+	return out
+}
+
+func TestFeedService_RoundsEaglesAndPersonalBests(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewFeedService(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+	organizer := organizerOf(t, db, event.ID)
+	viewer := follower(t, db, aliceRP.UserID)
+
+	completeViaService(t, db, roundID, organizer)
+	page, err := svc.List(ctx, viewer, services.ListFeedInput{})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1, "bob isn't followed; a first card isn't a personal best")
+	first := page.Items[0]
+	assert.Equal(t, string(models.ActivityKindRoundCompleted), first.Kind)
+	assert.Equal(t, 72, *first.Score)
+	assert.Equal(t, 0, *first.ToPar)
+	assert.Equal(t, event.Name, *first.EventName)
+	assert.Equal(t, "Leaderboard Course", *first.CourseName)
+
+	// A 70 with an eagle on 5 beats the 72.
+	var round models.Round
+	require.NoError(t, db.First(&round, "id = ?", roundID).Error)
+	second := scheduleRound(t, services.NewRoundService(db, services.NewEventService(db)), event.ID, organizer,
+		round.CourseID.String(), round.DefaultTeeID.String())
+	rp := addRoundPlayer(t, db, second.Round.ID, *aliceRP.EventPlayerID)
+	enterCard(t, db, rp.ID, organizer, map[int]int{5: 2})
+	completeViaService(t, db, second.Round.ID, organizer)
+
+	kinds := feedKinds(t, svc, viewer)
+	require.Contains(t, kinds, string(models.ActivityKindEagle))
+	eagle := kinds[string(models.ActivityKindEagle)]
+	assert.Equal(t, 5, *eagle.HoleNumber)
+	assert.Equal(t, -2, *eagle.ToPar)
+	require.Contains(t, kinds, string(models.ActivityKindPersonalBest))
+	assert.Equal(t, 70, *kinds[string(models.ActivityKindPersonalBest)].Score)
+
+	page, err = svc.List(ctx, viewer, services.ListFeedInput{})
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 4)
+}
+
+func TestFeedService_CorrectedOlderRoundIgnoresLaterCards(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewFeedService(db)
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+	organizer := organizerOf(t, db, event.ID)
+	viewer := follower(t, db, aliceRP.UserID)
+	completeViaService(t, db, roundID, organizer)
+
+	// A later 75, then the first round corrected to a 71: it's still alice's
+	// first card, so beating the later 75 doesn't make it a personal best.
+	var round models.Round
+	require.NoError(t, db.First(&round, "id = ?", roundID).Error)
+	second := scheduleRound(t, services.NewRoundService(db, services.NewEventService(db)), event.ID, organizer,
+		round.CourseID.String(), round.DefaultTeeID.String())
+	rp := addRoundPlayer(t, db, second.Round.ID, *aliceRP.EventPlayerID)
+	enterCard(t, db, rp.ID, organizer, map[int]int{1: 7})
+	completeViaService(t, db, second.Round.ID, organizer)
+
+	_, err := newScoreSvc(db).UpsertScores(context.Background(), roundID, aliceRP.ID, organizer, "user",
+		[]services.ScoreInput{{HoleNumber: 1, GrossScore: 3}})
+	require.NoError(t, err)
+
+	kinds := feedKinds(t, svc, viewer)
+	require.Contains(t, kinds, string(models.ActivityKindRoundCompleted))
+	assert.NotContains(t, kinds, string(models.ActivityKindPersonalBest))
+}
+
+func TestFeedService_CorrectionRewritesRound(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewFeedService(db)
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+	organizer := organizerOf(t, db, event.ID)
+	viewer := follower(t, db, aliceRP.UserID)
+	completeViaService(t, db, roundID, organizer)
+	before := feedKinds(t, svc, viewer)[string(models.ActivityKindRoundCompleted)]
+
+	_, err := newScoreSvc(db).UpsertScores(context.Background(), roundID, aliceRP.ID, organizer, "user",
+		[]services.ScoreInput{{HoleNumber: 3, GrossScore: 1}})
+	require.NoError(t, err)
+
+	kinds := feedKinds(t, svc, viewer)
+	require.Contains(t, kinds, string(models.ActivityKindAce))
+	assert.Equal(t, 3, *kinds[string(models.ActivityKindAce)].HoleNumber)
+	after := kinds[string(models.ActivityKindRoundCompleted)]
+	assert.Equal(t, 69, *after.Score)
+	assert.Equal(t, before.CreatedAt, after.CreatedAt, "a correction doesn't bump the round up the feed")
+	assert.NotEqual(t, before.ID, after.ID)
+}
+
+func TestFeedService_EventWinAndPaging(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewFeedService(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	organizer := organizerOf(t, db, event.ID)
+	viewer := follower(t, db, aliceRP.UserID, bobRP.UserID)
+	completeViaService(t, db, roundID, organizer)
+	_, err := newLeaderboardSvc(db).FinalizeEvent(ctx, event.ID, organizer, "user")
+	require.NoError(t, err)
+
+	page, err := svc.List(ctx, viewer, services.ListFeedInput{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	require.NotNil(t, page.NextCursor)
+	assert.Equal(t, string(models.ActivityKindEventWon), page.Items[0].Kind, "finalized after the round")
+	assert.Equal(t, bobRP.UserID.String(), page.Items[0].UserID, "bob won the card-off")
+	assert.Nil(t, page.Items[0].RoundID)
+
+	rest, err := svc.List(ctx, viewer, services.ListFeedInput{Limit: 2, Cursor: *page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, rest.Items, 1)
+	assert.Nil(t, rest.NextCursor)
+	assert.NotEqual(t, page.Items[1].ID, rest.Items[0].ID, "items sharing a timestamp aren't repeated")
+
+	// The organizer follows nobody.
+	empty, err := svc.List(ctx, organizer, services.ListFeedInput{})
+	require.NoError(t, err)
+	assert.Empty(t, empty.Items)
+}
+
+func TestFeedService_ReopenClearsEventWin(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewFeedService(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	organizer := organizerOf(t, db, event.ID)
+	viewer := follower(t, db, aliceRP.UserID, bobRP.UserID)
+	completeViaService(t, db, roundID, organizer)
+	lb := newLeaderboardSvc(db)
+	_, err := lb.FinalizeEvent(ctx, event.ID, organizer, "user")
+	require.NoError(t, err)
+	require.Contains(t, feedKinds(t, svc, viewer), string(models.ActivityKindEventWon))
+
+	_, err = lb.ReopenEvent(ctx, event.ID, organizer, "user", "wrong card")
+	require.NoError(t, err)
+	kinds := feedKinds(t, svc, viewer)
+	assert.NotContains(t, kinds, string(models.ActivityKindEventWon), "the win is gone until the event is finalized again")
+	assert.Contains(t, kinds, string(models.ActivityKindRoundCompleted))
+}
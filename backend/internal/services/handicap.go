@@ -81,6 +81,17 @@ func filterPlayedHoles(holes []models.Hole, sel *string) []models.Hole {
 	return result
 }
 
+// CourseHandicapFromIndex works out a course handicap from a handicap index
+// for the given tee (WHS: index × slope / 113 + (course rating − par)), rounded
+// to the nearest stroke. For a nine-hole round it is half the 18-hole value.
+func CourseHandicapFromIndex(handicapIndex float64, tee models.Tee, nineHoles bool) int {
+	ch := handicapIndex*float64(tee.SlopeRating)/113.0 + (tee.CourseRating - float64(tee.Par))
+	if nineHoles {
+		ch /= 2
+	}
+	return int(math.Round(ch))
+}
+
 // EffectiveCourseHandicap applies the event's handicap allowance percentage to
 // a player's raw course handicap.
 //
@@ -96,12 +107,16 @@ func EffectiveCourseHandicap(courseHandicap int, allowance *float64) int {
 }
 
 // RecalculateEventScores recomputes net_score for every scored hole across all
-// rounds in an event. Triggered when an event's handicap_allowance changes.
+// rounds in an event. Triggered when an event's handicap_allowance changes;
+// changedBy is the organizer who changed it.
 //
 // Processes per-round so each round's nine_hole_selection can be used to
-// normalize stroke indexes before applying HandicapStrokes.
+// normalize stroke indexes before applying HandicapStrokes. Each round's
+// updates and their score_changes rows are written in one transaction, so a
+// card never disagrees with its history. Completed rounds then get their
+// finish positions re-ranked, as after any other score write.
 // Best-effort: returns the first DB error encountered.
-func RecalculateEventScores(ctx context.Context, db *gorm.DB, eventID uuid.UUID, allowance *float64) error {
+func RecalculateEventScores(ctx context.Context, db *gorm.DB, eventID, changedBy uuid.UUID, allowance *float64) error {
 	var rounds []models.Round
 	if err := db.WithContext(ctx).
 		Preload("DefaultTee.Holes").
@@ -112,7 +127,9 @@ func RecalculateEventScores(ctx context.Context, db *gorm.DB, eventID uuid.UUID,
 
 	type scoreRow struct {
 		ScoreID        uuid.UUID
+		RoundPlayerID  uuid.UUID
 		GrossScore     int
+		NetScore       int
 		HoleNumber     int
 		CourseHandicap *int
 	}
@@ -125,28 +142,50 @@ func RecalculateEventScores(ctx context.Context, db *gorm.DB, eventID uuid.UUID,
 		siMap := NormalizeStrokeIndexes(played)
 		holeCount := len(played)
 
-		var rows []scoreRow
-		if err := db.WithContext(ctx).Table("scores s").
-			Select("s.id as score_id, s.gross_score, s.hole_number, rp.course_handicap").
-			Joins("JOIN round_players rp ON rp.id = s.round_player_id").
-			Where("rp.round_id = ?", round.ID).
-			Scan(&rows).Error; err != nil {
-			return fmt.Errorf("load scores for round %s: %w", round.ID, err)
-		}
-
-		for _, row := range rows {
-			raw := 0
-			if row.CourseHandicap != nil {
-				raw = *row.CourseHandicap
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var rows []scoreRow
+			if err := tx.Table("scores s").
+				Select("s.id as score_id, s.round_player_id, s.gross_score, s.net_score, s.hole_number, rp.course_handicap").
+				Joins("JOIN round_players rp ON rp.id = s.round_player_id").
+				Where("rp.round_id = ?", round.ID).
+				Order("s.round_player_id, s.hole_number").
+				Scan(&rows).Error; err != nil {
+				return fmt.Errorf("load scores for round %s: %w", round.ID, err)
 			}
-			eff := EffectiveCourseHandicap(raw, allowance)
-			netScore := row.GrossScore - HandicapStrokes(eff, siMap[row.HoleNumber], holeCount)
 
-			if err := db.WithContext(ctx).Model(&models.Score{}).
-				Where("id = ?", row.ScoreID).
-				Update("net_score", netScore).Error; err != nil {
-				return fmt.Errorf("update score %s: %w", row.ScoreID, err)
+			var changes []models.ScoreChange
+			for _, row := range rows {
+				raw := 0
+				if row.CourseHandicap != nil {
+					raw = *row.CourseHandicap
+				}
+				eff := EffectiveCourseHandicap(raw, allowance)
+				netScore := row.GrossScore - HandicapStrokes(eff, siMap[row.HoleNumber], holeCount)
+				if netScore == row.NetScore {
+					continue
+				}
+
+				if err := tx.Model(&models.Score{}).
+					Where("id = ?", row.ScoreID).
+					Update("net_score", netScore).Error; err != nil {
+					return fmt.Errorf("update score %s: %w", row.ScoreID, err)
+				}
+				oldScore, newScore := scoreValue(row.GrossScore, row.NetScore), scoreValue(row.GrossScore, netScore)
+				changes = append(changes, models.ScoreChange{
+					RoundPlayerID: row.RoundPlayerID, Kind: string(models.ScoreChangeKindScore), HoleNumber: intPtr(row.HoleNumber),
+					OldValue: &oldScore, NewValue: &newScore, ChangedBy: changedBy,
+				})
+			}
+			return recordScoreChanges(tx, changes)
+		})
+		if err != nil {
+			return err
+		}
+		if round.Status == models.RoundStatusCompleted {
+			if err := saveRoundFinishPositions(ctx, db, round.ID); err != nil {
+				return err
 			}
+			recordRoundActivity(ctx, db, round.ID)
 		}
 	}
 	return nil
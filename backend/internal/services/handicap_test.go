@@ -1,6 +1,6 @@
 // services/handicap_test.go
-// Tier 1 unit tests for HandicapStrokes, EffectiveCourseHandicap, and
-// NormalizeStrokeIndexes. No DB or Docker required — pure arithmetic functions.
+// Tier 1 unit tests for HandicapStrokes, EffectiveCourseHandicap,
+// CourseHandicapFromIndex and NormalizeStrokeIndexes. No DB or Docker required — pure arithmetic functions.
 //
 // Run:
 //
@@ -158,3 +158,15 @@ func TestEffectiveCourseHandicap_90Percent(t *testing.T) {
 func TestEffectiveCourseHandicap_75Percent(t *testing.T) {
 	assert.Equal(t, 15, services.EffectiveCourseHandicap(20, ptrFloat(75)))
 }
+
+// ─── CourseHandicapFromIndex ──────────────────────────────────────────────────
+
+// TestCourseHandicapFromIndex verifies slope and course rating are applied and
+// a nine-hole round plays off half.
+func TestCourseHandicapFromIndex(t *testing.T) {
+	hard := models.Tee{SlopeRating: 140, CourseRating: 74.2, Par: 72}
+	assert.Equal(t, 15, services.CourseHandicapFromIndex(10.4, hard, false)) // 12.88 + 2.2
+	assert.Equal(t, 8, services.CourseHandicapFromIndex(10.4, hard, true))
+	neutral := models.Tee{SlopeRating: 113, CourseRating: 72, Par: 72}
+	assert.Equal(t, 10, services.CourseHandicapFromIndex(10.4, neutral, false))
+}
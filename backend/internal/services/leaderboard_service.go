@@ -0,0 +1,1048 @@
+// services/leaderboard_service.go
+// LeaderboardService ranks players within a round (leaderboard) and across an
+// event's completed rounds (standings), applying the event's tiebreak policy.
+//
+// When the event has flights, every ranking is done within each flight, so
+// each flight has its own positions, points and winners; players without a
+// flight are ranked together after the flights.
+//
+// Positions are always computed on the fly and reads never write. Completing a
+// round (or correcting a completed one) writes RoundPlayer.FinishPosition via
+// saveRoundFinishPositions; event results (positions, totals, points)
+// are written only by FinalizeEvent, so live standings never persist a
+// provisional finish. The ranking math itself lives in tiebreak.go.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ─── Sentinel errors ──────────────────────────────────────────────────────────
+
+var (
+	// ErrRoundsIncomplete is returned when finalizing an event that still has a
+	// scheduled or active round.
+	ErrRoundsIncomplete = errors.New("all rounds must be completed before the event can be finalized")
+)
+
+// Standings ranking modes reported in EventStandings.RankedBy.
+const (
+	StandingsRankedByNet    = "net"    // lowest total net across every counted round
+	StandingsRankedByPoints = "points" // most points from the event's points table
+)
+
+// ─── Result types (returned directly as JSON by the leaderboard handlers) ─────
+
+// LeaderboardEntry is one player's line on a round leaderboard.
+type LeaderboardEntry struct {
+	RoundPlayerID           string  `json:"round_player_id"`
+	UserID                  string  `json:"user_id"`
+	DisplayName             string  `json:"display_name"`
+	AvatarURL               *string `json:"avatar_url"`
+	IsGuest                 bool    `json:"is_guest"`
+	Status                  string  `json:"status"`
+	StatusReason            *string `json:"status_reason"` // set for withdrawn, disqualified and no_card
+	EffectiveCourseHandicap *int    `json:"effective_course_handicap"`
+	Thru                    int     `json:"thru"`        // holes scored so far
+	NetToPar                int     `json:"net_to_par"`  // net relative to par over the holes scored
+	TotalGross              *int    `json:"total_gross"` // nil until the card is complete
+	TotalNet                *int    `json:"total_net"`
+	// Position is nil for players without a complete card, absent players, and
+	// players out of the results (withdrawn, disqualified or no_card).
+	Position *int `json:"position"`
+	// Tiebreak names the criterion that decided a tied position — "back_9",
+	// "back_6", "back_3", "last_hole", or "shared". Nil when the player was not tied.
+	Tiebreak *string `json:"tiebreak"`
+	// DisputedHoles lists holes on this card with an open score dispute, ascending.
+	// Empty when the result is undisputed.
+	DisputedHoles []int `json:"disputed_holes"`
+	// FlightID/FlightName identify the flight this player is ranked in for the
+	// round; nil when the event has no flights or the player is unflighted.
+	FlightID   *string `json:"flight_id"`
+	FlightName *string `json:"flight_name"`
+}
+
+// FlightSummary names one of an event's flights, in ranking order.
+type FlightSummary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// RoundLeaderboard is the payload for GET /rounds/:roundId/leaderboard.
+// Entries are grouped by flight (in Flights order, unflighted last); within a
+// group, ranked players come first in finishing order, followed by unranked
+// players ordered by net-to-par, with players out of the results (WD, DQ, NC)
+// listed last.
+type RoundLeaderboard struct {
+	RoundID        string             `json:"round_id"`
+	Status         string             `json:"status"`
+	HoleCount      int                `json:"hole_count"`
+	TiebreakPolicy string             `json:"tiebreak_policy"`
+	Flights        []FlightSummary    `json:"flights"` // empty when the event has no flights
+	Entries        []LeaderboardEntry `json:"entries"`
+}
+
+// StandingsEntry is one event member's line in the event standings.
+type StandingsEntry struct {
+	EventPlayerID string  `json:"event_player_id"`
+	UserID        string  `json:"user_id"`
+	DisplayName   string  `json:"display_name"`
+	AvatarURL     *string `json:"avatar_url"`
+	RoundsPlayed  int     `json:"rounds_played"` // completed rounds with a full card
+	TotalGross    *int    `json:"total_gross"`   // nil unless every counted round has a full card
+	TotalNet      *int    `json:"total_net"`
+	TotalPoints   *int    `json:"total_points"` // nil when the event has no points table
+	Position      *int    `json:"position"`     // within the member's flight when the event has flights
+	Tiebreak      *string `json:"tiebreak"`
+	FlightID      *string `json:"flight_id"` // the member's current flight; nil when unflighted
+	FlightName    *string `json:"flight_name"`
+}
+
+// EventStandings is the payload for GET /events/:id/standings.
+type EventStandings struct {
+	EventID        string           `json:"event_id"`
+	Status         string           `json:"status"`
+	TiebreakPolicy string           `json:"tiebreak_policy"`
+	RankedBy       string           `json:"ranked_by"`      // "net" or "points"
+	RoundsCounted  int              `json:"rounds_counted"` // completed rounds included in the totals
+	FinalizedAt    *string          `json:"finalized_at"`   // RFC 3339; nil until FinalizeEvent
+	Flights        []FlightSummary  `json:"flights"`        // empty when the event has no flights
+	Entries        []StandingsEntry `json:"entries"`        // grouped by flight, like RoundLeaderboard
+}
+
+// ─── Service ──────────────────────────────────────────────────────────────────
+
+// LeaderboardService computes round leaderboards and event standings, and
+// finalizes (or reopens) an event's results.
+type LeaderboardService struct {
+	DB       *gorm.DB
+	EventSvc *EventService
+}
+
+// NewLeaderboardService returns a LeaderboardService wired to the given DB and EventService.
+// EventSvc supplies the organizer check for FinalizeEvent and ReopenEvent.
+func NewLeaderboardService(db *gorm.DB, eventSvc *EventService) *LeaderboardService {
+	return &LeaderboardService{DB: db, EventSvc: eventSvc}
+}
+
+// roundCard is one player's scores on a round, reduced to what ranking needs.
+type roundCard struct {
+	Player     leaderboardPlayerRow
+	Card       tiebreakCard
+	Thru       int
+	NetToPar   int
+	TotalGross int
+	TotalNet   int
+	Complete   bool
+}
+
+// leaderboardPlayerRow is the round_players ⨝ users projection used by both engines.
+// FlightID is the flight the player competes in for this round (the snapshot on
+// round_players, else their event flight); EventFlightID is their current
+// event flight, which standings rank on. ReplacedEventPlayerID is set when a
+// substitute is playing the spot of that member.
+type leaderboardPlayerRow struct {
+	RoundPlayerID  uuid.UUID
+	UserID         uuid.UUID
+	EventPlayerID  *uuid.UUID
+	DisplayName    string
+	AvatarURL      *string
+	IsGuest        bool
+	Status         models.RoundPlayerStatus
+	StatusReason   *string
+	CourseHandicap *int
+	FlightID       *uuid.UUID
+	EventFlightID  *uuid.UUID
+
+	ReplacedEventPlayerID *uuid.UUID
+	Absent                bool
+}
+
+// tiebreakPolicyFor returns the event's policy, defaulting to card_off for
+// eventless rounds and legacy rows with an empty value.
+func tiebreakPolicyFor(event *models.Event) models.TiebreakPolicy {
+	if event == nil || event.TiebreakPolicy == "" {
+		return models.TiebreakPolicyCardOff
+	}
+	return models.TiebreakPolicy(event.TiebreakPolicy)
+}
+
+// loadRoundCards loads every round_player on the round with their scores over
+// the played holes. round must have DefaultTee.Holes and Event preloaded.
+func (s *LeaderboardService) loadRoundCards(ctx context.Context, round *models.Round) ([]roundCard, int, error) {
+	played := filterPlayedHoles(round.DefaultTee.Holes, round.NineHoleSelection)
+	sort.Slice(played, func(i, j int) bool { return played[i].HoleNumber < played[j].HoleNumber })
+	holeNumbers := make([]int, len(played))
+	par := make(map[int]int, len(played))
+	for i, h := range played {
+		holeNumbers[i] = h.HoleNumber
+		par[h.HoleNumber] = h.Par
+	}
+
+	var players []leaderboardPlayerRow
+	if err := s.DB.WithContext(ctx).Table("round_players rp").
+		Select("rp.id as round_player_id, rp.user_id, rp.event_player_id, u.display_name, u.avatar_url, u.is_guest, rp.status, rp.status_reason, rp.course_handicap, "+
+			"COALESCE(rp.flight_id, ep.flight_id) AS flight_id, ep.flight_id AS event_flight_id, rp.replaced_event_player_id, rp.absent_at IS NOT NULL AS absent").
+		Joins("JOIN users u ON u.id = rp.user_id").
+		Joins("LEFT JOIN event_players ep ON ep.id = rp.event_player_id").
+		Where("rp.round_id = ?", round.ID).
+		Order("u.display_name ASC").
+		Scan(&players).Error; err != nil {
+		return nil, 0, fmt.Errorf("load round players: %w", err)
+	}
+
+	var scores []models.Score
+	if err := s.DB.WithContext(ctx).
+		Joins("JOIN round_players rp ON rp.id = scores.round_player_id").
+		Where("rp.round_id = ?", round.ID).
+		Find(&scores).Error; err != nil {
+		return nil, 0, fmt.Errorf("load scores: %w", err)
+	}
+	byPlayer := make(map[uuid.UUID][]models.Score, len(players))
+	for _, sc := range scores {
+		byPlayer[sc.RoundPlayerID] = append(byPlayer[sc.RoundPlayerID], sc)
+	}
+
+	allowance := roundHandicapAllowance(round)
+	cards := make([]roundCard, 0, len(players))
+	for _, p := range players {
+		raw := 0
+		if p.CourseHandicap != nil {
+			raw = *p.CourseHandicap
+		}
+		rc := roundCard{
+			Player: p,
+			Card: tiebreakCard{
+				Holes:    holeNumbers,
+				Gross:    make(map[int]int, len(holeNumbers)),
+				Handicap: EffectiveCourseHandicap(raw, allowance),
+			},
+		}
+		for _, sc := range byPlayer[p.RoundPlayerID] {
+			holePar, ok := par[sc.HoleNumber]
+			if !ok {
+				continue // score on a hole outside the selected nine
+			}
+			rc.Card.Gross[sc.HoleNumber] = sc.GrossScore
+			rc.Thru++
+			rc.NetToPar += sc.NetScore - holePar
+			rc.TotalGross += sc.GrossScore
+			rc.TotalNet += sc.NetScore
+		}
+		rc.Complete = len(holeNumbers) > 0 && rc.Thru == len(holeNumbers)
+		cards = append(cards, rc)
+	}
+	return cards, len(holeNumbers), nil
+}
+
+// rankRoundCards ranks the complete cards on total net within each flight and
+// returns each ranked card's result keyed by its index in cards. Players out of
+// the results (withdrawn, disqualified, no_card) and an absent player's
+// generated card are never ranked.
+func rankRoundCards(cards []roundCard, policy models.TiebreakPolicy) map[int]rankResult {
+	var inputs []rankInput
+	var flightOf []*uuid.UUID
+	var idx []int
+	for i, rc := range cards {
+		if rc.Complete && !slices.Contains(models.RoundPlayerOutStatuses, rc.Player.Status) && !rc.Player.Absent {
+			inputs = append(inputs, rankInput{Total: rc.TotalNet, Card: rc.Card})
+			flightOf = append(flightOf, rc.Player.FlightID)
+			idx = append(idx, i)
+		}
+	}
+	out := make(map[int]rankResult, len(inputs))
+	for _, r := range rankByFlight(inputs, flightOf, policy) {
+		ci := idx[r.Index]
+		out[ci] = rankResult{Index: ci, Position: r.Position, Tiebreak: r.Tiebreak}
+	}
+	return out
+}
+
+// rankByFlight ranks inputs separately within each flight — flightOf[i] is
+// input i's flight, and nil inputs form a group of their own — so positions
+// restart at 1 in every flight. Result indexes point into inputs.
+func rankByFlight(inputs []rankInput, flightOf []*uuid.UUID, policy models.TiebreakPolicy) []rankResult {
+	groups := make(map[uuid.UUID][]int)
+	var keys []uuid.UUID
+	for i := range inputs {
+		key := uuid.Nil // unflighted
+		if flightOf[i] != nil {
+			key = *flightOf[i]
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+	results := make([]rankResult, 0, len(inputs))
+	for _, key := range keys {
+		idx := groups[key]
+		group := make([]rankInput, len(idx))
+		for n, i := range idx {
+			group[n] = inputs[i]
+		}
+		for _, r := range rankWithTiebreak(group, policy) {
+			r.Index = idx[r.Index]
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+// flightDirectory orders and names an event's flights for ranking output.
+type flightDirectory struct {
+	order   map[uuid.UUID]int
+	names   map[uuid.UUID]string
+	Summary []FlightSummary
+}
+
+// newFlightDirectory indexes flights (already in sort order).
+func newFlightDirectory(flights []models.EventFlight) flightDirectory {
+	d := flightDirectory{
+		order:   make(map[uuid.UUID]int, len(flights)),
+		names:   make(map[uuid.UUID]string, len(flights)),
+		Summary: make([]FlightSummary, 0, len(flights)),
+	}
+	for i, f := range flights {
+		d.order[f.ID] = i
+		d.names[f.ID] = f.Name
+		d.Summary = append(d.Summary, FlightSummary{ID: f.ID.String(), Name: f.Name})
+	}
+	return d
+}
+
+// group returns the output group for a flight: its sort position, or
+// len(flights) for unflighted players so they list last.
+func (d flightDirectory) group(id *uuid.UUID) int {
+	if id != nil {
+		if n, ok := d.order[*id]; ok {
+			return n
+		}
+	}
+	return len(d.Summary)
+}
+
+// label returns a flight's id and name for JSON; both nil when unflighted.
+func (d flightDirectory) label(id *uuid.UUID) (*string, *string) {
+	if id == nil {
+		return nil, nil
+	}
+	name, ok := d.names[*id]
+	if !ok {
+		return nil, nil
+	}
+	sid := id.String()
+	return &sid, &name
+}
+
+// loadFlightDirectory loads the flights of the round's event; empty for
+// eventless rounds.
+func (s *LeaderboardService) loadFlightDirectory(ctx context.Context, eventID *uuid.UUID) (flightDirectory, error) {
+	if eventID == nil {
+		return newFlightDirectory(nil), nil
+	}
+	flights, err := loadFlights(ctx, s.DB, *eventID)
+	if err != nil {
+		return flightDirectory{}, err
+	}
+	return newFlightDirectory(flights), nil
+}
+
+// loadMemberRows loads every member of the event keyed by event_player ID, for
+// crediting a substitute's round to the member they replaced.
+func (s *LeaderboardService) loadMemberRows(ctx context.Context, eventID uuid.UUID) (map[uuid.UUID]leaderboardPlayerRow, error) {
+	var rows []leaderboardPlayerRow
+	if err := s.DB.WithContext(ctx).Table("event_players ep").
+		Select("ep.id AS event_player_id, ep.user_id, u.display_name, u.avatar_url, u.is_guest, ep.flight_id AS event_flight_id").
+		Joins("JOIN users u ON u.id = ep.user_id").
+		Where("ep.event_id = ?", eventID).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load event members: %w", err)
+	}
+	out := make(map[uuid.UUID]leaderboardPlayerRow, len(rows))
+	for _, r := range rows {
+		out[*r.EventPlayerID] = r
+	}
+	return out, nil
+}
+
+// ─── Round leaderboard ────────────────────────────────────────────────────────
+
+// rankedRoundCards is a round's cards ranked per its tiebreak policy.
+type rankedRoundCards struct {
+	Round     models.Round
+	Policy    models.TiebreakPolicy
+	Cards     []roundCard
+	HoleCount int
+	Results   map[int]rankResult
+}
+
+// rankRound loads a round's cards and ranks them.
+func (s *LeaderboardService) rankRound(ctx context.Context, roundID uuid.UUID) (*rankedRoundCards, error) {
+	var round models.Round
+	if err := s.DB.WithContext(ctx).
+		Preload("DefaultTee.Holes").
+		Preload("Event").
+		First(&round, "id = ?", roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundNotFound
+		}
+		return nil, fmt.Errorf("load round: %w", err)
+	}
+	policy := tiebreakPolicyFor(round.Event)
+	cards, holeCount, err := s.loadRoundCards(ctx, &round)
+	if err != nil {
+		return nil, err
+	}
+	return &rankedRoundCards{
+		Round: round, Policy: policy, Cards: cards, HoleCount: holeCount,
+		Results: rankRoundCards(cards, policy),
+	}, nil
+}
+
+// RoundLeaderboard ranks every player with a complete card on total net,
+// breaking ties per the event's tiebreak policy (card_off for eventless rounds).
+// Any authenticated user may view it, matching the scorecard. It only reads:
+// stored finish positions are written by saveRoundFinishPositions.
+func (s *LeaderboardService) RoundLeaderboard(ctx context.Context, roundID uuid.UUID) (*RoundLeaderboard, error) {
+	ranked, err := s.rankRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	round, policy, cards, holeCount, results := ranked.Round, ranked.Policy, ranked.Cards, ranked.HoleCount, ranked.Results
+	flights, err := s.loadFlightDirectory(ctx, round.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := make([]rankResult, 0, len(results))
+	for _, r := range results {
+		ordered = append(ordered, r)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].Position != ordered[j].Position {
+			return ordered[i].Position < ordered[j].Position
+		}
+		return ordered[i].Index < ordered[j].Index
+	})
+
+	// One bucket per flight plus a trailing one for unflighted players.
+	buckets := make([][]LeaderboardEntry, len(flights.Summary)+1)
+	add := func(rc roundCard, position *int, tiebreak *string) {
+		e := buildLeaderboardEntry(rc, position, tiebreak)
+		e.FlightID, e.FlightName = flights.label(rc.Player.FlightID)
+		g := flights.group(rc.Player.FlightID)
+		buckets[g] = append(buckets[g], e)
+	}
+	for _, r := range ordered {
+		pos := r.Position
+		add(cards[r.Index], &pos, r.Tiebreak)
+	}
+	var unranked []roundCard
+	for i, rc := range cards {
+		if _, ok := results[i]; !ok {
+			unranked = append(unranked, rc)
+		}
+	}
+	sort.SliceStable(unranked, func(i, j int) bool {
+		outI := slices.Contains(models.RoundPlayerOutStatuses, unranked[i].Player.Status)
+		outJ := slices.Contains(models.RoundPlayerOutStatuses, unranked[j].Player.Status)
+		if outI != outJ {
+			return outJ // below the field
+		}
+		if unranked[i].Thru == 0 || unranked[j].Thru == 0 {
+			return unranked[i].Thru > unranked[j].Thru
+		}
+		return unranked[i].NetToPar < unranked[j].NetToPar
+	})
+	for _, rc := range unranked {
+		add(rc, nil, nil)
+	}
+	entries := make([]LeaderboardEntry, 0, len(cards))
+	for _, b := range buckets {
+		entries = append(entries, b...)
+	}
+	if err := s.markDisputedHoles(ctx, round.ID, entries); err != nil {
+		return nil, err
+	}
+
+	return &RoundLeaderboard{
+		RoundID:        round.ID.String(),
+		Status:         string(round.Status),
+		HoleCount:      holeCount,
+		TiebreakPolicy: string(policy),
+		Flights:        flights.Summary,
+		Entries:        entries,
+	}, nil
+}
+
+// markDisputedHoles fills DisputedHoles on each entry from the round's open
+// score disputes.
+func (s *LeaderboardService) markDisputedHoles(ctx context.Context, roundID uuid.UUID, entries []LeaderboardEntry) error {
+	type disputeRow struct {
+		RoundPlayerID uuid.UUID
+		HoleNumber    int
+	}
+	var rows []disputeRow
+	if err := s.DB.WithContext(ctx).Table("score_disputes d").
+		Select("d.round_player_id, d.hole_number").
+		Joins("JOIN round_players rp ON rp.id = d.round_player_id").
+		Where("rp.round_id = ? AND d.status = ?", roundID, models.ScoreDisputeStatusOpen).
+		Order("d.hole_number ASC").
+		Scan(&rows).Error; err != nil {
+		return fmt.Errorf("load open disputes: %w", err)
+	}
+	byPlayer := make(map[string][]int, len(rows))
+	for _, r := range rows {
+		key := r.RoundPlayerID.String()
+		byPlayer[key] = append(byPlayer[key], r.HoleNumber)
+	}
+	for i := range entries {
+		if holes, ok := byPlayer[entries[i].RoundPlayerID]; ok {
+			entries[i].DisputedHoles = holes
+		}
+	}
+	return nil
+}
+
+// saveRoundFinishPositions ranks a completed round and writes each
+// RoundPlayer.FinishPosition (nil for unranked players), so the stored finish
+// matches the leaderboard. Call it when the round completes and after any
+// change to a completed round's cards or player statuses.
+func saveRoundFinishPositions(ctx context.Context, db *gorm.DB, roundID uuid.UUID) error {
+	ranked, err := (&LeaderboardService{DB: db}).rankRound(ctx, roundID)
+	if err != nil {
+		return err
+	}
+	if ranked.Round.Status != models.RoundStatusCompleted {
+		return nil
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveRoundPositions(tx, ranked.Cards, ranked.Results, nil)
+	})
+}
+
+// saveRoundPositions writes finish_position (nil for unranked cards) onto every
+// round player in cards. When points is non-nil, points_earned is written too.
+func saveRoundPositions(tx *gorm.DB, cards []roundCard, results map[int]rankResult, points map[uuid.UUID]int) error {
+	for i, rc := range cards {
+		updates := map[string]any{"finish_position": nil}
+		if r, ok := results[i]; ok {
+			updates["finish_position"] = r.Position
+		}
+		if points != nil {
+			updates["points_earned"] = nil
+			if p, ok := points[rc.Player.RoundPlayerID]; ok {
+				updates["points_earned"] = p
+			}
+		}
+		if err := tx.Model(&models.RoundPlayer{}).Where("id = ?", rc.Player.RoundPlayerID).
+			Updates(updates).Error; err != nil {
+			return fmt.Errorf("save finish position for %s: %w", rc.Player.RoundPlayerID, err)
+		}
+	}
+	return nil
+}
+
+// buildLeaderboardEntry converts a roundCard into its JSON line.
+func buildLeaderboardEntry(rc roundCard, position *int, tiebreak *string) LeaderboardEntry {
+	e := LeaderboardEntry{
+		RoundPlayerID: rc.Player.RoundPlayerID.String(),
+		UserID:        rc.Player.UserID.String(),
+		DisplayName:   rc.Player.DisplayName,
+		AvatarURL:     rc.Player.AvatarURL,
+		IsGuest:       rc.Player.IsGuest,
+		Status:        string(rc.Player.Status),
+		StatusReason:  rc.Player.StatusReason,
+		Thru:          rc.Thru,
+		NetToPar:      rc.NetToPar,
+		Position:      position,
+		Tiebreak:      tiebreak,
+		DisputedHoles: []int{},
+	}
+	if rc.Player.CourseHandicap != nil {
+		eff := rc.Card.Handicap
+		e.EffectiveCourseHandicap = &eff
+	}
+	if rc.Complete {
+		gross, net := rc.TotalGross, rc.TotalNet
+		e.TotalGross, e.TotalNet = &gross, &net
+	}
+	return e
+}
+
+// ─── Event standings ──────────────────────────────────────────────────────────
+
+// rankedRound is one counted round's cards with their ranking and, when the
+// event has a points table, the points each round player earned.
+type rankedRound struct {
+	Cards   []roundCard
+	Results map[int]rankResult
+	Points  map[uuid.UUID]int // nil when the event has no points rules
+}
+
+// standingsComputation is the full result of computeStandings: the JSON payload
+// plus the per-round detail FinalizeEvent writes back onto round_players.
+type standingsComputation struct {
+	Standings *EventStandings
+	Rounds    []rankedRound
+}
+
+// pointsTables holds an event's points rules: finish position → points, per
+// flight, with the flight-less rules as the default table.
+type pointsTables struct {
+	byFlight map[uuid.UUID]map[int]int
+	fallback map[int]int
+}
+
+// newPointsTables groups rules by flight; nil when the event has no rules.
+func newPointsTables(rules []models.EventPointsRule) *pointsTables {
+	if len(rules) == 0 {
+		return nil
+	}
+	t := &pointsTables{byFlight: make(map[uuid.UUID]map[int]int), fallback: make(map[int]int)}
+	for _, r := range rules {
+		table := t.fallback
+		if r.FlightID != nil {
+			if t.byFlight[*r.FlightID] == nil {
+				t.byFlight[*r.FlightID] = make(map[int]int)
+			}
+			table = t.byFlight[*r.FlightID]
+		}
+		table[r.FinishPosition] = r.Points
+	}
+	return t
+}
+
+// points returns what a finish position earns in a flight: the flight's own
+// table when it has one, else the default table.
+func (t *pointsTables) points(flightID *uuid.UUID, position int) int {
+	if flightID != nil {
+		if table, ok := t.byFlight[*flightID]; ok {
+			return table[position]
+		}
+	}
+	return t.fallback[position]
+}
+
+// computeStandings ranks an event's members across its completed rounds.
+//
+// Without a points table, members are ranked on total net and only those with
+// a full card in every counted round are ranked. With a points table
+// (event_points_rules), each round awards points by round finish position and
+// members are ranked on total points (highest first) — a league member who
+// missed a week still places. Either way, ties are broken on each member's
+// latest counted card per the event's tiebreak policy.
+//
+// With flights, round points follow the flight a player competed in that round
+// (and that flight's points table, if it has one), while standings rank each
+// member within their current flight.
+//
+// A substitute's round counts per the event's sub policy: for the member whose
+// spot they played (team), for the sub themselves (self), or for nobody (none,
+// and the card earns no points).
+//
+// before, when set, counts only rounds scheduled before that day, giving the
+// standings as they stood then (the weekly digest's standings movement).
+func (s *LeaderboardService) computeStandings(ctx context.Context, event *models.Event, before *time.Time) (*standingsComputation, error) {
+	policy := tiebreakPolicyFor(event)
+	subs := subPolicyFor(event)
+	var members map[uuid.UUID]leaderboardPlayerRow // loaded on the first sub card under the team policy
+
+	var rules []models.EventPointsRule
+	if err := s.DB.WithContext(ctx).Where("event_id = ?", event.ID).Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("load points rules: %w", err)
+	}
+	pointsFor := newPointsTables(rules)
+	flights, err := s.loadFlightDirectory(ctx, &event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rounds []models.Round
+	q := s.DB.WithContext(ctx).
+		Preload("DefaultTee.Holes").
+		Where("event_id = ? AND status = ?", event.ID, models.RoundStatusCompleted)
+	if before != nil {
+		q = q.Where("scheduled_date < ?", before.UTC().Format("2006-01-02"))
+	}
+	if err := q.Order("scheduled_date ASC, round_number ASC").
+		Find(&rounds).Error; err != nil {
+		return nil, fmt.Errorf("load completed rounds: %w", err)
+	}
+
+	// Accumulate per event player, in first-seen order for a stable listing.
+	type standing struct {
+		Player     leaderboardPlayerRow
+		Rounds     int
+		TotalGross int
+		TotalNet   int
+		Points     int
+		LastCard   tiebreakCard // card from the latest counted round; decides a card-off
+	}
+	byPlayer := make(map[uuid.UUID]*standing)
+	var order []uuid.UUID
+	ranked := make([]rankedRound, 0, len(rounds))
+	for ri := range rounds {
+		rounds[ri].Event = event // allowance comes from the event row already loaded
+		cards, _, err := s.loadRoundCards(ctx, &rounds[ri])
+		if err != nil {
+			return nil, err
+		}
+		rr := rankedRound{Cards: cards, Results: rankRoundCards(cards, policy)}
+		if pointsFor != nil {
+			rr.Points = make(map[uuid.UUID]int, len(rr.Results))
+			for ci, r := range rr.Results {
+				p := cards[ci].Player
+				if p.ReplacedEventPlayerID != nil && subs == models.SubPolicyNone {
+					continue
+				}
+				rr.Points[p.RoundPlayerID] = pointsFor.points(p.FlightID, r.Position)
+			}
+		}
+		ranked = append(ranked, rr)
+
+		for ci, rc := range cards {
+			if rc.Player.EventPlayerID == nil || rc.Thru == 0 {
+				continue
+			}
+			epID, holder := *rc.Player.EventPlayerID, rc.Player
+			if rc.Player.ReplacedEventPlayerID != nil {
+				switch subs {
+				case models.SubPolicyNone:
+					continue
+				case models.SubPolicyTeam:
+					if members == nil {
+						if members, err = s.loadMemberRows(ctx, event.ID); err != nil {
+							return nil, err
+						}
+					}
+					epID = *rc.Player.ReplacedEventPlayerID
+					holder = members[epID]
+				}
+			}
+			st, ok := byPlayer[epID]
+			if !ok {
+				st = &standing{Player: holder}
+				byPlayer[epID] = st
+				order = append(order, epID)
+			}
+			if _, counted := rr.Results[ci]; !counted {
+				continue
+			}
+			st.Rounds++
+			st.TotalGross += rc.TotalGross
+			st.TotalNet += rc.TotalNet
+			st.Points += rr.Points[rc.Player.RoundPlayerID]
+			st.LastCard = rc.Card
+		}
+	}
+
+	rankedBy := StandingsRankedByNet
+	if pointsFor != nil {
+		rankedBy = StandingsRankedByPoints
+	}
+	var inputs []rankInput
+	var flightOf []*uuid.UUID
+	var rankedIDs []uuid.UUID
+	for _, epID := range order {
+		st := byPlayer[epID]
+		switch {
+		case pointsFor != nil && st.Rounds > 0:
+			// Negated so the ascending rank puts the most points first.
+			inputs = append(inputs, rankInput{Total: -st.Points, Card: st.LastCard})
+		case pointsFor == nil && len(rounds) > 0 && st.Rounds == len(rounds):
+			inputs = append(inputs, rankInput{Total: st.TotalNet, Card: st.LastCard})
+		default:
+			continue
+		}
+		flightOf = append(flightOf, st.Player.EventFlightID)
+		rankedIDs = append(rankedIDs, epID)
+	}
+	results := rankByFlight(inputs, flightOf, policy)
+
+	// One bucket per flight plus a trailing one for unflighted members.
+	buckets := make([][]StandingsEntry, len(flights.Summary)+1)
+	add := func(st *standing, e StandingsEntry) {
+		e.FlightID, e.FlightName = flights.label(st.Player.EventFlightID)
+		g := flights.group(st.Player.EventFlightID)
+		buckets[g] = append(buckets[g], e)
+	}
+	placed := make(map[uuid.UUID]bool, len(results))
+	for _, r := range results {
+		epID := rankedIDs[r.Index]
+		placed[epID] = true
+		st := byPlayer[epID]
+		pos, gross, net := r.Position, st.TotalGross, st.TotalNet
+		e := StandingsEntry{
+			EventPlayerID: epID.String(), UserID: st.Player.UserID.String(),
+			DisplayName: st.Player.DisplayName, AvatarURL: st.Player.AvatarURL,
+			RoundsPlayed: st.Rounds, Position: &pos, Tiebreak: r.Tiebreak,
+		}
+		if st.Rounds == len(rounds) {
+			e.TotalGross, e.TotalNet = &gross, &net
+		}
+		if pointsFor != nil {
+			pts := st.Points
+			e.TotalPoints = &pts
+		}
+		add(st, e)
+	}
+	for _, epID := range order {
+		if placed[epID] {
+			continue
+		}
+		st := byPlayer[epID]
+		add(st, StandingsEntry{
+			EventPlayerID: epID.String(), UserID: st.Player.UserID.String(),
+			DisplayName: st.Player.DisplayName, AvatarURL: st.Player.AvatarURL,
+			RoundsPlayed: st.Rounds,
+		})
+	}
+	entries := make([]StandingsEntry, 0, len(order))
+	for _, b := range buckets {
+		entries = append(entries, b...)
+	}
+
+	return &standingsComputation{
+		Standings: &EventStandings{
+			EventID:        event.ID.String(),
+			Status:         string(event.Status),
+			TiebreakPolicy: string(policy),
+			RankedBy:       rankedBy,
+			RoundsCounted:  len(rounds),
+			FinalizedAt:    FormatTimestamp(event.FinalizedAt),
+			Flights:        flights.Summary,
+			Entries:        entries,
+		},
+		Rounds: ranked,
+	}, nil
+}
+
+// EventStandings returns the live standings for an event (see computeStandings).
+// Non-admins must be members of the event (ErrEventNotMember otherwise).
+// Read-only: FinalizeEvent is what writes results onto event_players.
+func (s *LeaderboardService) EventStandings(ctx context.Context, eventID, requesterID uuid.UUID, requesterRole string) (*EventStandings, error) {
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("load event: %w", err)
+	}
+	if models.UserRole(requesterRole) != models.UserRoleAdmin {
+		var count int64
+		if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+			Where("event_id = ? AND user_id = ?", eventID, requesterID).
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("check membership: %w", err)
+		}
+		if count == 0 {
+			return nil, ErrEventNotMember
+		}
+	}
+
+	computed, err := s.computeStandings(ctx, &event, nil)
+	if err != nil {
+		return nil, err
+	}
+	return computed.Standings, nil
+}
+
+// ─── Finalize / reopen ────────────────────────────────────────────────────────
+
+// FinalizeEvent closes out an event's results. Caller must be an organizer (or admin).
+//
+// Every round must be completed (ErrRoundsIncomplete otherwise). In one
+// transaction it writes round finish positions and points onto round_players,
+// final positions and totals onto event_players, moves registered players to
+// completed, and marks the event completed + finalized, then notifies the
+// field. Once finalized, score
+// edits are locked for everyone but admins (see ScoreService.canModifyScores)
+// until an organizer calls ReopenEvent.
+func (s *LeaderboardService) FinalizeEvent(ctx context.Context, eventID, callerID uuid.UUID, callerRole string) (*EventStandings, error) {
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("load event: %w", err)
+	}
+	isOrg, err := s.EventSvc.IsOrganizer(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if !isOrg {
+		return nil, ErrEventForbidden
+	}
+	if event.FinalizedAt != nil {
+		return nil, ErrEventFinalized
+	}
+
+	now := time.Now().UTC()
+	var computed *standingsComputation
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the event so a concurrent finalize waits, then rank from the
+		// same transaction that freezes the results.
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&event, "id = ?", eventID).Error; err != nil {
+			return fmt.Errorf("lock event: %w", err)
+		}
+		if event.FinalizedAt != nil {
+			return ErrEventFinalized
+		}
+		// Counted under the lock: a round status change waits on the event
+		// row (see RoundService.Update) instead of slipping in between.
+		var open int64
+		if err := tx.Model(&models.Round{}).
+			Where("event_id = ? AND status <> ?", eventID, models.RoundStatusCompleted).
+			Count(&open).Error; err != nil {
+			return fmt.Errorf("count open rounds: %w", err)
+		}
+		if open > 0 {
+			return ErrRoundsIncomplete
+		}
+		event.Status = models.EventStatusCompleted
+		event.FinalizedAt = &now
+		event.FinalizedBy = &callerID
+		var err error
+		computed, err = (&LeaderboardService{DB: tx, EventSvc: s.EventSvc}).computeStandings(ctx, &event, nil)
+		if err != nil {
+			return err
+		}
+		for _, rr := range computed.Rounds {
+			points := rr.Points
+			if points == nil {
+				points = map[uuid.UUID]int{} // no points table: clear any stale points
+			}
+			if err := saveRoundPositions(tx, rr.Cards, rr.Results, points); err != nil {
+				return err
+			}
+		}
+		for _, e := range computed.Standings.Entries {
+			if err := tx.Model(&models.EventPlayer{}).Where("id = ?", e.EventPlayerID).
+				Updates(map[string]any{
+					"finish_position":   e.Position,
+					"total_gross_score": e.TotalGross,
+					"total_net_score":   e.TotalNet,
+					"total_points":      e.TotalPoints,
+				}).Error; err != nil {
+				return fmt.Errorf("save standing for %s: %w", e.EventPlayerID, err)
+			}
+		}
+		if err := tx.Model(&models.EventPlayer{}).
+			Where("event_id = ? AND status = ?", eventID, models.EventPlayerStatusRegistered).
+			Update("status", models.EventPlayerStatusCompleted).Error; err != nil {
+			return fmt.Errorf("mark players completed: %w", err)
+		}
+		if err := tx.Model(&models.Event{}).Where("id = ?", eventID).Updates(map[string]any{
+			"status":       models.EventStatusCompleted,
+			"finalized_at": now,
+			"finalized_by": callerID,
+		}).Error; err != nil {
+			return fmt.Errorf("mark event finalized: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(txErr, ErrEventFinalized) || errors.Is(txErr, ErrRoundsIncomplete) {
+		return nil, txErr
+	}
+	if txErr != nil {
+		return nil, fmt.Errorf("finalize event: %w", txErr)
+	}
+	notify(ctx, s.DB, models.Notification{
+		Type: models.NotificationTypeResultsFinalized, ActorID: &callerID, EventID: &eventID,
+	}, eventFieldIDs(s.DB, eventID))
+	recordEventWins(ctx, s.DB, eventID, computed.Standings)
+	return computed.Standings, nil
+}
+
+// ReopenEvent undoes a finalize so results can be corrected. Caller must be an
+// organizer (or admin) and must give a reason, which is recorded in
+// event_reopenings. The event returns to active, completed players return to
+// registered, score edits unlock, and the event's wins leave the feed until
+// it's finalized again. Stored positions and points are left in place until
+// the next FinalizeEvent overwrites them.
+func (s *LeaderboardService) ReopenEvent(ctx context.Context, eventID, callerID uuid.UUID, callerRole, reason string) (models.EventReopening, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return models.EventReopening{}, &ValidationError{Field: "reason", Message: "reason is required"}
+	}
+
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.EventReopening{}, ErrEventNotFound
+		}
+		return models.EventReopening{}, fmt.Errorf("load event: %w", err)
+	}
+	isOrg, err := s.EventSvc.IsOrganizer(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return models.EventReopening{}, err
+	}
+	if !isOrg {
+		return models.EventReopening{}, ErrEventForbidden
+	}
+	if event.FinalizedAt == nil {
+		return models.EventReopening{}, ErrEventNotFinalized
+	}
+
+	reopening := models.EventReopening{EventID: eventID, ReopenedBy: callerID, Reason: reason}
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the event so a concurrent finalize or reopen waits, then check
+		// it's still finalized.
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&event, "id = ?", eventID).Error; err != nil {
+			return fmt.Errorf("lock event: %w", err)
+		}
+		if event.FinalizedAt == nil {
+			return ErrEventNotFinalized
+		}
+		if err := tx.Omit(clause.Associations).Create(&reopening).Error; err != nil {
+			return fmt.Errorf("record reopening: %w", err)
+		}
+		if err := tx.Model(&models.EventPlayer{}).
+			Where("event_id = ? AND status = ?", eventID, models.EventPlayerStatusCompleted).
+			Update("status", models.EventPlayerStatusRegistered).Error; err != nil {
+			return fmt.Errorf("reset player status: %w", err)
+		}
+		if err := tx.Model(&models.Event{}).Where("id = ?", eventID).Updates(map[string]any{
+			"status":       models.EventStatusActive,
+			"finalized_at": nil,
+			"finalized_by": nil,
+		}).Error; err != nil {
+			return fmt.Errorf("clear finalized: %w", err)
+		}
+		if err := tx.Where("event_id = ? AND kind = ?", eventID, models.ActivityKindEventWon).
+			Delete(&models.ActivityItem{}).Error; err != nil {
+			return fmt.Errorf("clear event wins: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(txErr, ErrEventNotFinalized) {
+		return models.EventReopening{}, ErrEventNotFinalized
+	}
+	if txErr != nil {
+		return models.EventReopening{}, fmt.Errorf("reopen event: %w", txErr)
+	}
+	return reopening, nil
+}
+
+// FormatTimestamp renders an optional timestamp as RFC 3339 UTC; handlers
+// use it too.
+func FormatTimestamp(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.UTC().Format(time.RFC3339)
+	return &s
+}
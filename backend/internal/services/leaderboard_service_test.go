@@ -0,0 +1,333 @@
+// services/leaderboard_service_test.go
+// Integration tests for LeaderboardService. Uses testutil.NewTestDB to spin up an
+// ephemeral Postgres container — Docker must be running.
+//
+// The card-off ordering rules are covered without a DB in tiebreak_internal_test.go;
+// these tests cover loading cards from real rows and persisting finish positions.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// ─── Fixtures ─────────────────────────────────────────────────────────────────
+
+// newLeaderboardSvc builds a LeaderboardService backed by the test DB.
+func newLeaderboardSvc(db *gorm.DB) *services.LeaderboardService {
+	return services.NewLeaderboardService(db, services.NewEventService(db))
+}
+
+// enterCard inserts a full 18-hole card of 4s (net = gross, no handicap) with the
+// given per-hole overrides.
+func enterCard(t *testing.T, db *gorm.DB, rpID, enteredBy uuid.UUID, overrides map[int]int) {
+	t.Helper()
+	for h := 1; h <= 18; h++ {
+		gross := 4
+		if g, ok := overrides[h]; ok {
+			gross = g
+		}
+		sc := models.Score{RoundPlayerID: rpID, HoleNumber: h, GrossScore: gross, NetScore: gross, EnteredBy: enteredBy}
+		require.NoError(t, db.Omit(clause.Associations).Create(&sc).Error)
+	}
+}
+
+// tiedRound schedules an 18-hole round in a fresh event with two members who
+// both shoot 72; bob wins the card-off on the back nine. Returns the round ID,
+// the event, and the two round players (alice, bob).
+func tiedRound(t *testing.T, db *gorm.DB) (uuid.UUID, models.Event, models.RoundPlayer, models.RoundPlayer) {
+	t.Helper()
+	eventSvc := services.NewEventService(db)
+	roundSvc := services.NewRoundService(db, eventSvc)
+
+	organizer := seedUser(t, db, "lbOrganizer")
+	event := seedEvent(t, eventSvc, organizer.ID)
+	course, tee := seedCourseWithTee(t, db, "Leaderboard Course")
+	seedHoles(t, db, tee.ID)
+	round := scheduleRound(t, roundSvc, event.ID, organizer.ID, course.ID.String(), tee.ID.String())
+
+	alice := seedUser(t, db, "lbAlice")
+	bob := seedUser(t, db, "lbBob")
+	aliceRP := addRoundPlayer(t, db, round.Round.ID, addEventMember(t, db, event.ID, alice.ID).ID)
+	bobRP := addRoundPlayer(t, db, round.Round.ID, addEventMember(t, db, event.ID, bob.ID).ID)
+	enterCard(t, db, aliceRP.ID, organizer.ID, nil)
+	enterCard(t, db, bobRP.ID, organizer.ID, map[int]int{1: 5, 10: 3})
+	return round.Round.ID, event, aliceRP, bobRP
+}
+
+// completeRound marks a round completed.
+func completeRound(t *testing.T, db *gorm.DB, roundID uuid.UUID) {
+	t.Helper()
+	require.NoError(t, db.Model(&models.Round{}).Where("id = ?", roundID).
+		Update("status", models.RoundStatusCompleted).Error)
+}
+
+// organizerOf returns the user ID of the event's organizer.
+func organizerOf(t *testing.T, db *gorm.DB, eventID uuid.UUID) uuid.UUID {
+	t.Helper()
+	var ep models.EventPlayer
+	require.NoError(t, db.First(&ep, "event_id = ? AND role = ?", eventID, models.EventPlayerRoleOrganizer).Error)
+	return ep.UserID
+}
+
+// ─── RoundLeaderboard ─────────────────────────────────────────────────────────
+
+func TestLeaderboardService_RoundLeaderboard_CardOffAndPersist(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	completeViaService(t, db, roundID, organizerOf(t, db, event.ID))
+
+	board, err := svc.RoundLeaderboard(context.Background(), roundID)
+	require.NoError(t, err)
+	require.Len(t, board.Entries, 2)
+	assert.Equal(t, string(models.TiebreakPolicyCardOff), board.TiebreakPolicy)
+	assert.Equal(t, bobRP.ID.String(), board.Entries[0].RoundPlayerID)
+	require.NotNil(t, board.Entries[0].Position)
+	assert.Equal(t, 1, *board.Entries[0].Position)
+	assert.Equal(t, services.TiebreakBack9, *board.Entries[0].Tiebreak)
+	assert.Equal(t, 2, *board.Entries[1].Position)
+
+	var stored models.RoundPlayer
+	require.NoError(t, db.First(&stored, "id = ?", aliceRP.ID).Error)
+	require.NotNil(t, stored.FinishPosition)
+	assert.Equal(t, 2, *stored.FinishPosition, "completing the round stores the finish")
+}
+
+func TestRecalculateEventScores_ReranksCompletedRound(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+	organizer := organizerOf(t, db, event.ID)
+	completeViaService(t, db, roundID, organizer)
+	require.NoError(t, db.Model(&aliceRP).Update("course_handicap", 18).Error)
+
+	require.NoError(t, services.RecalculateEventScores(context.Background(), db, event.ID, organizer, nil))
+
+	var stored models.RoundPlayer
+	require.NoError(t, db.First(&stored, "id = ?", aliceRP.ID).Error)
+	require.NotNil(t, stored.FinishPosition)
+	assert.Equal(t, 1, *stored.FinishPosition, "alice's strokes now win on net")
+}
+
+func TestLeaderboardService_RoundLeaderboard_ReadOnly(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	roundID, _, _, bobRP := tiedRound(t, db)
+	completeRound(t, db, roundID)
+
+	board, err := svc.RoundLeaderboard(context.Background(), roundID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *board.Entries[0].Position)
+
+	var stored models.RoundPlayer
+	require.NoError(t, db.First(&stored, "id = ?", bobRP.ID).Error)
+	assert.Nil(t, stored.FinishPosition, "viewing the board doesn't write")
+}
+
+func TestLeaderboardService_RoundLeaderboard_ActiveRoundNotPersisted(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	roundID, _, _, bobRP := tiedRound(t, db)
+
+	board, err := svc.RoundLeaderboard(context.Background(), roundID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *board.Entries[0].Position)
+
+	var stored models.RoundPlayer
+	require.NoError(t, db.First(&stored, "id = ?", bobRP.ID).Error)
+	assert.Nil(t, stored.FinishPosition, "provisional positions must not be written")
+}
+
+func TestLeaderboardService_RoundLeaderboard_NotFound(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	_, err := svc.RoundLeaderboard(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, services.ErrRoundNotFound)
+}
+
+// ─── EventStandings ───────────────────────────────────────────────────────────
+
+func TestLeaderboardService_EventStandings_SharedPolicy(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+	completeRound(t, db, roundID)
+	require.NoError(t, db.Model(&models.Event{}).Where("id = ?", event.ID).
+		Update("tiebreak_policy", models.TiebreakPolicyShared).Error)
+
+	standings, err := svc.EventStandings(context.Background(), event.ID, uuid.Nil, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, 1, standings.RoundsCounted)
+	assert.Equal(t, services.StandingsRankedByNet, standings.RankedBy)
+	require.Len(t, standings.Entries, 2)
+	for _, e := range standings.Entries {
+		require.NotNil(t, e.Position)
+		assert.Equal(t, 1, *e.Position)
+		assert.Equal(t, services.TiebreakShared, *e.Tiebreak)
+		assert.Equal(t, 72, *e.TotalNet)
+	}
+
+	// Standings are read-only; only FinalizeEvent writes event results.
+	var ep models.EventPlayer
+	require.NoError(t, db.First(&ep, "id = ?", *aliceRP.EventPlayerID).Error)
+	assert.Nil(t, ep.FinishPosition)
+}
+
+func TestLeaderboardService_EventStandings_NonMemberForbidden(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	_, event, _, _ := tiedRound(t, db)
+	outsider := seedUser(t, db, "lbOutsider")
+
+	_, err := svc.EventStandings(context.Background(), event.ID, outsider.ID, "user")
+	assert.ErrorIs(t, err, services.ErrEventNotMember)
+}
+
+// ─── FinalizeEvent / ReopenEvent ──────────────────────────────────────────────
+
+func TestLeaderboardService_FinalizeEvent_RequiresCompletedRounds(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	_, event, _, _ := tiedRound(t, db)
+
+	_, err := svc.FinalizeEvent(context.Background(), event.ID, organizerOf(t, db, event.ID), "user")
+	assert.ErrorIs(t, err, services.ErrRoundsIncomplete)
+}
+
+func TestLeaderboardService_FinalizeEvent_NonOrganizerForbidden(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+	completeRound(t, db, roundID)
+
+	_, err := svc.FinalizeEvent(context.Background(), event.ID, aliceRP.UserID, "user")
+	assert.ErrorIs(t, err, services.ErrEventForbidden)
+}
+
+func TestLeaderboardService_FinalizeEvent_WritesResultsAndPoints(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	completeRound(t, db, roundID)
+	for pos, pts := range map[int]int{1: 10, 2: 6} {
+		rule := models.EventPointsRule{EventID: event.ID, FinishPosition: pos, Points: pts}
+		require.NoError(t, db.Omit(clause.Associations).Create(&rule).Error)
+	}
+	orgID := organizerOf(t, db, event.ID)
+
+	standings, err := svc.FinalizeEvent(context.Background(), event.ID, orgID, "user")
+	require.NoError(t, err)
+	assert.Equal(t, services.StandingsRankedByPoints, standings.RankedBy)
+	require.NotNil(t, standings.FinalizedAt)
+
+	var bobEP, aliceEP models.EventPlayer
+	require.NoError(t, db.First(&bobEP, "id = ?", *bobRP.EventPlayerID).Error)
+	require.NoError(t, db.First(&aliceEP, "id = ?", *aliceRP.EventPlayerID).Error)
+	assert.Equal(t, 1, *bobEP.FinishPosition)
+	assert.Equal(t, 10, *bobEP.TotalPoints)
+	assert.Equal(t, 2, *aliceEP.FinishPosition)
+	assert.Equal(t, 6, *aliceEP.TotalPoints)
+	assert.Equal(t, models.EventPlayerStatusCompleted, bobEP.Status)
+
+	var bobStored models.RoundPlayer
+	require.NoError(t, db.First(&bobStored, "id = ?", bobRP.ID).Error)
+	assert.Equal(t, 10, *bobStored.PointsEarned)
+
+	var stored models.Event
+	require.NoError(t, db.First(&stored, "id = ?", event.ID).Error)
+	assert.Equal(t, models.EventStatusCompleted, stored.Status)
+	require.NotNil(t, stored.FinalizedBy)
+	assert.Equal(t, orgID, *stored.FinalizedBy)
+
+	_, err = svc.FinalizeEvent(context.Background(), event.ID, orgID, "user")
+	assert.ErrorIs(t, err, services.ErrEventFinalized)
+}
+
+func TestLeaderboardService_FinalizeEvent_LocksScoresExceptAdmin(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	scoreSvc := newScoreSvc(db)
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+	completeRound(t, db, roundID)
+	orgID := organizerOf(t, db, event.ID)
+	_, err := svc.FinalizeEvent(context.Background(), event.ID, orgID, "user")
+	require.NoError(t, err)
+
+	edit := []services.ScoreInput{{HoleNumber: 1, GrossScore: 3}}
+	_, err = scoreSvc.UpsertScores(context.Background(), roundID, aliceRP.ID, orgID, "user", edit)
+	assert.ErrorIs(t, err, services.ErrScoresLocked)
+
+	admin := seedAdmin(t, db)
+	_, err = scoreSvc.UpsertScores(context.Background(), roundID, aliceRP.ID, admin.ID, "admin", edit)
+	assert.NoError(t, err)
+}
+
+func TestLeaderboardService_FinalizeEvent_LocksResultSettings(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	eventSvc := services.NewEventService(db)
+	roundID, event, _, _ := tiedRound(t, db)
+	completeRound(t, db, roundID)
+	orgID := organizerOf(t, db, event.ID)
+	_, err := svc.FinalizeEvent(context.Background(), event.ID, orgID, "user")
+	require.NoError(t, err)
+
+	allowance := 80.0
+	shared := string(models.TiebreakPolicyShared)
+	for name, in := range map[string]services.UpdateEventInput{
+		"allowance": {HandicapAllowance: &allowance},
+		"tiebreak":  {TiebreakPolicy: &shared},
+	} {
+		_, err := eventSvc.Update(context.Background(), event.ID, orgID, "user", in)
+		assert.ErrorIs(t, err, services.ErrEventFinalized, name)
+	}
+
+	// Fields that don't touch results stay editable.
+	name := "Renamed After Finalize"
+	res, err := eventSvc.Update(context.Background(), event.ID, orgID, "user", services.UpdateEventInput{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, name, res.Event.Name)
+}
+
+func TestLeaderboardService_ReopenEvent_RecordsReasonAndUnlocks(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLeaderboardSvc(db)
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+	completeRound(t, db, roundID)
+	orgID := organizerOf(t, db, event.ID)
+
+	_, err := svc.ReopenEvent(context.Background(), event.ID, orgID, "user", "wrong card")
+	assert.ErrorIs(t, err, services.ErrEventNotFinalized)
+
+	_, err = svc.FinalizeEvent(context.Background(), event.ID, orgID, "user")
+	require.NoError(t, err)
+
+	reopening, err := svc.ReopenEvent(context.Background(), event.ID, orgID, "user", "  wrong card on hole 7  ")
+	require.NoError(t, err)
+	assert.Equal(t, "wrong card on hole 7", reopening.Reason)
+
+	var stored models.Event
+	require.NoError(t, db.First(&stored, "id = ?", event.ID).Error)
+	assert.Nil(t, stored.FinalizedAt)
+	assert.Equal(t, models.EventStatusActive, stored.Status)
+
+	var ep models.EventPlayer
+	require.NoError(t, db.First(&ep, "id = ?", *aliceRP.EventPlayerID).Error)
+	assert.Equal(t, models.EventPlayerStatusRegistered, ep.Status)
+
+	var count int64
+	require.NoError(t, db.Model(&models.EventReopening{}).Where("event_id = ?", event.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
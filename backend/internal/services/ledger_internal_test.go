@@ -0,0 +1,111 @@
+// services/ledger_internal_test.go
+// White-box tests for the unexported side-bet ledger helpers in ledger_service.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestValidateSideGameResults|TestBalanceEffect|TestTallyLedger' -v
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+func TestValidateSideGameResults(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	long := strings.Repeat("x", maxLedgerNoteLen+1)
+	line := func(from, to uuid.UUID, cents int) SideGameResultInput {
+		return SideGameResultInput{FromUserID: from, ToUserID: to, AmountCents: cents}
+	}
+	cases := map[string]struct {
+		game      string
+		lines     []SideGameResultInput
+		wantField string
+	}{
+		"skins":           {"skins", []SideGameResultInput{line(a, b, 500)}, ""},
+		"empty clears":    {"nassau", nil, ""},
+		"top amount":      {"las_vegas", []SideGameResultInput{line(a, b, maxSideBetCents)}, ""},
+		"unknown game":    {"poker", nil, "game"},
+		"owes themselves": {"other", []SideGameResultInput{line(a, a, 500)}, "results"},
+		"zero amount":     {"skins", []SideGameResultInput{line(a, b, 0)}, "results"},
+		"over the cap":    {"skins", []SideGameResultInput{line(a, b, maxSideBetCents+1)}, "results"},
+		"too many lines":  {"skins", make([]SideGameResultInput, maxResultLines+1), "results"},
+		"long note":       {"other", []SideGameResultInput{{FromUserID: a, ToUserID: b, AmountCents: 100, Note: &long}}, "note"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateSideGameResults(tc.game, tc.lines)
+			if tc.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var ve *ValidationError
+			require.ErrorAs(t, err, &ve)
+			assert.Equal(t, tc.wantField, ve.Field)
+		})
+	}
+}
+
+func TestBalanceEffect(t *testing.T) {
+	me, them := uuid.New(), uuid.New()
+	entry := func(kind models.LedgerEntryKind, from, to uuid.UUID) models.LedgerEntry {
+		return models.LedgerEntry{Kind: kind, FromUserID: from, ToUserID: to, AmountCents: 300}
+	}
+	assert.Equal(t, 300, balanceEffect(me, entry(models.LedgerEntryKindResult, them, me)), "they lost to me")
+	assert.Equal(t, -300, balanceEffect(me, entry(models.LedgerEntryKindResult, me, them)), "I lost to them")
+	assert.Equal(t, 300, balanceEffect(me, entry(models.LedgerEntryKindSettleUp, me, them)), "I paid them")
+	assert.Equal(t, -300, balanceEffect(me, entry(models.LedgerEntryKindSettleUp, them, me)), "they paid me")
+}
+
+func TestTallyLedger_NetsPerPlayerAndDropsSettled(t *testing.T) {
+	me := uuid.New()
+	ann := models.User{ID: uuid.New(), DisplayName: "Ann"}
+	bob := models.User{ID: uuid.New(), DisplayName: "Bob"}
+	cat := models.User{ID: uuid.New(), DisplayName: "Cat"}
+	self := models.User{ID: me, DisplayName: "Me"}
+	t0 := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	entry := func(kind models.LedgerEntryKind, from, to models.User, cents int, at time.Time) models.LedgerEntry {
+		return models.LedgerEntry{
+			Kind: kind, FromUserID: from.ID, FromUser: from, ToUserID: to.ID, ToUser: to,
+			AmountCents: cents, CreatedAt: at,
+		}
+	}
+	entries := []models.LedgerEntry{
+		// Ann owes me 1500 over two rounds, less 500 I lost to her.
+		entry(models.LedgerEntryKindResult, ann, self, 1000, t0),
+		entry(models.LedgerEntryKindResult, ann, self, 1000, t0.Add(48*time.Hour)),
+		entry(models.LedgerEntryKindResult, self, ann, 500, t0.Add(24*time.Hour)),
+		// I owe Bob 700.
+		entry(models.LedgerEntryKindResult, self, bob, 700, t0),
+		// Cat and I are square after a settle-up.
+		entry(models.LedgerEntryKindResult, cat, self, 400, t0),
+		entry(models.LedgerEntryKindSettleUp, cat, self, 400, t0.Add(time.Hour)),
+	}
+
+	data := tallyLedger(me, entries)
+
+	require.Len(t, data.Balances, 2)
+	assert.Equal(t, ann.ID.String(), data.Balances[0].UserID)
+	assert.Equal(t, 1500, data.Balances[0].BalanceCents)
+	assert.Equal(t, t0.Add(48*time.Hour).Format(time.RFC3339), data.Balances[0].LastActivityAt)
+	assert.Equal(t, "Bob", data.Balances[1].DisplayName)
+	assert.Equal(t, -700, data.Balances[1].BalanceCents)
+	assert.Equal(t, 1500, data.OwedToYouCents)
+	assert.Equal(t, 700, data.YouOweCents)
+	assert.Equal(t, 800, data.NetCents)
+}
+
+func TestTallyLedger_Empty(t *testing.T) {
+	data := tallyLedger(uuid.New(), nil)
+	assert.NotNil(t, data.Balances)
+	assert.Empty(t, data.Balances)
+	assert.Zero(t, data.NetCents)
+}
@@ -0,0 +1,527 @@
+// services/ledger_service.go
+// LedgerService keeps the side-bet ledger between players: what each round's
+// side games (Vegas, skins, Nassau, ...) came to, and the settle-ups that
+// square those debts. As with the purse, no money moves through the app.
+//
+// The games themselves are scored client-side, so the server doesn't work out
+// who won what. Once a round is completed, its organizer posts a game's
+// results as player-to-player amounts; players in the round can read them but
+// not write debts onto each other. Re-posting a game replaces the results the
+// same organizer posted for it. Balances are never stored — they net from
+// every entry between a pair of players, across all their rounds and events.
+// A settle-up records that the one who owes paid the other; either player can
+// record it, and either can delete it if it didn't happen.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrRoundNotCompleted is returned when side-game results are posted for a
+	// round that hasn't finished.
+	ErrRoundNotCompleted = errors.New("round is not completed")
+	// ErrNothingToSettle is returned when settling up a balance that is already zero.
+	ErrNothingToSettle = errors.New("nothing to settle; the balance is zero")
+	// ErrLedgerEntryNotFound is returned when a settle-up doesn't exist or isn't
+	// between the caller and another player.
+	ErrLedgerEntryNotFound = errors.New("ledger entry not found")
+)
+
+const (
+	// maxSideBetCents caps a single result or settle-up ($10,000).
+	maxSideBetCents = 1_000_000
+	// maxResultLines caps the lines posted for one game in one round.
+	maxResultLines = 100
+	// maxLedgerNoteLen caps a ledger entry's note.
+	maxLedgerNoteLen = 200
+)
+
+// sideGames lists the accepted values for a result's game.
+var sideGames = []models.SideGame{
+	models.SideGameLasVegas, models.SideGameSkins, models.SideGameNassau, models.SideGameOther,
+}
+
+// ─── Inputs and DTOs ──────────────────────────────────────────────────────────
+
+// SideGameResultInput is one line of a game's results: From lost AmountCents to To.
+type SideGameResultInput struct {
+	FromUserID  uuid.UUID
+	ToUserID    uuid.UUID
+	AmountCents int
+	Note        *string
+}
+
+// LedgerEntryData is one ledger entry.
+type LedgerEntryData struct {
+	ID              string  `json:"id"`
+	Kind            string  `json:"kind"` // "result" or "settle_up"
+	RoundID         *string `json:"round_id"`
+	RoundName       *string `json:"round_name"`
+	Game            *string `json:"game"`
+	FromUserID      string  `json:"from_user_id"`
+	FromDisplayName string  `json:"from_display_name"`
+	ToUserID        string  `json:"to_user_id"`
+	ToDisplayName   string  `json:"to_display_name"`
+	AmountCents     int     `json:"amount_cents"`
+	Note            *string `json:"note"`
+	RecordedBy      string  `json:"recorded_by"`
+	CreatedAt       string  `json:"created_at"` // RFC 3339
+}
+
+// RoundSideGamesData is every side-game result posted for a round.
+type RoundSideGamesData struct {
+	RoundID string            `json:"round_id"`
+	Entries []LedgerEntryData `json:"entries"`
+}
+
+// LedgerBalanceData is the net balance with one other player. Positive means
+// they owe you; negative means you owe them.
+type LedgerBalanceData struct {
+	UserID         string `json:"user_id"`
+	DisplayName    string `json:"display_name"`
+	BalanceCents   int    `json:"balance_cents"`
+	LastActivityAt string `json:"last_activity_at"` // RFC 3339
+}
+
+// LedgerData is a player's ledger: every open balance, largest credit first.
+type LedgerData struct {
+	NetCents       int                 `json:"net_cents"`         // owed to you minus what you owe
+	OwedToYouCents int                 `json:"owed_to_you_cents"` // sum of positive balances
+	YouOweCents    int                 `json:"you_owe_cents"`     // sum of negative balances, as a positive number
+	Balances       []LedgerBalanceData `json:"balances"`
+}
+
+// PairLedgerData is the balance with one other player and the entries behind
+// it, newest first.
+type PairLedgerData struct {
+	UserID       string            `json:"user_id"`
+	DisplayName  string            `json:"display_name"`
+	BalanceCents int               `json:"balance_cents"` // positive = they owe you
+	Entries      []LedgerEntryData `json:"entries"`
+}
+
+// ─── Service ──────────────────────────────────────────────────────────────────
+
+// LedgerService owns side-game results and settle-ups.
+type LedgerService struct {
+	DB       *gorm.DB
+	RoundSvc *RoundService
+}
+
+// NewLedgerService constructs a LedgerService.
+func NewLedgerService(db *gorm.DB, roundSvc *RoundService) *LedgerService {
+	return &LedgerService{DB: db, RoundSvc: roundSvc}
+}
+
+// RoundSideGames returns the side-game results posted for a round. The caller
+// must be a player in the round or its organizer.
+func (s *LedgerService) RoundSideGames(ctx context.Context, roundID, callerID uuid.UUID, callerRole string) (*RoundSideGamesData, error) {
+	if _, err := s.requireRoundParticipant(ctx, roundID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	return s.loadRoundSideGames(ctx, roundID)
+}
+
+// PostSideGameResults replaces the results for one game in a round, whoever
+// posted them; an empty list clears them. Organizer-only (ErrRoundForbidden).
+// The round must be completed, and every player named must be in it.
+func (s *LedgerService) PostSideGameResults(ctx context.Context, roundID, callerID uuid.UUID, callerRole, game string, lines []SideGameResultInput) (*RoundSideGamesData, error) {
+	if err := validateSideGameResults(game, lines); err != nil {
+		return nil, err
+	}
+	var round models.Round
+	if err := s.DB.WithContext(ctx).First(&round, "id = ?", roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundNotFound
+		}
+		return nil, fmt.Errorf("load round: %w", err)
+	}
+	isOrg, err := s.RoundSvc.IsRoundOrganizer(ctx, roundID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if !isOrg {
+		return nil, ErrRoundForbidden
+	}
+	if round.Status != models.RoundStatusCompleted {
+		return nil, ErrRoundNotCompleted
+	}
+
+	var players []models.RoundPlayer
+	if err := s.DB.WithContext(ctx).Select("user_id").
+		Where("round_id = ?", roundID).Find(&players).Error; err != nil {
+		return nil, fmt.Errorf("load round players: %w", err)
+	}
+	inRound := make(map[uuid.UUID]bool, len(players))
+	for _, p := range players {
+		inRound[p.UserID] = true
+	}
+	for _, l := range lines {
+		if !inRound[l.FromUserID] || !inRound[l.ToUserID] {
+			return nil, ErrPlayerNotInRound
+		}
+	}
+
+	sideGame := models.SideGame(game)
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the round so two organizers posting the same game at once
+		// replace each other rather than both landing.
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").First(&models.Round{}, "id = ?", roundID).Error; err != nil {
+			return fmt.Errorf("lock round: %w", err)
+		}
+		if err := tx.Where("round_id = ? AND game = ? AND kind = ?",
+			roundID, sideGame, models.LedgerEntryKindResult).
+			Delete(&models.LedgerEntry{}).Error; err != nil {
+			return fmt.Errorf("replace results: %w", err)
+		}
+		for _, l := range lines {
+			entry := models.LedgerEntry{
+				Kind:        models.LedgerEntryKindResult,
+				RoundID:     &roundID,
+				Game:        &sideGame,
+				FromUserID:  l.FromUserID,
+				ToUserID:    l.ToUserID,
+				AmountCents: l.AmountCents,
+				Note:        l.Note,
+				RecordedBy:  callerID,
+			}
+			if err := tx.Omit(clause.Associations).Create(&entry).Error; err != nil {
+				return fmt.Errorf("record result: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.loadRoundSideGames(ctx, roundID)
+}
+
+// MyLedger returns the caller's open balances with every other player.
+func (s *LedgerService) MyLedger(ctx context.Context, userID uuid.UUID) (*LedgerData, error) {
+	var entries []models.LedgerEntry
+	if err := s.DB.WithContext(ctx).Preload("FromUser").Preload("ToUser").
+		Where("from_user_id = ? OR to_user_id = ?", userID, userID).
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("load ledger: %w", err)
+	}
+	return tallyLedger(userID, entries), nil
+}
+
+// PairLedger returns the caller's balance with one other player and the
+// entries behind it.
+func (s *LedgerService) PairLedger(ctx context.Context, userID, otherID uuid.UUID) (*PairLedgerData, error) {
+	if userID == otherID {
+		return nil, &ValidationError{Field: "user_id", Message: "pick another player"}
+	}
+	return s.loadPair(ctx, s.DB.WithContext(ctx), userID, otherID)
+}
+
+// SettleUp records that whoever owes on the pair's balance paid the other.
+// amountCents defaults to the whole balance and can't exceed it.
+func (s *LedgerService) SettleUp(ctx context.Context, userID, otherID uuid.UUID, amountCents *int, note *string) (*PairLedgerData, error) {
+	if userID == otherID {
+		return nil, &ValidationError{Field: "user_id", Message: "pick another player"}
+	}
+	if amountCents != nil && (*amountCents < 1 || *amountCents > maxSideBetCents) {
+		return nil, &ValidationError{Field: "amount_cents", Message: fmt.Sprintf("amount_cents must be between 1 and %d", maxSideBetCents)}
+	}
+	if err := validateLedgerNote(note); err != nil {
+		return nil, err
+	}
+
+	var pair *PairLedgerData
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock both users (in a fixed order) so two settle-ups can't each
+		// clear the same balance.
+		var users []models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			Where("id IN ?", []uuid.UUID{userID, otherID}).Order("id").
+			Find(&users).Error; err != nil {
+			return fmt.Errorf("lock users: %w", err)
+		}
+		if len(users) < 2 {
+			return ErrUserNotFound
+		}
+		current, err := s.loadPair(ctx, tx, userID, otherID)
+		if err != nil {
+			return err
+		}
+		balance := current.BalanceCents
+		if balance == 0 {
+			return ErrNothingToSettle
+		}
+		owed := abs(balance)
+		amount := owed
+		if amountCents != nil {
+			if *amountCents > owed {
+				return &ValidationError{Field: "amount_cents", Message: fmt.Sprintf("amount_cents can't exceed the balance of %d", owed)}
+			}
+			amount = *amountCents
+		}
+
+		entry := models.LedgerEntry{
+			Kind:        models.LedgerEntryKindSettleUp,
+			FromUserID:  userID,
+			ToUserID:    otherID,
+			AmountCents: amount,
+			Note:        note,
+			RecordedBy:  userID,
+		}
+		if balance > 0 { // they owe the caller, so they paid
+			entry.FromUserID, entry.ToUserID = otherID, userID
+		}
+		if err := tx.Omit(clause.Associations).Create(&entry).Error; err != nil {
+			return fmt.Errorf("record settle-up: %w", err)
+		}
+		pair, err = s.loadPair(ctx, tx, userID, otherID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// DeleteSettleUp removes a settle-up between the caller and another player —
+// a mistake by whoever recorded it, or a payment the other side disputes.
+// Side-game results are changed by re-posting the round's game instead.
+func (s *LedgerService) DeleteSettleUp(ctx context.Context, userID, entryID uuid.UUID) error {
+	res := s.DB.WithContext(ctx).
+		Where("id = ? AND kind = ? AND (from_user_id = ? OR to_user_id = ?)",
+			entryID, models.LedgerEntryKindSettleUp, userID, userID).
+		Delete(&models.LedgerEntry{})
+	if res.Error != nil {
+		return fmt.Errorf("delete settle-up: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrLedgerEntryNotFound
+	}
+	return nil
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// validateSideGameResults checks a game name and its result lines.
+func validateSideGameResults(game string, lines []SideGameResultInput) error {
+	known := false
+	for _, g := range sideGames {
+		if string(g) == game {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return &ValidationError{Field: "game", Message: "game must be 'las_vegas', 'skins', 'nassau' or 'other'"}
+	}
+	if len(lines) > maxResultLines {
+		return &ValidationError{Field: "results", Message: fmt.Sprintf("at most %d results per game", maxResultLines)}
+	}
+	for _, l := range lines {
+		if l.FromUserID == l.ToUserID {
+			return &ValidationError{Field: "results", Message: "a player can't owe themselves"}
+		}
+		if l.AmountCents < 1 || l.AmountCents > maxSideBetCents {
+			return &ValidationError{Field: "results", Message: fmt.Sprintf("amount_cents must be between 1 and %d", maxSideBetCents)}
+		}
+		if err := validateLedgerNote(l.Note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLedgerNote checks an optional note's length.
+func validateLedgerNote(note *string) error {
+	if note != nil && len(*note) > maxLedgerNoteLen {
+		return &ValidationError{Field: "note", Message: fmt.Sprintf("note must be at most %d characters", maxLedgerNoteLen)}
+	}
+	return nil
+}
+
+// balanceEffect is what an entry adds to me's balance with the other player
+// (positive = they owe me more). A result moves the balance toward the
+// winner; a settle-up moves it back toward the one who paid.
+func balanceEffect(me uuid.UUID, e models.LedgerEntry) int {
+	toMe := e.ToUserID == me
+	if (e.Kind == models.LedgerEntryKindResult) == toMe {
+		return e.AmountCents
+	}
+	return -e.AmountCents
+}
+
+// tallyLedger nets me's entries into one balance per other player, dropping
+// settled ones. entries must have FromUser and ToUser loaded.
+func tallyLedger(me uuid.UUID, entries []models.LedgerEntry) *LedgerData {
+	type tally struct {
+		name    string
+		balance int
+		last    time.Time
+	}
+	byUser := make(map[uuid.UUID]*tally)
+	for _, e := range entries {
+		other, name := e.FromUserID, e.FromUser.DisplayName
+		if other == me {
+			other, name = e.ToUserID, e.ToUser.DisplayName
+		}
+		t, ok := byUser[other]
+		if !ok {
+			t = &tally{name: name}
+			byUser[other] = t
+		}
+		t.balance += balanceEffect(me, e)
+		if e.CreatedAt.After(t.last) {
+			t.last = e.CreatedAt
+		}
+	}
+
+	data := &LedgerData{Balances: []LedgerBalanceData{}}
+	for id, t := range byUser {
+		if t.balance == 0 {
+			continue
+		}
+		data.Balances = append(data.Balances, LedgerBalanceData{
+			UserID:         id.String(),
+			DisplayName:    t.name,
+			BalanceCents:   t.balance,
+			LastActivityAt: t.last.UTC().Format(time.RFC3339),
+		})
+		data.NetCents += t.balance
+		if t.balance > 0 {
+			data.OwedToYouCents += t.balance
+		} else {
+			data.YouOweCents -= t.balance
+		}
+	}
+	sort.Slice(data.Balances, func(i, j int) bool {
+		a, b := data.Balances[i], data.Balances[j]
+		if a.BalanceCents != b.BalanceCents {
+			return a.BalanceCents > b.BalanceCents
+		}
+		return a.DisplayName < b.DisplayName
+	})
+	return data
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// buildLedgerEntryData converts an entry (FromUser, ToUser and Round loaded)
+// into its JSON shape.
+func buildLedgerEntryData(e models.LedgerEntry) LedgerEntryData {
+	out := LedgerEntryData{
+		ID:              e.ID.String(),
+		Kind:            string(e.Kind),
+		FromUserID:      e.FromUserID.String(),
+		FromDisplayName: e.FromUser.DisplayName,
+		ToUserID:        e.ToUserID.String(),
+		ToDisplayName:   e.ToUser.DisplayName,
+		AmountCents:     e.AmountCents,
+		Note:            e.Note,
+		RecordedBy:      e.RecordedBy.String(),
+		CreatedAt:       e.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if e.RoundID != nil {
+		id := e.RoundID.String()
+		out.RoundID = &id
+	}
+	if e.Round != nil {
+		out.RoundName = &e.Round.Name
+	}
+	if e.Game != nil {
+		g := string(*e.Game)
+		out.Game = &g
+	}
+	return out
+}
+
+// requireRoundParticipant loads the round and checks the caller is a player in
+// it or its organizer (ErrRoundForbidden otherwise).
+func (s *LedgerService) requireRoundParticipant(ctx context.Context, roundID, callerID uuid.UUID, callerRole string) (*models.Round, error) {
+	var round models.Round
+	if err := s.DB.WithContext(ctx).First(&round, "id = ?", roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundNotFound
+		}
+		return nil, fmt.Errorf("load round: %w", err)
+	}
+	var playing int64
+	if err := s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).
+		Where("round_id = ? AND user_id = ?", roundID, callerID).
+		Count(&playing).Error; err != nil {
+		return nil, fmt.Errorf("check round player: %w", err)
+	}
+	if playing > 0 {
+		return &round, nil
+	}
+	isOrg, err := s.RoundSvc.IsRoundOrganizer(ctx, roundID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if !isOrg {
+		return nil, ErrRoundForbidden
+	}
+	return &round, nil
+}
+
+// loadRoundSideGames loads a round's results, grouped by game.
+func (s *LedgerService) loadRoundSideGames(ctx context.Context, roundID uuid.UUID) (*RoundSideGamesData, error) {
+	var entries []models.LedgerEntry
+	if err := s.DB.WithContext(ctx).Preload("FromUser").Preload("ToUser").Preload("Round").
+		Where("round_id = ? AND kind = ?", roundID, models.LedgerEntryKindResult).
+		Order("game ASC, created_at ASC").
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("load side-game results: %w", err)
+	}
+	data := &RoundSideGamesData{RoundID: roundID.String(), Entries: make([]LedgerEntryData, len(entries))}
+	for i, e := range entries {
+		data.Entries[i] = buildLedgerEntryData(e)
+	}
+	return data, nil
+}
+
+// loadPair loads the balance and entries between two players on db (the
+// caller's transaction, when there is one).
+func (s *LedgerService) loadPair(ctx context.Context, db *gorm.DB, userID, otherID uuid.UUID) (*PairLedgerData, error) {
+	var other models.User
+	if err := db.WithContext(ctx).Select("id", "display_name").First(&other, "id = ?", otherID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("load user: %w", err)
+	}
+	var entries []models.LedgerEntry
+	if err := db.WithContext(ctx).Preload("FromUser").Preload("ToUser").Preload("Round").
+		Where("(from_user_id = ? AND to_user_id = ?) OR (from_user_id = ? AND to_user_id = ?)",
+			userID, otherID, otherID, userID).
+		Order("created_at DESC").
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("load ledger entries: %w", err)
+	}
+	pair := &PairLedgerData{
+		UserID:      other.ID.String(),
+		DisplayName: other.DisplayName,
+		Entries:     make([]LedgerEntryData, len(entries)),
+	}
+	for i, e := range entries {
+		pair.BalanceCents += balanceEffect(userID, e)
+		pair.Entries[i] = buildLedgerEntryData(e)
+	}
+	return pair, nil
+}
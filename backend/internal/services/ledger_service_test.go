@@ -0,0 +1,160 @@
+// services/ledger_service_test.go
+// Integration tests for LedgerService: posting a round's side-game results,
+// netting balances between players, and settling up. Docker must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run LedgerService -v
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// newLedgerSvc builds a LedgerService backed by the test DB.
+func newLedgerSvc(db *gorm.DB) *services.LedgerService {
+	return services.NewLedgerService(db, services.NewRoundService(db, services.NewEventService(db)))
+}
+
+// owes is a result line: from lost cents to to.
+func owes(from, to uuid.UUID, cents int) services.SideGameResultInput {
+	return services.SideGameResultInput{FromUserID: from, ToUserID: to, AmountCents: cents}
+}
+
+func TestLedgerService_PostResults_NetsAndReplaces(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLedgerSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	alice, bob := aliceRP.UserID, bobRP.UserID
+	org := organizerOf(t, db, event.ID)
+
+	_, err := svc.PostSideGameResults(ctx, roundID, org, "user", "skins", []services.SideGameResultInput{owes(alice, bob, 500)})
+	assert.ErrorIs(t, err, services.ErrRoundNotCompleted)
+
+	completeRound(t, db, roundID)
+	_, err = svc.PostSideGameResults(ctx, roundID, org, "user", "skins", []services.SideGameResultInput{owes(alice, bob, 800)})
+	require.NoError(t, err)
+	// Re-posting a game replaces its results.
+	_, err = svc.PostSideGameResults(ctx, roundID, org, "user", "skins", []services.SideGameResultInput{owes(alice, bob, 500)})
+	require.NoError(t, err)
+	data, err := svc.PostSideGameResults(ctx, roundID, org, "user", "nassau", []services.SideGameResultInput{owes(bob, alice, 300)})
+	require.NoError(t, err)
+	require.Len(t, data.Entries, 2)
+	assert.Equal(t, "nassau", *data.Entries[0].Game)
+	require.NotNil(t, data.Entries[0].RoundName)
+
+	ledger, err := svc.MyLedger(ctx, bob)
+	require.NoError(t, err)
+	require.Len(t, ledger.Balances, 1)
+	assert.Equal(t, alice.String(), ledger.Balances[0].UserID)
+	assert.Equal(t, 200, ledger.Balances[0].BalanceCents, "alice owes bob 500 - 300")
+
+	ledger, err = svc.MyLedger(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, -200, ledger.NetCents)
+	assert.Equal(t, 200, ledger.YouOweCents)
+}
+
+func TestLedgerService_PostResults_Permissions(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLedgerSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	completeRound(t, db, roundID)
+	stranger := seedUser(t, db, "ledgerStranger")
+
+	_, err := svc.RoundSideGames(ctx, roundID, stranger.ID, "user")
+	assert.ErrorIs(t, err, services.ErrRoundForbidden)
+
+	// Players can read the results but not post debts onto each other.
+	_, err = svc.PostSideGameResults(ctx, roundID, aliceRP.UserID, "user", "skins",
+		[]services.SideGameResultInput{owes(bobRP.UserID, aliceRP.UserID, 500)})
+	assert.ErrorIs(t, err, services.ErrRoundForbidden)
+
+	org := organizerOf(t, db, event.ID)
+	_, err = svc.PostSideGameResults(ctx, roundID, org, "user", "skins",
+		[]services.SideGameResultInput{owes(stranger.ID, aliceRP.UserID, 500)})
+	assert.ErrorIs(t, err, services.ErrPlayerNotInRound)
+
+	// The organizer isn't playing but posts for the group.
+	_, err = svc.PostSideGameResults(ctx, roundID, org, "user", "las_vegas",
+		[]services.SideGameResultInput{owes(aliceRP.UserID, bobRP.UserID, 1200)})
+	require.NoError(t, err)
+
+	// Another organizer re-posting the game replaces the first one's results,
+	// so balances don't count the game twice.
+	admin := seedAdmin(t, db)
+	data, err := svc.PostSideGameResults(ctx, roundID, admin.ID, "admin", "las_vegas",
+		[]services.SideGameResultInput{owes(aliceRP.UserID, bobRP.UserID, 900)})
+	require.NoError(t, err)
+	require.Len(t, data.Entries, 1)
+	assert.Equal(t, 900, data.Entries[0].AmountCents)
+	ledger, err := svc.MyLedger(ctx, bobRP.UserID)
+	require.NoError(t, err)
+	require.Len(t, ledger.Balances, 1)
+	assert.Equal(t, 900, ledger.Balances[0].BalanceCents)
+
+	_, err = svc.RoundSideGames(ctx, uuid.New(), aliceRP.UserID, "user")
+	assert.ErrorIs(t, err, services.ErrRoundNotFound)
+}
+
+func TestLedgerService_SettleUp(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newLedgerSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	alice, bob := aliceRP.UserID, bobRP.UserID
+	completeRound(t, db, roundID)
+	_, err := svc.PostSideGameResults(ctx, roundID, organizerOf(t, db, event.ID), "user", "skins",
+		[]services.SideGameResultInput{owes(alice, bob, 1000)})
+	require.NoError(t, err)
+
+	tooMuch := 1500
+	_, err = svc.SettleUp(ctx, bob, alice, &tooMuch, nil)
+	var ve *services.ValidationError
+	require.ErrorAs(t, err, &ve)
+
+	// Bob records alice paying half.
+	half := 500
+	pair, err := svc.SettleUp(ctx, bob, alice, &half, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 500, pair.BalanceCents)
+	require.Len(t, pair.Entries, 2)
+	assert.Equal(t, "settle_up", pair.Entries[0].Kind)
+	assert.Equal(t, alice.String(), pair.Entries[0].FromUserID, "the one who owes pays")
+	settleID := uuid.MustParse(pair.Entries[0].ID)
+
+	// Alice settles the rest from her side.
+	pair, err = svc.SettleUp(ctx, alice, bob, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, pair.BalanceCents)
+	_, err = svc.SettleUp(ctx, alice, bob, nil, nil)
+	assert.ErrorIs(t, err, services.ErrNothingToSettle)
+
+	ledger, err := svc.MyLedger(ctx, alice)
+	require.NoError(t, err)
+	assert.Empty(t, ledger.Balances, "settled balances drop off")
+
+	// Either party can remove a settle-up: alice disputes the one bob recorded.
+	stranger := seedUser(t, db, "ledgerBystander")
+	assert.ErrorIs(t, svc.DeleteSettleUp(ctx, stranger.ID, settleID), services.ErrLedgerEntryNotFound)
+	require.NoError(t, svc.DeleteSettleUp(ctx, alice, settleID))
+	pair, err = svc.PairLedger(ctx, bob, alice)
+	require.NoError(t, err)
+	assert.Equal(t, 500, pair.BalanceCents)
+
+	_, err = svc.SettleUp(ctx, alice, uuid.New(), nil, nil)
+	assert.ErrorIs(t, err, services.ErrUserNotFound)
+}
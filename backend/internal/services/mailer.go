@@ -0,0 +1,244 @@
+// services/mailer.go
+// Outgoing email. Mailer is the seam the digest job sends through:
+// SMTPMailer delivers through an SMTP relay in production, and LogMailer
+// writes each message to a directory (or the log) for local development, so
+// digests can be read without a mail server.
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// MailMessage is one email with plain-text and HTML bodies.
+type MailMessage struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	Headers map[string]string // extra headers, e.g. List-Unsubscribe
+}
+
+// Mailer sends email.
+type Mailer interface {
+	Send(ctx context.Context, msg MailMessage) error
+}
+
+// ─── SMTP ─────────────────────────────────────────────────────────────────────
+
+// smtpTimeout bounds one whole SMTP conversation, dial to QUIT, so a relay
+// that hangs can't stall the digest job.
+const smtpTimeout = 30 * time.Second
+
+// SMTPMailer sends mail through an SMTP relay, upgrading to TLS with STARTTLS
+// when the server offers it.
+type SMTPMailer struct {
+	addr     string // host:port
+	host     string
+	username string
+	password string
+	from     string
+	timeout  time.Duration // explicit timeout — never wait on an external server forever
+}
+
+// NewSMTPMailer returns a mailer for the relay at host:port. username may be
+// empty for relays that don't authenticate. from is the sender address, e.g.
+// "Golf League <digest@example.com>".
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		addr:     net.JoinHostPort(host, port),
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+		timeout:  smtpTimeout,
+	}
+}
+
+// Send implements Mailer. The conversation ends at ctx's deadline or after
+// smtpTimeout, whichever comes first; cancelling ctx aborts it.
+func (m *SMTPMailer) Send(ctx context.Context, msg MailMessage) error {
+	raw, err := buildMIMEMessage(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("parse from address: %w", err)
+	}
+
+	deadline := time.Now().Add(m.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	dialer := net.Dialer{Timeout: m.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return fmt.Errorf("smtp dial: %w", err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp deadline: %w", err)
+	}
+	// net/smtp takes no context: closing the connection is how a cancelled
+	// ctx interrupts a read or write in progress.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+	defer client.Close()
+	if err := m.deliver(client, sender.Address, msg.To, raw); err != nil {
+		return fmt.Errorf("smtp send: %w", err)
+	}
+	return nil
+}
+
+// deliver runs the SMTP conversation on an open client, as smtp.SendMail does.
+func (m *SMTPMailer) deliver(client *smtp.Client, from, to string, raw []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(raw); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// ─── File / log ───────────────────────────────────────────────────────────────
+
+// LogMailer is a Mailer for local development. With a directory it writes
+// each message there as an .eml file that any mail client opens; without one
+// it logs the recipient, subject and plain-text body.
+type LogMailer struct {
+	dir  string
+	from string
+}
+
+// NewLogMailer returns a LogMailer writing to dir ("" = log only).
+func NewLogMailer(dir, from string) *LogMailer {
+	return &LogMailer{dir: dir, from: from}
+}
+
+// unsafeFileChars matches characters kept out of .eml file names.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Send implements Mailer.
+func (m *LogMailer) Send(ctx context.Context, msg MailMessage) error {
+	if m.dir == "" {
+		slog.InfoContext(ctx, "Email (not sent: no mail server configured)",
+			"event_type_label", "mail.logged",
+			"to", msg.To,
+			"subject", msg.Subject,
+			"body", msg.Text,
+		)
+		return nil
+	}
+	now := time.Now()
+	raw, err := buildMIMEMessage(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("create mail dir: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+	if err := os.WriteFile(filepath.Join(m.dir, name), raw, 0o644); err != nil {
+		return fmt.Errorf("write mail file: %w", err)
+	}
+	return nil
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// buildMIMEMessage renders msg as a multipart/alternative message (text, then
+// HTML) ready to hand to an SMTP server.
+func buildMIMEMessage(from string, msg MailMessage, now time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", msg.Text},
+		{"text/html; charset=UTF-8", msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create mime part: %w", err)
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("encode mime part: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("encode mime part: %w", err)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("close mime message: %w", err)
+	}
+
+	headers := map[string]string{
+		"From":         from,
+		"To":           msg.To,
+		"Subject":      mime.QEncoding.Encode("UTF-8", msg.Subject),
+		"Date":         now.Format(time.RFC1123Z),
+		"MIME-Version": "1.0",
+		"Content-Type": "multipart/alternative; boundary=" + parts.Boundary(),
+	}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var out bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&out, "%s: %s\r\n", k, headers[k])
+	}
+	out.WriteString("\r\n")
+	out.Write(body.Bytes())
+	return out.Bytes(), nil
+}
@@ -0,0 +1,106 @@
+// services/mailer_test.go
+// Tests for LogMailer, the MIME message every Mailer sends, and SMTPMailer
+// giving up on a relay that hangs. No database or mail server needed.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'LogMailer|SMTPMailer' -v
+package services_test
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/services"
+)
+
+func TestLogMailer_WritesReadableEML(t *testing.T) {
+	dir := t.TempDir()
+	mailer := services.NewLogMailer(dir, "Golf League <digest@example.com>")
+	err := mailer.Send(context.Background(), services.MailMessage{
+		To:      "alice@example.com",
+		Subject: "Your week in golf — 3 results",
+		Text:    "Hi Alice,\nYou finished 1st.",
+		HTML:    "<p>Hi Alice,</p><p>You finished <strong>1st</strong>.</p>",
+		Headers: map[string]string{"List-Unsubscribe": "<https://api.example.com/digest/unsubscribe?token=abc>"},
+	})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Contains(t, files[0], "alice_example.com")
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+
+	msg, err := mail.ReadMessage(f)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", msg.Header.Get("To"))
+	assert.Equal(t, "<https://api.example.com/digest/unsubscribe?token=abc>", msg.Header.Get("List-Unsubscribe"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Your week in golf — 3 results", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	var bodies []string
+	for {
+		p, err := parts.NextPart() // decodes quoted-printable
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(p)
+		require.NoError(t, err)
+		bodies = append(bodies, string(b))
+	}
+	require.Len(t, bodies, 2)
+	assert.Equal(t, "Hi Alice,\r\nYou finished 1st.", bodies[0], "line breaks go out as CRLF")
+	assert.True(t, strings.HasPrefix(bodies[1], "<p>Hi Alice,</p>"))
+}
+
+func TestLogMailer_NoDir_LogsOnly(t *testing.T) {
+	mailer := services.NewLogMailer("", "Golf League <digest@example.com>")
+	assert.NoError(t, mailer.Send(context.Background(), services.MailMessage{To: "bob@example.com", Subject: "hi"}))
+}
+
+func TestSMTPMailer_HangingRelayStopsAtContextDeadline(t *testing.T) {
+	// A relay that accepts the connection but never sends its greeting.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	host, port, err := net.SplitHostPort(ln.Addr().String())
+	require.NoError(t, err)
+	m := services.NewSMTPMailer(host, port, "", "", "Golf League <digest@example.com>")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = m.Send(ctx, services.MailMessage{To: "player@example.com", Subject: "Digest", Text: "hi", HTML: "<p>hi</p>"})
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second, "the send gives up instead of waiting on the relay")
+}
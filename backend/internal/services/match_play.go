@@ -0,0 +1,112 @@
+// services/match_play.go
+// Hole-by-hole match play scoring shared by bracket matches (bracket_service.go)
+// and cup matches (cup_service.go). Matches are played on net: each hole goes
+// to the lower net score recorded on the scorecards, so handicap strokes are
+// those already applied to scores.net_score.
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+)
+
+// matchHoles returns the round's played hole numbers in order.
+func matchHoles(ctx context.Context, db *gorm.DB, roundID uuid.UUID) ([]int, error) {
+	var round models.Round
+	if err := db.WithContext(ctx).Preload("DefaultTee.Holes").First(&round, "id = ?", roundID).Error; err != nil {
+		return nil, fmt.Errorf("load round: %w", err)
+	}
+	played := filterPlayedHoles(round.DefaultTee.Holes, round.NineHoleSelection)
+	holes := make([]int, len(played))
+	for i, h := range played {
+		holes[i] = h.HoleNumber
+	}
+	sort.Ints(holes)
+	return holes, nil
+}
+
+// matchNetScores returns each event player's net score per hole in a round.
+// Players without a card in the round are absent from the map.
+func matchNetScores(ctx context.Context, db *gorm.DB, roundID uuid.UUID, eventPlayerIDs []uuid.UUID) (map[uuid.UUID]map[int]int, error) {
+	type netRow struct {
+		EventPlayerID uuid.UUID
+		HoleNumber    int
+		NetScore      int
+	}
+	var rows []netRow
+	if err := db.WithContext(ctx).Table("scores s").
+		Select("rp.event_player_id, s.hole_number, s.net_score").
+		Joins("JOIN round_players rp ON rp.id = s.round_player_id").
+		Where("rp.round_id = ? AND rp.event_player_id IN ?", roundID, eventPlayerIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load match scores: %w", err)
+	}
+	out := make(map[uuid.UUID]map[int]int, len(eventPlayerIDs))
+	for _, r := range rows {
+		if out[r.EventPlayerID] == nil {
+			out[r.EventPlayerID] = make(map[int]int)
+		}
+		out[r.EventPlayerID][r.HoleNumber] = r.NetScore
+	}
+	return out, nil
+}
+
+// bestBall combines a side's cards into one: on each hole, the lowest net
+// score any of them recorded.
+func bestBall(cards ...map[int]int) map[int]int {
+	out := make(map[int]int)
+	for _, card := range cards {
+		for h, net := range card {
+			if best, ok := out[h]; !ok || net < best {
+				out[h] = net
+			}
+		}
+	}
+	return out
+}
+
+// matchPlayResult plays out a match hole by hole. It returns the winning side
+// (1 or 2, or 0 when all square after the last hole) and the result — "3&2"
+// when the match closed early, "2 up" when it went the distance, "A/S" when
+// halved. ok is false when a side is missing a hole before the match was
+// decided.
+func matchPlayResult(holes []int, net1, net2 map[int]int) (winner int, result string, ok bool) {
+	lead := 0 // positive: side 1 up
+	for i, h := range holes {
+		s1, ok1 := net1[h]
+		s2, ok2 := net2[h]
+		if !ok1 || !ok2 {
+			return 0, "", false
+		}
+		switch {
+		case s1 < s2:
+			lead++
+		case s2 < s1:
+			lead--
+		}
+		remaining := len(holes) - i - 1
+		up := lead
+		if up < 0 {
+			up = -up
+		}
+		if up > remaining {
+			side := 1
+			if lead < 0 {
+				side = 2
+			}
+			if remaining == 0 {
+				return side, fmt.Sprintf("%d up", up), true
+			}
+			return side, fmt.Sprintf("%d&%d", up, remaining), true
+		}
+	}
+	if len(holes) == 0 {
+		return 0, "", false
+	}
+	return 0, "A/S", true
+}
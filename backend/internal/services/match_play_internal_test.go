@@ -0,0 +1,69 @@
+// services/match_play_internal_test.go
+// White-box tests for the unexported match play helpers in match_play.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestMatchPlay|TestBestBall' -v
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// holesThrough returns hole numbers 1..n.
+func holesThrough(n int) []int {
+	holes := make([]int, n)
+	for i := range holes {
+		holes[i] = i + 1
+	}
+	return holes
+}
+
+func TestMatchPlayResult_ClosesOutEarly(t *testing.T) {
+	net1, net2 := map[int]int{}, map[int]int{}
+	for _, h := range holesThrough(18) {
+		net1[h], net2[h] = 4, 4
+	}
+	for _, h := range []int{1, 2, 3} {
+		net1[h] = 3 // side 1 wins the first three holes, halves the rest
+	}
+	// Three up, then halves: the match ends at the 16th, when the lead first
+	// exceeds the holes left.
+	winner, result, ok := matchPlayResult(holesThrough(18), net1, net2)
+	assert.True(t, ok)
+	assert.Equal(t, 1, winner)
+	assert.Equal(t, "3&2", result)
+}
+
+func TestMatchPlayResult_WonOnLastHole(t *testing.T) {
+	net1 := map[int]int{1: 4, 2: 4, 3: 5}
+	net2 := map[int]int{1: 4, 2: 4, 3: 4}
+	winner, result, ok := matchPlayResult(holesThrough(3), net1, net2)
+	assert.True(t, ok)
+	assert.Equal(t, 2, winner)
+	assert.Equal(t, "1 up", result)
+}
+
+func TestMatchPlayResult_AllSquareIsHalved(t *testing.T) {
+	net1 := map[int]int{1: 3, 2: 5}
+	net2 := map[int]int{1: 4, 2: 4}
+	winner, result, ok := matchPlayResult(holesThrough(2), net1, net2)
+	assert.True(t, ok)
+	assert.Zero(t, winner)
+	assert.Equal(t, "A/S", result)
+}
+
+func TestMatchPlayResult_MissingHoleUndecided(t *testing.T) {
+	net1 := map[int]int{1: 3, 3: 4}
+	net2 := map[int]int{1: 4, 2: 4, 3: 4}
+	_, _, ok := matchPlayResult(holesThrough(3), net1, net2)
+	assert.False(t, ok)
+}
+
+func TestBestBall_LowestNetPerHole(t *testing.T) {
+	side := bestBall(map[int]int{1: 5, 2: 3}, map[int]int{1: 4, 3: 6})
+	assert.Equal(t, map[int]int{1: 4, 2: 3, 3: 6}, side)
+}
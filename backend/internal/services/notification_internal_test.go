@@ -0,0 +1,98 @@
+// services/notification_internal_test.go
+// White-box tests for the unexported notification helpers in notification_service.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestValidateNotificationPreferences|TestNotificationMessage|TestBuildNotificationData' -v
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+func TestValidateNotificationPreferences(t *testing.T) {
+	require.NoError(t, validateNotificationPreferences(nil))
+	require.NoError(t, validateNotificationPreferences(map[string]bool{"new_follower": false, "round_started": true}))
+
+	err := validateNotificationPreferences(map[string]bool{"new_follower": false, "spam": true})
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "preferences", ve.Field)
+	assert.Contains(t, ve.Message, "spam")
+}
+
+func TestNotificationMessage(t *testing.T) {
+	actor := &models.User{DisplayName: "Alice"}
+	event := &models.Event{Name: "Spring League"}
+	round := &models.Round{Name: "Week 3", Event: event}
+
+	cases := map[string]struct {
+		n    models.Notification
+		want string
+	}{
+		"join request": {
+			models.Notification{Type: models.NotificationTypeJoinRequest, Actor: actor, Event: event},
+			"Alice asked to join Spring League",
+		},
+		"round names its event": {
+			models.Notification{Type: models.NotificationTypeRoundStarted, Round: round},
+			"Spring League — Week 3 is underway",
+		},
+		"eventless round": {
+			models.Notification{Type: models.NotificationTypeRoundCompleted, Round: &models.Round{Name: "Saturday"}},
+			"Saturday is complete",
+		},
+		"deleted actor": {
+			models.Notification{Type: models.NotificationTypeNewFollower},
+			"Someone started following you",
+		},
+		"unknown type": {
+			models.Notification{Type: "something_new"},
+			"You have a new notification",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, notificationMessage(tc.n))
+		})
+	}
+}
+
+// Every known type has its own message rather than the fallback.
+func TestNotificationMessage_EveryTypeRendered(t *testing.T) {
+	for _, nt := range notificationTypes {
+		assert.NotEqual(t, "You have a new notification", notificationMessage(models.Notification{Type: nt}), nt)
+	}
+}
+
+func TestBuildNotificationData(t *testing.T) {
+	actorID, eventID := uuid.New(), uuid.New()
+	read := time.Now()
+	n := models.Notification{
+		ID:        uuid.New(),
+		Type:      models.NotificationTypeJoinApproved,
+		ActorID:   &actorID,
+		Actor:     &models.User{DisplayName: "Org"},
+		EventID:   &eventID,
+		Event:     &models.Event{Name: "Club Champs"},
+		ReadAt:    &read,
+		CreatedAt: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	got := buildNotificationData(n)
+	assert.Equal(t, "join_approved", got.Type)
+	assert.True(t, got.Read)
+	assert.Equal(t, "2026-05-01T12:00:00Z", got.CreatedAt)
+	require.NotNil(t, got.ActorName)
+	assert.Equal(t, "Org", *got.ActorName)
+	assert.Equal(t, eventID.String(), *got.EventID)
+	assert.Nil(t, got.RoundID)
+	assert.Contains(t, got.Message, "Club Champs")
+}
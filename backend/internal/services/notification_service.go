@@ -0,0 +1,380 @@
+// services/notification_service.go
+// NotificationService owns the in-app inbox: listing a user's notifications,
+// marking them read, and per-type preferences.
+//
+// Other services don't depend on NotificationService. They call notify after
+// their change has committed, with the recipients and what happened; notify
+// writes one row per recipient, skipping the actor, guests and anyone who has
+// turned the type off. It's best-effort: a failure is logged, never returned,
+// so a lost notification can't undo the change it reports.
+//
+// Rows store only references (actor, event, round). Messages are rendered when
+// listed, so a renamed event reads correctly in old notifications.
+//
+// notify also queues a push for each of the recipient's registered devices;
+// PushService (push_service.go) delivers the queue.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrNotificationNotFound is returned when a notification doesn't exist or
+// belongs to someone else.
+var ErrNotificationNotFound = errors.New("notification not found")
+
+const (
+	// defaultNotificationLimit is the page size when none is given.
+	defaultNotificationLimit = 30
+	// maxNotificationLimit caps the page size.
+	maxNotificationLimit = 100
+	// teeTimeReminderLead is how long before a group's tee time its players
+	// are reminded.
+	teeTimeReminderLead = time.Hour
+)
+
+// notificationTypes lists every type, in the order preferences are shown.
+var notificationTypes = []models.NotificationType{
+	models.NotificationTypeJoinRequest,
+	models.NotificationTypeJoinApproved,
+	models.NotificationTypeJoinDeclined,
+	models.NotificationTypeWaitlistJoined,
+	models.NotificationTypeWaitlistOffer,
+	models.NotificationTypeGroupAssigned,
+	models.NotificationTypeRoundStarted,
+	models.NotificationTypeRoundCompleted,
+	models.NotificationTypeNewFollower,
+	models.NotificationTypeResultsFinalized,
+	models.NotificationTypeTeeTimeSoon,
+}
+
+// ─── Inputs and DTOs ──────────────────────────────────────────────────────────
+
+// ListNotificationsInput filters and pages a user's inbox. Before is the
+// next_before cursor from the previous page.
+type ListNotificationsInput struct {
+	UnreadOnly bool
+	Limit      int
+	Before     *time.Time
+}
+
+// NotificationData is one inbox entry with its rendered message.
+type NotificationData struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	Message   string  `json:"message"`
+	ActorID   *string `json:"actor_id"`
+	ActorName *string `json:"actor_name"`
+	EventID   *string `json:"event_id"`
+	RoundID   *string `json:"round_id"`
+	Read      bool    `json:"read"`
+	CreatedAt string  `json:"created_at"` // RFC 3339
+}
+
+// NotificationPage is one page of the inbox, newest first. NextBefore is nil
+// on the last page.
+type NotificationPage struct {
+	UnreadCount   int64              `json:"unread_count"`
+	Notifications []NotificationData `json:"notifications"`
+	NextBefore    *string            `json:"next_before"` // RFC 3339 with nanoseconds
+}
+
+// ─── Service ──────────────────────────────────────────────────────────────────
+
+// NotificationService reads and manages a user's inbox.
+type NotificationService struct {
+	DB *gorm.DB
+}
+
+// NewNotificationService constructs a NotificationService.
+func NewNotificationService(db *gorm.DB) *NotificationService {
+	return &NotificationService{DB: db}
+}
+
+// List returns a page of the user's notifications, newest first.
+func (s *NotificationService) List(ctx context.Context, userID uuid.UUID, in ListNotificationsInput) (*NotificationPage, error) {
+	if in.Limit < 0 || in.Limit > maxNotificationLimit {
+		return nil, &ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 1 and %d", maxNotificationLimit)}
+	}
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultNotificationLimit
+	}
+
+	q := s.DB.WithContext(ctx).Preload("Actor").Preload("Event").Preload("Round").Preload("Round.Event").
+		Where("user_id = ?", userID)
+	if in.UnreadOnly {
+		q = q.Where("read_at IS NULL")
+	}
+	if in.Before != nil {
+		q = q.Where("created_at < ?", *in.Before)
+	}
+	var rows []models.Notification
+	if err := q.Order("created_at DESC").Limit(limit + 1).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list notifications: %w", err)
+	}
+
+	page := &NotificationPage{Notifications: []NotificationData{}}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		next := rows[limit-1].CreatedAt.UTC().Format(time.RFC3339Nano)
+		page.NextBefore = &next
+	}
+	for _, n := range rows {
+		page.Notifications = append(page.Notifications, buildNotificationData(n))
+	}
+	if err := s.DB.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&page.UnreadCount).Error; err != nil {
+		return nil, fmt.Errorf("count unread: %w", err)
+	}
+	return page, nil
+}
+
+// MarkRead marks one of the user's notifications read. Idempotent.
+func (s *NotificationService) MarkRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	var n models.Notification
+	if err := s.DB.WithContext(ctx).Select("id", "read_at").
+		First(&n, "id = ? AND user_id = ?", notificationID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotificationNotFound
+		}
+		return fmt.Errorf("load notification: %w", err)
+	}
+	if n.ReadAt != nil {
+		return nil
+	}
+	if err := s.DB.WithContext(ctx).Model(&n).Update("read_at", time.Now().UTC()).Error; err != nil {
+		return fmt.Errorf("mark read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllRead marks every unread notification read and returns how many
+// changed.
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	res := s.DB.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now().UTC())
+	if res.Error != nil {
+		return 0, fmt.Errorf("mark all read: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
+
+// Preferences returns every notification type and whether it's on.
+func (s *NotificationService) Preferences(ctx context.Context, userID uuid.UUID) (map[string]bool, error) {
+	var rows []models.NotificationPreference
+	if err := s.DB.WithContext(ctx).Where("user_id = ?", userID).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load notification preferences: %w", err)
+	}
+	out := make(map[string]bool, len(notificationTypes))
+	for _, t := range notificationTypes {
+		out[string(t)] = true
+	}
+	for _, r := range rows {
+		if _, known := out[string(r.Type)]; known {
+			out[string(r.Type)] = r.Enabled
+		}
+	}
+	return out, nil
+}
+
+// UpdatePreferences turns the given types on or off, leaving the rest alone,
+// and returns the full set.
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID uuid.UUID, prefs map[string]bool) (map[string]bool, error) {
+	if err := validateNotificationPreferences(prefs); err != nil {
+		return nil, err
+	}
+	if len(prefs) > 0 {
+		rows := make([]models.NotificationPreference, 0, len(prefs))
+		for t, enabled := range prefs {
+			rows = append(rows, models.NotificationPreference{UserID: userID, Type: models.NotificationType(t), Enabled: enabled})
+		}
+		if err := s.DB.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+		}).Create(&rows).Error; err != nil {
+			return nil, fmt.Errorf("save notification preferences: %w", err)
+		}
+	}
+	return s.Preferences(ctx, userID)
+}
+
+// ─── Emitting ─────────────────────────────────────────────────────────────────
+
+// SendTeeTimeReminders reminds the players in every group teeing off within
+// teeTimeReminderLead. Each player is reminded once per round, so a moved tee
+// time doesn't remind them again. Run periodically from main.go.
+func (s *NotificationService) SendTeeTimeReminders(ctx context.Context) error {
+	now := time.Now().UTC()
+	var groups []models.Group
+	if err := s.DB.WithContext(ctx).
+		Joins("JOIN rounds r ON r.id = groups.round_id").
+		Where("groups.tee_time > ? AND groups.tee_time <= ?", now, now.Add(teeTimeReminderLead)).
+		Where("r.status IN ?", []models.RoundStatus{models.RoundStatusScheduled, models.RoundStatusActive}).
+		Find(&groups).Error; err != nil {
+		return fmt.Errorf("find upcoming tee times: %w", err)
+	}
+	for _, g := range groups {
+		recipients := s.DB.Table("group_players gp").Select("rp.user_id").
+			Joins("JOIN round_players rp ON rp.id = gp.round_player_id").
+			Where("gp.group_id = ? AND rp.status NOT IN ?", g.ID, models.RoundPlayerOutStatuses).
+			Where("NOT EXISTS (SELECT 1 FROM notifications n WHERE n.user_id = rp.user_id AND n.round_id = ? AND n.type = ?)",
+				g.RoundID, models.NotificationTypeTeeTimeSoon)
+		notify(ctx, s.DB, models.Notification{Type: models.NotificationTypeTeeTimeSoon, RoundID: &g.RoundID}, recipients)
+	}
+	return nil
+}
+
+// notify writes n to each recipient's inbox and queues a push to each of
+// their devices. recipients is a []uuid.UUID or a subquery selecting user IDs.
+// The actor, guests and users who turned the type off are skipped. Call it
+// after the change has committed, on the service's DB rather than a
+// transaction: failures are only logged.
+func notify(ctx context.Context, db *gorm.DB, n models.Notification, recipients any) {
+	err := db.WithContext(ctx).Exec(`
+		WITH inserted AS (
+		    INSERT INTO notifications (user_id, type, actor_id, event_id, round_id)
+		    SELECT u.id, ?::text, ?::uuid, ?::uuid, ?::uuid
+		    FROM users u
+		    WHERE u.id IN (?)
+		      AND NOT u.is_guest
+		      AND u.id IS DISTINCT FROM ?::uuid
+		      AND NOT EXISTS (
+		          SELECT 1 FROM notification_preferences np
+		          WHERE np.user_id = u.id AND np.type = ?::text AND NOT np.enabled
+		      )
+		    RETURNING id, user_id
+		)
+		INSERT INTO push_outbox (device_token_id, notification_id)
+		SELECT dt.id, i.id FROM inserted i JOIN device_tokens dt ON dt.user_id = i.user_id`,
+		n.Type, n.ActorID, n.EventID, n.RoundID, recipients, n.ActorID, n.Type,
+	).Error
+	if err != nil {
+		slog.WarnContext(ctx, "Notification not written",
+			"event_type_label", "notification.failed",
+			"type", string(n.Type),
+			"error", err.Error(),
+		)
+	}
+}
+
+// eventOrganizerIDs is a subquery selecting an event's organizers, for notify.
+func eventOrganizerIDs(db *gorm.DB, eventID uuid.UUID) *gorm.DB {
+	return db.Model(&models.EventPlayer{}).Select("user_id").
+		Where("event_id = ? AND role = ?", eventID, models.EventPlayerRoleOrganizer)
+}
+
+// eventFieldIDs is a subquery selecting an event's registered and completed
+// members, for notify.
+func eventFieldIDs(db *gorm.DB, eventID uuid.UUID) *gorm.DB {
+	return db.Model(&models.EventPlayer{}).Select("user_id").
+		Where("event_id = ? AND status IN ?", eventID, flightedStatuses)
+}
+
+// roundPlayerIDs is a subquery selecting a round's players, for notify.
+func roundPlayerIDs(db *gorm.DB, roundID uuid.UUID) *gorm.DB {
+	return db.Model(&models.RoundPlayer{}).Select("user_id").Where("round_id = ?", roundID)
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// validateNotificationPreferences rejects unknown types.
+func validateNotificationPreferences(prefs map[string]bool) error {
+	for t := range prefs {
+		known := false
+		for _, nt := range notificationTypes {
+			if string(nt) == t {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return &ValidationError{Field: "preferences", Message: fmt.Sprintf("unknown notification type %q", t)}
+		}
+	}
+	return nil
+}
+
+// buildNotificationData converts a notification (Actor, Event, Round and
+// Round.Event loaded) into its JSON shape.
+func buildNotificationData(n models.Notification) NotificationData {
+	out := NotificationData{
+		ID:        n.ID.String(),
+		Type:      string(n.Type),
+		Message:   notificationMessage(n),
+		Read:      n.ReadAt != nil,
+		CreatedAt: n.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if n.ActorID != nil {
+		id := n.ActorID.String()
+		out.ActorID = &id
+	}
+	if n.Actor != nil {
+		out.ActorName = &n.Actor.DisplayName
+	}
+	if n.EventID != nil {
+		id := n.EventID.String()
+		out.EventID = &id
+	}
+	if n.RoundID != nil {
+		id := n.RoundID.String()
+		out.RoundID = &id
+	}
+	return out
+}
+
+// notificationMessage renders the inbox text for n.
+func notificationMessage(n models.Notification) string {
+	actor := "Someone"
+	if n.Actor != nil {
+		actor = n.Actor.DisplayName
+	}
+	event := "an event"
+	if n.Event != nil {
+		event = n.Event.Name
+	}
+	round := "a round"
+	if n.Round != nil {
+		round = n.Round.Name
+		if n.Round.Event != nil {
+			round = n.Round.Event.Name + " — " + n.Round.Name
+		}
+	}
+
+	switch n.Type {
+	case models.NotificationTypeJoinRequest:
+		return actor + " asked to join " + event
+	case models.NotificationTypeJoinApproved:
+		return "You're in: your request to join " + event + " was approved"
+	case models.NotificationTypeJoinDeclined:
+		return "Your request to join " + event + " was declined"
+	case models.NotificationTypeWaitlistJoined:
+		return "A spot opened up: you're now registered for " + event
+	case models.NotificationTypeWaitlistOffer:
+		return "A spot opened up in " + event + ". Accept it before the offer expires"
+	case models.NotificationTypeGroupAssigned:
+		return actor + " put you in a group for " + round
+	case models.NotificationTypeRoundStarted:
+		return round + " is underway"
+	case models.NotificationTypeRoundCompleted:
+		return round + " is complete"
+	case models.NotificationTypeNewFollower:
+		return actor + " started following you"
+	case models.NotificationTypeResultsFinalized:
+		return "Final results are in for " + event
+	case models.NotificationTypeTeeTimeSoon:
+		return "You tee off within the hour: " + round
+	}
+	return "You have a new notification"
+}
@@ -0,0 +1,133 @@
+// services/notification_service_test.go
+// Integration tests for NotificationService and the notifications written by
+// the other services as things happen. Docker must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run NotificationService -v
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// openLeague creates a public league owned by creator; joining it needs approval.
+func openLeague(t *testing.T, svc *services.EventService, creatorID uuid.UUID) uuid.UUID {
+	t.Helper()
+	event, err := svc.Create(context.Background(), services.CreateEventInput{
+		Name: "Open League", EventType: "league", IsPublic: true, CreatedBy: creatorID,
+	})
+	require.NoError(t, err)
+	return event.Event.ID
+}
+
+// inboxTypes returns the types in userID's inbox, newest first.
+func inboxTypes(t *testing.T, db *gorm.DB, userID uuid.UUID) []string {
+	t.Helper()
+	page, err := services.NewNotificationService(db).List(context.Background(), userID, services.ListNotificationsInput{})
+	require.NoError(t, err)
+	out := make([]string, len(page.Notifications))
+	for i, n := range page.Notifications {
+		out[i] = n.Type
+	}
+	return out
+}
+
+func TestNotificationService_JoinRequest_NotifiesBothSides(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	eventSvc := services.NewEventService(db)
+	ctx := context.Background()
+	creator := seedUser(t, db, "creator")
+	requester := seedUser(t, db, "requester")
+	eventID := openLeague(t, eventSvc, creator.ID)
+
+	_, err := eventSvc.RequestJoin(ctx, eventID, requester.ID)
+	require.NoError(t, err)
+	page, err := services.NewNotificationService(db).List(ctx, creator.ID, services.ListNotificationsInput{})
+	require.NoError(t, err)
+	require.Len(t, page.Notifications, 1)
+	got := page.Notifications[0]
+	assert.Equal(t, "join_request", got.Type)
+	assert.Equal(t, requester.ID.String(), *got.ActorID)
+	assert.Contains(t, got.Message, "Open League")
+	assert.EqualValues(t, 1, page.UnreadCount)
+
+	require.NoError(t, eventSvc.HandleJoinRequest(ctx, eventID, creator.ID, "user", requester.ID, true))
+	assert.Equal(t, []string{"join_approved"}, inboxTypes(t, db, requester.ID))
+}
+
+func TestNotificationService_DisabledType_Suppressed(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewNotificationService(db)
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+	carol := seedUser(t, db, "carol")
+	userSvc := services.NewUserService(db)
+
+	prefs, err := svc.UpdatePreferences(ctx, alice.ID, map[string]bool{"new_follower": false})
+	require.NoError(t, err)
+	assert.False(t, prefs["new_follower"])
+	assert.True(t, prefs["join_request"], "types never set default to on")
+
+	require.NoError(t, userSvc.FollowUser(ctx, bob.ID, alice.ID))
+	assert.Empty(t, inboxTypes(t, db, alice.ID))
+
+	_, err = svc.UpdatePreferences(ctx, alice.ID, map[string]bool{"new_follower": true})
+	require.NoError(t, err)
+	require.NoError(t, userSvc.FollowUser(ctx, carol.ID, alice.ID))
+	assert.Equal(t, []string{"new_follower"}, inboxTypes(t, db, alice.ID))
+}
+
+func TestNotificationService_ListPagesAndMarksRead(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewNotificationService(db)
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+	for i := 0; i < 3; i++ {
+		require.NoError(t, db.Create(&models.Notification{UserID: alice.ID, Type: models.NotificationTypeNewFollower, ActorID: &bob.ID}).Error)
+	}
+
+	first, err := svc.List(ctx, alice.ID, services.ListNotificationsInput{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Notifications, 2)
+	require.NotNil(t, first.NextBefore)
+	before, err := time.Parse(time.RFC3339Nano, *first.NextBefore)
+	require.NoError(t, err)
+	second, err := svc.List(ctx, alice.ID, services.ListNotificationsInput{Limit: 2, Before: &before})
+	require.NoError(t, err)
+	require.Len(t, second.Notifications, 1)
+	assert.Nil(t, second.NextBefore)
+
+	id := uuid.MustParse(first.Notifications[0].ID)
+	require.NoError(t, svc.MarkRead(ctx, alice.ID, id))
+	require.NoError(t, svc.MarkRead(ctx, alice.ID, id), "marking twice is a no-op")
+	assert.ErrorIs(t, svc.MarkRead(ctx, bob.ID, id), services.ErrNotificationNotFound)
+
+	unread, err := svc.List(ctx, alice.ID, services.ListNotificationsInput{UnreadOnly: true})
+	require.NoError(t, err)
+	assert.Len(t, unread.Notifications, 2)
+	assert.EqualValues(t, 2, unread.UnreadCount)
+
+	marked, err := svc.MarkAllRead(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, marked)
+	page, err := svc.List(ctx, alice.ID, services.ListNotificationsInput{})
+	require.NoError(t, err)
+	assert.Zero(t, page.UnreadCount)
+	assert.True(t, page.Notifications[2].Read)
+}
@@ -0,0 +1,385 @@
+// services/privacy_service.go
+// PrivacyService owns each user's privacy settings and the users they've
+// blocked. The helpers below the service apply them for the rest of the
+// package: loadProfileAccess decides what one caller may see of one user
+// (profile, stats, scorecards), and notBlockedSQL / feedAuthorIDs filter
+// search and the activity feed.
+//
+// Rules, for a caller looking at someone else:
+//   - A block in either direction hides the user entirely: search skips them,
+//     their profile reads as not found, and neither can follow the other.
+//   - profile_visibility gates the profile's counts, rounds, stats, scorecards
+//     and feed items: public to anyone, followers to people who follow them,
+//     private to no one.
+//   - hide_stats additionally keeps stats, scorecards and the scores in feed
+//     items to the user themself.
+//   - hide_email leaves the email off search results, member lists and round
+//     groups, and stops search matching it; hide_from_search keeps the user
+//     out of search unless the query is their exact (visible) email.
+//
+// Rounds are shared: players and organizers of a round always see each other's
+// cards on its scorecard (ScoreService.GetScorecard).
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrProfilePrivate is returned when the user's profile visibility doesn't
+	// include the caller.
+	ErrProfilePrivate = errors.New("this profile is private")
+	// ErrStatsHidden is returned when the user keeps their stats to themself.
+	ErrStatsHidden = errors.New("this player's stats are private")
+	// ErrBlockSelf is returned when a user tries to block themself.
+	ErrBlockSelf = errors.New("cannot block yourself")
+)
+
+// ─── Inputs and DTOs ──────────────────────────────────────────────────────────
+
+// PrivacySettingsData is a user's privacy settings.
+type PrivacySettingsData struct {
+	ProfileVisibility string `json:"profile_visibility"` // public | followers | private
+	HideEmail         bool   `json:"hide_email"`
+	HideStats         bool   `json:"hide_stats"`
+	HideFromSearch    bool   `json:"hide_from_search"`
+}
+
+// PrivacySettingsInput changes privacy settings; nil fields are left as they
+// are.
+type PrivacySettingsInput struct {
+	ProfileVisibility *string `json:"profile_visibility"`
+	HideEmail         *bool   `json:"hide_email"`
+	HideStats         *bool   `json:"hide_stats"`
+	HideFromSearch    *bool   `json:"hide_from_search"`
+}
+
+// BlockedUserData is one user the caller has blocked.
+type BlockedUserData struct {
+	ID          string  `json:"id"`
+	DisplayName string  `json:"display_name"`
+	AvatarURL   *string `json:"avatar_url,omitempty"`
+	BlockedAt   string  `json:"blocked_at"` // RFC 3339
+}
+
+// ─── Service ──────────────────────────────────────────────────────────────────
+
+// PrivacyService owns privacy settings and blocks.
+type PrivacyService struct {
+	DB *gorm.DB
+}
+
+// NewPrivacyService constructs a PrivacyService.
+func NewPrivacyService(db *gorm.DB) *PrivacyService {
+	return &PrivacyService{DB: db}
+}
+
+// GetSettings returns the user's privacy settings, or the defaults if they've
+// never changed them.
+func (s *PrivacyService) GetSettings(ctx context.Context, userID uuid.UUID) (*PrivacySettingsData, error) {
+	row, err := loadPrivacySettings(ctx, s.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	data := toPrivacyData(row)
+	return &data, nil
+}
+
+// UpdateSettings applies the non-nil fields of in to the user's settings.
+func (s *PrivacyService) UpdateSettings(ctx context.Context, userID uuid.UUID, in PrivacySettingsInput) (*PrivacySettingsData, error) {
+	if in.ProfileVisibility != nil {
+		switch models.ProfileVisibility(*in.ProfileVisibility) {
+		case models.ProfileVisibilityPublic, models.ProfileVisibilityFollowers, models.ProfileVisibilityPrivate:
+		default:
+			return nil, &ValidationError{Field: "profile_visibility", Message: "profile_visibility must be public, followers or private"}
+		}
+	}
+	row, err := loadPrivacySettings(ctx, s.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	if in.ProfileVisibility != nil {
+		row.ProfileVisibility = models.ProfileVisibility(*in.ProfileVisibility)
+	}
+	if in.HideEmail != nil {
+		row.HideEmail = *in.HideEmail
+	}
+	if in.HideStats != nil {
+		row.HideStats = *in.HideStats
+	}
+	if in.HideFromSearch != nil {
+		row.HideFromSearch = *in.HideFromSearch
+	}
+	// Upsert every column explicitly: GORM's Create skips false booleans and
+	// would let the column defaults win.
+	row.UpdatedAt = time.Now().UTC()
+	if err := s.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"profile_visibility", "hide_email", "hide_stats", "hide_from_search", "updated_at"}),
+	}).Select("*").Create(&row).Error; err != nil {
+		return nil, fmt.Errorf("save privacy settings: %w", err)
+	}
+	data := toPrivacyData(row)
+	return &data, nil
+}
+
+// Block blocks targetID for the caller and removes any follow between them.
+// Blocking someone already blocked is a no-op.
+func (s *PrivacyService) Block(ctx context.Context, callerID, targetID uuid.UUID) error {
+	if callerID == targetID {
+		return ErrBlockSelf
+	}
+	var exists int64
+	if err := s.DB.WithContext(ctx).Model(&models.User{}).Where("id = ?", targetID).Count(&exists).Error; err != nil {
+		return fmt.Errorf("load user: %w", err)
+	}
+	if exists == 0 {
+		return ErrUserNotFound
+	}
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.UserBlock{BlockerID: callerID, BlockedID: targetID}).Error; err != nil {
+			return fmt.Errorf("create block: %w", err)
+		}
+		if err := tx.Where("(follower_id = ? AND followee_id = ?) OR (follower_id = ? AND followee_id = ?)",
+			callerID, targetID, targetID, callerID).Delete(&models.Follow{}).Error; err != nil {
+			return fmt.Errorf("remove follows: %w", err)
+		}
+		return nil
+	})
+}
+
+// Unblock lifts the caller's block on targetID. Idempotent.
+func (s *PrivacyService) Unblock(ctx context.Context, callerID, targetID uuid.UUID) error {
+	if err := s.DB.WithContext(ctx).
+		Delete(&models.UserBlock{}, "blocker_id = ? AND blocked_id = ?", callerID, targetID).Error; err != nil {
+		return fmt.Errorf("delete block: %w", err)
+	}
+	return nil
+}
+
+// ListBlocked returns the users the caller has blocked, most recent first.
+func (s *PrivacyService) ListBlocked(ctx context.Context, callerID uuid.UUID) ([]BlockedUserData, error) {
+	var rows []models.UserBlock
+	if err := s.DB.WithContext(ctx).Preload("Blocked").
+		Where("blocker_id = ?", callerID).
+		Order("created_at DESC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list blocks: %w", err)
+	}
+	out := make([]BlockedUserData, 0, len(rows))
+	for _, b := range rows {
+		out = append(out, BlockedUserData{
+			ID:          b.BlockedID.String(),
+			DisplayName: b.Blocked.DisplayName,
+			AvatarURL:   b.Blocked.AvatarURL,
+			BlockedAt:   b.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return out, nil
+}
+
+// ─── Access checks ────────────────────────────────────────────────────────────
+
+// profileAccess is what one caller may see of one user.
+type profileAccess struct {
+	Blocked bool // a block in either direction; nothing is visible
+	Profile bool // counts, rounds and feed items
+	Stats   bool // stats and scorecards
+}
+
+// loadProfileAccess works out what callerID may see of targetID.
+func loadProfileAccess(ctx context.Context, db *gorm.DB, callerID, targetID uuid.UUID) (profileAccess, error) {
+	access, err := loadProfileAccesses(ctx, db, callerID, []uuid.UUID{targetID})
+	if err != nil {
+		return profileAccess{}, err
+	}
+	return access[targetID], nil
+}
+
+// loadProfileAccesses works out what callerID may see of each of targetIDs,
+// with one query each for blocks, settings and follows however many there are.
+func loadProfileAccesses(ctx context.Context, db *gorm.DB, callerID uuid.UUID, targetIDs []uuid.UUID) (map[uuid.UUID]profileAccess, error) {
+	out := make(map[uuid.UUID]profileAccess, len(targetIDs))
+	others := make([]uuid.UUID, 0, len(targetIDs))
+	for _, id := range targetIDs {
+		if id == callerID {
+			out[id] = profileAccess{Profile: true, Stats: true}
+			continue
+		}
+		others = append(others, id)
+	}
+	if len(others) == 0 {
+		return out, nil
+	}
+
+	type blockRow struct{ BlockerID, BlockedID uuid.UUID }
+	var blocks []blockRow
+	if err := db.WithContext(ctx).Model(&models.UserBlock{}).
+		Select("blocker_id, blocked_id").
+		Where("(blocker_id = ? AND blocked_id IN ?) OR (blocked_id = ? AND blocker_id IN ?)",
+			callerID, others, callerID, others).
+		Scan(&blocks).Error; err != nil {
+		return nil, fmt.Errorf("check blocks: %w", err)
+	}
+	blocked := make(map[uuid.UUID]bool, len(blocks))
+	for _, b := range blocks {
+		blocked[b.BlockerID], blocked[b.BlockedID] = true, true
+	}
+
+	var rows []models.UserPrivacySettings
+	if err := db.WithContext(ctx).Where("user_id IN ?", others).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load privacy settings: %w", err)
+	}
+	settings := make(map[uuid.UUID]models.UserPrivacySettings, len(rows))
+	for _, r := range rows {
+		settings[r.UserID] = r
+	}
+
+	var followed []uuid.UUID
+	if err := db.WithContext(ctx).Model(&models.Follow{}).
+		Where("follower_id = ? AND followee_id IN ?", callerID, others).
+		Pluck("followee_id", &followed).Error; err != nil {
+		return nil, fmt.Errorf("check follow: %w", err)
+	}
+	follows := make(map[uuid.UUID]bool, len(followed))
+	for _, id := range followed {
+		follows[id] = true
+	}
+
+	for _, id := range others {
+		if blocked[id] {
+			out[id] = profileAccess{Blocked: true}
+			continue
+		}
+		row, ok := settings[id]
+		if !ok {
+			row = models.UserPrivacySettings{UserID: id, ProfileVisibility: models.ProfileVisibilityPublic}
+		}
+		access := profileAccess{}
+		switch row.ProfileVisibility {
+		case models.ProfileVisibilityPrivate:
+		case models.ProfileVisibilityFollowers:
+			access.Profile = follows[id]
+		default:
+			access.Profile = true
+		}
+		access.Stats = access.Profile && !row.HideStats
+		out[id] = access
+	}
+	return out, nil
+}
+
+// requireProfileAccess returns ErrUserNotFound (blocked) or ErrProfilePrivate
+// unless callerID may see targetID's profile, and with wantStats also
+// ErrStatsHidden unless they may see their stats.
+func requireProfileAccess(ctx context.Context, db *gorm.DB, callerID, targetID uuid.UUID, wantStats bool) error {
+	access, err := loadProfileAccess(ctx, db, callerID, targetID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case access.Blocked:
+		return ErrUserNotFound
+	case !access.Profile:
+		return ErrProfilePrivate
+	case wantStats && !access.Stats:
+		return ErrStatsHidden
+	}
+	return nil
+}
+
+// loadPrivacySettings returns the user's settings row, or the defaults when
+// they have none.
+func loadPrivacySettings(ctx context.Context, db *gorm.DB, userID uuid.UUID) (models.UserPrivacySettings, error) {
+	var row models.UserPrivacySettings
+	if err := db.WithContext(ctx).First(&row, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.UserPrivacySettings{UserID: userID, ProfileVisibility: models.ProfileVisibilityPublic}, nil
+		}
+		return models.UserPrivacySettings{}, fmt.Errorf("load privacy settings: %w", err)
+	}
+	return row, nil
+}
+
+// notBlockedSQL is a WHERE condition matching rows whose user column (e.g.
+// "users.id") has no block with the caller in either direction. Bind the
+// caller's ID twice.
+func notBlockedSQL(col string) string {
+	return "NOT EXISTS (SELECT 1 FROM user_blocks ub WHERE (ub.blocker_id = ? AND ub.blocked_id = " + col +
+		") OR (ub.blocker_id = " + col + " AND ub.blocked_id = ?))"
+}
+
+// emailHiddenSQL is a WHERE condition matching rows whose user column (e.g.
+// "users.id") hides their email from the caller. Everyone sees their own.
+// Bind the caller's ID once.
+func emailHiddenSQL(col string) string {
+	return col + " <> ? AND EXISTS (SELECT 1 FROM user_privacy_settings ps WHERE ps.user_id = " + col +
+		" AND ps.hide_email)"
+}
+
+// visibleEmailSQL selects the email of the user in col as "email", NULL when
+// they hide it from the caller. Bind the caller's ID once.
+func visibleEmailSQL(col, emailCol string) string {
+	return "CASE WHEN " + emailHiddenSQL(col) + " THEN NULL ELSE " + emailCol + " END AS email"
+}
+
+// hiddenEmails returns which of userIDs hide their email from callerID.
+func hiddenEmails(ctx context.Context, db *gorm.DB, callerID uuid.UUID, userIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	if len(userIDs) == 0 {
+		return map[uuid.UUID]bool{}, nil
+	}
+	var ids []uuid.UUID
+	if err := db.WithContext(ctx).Model(&models.User{}).
+		Where("users.id IN ?", userIDs).
+		Where(emailHiddenSQL("users.id"), callerID).
+		Pluck("users.id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("check hidden emails: %w", err)
+	}
+	out := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		out[id] = true
+	}
+	return out, nil
+}
+
+// withholdMemberEmails marks the members whose email is hidden from callerID.
+func withholdMemberEmails(ctx context.Context, db *gorm.DB, callerID uuid.UUID, items []EventMemberItem) error {
+	ids := make([]uuid.UUID, len(items))
+	for i, m := range items {
+		ids[i] = m.User.ID
+	}
+	hidden, err := hiddenEmails(ctx, db, callerID, ids)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].EmailHidden = hidden[items[i].User.ID]
+	}
+	return nil
+}
+
+// statsHiddenUserIDs is a subquery selecting users who keep their stats to
+// themselves.
+func statsHiddenUserIDs(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.UserPrivacySettings{}).Select("user_id").Where("hide_stats")
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+func toPrivacyData(row models.UserPrivacySettings) PrivacySettingsData {
+	return PrivacySettingsData{
+		ProfileVisibility: string(row.ProfileVisibility),
+		HideEmail:         row.HideEmail,
+		HideStats:         row.HideStats,
+		HideFromSearch:    row.HideFromSearch,
+	}
+}
@@ -0,0 +1,245 @@
+// services/privacy_service_test.go
+// Integration tests for PrivacyService and how privacy settings and blocks are
+// enforced in search, profiles, stats, scorecards and the feed. Docker must be
+// running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run PrivacyService -v
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestPrivacyService_Search(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewPrivacyService(db)
+	users := services.NewUserService(db)
+	ctx := context.Background()
+	caller := seedUser(t, db, "searcher")
+	quiet := seedUser(t, db, "quietgolfer")
+	hidden := seedUser(t, db, "hiddengolfer")
+	blocked := seedUser(t, db, "blockedgolfer")
+
+	_, err := svc.UpdateSettings(ctx, quiet.ID, services.PrivacySettingsInput{HideEmail: boolPtr(true)})
+	require.NoError(t, err)
+	_, err = svc.UpdateSettings(ctx, hidden.ID, services.PrivacySettingsInput{HideFromSearch: boolPtr(true)})
+	require.NoError(t, err)
+	require.NoError(t, svc.Block(ctx, blocked.ID, caller.ID))
+
+	results, err := users.SearchUsers(ctx, caller.ID, "")
+	require.NoError(t, err)
+	require.Len(t, results, 1, "hidden and blocking users are left out")
+	assert.Equal(t, quiet.ID.String(), results[0].ID)
+	assert.Nil(t, results[0].Email)
+
+	results, err = users.SearchUsers(ctx, caller.ID, "quietgolfer@test")
+	require.NoError(t, err)
+	assert.Empty(t, results, "a hidden email isn't matched in part")
+
+	results, err = users.SearchUsers(ctx, caller.ID, hidden.Email)
+	require.NoError(t, err)
+	require.Len(t, results, 1, "an exact email still finds a hidden user")
+	assert.Equal(t, hidden.Email, *results[0].Email)
+}
+
+func TestPrivacyService_ProfileAndStats(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewPrivacyService(db)
+	users := services.NewUserService(db)
+	ctx := context.Background()
+	target := seedUser(t, db, "privTarget")
+	fan := seedUser(t, db, "privFan")
+	stranger := seedUser(t, db, "privStranger")
+	require.NoError(t, users.FollowUser(ctx, fan.ID, target.ID))
+
+	followers := string(models.ProfileVisibilityFollowers)
+	settings, err := svc.UpdateSettings(ctx, target.ID, services.PrivacySettingsInput{ProfileVisibility: &followers})
+	require.NoError(t, err)
+	assert.Equal(t, followers, settings.ProfileVisibility)
+	assert.False(t, settings.HideStats)
+
+	profile, err := users.GetUserProfile(ctx, stranger.ID, target.ID)
+	require.NoError(t, err)
+	assert.True(t, profile.IsRestricted)
+	assert.True(t, profile.StatsHidden)
+	_, err = users.GetUserStats(ctx, stranger.ID, target.ID, "all_time")
+	assert.ErrorIs(t, err, services.ErrProfilePrivate)
+	_, err = users.GetUserRounds(ctx, stranger.ID, target.ID)
+	assert.ErrorIs(t, err, services.ErrProfilePrivate)
+
+	profile, err = users.GetUserProfile(ctx, fan.ID, target.ID)
+	require.NoError(t, err)
+	assert.False(t, profile.IsRestricted)
+	_, err = users.GetUserStats(ctx, fan.ID, target.ID, "all_time")
+	require.NoError(t, err)
+
+	_, err = svc.UpdateSettings(ctx, target.ID, services.PrivacySettingsInput{HideStats: boolPtr(true)})
+	require.NoError(t, err)
+	_, err = users.GetUserStats(ctx, fan.ID, target.ID, "all_time")
+	assert.ErrorIs(t, err, services.ErrStatsHidden)
+	_, err = users.GetUserStats(ctx, target.ID, target.ID, "all_time")
+	require.NoError(t, err, "you always see your own stats")
+	settings, err = svc.GetSettings(ctx, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, followers, settings.ProfileVisibility, "a partial update keeps the other settings")
+}
+
+func TestPrivacyService_BlockRemovesFollowsAndHidesProfile(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewPrivacyService(db)
+	users := services.NewUserService(db)
+	ctx := context.Background()
+	alice := seedUser(t, db, "blockAlice")
+	bob := seedUser(t, db, "blockBob")
+	require.NoError(t, users.FollowUser(ctx, alice.ID, bob.ID))
+	require.NoError(t, users.FollowUser(ctx, bob.ID, alice.ID))
+
+	require.NoError(t, svc.Block(ctx, alice.ID, bob.ID))
+	require.NoError(t, svc.Block(ctx, alice.ID, bob.ID), "blocking twice is a no-op")
+	assert.ErrorIs(t, svc.Block(ctx, alice.ID, alice.ID), services.ErrBlockSelf)
+
+	var follows int64
+	require.NoError(t, db.Model(&models.Follow{}).Count(&follows).Error)
+	assert.Zero(t, follows)
+	_, err := users.GetUserProfile(ctx, bob.ID, alice.ID)
+	assert.ErrorIs(t, err, services.ErrUserNotFound, "the block works both ways")
+	assert.ErrorIs(t, users.FollowUser(ctx, bob.ID, alice.ID), services.ErrUserNotFound)
+
+	blocked, err := svc.ListBlocked(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, blocked, 1)
+	assert.Equal(t, bob.ID.String(), blocked[0].ID)
+
+	require.NoError(t, svc.Unblock(ctx, alice.ID, bob.ID))
+	require.NoError(t, users.FollowUser(ctx, bob.ID, alice.ID))
+}
+
+func TestPrivacyService_FeedAndScorecards(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewPrivacyService(db)
+	feed := services.NewFeedService(db)
+	scores := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	addGroupWithPlayer(t, db, roundID, 1, aliceRP.ID)
+	addGroupWithPlayer(t, db, roundID, 1, bobRP.ID)
+	organizer := organizerOf(t, db, event.ID)
+	viewer := follower(t, db, aliceRP.UserID)
+	completeViaService(t, db, roundID, organizer)
+	require.Contains(t, feedKinds(t, feed, viewer), string(models.ActivityKindRoundCompleted))
+
+	_, err := svc.UpdateSettings(ctx, aliceRP.UserID, services.PrivacySettingsInput{HideStats: boolPtr(true)})
+	require.NoError(t, err)
+	assert.Empty(t, feedKinds(t, feed, viewer), "scores drop out of the feed")
+
+	card, err := scores.GetScorecard(ctx, roundID, viewer, "user")
+	require.NoError(t, err)
+	var hidden, shown int
+	for _, g := range card.Groups {
+		for _, p := range g.Players {
+			if p.ScoresHidden {
+				hidden++
+				assert.Empty(t, p.Scores)
+				assert.Nil(t, p.TotalGross)
+			} else {
+				shown++
+			}
+		}
+	}
+	assert.Equal(t, 1, hidden, "alice's card is withheld from an outsider")
+	assert.Equal(t, 1, shown)
+
+	card, err = scores.GetScorecard(ctx, roundID, organizer, "user")
+	require.NoError(t, err)
+	for _, g := range card.Groups {
+		for _, p := range g.Players {
+			assert.False(t, p.ScoresHidden, "organizers see every card")
+		}
+	}
+
+	_, err = scores.GetUserScorecards(ctx, aliceRP.UserID, viewer, "user", 20)
+	assert.ErrorIs(t, err, services.ErrStatsHidden)
+
+	_, err = scores.GetScoreHistory(ctx, roundID, aliceRP.ID, viewer, "user")
+	assert.ErrorIs(t, err, services.ErrStatsHidden, "the history is withheld like the card")
+	_, err = scores.GetScoreHistory(ctx, roundID, aliceRP.ID, bobRP.UserID, "user")
+	assert.NoError(t, err, "players in the round share it")
+}
+
+func TestPrivacyService_AdminSeesPrivateEventlessCard(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	scores := newScoreSvc(db)
+	ctx := context.Background()
+	creator := seedUser(t, db, "pvCreator")
+	alice := seedUser(t, db, "pvAlice")
+	admin := seedAdmin(t, db)
+	course, tee := seedCourseWithTee(t, db, "Privacy Course")
+	seedHoles(t, db, tee.ID)
+	round := seedEventlessRound(t, db, creator.ID, course.ID, tee.ID)
+	rp := addEventlessRoundPlayer(t, db, round.ID, alice.ID)
+	addGroupWithPlayer(t, db, round.ID, 1, rp.ID)
+	enterCard(t, db, rp.ID, creator.ID, nil)
+	_, err := services.NewPrivacyService(db).UpdateSettings(ctx, alice.ID, services.PrivacySettingsInput{HideStats: boolPtr(true)})
+	require.NoError(t, err)
+
+	card, err := scores.GetScorecard(ctx, round.ID, admin.ID, "admin")
+	require.NoError(t, err)
+	for _, g := range card.Groups {
+		for _, p := range g.Players {
+			assert.False(t, p.ScoresHidden, "admins see every card, eventless rounds included")
+		}
+	}
+	_, err = scores.GetScoreHistory(ctx, round.ID, rp.ID, admin.ID, "admin")
+	assert.NoError(t, err)
+}
+
+func TestPrivacyService_HiddenEmailInMembersAndGroups(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewPrivacyService(db)
+	events := services.NewEventService(db)
+	rounds := services.NewRoundService(db, events)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	addGroupWithPlayer(t, db, roundID, 1, aliceRP.ID)
+	addGroupWithPlayer(t, db, roundID, 1, bobRP.ID)
+	_, err := svc.UpdateSettings(ctx, aliceRP.UserID, services.PrivacySettingsInput{HideEmail: boolPtr(true)})
+	require.NoError(t, err)
+
+	members, err := events.GetMembers(ctx, event.ID, bobRP.UserID)
+	require.NoError(t, err)
+	for _, m := range members {
+		assert.Equal(t, m.User.ID == aliceRP.UserID, m.EmailHidden, m.User.DisplayName)
+	}
+	members, err = events.GetMembers(ctx, event.ID, aliceRP.UserID)
+	require.NoError(t, err)
+	for _, m := range members {
+		assert.False(t, m.EmailHidden, "everyone sees their own email")
+	}
+
+	detail, err := rounds.Get(ctx, roundID, bobRP.UserID, "user")
+	require.NoError(t, err)
+	require.NotEmpty(t, detail.Groups)
+	for _, p := range detail.Groups[0].Players {
+		assert.Equal(t, p.UserID == aliceRP.UserID.String(), p.Email == nil, p.DisplayName)
+	}
+
+	alice := models.User{}
+	require.NoError(t, db.First(&alice, "id = ?", aliceRP.UserID).Error)
+	results, err := services.NewUserService(db).SearchUsers(ctx, bobRP.UserID, alice.Email)
+	require.NoError(t, err)
+	assert.Empty(t, results, "a hidden email isn't matched exactly either")
+}
@@ -0,0 +1,115 @@
+// services/purse_internal_test.go
+// White-box tests for the unexported purse helpers in purse_service.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestValidatePurse|TestSplitPurse|TestBuildPurse' -v
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+func TestValidatePurse(t *testing.T) {
+	place := func(p int, pct float64) PayoutPlaceInput { return PayoutPlaceInput{Place: p, Percent: pct} }
+	cases := map[string]struct {
+		in        SetPurseInput
+		wantField string
+	}{
+		"no fee, no schedule": {SetPurseInput{}, ""},
+		"fee only":            {SetPurseInput{EntryFeeCents: 2000}, ""},
+		"exactly 100 percent": {SetPurseInput{EntryFeeCents: 2000, Payouts: []PayoutPlaceInput{place(1, 50), place(2, 30), place(3, 20)}}, ""},
+		"partial schedule":    {SetPurseInput{Payouts: []PayoutPlaceInput{place(1, 33.33)}}, ""},
+		"negative fee":        {SetPurseInput{EntryFeeCents: -1}, "entry_fee_cents"},
+		"fee over cap":        {SetPurseInput{EntryFeeCents: maxEntryFeeCents + 1}, "entry_fee_cents"},
+		"place zero":          {SetPurseInput{Payouts: []PayoutPlaceInput{place(0, 10)}}, "payouts"},
+		"duplicate place":     {SetPurseInput{Payouts: []PayoutPlaceInput{place(1, 10), place(1, 10)}}, "payouts"},
+		"zero percent":        {SetPurseInput{Payouts: []PayoutPlaceInput{place(1, 0)}}, "payouts"},
+		"over 100 percent":    {SetPurseInput{Payouts: []PayoutPlaceInput{place(1, 60), place(2, 40.01)}}, "payouts"},
+		"too many places":     {SetPurseInput{Payouts: make([]PayoutPlaceInput, maxPayoutPlaces+1)}, "payouts"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validatePurse(tc.in)
+			if tc.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var ve *ValidationError
+			require.ErrorAs(t, err, &ve)
+			assert.Equal(t, tc.wantField, ve.Field)
+		})
+	}
+}
+
+func TestValidatePaymentAmount(t *testing.T) {
+	ptr := func(v int) *int { return &v }
+	assert.NoError(t, validatePaymentAmount(nil))
+	assert.NoError(t, validatePaymentAmount(ptr(0)))
+	assert.Error(t, validatePaymentAmount(ptr(-1)))
+	assert.Error(t, validatePaymentAmount(ptr(maxEntryFeeCents+1)))
+}
+
+func TestSplitPurse_ByPlace(t *testing.T) {
+	bps := map[int]int{1: 5000, 2: 3000, 3: 2000}
+	got := splitPurse(10000, bps, []int{2, 1, 3, 4})
+	assert.Equal(t, []int{3000, 5000, 2000, 0}, got)
+}
+
+func TestSplitPurse_TiesShareCombinedPlaces(t *testing.T) {
+	// Two tied for first share places 1 and 2; third place is paid alone.
+	bps := map[int]int{1: 5000, 2: 3000, 3: 2000}
+	got := splitPurse(10000, bps, []int{1, 1, 3})
+	assert.Equal(t, []int{4000, 4000, 2000}, got)
+}
+
+func TestSplitPurse_LeftoverCentsGoToFirstTied(t *testing.T) {
+	// 100 cents for first split three ways: 34, 33, 33.
+	got := splitPurse(100, map[int]int{1: 10000}, []int{1, 1, 1})
+	assert.Equal(t, []int{34, 33, 33}, got)
+}
+
+func TestSplitPurse_UnplacedPlayersGetNothing(t *testing.T) {
+	got := splitPurse(10000, map[int]int{1: 10000}, []int{0, 1})
+	assert.Equal(t, []int{0, 10000}, got)
+}
+
+func TestBuildPurse_PoolsPerFlightAndBalances(t *testing.T) {
+	ptr := func(v int) *int { return &v }
+	a, b := "flight-a", "flight-b"
+	paid := &models.FeePayment{AmountCents: 2000}
+	lines := []purseLine{
+		{EventPlayerID: uuid.New(), UserID: uuid.New(), FlightID: &a, Owes: true, Position: ptr(1), Payment: paid},
+		{EventPlayerID: uuid.New(), UserID: uuid.New(), FlightID: &a, Owes: true, Position: ptr(2)},
+		{EventPlayerID: uuid.New(), UserID: uuid.New(), FlightID: &b, Owes: true, Position: ptr(1)},
+		{EventPlayerID: uuid.New(), UserID: uuid.New(), FlightID: &b, Owes: false, Position: ptr(2)}, // substitute
+	}
+	schedule := []models.PayoutPlace{{Place: 1, Percent: 70}, {Place: 2, Percent: 30}}
+
+	data := buildPurse(2000, schedule, lines)
+
+	require.Len(t, data.Pools, 2)
+	assert.Equal(t, 4000, data.Pools[0].PurseCents)
+	assert.Equal(t, 2000, data.Pools[1].PurseCents)
+	assert.Equal(t, 6000, data.PurseCents)
+	assert.Equal(t, 2000, data.CollectedCents)
+	assert.Equal(t, 4000, data.OutstandingCents)
+
+	assert.Equal(t, 0, data.Players[0].BalanceCents)
+	require.NotNil(t, data.Players[0].PaidAt)
+	assert.Equal(t, 2000, data.Players[1].BalanceCents)
+	assert.Equal(t, 0, data.Players[3].OwedCents)
+
+	assert.Equal(t, []int{2800, 1200, 1400, 600}, []int{
+		data.Players[0].PayoutCents, data.Players[1].PayoutCents,
+		data.Players[2].PayoutCents, data.Players[3].PayoutCents,
+	})
+	assert.Equal(t, 6000, data.PayoutCents)
+}
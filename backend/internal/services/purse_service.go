@@ -0,0 +1,685 @@
+// services/purse_service.go
+// PurseService keeps an event's money ledger: entry fees, who has paid them,
+// the purse they add up to, and what each finishing place is owed. No money
+// moves through the app; the server is just the record both sides agree on.
+//
+// An event and each of its rounds carry their own entry fee and payout
+// schedule:
+//
+//   - Event purse: every member of the field (registered or completed, not a
+//     substitute) owes the event's fee. It pays out on the event standings —
+//     projected until the event is finalized, then the stored final standings.
+//   - Round purse: every player in the round owes the round's fee. It pays out
+//     on the round leaderboard once the round is completed.
+//
+// The purse is the fees owed, not just those collected, so payouts don't shift
+// as payments trickle in. With flights, each flight is its own pool: its
+// members' fees, paid out on its own positions. A schedule gives each place a
+// percentage; players tied on a place share the places they cover evenly, and
+// whatever the schedule doesn't cover stays with the organizer.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// maxEntryFeeCents caps an entry fee ($100,000).
+	maxEntryFeeCents = 10_000_000
+	// maxPayoutPlaces caps the length of a payout schedule.
+	maxPayoutPlaces = 50
+)
+
+// ─── Inputs and DTOs ──────────────────────────────────────────────────────────
+
+// PayoutPlaceInput is one place in a payout schedule: the share of the purse,
+// in percent, that finishing place earns.
+type PayoutPlaceInput struct {
+	Place   int     `json:"place"`
+	Percent float64 `json:"percent"`
+}
+
+// SetPurseInput replaces an event's or round's entry fee and payout schedule.
+type SetPurseInput struct {
+	EntryFeeCents int
+	Payouts       []PayoutPlaceInput
+}
+
+// PurseData is an event's or round's money ledger.
+type PurseData struct {
+	EventID          string             `json:"event_id"`
+	RoundID          *string            `json:"round_id"` // nil for the event purse
+	EntryFeeCents    int                `json:"entry_fee_cents"`
+	PurseCents       int                `json:"purse_cents"`       // fees owed by the field
+	CollectedCents   int                `json:"collected_cents"`   // fees marked paid
+	OutstandingCents int                `json:"outstanding_cents"` // fees owed and not yet paid
+	PayoutCents      int                `json:"payout_cents"`      // what the schedule pays out in total
+	Final            bool               `json:"final"`             // false while positions can still change
+	Payouts          []PayoutPlaceInput `json:"payouts"`
+	Pools            []PursePoolData    `json:"pools"` // one per flight; a single pool without flights
+	Players          []LedgerLineData   `json:"players"`
+}
+
+// PursePoolData is one flight's share of the purse.
+type PursePoolData struct {
+	FlightID   *string `json:"flight_id"`
+	FlightName *string `json:"flight_name"`
+	PurseCents int     `json:"purse_cents"`
+}
+
+// LedgerLineData is one player's line in the ledger. BalanceCents is what they
+// still owe (negative when they overpaid); PayoutCents is what they're owed.
+type LedgerLineData struct {
+	EventPlayerID string  `json:"event_player_id"`
+	UserID        string  `json:"user_id"`
+	DisplayName   string  `json:"display_name"`
+	FlightID      *string `json:"flight_id"`
+	OwedCents     int     `json:"owed_cents"`
+	PaidCents     int     `json:"paid_cents"`
+	PaidAt        *string `json:"paid_at"` // RFC 3339; nil until marked paid
+	BalanceCents  int     `json:"balance_cents"`
+	Position      *int    `json:"position"`
+	PayoutCents   int     `json:"payout_cents"`
+}
+
+// purseLine is one player going into buildPurse.
+type purseLine struct {
+	EventPlayerID uuid.UUID
+	UserID        uuid.UUID
+	DisplayName   string
+	FlightID      *string
+	FlightName    *string
+	Owes          bool
+	Position      *int
+	Payment       *models.FeePayment
+}
+
+// ─── Constructor ──────────────────────────────────────────────────────────────
+
+// PurseService owns entry fees, payments and payouts.
+// Construct once in main.go and inject into the purse handler factories.
+type PurseService struct {
+	DB             *gorm.DB
+	EventSvc       *EventService
+	LeaderboardSvc *LeaderboardService
+}
+
+// NewPurseService builds a PurseService. EventSvc provides the organizer and
+// membership checks; LeaderboardSvc provides the positions payouts follow.
+func NewPurseService(db *gorm.DB, eventSvc *EventService, leaderboardSvc *LeaderboardService) *PurseService {
+	return &PurseService{DB: db, EventSvc: eventSvc, LeaderboardSvc: leaderboardSvc}
+}
+
+// ─── Queries ──────────────────────────────────────────────────────────────────
+
+// EventPurse returns the event's ledger. Non-admins must be members.
+func (s *PurseService) EventPurse(ctx context.Context, eventID, callerID uuid.UUID, callerRole string) (*PurseData, error) {
+	if err := s.EventSvc.requireEventMember(ctx, eventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		return nil, fmt.Errorf("load event: %w", err)
+	}
+
+	var members []models.EventPlayer
+	if err := s.DB.WithContext(ctx).Preload("User").
+		Where("event_id = ?", eventID).Order("created_at ASC").
+		Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("load members: %w", err)
+	}
+	flights, err := s.LeaderboardSvc.loadFlightDirectory(ctx, &event.ID)
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[string]*int, len(members))
+	if event.FinalizedAt != nil {
+		// Pay out on the standings FinalizeEvent froze, not a fresh ranking
+		// that a later score or rule edit could move.
+		for _, m := range members {
+			positions[m.ID.String()] = m.FinishPosition
+		}
+	} else {
+		computed, err := s.LeaderboardSvc.computeStandings(ctx, &event, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range computed.Standings.Entries {
+			positions[e.EventPlayerID] = e.Position
+		}
+	}
+	payments, err := s.loadPayments(ctx, eventID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []purseLine
+	for _, m := range members {
+		line := purseLine{
+			EventPlayerID: m.ID,
+			UserID:        m.UserID,
+			DisplayName:   m.User.DisplayName,
+			Owes:          slices.Contains(flightedStatuses, m.Status) && !m.IsSubstitute,
+			Position:      positions[m.ID.String()],
+			Payment:       payments[m.ID],
+		}
+		line.FlightID, line.FlightName = flights.label(m.FlightID)
+		if line.Owes || line.Payment != nil || line.Position != nil {
+			lines = append(lines, line)
+		}
+	}
+	schedule, err := s.loadSchedule(ctx, eventID, nil)
+	if err != nil {
+		return nil, err
+	}
+	data := buildPurse(event.EntryFeeCents, schedule, lines)
+	data.EventID = eventID.String()
+	data.Final = event.FinalizedAt != nil
+	return data, nil
+}
+
+// RoundPurse returns a round's ledger. Non-admins must be members of the
+// round's event; eventless rounds have no purse.
+func (s *PurseService) RoundPurse(ctx context.Context, roundID, callerID uuid.UUID, callerRole string) (*PurseData, error) {
+	round, err := s.loadEventRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.EventSvc.requireEventMember(ctx, *round.EventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+
+	board, err := s.LeaderboardSvc.RoundLeaderboard(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	var players []models.RoundPlayer
+	if err := s.DB.WithContext(ctx).
+		Where("round_id = ? AND event_player_id IS NOT NULL", roundID).
+		Find(&players).Error; err != nil {
+		return nil, fmt.Errorf("load round players: %w", err)
+	}
+	eventPlayerOf := make(map[string]uuid.UUID, len(players))
+	for _, p := range players {
+		eventPlayerOf[p.ID.String()] = *p.EventPlayerID
+	}
+	payments, err := s.loadPayments(ctx, *round.EventID, &roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []purseLine
+	for _, e := range board.Entries {
+		epID, ok := eventPlayerOf[e.RoundPlayerID]
+		if !ok {
+			continue
+		}
+		lines = append(lines, purseLine{
+			EventPlayerID: epID,
+			UserID:        uuid.MustParse(e.UserID),
+			DisplayName:   e.DisplayName,
+			FlightID:      e.FlightID,
+			FlightName:    e.FlightName,
+			Owes:          true,
+			Position:      e.Position,
+			Payment:       payments[epID],
+		})
+	}
+	schedule, err := s.loadSchedule(ctx, *round.EventID, &roundID)
+	if err != nil {
+		return nil, err
+	}
+	data := buildPurse(round.EntryFeeCents, schedule, lines)
+	data.EventID = round.EventID.String()
+	rid := roundID.String()
+	data.RoundID = &rid
+	data.Final = round.Status == models.RoundStatusCompleted
+	return data, nil
+}
+
+// ─── Mutations ────────────────────────────────────────────────────────────────
+
+// SetEventPurse replaces the event's entry fee and payout schedule.
+// Organizer-only; refused once the event is finalized.
+func (s *PurseService) SetEventPurse(ctx context.Context, eventID, callerID uuid.UUID, callerRole string, in SetPurseInput) (*PurseData, error) {
+	if err := validatePurse(in); err != nil {
+		return nil, err
+	}
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, eventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Event{}).Where("id = ?", eventID).
+			Update("entry_fee_cents", in.EntryFeeCents).Error; err != nil {
+			return fmt.Errorf("save entry fee: %w", err)
+		}
+		return replaceSchedule(tx, eventID, nil, in.Payouts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.EventPurse(ctx, eventID, callerID, callerRole)
+}
+
+// SetRoundPurse replaces a round's entry fee and payout schedule.
+// Organizer-only; refused once the event is finalized.
+func (s *PurseService) SetRoundPurse(ctx context.Context, roundID, callerID uuid.UUID, callerRole string, in SetPurseInput) (*PurseData, error) {
+	if err := validatePurse(in); err != nil {
+		return nil, err
+	}
+	round, err := s.loadEventRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.EventSvc.loadOrganizedEvent(ctx, *round.EventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Round{}).Where("id = ?", roundID).
+			Update("entry_fee_cents", in.EntryFeeCents).Error; err != nil {
+			return fmt.Errorf("save entry fee: %w", err)
+		}
+		return replaceSchedule(tx, *round.EventID, &roundID, in.Payouts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.RoundPurse(ctx, roundID, callerID, callerRole)
+}
+
+// RecordEventPayment marks a member's event entry fee paid. amountCents nil
+// records the current fee; marking again replaces the amount. Organizer-only,
+// and still allowed after the event is finalized (people pay late).
+func (s *PurseService) RecordEventPayment(ctx context.Context, eventID, targetUserID, callerID uuid.UUID, callerRole string, amountCents *int) (*PurseData, error) {
+	if err := validatePaymentAmount(amountCents); err != nil {
+		return nil, err
+	}
+	event, err := s.requireOrganizer(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	epID, err := s.eventPlayerFor(ctx, eventID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.savePayment(ctx, eventID, nil, epID, callerID, feeOr(amountCents, event.EntryFeeCents)); err != nil {
+		return nil, err
+	}
+	return s.EventPurse(ctx, eventID, callerID, callerRole)
+}
+
+// ClearEventPayment unmarks a member's event entry fee. A no-op when it wasn't
+// marked paid. Organizer-only.
+func (s *PurseService) ClearEventPayment(ctx context.Context, eventID, targetUserID, callerID uuid.UUID, callerRole string) (*PurseData, error) {
+	if _, err := s.requireOrganizer(ctx, eventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	epID, err := s.eventPlayerFor(ctx, eventID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.DB.WithContext(ctx).
+		Where("event_player_id = ? AND round_id IS NULL", epID).
+		Delete(&models.FeePayment{}).Error; err != nil {
+		return nil, fmt.Errorf("clear payment: %w", err)
+	}
+	return s.EventPurse(ctx, eventID, callerID, callerRole)
+}
+
+// RecordRoundPayment marks a player's round entry fee paid. amountCents nil
+// records the current fee. Organizer-only.
+func (s *PurseService) RecordRoundPayment(ctx context.Context, roundID, targetUserID, callerID uuid.UUID, callerRole string, amountCents *int) (*PurseData, error) {
+	if err := validatePaymentAmount(amountCents); err != nil {
+		return nil, err
+	}
+	round, err := s.loadEventRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.requireOrganizer(ctx, *round.EventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	epID, err := s.roundEventPlayerFor(ctx, roundID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.savePayment(ctx, *round.EventID, &roundID, epID, callerID, feeOr(amountCents, round.EntryFeeCents)); err != nil {
+		return nil, err
+	}
+	return s.RoundPurse(ctx, roundID, callerID, callerRole)
+}
+
+// ClearRoundPayment unmarks a player's round entry fee. Organizer-only.
+func (s *PurseService) ClearRoundPayment(ctx context.Context, roundID, targetUserID, callerID uuid.UUID, callerRole string) (*PurseData, error) {
+	round, err := s.loadEventRound(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.requireOrganizer(ctx, *round.EventID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	epID, err := s.roundEventPlayerFor(ctx, roundID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.DB.WithContext(ctx).
+		Where("event_player_id = ? AND round_id = ?", epID, roundID).
+		Delete(&models.FeePayment{}).Error; err != nil {
+		return nil, fmt.Errorf("clear payment: %w", err)
+	}
+	return s.RoundPurse(ctx, roundID, callerID, callerRole)
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// validatePurse checks the entry fee and payout schedule: unique places from
+// 1, each with a positive share, adding up to at most 100%.
+func validatePurse(in SetPurseInput) error {
+	if in.EntryFeeCents < 0 || in.EntryFeeCents > maxEntryFeeCents {
+		return &ValidationError{Field: "entry_fee_cents", Message: fmt.Sprintf("entry_fee_cents must be between 0 and %d", maxEntryFeeCents)}
+	}
+	if len(in.Payouts) > maxPayoutPlaces {
+		return &ValidationError{Field: "payouts", Message: fmt.Sprintf("at most %d payout places", maxPayoutPlaces)}
+	}
+	seen := make(map[int]bool, len(in.Payouts))
+	total := 0
+	for _, p := range in.Payouts {
+		if p.Place < 1 {
+			return &ValidationError{Field: "payouts", Message: "place must be 1 or more"}
+		}
+		if seen[p.Place] {
+			return &ValidationError{Field: "payouts", Message: fmt.Sprintf("place %d is listed twice", p.Place)}
+		}
+		seen[p.Place] = true
+		bps := basisPoints(p.Percent)
+		if bps <= 0 {
+			return &ValidationError{Field: "payouts", Message: "percent must be greater than 0"}
+		}
+		total += bps
+	}
+	if total > 10000 {
+		return &ValidationError{Field: "payouts", Message: "payouts add up to more than 100%"}
+	}
+	return nil
+}
+
+// validatePaymentAmount checks an explicit payment amount.
+func validatePaymentAmount(amountCents *int) error {
+	if amountCents != nil && (*amountCents < 0 || *amountCents > maxEntryFeeCents) {
+		return &ValidationError{Field: "amount_cents", Message: fmt.Sprintf("amount_cents must be between 0 and %d", maxEntryFeeCents)}
+	}
+	return nil
+}
+
+// basisPoints converts a percentage (two decimal places) to hundredths of a
+// percent, so purse math stays in integers.
+func basisPoints(percent float64) int {
+	return int(math.Round(percent * 100))
+}
+
+// feeOr returns the explicit amount when given, else the fee.
+func feeOr(amountCents *int, fee int) int {
+	if amountCents != nil {
+		return *amountCents
+	}
+	return fee
+}
+
+// buildPurse totals the fees in lines and splits each flight's pool by
+// position per the schedule. Pools are listed in the order their first player
+// appears.
+func buildPurse(fee int, schedule []models.PayoutPlace, lines []purseLine) *PurseData {
+	data := &PurseData{
+		EntryFeeCents: fee,
+		Payouts:       make([]PayoutPlaceInput, len(schedule)),
+		Pools:         []PursePoolData{},
+		Players:       make([]LedgerLineData, len(lines)),
+	}
+	bps := make(map[int]int, len(schedule))
+	for i, p := range schedule {
+		data.Payouts[i] = PayoutPlaceInput{Place: p.Place, Percent: p.Percent}
+		bps[p.Place] = basisPoints(p.Percent)
+	}
+
+	poolOf := make(map[string]int)
+	var members [][]int // line indexes per pool
+	for i, l := range lines {
+		key := ""
+		if l.FlightID != nil {
+			key = *l.FlightID
+		}
+		pi, ok := poolOf[key]
+		if !ok {
+			pi = len(data.Pools)
+			poolOf[key] = pi
+			data.Pools = append(data.Pools, PursePoolData{FlightID: l.FlightID, FlightName: l.FlightName})
+			members = append(members, nil)
+		}
+		members[pi] = append(members[pi], i)
+
+		out := LedgerLineData{
+			EventPlayerID: l.EventPlayerID.String(),
+			UserID:        l.UserID.String(),
+			DisplayName:   l.DisplayName,
+			FlightID:      l.FlightID,
+			Position:      l.Position,
+		}
+		if l.Owes {
+			out.OwedCents = fee
+			data.Pools[pi].PurseCents += fee
+		}
+		if l.Payment != nil {
+			out.PaidCents = l.Payment.AmountCents
+			out.PaidAt = FormatTimestamp(&l.Payment.PaidAt)
+		}
+		out.BalanceCents = out.OwedCents - out.PaidCents
+		data.CollectedCents += out.PaidCents
+		data.OutstandingCents += max(out.BalanceCents, 0)
+		data.Players[i] = out
+	}
+
+	for pi, idx := range members {
+		data.PurseCents += data.Pools[pi].PurseCents
+		positions := make([]int, len(idx))
+		for k, i := range idx {
+			if lines[i].Position != nil {
+				positions[k] = *lines[i].Position
+			}
+		}
+		for k, cents := range splitPurse(data.Pools[pi].PurseCents, bps, positions) {
+			data.Players[idx[k]].PayoutCents = cents
+			data.PayoutCents += cents
+		}
+	}
+	return data
+}
+
+// splitPurse pays a pool out by finishing position. positions[i] is player i's
+// place (0 = unranked). The k players tied on place p share the schedule's
+// places p..p+k-1 evenly; leftover cents go to the first of them, and rounding
+// never pays out more than the schedule covers.
+func splitPurse(purse int, bps map[int]int, positions []int) []int {
+	out := make([]int, len(positions))
+	byPosition := make(map[int][]int)
+	for i, p := range positions {
+		if p > 0 {
+			byPosition[p] = append(byPosition[p], i)
+		}
+	}
+	for pos, tied := range byPosition {
+		share := 0
+		for place := pos; place < pos+len(tied); place++ {
+			share += bps[place]
+		}
+		total := purse * share / 10000
+		each, extra := total/len(tied), total%len(tied)
+		for k, i := range tied {
+			out[i] = each
+			if k < extra {
+				out[i]++
+			}
+		}
+	}
+	return out
+}
+
+// replaceSchedule swaps the event's (roundID nil) or a round's payout places.
+func replaceSchedule(tx *gorm.DB, eventID uuid.UUID, roundID *uuid.UUID, payouts []PayoutPlaceInput) error {
+	q := tx.Where("event_id = ?", eventID)
+	if roundID == nil {
+		q = q.Where("round_id IS NULL")
+	} else {
+		q = q.Where("round_id = ?", *roundID)
+	}
+	if err := q.Delete(&models.PayoutPlace{}).Error; err != nil {
+		return fmt.Errorf("clear payout schedule: %w", err)
+	}
+	if len(payouts) == 0 {
+		return nil
+	}
+	rows := make([]models.PayoutPlace, len(payouts))
+	for i, p := range payouts {
+		rows[i] = models.PayoutPlace{EventID: eventID, RoundID: roundID, Place: p.Place, Percent: p.Percent}
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("save payout schedule: %w", err)
+	}
+	return nil
+}
+
+// loadSchedule returns the event's (roundID nil) or a round's payout places
+// by place.
+func (s *PurseService) loadSchedule(ctx context.Context, eventID uuid.UUID, roundID *uuid.UUID) ([]models.PayoutPlace, error) {
+	q := s.DB.WithContext(ctx).Where("event_id = ?", eventID)
+	if roundID == nil {
+		q = q.Where("round_id IS NULL")
+	} else {
+		q = q.Where("round_id = ?", *roundID)
+	}
+	var schedule []models.PayoutPlace
+	if err := q.Find(&schedule).Error; err != nil {
+		return nil, fmt.Errorf("load payout schedule: %w", err)
+	}
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i].Place < schedule[j].Place })
+	return schedule, nil
+}
+
+// loadPayments returns the event's (roundID nil) or a round's payments by
+// event player.
+func (s *PurseService) loadPayments(ctx context.Context, eventID uuid.UUID, roundID *uuid.UUID) (map[uuid.UUID]*models.FeePayment, error) {
+	q := s.DB.WithContext(ctx).Where("event_id = ?", eventID)
+	if roundID == nil {
+		q = q.Where("round_id IS NULL")
+	} else {
+		q = q.Where("round_id = ?", *roundID)
+	}
+	var payments []models.FeePayment
+	if err := q.Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("load payments: %w", err)
+	}
+	out := make(map[uuid.UUID]*models.FeePayment, len(payments))
+	for i := range payments {
+		out[payments[i].EventPlayerID] = &payments[i]
+	}
+	return out, nil
+}
+
+// savePayment records a payment, replacing any earlier one for the same fee.
+func (s *PurseService) savePayment(ctx context.Context, eventID uuid.UUID, roundID *uuid.UUID, eventPlayerID, callerID uuid.UUID, amountCents int) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		q := tx.Where("event_player_id = ?", eventPlayerID)
+		if roundID == nil {
+			q = q.Where("round_id IS NULL")
+		} else {
+			q = q.Where("round_id = ?", *roundID)
+		}
+		if err := q.Delete(&models.FeePayment{}).Error; err != nil {
+			return fmt.Errorf("replace payment: %w", err)
+		}
+		payment := models.FeePayment{
+			EventID:       eventID,
+			RoundID:       roundID,
+			EventPlayerID: eventPlayerID,
+			AmountCents:   amountCents,
+			RecordedBy:    callerID,
+		}
+		if err := tx.Omit(clause.Associations).Create(&payment).Error; err != nil {
+			return fmt.Errorf("record payment: %w", err)
+		}
+		return nil
+	})
+}
+
+// requireOrganizer loads the event and checks the caller may manage it. Unlike
+// loadOrganizedEvent it doesn't refuse a finalized event.
+func (s *PurseService) requireOrganizer(ctx context.Context, eventID, callerID uuid.UUID, callerRole string) (*models.Event, error) {
+	var event models.Event
+	if err := s.DB.WithContext(ctx).First(&event, "id = ?", eventID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("load event: %w", err)
+	}
+	ok, err := s.EventSvc.IsOrganizer(ctx, eventID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrEventForbidden
+	}
+	return &event, nil
+}
+
+// loadEventRound loads a round that belongs to an event.
+func (s *PurseService) loadEventRound(ctx context.Context, roundID uuid.UUID) (*models.Round, error) {
+	var round models.Round
+	if err := s.DB.WithContext(ctx).First(&round, "id = ?", roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundNotFound
+		}
+		return nil, fmt.Errorf("load round: %w", err)
+	}
+	if round.EventID == nil {
+		return nil, &ValidationError{Field: "round", Message: "entry fees apply to event rounds only"}
+	}
+	return &round, nil
+}
+
+// eventPlayerFor returns the event_players ID of a member (ErrMemberNotFound).
+func (s *PurseService) eventPlayerFor(ctx context.Context, eventID, userID uuid.UUID) (uuid.UUID, error) {
+	var player models.EventPlayer
+	err := s.DB.WithContext(ctx).Select("id").
+		Where("event_id = ? AND user_id = ?", eventID, userID).First(&player).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.Nil, ErrMemberNotFound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("load member: %w", err)
+	}
+	return player.ID, nil
+}
+
+// roundEventPlayerFor returns the event_players ID behind a player in the
+// round (ErrPlayerNotInRound).
+func (s *PurseService) roundEventPlayerFor(ctx context.Context, roundID, userID uuid.UUID) (uuid.UUID, error) {
+	var rp models.RoundPlayer
+	err := s.DB.WithContext(ctx).
+		Where("round_id = ? AND user_id = ? AND event_player_id IS NOT NULL", roundID, userID).First(&rp).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.Nil, ErrPlayerNotInRound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("load round player: %w", err)
+	}
+	return *rp.EventPlayerID, nil
+}
@@ -0,0 +1,184 @@
+// services/purse_service_test.go
+// Integration tests for PurseService: setting entry fees and payout schedules,
+// marking fees paid, and the payouts each position earns. Docker must be
+// running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run PurseService -v
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// newPurseSvc builds a PurseService backed by the test DB.
+func newPurseSvc(db *gorm.DB) *services.PurseService {
+	eventSvc := services.NewEventService(db)
+	return services.NewPurseService(db, eventSvc, services.NewLeaderboardService(db, eventSvc))
+}
+
+// lineFor returns the ledger line for userID.
+func lineFor(t *testing.T, data *services.PurseData, userID uuid.UUID) services.LedgerLineData {
+	t.Helper()
+	for _, l := range data.Players {
+		if l.UserID == userID.String() {
+			return l
+		}
+	}
+	t.Fatalf("no ledger line for user %s", userID)
+	return services.LedgerLineData{}
+}
+
+var seventyThirty = []services.PayoutPlaceInput{{Place: 1, Percent: 70}, {Place: 2, Percent: 30}}
+
+func TestPurseService_RoundPurse_PaysByFinishingPosition(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newPurseSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+
+	data, err := svc.SetRoundPurse(ctx, roundID, orgID, "user", services.SetPurseInput{
+		EntryFeeCents: 1000, Payouts: seventyThirty,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2000, data.PurseCents)
+	assert.Equal(t, 2000, data.OutstandingCents)
+	assert.False(t, data.Final)
+
+	completeRound(t, db, roundID)
+	data, err = svc.RoundPurse(ctx, roundID, aliceRP.UserID, "user")
+	require.NoError(t, err)
+	assert.True(t, data.Final)
+	assert.Equal(t, 1400, lineFor(t, data, bobRP.UserID).PayoutCents, "bob wins the card-off")
+	assert.Equal(t, 600, lineFor(t, data, aliceRP.UserID).PayoutCents)
+	assert.Equal(t, 2000, data.PayoutCents)
+}
+
+func TestPurseService_RoundPayment_RecordAndClear(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newPurseSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+	_, err := svc.SetRoundPurse(ctx, roundID, orgID, "user", services.SetPurseInput{EntryFeeCents: 1000})
+	require.NoError(t, err)
+
+	data, err := svc.RecordRoundPayment(ctx, roundID, aliceRP.UserID, orgID, "user", nil)
+	require.NoError(t, err)
+	alice := lineFor(t, data, aliceRP.UserID)
+	assert.Equal(t, 1000, alice.PaidCents, "amount defaults to the entry fee")
+	assert.Equal(t, 0, alice.BalanceCents)
+	assert.NotNil(t, alice.PaidAt)
+	assert.Equal(t, 1000, data.CollectedCents)
+	assert.Equal(t, 1000, data.OutstandingCents)
+
+	partial := 400
+	data, err = svc.RecordRoundPayment(ctx, roundID, aliceRP.UserID, orgID, "user", &partial)
+	require.NoError(t, err)
+	assert.Equal(t, 600, lineFor(t, data, aliceRP.UserID).BalanceCents, "re-recording replaces the amount")
+
+	data, err = svc.ClearRoundPayment(ctx, roundID, aliceRP.UserID, orgID, "user")
+	require.NoError(t, err)
+	assert.Equal(t, 0, data.CollectedCents)
+	assert.Nil(t, lineFor(t, data, aliceRP.UserID).PaidAt)
+}
+
+func TestPurseService_RoundPayment_UnknownPlayer(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newPurseSvc(db)
+	roundID, event, _, _ := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+
+	_, err := svc.RecordRoundPayment(context.Background(), roundID, seedUser(t, db, "purseStranger").ID, orgID, "user", nil)
+	assert.ErrorIs(t, err, services.ErrPlayerNotInRound)
+}
+
+func TestPurseService_SetPurse_OrganizerOnly(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newPurseSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+
+	_, err := svc.SetEventPurse(ctx, event.ID, aliceRP.UserID, "user", services.SetPurseInput{EntryFeeCents: 500})
+	assert.ErrorIs(t, err, services.ErrEventForbidden)
+	_, err = svc.SetRoundPurse(ctx, roundID, aliceRP.UserID, "user", services.SetPurseInput{EntryFeeCents: 500})
+	assert.ErrorIs(t, err, services.ErrEventForbidden)
+	_, err = svc.RecordEventPayment(ctx, event.ID, aliceRP.UserID, aliceRP.UserID, "user", nil)
+	assert.ErrorIs(t, err, services.ErrEventForbidden)
+
+	_, err = svc.EventPurse(ctx, event.ID, seedUser(t, db, "purseOutsider").ID, "user")
+	assert.ErrorIs(t, err, services.ErrEventNotMember)
+}
+
+func TestPurseService_EventPurse_ScheduleReplacedAndFinalized(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newPurseSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, _ := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+
+	_, err := svc.SetEventPurse(ctx, event.ID, orgID, "user", services.SetPurseInput{
+		EntryFeeCents: 2500, Payouts: seventyThirty,
+	})
+	require.NoError(t, err)
+	data, err := svc.SetEventPurse(ctx, event.ID, orgID, "user", services.SetPurseInput{
+		EntryFeeCents: 2000, Payouts: []services.PayoutPlaceInput{{Place: 1, Percent: 100}},
+	})
+	require.NoError(t, err)
+	require.Len(t, data.Payouts, 1, "the schedule is replaced, not merged")
+	assert.Equal(t, 6000, data.PurseCents, "organizer, alice and bob all owe")
+
+	completeRound(t, db, roundID)
+	_, err = newLeaderboardSvc(db).FinalizeEvent(ctx, event.ID, orgID, "user")
+	require.NoError(t, err)
+
+	_, err = svc.SetEventPurse(ctx, event.ID, orgID, "user", services.SetPurseInput{EntryFeeCents: 100})
+	assert.ErrorIs(t, err, services.ErrEventFinalized)
+
+	data, err = svc.RecordEventPayment(ctx, event.ID, aliceRP.UserID, orgID, "user", nil)
+	require.NoError(t, err, "payments can still be marked after finalizing")
+	assert.True(t, data.Final)
+	assert.Equal(t, 2000, data.CollectedCents)
+}
+
+func TestPurseService_EventPurse_FinalizedPaysStoredStandings(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newPurseSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+	_, err := svc.SetEventPurse(ctx, event.ID, orgID, "user", services.SetPurseInput{
+		EntryFeeCents: 1000, Payouts: []services.PayoutPlaceInput{{Place: 1, Percent: 100}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&models.Score{}).
+		Where("round_player_id = ? AND hole_number = 2", bobRP.ID).
+		Updates(map[string]any{"gross_score": 3, "net_score": 3}).Error) // bob wins by one
+	completeRound(t, db, roundID)
+	_, err = newLeaderboardSvc(db).FinalizeEvent(ctx, event.ID, orgID, "user")
+	require.NoError(t, err)
+
+	// An admin edit after finalizing would hand alice the win on a re-rank.
+	require.NoError(t, db.Model(&models.Score{}).
+		Where("round_player_id = ? AND hole_number = 10", bobRP.ID).
+		Updates(map[string]any{"gross_score": 6, "net_score": 6}).Error)
+
+	data, err := svc.EventPurse(ctx, event.ID, aliceRP.UserID, "user")
+	require.NoError(t, err)
+	assert.Equal(t, 3000, lineFor(t, data, bobRP.UserID).PayoutCents, "bob won the finalized standings")
+	assert.Zero(t, lineFor(t, data, aliceRP.UserID).PayoutCents)
+}
@@ -0,0 +1,74 @@
+// services/push_internal_test.go
+// White-box tests for the unexported push helpers in push_service.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestPushBackoff|TestValidateDeviceInput|TestBuildPushMessage' -v
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+func TestPushBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, pushBackoff(1))
+	assert.Equal(t, time.Minute, pushBackoff(2))
+	assert.Equal(t, 2*time.Minute, pushBackoff(3))
+	assert.Equal(t, 32*time.Minute, pushBackoff(7))
+	assert.Equal(t, pushRetryMax, pushBackoff(8), "capped")
+	assert.Equal(t, pushRetryMax, pushBackoff(100))
+}
+
+func TestValidateDeviceInput(t *testing.T) {
+	cases := map[string]struct {
+		in        RegisterDeviceInput
+		wantField string
+	}{
+		"ios":            {RegisterDeviceInput{Token: "ExponentPushToken[abc]", Platform: "ios"}, ""},
+		"android":        {RegisterDeviceInput{Token: "ExponentPushToken[abc]", Platform: "android"}, ""},
+		"blank token":    {RegisterDeviceInput{Token: "   ", Platform: "ios"}, "token"},
+		"long token":     {RegisterDeviceInput{Token: strings.Repeat("x", maxDeviceTokenLen+1), Platform: "ios"}, "token"},
+		"no platform":    {RegisterDeviceInput{Token: "ExponentPushToken[abc]"}, "platform"},
+		"other platform": {RegisterDeviceInput{Token: "ExponentPushToken[abc]", Platform: "web"}, "platform"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateDeviceInput(tc.in)
+			if tc.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var ve *ValidationError
+			require.ErrorAs(t, err, &ve)
+			assert.Equal(t, tc.wantField, ve.Field)
+		})
+	}
+}
+
+func TestBuildPushMessage(t *testing.T) {
+	roundID := uuid.New()
+	row := models.PushOutbox{
+		DeviceToken: models.DeviceToken{Token: "ExponentPushToken[abc]"},
+		Notification: models.Notification{
+			ID:      uuid.New(),
+			Type:    models.NotificationTypeTeeTimeSoon,
+			RoundID: &roundID,
+			Round:   &models.Round{Name: "Saturday"},
+		},
+	}
+	msg := buildPushMessage(row)
+	assert.Equal(t, "ExponentPushToken[abc]", msg.Token)
+	assert.Equal(t, "You tee off within the hour: Saturday", msg.Body)
+	assert.Equal(t, "tee_time_soon", msg.Data["type"])
+	assert.Equal(t, roundID.String(), msg.Data["round_id"])
+	assert.NotContains(t, msg.Data, "event_id")
+}
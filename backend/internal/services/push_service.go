@@ -0,0 +1,294 @@
+// services/push_service.go
+// PushService owns device registration and delivery of the push outbox.
+//
+// notify (notification_service.go) queues a push_outbox row for each of a
+// recipient's devices whenever it writes an in-app notification, so a push
+// goes out only for notifications the user hasn't turned off. DeliverDue,
+// run periodically from main.go, sends whatever is due through a PushSender:
+// delivered rows are deleted, tokens the sender reports as no longer
+// registered are pruned (taking their queued rows with them), and anything
+// else is retried with exponential backoff until maxPushAttempts.
+//
+// Rows are claimed FOR UPDATE SKIP LOCKED and leased before they're sent, so
+// more than one server can run the worker without sending a push twice.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDeviceNotFound is returned when a device doesn't exist or is registered
+// to someone else.
+var ErrDeviceNotFound = errors.New("device not found")
+
+const (
+	// pushBatchSize caps how many queued pushes one DeliverDue call sends.
+	pushBatchSize = 100
+	// maxPushAttempts is how many failed attempts a push gets before it's
+	// marked failed.
+	maxPushAttempts = 8
+	// pushRetryBase is the wait after the first failed attempt; it doubles
+	// with each further failure up to pushRetryMax.
+	pushRetryBase = 30 * time.Second
+	pushRetryMax  = time.Hour
+	// pushClaimLease is how long a claimed push is held back from other
+	// workers while it's being sent.
+	pushClaimLease = 5 * time.Minute
+	// maxDeviceTokenLen bounds a registered token.
+	maxDeviceTokenLen = 200
+)
+
+// ─── Delivery interface ───────────────────────────────────────────────────────
+
+// PushMessage is one push to one device.
+type PushMessage struct {
+	Token string
+	Body  string
+	Data  map[string]string // for deep links: notification_id, type, event_id, round_id
+}
+
+// PushResult is the outcome of sending one PushMessage. The zero value means
+// delivered. DeadToken means the device is no longer registered and won't be
+// retried; otherwise a non-nil Err is retried.
+type PushResult struct {
+	DeadToken bool
+	Err       error
+}
+
+// PushSender delivers pushes. Send returns one result per message, in order;
+// a transport failure is reported as an Err on each affected message.
+type PushSender interface {
+	Send(ctx context.Context, msgs []PushMessage) []PushResult
+}
+
+// ─── Inputs and DTOs ──────────────────────────────────────────────────────────
+
+// RegisterDeviceInput is the body of POST /users/me/devices.
+type RegisterDeviceInput struct {
+	Token    string
+	Platform string
+}
+
+// DeviceData is a registered device.
+type DeviceData struct {
+	ID        string `json:"id"`
+	Platform  string `json:"platform"`
+	CreatedAt string `json:"created_at"` // RFC 3339
+}
+
+// ─── Service ──────────────────────────────────────────────────────────────────
+
+// PushService registers devices and delivers the push outbox.
+type PushService struct {
+	DB     *gorm.DB
+	Sender PushSender
+}
+
+// NewPushService constructs a PushService.
+func NewPushService(db *gorm.DB, sender PushSender) *PushService {
+	return &PushService{DB: db, Sender: sender}
+}
+
+// RegisterDevice registers the caller's device for pushes. Registering a
+// token again refreshes it; registering one another user had moves it to the
+// caller, since only the signed-in account should get the device's pushes.
+func (s *PushService) RegisterDevice(ctx context.Context, userID uuid.UUID, in RegisterDeviceInput) (*DeviceData, error) {
+	if err := validateDeviceInput(in); err != nil {
+		return nil, err
+	}
+	device := models.DeviceToken{
+		UserID:   userID,
+		Token:    strings.TrimSpace(in.Token),
+		Platform: models.DevicePlatform(in.Platform),
+	}
+	if err := s.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "updated_at"}),
+	}).Create(&device).Error; err != nil {
+		return nil, fmt.Errorf("register device: %w", err)
+	}
+	// On conflict Postgres keeps the existing row's ID; reload to return it.
+	if err := s.DB.WithContext(ctx).First(&device, "token = ?", device.Token).Error; err != nil {
+		return nil, fmt.Errorf("reload device: %w", err)
+	}
+	return &DeviceData{
+		ID:        device.ID.String(),
+		Platform:  string(device.Platform),
+		CreatedAt: device.CreatedAt.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// UnregisterDevice stops pushes to one of the caller's devices, e.g. on sign
+// out. Pushes still queued for it are dropped.
+func (s *PushService) UnregisterDevice(ctx context.Context, userID, deviceID uuid.UUID) error {
+	res := s.DB.WithContext(ctx).Where("id = ? AND user_id = ?", deviceID, userID).Delete(&models.DeviceToken{})
+	if res.Error != nil {
+		return fmt.Errorf("unregister device: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
+
+// DeliverDue sends up to pushBatchSize queued pushes that are due and returns
+// how many were delivered. Run periodically from main.go.
+//
+// The batch is claimed in a short transaction that pushes each row's
+// next_attempt_at out by pushClaimLease; the sender is called after that
+// commits, so no row lock is held across the HTTP call. A worker that dies
+// mid-send only delays its batch until the lease runs out.
+func (s *PushService) DeliverDue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	var ids []uuid.UUID
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.PushOutbox{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("failed_at IS NULL AND next_attempt_at <= ?", now).
+			Order("next_attempt_at").Limit(pushBatchSize).
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("claim push outbox: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Model(&models.PushOutbox{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(pushClaimLease)).Error; err != nil {
+			return fmt.Errorf("claim push outbox: %w", err)
+		}
+		return nil
+	})
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	var rows []models.PushOutbox
+	if err := s.DB.WithContext(ctx).Preload("DeviceToken").
+		Preload("Notification.Actor").Preload("Notification.Event").
+		Preload("Notification.Round.Event").
+		Where("id IN ?", ids).Order("created_at").Find(&rows).Error; err != nil {
+		return 0, fmt.Errorf("load push outbox: %w", err)
+	}
+	msgs := make([]PushMessage, len(rows))
+	for i, row := range rows {
+		msgs[i] = buildPushMessage(row)
+	}
+	results := s.Sender.Send(ctx, msgs)
+	if len(results) != len(msgs) {
+		// Which pushes went out is unknown, so every one counts as a failed
+		// attempt rather than being resent forever.
+		mismatch := fmt.Errorf("push sender returned %d results for %d messages", len(results), len(msgs))
+		for _, row := range rows {
+			if err := scheduleRetry(s.DB.WithContext(ctx), row, mismatch, now); err != nil {
+				return 0, err
+			}
+		}
+		return 0, mismatch
+	}
+
+	var sent, deadTokens []uuid.UUID
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, row := range rows {
+			switch r := results[i]; {
+			case r.DeadToken:
+				deadTokens = append(deadTokens, row.DeviceTokenID)
+			case r.Err != nil:
+				if err := scheduleRetry(tx, row, r.Err, now); err != nil {
+					return err
+				}
+			default:
+				sent = append(sent, row.ID)
+			}
+		}
+		if len(sent) > 0 {
+			if err := tx.Where("id IN ?", sent).Delete(&models.PushOutbox{}).Error; err != nil {
+				return fmt.Errorf("clear delivered pushes: %w", err)
+			}
+		}
+		// Deleting a token cascades to everything still queued for it.
+		if len(deadTokens) > 0 {
+			if err := tx.Where("id IN ?", deadTokens).Delete(&models.DeviceToken{}).Error; err != nil {
+				return fmt.Errorf("prune device tokens: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(sent), nil
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// validateDeviceInput checks a device registration.
+func validateDeviceInput(in RegisterDeviceInput) error {
+	token := strings.TrimSpace(in.Token)
+	if token == "" || len(token) > maxDeviceTokenLen {
+		return &ValidationError{Field: "token", Message: fmt.Sprintf("token is required and at most %d characters", maxDeviceTokenLen)}
+	}
+	switch models.DevicePlatform(in.Platform) {
+	case models.DevicePlatformIOS, models.DevicePlatformAndroid:
+	default:
+		return &ValidationError{Field: "platform", Message: "platform must be 'ios' or 'android'"}
+	}
+	return nil
+}
+
+// buildPushMessage renders a queued push (DeviceToken and Notification with
+// its Actor, Event and Round.Event loaded).
+func buildPushMessage(row models.PushOutbox) PushMessage {
+	n := row.Notification
+	data := map[string]string{
+		"notification_id": n.ID.String(),
+		"type":            string(n.Type),
+	}
+	if n.EventID != nil {
+		data["event_id"] = n.EventID.String()
+	}
+	if n.RoundID != nil {
+		data["round_id"] = n.RoundID.String()
+	}
+	return PushMessage{Token: row.DeviceToken.Token, Body: notificationMessage(n), Data: data}
+}
+
+// pushBackoff is the wait before the next attempt after the given number of
+// failed attempts.
+func pushBackoff(attempts int) time.Duration {
+	wait := pushRetryBase
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= pushRetryMax {
+			return pushRetryMax
+		}
+	}
+	return wait
+}
+
+// scheduleRetry records a failed attempt on row: it's retried after
+// pushBackoff, or marked failed once maxPushAttempts is reached.
+func scheduleRetry(tx *gorm.DB, row models.PushOutbox, sendErr error, now time.Time) error {
+	attempts := row.Attempts + 1
+	updates := map[string]any{
+		"attempts":   attempts,
+		"last_error": sendErr.Error(),
+	}
+	if attempts >= maxPushAttempts {
+		updates["failed_at"] = now
+	} else {
+		updates["next_attempt_at"] = now.Add(pushBackoff(attempts))
+	}
+	if err := tx.Model(&models.PushOutbox{}).Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("schedule push retry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// services/push_service_test.go
+// Integration tests for PushService: device registration, queueing pushes
+// alongside in-app notifications, delivery through a recording PushSender,
+// retries and dead-token pruning, and the tee-time reminder sweep. Docker
+// must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'PushService|TeeTimeReminders' -v
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// recordingSender is a PushSender that records every message and answers
+// with result(token), delivered by default.
+type recordingSender struct {
+	sent   []services.PushMessage
+	result func(token string) services.PushResult
+}
+
+func (r *recordingSender) Send(_ context.Context, msgs []services.PushMessage) []services.PushResult {
+	r.sent = append(r.sent, msgs...)
+	out := make([]services.PushResult, len(msgs))
+	for i, m := range msgs {
+		if r.result != nil {
+			out[i] = r.result(m.Token)
+		}
+	}
+	return out
+}
+
+// registerDevice registers token for userID and returns the device ID.
+func registerDevice(t *testing.T, svc *services.PushService, userID uuid.UUID, token string) string {
+	t.Helper()
+	device, err := svc.RegisterDevice(context.Background(), userID, services.RegisterDeviceInput{Token: token, Platform: "ios"})
+	require.NoError(t, err)
+	return device.ID
+}
+
+// queuedPushes returns the outbox rows for userID's devices.
+func queuedPushes(t *testing.T, db *gorm.DB, userID uuid.UUID) []models.PushOutbox {
+	t.Helper()
+	var rows []models.PushOutbox
+	require.NoError(t, db.Joins("JOIN device_tokens dt ON dt.id = push_outbox.device_token_id").
+		Where("dt.user_id = ?", userID).Find(&rows).Error)
+	return rows
+}
+
+func TestPushService_NotificationQueuesAndDelivers(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	sender := &recordingSender{}
+	svc := services.NewPushService(db, sender)
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+	registerDevice(t, svc, alice.ID, "ExponentPushToken[phone]")
+	registerDevice(t, svc, alice.ID, "ExponentPushToken[tablet]")
+
+	require.NoError(t, services.NewUserService(db).FollowUser(ctx, bob.ID, alice.ID))
+	require.Len(t, queuedPushes(t, db, alice.ID), 2, "one push per device")
+
+	delivered, err := svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	require.Len(t, sender.sent, 2)
+	assert.Equal(t, bob.DisplayName+" started following you", sender.sent[0].Body)
+	assert.Equal(t, "new_follower", sender.sent[0].Data["type"])
+	assert.Empty(t, queuedPushes(t, db, alice.ID), "delivered pushes leave the outbox")
+
+	delivered, err = svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+}
+
+func TestPushService_DisabledTypeNotPushed(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewPushService(db, &recordingSender{})
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+	registerDevice(t, svc, alice.ID, "ExponentPushToken[phone]")
+	_, err := services.NewNotificationService(db).UpdatePreferences(ctx, alice.ID, map[string]bool{"new_follower": false})
+	require.NoError(t, err)
+
+	require.NoError(t, services.NewUserService(db).FollowUser(ctx, bob.ID, alice.ID))
+	assert.Empty(t, queuedPushes(t, db, alice.ID))
+}
+
+func TestPushService_RetriesThenFails(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	sender := &recordingSender{result: func(string) services.PushResult {
+		return services.PushResult{Err: errors.New("expo unavailable")}
+	}}
+	svc := services.NewPushService(db, sender)
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+	registerDevice(t, svc, alice.ID, "ExponentPushToken[phone]")
+	require.NoError(t, services.NewUserService(db).FollowUser(ctx, bob.ID, alice.ID))
+
+	before := time.Now()
+	_, err := svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	rows := queuedPushes(t, db, alice.ID)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 1, rows[0].Attempts)
+	assert.Equal(t, "expo unavailable", *rows[0].LastError)
+	assert.WithinDuration(t, before.Add(30*time.Second), rows[0].NextAttemptAt, 5*time.Second)
+
+	// Not due yet, so nothing is sent.
+	_, err = svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Len(t, sender.sent, 1)
+
+	// The last allowed attempt marks the push failed instead of rescheduling.
+	require.NoError(t, db.Model(&models.PushOutbox{}).Where("id = ?", rows[0].ID).
+		Updates(map[string]any{"attempts": 7, "next_attempt_at": time.Now().Add(-time.Second)}).Error)
+	_, err = svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	rows = queuedPushes(t, db, alice.ID)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 8, rows[0].Attempts)
+	assert.NotNil(t, rows[0].FailedAt)
+}
+
+// shortSender is a PushSender that loses track of its results.
+type shortSender struct{}
+
+func (shortSender) Send(context.Context, []services.PushMessage) []services.PushResult { return nil }
+
+func TestPushService_ResultMismatchCountsAsAttempt(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewPushService(db, shortSender{})
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+	registerDevice(t, svc, alice.ID, "ExponentPushToken[phone]")
+	require.NoError(t, services.NewUserService(db).FollowUser(ctx, bob.ID, alice.ID))
+
+	_, err := svc.DeliverDue(ctx)
+	require.Error(t, err)
+	rows := queuedPushes(t, db, alice.ID)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 1, rows[0].Attempts, "retried with backoff, not resent every run")
+	require.NotNil(t, rows[0].LastError)
+	assert.Contains(t, *rows[0].LastError, "returned 0 results for 1 messages")
+}
+
+// nestedSender runs a second worker while the first is mid-send.
+type nestedSender struct {
+	svc        *services.PushService
+	sends      int
+	nestedSent int
+}
+
+func (n *nestedSender) Send(ctx context.Context, msgs []services.PushMessage) []services.PushResult {
+	n.sends++
+	if n.sends == 1 {
+		n.nestedSent, _ = n.svc.DeliverDue(ctx)
+	}
+	return make([]services.PushResult, len(msgs))
+}
+
+func TestPushService_ClaimedPushesSkippedWhileSending(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	sender := &nestedSender{}
+	svc := services.NewPushService(db, sender)
+	sender.svc = svc
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+	registerDevice(t, svc, alice.ID, "ExponentPushToken[phone]")
+	require.NoError(t, services.NewUserService(db).FollowUser(ctx, bob.ID, alice.ID))
+
+	delivered, err := svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, 1, sender.sends, "the second worker found nothing to send")
+	assert.Zero(t, sender.nestedSent)
+}
+
+func TestPushService_DeadTokenPruned(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	sender := &recordingSender{result: func(token string) services.PushResult {
+		return services.PushResult{DeadToken: token == "ExponentPushToken[old]"}
+	}}
+	svc := services.NewPushService(db, sender)
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+	registerDevice(t, svc, alice.ID, "ExponentPushToken[old]")
+	registerDevice(t, svc, alice.ID, "ExponentPushToken[new]")
+	require.NoError(t, services.NewUserService(db).FollowUser(ctx, bob.ID, alice.ID))
+
+	delivered, err := svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	var tokens []string
+	require.NoError(t, db.Model(&models.DeviceToken{}).Where("user_id = ?", alice.ID).Pluck("token", &tokens).Error)
+	assert.Equal(t, []string{"ExponentPushToken[new]"}, tokens)
+	assert.Empty(t, queuedPushes(t, db, alice.ID))
+}
+
+func TestPushService_RegisterMovesTokenAndUnregister(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewPushService(db, &recordingSender{})
+	ctx := context.Background()
+	alice := seedUser(t, db, "alice")
+	bob := seedUser(t, db, "bob")
+
+	first := registerDevice(t, svc, alice.ID, "ExponentPushToken[shared]")
+	again := registerDevice(t, svc, alice.ID, "ExponentPushToken[shared]")
+	assert.Equal(t, first, again, "re-registering keeps the device")
+
+	moved := registerDevice(t, svc, bob.ID, "ExponentPushToken[shared]")
+	assert.Equal(t, first, moved)
+	assert.ErrorIs(t, svc.UnregisterDevice(ctx, alice.ID, uuid.MustParse(moved)), services.ErrDeviceNotFound)
+	require.NoError(t, svc.UnregisterDevice(ctx, bob.ID, uuid.MustParse(moved)))
+
+	var count int64
+	require.NoError(t, db.Model(&models.DeviceToken{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestNotificationService_TeeTimeReminders_OncePerRound(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	roundID, _, aliceRP, bobRP := tiedRound(t, db)
+	soon := addGroupWithPlayer(t, db, roundID, 2, aliceRP.ID)
+	later := addGroupWithPlayer(t, db, roundID, 3, bobRP.ID)
+	require.NoError(t, db.Model(&soon).Update("tee_time", time.Now().Add(40*time.Minute)).Error)
+	require.NoError(t, db.Model(&later).Update("tee_time", time.Now().Add(3*time.Hour)).Error)
+	svc := services.NewNotificationService(db)
+
+	require.NoError(t, svc.SendTeeTimeReminders(ctx))
+	require.NoError(t, svc.SendTeeTimeReminders(ctx))
+	assert.Equal(t, []string{"tee_time_soon"}, inboxTypes(t, db, aliceRP.UserID), "reminded once")
+	assert.Empty(t, inboxTypes(t, db, bobRP.UserID), "not within the hour")
+
+	// Moving the tee time doesn't remind alice again; bob's group now qualifies.
+	require.NoError(t, db.Model(&later).Update("tee_time", time.Now().Add(50*time.Minute)).Error)
+	require.NoError(t, db.Model(&soon).Update("tee_time", time.Now().Add(55*time.Minute)).Error)
+	require.NoError(t, svc.SendTeeTimeReminders(ctx))
+	assert.Len(t, inboxTypes(t, db, aliceRP.UserID), 1)
+	assert.Equal(t, []string{"tee_time_soon"}, inboxTypes(t, db, bobRP.UserID))
+}
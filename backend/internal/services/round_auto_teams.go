@@ -0,0 +1,282 @@
+// services/round_auto_teams.go
+// Automatic team builder for team formats. Instead of calling CreateTeam and
+// AssignTeamMembers by hand, an organizer asks for every group in a
+// las_vegas or best_ball round to be split into two teams whose combined
+// course handicaps are as even as possible.
+//
+// Each group is split on its own (teams never span groups). With four players
+// that is two teams of two; a threesome becomes a two and a one, which
+// best_ball allows and Las Vegas (always two against two) rejects. The split
+// is the one with the smallest difference in combined handicap, found by
+// trying every split — groups hold at most four players, so there are at most
+// three to try.
+//
+// Like pairings, a preview returns the proposed teams without saving them; a
+// commit replaces every team on the round.
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxVegasTeamSize is the Las Vegas team size; the same cap AssignTeamMembers
+// enforces. A Vegas group is exactly two such teams.
+const maxVegasTeamSize = 2
+
+// GenerateTeamsInput is the payload for GenerateTeams.
+type GenerateTeamsInput struct {
+	Preview bool // true = return the proposed teams without saving them
+}
+
+// AutoTeamsResult is returned by GenerateTeams.
+type AutoTeamsResult struct {
+	Preview bool
+	Groups  []AutoTeamGroupResult
+}
+
+// AutoTeamGroupResult is one group's split. HandicapGap is the difference
+// between the two teams' combined handicaps.
+type AutoTeamGroupResult struct {
+	GroupID     uuid.UUID
+	GroupNumber int
+	HandicapGap int
+	Teams       []AutoTeamResult
+}
+
+// AutoTeamResult is one proposed or saved team. TeamID is nil in a preview.
+type AutoTeamResult struct {
+	TeamID           *uuid.UUID
+	Name             string
+	CombinedHandicap int
+	Players          []AutoTeamPlayerResult
+}
+
+// AutoTeamPlayerResult is one player on a team. CourseHandicap is the playing
+// handicap the split used; nil when the player has neither a course handicap
+// nor a handicap index (counted as 0).
+type AutoTeamPlayerResult struct {
+	RoundPlayerID  uuid.UUID
+	UserID         uuid.UUID
+	DisplayName    string
+	IsGuest        bool
+	CourseHandicap *int
+}
+
+// GenerateTeams splits every group of a las_vegas or best_ball round into two
+// handicap-balanced teams. Groups with fewer than two players are left out.
+// Organizer-only, and only while the round is still scheduled.
+func (s *RoundService) GenerateTeams(ctx context.Context, roundID, callerID uuid.UUID, callerRole string, in GenerateTeamsInput) (AutoTeamsResult, error) {
+	isOrg, err := s.requireRoundOrganizer(ctx, roundID, callerID, callerRole)
+	if err != nil {
+		return AutoTeamsResult{}, err
+	}
+	if !isOrg {
+		return AutoTeamsResult{}, ErrRoundForbidden
+	}
+	var round models.Round
+	if err := s.DB.WithContext(ctx).Preload("Event").First(&round, "id = ?", roundID).Error; err != nil {
+		return AutoTeamsResult{}, fmt.Errorf("load round: %w", err)
+	}
+	if round.ScoringFormat != models.ScoringFormatLasVegas && round.ScoringFormat != models.ScoringFormatBestBall {
+		return AutoTeamsResult{}, &ValidationError{Field: "round", Message: "teams can only be generated for las_vegas and best_ball rounds"}
+	}
+	if round.Status != models.RoundStatusScheduled {
+		return AutoTeamsResult{}, ErrRoundStarted
+	}
+
+	var groups []models.Group
+	if err := s.DB.WithContext(ctx).Where("round_id = ?", roundID).
+		Order("group_number ASC").Find(&groups).Error; err != nil {
+		return AutoTeamsResult{}, fmt.Errorf("load groups: %w", err)
+	}
+	out := AutoTeamsResult{Preview: in.Preview}
+	for _, g := range groups {
+		players, err := s.teamCandidates(ctx, &round, g.ID)
+		if err != nil {
+			return AutoTeamsResult{}, err
+		}
+		if len(players) < 2 {
+			continue
+		}
+		if round.ScoringFormat == models.ScoringFormatLasVegas && len(players) != 2*maxVegasTeamSize {
+			return AutoTeamsResult{}, &ValidationError{
+				Field:   "round",
+				Message: fmt.Sprintf("group %d has %d players; las_vegas needs two teams of %d", g.GroupNumber, len(players), maxVegasTeamSize),
+			}
+		}
+		out.Groups = append(out.Groups, splitGroupTeams(g, players))
+	}
+	if len(out.Groups) == 0 {
+		return AutoTeamsResult{}, &ValidationError{Field: "round", Message: "round has no groups with at least 2 players"}
+	}
+	if in.Preview {
+		return out, nil
+	}
+
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("round_id = ?", roundID).Delete(&models.Team{}).Error; err != nil {
+			return fmt.Errorf("clear teams: %w", err)
+		}
+		for gi := range out.Groups {
+			for ti := range out.Groups[gi].Teams {
+				team := &out.Groups[gi].Teams[ti]
+				row := models.Team{RoundID: roundID, Name: team.Name}
+				if err := tx.Omit(clause.Associations).Create(&row).Error; err != nil {
+					return fmt.Errorf("create team: %w", err)
+				}
+				for _, p := range team.Players {
+					if err := tx.Create(&models.TeamMember{TeamID: row.ID, RoundPlayerID: p.RoundPlayerID}).Error; err != nil {
+						return fmt.Errorf("add team member: %w", err)
+					}
+				}
+				team.TeamID = &row.ID
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return AutoTeamsResult{}, fmt.Errorf("save teams: %w", txErr)
+	}
+	return out, nil
+}
+
+// teamCandidates loads a group's players with the handicap the split uses:
+// the playing handicap the scorecard nets with, i.e. the course handicap
+// (entered, or worked out from the handicap index on the player's tee) after
+// the event's allowance.
+func (s *RoundService) teamCandidates(ctx context.Context, round *models.Round, groupID uuid.UUID) ([]AutoTeamPlayerResult, error) {
+	type playerRow struct {
+		RoundPlayerID  uuid.UUID
+		UserID         uuid.UUID
+		DisplayName    string
+		IsGuest        bool
+		CourseHandicap *int
+		HandicapIndex  *float64
+		SlopeRating    int
+		CourseRating   float64
+		Par            int
+	}
+	var rows []playerRow
+	if err := s.DB.WithContext(ctx).Table("group_players gp").
+		Select("gp.round_player_id, u.id as user_id, u.display_name, u.is_guest, rp.course_handicap, rp.handicap_index, "+
+			"t.slope_rating, t.course_rating, t.par").
+		Joins("JOIN round_players rp ON rp.id = gp.round_player_id").
+		Joins("JOIN users u ON u.id = rp.user_id").
+		Joins("JOIN tees t ON t.id = COALESCE(rp.tee_id, ?)", round.DefaultTeeID).
+		Where("gp.group_id = ?", groupID).
+		Order("u.display_name ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load group players: %w", err)
+	}
+
+	var needIndex []uuid.UUID
+	for _, r := range rows {
+		if r.CourseHandicap == nil && r.HandicapIndex == nil {
+			needIndex = append(needIndex, r.UserID)
+		}
+	}
+	indexes := map[uuid.UUID]float64{}
+	if len(needIndex) > 0 {
+		var err error
+		if indexes, err = latestHandicapIndexes(ctx, s.DB, needIndex); err != nil {
+			return nil, err
+		}
+	}
+
+	allowance := roundHandicapAllowance(round)
+	out := make([]AutoTeamPlayerResult, len(rows))
+	for i, r := range rows {
+		p := AutoTeamPlayerResult{
+			RoundPlayerID: r.RoundPlayerID, UserID: r.UserID,
+			DisplayName: r.DisplayName, IsGuest: r.IsGuest,
+		}
+		ch := r.CourseHandicap
+		if ch == nil {
+			hi, ok := indexes[r.UserID]
+			if r.HandicapIndex != nil {
+				hi, ok = *r.HandicapIndex, true
+			}
+			if ok {
+				tee := models.Tee{SlopeRating: r.SlopeRating, CourseRating: r.CourseRating, Par: r.Par}
+				v := CourseHandicapFromIndex(hi, tee, round.NineHoleSelection != nil)
+				ch = &v
+			}
+		}
+		if ch != nil {
+			eff := EffectiveCourseHandicap(*ch, allowance)
+			p.CourseHandicap = &eff
+		}
+		out[i] = p
+	}
+	return out, nil
+}
+
+// splitGroupTeams splits a group's players into two teams with the smallest
+// gap in combined handicap. The first team is the larger one when the group
+// is odd. Ties go to the first split found, so the result is deterministic
+// for a given player order.
+func splitGroupTeams(g models.Group, players []AutoTeamPlayerResult) AutoTeamGroupResult {
+	n := len(players)
+	size := (n + 1) / 2
+	best, bestGap := 0, math.MaxInt
+	for mask := 0; mask < 1<<n; mask++ {
+		if bits.OnesCount(uint(mask)) != size {
+			continue
+		}
+		// With even teams each split appears twice (once per side); keep the
+		// one that puts the first player on team A.
+		if n%2 == 0 && mask&1 == 0 {
+			continue
+		}
+		a, b := 0, 0
+		for i, p := range players {
+			if mask&(1<<i) != 0 {
+				a += teamHandicap(p)
+			} else {
+				b += teamHandicap(p)
+			}
+		}
+		gap := a - b
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap < bestGap {
+			best, bestGap = mask, gap
+		}
+	}
+
+	teamA := AutoTeamResult{Name: fmt.Sprintf("Group %d A", g.GroupNumber)}
+	teamB := AutoTeamResult{Name: fmt.Sprintf("Group %d B", g.GroupNumber)}
+	for i, p := range players {
+		team := &teamB
+		if best&(1<<i) != 0 {
+			team = &teamA
+		}
+		team.Players = append(team.Players, p)
+		team.CombinedHandicap += teamHandicap(p)
+	}
+	for _, t := range []*AutoTeamResult{&teamA, &teamB} {
+		sort.SliceStable(t.Players, func(i, j int) bool { return teamHandicap(t.Players[i]) < teamHandicap(t.Players[j]) })
+	}
+	return AutoTeamGroupResult{
+		GroupID: g.ID, GroupNumber: g.GroupNumber, HandicapGap: bestGap,
+		Teams: []AutoTeamResult{teamA, teamB},
+	}
+}
+
+// teamHandicap is the handicap a player counts for in a split; 0 when unknown.
+func teamHandicap(p AutoTeamPlayerResult) int {
+	if p.CourseHandicap == nil {
+		return 0
+	}
+	return *p.CourseHandicap
+}
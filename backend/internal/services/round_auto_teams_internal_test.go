@@ -0,0 +1,73 @@
+// services/round_auto_teams_internal_test.go
+// White-box tests for the unexported split helper in round_auto_teams.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run TestSplitGroupTeams -v
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+// teamPlayers builds one player per handicap; nil entries have no handicap.
+func teamPlayers(handicaps ...*int) []AutoTeamPlayerResult {
+	out := make([]AutoTeamPlayerResult, len(handicaps))
+	for i, h := range handicaps {
+		out[i] = AutoTeamPlayerResult{RoundPlayerID: uuid.New(), DisplayName: fmt.Sprintf("P%d", i+1), CourseHandicap: h}
+	}
+	return out
+}
+
+func hcp(v int) *int { return &v }
+
+// teamHandicaps returns each team's player handicaps.
+func teamHandicaps(g AutoTeamGroupResult) [][]int {
+	out := make([][]int, len(g.Teams))
+	for i, t := range g.Teams {
+		for _, p := range t.Players {
+			out[i] = append(out[i], teamHandicap(p))
+		}
+	}
+	return out
+}
+
+func TestSplitGroupTeams_FoursomePairsLowWithHigh(t *testing.T) {
+	g := splitGroupTeams(models.Group{GroupNumber: 2}, teamPlayers(hcp(2), hcp(8), hcp(14), hcp(20)))
+
+	require.Len(t, g.Teams, 2)
+	assert.Equal(t, [][]int{{2, 20}, {8, 14}}, teamHandicaps(g))
+	assert.Equal(t, 22, g.Teams[0].CombinedHandicap)
+	assert.Equal(t, 22, g.Teams[1].CombinedHandicap)
+	assert.Equal(t, 0, g.HandicapGap)
+	assert.Equal(t, "Group 2 A", g.Teams[0].Name)
+	assert.Equal(t, "Group 2 B", g.Teams[1].Name)
+}
+
+func TestSplitGroupTeams_SmallestGapWhenUneven(t *testing.T) {
+	g := splitGroupTeams(models.Group{GroupNumber: 1}, teamPlayers(hcp(0), hcp(1), hcp(5), hcp(30)))
+	assert.Equal(t, [][]int{{0, 30}, {1, 5}}, teamHandicaps(g))
+	assert.Equal(t, 24, g.HandicapGap)
+}
+
+func TestSplitGroupTeams_ThreesomeLargerTeamFirst(t *testing.T) {
+	g := splitGroupTeams(models.Group{GroupNumber: 1}, teamPlayers(hcp(10), hcp(12), hcp(20)))
+	require.Len(t, g.Teams[0].Players, 2)
+	require.Len(t, g.Teams[1].Players, 1)
+	assert.Equal(t, [][]int{{10, 12}, {20}}, teamHandicaps(g))
+	assert.Equal(t, 2, g.HandicapGap)
+}
+
+func TestSplitGroupTeams_MissingHandicapCountsAsZero(t *testing.T) {
+	g := splitGroupTeams(models.Group{GroupNumber: 1}, teamPlayers(nil, hcp(10)))
+	assert.Equal(t, 10, g.HandicapGap)
+	assert.Nil(t, g.Teams[0].Players[0].CourseHandicap)
+}
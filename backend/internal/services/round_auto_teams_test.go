@@ -0,0 +1,111 @@
+// services/round_auto_teams_test.go
+// Integration tests for RoundService.GenerateTeams (round_auto_teams.go).
+// Uses testutil.NewTestDB to spin up an ephemeral Postgres container — Docker
+// must be running. Reuses the Vegas fixtures from round_service_vegas_test.go.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// vegasFoursome fills a las_vegas round's group with four players holding the
+// given course handicaps and returns the round, organizer and round_player IDs.
+func vegasFoursome(t *testing.T, db *gorm.DB, svc *services.RoundService, prefix string, handicaps [4]int) (uuid.UUID, models.User, []uuid.UUID) {
+	t.Helper()
+	roundID, groupID, event, organizer := vegasRoundWithGroup(t, svc, services.NewEventService(db), db, prefix)
+	rpIDs := make([]uuid.UUID, 4)
+	for i, h := range handicaps {
+		_, rpID := addVegasPlayer(t, svc, db, roundID, groupID, event.ID, organizer.ID, prefix+string(rune('a'+i)))
+		require.NoError(t, db.Model(&models.RoundPlayer{}).Where("id = ?", rpID).Update("course_handicap", h).Error)
+		rpIDs[i] = rpID
+	}
+	return roundID, organizer, rpIDs
+}
+
+func TestRoundService_GenerateTeams_CommitBalancedVegasTeams(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewRoundService(db, services.NewEventService(db))
+	roundID, organizer, rp := vegasFoursome(t, db, svc, "gt1", [4]int{4, 9, 15, 22})
+
+	// A hand-made team is replaced by the generated ones.
+	_, err := svc.CreateTeam(context.Background(), roundID, organizer.ID, "user", "Old Team")
+	require.NoError(t, err)
+
+	result, err := svc.GenerateTeams(context.Background(), roundID, organizer.ID, "user", services.GenerateTeamsInput{})
+	require.NoError(t, err)
+	require.Len(t, result.Groups, 1)
+	assert.Equal(t, 2, result.Groups[0].HandicapGap) // 4+22 vs 9+15
+
+	teams, err := svc.ListTeams(context.Background(), roundID, organizer.ID, "user")
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	byTeam := map[string][]string{}
+	for _, team := range teams {
+		assert.NotEqual(t, "Old Team", team.Team.Name)
+		for _, m := range team.Members {
+			byTeam[team.Team.Name] = append(byTeam[team.Team.Name], m.RoundPlayerID)
+		}
+	}
+	assert.ElementsMatch(t, []string{rp[0].String(), rp[3].String()}, byTeam[result.Groups[0].Teams[0].Name])
+	assert.ElementsMatch(t, []string{rp[1].String(), rp[2].String()}, byTeam[result.Groups[0].Teams[1].Name])
+}
+
+func TestRoundService_GenerateTeams_PreviewSavesNothing(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewRoundService(db, services.NewEventService(db))
+	roundID, organizer, _ := vegasFoursome(t, db, svc, "gt2", [4]int{1, 2, 3, 4})
+
+	result, err := svc.GenerateTeams(context.Background(), roundID, organizer.ID, "user", services.GenerateTeamsInput{Preview: true})
+	require.NoError(t, err)
+	require.Len(t, result.Groups, 1)
+	assert.Nil(t, result.Groups[0].Teams[0].TeamID)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Team{}).Where("round_id = ?", roundID).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestRoundService_GenerateTeams_VegasThreesomeRejected(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	eventSvc := services.NewEventService(db)
+	svc := services.NewRoundService(db, eventSvc)
+	roundID, groupID, event, organizer := vegasRoundWithGroup(t, svc, eventSvc, db, "gt3")
+	for _, name := range []string{"gt3a", "gt3b", "gt3c"} {
+		addVegasPlayer(t, svc, db, roundID, groupID, event.ID, organizer.ID, name)
+	}
+
+	_, err := svc.GenerateTeams(context.Background(), roundID, organizer.ID, "user", services.GenerateTeamsInput{Preview: true})
+	var ve *services.ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestRoundService_GenerateTeams_StrokeRoundRejected(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc, round, organizer := roundWithGroups(t, db, 1)
+
+	_, err := svc.GenerateTeams(context.Background(), round.ID, organizer.ID, "user", services.GenerateTeamsInput{})
+	var ve *services.ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestRoundService_GenerateTeams_StartedRound(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := services.NewRoundService(db, services.NewEventService(db))
+	roundID, organizer, _ := vegasFoursome(t, db, svc, "gt5", [4]int{1, 2, 3, 4})
+	require.NoError(t, db.Model(&models.Round{}).Where("id = ?", roundID).Update("status", models.RoundStatusActive).Error)
+
+	_, err := svc.GenerateTeams(context.Background(), roundID, organizer.ID, "user", services.GenerateTeamsInput{})
+	assert.ErrorIs(t, err, services.ErrRoundStarted)
+}
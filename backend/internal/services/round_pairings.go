@@ -0,0 +1,598 @@
+// services/round_pairings.go
+// Automatic pairings for a round's tee-time groups. Instead of building groups
+// one player at a time (CreateGroup / AddGroupMember), an organizer picks a
+// strategy and GeneratePairings draws the whole field into groups of at most 4:
+//
+//   - random            — a straight shuffle
+//   - handicap_balanced — a snake draft by handicap index, so group averages are close
+//   - abcd              — the field split into A/B/C/D bands by handicap; each group
+//     takes one player from each band
+//   - minimize_repeats  — players who shared a group in the event's last N rounds
+//     are kept apart where possible
+//
+// Group sizes are as even as possible (10 players → 4, 3, 3). Every draw is
+// driven by a seed: a preview returns nothing but the proposed groups and the
+// seed, and committing with that seed reproduces the same groups as long as the
+// field has not changed.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrRoundStarted — the round is active or completed, so its groups are locked.
+var ErrRoundStarted = errors.New("round has already started")
+
+// PairingStrategy selects how GeneratePairings draws groups.
+type PairingStrategy string
+
+const (
+	PairingStrategyRandom           PairingStrategy = "random"
+	PairingStrategyHandicapBalanced PairingStrategy = "handicap_balanced"
+	PairingStrategyABCD             PairingStrategy = "abcd"
+	PairingStrategyMinimizeRepeats  PairingStrategy = "minimize_repeats"
+)
+
+const (
+	// defaultPairingLookback is how many earlier rounds minimize_repeats checks.
+	defaultPairingLookback = 5
+	// maxPairingLookback caps LookbackRounds at a season of weekly rounds.
+	maxPairingLookback = 52
+	// maxPairingSeed keeps seeds exact in JSON clients (2^53).
+	maxPairingSeed = 1 << 53
+)
+
+// GeneratePairingsInput is the payload for GeneratePairings.
+type GeneratePairingsInput struct {
+	Strategy       string // blank = random
+	GroupSize      int    // largest group, 2–4; 0 = 4
+	LookbackRounds int    // minimize_repeats only; 0 = 5
+	Seed           *int64 // nil = a fresh draw; pass a preview's Seed to commit the same groups
+	Preview        bool   // true = return the proposed groups without saving them
+}
+
+// PairingsResult is returned by GeneratePairings. RepeatPairings counts pairs
+// of players grouped together who also shared a group in the lookback window
+// (minimize_repeats only).
+type PairingsResult struct {
+	Strategy       string
+	Seed           int64
+	Preview        bool
+	RepeatPairings int
+	Groups         []PairingGroupResult
+}
+
+// PairingGroupResult is one proposed or committed group. GroupID is nil in a preview.
+type PairingGroupResult struct {
+	GroupID         *uuid.UUID
+	GroupNumber     int
+	AverageHandicap *float64 // nil when no player in the group has an index
+	RepeatPairings  int
+	Players         []PairingPlayerResult
+}
+
+// PairingPlayerResult is one player in a group. RoundPlayerID is nil in a
+// preview for event members not yet registered for the round. Band is the
+// player's ABCD band ("A" = lowest handicaps), empty for other strategies.
+type PairingPlayerResult struct {
+	UserID        uuid.UUID
+	RoundPlayerID *uuid.UUID
+	DisplayName   string
+	IsGuest       bool
+	HandicapIndex *float64
+	Band          string
+}
+
+// pairingCandidate is one player in the field being drawn.
+type pairingCandidate struct {
+	UserID        uuid.UUID
+	EventPlayerID *uuid.UUID
+	RoundPlayerID *uuid.UUID
+	DisplayName   string
+	IsGuest       bool
+	HandicapIndex *float64
+}
+
+// pairKey is an order-independent key for two players.
+type pairKey [2]uuid.UUID
+
+func newPairKey(a, b uuid.UUID) pairKey {
+	if strings.Compare(a.String(), b.String()) > 0 {
+		a, b = b, a
+	}
+	return pairKey{a, b}
+}
+
+// GeneratePairings draws the round's field into groups. The field is every
+// player already registered for the round (guests included) plus, on an
+// event-linked round, every registered event member not yet in it.
+//
+// Unless in.Preview is set, the draw replaces the round's groups: existing
+// groups are reused in order (keeping their tee times), extra ones are
+// created or deleted, members of the event join the round, and any Las Vegas
+// teams are cleared because they no longer match the groups. Organizer-only,
+// and only while the round is still scheduled.
+func (s *RoundService) GeneratePairings(ctx context.Context, roundID, callerID uuid.UUID, callerRole string, in GeneratePairingsInput) (PairingsResult, error) {
+	strategy, groupSize, lookback, err := normalizePairingsInput(in)
+	if err != nil {
+		return PairingsResult{}, err
+	}
+
+	isOrg, err := s.requireRoundOrganizer(ctx, roundID, callerID, callerRole)
+	if err != nil {
+		return PairingsResult{}, err
+	}
+	if !isOrg {
+		return PairingsResult{}, ErrRoundForbidden
+	}
+	var round models.Round
+	if err := s.DB.WithContext(ctx).First(&round, "id = ?", roundID).Error; err != nil {
+		return PairingsResult{}, fmt.Errorf("load round: %w", err)
+	}
+	if round.Status != models.RoundStatusScheduled {
+		return PairingsResult{}, ErrRoundStarted
+	}
+
+	field, err := s.pairingField(ctx, round)
+	if err != nil {
+		return PairingsResult{}, err
+	}
+	if len(field) == 0 {
+		return PairingsResult{}, &ValidationError{Field: "round", Message: "round has no players to pair"}
+	}
+	var history map[pairKey]int
+	if strategy == PairingStrategyMinimizeRepeats {
+		if history, err = s.recentPairings(ctx, round, field, lookback); err != nil {
+			return PairingsResult{}, err
+		}
+	}
+
+	seed := rand.Int64N(maxPairingSeed)
+	if in.Seed != nil {
+		seed = *in.Seed
+	}
+	rng := rand.New(rand.NewPCG(uint64(seed), 0))
+	groups, bands := drawPairings(field, strategy, pairingGroupSizes(len(field), groupSize), rng, history)
+
+	var saved []models.Group
+	if !in.Preview {
+		if saved, err = s.commitPairings(ctx, round, groups); err != nil {
+			return PairingsResult{}, err
+		}
+		var placed []uuid.UUID
+		for _, group := range groups {
+			for _, p := range group {
+				placed = append(placed, p.UserID)
+			}
+		}
+		notify(ctx, s.DB, models.Notification{
+			Type: models.NotificationTypeGroupAssigned, ActorID: &callerID, EventID: round.EventID, RoundID: &round.ID,
+		}, placed)
+	}
+	return buildPairingsResult(strategy, seed, groups, saved, bands, history), nil
+}
+
+// normalizePairingsInput validates the input and applies defaults.
+func normalizePairingsInput(in GeneratePairingsInput) (PairingStrategy, int, int, error) {
+	strategy := PairingStrategy(in.Strategy)
+	switch strategy {
+	case "":
+		strategy = PairingStrategyRandom
+	case PairingStrategyRandom, PairingStrategyHandicapBalanced, PairingStrategyABCD, PairingStrategyMinimizeRepeats:
+	default:
+		return "", 0, 0, &ValidationError{
+			Field:   "strategy",
+			Message: "strategy must be 'random', 'handicap_balanced', 'abcd' or 'minimize_repeats'",
+		}
+	}
+	groupSize := in.GroupSize
+	if groupSize == 0 {
+		groupSize = 4
+	}
+	if groupSize < 2 || groupSize > 4 {
+		return "", 0, 0, &ValidationError{Field: "group_size", Message: "group_size must be between 2 and 4"}
+	}
+	lookback := in.LookbackRounds
+	if lookback == 0 {
+		lookback = defaultPairingLookback
+	}
+	if lookback < 1 || lookback > maxPairingLookback {
+		return "", 0, 0, &ValidationError{
+			Field:   "lookback_rounds",
+			Message: fmt.Sprintf("lookback_rounds must be between 1 and %d", maxPairingLookback),
+		}
+	}
+	if in.Seed != nil && (*in.Seed < 0 || *in.Seed >= maxPairingSeed) {
+		return "", 0, 0, &ValidationError{Field: "seed", Message: "seed is out of range"}
+	}
+	return strategy, groupSize, lookback, nil
+}
+
+// pairingField loads the players to draw, ordered by name so a seed always
+// produces the same draw for the same field. Substitutes only join the draw
+// once they're in the round.
+func (s *RoundService) pairingField(ctx context.Context, round models.Round) ([]pairingCandidate, error) {
+	var field []pairingCandidate
+	if err := s.DB.WithContext(ctx).Table("round_players rp").
+		Select("rp.user_id, rp.event_player_id, rp.id AS round_player_id, u.display_name, u.is_guest, rp.handicap_index").
+		Joins("JOIN users u ON u.id = rp.user_id").
+		Where("rp.round_id = ? AND rp.status NOT IN ?", round.ID, models.RoundPlayerOutStatuses).
+		Scan(&field).Error; err != nil {
+		return nil, fmt.Errorf("load round players: %w", err)
+	}
+	if round.EventID != nil {
+		var members []pairingCandidate
+		if err := s.DB.WithContext(ctx).Table("event_players ep").
+			Select("ep.user_id, ep.id AS event_player_id, u.display_name, u.is_guest").
+			Joins("JOIN users u ON u.id = ep.user_id").
+			Where("ep.event_id = ? AND ep.status = ? AND NOT ep.is_substitute", *round.EventID, models.EventPlayerStatusRegistered).
+			Where("NOT EXISTS (SELECT 1 FROM round_players rp WHERE rp.round_id = ? AND rp.event_player_id = ep.id)", round.ID).
+			Scan(&members).Error; err != nil {
+			return nil, fmt.Errorf("load event members: %w", err)
+		}
+		field = append(field, members...)
+	}
+
+	var missing []uuid.UUID
+	for _, p := range field {
+		if p.HandicapIndex == nil && !p.IsGuest {
+			missing = append(missing, p.UserID)
+		}
+	}
+	if len(missing) > 0 {
+		indexes, err := latestHandicapIndexes(ctx, s.DB, missing)
+		if err != nil {
+			return nil, err
+		}
+		for i := range field {
+			if idx, ok := indexes[field[i].UserID]; ok && field[i].HandicapIndex == nil {
+				field[i].HandicapIndex = &idx
+			}
+		}
+	}
+
+	sort.Slice(field, func(i, j int) bool {
+		if field[i].DisplayName != field[j].DisplayName {
+			return field[i].DisplayName < field[j].DisplayName
+		}
+		return field[i].UserID.String() < field[j].UserID.String()
+	})
+	return field, nil
+}
+
+// recentPairings counts how often each pair of players in the field shared a
+// group over the last lookback rounds before this one — the event's rounds
+// for an event-linked round, otherwise any round the players played.
+func (s *RoundService) recentPairings(ctx context.Context, round models.Round, field []pairingCandidate, lookback int) (map[pairKey]int, error) {
+	userIDs := make([]uuid.UUID, len(field))
+	for i, p := range field {
+		userIDs[i] = p.UserID
+	}
+
+	q := s.DB.WithContext(ctx).Model(&models.Round{}).
+		Where("rounds.id <> ? AND rounds.scheduled_date <= ?", round.ID, round.ScheduledDate).
+		Where(`EXISTS (SELECT 1 FROM group_players gp
+			JOIN groups g ON g.id = gp.group_id
+			JOIN round_players rp ON rp.id = gp.round_player_id
+			WHERE g.round_id = rounds.id AND rp.user_id IN ?)`, userIDs)
+	if round.EventID != nil {
+		q = q.Where("rounds.event_id = ?", *round.EventID)
+	}
+	var roundIDs []uuid.UUID
+	if err := q.Order("rounds.scheduled_date DESC, rounds.created_at DESC").
+		Limit(lookback).Pluck("rounds.id", &roundIDs).Error; err != nil {
+		return nil, fmt.Errorf("load recent rounds: %w", err)
+	}
+	history := make(map[pairKey]int)
+	if len(roundIDs) == 0 {
+		return history, nil
+	}
+
+	type memberRow struct {
+		GroupID uuid.UUID
+		UserID  uuid.UUID
+	}
+	var rows []memberRow
+	if err := s.DB.WithContext(ctx).Table("group_players gp").
+		Select("gp.group_id, rp.user_id").
+		Joins("JOIN groups g ON g.id = gp.group_id").
+		Joins("JOIN round_players rp ON rp.id = gp.round_player_id").
+		Where("g.round_id IN ? AND rp.user_id IN ?", roundIDs, userIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load recent groups: %w", err)
+	}
+	byGroup := make(map[uuid.UUID][]uuid.UUID)
+	for _, r := range rows {
+		byGroup[r.GroupID] = append(byGroup[r.GroupID], r.UserID)
+	}
+	for _, members := range byGroup {
+		for i := range members {
+			for j := i + 1; j < len(members); j++ {
+				history[newPairKey(members[i], members[j])]++
+			}
+		}
+	}
+	return history, nil
+}
+
+// commitPairings replaces the round's groups with the drawn ones and returns
+// the saved groups in draw order.
+func (s *RoundService) commitPairings(ctx context.Context, round models.Round, groups [][]pairingCandidate) ([]models.Group, error) {
+	saved := make([]models.Group, len(groups))
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Members of the event who aren't in the round yet join it.
+		for _, group := range groups {
+			for i, p := range group {
+				if p.RoundPlayerID != nil {
+					continue
+				}
+				rp := models.RoundPlayer{
+					RoundID: round.ID, UserID: p.UserID, EventPlayerID: p.EventPlayerID,
+					Status: models.RoundPlayerStatusRegistered,
+				}
+				if err := tx.Omit(clause.Associations).Create(&rp).Error; err != nil {
+					return fmt.Errorf("create round player: %w", err)
+				}
+				group[i].RoundPlayerID = &rp.ID
+			}
+		}
+
+		var existing []models.Group
+		if err := tx.Where("round_id = ?", round.ID).Order("group_number ASC").Find(&existing).Error; err != nil {
+			return fmt.Errorf("load groups: %w", err)
+		}
+		if err := tx.Where("round_id = ?", round.ID).Delete(&models.Team{}).Error; err != nil {
+			return fmt.Errorf("clear teams: %w", err)
+		}
+		if err := tx.Where("group_id IN (?)", tx.Model(&models.Group{}).Select("id").Where("round_id = ?", round.ID)).
+			Delete(&models.GroupPlayer{}).Error; err != nil {
+			return fmt.Errorf("clear groups: %w", err)
+		}
+
+		nextNumber := 1
+		if len(existing) > 0 {
+			nextNumber = existing[len(existing)-1].GroupNumber + 1
+		}
+		for i := range groups {
+			if i < len(existing) {
+				saved[i] = existing[i]
+				continue
+			}
+			group := models.Group{RoundID: round.ID, GroupNumber: nextNumber, StartingHole: 1}
+			if err := tx.Omit(clause.Associations).Create(&group).Error; err != nil {
+				return fmt.Errorf("create group %d: %w", nextNumber, err)
+			}
+			nextNumber++
+			saved[i] = group
+		}
+		for _, extra := range existing[min(len(groups), len(existing)):] {
+			if err := tx.Delete(&models.Group{}, "id = ?", extra.ID).Error; err != nil {
+				return fmt.Errorf("delete group %d: %w", extra.GroupNumber, err)
+			}
+		}
+
+		var rows []models.GroupPlayer
+		for i, group := range groups {
+			for _, p := range group {
+				rows = append(rows, models.GroupPlayer{GroupID: saved[i].ID, RoundPlayerID: *p.RoundPlayerID})
+			}
+		}
+		if err := tx.Omit(clause.Associations).Create(&rows).Error; err != nil {
+			return fmt.Errorf("assign group players: %w", err)
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("commit pairings: %w", txErr)
+	}
+	return saved, nil
+}
+
+// ─── Drawing ──────────────────────────────────────────────────────────────────
+
+// pairingGroupSizes splits n players into as few groups of at most maxSize as
+// possible, as evenly as possible, larger groups first: 10 by 4 → [4 3 3].
+func pairingGroupSizes(n, maxSize int) []int {
+	if n == 0 {
+		return nil
+	}
+	count := (n + maxSize - 1) / maxSize
+	sizes := make([]int, count)
+	for i := range sizes {
+		sizes[i] = n / count
+		if i < n%count {
+			sizes[i]++
+		}
+	}
+	return sizes
+}
+
+// drawPairings deals the field into groups of the given sizes. bands maps each
+// player to an ABCD band letter (abcd only). history is only read by
+// minimize_repeats.
+func drawPairings(field []pairingCandidate, strategy PairingStrategy, sizes []int, rng *rand.Rand, history map[pairKey]int) ([][]pairingCandidate, map[uuid.UUID]string) {
+	players := make([]pairingCandidate, len(field))
+	copy(players, field)
+	rng.Shuffle(len(players), func(i, j int) { players[i], players[j] = players[j], players[i] })
+
+	switch strategy {
+	case PairingStrategyHandicapBalanced:
+		return snakeDraft(byHandicap(players), sizes), nil
+	case PairingStrategyABCD:
+		return bandDraft(byHandicap(players), sizes, rng)
+	case PairingStrategyMinimizeRepeats:
+		return spreadRepeats(players, sizes, history), nil
+	}
+	groups := make([][]pairingCandidate, len(sizes))
+	for i, size := range sizes {
+		groups[i], players = players[:size], players[size:]
+	}
+	return groups, nil
+}
+
+// byHandicap sorts players lowest index first; players without an index go
+// last. The sort is stable so ties keep their shuffled order.
+func byHandicap(players []pairingCandidate) []pairingCandidate {
+	sort.SliceStable(players, func(i, j int) bool {
+		a, b := players[i].HandicapIndex, players[j].HandicapIndex
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+	return players
+}
+
+// snakeDraft deals sorted players across the groups and back again
+// (1, 2, 3, 3, 2, 1, …), skipping full groups, so every group gets a similar
+// spread of handicaps.
+func snakeDraft(sorted []pairingCandidate, sizes []int) [][]pairingCandidate {
+	groups := make([][]pairingCandidate, len(sizes))
+	g, dir := 0, 1
+	step := func() {
+		if next := g + dir; next < 0 || next >= len(sizes) {
+			dir = -dir
+		} else {
+			g = next
+		}
+	}
+	for _, p := range sorted {
+		for len(groups[g]) >= sizes[g] {
+			step()
+		}
+		groups[g] = append(groups[g], p)
+		step()
+	}
+	return groups
+}
+
+// bandDraft splits sorted players into bands of one player per group (A = the
+// lowest handicaps) and gives each group one player from each band, drawn at
+// random within the band.
+func bandDraft(sorted []pairingCandidate, sizes []int, rng *rand.Rand) ([][]pairingCandidate, map[uuid.UUID]string) {
+	groups := make([][]pairingCandidate, len(sizes))
+	bands := make(map[uuid.UUID]string, len(sorted))
+	for b := 0; len(sorted) > 0; b++ {
+		band := sorted[:min(len(sizes), len(sorted))]
+		sorted = sorted[len(band):]
+		rng.Shuffle(len(band), func(i, j int) { band[i], band[j] = band[j], band[i] })
+		// sizes are largest first, so a short last band fills the first groups.
+		for i, p := range band {
+			groups[i] = append(groups[i], p)
+			bands[p.UserID] = string(rune('A' + b))
+		}
+	}
+	return groups, bands
+}
+
+// spreadRepeats builds groups greedily — each group starts from the next
+// shuffled player and adds whoever has played least with its members — then
+// swaps players between groups while that lowers the total repeat count.
+func spreadRepeats(players []pairingCandidate, sizes []int, history map[pairKey]int) [][]pairingCandidate {
+	cost := func(p pairingCandidate, group []pairingCandidate) int {
+		total := 0
+		for _, o := range group {
+			if o.UserID != p.UserID {
+				total += history[newPairKey(p.UserID, o.UserID)]
+			}
+		}
+		return total
+	}
+
+	groups := make([][]pairingCandidate, len(sizes))
+	remaining := players
+	for g, size := range sizes {
+		group := []pairingCandidate{remaining[0]}
+		remaining = remaining[1:]
+		for len(group) < size {
+			best := 0
+			for j := 1; j < len(remaining); j++ {
+				if cost(remaining[j], group) < cost(remaining[best], group) {
+					best = j
+				}
+			}
+			group = append(group, remaining[best])
+			remaining = append(remaining[:best:best], remaining[best+1:]...)
+		}
+		groups[g] = group
+	}
+
+	// Local improvement; bounded so a pathological history can't spin.
+	for pass := 0; pass < 20; pass++ {
+		improved := false
+		for a := range groups {
+			for b := a + 1; b < len(groups); b++ {
+				for i := range groups[a] {
+					for j := range groups[b] {
+						pa, pb := groups[a][i], groups[b][j]
+						before := cost(pa, groups[a]) + cost(pb, groups[b])
+						groups[a][i], groups[b][j] = pb, pa
+						after := cost(pb, groups[a]) + cost(pa, groups[b])
+						if after < before {
+							improved = true
+						} else {
+							groups[a][i], groups[b][j] = pa, pb
+						}
+					}
+				}
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return groups
+}
+
+// groupRepeats counts the repeat pairings within one group.
+func groupRepeats(group []pairingCandidate, history map[pairKey]int) int {
+	total := 0
+	for i := range group {
+		for j := i + 1; j < len(group); j++ {
+			total += history[newPairKey(group[i].UserID, group[j].UserID)]
+		}
+	}
+	return total
+}
+
+// buildPairingsResult assembles the result. saved is nil for a preview, whose
+// groups are numbered in draw order.
+func buildPairingsResult(strategy PairingStrategy, seed int64, groups [][]pairingCandidate, saved []models.Group, bands map[uuid.UUID]string, history map[pairKey]int) PairingsResult {
+	out := PairingsResult{Strategy: string(strategy), Seed: seed, Preview: saved == nil}
+	for i, group := range groups {
+		gr := PairingGroupResult{GroupNumber: i + 1, RepeatPairings: groupRepeats(group, history)}
+		if saved != nil {
+			gr.GroupID = &saved[i].ID
+			gr.GroupNumber = saved[i].GroupNumber
+		}
+		var sum float64
+		var rated int
+		for _, p := range group {
+			if p.HandicapIndex != nil {
+				sum += *p.HandicapIndex
+				rated++
+			}
+			gr.Players = append(gr.Players, PairingPlayerResult{
+				UserID: p.UserID, RoundPlayerID: p.RoundPlayerID, DisplayName: p.DisplayName,
+				IsGuest: p.IsGuest, HandicapIndex: p.HandicapIndex, Band: bands[p.UserID],
+			})
+		}
+		if rated > 0 {
+			avg := sum / float64(rated)
+			gr.AverageHandicap = &avg
+		}
+		out.RepeatPairings += gr.RepeatPairings
+		out.Groups = append(out.Groups, gr)
+	}
+	return out
+}
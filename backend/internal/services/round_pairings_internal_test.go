@@ -0,0 +1,102 @@
+// services/round_pairings_internal_test.go
+// White-box tests for the unexported draw helpers in round_pairings.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestPairing|TestSnakeDraft|TestBandDraft|TestSpreadRepeats' -v
+package services
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldOf builds n players with handicap indexes 1..n.
+func fieldOf(n int) []pairingCandidate {
+	field := make([]pairingCandidate, n)
+	for i := range field {
+		idx := float64(i + 1)
+		field[i] = pairingCandidate{UserID: uuid.New(), DisplayName: fmt.Sprintf("P%02d", i+1), HandicapIndex: &idx}
+	}
+	return field
+}
+
+// handicapsOf returns each group's handicap indexes.
+func handicapsOf(groups [][]pairingCandidate) [][]float64 {
+	out := make([][]float64, len(groups))
+	for i, g := range groups {
+		for _, p := range g {
+			out[i] = append(out[i], *p.HandicapIndex)
+		}
+	}
+	return out
+}
+
+func TestPairingGroupSizes_EvenSplit(t *testing.T) {
+	assert.Equal(t, []int{4, 3, 3}, pairingGroupSizes(10, 4))
+	assert.Equal(t, []int{4, 4}, pairingGroupSizes(8, 4))
+	assert.Equal(t, []int{3, 2}, pairingGroupSizes(5, 4))
+	assert.Equal(t, []int{2, 2, 1}, pairingGroupSizes(5, 2))
+	assert.Nil(t, pairingGroupSizes(0, 4))
+}
+
+func TestPairingDraw_SameSeedSameGroups(t *testing.T) {
+	field := fieldOf(12)
+	sizes := pairingGroupSizes(12, 4)
+	a, _ := drawPairings(field, PairingStrategyRandom, sizes, rand.New(rand.NewPCG(42, 0)), nil)
+	b, _ := drawPairings(field, PairingStrategyRandom, sizes, rand.New(rand.NewPCG(42, 0)), nil)
+	assert.Equal(t, a, b)
+}
+
+func TestSnakeDraft_BalancesGroups(t *testing.T) {
+	groups := snakeDraft(byHandicap(fieldOf(8)), []int{4, 4})
+	// 1,4,5,8 and 2,3,6,7 — both groups total 18.
+	assert.Equal(t, [][]float64{{1, 4, 5, 8}, {2, 3, 6, 7}}, handicapsOf(groups))
+}
+
+func TestSnakeDraft_UnknownIndexGoesLast(t *testing.T) {
+	field := fieldOf(3)
+	field[0].HandicapIndex = nil
+	sorted := byHandicap(field)
+	assert.Nil(t, sorted[2].HandicapIndex)
+}
+
+func TestBandDraft_OnePlayerPerBand(t *testing.T) {
+	groups, bands := bandDraft(byHandicap(fieldOf(10)), []int{4, 3, 3}, rand.New(rand.NewPCG(7, 0)))
+	require.Len(t, groups, 3)
+	for gi, g := range groups {
+		seen := map[string]bool{}
+		for _, p := range g {
+			band := bands[p.UserID]
+			assert.False(t, seen[band], "group %d has two %s players", gi+1, band)
+			seen[band] = true
+			// Bands of three: A = 1–3, B = 4–6, C = 7–9, D = 10.
+			assert.Equal(t, string(rune('A'+int(*p.HandicapIndex-1)/3)), band)
+		}
+	}
+	assert.Len(t, groups[0], 4)
+}
+
+func TestSpreadRepeats_AvoidsLastWeeksGroups(t *testing.T) {
+	field := fieldOf(8)
+	history := map[pairKey]int{}
+	// Last week: players 1–4 and 5–8 played together.
+	for _, block := range [][]pairingCandidate{field[:4], field[4:]} {
+		for i := range block {
+			for j := i + 1; j < len(block); j++ {
+				history[newPairKey(block[i].UserID, block[j].UserID)]++
+			}
+		}
+	}
+	groups, _ := drawPairings(field, PairingStrategyMinimizeRepeats, []int{4, 4}, rand.New(rand.NewPCG(1, 0)), history)
+	// Two from each of last week's groups is the best possible: one repeat pair
+	// per old group in each new group.
+	assert.Equal(t, 2, groupRepeats(groups[0], history))
+	assert.Equal(t, 2, groupRepeats(groups[1], history))
+}
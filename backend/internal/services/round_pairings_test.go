@@ -0,0 +1,103 @@
+// services/round_pairings_test.go
+// Integration tests for RoundService.GeneratePairings (round_pairings.go).
+// Uses testutil.NewTestDB to spin up an ephemeral Postgres container — Docker
+// must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// groupedUsers returns each pairing group's user IDs.
+func groupedUsers(result services.PairingsResult) [][]uuid.UUID {
+	out := make([][]uuid.UUID, len(result.Groups))
+	for i, g := range result.Groups {
+		for _, p := range g.Players {
+			out[i] = append(out[i], p.UserID)
+		}
+	}
+	return out
+}
+
+func TestRoundService_GeneratePairings_PreviewThenCommit(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	eventSvc := services.NewEventService(db)
+	svc := services.NewRoundService(db, eventSvc)
+	organizer := seedUser(t, db, "pairingsOrganizer")
+	event := seedEvent(t, eventSvc, organizer.ID)
+	for i := 0; i < 9; i++ {
+		addEventMember(t, db, event.ID, seedUser(t, db, fmt.Sprintf("golfer%d", i)).ID)
+	}
+	course, tee := seedCourseWithTee(t, db, "Pairings Course")
+	round := scheduleRound(t, svc, event.ID, organizer.ID, course.ID.String(), tee.ID.String()).Round
+
+	preview, err := svc.GeneratePairings(ctx, round.ID, organizer.ID, "user", services.GeneratePairingsInput{
+		Strategy: "handicap_balanced", Preview: true,
+	})
+	require.NoError(t, err)
+	assert.True(t, preview.Preview)
+	// The organizer plus nine members: groups of 4, 3 and 3.
+	require.Len(t, preview.Groups, 3)
+	assert.Len(t, preview.Groups[0].Players, 4)
+	assert.Nil(t, preview.Groups[0].GroupID)
+
+	var grouped int64
+	require.NoError(t, db.Model(&models.GroupPlayer{}).Count(&grouped).Error)
+	assert.Zero(t, grouped, "a preview saves nothing")
+
+	committed, err := svc.GeneratePairings(ctx, round.ID, organizer.ID, "user", services.GeneratePairingsInput{
+		Strategy: "handicap_balanced", Seed: &preview.Seed,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, groupedUsers(preview), groupedUsers(committed))
+	require.NotNil(t, committed.Groups[0].GroupID)
+	assert.Equal(t, 1, committed.Groups[0].GroupNumber)
+
+	require.NoError(t, db.Model(&models.GroupPlayer{}).Count(&grouped).Error)
+	assert.Equal(t, int64(10), grouped)
+	var groups int64
+	require.NoError(t, db.Model(&models.Group{}).Where("round_id = ?", round.ID).Count(&groups).Error)
+	assert.Equal(t, int64(3), groups)
+}
+
+func TestRoundService_GeneratePairings_StartedRoundLocked(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	eventSvc := services.NewEventService(db)
+	svc := services.NewRoundService(db, eventSvc)
+	organizer := seedUser(t, db, "lateOrganizer")
+	event := seedEvent(t, eventSvc, organizer.ID)
+	course, tee := seedCourseWithTee(t, db, "Started Course")
+	round := scheduleRound(t, svc, event.ID, organizer.ID, course.ID.String(), tee.ID.String()).Round
+	require.NoError(t, db.Model(&round).Update("status", models.RoundStatusActive).Error)
+
+	_, err := svc.GeneratePairings(context.Background(), round.ID, organizer.ID, "user", services.GeneratePairingsInput{Preview: true})
+	assert.ErrorIs(t, err, services.ErrRoundStarted)
+}
+
+func TestRoundService_GeneratePairings_NonOrganizerForbidden(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	eventSvc := services.NewEventService(db)
+	svc := services.NewRoundService(db, eventSvc)
+	organizer := seedUser(t, db, "realOrganizer")
+	event := seedEvent(t, eventSvc, organizer.ID)
+	course, tee := seedCourseWithTee(t, db, "Forbidden Course")
+	round := scheduleRound(t, svc, event.ID, organizer.ID, course.ID.String(), tee.ID.String()).Round
+	player := seedUser(t, db, "justAPlayer")
+	addEventMember(t, db, event.ID, player.ID)
+
+	_, err := svc.GeneratePairings(context.Background(), round.ID, player.ID, "user", services.GeneratePairingsInput{Preview: true})
+	assert.ErrorIs(t, err, services.ErrRoundForbidden)
+}
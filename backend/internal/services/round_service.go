@@ -28,6 +28,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/trentd187/golf-league/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ─── Field-name constants ─────────────────────────────────────────────────────
@@ -193,13 +194,16 @@ type MyRoundResult struct {
 	CourseRating float64
 	SlopeRating  int
 	GroupCount   int
+	Engagement   RoundEngagement
 }
 
-// RoundDetailResult is returned by Get: the round, its organizer flag, and groups.
+// RoundDetailResult is returned by Get: the round, its organizer flag, groups,
+// and comment and reaction counts.
 type RoundDetailResult struct {
 	Round       models.Round
 	IsOrganizer bool
 	Groups      []GroupDetailResult
+	Engagement  RoundEngagement
 }
 
 // GroupDetailResult is one tee-time group with its current players.
@@ -215,11 +219,12 @@ type GroupMutationResult struct {
 }
 
 // GroupPlayerResult is one player within a group, joined from group_players → round_players → users.
+// Email is nil when the player hides it from the caller.
 type GroupPlayerResult struct {
 	RoundPlayerID string
 	UserID        string
 	DisplayName   string
-	Email         string
+	Email         *string
 	AvatarURL     *string
 	IsGuest       bool
 }
@@ -319,6 +324,10 @@ func (s *RoundService) GetMyRounds(ctx context.Context, userID uuid.UUID) ([]MyR
 	for _, row := range countRows {
 		countMap[row.RoundID] = row.Count
 	}
+	engagement, err := roundEngagementCounts(ctx, s.DB, roundIDs)
+	if err != nil {
+		return nil, err
+	}
 
 	out := make([]MyRoundResult, len(rounds))
 	for i, r := range rounds {
@@ -336,6 +345,7 @@ func (s *RoundService) GetMyRounds(ctx context.Context, userID uuid.UUID) ([]MyR
 			CourseRating: r.DefaultTee.CourseRating,
 			SlopeRating:  r.DefaultTee.SlopeRating,
 			GroupCount:   countMap[r.ID.String()],
+			Engagement:   engagement[r.ID],
 		}
 	}
 	return out, nil
@@ -364,17 +374,22 @@ func (s *RoundService) Get(ctx context.Context, roundID, callerID uuid.UUID, cal
 
 	groupResults := make([]GroupDetailResult, len(groups))
 	for i, g := range groups {
-		players, err := s.loadGroupPlayers(ctx, g.ID)
+		players, err := s.loadGroupPlayers(ctx, g.ID, callerID)
 		if err != nil {
 			return RoundDetailResult{}, err
 		}
 		groupResults[i] = GroupDetailResult{Group: g, Players: players}
 	}
+	engagement, err := roundEngagementCounts(ctx, s.DB, []uuid.UUID{roundID})
+	if err != nil {
+		return RoundDetailResult{}, err
+	}
 
 	return RoundDetailResult{
 		Round:       round,
 		IsOrganizer: isOrg,
 		Groups:      groupResults,
+		Engagement:  engagement[roundID],
 	}, nil
 }
 
@@ -635,9 +650,6 @@ func (s *RoundService) Update(ctx context.Context, roundID, callerID uuid.UUID,
 	if in.ScoringFormat != nil && *in.ScoringFormat != "" {
 		round.ScoringFormat = models.ScoringFormat(*in.ScoringFormat)
 	}
-	if in.Status != nil {
-		round.Status = models.RoundStatus(*in.Status)
-	}
 	if in.VegasBirdieFlip != nil {
 		round.VegasBirdieFlip = *in.VegasBirdieFlip
 	}
@@ -714,8 +726,70 @@ func (s *RoundService) Update(ctx context.Context, roundID, callerID uuid.UUID,
 		}
 	}
 
-	if err := s.DB.WithContext(ctx).Save(&round).Error; err != nil {
-		return RoundUpdateResult{}, fmt.Errorf("save round: %w", err)
+	var wasActive, wasCompleted bool
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A status change takes the event lock first, as FinalizeEvent does, so
+		// a round can't reopen between its open-rounds count and its commit.
+		if in.Status != nil && round.EventID != nil {
+			if _, err := lockEvent(tx, *round.EventID); err != nil {
+				return err
+			}
+		}
+		// Read the status under a row lock so two concurrent completions can't
+		// both see the round as still open and decide its matches twice.
+		var current models.Round
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("status").First(&current, "id = ?", roundID).Error; err != nil {
+			return fmt.Errorf("lock round: %w", err)
+		}
+		wasActive = current.Status == models.RoundStatusActive
+		wasCompleted = current.Status == models.RoundStatusCompleted
+		round.Status = current.Status
+		if in.Status != nil {
+			round.Status = models.RoundStatus(*in.Status)
+		}
+		if err := tx.Save(&round).Error; err != nil {
+			return fmt.Errorf("save round: %w", err)
+		}
+		// Completing a round fixes its finish and decides the bracket and cup
+		// matches played in it, in the same transaction as the status change.
+		if !wasCompleted && round.Status == models.RoundStatusCompleted {
+			if err := saveRoundFinishPositions(ctx, tx, round.ID); err != nil {
+				return err
+			}
+			if err := advanceBracketRound(ctx, tx, round.ID); err != nil {
+				return err
+			}
+			if err := scoreCupRound(ctx, tx, round.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return RoundUpdateResult{}, txErr
+	}
+	// Going active fixes each player's flight for this round.
+	if !wasActive && round.Status == models.RoundStatusActive && round.EventID != nil {
+		if err := s.EventSvc.SnapshotRoundFlights(ctx, round.ID, *round.EventID); err != nil {
+			return RoundUpdateResult{}, err
+		}
+	}
+	if !wasCompleted && round.Status == models.RoundStatusCompleted {
+		recordRoundActivity(ctx, s.DB, round.ID)
+	}
+	// Players hear when their round goes live and when it's done.
+	var statusNote models.NotificationType
+	switch {
+	case !wasActive && round.Status == models.RoundStatusActive:
+		statusNote = models.NotificationTypeRoundStarted
+	case !wasCompleted && round.Status == models.RoundStatusCompleted:
+		statusNote = models.NotificationTypeRoundCompleted
+	}
+	if statusNote != "" {
+		notify(ctx, s.DB, models.Notification{
+			Type: statusNote, ActorID: &callerID, EventID: round.EventID, RoundID: &round.ID,
+		}, roundPlayerIDs(s.DB, round.ID))
 	}
 
 	// Reload for the fresh course name after a potential course change.
@@ -772,7 +846,7 @@ func (s *RoundService) CreateGroup(ctx context.Context, roundID, callerID uuid.U
 		return GroupMutationResult{}, fmt.Errorf("create group: %w", err)
 	}
 
-	players, err := s.loadGroupPlayers(ctx, group.ID)
+	players, err := s.loadGroupPlayers(ctx, group.ID, callerID)
 	if err != nil {
 		return GroupMutationResult{}, err
 	}
@@ -836,7 +910,7 @@ func (s *RoundService) UpdateGroup(ctx context.Context, roundID, groupID, caller
 		return GroupMutationResult{}, fmt.Errorf("save group: %w", err)
 	}
 
-	players, err := s.loadGroupPlayers(ctx, group.ID)
+	players, err := s.loadGroupPlayers(ctx, group.ID, callerID)
 	if err != nil {
 		return GroupMutationResult{}, err
 	}
@@ -963,8 +1037,11 @@ func (s *RoundService) AddGroupMember(ctx context.Context, roundID, groupID, cal
 	if err := s.DB.WithContext(ctx).Create(&gp).Error; err != nil {
 		return GroupMutationResult{}, fmt.Errorf("add group player: %w", err)
 	}
+	notify(ctx, s.DB, models.Notification{
+		Type: models.NotificationTypeGroupAssigned, ActorID: &callerID, EventID: round.EventID, RoundID: &roundID,
+	}, []uuid.UUID{targetUserID})
 
-	players, err := s.loadGroupPlayers(ctx, group.ID)
+	players, err := s.loadGroupPlayers(ctx, group.ID, callerID)
 	if err != nil {
 		return GroupMutationResult{}, err
 	}
@@ -1043,7 +1120,7 @@ func (s *RoundService) AddGuestToGroup(ctx context.Context, roundID, groupID, ca
 		return GroupMutationResult{}, err
 	}
 
-	players, err := s.loadGroupPlayers(ctx, group.ID)
+	players, err := s.loadGroupPlayers(ctx, group.ID, callerID)
 	if err != nil {
 		return GroupMutationResult{}, err
 	}
@@ -1156,7 +1233,7 @@ func (s *RoundService) ListTeams(ctx context.Context, roundID, callerID uuid.UUI
 
 	out := make([]TeamResult, len(teams))
 	for i, t := range teams {
-		members, err := s.loadTeamMembers(ctx, t.ID)
+		members, err := s.loadTeamMembers(ctx, t.ID, callerID)
 		if err != nil {
 			return nil, err
 		}
@@ -1256,7 +1333,7 @@ func (s *RoundService) AssignTeamMembers(ctx context.Context, roundID, teamID, c
 		return TeamResult{}, txErr
 	}
 
-	members, err := s.loadTeamMembers(ctx, teamID)
+	members, err := s.loadTeamMembers(ctx, teamID, callerID)
 	if err != nil {
 		return TeamResult{}, err
 	}
@@ -1301,19 +1378,21 @@ func (s *RoundService) requireRoundOrganizer(ctx context.Context, roundID, calle
 	return isOrg, nil
 }
 
-// loadTeamMembers fetches a team's members via team_members → round_players → users.
-func (s *RoundService) loadTeamMembers(ctx context.Context, teamID uuid.UUID) ([]GroupPlayerResult, error) {
+// loadTeamMembers fetches a team's members via team_members → round_players → users,
+// withholding emails the players hide from callerID.
+func (s *RoundService) loadTeamMembers(ctx context.Context, teamID, callerID uuid.UUID) ([]GroupPlayerResult, error) {
 	type playerRow struct {
 		RoundPlayerID string
 		UserID        string
 		DisplayName   string
-		Email         string
+		Email         *string
 		AvatarURL     *string
 		IsGuest       bool
 	}
 	var rows []playerRow
 	if err := s.DB.WithContext(ctx).Table("team_members tm").
-		Select("tm.round_player_id, u.id as user_id, u.display_name, u.email, u.avatar_url, u.is_guest").
+		Select("tm.round_player_id, u.id as user_id, u.display_name, u.avatar_url, u.is_guest, "+
+			visibleEmailSQL("u.id", "u.email"), callerID).
 		Joins("JOIN round_players rp ON rp.id = tm.round_player_id").
 		Joins("JOIN users u ON u.id = rp.user_id").
 		Where("tm.team_id = ?", teamID).
@@ -1523,18 +1602,20 @@ func (s *RoundService) CreateEventlessRound(ctx context.Context, callerID uuid.U
 // loadGroupPlayers fetches the current players for a group via a raw join.
 // Uses rp.user_id directly — works for both event-linked and eventless rounds
 // after migration 000020 backfilled user_id on all existing round_players.
-func (s *RoundService) loadGroupPlayers(ctx context.Context, groupID uuid.UUID) ([]GroupPlayerResult, error) {
+// Emails the players hide from callerID are withheld.
+func (s *RoundService) loadGroupPlayers(ctx context.Context, groupID, callerID uuid.UUID) ([]GroupPlayerResult, error) {
 	type playerRow struct {
 		RoundPlayerID string
 		UserID        string
 		DisplayName   string
-		Email         string
+		Email         *string
 		AvatarURL     *string
 		IsGuest       bool
 	}
 	var rows []playerRow
 	if err := s.DB.WithContext(ctx).Table("group_players gp").
-		Select("gp.round_player_id, u.id as user_id, u.display_name, u.email, u.avatar_url, u.is_guest").
+		Select("gp.round_player_id, u.id as user_id, u.display_name, u.avatar_url, u.is_guest, "+
+			visibleEmailSQL("u.id", "u.email"), callerID).
 		Joins("JOIN round_players rp ON rp.id = gp.round_player_id").
 		Joins("JOIN users u ON u.id = rp.user_id").
 		Where("gp.group_id = ?", groupID).
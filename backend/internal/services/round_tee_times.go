@@ -0,0 +1,273 @@
+// services/round_tee_times.go
+// Tee sheet generator. Assigns a tee time and starting hole to every group in
+// a round in one call, instead of setting each group by hand:
+//
+//   - interval — groups go off one tee every N minutes from the first tee time;
+//     with a split tee, groups alternate between the 1st and 10th tees so two
+//     groups go off at each time
+//   - shotgun  — every group starts at the same time on its own hole; when there
+//     are more groups than holes, the extra groups double up as A and B groups
+//     on the same hole (the B group follows the A group off the tee)
+//
+// Groups are taken in group_number order. Like pairings, a preview returns the
+// proposed tee sheet without saving it.
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+)
+
+// TeeSheetMode selects how GenerateTeeTimes starts the groups.
+type TeeSheetMode string
+
+const (
+	TeeSheetModeInterval TeeSheetMode = "interval"
+	TeeSheetModeShotgun  TeeSheetMode = "shotgun"
+)
+
+const (
+	// defaultTeeInterval is the gap between interval tee times, in minutes.
+	defaultTeeInterval = 10
+	// maxTeeInterval bounds the gap between interval tee times, in minutes.
+	maxTeeInterval = 30
+	// maxGroupsPerShotgunHole is the A and B group on a doubled-up hole.
+	maxGroupsPerShotgunHole = 2
+)
+
+// GenerateTeeTimesInput is the payload for GenerateTeeTimes.
+type GenerateTeeTimesInput struct {
+	Mode            string // "interval" (default) or "shotgun"
+	FirstTeeTime    string // "HH:MM" or "H:MM AM/PM"; the shotgun time in shotgun mode
+	IntervalMinutes int    // interval only; 0 = 10
+	SplitTee        bool   // interval only; alternate groups between the 1st and 10th tees
+	// ShotgunHoles is an explicit shotgun layout: one starting hole per group in
+	// group order, each hole used at most twice. Empty = holes in order, doubling
+	// up from the first hole when there are more groups than holes.
+	ShotgunHoles []int
+	Preview      bool // true = return the proposed tee sheet without saving it
+}
+
+// TeeSheetResult is returned by GenerateTeeTimes.
+type TeeSheetResult struct {
+	Mode    string
+	Preview bool
+	Groups  []TeeSheetGroupResult
+}
+
+// TeeSheetGroupResult is one group's start. Label names the start as the tee
+// sheet prints it: the hole, with A/B for groups sharing a shotgun hole
+// ("1", "10", "4A", "4B").
+type TeeSheetGroupResult struct {
+	Group   models.Group
+	Label   string
+	Players []GroupPlayerResult
+}
+
+// teeSlot is one computed start.
+type teeSlot struct {
+	Offset time.Duration // after the first tee time
+	Hole   int
+	Label  string
+}
+
+// GenerateTeeTimes sets every group's tee time and starting hole. Organizer-only,
+// and only while the round is still scheduled.
+func (s *RoundService) GenerateTeeTimes(ctx context.Context, roundID, callerID uuid.UUID, callerRole string, in GenerateTeeTimesInput) (TeeSheetResult, error) {
+	mode := TeeSheetMode(in.Mode)
+	switch mode {
+	case "":
+		mode = TeeSheetModeInterval
+	case TeeSheetModeInterval, TeeSheetModeShotgun:
+	default:
+		return TeeSheetResult{}, &ValidationError{Field: "mode", Message: "mode must be 'interval' or 'shotgun'"}
+	}
+	first, err := parseTeeTime(in.FirstTeeTime)
+	if err != nil {
+		return TeeSheetResult{}, &ValidationError{Field: "first_tee_time", Message: "first_tee_time must be HH:MM or H:MM AM/PM"}
+	}
+	interval := in.IntervalMinutes
+	if interval == 0 {
+		interval = defaultTeeInterval
+	}
+	if interval < 1 || interval > maxTeeInterval {
+		return TeeSheetResult{}, &ValidationError{
+			Field:   "interval_minutes",
+			Message: fmt.Sprintf("interval_minutes must be between 1 and %d", maxTeeInterval),
+		}
+	}
+
+	isOrg, err := s.requireRoundOrganizer(ctx, roundID, callerID, callerRole)
+	if err != nil {
+		return TeeSheetResult{}, err
+	}
+	if !isOrg {
+		return TeeSheetResult{}, ErrRoundForbidden
+	}
+	var round models.Round
+	if err := s.DB.WithContext(ctx).Preload("Course").First(&round, "id = ?", roundID).Error; err != nil {
+		return TeeSheetResult{}, fmt.Errorf("load round: %w", err)
+	}
+	if round.Status != models.RoundStatusScheduled {
+		return TeeSheetResult{}, ErrRoundStarted
+	}
+	var groups []models.Group
+	if err := s.DB.WithContext(ctx).Where("round_id = ?", roundID).
+		Order("group_number ASC").Find(&groups).Error; err != nil {
+		return TeeSheetResult{}, fmt.Errorf("load groups: %w", err)
+	}
+	if len(groups) == 0 {
+		return TeeSheetResult{}, &ValidationError{Field: "round", Message: "round has no groups"}
+	}
+
+	firstHole, lastHole := playedHoles(round)
+	var slots []teeSlot
+	if mode == TeeSheetModeShotgun {
+		slots, err = shotgunSlots(len(groups), firstHole, lastHole, in.ShotgunHoles)
+	} else {
+		slots, err = intervalSlots(len(groups), firstHole, lastHole, time.Duration(interval)*time.Minute, in.SplitTee)
+	}
+	if err != nil {
+		return TeeSheetResult{}, err
+	}
+	// Keep the whole sheet on the round's date.
+	if last := first + slots[len(slots)-1].Offset; last >= 24*time.Hour {
+		return TeeSheetResult{}, &ValidationError{Field: "first_tee_time", Message: "the last tee time would fall after midnight"}
+	}
+
+	day := time.Date(round.ScheduledDate.Year(), round.ScheduledDate.Month(), round.ScheduledDate.Day(), 0, 0, 0, 0, time.UTC)
+	for i := range groups {
+		teeTime := day.Add(first + slots[i].Offset)
+		groups[i].TeeTime = &teeTime
+		groups[i].StartingHole = slots[i].Hole
+	}
+
+	if !in.Preview {
+		txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, g := range groups {
+				if err := tx.Model(&models.Group{}).Where("id = ?", g.ID).Updates(map[string]any{
+					"tee_time":      g.TeeTime,
+					"starting_hole": g.StartingHole,
+				}).Error; err != nil {
+					return fmt.Errorf("update group %d: %w", g.GroupNumber, err)
+				}
+			}
+			return nil
+		})
+		if txErr != nil {
+			return TeeSheetResult{}, fmt.Errorf("save tee sheet: %w", txErr)
+		}
+	}
+
+	out := TeeSheetResult{Mode: string(mode), Preview: in.Preview}
+	for i, g := range groups {
+		players, err := s.loadGroupPlayers(ctx, g.ID, callerID)
+		if err != nil {
+			return TeeSheetResult{}, err
+		}
+		out.Groups = append(out.Groups, TeeSheetGroupResult{Group: g, Label: slots[i].Label, Players: players})
+	}
+	return out, nil
+}
+
+// parseTeeTime parses "15:04" or "3:04 PM" into an offset from midnight.
+func parseTeeTime(raw string) (time.Duration, error) {
+	t, err := time.Parse("15:04", raw)
+	if err != nil {
+		if t, err = time.Parse("3:04 PM", raw); err != nil {
+			return 0, err
+		}
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// playedHoles returns the first and last hole the round plays: 1–18, 1–9 on a
+// nine-hole course or the front nine, 10–18 on the back nine.
+func playedHoles(round models.Round) (int, int) {
+	if round.Course.HoleCount == 9 {
+		return 1, 9
+	}
+	if round.NineHoleSelection != nil {
+		switch *round.NineHoleSelection {
+		case "front":
+			return 1, 9
+		case "back":
+			return 10, 18
+		}
+	}
+	return 1, 18
+}
+
+// intervalSlots starts n groups one interval apart off the first hole, or, with
+// a split tee, in pairs off the 1st and 10th tees.
+func intervalSlots(n, firstHole, lastHole int, interval time.Duration, split bool) ([]teeSlot, error) {
+	if split && (firstHole != 1 || lastHole != 18) {
+		return nil, &ValidationError{Field: "split_tee", Message: "a split tee needs a full 18-hole round"}
+	}
+	slots := make([]teeSlot, n)
+	for i := range slots {
+		if split {
+			hole := 1 + 9*(i%2)
+			slots[i] = teeSlot{Offset: time.Duration(i/2) * interval, Hole: hole, Label: fmt.Sprint(hole)}
+			continue
+		}
+		slots[i] = teeSlot{Offset: time.Duration(i) * interval, Hole: firstHole, Label: fmt.Sprint(firstHole)}
+	}
+	return slots, nil
+}
+
+// shotgunSlots gives each of n groups a starting hole at the same time. With no
+// layout, groups take the holes in order; when there are more groups than
+// holes, the first holes take an A and a B group. Groups sharing a hole are
+// labeled A and B in group order.
+func shotgunSlots(n, firstHole, lastHole int, layout []int) ([]teeSlot, error) {
+	holeCount := lastHole - firstHole + 1
+	if len(layout) == 0 {
+		if n > holeCount*maxGroupsPerShotgunHole {
+			return nil, &ValidationError{
+				Field:   "round",
+				Message: fmt.Sprintf("a shotgun start fits at most %d groups", holeCount*maxGroupsPerShotgunHole),
+			}
+		}
+		doubled := max(n-holeCount, 0)
+		for h := firstHole; h <= lastHole && len(layout) < n; h++ {
+			layout = append(layout, h)
+			if h-firstHole < doubled {
+				layout = append(layout, h)
+			}
+		}
+	}
+	if len(layout) != n {
+		return nil, &ValidationError{Field: "shotgun_holes", Message: fmt.Sprintf("shotgun_holes needs one hole for each of the %d groups", n)}
+	}
+
+	perHole := make(map[int]int)
+	for _, h := range layout {
+		if h < firstHole || h > lastHole {
+			return nil, &ValidationError{
+				Field:   "shotgun_holes",
+				Message: fmt.Sprintf("shotgun_holes must be between %d and %d", firstHole, lastHole),
+			}
+		}
+		perHole[h]++
+		if perHole[h] > maxGroupsPerShotgunHole {
+			return nil, &ValidationError{Field: "shotgun_holes", Message: "at most 2 groups can start on a hole"}
+		}
+	}
+	seen := make(map[int]int)
+	slots := make([]teeSlot, n)
+	for i, h := range layout {
+		label := fmt.Sprint(h)
+		if perHole[h] > 1 {
+			label += string(rune('A' + seen[h]))
+		}
+		seen[h]++
+		slots[i] = teeSlot{Hole: h, Label: label}
+	}
+	return slots, nil
+}
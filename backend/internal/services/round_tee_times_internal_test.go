@@ -0,0 +1,96 @@
+// services/round_tee_times_internal_test.go
+// White-box tests for the unexported tee sheet helpers in round_tee_times.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestIntervalSlots|TestShotgunSlots|TestPlayedHoles|TestParseTeeTime' -v
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+// slotLabels returns each slot's label.
+func slotLabels(slots []teeSlot) []string {
+	out := make([]string, len(slots))
+	for i, s := range slots {
+		out[i] = s.Label
+	}
+	return out
+}
+
+func TestParseTeeTime_BothFormats(t *testing.T) {
+	d, err := parseTeeTime("13:05")
+	require.NoError(t, err)
+	assert.Equal(t, 13*time.Hour+5*time.Minute, d)
+	d, err = parseTeeTime("7:30 AM")
+	require.NoError(t, err)
+	assert.Equal(t, 7*time.Hour+30*time.Minute, d)
+	_, err = parseTeeTime("noon")
+	assert.Error(t, err)
+}
+
+func TestPlayedHoles_NineSelections(t *testing.T) {
+	back := "back"
+	assert.Equal(t, [2]int{10, 18}, pair(playedHoles(models.Round{Course: models.Course{HoleCount: 18}, NineHoleSelection: &back})))
+	assert.Equal(t, [2]int{1, 9}, pair(playedHoles(models.Round{Course: models.Course{HoleCount: 9}})))
+	assert.Equal(t, [2]int{1, 18}, pair(playedHoles(models.Round{Course: models.Course{HoleCount: 18}})))
+}
+
+// pair packs two results for a single assertion.
+func pair(a, b int) [2]int { return [2]int{a, b} }
+
+func TestIntervalSlots_OneTee(t *testing.T) {
+	slots, err := intervalSlots(3, 10, 18, 9*time.Minute, false)
+	require.NoError(t, err)
+	for i, s := range slots {
+		assert.Equal(t, 10, s.Hole)
+		assert.Equal(t, time.Duration(i)*9*time.Minute, s.Offset)
+	}
+}
+
+func TestIntervalSlots_SplitTeeSharesTimes(t *testing.T) {
+	slots, err := intervalSlots(5, 1, 18, 10*time.Minute, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "10", "1", "10", "1"}, slotLabels(slots))
+	assert.Equal(t, slots[0].Offset, slots[1].Offset)
+	assert.Equal(t, 20*time.Minute, slots[4].Offset)
+
+	_, err = intervalSlots(4, 1, 9, 10*time.Minute, true)
+	var ve *ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestShotgunSlots_DoublesUpFirstHoles(t *testing.T) {
+	slots, err := shotgunSlots(11, 1, 9, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1A", "1B", "2A", "2B", "3", "4", "5", "6", "7", "8", "9"}, slotLabels(slots))
+	for _, s := range slots {
+		assert.Zero(t, s.Offset)
+	}
+
+	_, err = shotgunSlots(19, 1, 9, nil)
+	var ve *ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestShotgunSlots_ExplicitLayout(t *testing.T) {
+	slots, err := shotgunSlots(4, 1, 18, []int{5, 1, 5, 18})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5A", "1", "5B", "18"}, slotLabels(slots))
+
+	var ve *ValidationError
+	_, err = shotgunSlots(3, 1, 18, []int{5, 5, 5})
+	assert.ErrorAs(t, err, &ve)
+	_, err = shotgunSlots(2, 10, 18, []int{1, 10})
+	assert.ErrorAs(t, err, &ve)
+	_, err = shotgunSlots(3, 1, 18, []int{1, 2})
+	assert.ErrorAs(t, err, &ve)
+}
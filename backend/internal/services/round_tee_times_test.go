@@ -0,0 +1,81 @@
+// services/round_tee_times_test.go
+// Integration tests for RoundService.GenerateTeeTimes (round_tee_times.go).
+// Uses testutil.NewTestDB to spin up an ephemeral Postgres container — Docker
+// must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// roundWithGroups schedules an event round with n empty groups.
+func roundWithGroups(t *testing.T, db *gorm.DB, n int) (*services.RoundService, models.Round, models.User) {
+	t.Helper()
+	eventSvc := services.NewEventService(db)
+	svc := services.NewRoundService(db, eventSvc)
+	organizer := seedUser(t, db, "starter")
+	event := seedEvent(t, eventSvc, organizer.ID)
+	course, tee := seedCourseWithTee(t, db, "Tee Sheet Course")
+	courseID, teeID := course.ID.String(), tee.ID.String()
+	result, err := svc.Schedule(context.Background(), event.ID, organizer.ID, "user", services.ScheduleRoundInput{
+		ScheduledDate: "2026-07-04", CourseID: &courseID, DefaultTeeID: &teeID,
+		Groups: make([]services.GroupScheduleInput, n),
+	})
+	require.NoError(t, err)
+	return svc, result.Round, organizer
+}
+
+func TestRoundService_GenerateTeeTimes_SplitTeeSaved(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc, round, organizer := roundWithGroups(t, db, 4)
+
+	sheet, err := svc.GenerateTeeTimes(context.Background(), round.ID, organizer.ID, "user", services.GenerateTeeTimesInput{
+		FirstTeeTime: "7:30 AM", IntervalMinutes: 8, SplitTee: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, sheet.Groups, 4)
+
+	var groups []models.Group
+	require.NoError(t, db.Where("round_id = ?", round.ID).Order("group_number").Find(&groups).Error)
+	want := []struct {
+		hole    int
+		hour    int
+		minutes int
+	}{{1, 7, 30}, {10, 7, 30}, {1, 7, 38}, {10, 7, 38}}
+	for i, g := range groups {
+		assert.Equal(t, want[i].hole, g.StartingHole, "group %d", g.GroupNumber)
+		require.NotNil(t, g.TeeTime)
+		assert.Equal(t, time.Date(2026, 7, 4, want[i].hour, want[i].minutes, 0, 0, time.UTC), g.TeeTime.UTC())
+	}
+}
+
+func TestRoundService_GenerateTeeTimes_ShotgunPreviewSavesNothing(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc, round, organizer := roundWithGroups(t, db, 3)
+
+	sheet, err := svc.GenerateTeeTimes(context.Background(), round.ID, organizer.ID, "user", services.GenerateTeeTimesInput{
+		Mode: "shotgun", FirstTeeTime: "13:00", ShotgunHoles: []int{1, 1, 7}, Preview: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1A", sheet.Groups[0].Label)
+	assert.Equal(t, "1B", sheet.Groups[1].Label)
+	assert.Equal(t, 7, sheet.Groups[2].Group.StartingHole)
+
+	var changed int64
+	require.NoError(t, db.Model(&models.Group{}).
+		Where("round_id = ? AND (tee_time IS NOT NULL OR starting_hole <> 1)", round.ID).
+		Count(&changed).Error)
+	assert.Zero(t, changed)
+}
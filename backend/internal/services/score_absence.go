@@ -0,0 +1,378 @@
+// services/score_absence.go
+// Absent players. When a member no-shows in a team format, an organizer marks
+// their round_players row absent and their card is filled in per the event's
+// absence policy so the partner's team still has a score on every hole:
+//
+//   - blind_draw:      a randomly drawn player's net score, preferring players
+//     from other groups. Needs someone with a complete card, so it is usually
+//     run once the cards are in; marking again redraws.
+//   - phantom_par:     par plus the absent player's handicap strokes (net par).
+//   - phantom_average: par plus the absent player's average over par from their
+//     completed rounds, spread over the holes by stroke index; net par without
+//     history.
+//
+// Every generated score is written through the same net calculation as an
+// entered one (so an allowance change recalculates it the same way) and is
+// labeled with the policy in scores.generated. An absent player's card doesn't
+// rank on the round leaderboard, and the round is left out of their stats and
+// handicap (see UserService). Marking or clearing an absence on a completed
+// round re-ranks it like any other score write.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AbsenceData is the response for marking a player absent: the policy used,
+// the card a blind draw copied (nil otherwise) and the generated scores.
+type AbsenceData struct {
+	RoundPlayerID          string               `json:"round_player_id"`
+	AbsentAt               string               `json:"absent_at"` // RFC 3339
+	Policy                 string               `json:"policy"`
+	BlindDrawRoundPlayerID *string              `json:"blind_draw_round_player_id"`
+	Scores                 []ScorecardScoreData `json:"scores"`
+}
+
+// absencePolicyFor returns the event's absence policy, defaulting to
+// phantom_par for eventless rounds and legacy rows with an empty value.
+func absencePolicyFor(event *models.Event) models.AbsencePolicy {
+	if event == nil || event.AbsencePolicy == "" {
+		return models.AbsencePolicyPhantomPar
+	}
+	return models.AbsencePolicy(event.AbsencePolicy)
+}
+
+// MarkAbsent marks a round player absent and generates their card per the
+// event's absence policy, replacing any card generated earlier. Refused once
+// the player has entered a score of their own (ErrPlayerHasScores).
+// Organizer-only; locked like any score edit once the event is finalized.
+func (s *ScoreService) MarkAbsent(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole string) (*AbsenceData, error) {
+	round, rp, err := s.loadCardTarget(ctx, roundID, roundPlayerID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	var entered int64
+	if err := s.DB.WithContext(ctx).Model(&models.Score{}).
+		Where("round_player_id = ? AND generated IS NULL", rp.ID).Count(&entered).Error; err != nil {
+		return nil, fmt.Errorf("count scores: %w", err)
+	}
+	if entered > 0 {
+		return nil, ErrPlayerHasScores
+	}
+
+	played := filterPlayedHoles(round.DefaultTee.Holes, round.NineHoleSelection)
+	if len(played) == 0 {
+		return nil, &ValidationError{Field: "round", Message: "round has no holes to score"}
+	}
+	raw := 0
+	if rp.CourseHandicap != nil {
+		raw = *rp.CourseHandicap
+	}
+	handicap := EffectiveCourseHandicap(raw, roundHandicapAllowance(round))
+
+	policy := absencePolicyFor(round.Event)
+	var gross map[int]int
+	var drawn *uuid.UUID
+	switch policy {
+	case models.AbsencePolicyBlindDraw:
+		cards, err := s.blindDrawCards(ctx, round.ID, rp.ID, played)
+		if err != nil {
+			return nil, err
+		}
+		pick, ok := pickBlindDraw(cards, rand.IntN)
+		if !ok {
+			return nil, &ValidationError{Field: "absence_policy", Message: "a blind draw needs another player with a complete card; mark the absence once cards are in"}
+		}
+		drawn = &pick.RoundPlayerID
+		gross = blindDrawGross(played, pick.Net, handicap)
+	case models.AbsencePolicyPhantomAverage:
+		avg, err := s.averageOverPar(ctx, rp.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if avg == nil {
+			gross = phantomGross(played, handicap, 0)
+		} else {
+			gross = phantomGross(played, 0, int(math.Round(*avg*float64(len(played)))))
+		}
+	default:
+		gross = phantomGross(played, handicap, 0)
+	}
+
+	siByHole := NormalizeStrokeIndexes(played)
+	label := string(policy)
+	records := make([]models.Score, 0, len(played))
+	for _, h := range played {
+		g := gross[h.HoleNumber]
+		records = append(records, models.Score{
+			RoundPlayerID: rp.ID, HoleNumber: h.HoleNumber, GrossScore: g,
+			NetScore:  g - HandicapStrokes(handicap, siByHole[h.HoleNumber], len(played)),
+			EnteredBy: callerID, Generated: &label,
+		})
+	}
+
+	absentAt := time.Now().UTC()
+	if rp.AbsentAt != nil {
+		absentAt = *rp.AbsentAt // marking again regenerates the card
+	}
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var prior []models.Score
+		if err := tx.Where("round_player_id = ?", rp.ID).Find(&prior).Error; err != nil {
+			return fmt.Errorf("load generated scores: %w", err)
+		}
+		priorByHole := make(map[int]string, len(prior))
+		for _, p := range prior {
+			priorByHole[p.HoleNumber] = scoreValue(p.GrossScore, p.NetScore)
+		}
+		if err := tx.Where("round_player_id = ?", rp.ID).Delete(&models.Score{}).Error; err != nil {
+			return fmt.Errorf("clear generated scores: %w", err)
+		}
+		if err := tx.Omit(clause.Associations).Create(&records).Error; err != nil {
+			return fmt.Errorf("create generated scores: %w", err)
+		}
+		changes := make([]models.ScoreChange, 0, len(records))
+		for _, r := range records {
+			newValue := scoreValue(r.GrossScore, r.NetScore)
+			old, existed := priorByHole[r.HoleNumber]
+			if existed && old == newValue {
+				continue
+			}
+			change := models.ScoreChange{
+				RoundPlayerID: rp.ID, Kind: string(models.ScoreChangeKindScore),
+				HoleNumber: intPtr(r.HoleNumber), NewValue: &newValue, ChangedBy: callerID,
+			}
+			if existed {
+				change.OldValue = &old
+			}
+			changes = append(changes, change)
+		}
+		if err := recordScoreChanges(tx, changes); err != nil {
+			return err
+		}
+		return tx.Model(&models.RoundPlayer{}).Where("id = ?", rp.ID).Updates(map[string]any{
+			"absent_at":                  absentAt,
+			"blind_draw_round_player_id": drawn,
+		}).Error
+	})
+	if txErr != nil {
+		return nil, fmt.Errorf("mark absent: %w", txErr)
+	}
+	if err := s.afterScoreWrite(ctx, round, rp.ID); err != nil {
+		return nil, err
+	}
+
+	out := &AbsenceData{
+		RoundPlayerID: rp.ID.String(), AbsentAt: absentAt.Format(time.RFC3339), Policy: label,
+		Scores: make([]ScorecardScoreData, len(records)),
+	}
+	if drawn != nil {
+		id := drawn.String()
+		out.BlindDrawRoundPlayerID = &id
+	}
+	for i, r := range records {
+		out.Scores[i] = ScorecardScoreData{HoleNumber: r.HoleNumber, GrossScore: r.GrossScore, NetScore: r.NetScore, Generated: r.Generated}
+	}
+	return out, nil
+}
+
+// ClearAbsence marks an absent player present again and deletes their
+// generated scores. A no-op for a player who isn't absent. Organizer-only.
+func (s *ScoreService) ClearAbsence(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole string) error {
+	round, rp, err := s.loadCardTarget(ctx, roundID, roundPlayerID, callerID, callerRole)
+	if err != nil {
+		return err
+	}
+	if rp.AbsentAt == nil {
+		return nil
+	}
+	txErr := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var generated []models.Score
+		if err := tx.Where("round_player_id = ? AND generated IS NOT NULL", rp.ID).Find(&generated).Error; err != nil {
+			return fmt.Errorf("load generated scores: %w", err)
+		}
+		if err := tx.Where("round_player_id = ? AND generated IS NOT NULL", rp.ID).Delete(&models.Score{}).Error; err != nil {
+			return fmt.Errorf("delete generated scores: %w", err)
+		}
+		changes := make([]models.ScoreChange, len(generated))
+		for i, g := range generated {
+			old := scoreValue(g.GrossScore, g.NetScore)
+			changes[i] = models.ScoreChange{
+				RoundPlayerID: rp.ID, Kind: string(models.ScoreChangeKindScore),
+				HoleNumber: intPtr(g.HoleNumber), OldValue: &old, ChangedBy: callerID,
+			}
+		}
+		if err := recordScoreChanges(tx, changes); err != nil {
+			return err
+		}
+		return tx.Model(&models.RoundPlayer{}).Where("id = ?", rp.ID).Updates(map[string]any{
+			"absent_at":                  nil,
+			"blind_draw_round_player_id": nil,
+		}).Error
+	})
+	if txErr != nil {
+		return fmt.Errorf("clear absence: %w", txErr)
+	}
+	return s.afterScoreWrite(ctx, round, rp.ID)
+}
+
+// loadCardTarget checks the caller may mark absences or withdrawals on the
+// round (an organizer, with the event not finalized) and loads the round — with its
+// holes and event — and the round player.
+func (s *ScoreService) loadCardTarget(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole string) (*models.Round, *models.RoundPlayer, error) {
+	if err := s.requireRoundOrganizer(ctx, roundID, callerID, callerRole); err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.canModifyScores(ctx, roundID, roundPlayerID, callerID, callerRole); err != nil {
+		return nil, nil, err
+	}
+	var round models.Round
+	if err := s.DB.WithContext(ctx).
+		Preload("DefaultTee.Holes").Preload("Event").
+		First(&round, "id = ?", roundID).Error; err != nil {
+		return nil, nil, fmt.Errorf("load round: %w", err)
+	}
+	var rp models.RoundPlayer
+	if err := s.DB.WithContext(ctx).First(&rp, "id = ? AND round_id = ?", roundPlayerID, roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrRoundPlayerNotFound
+		}
+		return nil, nil, fmt.Errorf("load round player: %w", err)
+	}
+	return &round, &rp, nil
+}
+
+// averageOverPar returns a player's average strokes over par per hole across
+// the entered scores of their completed rounds; nil without any history.
+func (s *ScoreService) averageOverPar(ctx context.Context, userID uuid.UUID) (*float64, error) {
+	var row struct{ Avg *float64 }
+	if err := s.DB.WithContext(ctx).Table("scores s").
+		Select("AVG(s.gross_score - h.par) AS avg").
+		Joins("JOIN round_players rp ON rp.id = s.round_player_id").
+		Joins("JOIN rounds r ON r.id = rp.round_id").
+		Joins("JOIN holes h ON h.tee_id = r.default_tee_id AND h.hole_number = s.hole_number").
+		Where("rp.user_id = ? AND r.status = ? AND rp.absent_at IS NULL AND s.generated IS NULL",
+			userID, models.RoundStatusCompleted).
+		Scan(&row).Error; err != nil {
+		return nil, fmt.Errorf("load scoring average: %w", err)
+	}
+	return row.Avg, nil
+}
+
+// blindDrawCard is a card a blind draw can copy: a present player's entered
+// net score on every played hole.
+type blindDrawCard struct {
+	RoundPlayerID uuid.UUID
+	OtherGroup    bool // not in the absent player's group
+	Net           map[int]int
+}
+
+// blindDrawCards loads the round's complete, entered cards other than the
+// absent player's, in a stable order.
+func (s *ScoreService) blindDrawCards(ctx context.Context, roundID, absentID uuid.UUID, played []models.Hole) ([]blindDrawCard, error) {
+	var absentGroup models.GroupPlayer
+	if err := s.DB.WithContext(ctx).Where("round_player_id = ?", absentID).Limit(1).Find(&absentGroup).Error; err != nil {
+		return nil, fmt.Errorf("load group: %w", err)
+	}
+	var rows []struct {
+		RoundPlayerID uuid.UUID
+		GroupID       *uuid.UUID
+	}
+	if err := s.DB.WithContext(ctx).Table("round_players rp").
+		Select("rp.id AS round_player_id, gp.group_id").
+		Joins("LEFT JOIN group_players gp ON gp.round_player_id = rp.id").
+		Where("rp.round_id = ? AND rp.id <> ? AND rp.absent_at IS NULL AND rp.status NOT IN ?",
+			roundID, absentID, models.RoundPlayerOutStatuses).
+		Order("rp.created_at ASC, rp.id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load blind draw candidates: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	ids := make([]uuid.UUID, len(rows))
+	for i, r := range rows {
+		ids[i] = r.RoundPlayerID
+	}
+	holes := make([]int, len(played))
+	for i, h := range played {
+		holes[i] = h.HoleNumber
+	}
+	var scores []models.Score
+	if err := s.DB.WithContext(ctx).
+		Where("round_player_id IN ? AND hole_number IN ? AND generated IS NULL", ids, holes).
+		Find(&scores).Error; err != nil {
+		return nil, fmt.Errorf("load blind draw scores: %w", err)
+	}
+	net := make(map[uuid.UUID]map[int]int, len(rows))
+	for _, sc := range scores {
+		if net[sc.RoundPlayerID] == nil {
+			net[sc.RoundPlayerID] = make(map[int]int, len(played))
+		}
+		net[sc.RoundPlayerID][sc.HoleNumber] = sc.NetScore
+	}
+	var out []blindDrawCard
+	for _, r := range rows {
+		if len(net[r.RoundPlayerID]) != len(played) {
+			continue
+		}
+		other := absentGroup.GroupID == uuid.Nil || r.GroupID == nil || *r.GroupID != absentGroup.GroupID
+		out = append(out, blindDrawCard{RoundPlayerID: r.RoundPlayerID, OtherGroup: other, Net: net[r.RoundPlayerID]})
+	}
+	return out, nil
+}
+
+// pickBlindDraw draws one card, from players outside the absent player's
+// group when there are any. intN is rand.IntN (injected for tests).
+func pickBlindDraw(cards []blindDrawCard, intN func(int) int) (blindDrawCard, bool) {
+	var pool []blindDrawCard
+	for _, c := range cards {
+		if c.OtherGroup {
+			pool = append(pool, c)
+		}
+	}
+	if len(pool) == 0 {
+		pool = cards
+	}
+	if len(pool) == 0 {
+		return blindDrawCard{}, false
+	}
+	return pool[intN(len(pool))], true
+}
+
+// phantomGross is par on each played hole plus handicap strokes and extra
+// strokes, each allocated by stroke index like a course handicap.
+func phantomGross(played []models.Hole, handicap, extra int) map[int]int {
+	si := NormalizeStrokeIndexes(played)
+	out := make(map[int]int, len(played))
+	for _, h := range played {
+		out[h.HoleNumber] = h.Par + HandicapStrokes(handicap, si[h.HoleNumber], len(played)) +
+			HandicapStrokes(extra, si[h.HoleNumber], len(played))
+	}
+	return out
+}
+
+// blindDrawGross turns a drawn card's net scores into gross scores for the
+// absent player, so that after their own handicap strokes the net matches the
+// drawn card. Never below 1.
+func blindDrawGross(played []models.Hole, net map[int]int, handicap int) map[int]int {
+	si := NormalizeStrokeIndexes(played)
+	out := make(map[int]int, len(played))
+	for _, h := range played {
+		g := net[h.HoleNumber] + HandicapStrokes(handicap, si[h.HoleNumber], len(played))
+		if g < 1 {
+			g = 1
+		}
+		out[h.HoleNumber] = g
+	}
+	return out
+}
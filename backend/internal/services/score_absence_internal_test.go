@@ -0,0 +1,78 @@
+// services/score_absence_internal_test.go
+// White-box tests for the unexported card generators in score_absence.go.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'TestPhantomGross|TestBlindDraw|TestPickBlindDraw' -v
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+// nineHoles is a par-36 nine with stroke indexes 1..9 in hole order.
+func nineHoles() []models.Hole {
+	holes := make([]models.Hole, 9)
+	for i := range holes {
+		holes[i] = models.Hole{HoleNumber: i + 1, Par: 4, StrokeIndex: i + 1}
+	}
+	holes[2].Par = 3
+	holes[6].Par = 5
+	return holes
+}
+
+func TestPhantomGross_NetPar(t *testing.T) {
+	gross := phantomGross(nineHoles(), 3, 0)
+	assert.Equal(t, 5, gross[1], "SI 1 gets a stroke")
+	assert.Equal(t, 4, gross[3], "par 3 at SI 3 gets a stroke")
+	assert.Equal(t, 4, gross[4], "SI 4 gets none")
+	assert.Equal(t, 5, gross[7])
+}
+
+func TestPhantomGross_ExtraStrokesSpreadByStrokeIndex(t *testing.T) {
+	gross := phantomGross(nineHoles(), 0, 11)
+	total := 0
+	for _, g := range gross {
+		total += g
+	}
+	assert.Equal(t, 36+11, total)
+	assert.Equal(t, 6, gross[1], "SI 1 and 2 get a second stroke")
+	assert.Equal(t, 5, gross[9])
+}
+
+func TestBlindDrawGross_AddsOwnStrokesAndFloorsAtOne(t *testing.T) {
+	net := map[int]int{}
+	for h := 1; h <= 9; h++ {
+		net[h] = 4
+	}
+	net[5] = -1 // a plus-handicap draw can net below zero
+	gross := blindDrawGross(nineHoles(), net, 2)
+	assert.Equal(t, 5, gross[1])
+	assert.Equal(t, 5, gross[2])
+	assert.Equal(t, 4, gross[3])
+	assert.Equal(t, 1, gross[5])
+}
+
+func TestPickBlindDraw_PrefersOtherGroups(t *testing.T) {
+	same := blindDrawCard{RoundPlayerID: uuid.New()}
+	other := blindDrawCard{RoundPlayerID: uuid.New(), OtherGroup: true}
+	for i := 0; i < 5; i++ {
+		pick, ok := pickBlindDraw([]blindDrawCard{same, other, same}, func(n int) int { return n - 1 })
+		require.True(t, ok)
+		assert.Equal(t, other.RoundPlayerID, pick.RoundPlayerID)
+	}
+
+	pick, ok := pickBlindDraw([]blindDrawCard{same}, func(int) int { return 0 })
+	require.True(t, ok, "falls back to the player's own group")
+	assert.Equal(t, same.RoundPlayerID, pick.RoundPlayerID)
+
+	_, ok = pickBlindDraw(nil, func(int) int { return 0 })
+	assert.False(t, ok)
+}
@@ -0,0 +1,155 @@
+// services/score_absence_test.go
+// Integration tests for absent players (score_absence.go): the generated card
+// per absence policy, the leaderboard leaving absent players unranked, and
+// clearing an absence. Uses testutil.NewTestDB — Docker must be running.
+//
+// The card generators themselves are covered without a DB by
+// score_absence_internal_test.go.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run Absen -v
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// absenceRound is tiedRound plus a third member, carol, with no scores.
+func absenceRound(t *testing.T, db *gorm.DB, policy models.AbsencePolicy) (uuid.UUID, uuid.UUID, models.RoundPlayer, models.RoundPlayer) {
+	t.Helper()
+	roundID, event, _, bobRP := tiedRound(t, db)
+	require.NoError(t, db.Model(&models.Event{}).Where("id = ?", event.ID).
+		Update("absence_policy", string(policy)).Error)
+	carol := seedUser(t, db, "absCarol")
+	carolRP := addRoundPlayer(t, db, roundID, addEventMember(t, db, event.ID, carol.ID).ID)
+	return roundID, organizerOf(t, db, event.ID), bobRP, carolRP
+}
+
+func TestScoreService_MarkAbsent_PhantomPar(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, orgID, _, carolRP := absenceRound(t, db, models.AbsencePolicyPhantomPar)
+
+	data, err := svc.MarkAbsent(ctx, roundID, carolRP.ID, orgID, "user")
+	require.NoError(t, err)
+	assert.Equal(t, string(models.AbsencePolicyPhantomPar), data.Policy)
+	assert.Nil(t, data.BlindDrawRoundPlayerID)
+	require.Len(t, data.Scores, 18)
+	for _, sc := range data.Scores {
+		assert.Equal(t, 4, sc.NetScore, "hole %d", sc.HoleNumber)
+		require.NotNil(t, sc.Generated)
+		assert.Equal(t, string(models.AbsencePolicyPhantomPar), *sc.Generated)
+	}
+
+	// Marking again regenerates rather than failing on the generated card.
+	_, err = svc.MarkAbsent(ctx, roundID, carolRP.ID, orgID, "user")
+	require.NoError(t, err)
+
+	completeRound(t, db, roundID)
+	board, err := newLeaderboardSvc(db).RoundLeaderboard(ctx, roundID)
+	require.NoError(t, err)
+	for _, e := range board.Entries {
+		if e.RoundPlayerID == carolRP.ID.String() {
+			assert.Nil(t, e.Position, "an absent player's card doesn't rank")
+		}
+	}
+}
+
+func TestScoreService_MarkAbsent_BlindDrawCopiesNet(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	roundID, orgID, _, carolRP := absenceRound(t, db, models.AbsencePolicyBlindDraw)
+
+	data, err := svc.MarkAbsent(context.Background(), roundID, carolRP.ID, orgID, "user")
+	require.NoError(t, err)
+	require.NotNil(t, data.BlindDrawRoundPlayerID)
+
+	var drawn []models.Score
+	require.NoError(t, db.Where("round_player_id = ?", *data.BlindDrawRoundPlayerID).Find(&drawn).Error)
+	want := map[int]int{}
+	for _, sc := range drawn {
+		want[sc.HoleNumber] = sc.NetScore
+	}
+	for _, sc := range data.Scores {
+		assert.Equal(t, want[sc.HoleNumber], sc.NetScore, "hole %d", sc.HoleNumber)
+	}
+
+	var stored models.RoundPlayer
+	require.NoError(t, db.First(&stored, "id = ?", carolRP.ID).Error)
+	assert.NotNil(t, stored.AbsentAt)
+	require.NotNil(t, stored.BlindDrawRoundPlayerID)
+	assert.Equal(t, *data.BlindDrawRoundPlayerID, stored.BlindDrawRoundPlayerID.String())
+}
+
+func TestScoreService_MarkAbsent_CompletedRoundReranked(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, orgID, bobRP, carolRP := absenceRound(t, db, models.AbsencePolicyPhantomPar)
+	completeRound(t, db, roundID) // no finish positions stored yet
+
+	_, err := svc.MarkAbsent(ctx, roundID, carolRP.ID, orgID, "user")
+	require.NoError(t, err)
+	var stored models.RoundPlayer
+	require.NoError(t, db.First(&stored, "id = ?", bobRP.ID).Error)
+	require.NotNil(t, stored.FinishPosition, "the completed round was re-ranked")
+	assert.Equal(t, 1, *stored.FinishPosition)
+
+	require.NoError(t, db.Model(&models.RoundPlayer{}).Where("round_id = ?", roundID).
+		Update("finish_position", nil).Error)
+	require.NoError(t, svc.ClearAbsence(ctx, roundID, carolRP.ID, orgID, "user"))
+	require.NoError(t, db.First(&stored, "id = ?", bobRP.ID).Error)
+	assert.NotNil(t, stored.FinishPosition, "and again once the absence is cleared")
+}
+
+func TestScoreService_MarkAbsent_Rejections(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, orgID, bobRP, carolRP := absenceRound(t, db, models.AbsencePolicyPhantomPar)
+
+	_, err := svc.MarkAbsent(ctx, roundID, bobRP.ID, orgID, "user")
+	assert.ErrorIs(t, err, services.ErrPlayerHasScores)
+	_, err = svc.MarkAbsent(ctx, roundID, carolRP.ID, bobRP.UserID, "user")
+	assert.ErrorIs(t, err, services.ErrScoreForbidden)
+}
+
+func TestScoreService_ClearAbsence_RemovesGeneratedCard(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, orgID, _, carolRP := absenceRound(t, db, models.AbsencePolicyPhantomPar)
+
+	_, err := svc.MarkAbsent(ctx, roundID, carolRP.ID, orgID, "user")
+	require.NoError(t, err)
+	require.NoError(t, svc.ClearAbsence(ctx, roundID, carolRP.ID, orgID, "user"))
+	require.NoError(t, svc.ClearAbsence(ctx, roundID, carolRP.ID, orgID, "user"), "clearing twice is a no-op")
+
+	var count int64
+	require.NoError(t, db.Model(&models.Score{}).Where("round_player_id = ?", carolRP.ID).Count(&count).Error)
+	assert.Zero(t, count)
+	var stored models.RoundPlayer
+	require.NoError(t, db.First(&stored, "id = ?", carolRP.ID).Error)
+	assert.Nil(t, stored.AbsentAt)
+
+	// An entered score is a normal score again.
+	sc := models.Score{RoundPlayerID: carolRP.ID, HoleNumber: 1, GrossScore: 5, NetScore: 5, EnteredBy: orgID}
+	require.NoError(t, db.Omit(clause.Associations).Create(&sc).Error)
+	_, err = svc.MarkAbsent(ctx, roundID, carolRP.ID, orgID, "user")
+	assert.ErrorIs(t, err, services.ErrPlayerHasScores)
+}
@@ -0,0 +1,342 @@
+// services/score_dispute.go
+// Score disputes: any player in a round can flag one hole score on a card with a
+// comment; organizers list the round's disputes and resolve each one by
+// accepting the recorded score, correcting it, or dismissing the dispute.
+// Open disputes are surfaced on the round leaderboard (LeaderboardEntry.DisputedHoles).
+//
+// A correction goes through UpsertScores, so it is recorded in the score history,
+// respects the finalized-event lock, and flags an attested card for re-attestation.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Dispute resolution actions accepted by ResolveDispute.
+const (
+	DisputeActionAccept  = "accept"  // recorded score stands
+	DisputeActionCorrect = "correct" // organizer sets a new gross score
+	DisputeActionDismiss = "dismiss" // dispute withdrawn or invalid
+)
+
+// disputeActionStatus maps each resolution action to the status it produces.
+var disputeActionStatus = map[string]models.ScoreDisputeStatus{
+	DisputeActionAccept:  models.ScoreDisputeStatusAccepted,
+	DisputeActionCorrect: models.ScoreDisputeStatusCorrected,
+	DisputeActionDismiss: models.ScoreDisputeStatusDismissed,
+}
+
+// ResolveDisputeInput carries an organizer's ruling on a dispute.
+type ResolveDisputeInput struct {
+	Action     string  // accept | correct | dismiss
+	GrossScore *int    // required for correct
+	Note       *string // optional explanation shown with the ruling
+}
+
+// ScoreDisputeData is one dispute, returned directly as JSON by the handlers.
+type ScoreDisputeData struct {
+	ID             string  `json:"id"`
+	RoundPlayerID  string  `json:"round_player_id"`
+	PlayerName     string  `json:"player_name"`
+	HoleNumber     int     `json:"hole_number"`
+	GrossScore     *int    `json:"gross_score"` // current score on the hole; nil if since removed
+	RaisedBy       string  `json:"raised_by"`
+	RaisedByName   string  `json:"raised_by_name"`
+	Comment        string  `json:"comment"`
+	Status         string  `json:"status"`
+	ResolutionNote *string `json:"resolution_note"`
+	ResolvedBy     *string `json:"resolved_by"`
+	ResolvedAt     *string `json:"resolved_at"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// ─── RaiseDispute ─────────────────────────────────────────────────────────────
+
+// RaiseDispute flags the score on holeNumber of roundPlayerID's card. The caller
+// must be a player in the round (any group) or an admin. The round must be active
+// or completed, the hole must have a score, and only one dispute per hole may be
+// open at a time.
+func (s *ScoreService) RaiseDispute(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole string, holeNumber int, comment string) (*ScoreDisputeData, error) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil, &ValidationError{Field: "comment", Message: "comment is required"}
+	}
+	if holeNumber < 1 {
+		return nil, &ValidationError{Field: "hole_number", Message: "hole_number must be between 1 and course hole count"}
+	}
+
+	var round models.Round
+	if err := s.DB.WithContext(ctx).Select("id", "event_id", "status").First(&round, "id = ?", roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundNotFound
+		}
+		return nil, fmt.Errorf("load round: %w", err)
+	}
+	if round.Status != models.RoundStatusActive && round.Status != models.RoundStatusCompleted {
+		return nil, ErrRoundNotActive
+	}
+	if err := requireEventNotFinalized(ctx, s.DB, round.EventID); err != nil {
+		return nil, err
+	}
+
+	var rp models.RoundPlayer
+	if err := s.DB.WithContext(ctx).Select("id").First(&rp, "id = ? AND round_id = ?", roundPlayerID, roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundPlayerNotFound
+		}
+		return nil, fmt.Errorf("load round player: %w", err)
+	}
+
+	if callerRole != "admin" {
+		var inRound int64
+		if err := s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).
+			Where("round_id = ? AND user_id = ?", roundID, callerID).
+			Count(&inRound).Error; err != nil {
+			return nil, fmt.Errorf("check caller in round: %w", err)
+		}
+		if inRound == 0 {
+			return nil, ErrDisputeForbidden
+		}
+	}
+
+	var scored int64
+	if err := s.DB.WithContext(ctx).Model(&models.Score{}).
+		Where("round_player_id = ? AND hole_number = ?", roundPlayerID, holeNumber).
+		Count(&scored).Error; err != nil {
+		return nil, fmt.Errorf("check score exists: %w", err)
+	}
+	if scored == 0 {
+		return nil, &ValidationError{Field: "hole_number", Message: "no score is recorded for that hole"}
+	}
+
+	var open int64
+	if err := s.DB.WithContext(ctx).Model(&models.ScoreDispute{}).
+		Where("round_player_id = ? AND hole_number = ? AND status = ?", roundPlayerID, holeNumber, models.ScoreDisputeStatusOpen).
+		Count(&open).Error; err != nil {
+		return nil, fmt.Errorf("check open dispute: %w", err)
+	}
+	if open > 0 {
+		return nil, ErrDisputeAlreadyOpen
+	}
+
+	dispute := models.ScoreDispute{
+		RoundPlayerID: roundPlayerID, HoleNumber: holeNumber, RaisedBy: callerID,
+		Comment: comment, Status: string(models.ScoreDisputeStatusOpen),
+	}
+	// The open-dispute unique index settles a race with a concurrent raise.
+	res := s.DB.WithContext(ctx).Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&dispute)
+	if res.Error != nil {
+		return nil, fmt.Errorf("create dispute: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrDisputeAlreadyOpen
+	}
+	return s.loadDisputeData(ctx, roundID, dispute.ID)
+}
+
+// ─── ListDisputes ─────────────────────────────────────────────────────────────
+
+// ListDisputes returns a round's disputes, oldest first, for its organizers.
+// status filters by dispute status; "" means open only and "all" returns every dispute.
+func (s *ScoreService) ListDisputes(ctx context.Context, roundID, callerID uuid.UUID, callerRole, status string) ([]ScoreDisputeData, error) {
+	switch status {
+	case "":
+		status = string(models.ScoreDisputeStatusOpen)
+	case "all", string(models.ScoreDisputeStatusOpen), string(models.ScoreDisputeStatusAccepted),
+		string(models.ScoreDisputeStatusCorrected), string(models.ScoreDisputeStatusDismissed):
+	default:
+		return nil, &ValidationError{Field: "status", Message: "status must be one of: open, accepted, corrected, dismissed, all"}
+	}
+
+	if err := s.requireRoundOrganizer(ctx, roundID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+
+	q := s.DB.WithContext(ctx).
+		Preload("Raiser").Preload("RoundPlayer.User").
+		Joins("JOIN round_players rp ON rp.id = score_disputes.round_player_id").
+		Where("rp.round_id = ?", roundID)
+	if status != "all" {
+		q = q.Where("score_disputes.status = ?", status)
+	}
+	var disputes []models.ScoreDispute
+	if err := q.Order("score_disputes.created_at ASC").Find(&disputes).Error; err != nil {
+		return nil, fmt.Errorf("load disputes: %w", err)
+	}
+	return s.disputeData(ctx, disputes)
+}
+
+// ─── ResolveDispute ───────────────────────────────────────────────────────────
+
+// ResolveDispute records an organizer's ruling on an open dispute. For
+// "correct", the hole is rescored to input.GrossScore in the same transaction,
+// with UpsertScores' checks.
+func (s *ScoreService) ResolveDispute(ctx context.Context, roundID, disputeID, callerID uuid.UUID, callerRole string, input ResolveDisputeInput) (*ScoreDisputeData, error) {
+	status, ok := disputeActionStatus[input.Action]
+	if !ok {
+		return nil, &ValidationError{Field: "action", Message: "action must be one of: accept, correct, dismiss"}
+	}
+	if input.Action == DisputeActionCorrect && (input.GrossScore == nil || *input.GrossScore < 1) {
+		return nil, &ValidationError{Field: "gross_score", Message: "gross_score is required to correct a score"}
+	}
+
+	if err := s.requireRoundOrganizer(ctx, roundID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+
+	var dispute models.ScoreDispute
+	if err := s.DB.WithContext(ctx).
+		Joins("JOIN round_players rp ON rp.id = score_disputes.round_player_id").
+		Where("score_disputes.id = ? AND rp.round_id = ?", disputeID, roundID).
+		First(&dispute).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDisputeNotFound
+		}
+		return nil, fmt.Errorf("load dispute: %w", err)
+	}
+	if dispute.Status != string(models.ScoreDisputeStatusOpen) {
+		return nil, ErrDisputeResolved
+	}
+
+	var round *models.Round
+	var rp *models.RoundPlayer
+	var correction []ScoreInput
+	if input.Action == DisputeActionCorrect {
+		correction = []ScoreInput{{HoleNumber: dispute.HoleNumber, GrossScore: *input.GrossScore}}
+		var err error
+		if round, rp, err = s.prepareScoreWrite(ctx, roundID, dispute.RoundPlayerID, callerID, callerRole, correction); err != nil {
+			return nil, err
+		}
+	}
+
+	var note *string
+	if input.Note != nil {
+		if trimmed := strings.TrimSpace(*input.Note); trimmed != "" {
+			note = &trimmed
+		}
+	}
+	now := time.Now().UTC()
+	// Claim the dispute before touching the score: the status guard lets only
+	// one of two concurrent rulings through, and the correction commits with it.
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.ScoreDispute{}).
+			Where("id = ? AND status = ?", dispute.ID, models.ScoreDisputeStatusOpen).
+			Updates(map[string]any{
+				"status":          string(status),
+				"resolution_note": note,
+				"resolved_by":     callerID,
+				"resolved_at":     now,
+			})
+		if res.Error != nil {
+			return fmt.Errorf("resolve dispute: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return ErrDisputeResolved
+		}
+		if correction == nil {
+			return nil
+		}
+		return writeScoresTx(tx, round, rp, callerID, correction, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if correction != nil {
+		if err := s.afterScoreWrite(ctx, round, rp.ID); err != nil {
+			return nil, err
+		}
+	}
+	return s.loadDisputeData(ctx, roundID, dispute.ID)
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// requireRoundOrganizer returns ErrRoundNotFound or ErrScoreForbidden unless the
+// caller is an organizer of the round (see isRoundOrganizer).
+func (s *ScoreService) requireRoundOrganizer(ctx context.Context, roundID, callerID uuid.UUID, callerRole string) error {
+	var round models.Round
+	if err := s.DB.WithContext(ctx).Select("id", "event_id", "created_by").First(&round, "id = ?", roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRoundNotFound
+		}
+		return fmt.Errorf("load round: %w", err)
+	}
+	isOrg, err := s.isRoundOrganizer(ctx, &round, callerID, callerRole)
+	if err != nil {
+		return err
+	}
+	if !isOrg {
+		return ErrScoreForbidden
+	}
+	return nil
+}
+
+// loadDisputeData reloads one dispute with its associations for the response.
+func (s *ScoreService) loadDisputeData(ctx context.Context, roundID, disputeID uuid.UUID) (*ScoreDisputeData, error) {
+	var dispute models.ScoreDispute
+	if err := s.DB.WithContext(ctx).
+		Preload("Raiser").Preload("RoundPlayer.User").
+		Joins("JOIN round_players rp ON rp.id = score_disputes.round_player_id").
+		Where("score_disputes.id = ? AND rp.round_id = ?", disputeID, roundID).
+		First(&dispute).Error; err != nil {
+		return nil, fmt.Errorf("reload dispute: %w", err)
+	}
+	data, err := s.disputeData(ctx, []models.ScoreDispute{dispute})
+	if err != nil {
+		return nil, err
+	}
+	return &data[0], nil
+}
+
+// disputeData maps disputes (with Raiser and RoundPlayer.User preloaded) to their
+// responses, attaching the current gross score on each disputed hole.
+func (s *ScoreService) disputeData(ctx context.Context, disputes []models.ScoreDispute) ([]ScoreDisputeData, error) {
+	rpIDs := make([]uuid.UUID, 0, len(disputes))
+	for _, d := range disputes {
+		rpIDs = append(rpIDs, d.RoundPlayerID)
+	}
+	type holeKey struct {
+		RoundPlayerID uuid.UUID
+		HoleNumber    int
+	}
+	current := make(map[holeKey]int, len(disputes))
+	if len(rpIDs) > 0 {
+		var scores []models.Score
+		if err := s.DB.WithContext(ctx).Where("round_player_id IN ?", rpIDs).Find(&scores).Error; err != nil {
+			return nil, fmt.Errorf("load disputed scores: %w", err)
+		}
+		for _, sc := range scores {
+			current[holeKey{sc.RoundPlayerID, sc.HoleNumber}] = sc.GrossScore
+		}
+	}
+
+	out := make([]ScoreDisputeData, 0, len(disputes))
+	for _, d := range disputes {
+		data := ScoreDisputeData{
+			ID: d.ID.String(), RoundPlayerID: d.RoundPlayerID.String(),
+			PlayerName: d.RoundPlayer.User.DisplayName, HoleNumber: d.HoleNumber,
+			RaisedBy: d.RaisedBy.String(), RaisedByName: d.Raiser.DisplayName,
+			Comment: d.Comment, Status: d.Status, ResolutionNote: d.ResolutionNote,
+			ResolvedAt: FormatTimestamp(d.ResolvedAt),
+			CreatedAt:  d.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		if gross, ok := current[holeKey{d.RoundPlayerID, d.HoleNumber}]; ok {
+			data.GrossScore = intPtr(gross)
+		}
+		if d.ResolvedBy != nil {
+			id := d.ResolvedBy.String()
+			data.ResolvedBy = &id
+		}
+		out = append(out, data)
+	}
+	return out, nil
+}
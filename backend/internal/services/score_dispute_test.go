@@ -0,0 +1,113 @@
+// services/score_dispute_test.go
+// Integration tests for score disputes (score_dispute.go). Uses
+// testutil.NewTestDB to spin up an ephemeral Postgres container — Docker must
+// be running.
+//
+// Input validation paths are covered without a DB by handlers/disputes_test.go.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+func TestScoreService_RaiseDispute_MarksLeaderboardOnce(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, organizer, alice, aliceRP := historyFixture(t, db)
+	enterCard(t, db, aliceRP.ID, alice.ID, nil)
+
+	dispute, err := svc.RaiseDispute(ctx, roundID, aliceRP.ID, organizer.ID, "user", 7, "saw a 5")
+	require.NoError(t, err)
+	assert.Equal(t, string(models.ScoreDisputeStatusOpen), dispute.Status)
+	require.NotNil(t, dispute.GrossScore)
+	assert.Equal(t, 4, *dispute.GrossScore)
+
+	_, err = svc.RaiseDispute(ctx, roundID, aliceRP.ID, alice.ID, "user", 7, "again")
+	assert.ErrorIs(t, err, services.ErrDisputeAlreadyOpen)
+
+	board, err := newLeaderboardSvc(db).RoundLeaderboard(ctx, roundID)
+	require.NoError(t, err)
+	for _, e := range board.Entries {
+		if e.RoundPlayerID == aliceRP.ID.String() {
+			assert.Equal(t, []int{7}, e.DisputedHoles)
+		} else {
+			assert.Empty(t, e.DisputedHoles)
+		}
+	}
+}
+
+func TestScoreService_RaiseDispute_OutsiderForbidden(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	roundID, _, alice, aliceRP := historyFixture(t, db)
+	enterCard(t, db, aliceRP.ID, alice.ID, nil)
+	outsider := seedUser(t, db, "disputeOutsider")
+
+	_, err := svc.RaiseDispute(context.Background(), roundID, aliceRP.ID, outsider.ID, "user", 1, "no")
+	assert.ErrorIs(t, err, services.ErrDisputeForbidden)
+}
+
+func TestScoreService_RaiseDispute_UnscoredHole(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	roundID, organizer, _, aliceRP := historyFixture(t, db)
+
+	_, err := svc.RaiseDispute(context.Background(), roundID, aliceRP.ID, organizer.ID, "user", 1, "no score yet")
+	var ve *services.ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestScoreService_ResolveDispute_CorrectRescoresHole(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, organizer, alice, aliceRP := historyFixture(t, db)
+	enterCard(t, db, aliceRP.ID, alice.ID, nil)
+	dispute, err := svc.RaiseDispute(ctx, roundID, aliceRP.ID, organizer.ID, "user", 7, "saw a 5")
+	require.NoError(t, err)
+	disputeID := uuid.MustParse(dispute.ID)
+
+	five := 5
+	_, err = svc.ResolveDispute(ctx, roundID, disputeID, alice.ID, "user",
+		services.ResolveDisputeInput{Action: services.DisputeActionCorrect, GrossScore: &five})
+	assert.ErrorIs(t, err, services.ErrScoreForbidden, "only organizers resolve")
+
+	resolved, err := svc.ResolveDispute(ctx, roundID, disputeID, organizer.ID, "user",
+		services.ResolveDisputeInput{Action: services.DisputeActionCorrect, GrossScore: &five})
+	require.NoError(t, err)
+	assert.Equal(t, string(models.ScoreDisputeStatusCorrected), resolved.Status)
+	assert.Equal(t, 5, *resolved.GrossScore)
+	require.NotNil(t, resolved.ResolvedBy)
+
+	_, err = svc.ResolveDispute(ctx, roundID, disputeID, organizer.ID, "user",
+		services.ResolveDisputeInput{Action: services.DisputeActionDismiss})
+	assert.ErrorIs(t, err, services.ErrDisputeResolved)
+
+	open, err := svc.ListDisputes(ctx, roundID, organizer.ID, "user", "")
+	require.NoError(t, err)
+	assert.Empty(t, open)
+	all, err := svc.ListDisputes(ctx, roundID, organizer.ID, "user", "all")
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func TestScoreService_ListDisputes_NonOrganizerForbidden(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	roundID, _, alice, _ := historyFixture(t, db)
+
+	_, err := svc.ListDisputes(context.Background(), roundID, alice.ID, "user", "")
+	assert.ErrorIs(t, err, services.ErrScoreForbidden)
+}
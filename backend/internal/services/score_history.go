@@ -0,0 +1,265 @@
+// services/score_history.go
+// Score change audit trail: the append-only score_changes rows written by
+// UpsertScores, UpsertHoleStats and SetHandicap, the history reader behind
+// GET /rounds/:roundId/players/:roundPlayerId/history, and the organizer revert.
+//
+// Values are stored as small JSON objects so one table covers all three kinds:
+//
+//	score     {"gross_score":5,"net_score":4}
+//	hole_stat the ScorecardHoleStatData shape returned by the scorecard
+//	handicap  {"course_handicap":12}
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ScoreChangeData is one history entry, returned directly as JSON by the handler.
+type ScoreChangeData struct {
+	ID            string          `json:"id"`
+	Kind          string          `json:"kind"`
+	HoleNumber    *int            `json:"hole_number"`
+	OldValue      json.RawMessage `json:"old_value"` // null when the row was first created
+	NewValue      json.RawMessage `json:"new_value"` // null when a revert removed the row
+	ChangedBy     string          `json:"changed_by"`
+	ChangedByName string          `json:"changed_by_name"`
+	RevertOf      *string         `json:"revert_of"`
+	CreatedAt     string          `json:"created_at"`
+}
+
+// scoreHistoryValue is the stored JSON shape of a score change.
+type scoreHistoryValue struct {
+	GrossScore int `json:"gross_score"`
+	NetScore   int `json:"net_score"`
+}
+
+// scoreValue encodes a hole score for score_changes.
+func scoreValue(gross, net int) string {
+	// Marshal of these fixed structs cannot fail; the error is ignored deliberately.
+	b, _ := json.Marshal(scoreHistoryValue{GrossScore: gross, NetScore: net})
+	return string(b)
+}
+
+// handicapValue encodes a course handicap for score_changes; nil when unset.
+func handicapValue(h *int) *string {
+	if h == nil {
+		return nil
+	}
+	b, _ := json.Marshal(map[string]int{"course_handicap": *h})
+	v := string(b)
+	return &v
+}
+
+// holeStatValue encodes a hole stat row for score_changes.
+func holeStatValue(st models.HoleStat) string {
+	b, _ := json.Marshal(holeStatData(st))
+	return string(b)
+}
+
+// holeStatData maps a hole_stats row to its scorecard/history representation.
+func holeStatData(st models.HoleStat) ScorecardHoleStatData {
+	return ScorecardHoleStatData{
+		HoleNumber: st.HoleNumber, GIR: st.GIR, GIRMissDirection: st.GIRMissDirection,
+		FIR: st.FIR, FIRMissDirection: st.FIRMissDirection, FIROB: st.FIROB, GIROB: st.GIROB,
+		Putts:             st.Putts,
+		FirstPuttDistance: st.FirstPuttDistance, PuttDistanceMade: st.PuttDistanceMade,
+		ApproachYds: st.ApproachYds, TeeShotClub: st.TeeShotClub, TeeShotDistance: st.TeeShotDistance,
+	}
+}
+
+// recordScoreChanges appends history rows inside the caller's transaction.
+// A no-op for an empty slice.
+func recordScoreChanges(tx *gorm.DB, changes []models.ScoreChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := tx.Omit(clause.Associations).Create(&changes).Error; err != nil {
+		return fmt.Errorf("record score changes: %w", err)
+	}
+	return nil
+}
+
+// intPtr returns a pointer to a copy of n.
+func intPtr(n int) *int { return &n }
+
+// ─── GetScoreHistory ──────────────────────────────────────────────────────────
+
+// GetScoreHistory returns every recorded change to a player's card in the round,
+// newest first. The same privacy rule as the scorecard applies: outside the
+// round, a player who hides their stats has their history withheld
+// (ErrStatsHidden, or ErrProfilePrivate / ErrUserNotFound per their profile).
+func (s *ScoreService) GetScoreHistory(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole string) ([]ScoreChangeData, error) {
+	var rp models.RoundPlayer
+	if err := s.DB.WithContext(ctx).Preload("Round").
+		First(&rp, "id = ? AND round_id = ?", roundPlayerID, roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundPlayerNotFound
+		}
+		return nil, fmt.Errorf("load round player: %w", err)
+	}
+	isOrg, err := s.isRoundOrganizer(ctx, &rp.Round, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	shared := isOrg
+	if !shared {
+		if shared, err = s.sharesRound(ctx, &rp.Round, callerID); err != nil {
+			return nil, err
+		}
+	}
+	if !shared {
+		if err := requireProfileAccess(ctx, s.DB, callerID, rp.UserID, true); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []models.ScoreChange
+	if err := s.DB.WithContext(ctx).
+		Preload("Changer").
+		Where("round_player_id = ?", roundPlayerID).
+		Order("created_at DESC, id").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("load score history: %w", err)
+	}
+
+	out := make([]ScoreChangeData, 0, len(changes))
+	for _, ch := range changes {
+		out = append(out, scoreChangeData(ch))
+	}
+	return out, nil
+}
+
+// scoreChangeData maps a score_changes row (with Changer preloaded) to its response.
+func scoreChangeData(ch models.ScoreChange) ScoreChangeData {
+	d := ScoreChangeData{
+		ID: ch.ID.String(), Kind: ch.Kind, HoleNumber: ch.HoleNumber,
+		ChangedBy: ch.ChangedBy.String(), ChangedByName: ch.Changer.DisplayName,
+		CreatedAt: ch.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if ch.OldValue != nil {
+		d.OldValue = json.RawMessage(*ch.OldValue)
+	}
+	if ch.NewValue != nil {
+		d.NewValue = json.RawMessage(*ch.NewValue)
+	}
+	if ch.RevertOf != nil {
+		id := ch.RevertOf.String()
+		d.RevertOf = &id
+	}
+	return d
+}
+
+// ─── RevertScoreChange ────────────────────────────────────────────────────────
+
+// RevertScoreChange undoes one hole-score change by restoring the hole to the
+// value it held before that change. Net is recomputed from the player's current
+// handicap. When the change created the score, the revert removes it. The revert
+// is itself recorded, with revert_of pointing at changeID.
+//
+// Organizer-only (admin, event organizer, or creator of an eventless round), and
+// subject to the finalized-event lock like any other score edit.
+func (s *ScoreService) RevertScoreChange(ctx context.Context, roundID, roundPlayerID, changeID, callerID uuid.UUID, callerRole string) (*ScoreChangeData, error) {
+	var round models.Round
+	if err := s.DB.WithContext(ctx).
+		Preload("DefaultTee.Holes").Preload("Course").Preload("Event").
+		First(&round, "id = ?", roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundNotFound
+		}
+		return nil, fmt.Errorf("load round: %w", err)
+	}
+
+	isOrg, err := s.isRoundOrganizer(ctx, &round, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if !isOrg {
+		return nil, ErrScoreForbidden
+	}
+	// Organizers always pass canModifyScores; it is called for the finalized-event lock.
+	if _, err := s.canModifyScores(ctx, roundID, roundPlayerID, callerID, callerRole); err != nil {
+		return nil, err
+	}
+
+	var rp models.RoundPlayer
+	if err := s.DB.WithContext(ctx).First(&rp, "id = ? AND round_id = ?", roundPlayerID, roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundPlayerNotFound
+		}
+		return nil, fmt.Errorf("load round player: %w", err)
+	}
+
+	var change models.ScoreChange
+	if err := s.DB.WithContext(ctx).
+		First(&change, "id = ? AND round_player_id = ?", changeID, roundPlayerID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScoreChangeNotFound
+		}
+		return nil, fmt.Errorf("load score change: %w", err)
+	}
+	if change.Kind != string(models.ScoreChangeKindScore) || change.HoleNumber == nil {
+		return nil, &ValidationError{Field: "change_id", Message: "only hole score changes can be reverted"}
+	}
+	hole := *change.HoleNumber
+
+	// Read the hole and write it back in one transaction, holding the score
+	// row so a concurrent entry can't slip in between.
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.Score
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "round_player_id = ? AND hole_number = ?", rp.ID, hole).Error
+		hasCurrent := err == nil
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("load current score: %w", err)
+		}
+
+		if change.OldValue == nil {
+			// The change created the score — reverting removes it.
+			if !hasCurrent {
+				return &ValidationError{Field: "change_id", Message: "hole already has that value"}
+			}
+			oldValue := scoreValue(current.GrossScore, current.NetScore)
+			if err := tx.Delete(&models.Score{}, "id = ?", current.ID).Error; err != nil {
+				return fmt.Errorf("delete score: %w", err)
+			}
+			return recordScoreChanges(tx, []models.ScoreChange{{
+				RoundPlayerID: rp.ID, Kind: string(models.ScoreChangeKindScore), HoleNumber: intPtr(hole),
+				OldValue: &oldValue, ChangedBy: callerID, RevertOf: &change.ID,
+			}})
+		}
+		var prior scoreHistoryValue
+		if err := json.Unmarshal([]byte(*change.OldValue), &prior); err != nil {
+			return fmt.Errorf("decode score change: %w", err)
+		}
+		if hasCurrent && current.GrossScore == prior.GrossScore {
+			return &ValidationError{Field: "change_id", Message: "hole already has that value"}
+		}
+		return writeScoresTx(tx, &round, &rp, callerID,
+			[]ScoreInput{{HoleNumber: hole, GrossScore: prior.GrossScore}}, &change.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.afterScoreWrite(ctx, &round, rp.ID); err != nil {
+		return nil, err
+	}
+
+	var recorded models.ScoreChange
+	if err := s.DB.WithContext(ctx).Preload("Changer").
+		Where("revert_of = ?", change.ID).
+		Order("created_at DESC").
+		First(&recorded).Error; err != nil {
+		return nil, fmt.Errorf("load revert entry: %w", err)
+	}
+	data := scoreChangeData(recorded)
+	return &data, nil
+}
@@ -0,0 +1,209 @@
+// services/score_history_test.go
+// Integration tests for the score change audit trail (score_history.go).
+// Uses testutil.NewTestDB to spin up an ephemeral Postgres container — Docker
+// must be running.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+// historyFixture builds an active eventless round whose creator (the organizer)
+// plays alongside alice in group 1.
+func historyFixture(t *testing.T, db *gorm.DB) (roundID uuid.UUID, organizer, alice models.User, aliceRP models.RoundPlayer) {
+	t.Helper()
+	organizer = seedUser(t, db, "historyOrganizer")
+	alice = seedUser(t, db, "historyAlice")
+	course, tee := seedCourseWithTee(t, db, "History Course")
+	seedHoles(t, db, tee.ID)
+	round := seedEventlessRound(t, db, organizer.ID, course.ID, tee.ID)
+	orgRP := addEventlessRoundPlayer(t, db, round.ID, organizer.ID)
+	aliceRP = addEventlessRoundPlayer(t, db, round.ID, alice.ID)
+	addGroupWithPlayer(t, db, round.ID, 1, orgRP.ID)
+	addGroupWithPlayer(t, db, round.ID, 1, aliceRP.ID)
+	return round.ID, organizer, alice, aliceRP
+}
+
+// grossOf decodes the gross_score from a score history value.
+func grossOf(t *testing.T, raw json.RawMessage) int {
+	t.Helper()
+	var v struct {
+		GrossScore int `json:"gross_score"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &v))
+	return v.GrossScore
+}
+
+func TestScoreService_History_RecordsChangesNotReplays(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, organizer, alice, aliceRP := historyFixture(t, db)
+
+	_, err := svc.UpsertScores(ctx, roundID, aliceRP.ID, alice.ID, "user", []services.ScoreInput{{HoleNumber: 1, GrossScore: 4}})
+	require.NoError(t, err)
+	// Replayed save (same value) records nothing.
+	_, err = svc.UpsertScores(ctx, roundID, aliceRP.ID, alice.ID, "user", []services.ScoreInput{{HoleNumber: 1, GrossScore: 4}})
+	require.NoError(t, err)
+	_, err = svc.UpsertScores(ctx, roundID, aliceRP.ID, organizer.ID, "user", []services.ScoreInput{{HoleNumber: 1, GrossScore: 6}})
+	require.NoError(t, err)
+
+	history, err := svc.GetScoreHistory(ctx, roundID, aliceRP.ID, alice.ID, "user")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	latest := history[0]
+	assert.Equal(t, string(models.ScoreChangeKindScore), latest.Kind)
+	assert.Equal(t, organizer.ID.String(), latest.ChangedBy)
+	assert.Equal(t, 4, grossOf(t, latest.OldValue))
+	assert.Equal(t, 6, grossOf(t, latest.NewValue))
+	assert.Nil(t, history[1].OldValue, "first entry has no prior value")
+}
+
+func TestScoreService_History_RecordsHandicapAndHoleStats(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, _, alice, aliceRP := historyFixture(t, db)
+
+	require.NoError(t, svc.SetHandicap(ctx, roundID, aliceRP.ID, alice.ID, "user", 12))
+	putts := 2
+	_, err := svc.UpsertHoleStats(ctx, roundID, aliceRP.ID, alice.ID, "user", []services.HoleStatInput{{HoleNumber: 1, Putts: &putts}})
+	require.NoError(t, err)
+
+	history, err := svc.GetScoreHistory(ctx, roundID, aliceRP.ID, alice.ID, "user")
+	require.NoError(t, err)
+	kinds := make([]string, 0, len(history))
+	for _, h := range history {
+		kinds = append(kinds, h.Kind)
+	}
+	assert.ElementsMatch(t, []string{string(models.ScoreChangeKindHandicap), string(models.ScoreChangeKindHoleStat)}, kinds)
+}
+
+func TestScoreService_History_HandicapRecordsNetBackfill(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, _, alice, aliceRP := historyFixture(t, db)
+	_, err := svc.UpsertScores(ctx, roundID, aliceRP.ID, alice.ID, "user", []services.ScoreInput{{HoleNumber: 1, GrossScore: 5}})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.SetHandicap(ctx, roundID, aliceRP.ID, alice.ID, "user", 18))
+
+	history, err := svc.GetScoreHistory(ctx, roundID, aliceRP.ID, alice.ID, "user")
+	require.NoError(t, err)
+	var net *services.ScoreChangeData
+	for i := range history {
+		if history[i].Kind == string(models.ScoreChangeKindScore) && history[i].OldValue != nil {
+			net = &history[i]
+		}
+	}
+	require.NotNil(t, net, "the net back-fill is in the history")
+	var stored models.Score
+	require.NoError(t, db.First(&stored, "round_player_id = ? AND hole_number = 1", aliceRP.ID).Error)
+	var v struct {
+		NetScore int `json:"net_score"`
+	}
+	require.NoError(t, json.Unmarshal(net.NewValue, &v))
+	assert.Equal(t, stored.NetScore, v.NetScore, "history matches the card")
+	assert.Equal(t, 4, stored.NetScore)
+}
+
+func TestRecalculateEventScores_RecordsChangedScores(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	ctx := context.Background()
+	_, event, aliceRP, bobRP := tiedRound(t, db)
+	organizer := organizerOf(t, db, event.ID)
+	require.NoError(t, db.Model(&aliceRP).Update("course_handicap", 18).Error)
+
+	require.NoError(t, services.RecalculateEventScores(ctx, db, event.ID, organizer, ptrFloat(100)))
+
+	var changes []models.ScoreChange
+	require.NoError(t, db.Where("round_player_id = ?", aliceRP.ID).Find(&changes).Error)
+	require.Len(t, changes, 18, "a stroke on every hole")
+	assert.Equal(t, organizer, changes[0].ChangedBy)
+	var v struct {
+		NetScore int `json:"net_score"`
+	}
+	require.NotNil(t, changes[0].NewValue)
+	require.NoError(t, json.Unmarshal([]byte(*changes[0].NewValue), &v))
+	assert.Equal(t, 3, v.NetScore)
+
+	var bobChanges int64
+	require.NoError(t, db.Model(&models.ScoreChange{}).Where("round_player_id = ?", bobRP.ID).Count(&bobChanges).Error)
+	assert.Zero(t, bobChanges, "scratch player's net scores didn't change")
+
+	// Recalculating with the same allowance changes nothing, so records nothing.
+	require.NoError(t, services.RecalculateEventScores(ctx, db, event.ID, organizer, ptrFloat(100)))
+	var total int64
+	require.NoError(t, db.Model(&models.ScoreChange{}).Count(&total).Error)
+	assert.Equal(t, int64(18), total)
+}
+
+func TestScoreService_RevertScoreChange_RestoresPriorValue(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, organizer, alice, aliceRP := historyFixture(t, db)
+
+	_, err := svc.UpsertScores(ctx, roundID, aliceRP.ID, alice.ID, "user", []services.ScoreInput{{HoleNumber: 3, GrossScore: 4}})
+	require.NoError(t, err)
+	_, err = svc.UpsertScores(ctx, roundID, aliceRP.ID, alice.ID, "user", []services.ScoreInput{{HoleNumber: 3, GrossScore: 3}})
+	require.NoError(t, err)
+	history, err := svc.GetScoreHistory(ctx, roundID, aliceRP.ID, alice.ID, "user")
+	require.NoError(t, err)
+	changeID := uuid.MustParse(history[0].ID)
+
+	_, err = svc.RevertScoreChange(ctx, roundID, aliceRP.ID, changeID, alice.ID, "user")
+	assert.ErrorIs(t, err, services.ErrScoreForbidden, "group members cannot revert")
+
+	entry, err := svc.RevertScoreChange(ctx, roundID, aliceRP.ID, changeID, organizer.ID, "user")
+	require.NoError(t, err)
+	require.NotNil(t, entry.RevertOf)
+	assert.Equal(t, changeID.String(), *entry.RevertOf)
+
+	var score models.Score
+	require.NoError(t, db.First(&score, "round_player_id = ? AND hole_number = ?", aliceRP.ID, 3).Error)
+	assert.Equal(t, 4, score.GrossScore)
+}
+
+func TestScoreService_RevertScoreChange_FirstEntryRemovesScore(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, organizer, alice, aliceRP := historyFixture(t, db)
+
+	_, err := svc.UpsertScores(ctx, roundID, aliceRP.ID, alice.ID, "user", []services.ScoreInput{{HoleNumber: 5, GrossScore: 7}})
+	require.NoError(t, err)
+	history, err := svc.GetScoreHistory(ctx, roundID, aliceRP.ID, alice.ID, "user")
+	require.NoError(t, err)
+
+	_, err = svc.RevertScoreChange(ctx, roundID, aliceRP.ID, uuid.MustParse(history[0].ID), organizer.ID, "user")
+	require.NoError(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Score{}).Where("round_player_id = ? AND hole_number = ?", aliceRP.ID, 5).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestScoreService_RevertScoreChange_UnknownChange(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	roundID, organizer, _, aliceRP := historyFixture(t, db)
+
+	_, err := svc.RevertScoreChange(context.Background(), roundID, aliceRP.ID, uuid.New(), organizer.ID, "user")
+	assert.ErrorIs(t, err, services.ErrScoreChangeNotFound)
+}
@@ -12,6 +12,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/trentd187/golf-league/internal/models"
@@ -30,6 +32,24 @@ var (
 	ErrHandicapRequired = errors.New("handicap must be set before entering scores for this round")
 	// ErrRoundNotActive is returned when a non-organizer tries to modify scores on a round that is not active.
 	ErrRoundNotActive = errors.New("round is not active — scores can only be entered while the round is in progress")
+	// ErrScoresLocked is returned when a non-admin tries to modify scores in a finalized event.
+	ErrScoresLocked = errors.New("event results are finalized — scores are locked")
+	// ErrScorecardAttested is returned when a group member tries to modify an attested card.
+	ErrScorecardAttested = errors.New("scorecard is attested — only an organizer can correct it")
+	// ErrScorecardIncomplete is returned when attesting a card that is missing hole scores.
+	ErrScorecardIncomplete = errors.New("every hole must be scored before the card can be attested")
+	// ErrAttestForbidden is returned when the caller is neither the player nor a marker in their group.
+	ErrAttestForbidden = errors.New("only the player or a marker from their group can attest this card")
+	// ErrScoreChangeNotFound is returned when a score history entry does not exist for the player.
+	ErrScoreChangeNotFound = errors.New("score change not found")
+	// ErrDisputeNotFound is returned when a score dispute does not exist in the round.
+	ErrDisputeNotFound = errors.New("dispute not found")
+	// ErrDisputeForbidden is returned when someone outside the round tries to dispute a score.
+	ErrDisputeForbidden = errors.New("only players in this round can dispute a score")
+	// ErrDisputeAlreadyOpen is returned when the hole already has an open dispute.
+	ErrDisputeAlreadyOpen = errors.New("this hole already has an open dispute")
+	// ErrDisputeResolved is returned when resolving a dispute that is no longer open.
+	ErrDisputeResolved = errors.New("dispute is already resolved")
 )
 
 // ─── Input types ──────────────────────────────────────────────────────────────
@@ -72,6 +92,9 @@ type ScorecardScoreData struct {
 	HoleNumber int `json:"hole_number"`
 	GrossScore int `json:"gross_score"`
 	NetScore   int `json:"net_score"`
+	// Generated is the absence policy that produced the score for an absent
+	// player (blind_draw, phantom_par, phantom_average); nil when entered.
+	Generated *string `json:"generated"`
 }
 
 // ScorecardHoleStatData holds advanced per-hole stats for one player on one hole.
@@ -97,7 +120,10 @@ type ScorecardPlayerData struct {
 	UserID         string  `json:"user_id"`
 	DisplayName    string  `json:"display_name"`
 	AvatarURL      *string `json:"avatar_url"`
-	IsGuest        bool    `json:"is_guest"` // score-only guest; UI hides synthetic email and skips advanced stats
+	IsGuest        bool    `json:"is_guest"`      // score-only guest; UI hides synthetic email and skips advanced stats
+	Status         string  `json:"status"`        // round_player status; "completed" once the card is attested
+	Absent         bool    `json:"absent"`        // marked absent; the card is generated (see Scores[].Generated)
+	StatusReason   *string `json:"status_reason"` // why the player is withdrawn, disqualified or no_card
 	CourseHandicap *int    `json:"course_handicap"`
 	// EffectiveCourseHandicap is CourseHandicap after applying the event's handicap allowance.
 	// Nil when CourseHandicap is nil; equals CourseHandicap when no allowance is set.
@@ -111,6 +137,23 @@ type ScorecardPlayerData struct {
 	// TotalGross/TotalNet are nil until all holes have been scored (prevents partial totals).
 	TotalGross *int `json:"total_gross"`
 	TotalNet   *int `json:"total_net"`
+	// Attestation state — see AttestScorecard.
+	PlayerAttested     bool `json:"player_attested"`
+	MarkerAttested     bool `json:"marker_attested"`
+	NeedsReattestation bool `json:"needs_reattestation"`
+	// ScoresHidden means the player's privacy settings keep their scores and
+	// stats from a caller outside the round; Scores and HoleStats are empty.
+	ScoresHidden bool `json:"scores_hidden"`
+}
+
+// AttestationData is the attestation state of one card, returned by AttestScorecard.
+type AttestationData struct {
+	RoundPlayerID      string  `json:"round_player_id"`
+	Status             string  `json:"status"`
+	PlayerAttestedAt   *string `json:"player_attested_at"`
+	MarkerAttestedAt   *string `json:"marker_attested_at"`
+	MarkerID           *string `json:"marker_id"`
+	NeedsReattestation bool    `json:"needs_reattestation"`
 }
 
 // ScorecardGroupData is one tee-time group's slice of the scorecard.
@@ -169,11 +212,33 @@ func NewScoreService(db *gorm.DB, eventSvc *EventService) *ScoreService {
 
 // ─── Permission helper ────────────────────────────────────────────────────────
 
+// requireEventNotFinalized returns ErrScoresLocked when eventID is a finalized
+// event. Eventless rounds (nil) are never locked.
+func requireEventNotFinalized(ctx context.Context, db *gorm.DB, eventID *uuid.UUID) error {
+	if eventID == nil {
+		return nil
+	}
+	var finalized int64
+	if err := db.WithContext(ctx).Model(&models.Event{}).
+		Where("id = ? AND finalized_at IS NOT NULL", *eventID).
+		Count(&finalized).Error; err != nil {
+		return fmt.Errorf("check event finalized: %w", err)
+	}
+	if finalized > 0 {
+		return ErrScoresLocked
+	}
+	return nil
+}
+
 // canModifyScores returns true when callerID may enter or modify scores for
 // targetRoundPlayerID. The three allowed cases:
 //  1. Global admin → always allowed.
 //  2. Round organizer (uses EventSvc.IsOrganizer after loading event_id from the round).
 //  3. Caller is in the same tee-time group as the target player.
+//
+// Rounds in a finalized event are locked for cases 2 and 3 (ErrScoresLocked).
+// An attested card is closed to case 3 (ErrScorecardAttested); organizer
+// corrections still go through and are flagged for re-attestation.
 func (s *ScoreService) canModifyScores(ctx context.Context, roundID, targetRoundPlayerID, callerID uuid.UUID, callerRole string) (bool, error) {
 	if callerRole == "admin" {
 		return true, nil
@@ -187,6 +252,10 @@ func (s *ScoreService) canModifyScores(ctx context.Context, roundID, targetRound
 		return false, fmt.Errorf("load round for permission check: %w", err)
 	}
 
+	if err := requireEventNotFinalized(ctx, s.DB, round.EventID); err != nil {
+		return false, err
+	}
+
 	var isOrg bool
 	if round.EventID != nil {
 		var err error
@@ -207,6 +276,18 @@ func (s *ScoreService) canModifyScores(ctx context.Context, roundID, targetRound
 		return false, ErrRoundNotActive
 	}
 
+	var target models.RoundPlayer
+	if err := s.DB.WithContext(ctx).Select("status").
+		First(&target, "id = ? AND round_id = ?", targetRoundPlayerID, roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, ErrRoundPlayerNotFound
+		}
+		return false, fmt.Errorf("load target player: %w", err)
+	}
+	if target.Status == models.RoundPlayerStatusCompleted {
+		return false, ErrScorecardAttested
+	}
+
 	// Find which group the target player belongs to.
 	var targetGP models.GroupPlayer
 	if err := s.DB.WithContext(ctx).First(&targetGP, "round_player_id = ?", targetRoundPlayerID).Error; err != nil {
@@ -243,6 +324,24 @@ func (s *ScoreService) canModifyScores(ctx context.Context, roundID, targetRound
 	return err2 == nil, nil
 }
 
+// isRoundOrganizer reports whether the caller may act as organizer of round:
+// a global admin, an organizer of the round's event, or the creator of an
+// eventless round. round needs only EventID and CreatedBy loaded.
+func (s *ScoreService) isRoundOrganizer(ctx context.Context, round *models.Round, callerID uuid.UUID, callerRole string) (bool, error) {
+	if callerRole == "admin" {
+		return true, nil
+	}
+	if round.EventID == nil {
+		// Eventless round: creator is the organizer.
+		return round.CreatedBy != nil && *round.CreatedBy == callerID, nil
+	}
+	isOrg, err := s.EventSvc.IsOrganizer(ctx, *round.EventID, callerID, callerRole)
+	if err != nil {
+		return false, fmt.Errorf("check organizer: %w", err)
+	}
+	return isOrg, nil
+}
+
 // ─── GetScorecard ─────────────────────────────────────────────────────────────
 
 // GetScorecardsForRounds assembles the scorecards for a set of rounds in one call,
@@ -272,11 +371,14 @@ func (s *ScoreService) GetScorecardsForRounds(ctx context.Context, roundIDs []uu
 // avoid fanning out one /rounds/:id/scorecard request per round (the FRONTEND-2 N+1). It
 // selects the same event-linked completed rounds as UserService.GetUserRounds (newest first),
 // then assembles each scorecard via GetScorecardsForRounds. last is clamped to ≥1; the caller
-// bounds the upper end.
+// bounds the upper end. The target's privacy settings must let the caller see their stats.
 func (s *ScoreService) GetUserScorecards(ctx context.Context, targetID, callerID uuid.UUID, callerRole string, last int) ([]*ScorecardData, error) {
 	if last < 1 {
 		last = 1
 	}
+	if err := requireProfileAccess(ctx, s.DB, callerID, targetID, true); err != nil {
+		return nil, err
+	}
 	var roundIDs []uuid.UUID
 	if err := s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).
 		Select("rounds.id").
@@ -292,7 +394,9 @@ func (s *ScoreService) GetUserScorecards(ctx context.Context, targetID, callerID
 }
 
 // GetScorecard assembles the full scorecard for a round. Any authenticated
-// user may call this — no write permission required.
+// user may call this — no write permission required. A caller outside the round
+// doesn't see the scores of players whose privacy settings hide them (see
+// withholdPrivateCards).
 // callerID may be uuid.Nil (unauthenticated fallback) — IsOrganizer returns false in that case.
 func (s *ScoreService) GetScorecard(ctx context.Context, roundID, callerID uuid.UUID, callerRole string) (*ScorecardData, error) {
 	var round models.Round
@@ -307,15 +411,9 @@ func (s *ScoreService) GetScorecard(ctx context.Context, roundID, callerID uuid.
 		return nil, fmt.Errorf("load round: %w", err)
 	}
 
-	var isOrg bool
-	if round.EventID != nil {
-		var err error
-		isOrg, err = s.EventSvc.IsOrganizer(ctx, *round.EventID, callerID, callerRole)
-		if err != nil {
-			return nil, fmt.Errorf("check organizer: %w", err)
-		}
-	} else {
-		isOrg = round.CreatedBy != nil && *round.CreatedBy == callerID
+	isOrg, err := s.isRoundOrganizer(ctx, &round, callerID, callerRole)
+	if err != nil {
+		return nil, err
 	}
 
 	effectiveHoleCount := round.Course.HoleCount
@@ -367,6 +465,9 @@ func (s *ScoreService) GetScorecard(ctx context.Context, roundID, callerID uuid.
 			GroupID: g.ID.String(), GroupNumber: g.GroupNumber, Players: players,
 		})
 	}
+	if err := s.withholdPrivateCards(ctx, &round, callerID, isOrg, groupData); err != nil {
+		return nil, err
+	}
 
 	return &ScorecardData{
 		RoundID:              round.ID.String(),
@@ -387,6 +488,68 @@ func (s *ScoreService) GetScorecard(ctx context.Context, roundID, callerID uuid.
 	}, nil
 }
 
+// sharesRound reports whether callerID plays in the round or belongs to its
+// event. Those players see every card in it, whatever the privacy settings.
+func (s *ScoreService) sharesRound(ctx context.Context, round *models.Round, callerID uuid.UUID) (bool, error) {
+	var shared int64
+	if err := s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).
+		Where("round_id = ? AND user_id = ?", round.ID, callerID).
+		Count(&shared).Error; err != nil {
+		return false, fmt.Errorf("check round membership: %w", err)
+	}
+	if shared == 0 && round.EventID != nil {
+		if err := s.DB.WithContext(ctx).Model(&models.EventPlayer{}).
+			Where("event_id = ? AND user_id = ?", *round.EventID, callerID).
+			Count(&shared).Error; err != nil {
+			return false, fmt.Errorf("check event membership: %w", err)
+		}
+	}
+	return shared > 0, nil
+}
+
+// withholdPrivateCards empties the scores and stats of players who keep them
+// from callerID. Organizers, players in the round and members of its event
+// share the round and see every card.
+func (s *ScoreService) withholdPrivateCards(ctx context.Context, round *models.Round, callerID uuid.UUID, isOrg bool, groups []ScorecardGroupData) error {
+	if isOrg {
+		return nil
+	}
+	shared, err := s.sharesRound(ctx, round, callerID)
+	if err != nil || shared {
+		return err
+	}
+	var userIDs []uuid.UUID
+	for _, g := range groups {
+		for _, p := range g.Players {
+			if p.IsGuest {
+				continue
+			}
+			userID, err := uuid.Parse(p.UserID)
+			if err != nil {
+				return fmt.Errorf("parse player user id: %w", err)
+			}
+			userIDs = append(userIDs, userID)
+		}
+	}
+	access, err := loadProfileAccesses(ctx, s.DB, callerID, userIDs)
+	if err != nil {
+		return err
+	}
+	for gi := range groups {
+		for pi := range groups[gi].Players {
+			p := &groups[gi].Players[pi]
+			if p.IsGuest || access[uuid.MustParse(p.UserID)].Stats {
+				continue
+			}
+			p.Scores = []ScorecardScoreData{}
+			p.HoleStats = []ScorecardHoleStatData{}
+			p.TotalGross, p.TotalNet = nil, nil
+			p.ScoresHidden = true
+		}
+	}
+	return nil
+}
+
 // assembleGroupPlayers joins group_players → round_players → users
 // and loads each player's scores and hole stats.
 // Uses rp.user_id directly — works for both event-linked and eventless rounds
@@ -398,7 +561,14 @@ func (s *ScoreService) assembleGroupPlayers(ctx context.Context, groupID uuid.UU
 		DisplayName    string
 		AvatarURL      *string
 		IsGuest        bool
+		Status         string
+		StatusReason   *string
+		AbsentAt       *time.Time
 		CourseHandicap *int
+		// Attestation columns.
+		PlayerAttestedAt   *time.Time
+		MarkerAttestedAt   *time.Time
+		NeedsReattestation bool
 		// TeamID/TeamName come from the LEFT JOIN — nil when the player has no team.
 		TeamID   *string
 		TeamName *string
@@ -408,7 +578,7 @@ func (s *ScoreService) assembleGroupPlayers(ctx context.Context, groupID uuid.UU
 	// membership rides along on the scorecard. Non-Vegas rounds simply have no teams,
 	// so these columns stay nil.
 	if err := s.DB.WithContext(ctx).Table("group_players gp").
-		Select("gp.round_player_id, u.id as user_id, u.display_name, u.avatar_url, u.is_guest, rp.course_handicap, t.id as team_id, t.name as team_name").
+		Select("gp.round_player_id, u.id as user_id, u.display_name, u.avatar_url, u.is_guest, rp.status, rp.status_reason, rp.absent_at, rp.course_handicap, rp.player_attested_at, rp.marker_attested_at, rp.needs_reattestation, t.id as team_id, t.name as team_name").
 		Joins("JOIN round_players rp ON rp.id = gp.round_player_id").
 		Joins("JOIN users u ON u.id = rp.user_id").
 		Joins("LEFT JOIN team_members tm ON tm.round_player_id = gp.round_player_id").
@@ -435,6 +605,7 @@ func (s *ScoreService) assembleGroupPlayers(ctx context.Context, groupID uuid.UU
 		for _, sc := range dbScores {
 			scores = append(scores, ScorecardScoreData{
 				HoleNumber: sc.HoleNumber, GrossScore: sc.GrossScore, NetScore: sc.NetScore,
+				Generated: sc.Generated,
 			})
 			totalGross += sc.GrossScore
 			totalNet += sc.NetScore
@@ -454,13 +625,7 @@ func (s *ScoreService) assembleGroupPlayers(ctx context.Context, groupID uuid.UU
 
 		holeStats := make([]ScorecardHoleStatData, 0, len(dbStats))
 		for _, st := range dbStats {
-			holeStats = append(holeStats, ScorecardHoleStatData{
-				HoleNumber: st.HoleNumber, GIR: st.GIR, GIRMissDirection: st.GIRMissDirection,
-				FIR: st.FIR, FIRMissDirection: st.FIRMissDirection, FIROB: st.FIROB, GIROB: st.GIROB,
-				Putts:             st.Putts,
-				FirstPuttDistance: st.FirstPuttDistance, PuttDistanceMade: st.PuttDistanceMade,
-				ApproachYds: st.ApproachYds, TeeShotClub: st.TeeShotClub, TeeShotDistance: st.TeeShotDistance,
-			})
+			holeStats = append(holeStats, holeStatData(st))
 		}
 
 		var effHCP *int
@@ -471,10 +636,13 @@ func (s *ScoreService) assembleGroupPlayers(ctx context.Context, groupID uuid.UU
 
 		players = append(players, ScorecardPlayerData{
 			RoundPlayerID: pr.RoundPlayerID, UserID: pr.UserID, DisplayName: pr.DisplayName,
-			AvatarURL: pr.AvatarURL, IsGuest: pr.IsGuest, CourseHandicap: pr.CourseHandicap,
+			AvatarURL: pr.AvatarURL, IsGuest: pr.IsGuest, Status: pr.Status, CourseHandicap: pr.CourseHandicap,
 			EffectiveCourseHandicap: effHCP, TeamID: pr.TeamID, TeamName: pr.TeamName,
+			Absent: pr.AbsentAt != nil, StatusReason: pr.StatusReason,
 			Scores: scores, HoleStats: holeStats,
 			TotalGross: tg, TotalNet: tn,
+			PlayerAttested: pr.PlayerAttestedAt != nil, MarkerAttested: pr.MarkerAttestedAt != nil,
+			NeedsReattestation: pr.NeedsReattestation,
 		})
 	}
 	return players, nil
@@ -484,7 +652,8 @@ func (s *ScoreService) assembleGroupPlayers(ctx context.Context, groupID uuid.UU
 
 // SetHandicap sets the playing handicap (course_handicap) for a single round_player
 // and back-fills net_score on all existing score rows for that player so the
-// leaderboard reflects the updated handicap without requiring re-entry.
+// leaderboard reflects the updated handicap without requiring re-entry. Each
+// net score it changes is recorded in the card's history.
 // Caller must share a tee-time group with the target player, or be an organizer/admin.
 func (s *ScoreService) SetHandicap(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole string, handicap int) error {
 	ok, err := s.canModifyScores(ctx, roundID, roundPlayerID, callerID, callerRole)
@@ -495,30 +664,18 @@ func (s *ScoreService) SetHandicap(ctx context.Context, roundID, roundPlayerID,
 		return ErrScoreForbidden
 	}
 
-	var rp models.RoundPlayer
-	if err := s.DB.WithContext(ctx).First(&rp, "id = ? AND round_id = ?", roundPlayerID, roundID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrRoundPlayerNotFound
-		}
-		return fmt.Errorf("load round player: %w", err)
-	}
-
-	rp.CourseHandicap = &handicap
-	if err := s.DB.WithContext(ctx).Save(&rp).Error; err != nil {
-		return fmt.Errorf("save handicap: %w", err)
-	}
-
-	// Back-fill net_score for every score this player has already entered.
-	// Mirrors RecalculateEventScores but scoped to a single round_player.
 	var round models.Round
 	if err := s.DB.WithContext(ctx).
 		Preload("Event").
+		Preload("Course").
 		Preload("DefaultTee.Holes").
 		First(&round, "id = ?", roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRoundNotFound
+		}
 		return fmt.Errorf("load round for recalc: %w", err)
 	}
 	eff := EffectiveCourseHandicap(handicap, roundHandicapAllowance(&round))
-
 	played := filterPlayedHoles(round.DefaultTee.Holes, round.NineHoleSelection)
 	siMap := NormalizeStrokeIndexes(played)
 	holeCount := len(played)
@@ -526,28 +683,58 @@ func (s *ScoreService) SetHandicap(ctx context.Context, roundID, roundPlayerID,
 		holeCount = 18
 	}
 
-	type scoreRow struct {
-		ScoreID    uuid.UUID
-		GrossScore int
-		HoleNumber int
-	}
-	var rows []scoreRow
-	if err := s.DB.WithContext(ctx).Table("scores s").
-		Select("s.id as score_id, s.gross_score, s.hole_number").
-		Where("s.round_player_id = ?", roundPlayerID).
-		Scan(&rows).Error; err != nil {
-		return fmt.Errorf("load scores for recalc: %w", err)
-	}
+	// The handicap, the back-filled net scores and their history are written
+	// together so the card never disagrees with its history.
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rp models.RoundPlayer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&rp, "id = ? AND round_id = ?", roundPlayerID, roundID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRoundPlayerNotFound
+			}
+			return fmt.Errorf("load round player: %w", err)
+		}
+		oldValue := handicapValue(rp.CourseHandicap)
+		rp.CourseHandicap = &handicap
+		newValue := handicapValue(rp.CourseHandicap)
+		if err := tx.Save(&rp).Error; err != nil {
+			return fmt.Errorf("save handicap: %w", err)
+		}
+		var changes []models.ScoreChange
+		if oldValue == nil || *oldValue != *newValue {
+			changes = append(changes, models.ScoreChange{
+				RoundPlayerID: rp.ID, Kind: string(models.ScoreChangeKindHandicap),
+				OldValue: oldValue, NewValue: newValue, ChangedBy: callerID,
+			})
+		}
 
-	for _, row := range rows {
-		netScore := row.GrossScore - HandicapStrokes(eff, siMap[row.HoleNumber], holeCount)
-		if err := s.DB.WithContext(ctx).Model(&models.Score{}).
-			Where("id = ?", row.ScoreID).
-			Update("net_score", netScore).Error; err != nil {
-			return fmt.Errorf("update score %s: %w", row.ScoreID, err)
+		// Back-fill net_score for every score this player has already entered.
+		// Mirrors RecalculateEventScores but scoped to a single round_player.
+		var scores []models.Score
+		if err := tx.Where("round_player_id = ?", rp.ID).Order("hole_number").Find(&scores).Error; err != nil {
+			return fmt.Errorf("load scores for recalc: %w", err)
 		}
+		for _, sc := range scores {
+			netScore := sc.GrossScore - HandicapStrokes(eff, siMap[sc.HoleNumber], holeCount)
+			if netScore == sc.NetScore {
+				continue
+			}
+			if err := tx.Model(&models.Score{}).Where("id = ?", sc.ID).
+				Update("net_score", netScore).Error; err != nil {
+				return fmt.Errorf("update score %s: %w", sc.ID, err)
+			}
+			oldScore, newScore := scoreValue(sc.GrossScore, sc.NetScore), scoreValue(sc.GrossScore, netScore)
+			changes = append(changes, models.ScoreChange{
+				RoundPlayerID: rp.ID, Kind: string(models.ScoreChangeKindScore), HoleNumber: intPtr(sc.HoleNumber),
+				OldValue: &oldScore, NewValue: &newScore, ChangedBy: callerID,
+			})
+		}
+		return recordScoreChanges(tx, changes)
+	})
+	if err != nil {
+		return err
 	}
-	return nil
+	return s.afterScoreWrite(ctx, &round, roundPlayerID)
 }
 
 // roundHandicapAllowance returns the event's handicap allowance, or nil for
@@ -567,12 +754,22 @@ func roundHandicapAllowance(round *models.Round) *float64 {
 // Net score is calculated at save time from course_handicap and stroke_index.
 // Blocked when requires_handicap is true and course_handicap is not yet set.
 func (s *ScoreService) UpsertScores(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole string, scores []ScoreInput) (int, error) {
-	ok, err := s.canModifyScores(ctx, roundID, roundPlayerID, callerID, callerRole)
+	round, rp, err := s.prepareScoreWrite(ctx, roundID, roundPlayerID, callerID, callerRole, scores)
 	if err != nil {
 		return 0, err
 	}
+	return s.writeScores(ctx, round, rp, callerID, scores, nil)
+}
+
+// prepareScoreWrite runs UpsertScores' permission and input checks and loads
+// the round and round player the write needs.
+func (s *ScoreService) prepareScoreWrite(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole string, scores []ScoreInput) (*models.Round, *models.RoundPlayer, error) {
+	ok, err := s.canModifyScores(ctx, roundID, roundPlayerID, callerID, callerRole)
+	if err != nil {
+		return nil, nil, err
+	}
 	if !ok {
-		return 0, ErrScoreForbidden
+		return nil, nil, ErrScoreForbidden
 	}
 
 	var round models.Round
@@ -580,21 +777,21 @@ func (s *ScoreService) UpsertScores(ctx context.Context, roundID, roundPlayerID,
 		Preload("DefaultTee.Holes").Preload("Course").Preload("Event").
 		First(&round, "id = ?", roundID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return 0, ErrRoundNotFound
+			return nil, nil, ErrRoundNotFound
 		}
-		return 0, fmt.Errorf("load round: %w", err)
+		return nil, nil, fmt.Errorf("load round: %w", err)
 	}
 
 	var rp models.RoundPlayer
 	if err := s.DB.WithContext(ctx).First(&rp, "id = ? AND round_id = ?", roundPlayerID, roundID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return 0, ErrRoundPlayerNotFound
+			return nil, nil, ErrRoundPlayerNotFound
 		}
-		return 0, fmt.Errorf("load round player: %w", err)
+		return nil, nil, fmt.Errorf("load round player: %w", err)
 	}
 
 	if round.RequiresHandicap && rp.CourseHandicap == nil {
-		return 0, ErrHandicapRequired
+		return nil, nil, ErrHandicapRequired
 	}
 
 	// courseHoleCount is used for hole_number validation (back 9 has numbers 10–18).
@@ -603,21 +800,47 @@ func (s *ScoreService) UpsertScores(ctx context.Context, roundID, roundPlayerID,
 		courseHoleCount = 18
 	}
 
+	for _, sc := range scores {
+		if sc.HoleNumber < 1 || sc.HoleNumber > courseHoleCount {
+			return nil, nil, &ValidationError{Field: "hole_number", Message: "hole_number must be between 1 and course hole count"}
+		}
+		if sc.GrossScore < 1 {
+			return nil, nil, &ValidationError{Field: "gross_score", Message: "gross_score must be at least 1"}
+		}
+	}
+	return &round, &rp, nil
+}
+
+// writeScores upserts validated hole scores for rp and appends a score_changes
+// row for every hole whose value actually changed (a replayed save records
+// nothing). revertOf is set when the write is an organizer revert.
+// Net score is calculated from the player's course handicap and the round's
+// normalized stroke indexes. Any attestation on the card is cleared afterwards.
+func (s *ScoreService) writeScores(ctx context.Context, round *models.Round, rp *models.RoundPlayer, callerID uuid.UUID, scores []ScoreInput, revertOf *uuid.UUID) (int, error) {
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return writeScoresTx(tx, round, rp, callerID, scores, revertOf)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := s.afterScoreWrite(ctx, round, rp.ID); err != nil {
+		return 0, err
+	}
+	return len(scores), nil
+}
+
+// writeScoresTx is the transactional half of writeScores, for callers that
+// need the write inside a transaction of their own.
+func writeScoresTx(tx *gorm.DB, round *models.Round, rp *models.RoundPlayer, callerID uuid.UUID, scores []ScoreInput, revertOf *uuid.UUID) error {
 	// For handicap allocation, normalize SIs within the played subset so that
 	// a 9-hole course handicap distributes correctly across the 9 holes.
 	played := filterPlayedHoles(round.DefaultTee.Holes, round.NineHoleSelection)
 	siByHole := NormalizeStrokeIndexes(played)
 	handicapHoleCount := len(played)
 	if handicapHoleCount == 0 {
-		handicapHoleCount = courseHoleCount
-	}
-
-	for _, sc := range scores {
-		if sc.HoleNumber < 1 || sc.HoleNumber > courseHoleCount {
-			return 0, &ValidationError{Field: "hole_number", Message: "hole_number must be between 1 and course hole count"}
-		}
-		if sc.GrossScore < 1 {
-			return 0, &ValidationError{Field: "gross_score", Message: "gross_score must be at least 1"}
+		handicapHoleCount = round.Course.HoleCount
+		if handicapHoleCount == 0 {
+			handicapHoleCount = 18
 		}
 	}
 
@@ -625,28 +848,81 @@ func (s *ScoreService) UpsertScores(ctx context.Context, roundID, roundPlayerID,
 	if rp.CourseHandicap != nil {
 		rawHandicap = *rp.CourseHandicap
 	}
-	chandi := EffectiveCourseHandicap(rawHandicap, roundHandicapAllowance(&round))
+	chandi := EffectiveCourseHandicap(rawHandicap, roundHandicapAllowance(round))
 
 	records := make([]models.Score, 0, len(scores))
+	holes := make([]int, 0, len(scores))
 	for _, sc := range scores {
 		si := siByHole[sc.HoleNumber]
 		records = append(records, models.Score{
-			RoundPlayerID: roundPlayerID,
+			RoundPlayerID: rp.ID,
 			HoleNumber:    sc.HoleNumber,
 			GrossScore:    sc.GrossScore,
 			NetScore:      sc.GrossScore - HandicapStrokes(chandi, si, handicapHoleCount),
 			EnteredBy:     callerID,
 		})
+		holes = append(holes, sc.HoleNumber)
+	}
+
+	var prior []models.Score
+	if err := tx.Where("round_player_id = ? AND hole_number IN ?", rp.ID, holes).Find(&prior).Error; err != nil {
+		return fmt.Errorf("load prior scores: %w", err)
+	}
+	priorByHole := make(map[int]string, len(prior))
+	for _, p := range prior {
+		priorByHole[p.HoleNumber] = scoreValue(p.GrossScore, p.NetScore)
 	}
 
-	result := s.DB.WithContext(ctx).Clauses(clause.OnConflict{
+	// generated is reset too: a score typed over a generated one is entered.
+	if err := tx.Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "round_player_id"}, {Name: "hole_number"}},
-		DoUpdates: clause.AssignmentColumns([]string{"gross_score", "net_score", "entered_by"}),
-	}).Create(&records)
-	if result.Error != nil {
-		return 0, fmt.Errorf("upsert scores: %w", result.Error)
+		DoUpdates: clause.AssignmentColumns([]string{"gross_score", "net_score", "entered_by", "generated"}),
+	}).Create(&records).Error; err != nil {
+		return fmt.Errorf("upsert scores: %w", err)
 	}
-	return len(records), nil
+
+	changes := make([]models.ScoreChange, 0, len(records))
+	for _, r := range records {
+		newValue := scoreValue(r.GrossScore, r.NetScore)
+		old, existed := priorByHole[r.HoleNumber]
+		if existed && old == newValue {
+			continue
+		}
+		change := models.ScoreChange{
+			RoundPlayerID: rp.ID, Kind: string(models.ScoreChangeKindScore),
+			HoleNumber: intPtr(r.HoleNumber), NewValue: &newValue,
+			ChangedBy: callerID, RevertOf: revertOf,
+		}
+		if existed {
+			change.OldValue = &old
+		}
+		changes = append(changes, change)
+	}
+	return recordScoreChanges(tx, changes)
+}
+
+// afterScoreWrite runs once a change to rp's card has committed: it clears the
+// card's attestation and, on a completed round, updates the round's finish and
+// what the feed shows for it.
+func (s *ScoreService) afterScoreWrite(ctx context.Context, round *models.Round, roundPlayerID uuid.UUID) error {
+	if err := s.clearAttestation(ctx, roundPlayerID); err != nil {
+		return err
+	}
+	return s.afterResultsChange(ctx, round)
+}
+
+// afterResultsChange updates a completed round's finish and what the feed
+// shows for it once anything that ranks the round has changed. Rounds still
+// in play are left alone.
+func (s *ScoreService) afterResultsChange(ctx context.Context, round *models.Round) error {
+	if round.Status != models.RoundStatusCompleted {
+		return nil
+	}
+	if err := saveRoundFinishPositions(ctx, s.DB, round.ID); err != nil {
+		return err
+	}
+	recordRoundActivity(ctx, s.DB, round.ID)
+	return nil
 }
 
 // ─── UpsertHoleStats ──────────────────────────────────────────────────────────
@@ -706,19 +982,209 @@ func (s *ScoreService) UpsertHoleStats(ctx context.Context, roundID, roundPlayer
 		})
 	}
 
-	result := s.DB.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns: []clause.Column{{Name: "round_player_id"}, {Name: "hole_number"}},
-		DoUpdates: clause.AssignmentColumns([]string{
-			"gir", "gir_miss_direction",
-			"fir", "fir_miss_direction",
-			"fir_ob", "gir_ob",
-			"putts", "first_putt_distance", "putt_distance_made", "approach_yds",
-			"tee_shot_club", "tee_shot_distance",
-			"updated_at",
-		}),
-	}).Create(&records)
-	if result.Error != nil {
-		return 0, fmt.Errorf("upsert hole stats: %w", result.Error)
+	holes := make([]int, 0, len(records))
+	for _, r := range records {
+		holes = append(holes, r.HoleNumber)
+	}
+
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var prior []models.HoleStat
+		if err := tx.Where("round_player_id = ? AND hole_number IN ?", roundPlayerID, holes).Find(&prior).Error; err != nil {
+			return fmt.Errorf("load prior hole stats: %w", err)
+		}
+		priorByHole := make(map[int]string, len(prior))
+		for _, p := range prior {
+			priorByHole[p.HoleNumber] = holeStatValue(p)
+		}
+
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "round_player_id"}, {Name: "hole_number"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"gir", "gir_miss_direction",
+				"fir", "fir_miss_direction",
+				"fir_ob", "gir_ob",
+				"putts", "first_putt_distance", "putt_distance_made", "approach_yds",
+				"tee_shot_club", "tee_shot_distance",
+				"updated_at",
+			}),
+		}).Create(&records).Error; err != nil {
+			return fmt.Errorf("upsert hole stats: %w", err)
+		}
+
+		changes := make([]models.ScoreChange, 0, len(records))
+		for _, r := range records {
+			newValue := holeStatValue(r)
+			old, existed := priorByHole[r.HoleNumber]
+			if existed && old == newValue {
+				continue
+			}
+			change := models.ScoreChange{
+				RoundPlayerID: roundPlayerID, Kind: string(models.ScoreChangeKindHoleStat),
+				HoleNumber: intPtr(r.HoleNumber), NewValue: &newValue, ChangedBy: callerID,
+			}
+			if existed {
+				change.OldValue = &old
+			}
+			changes = append(changes, change)
+		}
+		return recordScoreChanges(tx, changes)
+	})
+	if err != nil {
+		return 0, err
 	}
 	return len(records), nil
 }
+
+// ─── Attestation ──────────────────────────────────────────────────────────────
+
+// AttestScorecard records the caller's signature on a complete card. The player
+// signs for themselves; any other player in the same tee-time group signs as
+// marker. Once both have signed, the round_player moves to "completed" and the
+// card is closed to group-member edits (see canModifyScores).
+//
+// There is no admin/organizer bypass — attestation is a personal sign-off.
+// Signing twice is harmless: the existing signature is refreshed.
+func (s *ScoreService) AttestScorecard(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID) (*AttestationData, error) {
+	var round models.Round
+	if err := s.DB.WithContext(ctx).Preload("Course").Preload("DefaultTee.Holes").First(&round, "id = ?", roundID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoundNotFound
+		}
+		return nil, fmt.Errorf("load round: %w", err)
+	}
+	if round.Status != models.RoundStatusActive && round.Status != models.RoundStatusCompleted {
+		return nil, ErrRoundNotActive
+	}
+	if err := requireEventNotFinalized(ctx, s.DB, round.EventID); err != nil {
+		return nil, err
+	}
+
+	var result *AttestationData
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rp models.RoundPlayer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&rp, "id = ? AND round_id = ?", roundPlayerID, roundID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRoundPlayerNotFound
+			}
+			return fmt.Errorf("load round player: %w", err)
+		}
+
+		asPlayer := rp.UserID == callerID
+		if !asPlayer {
+			sameGroup, err := sharesGroup(tx, roundID, rp.ID, callerID)
+			if err != nil {
+				return err
+			}
+			if !sameGroup {
+				return ErrAttestForbidden
+			}
+		}
+
+		// Only the holes being played count, so a nine-hole round is
+		// complete after nine.
+		played := filterPlayedHoles(round.DefaultTee.Holes, round.NineHoleSelection)
+		holeNumbers := make([]int, len(played))
+		for i, h := range played {
+			holeNumbers[i] = h.HoleNumber
+		}
+		scoredQ := tx.Model(&models.Score{}).Where("round_player_id = ?", rp.ID)
+		holeCount := len(holeNumbers)
+		if holeCount > 0 {
+			scoredQ = scoredQ.Where("hole_number IN ?", holeNumbers)
+		} else {
+			// A tee without hole rows falls back to the course's hole count.
+			holeCount = round.Course.HoleCount
+			if holeCount == 0 {
+				holeCount = 18
+			}
+			if round.NineHoleSelection != nil {
+				holeCount = 9
+			}
+		}
+		var scored int64
+		if err := scoredQ.Count(&scored).Error; err != nil {
+			return fmt.Errorf("count scores: %w", err)
+		}
+		if int(scored) < holeCount {
+			return ErrScorecardIncomplete
+		}
+
+		now := time.Now().UTC()
+		if asPlayer {
+			rp.PlayerAttestedAt = &now
+		} else {
+			rp.MarkerAttestedAt = &now
+			rp.MarkerID = &callerID
+		}
+		// A player out of the results keeps that status through sign-off.
+		if rp.PlayerAttestedAt != nil && rp.MarkerAttestedAt != nil && !slices.Contains(models.RoundPlayerOutStatuses, rp.Status) {
+			rp.Status = models.RoundPlayerStatusCompleted
+			rp.NeedsReattestation = false
+		}
+		if err := tx.Model(&models.RoundPlayer{}).Where("id = ?", rp.ID).Updates(map[string]any{
+			"player_attested_at":  rp.PlayerAttestedAt,
+			"marker_attested_at":  rp.MarkerAttestedAt,
+			"marker_id":           rp.MarkerID,
+			"status":              rp.Status,
+			"needs_reattestation": rp.NeedsReattestation,
+		}).Error; err != nil {
+			return fmt.Errorf("save attestation: %w", err)
+		}
+
+		result = &AttestationData{
+			RoundPlayerID:      rp.ID.String(),
+			Status:             string(rp.Status),
+			PlayerAttestedAt:   FormatTimestamp(rp.PlayerAttestedAt),
+			MarkerAttestedAt:   FormatTimestamp(rp.MarkerAttestedAt),
+			NeedsReattestation: rp.NeedsReattestation,
+		}
+		if rp.MarkerID != nil {
+			markerID := rp.MarkerID.String()
+			result.MarkerID = &markerID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sharesGroup reports whether callerID has a round_player in the same tee-time
+// group as targetRoundPlayerID. round_players.user_id is always set, so this
+// works for event-linked and eventless rounds alike.
+func sharesGroup(db *gorm.DB, roundID, targetRoundPlayerID, callerID uuid.UUID) (bool, error) {
+	var n int64
+	if err := db.Table("group_players target").
+		Joins("JOIN group_players caller ON caller.group_id = target.group_id").
+		Joins("JOIN round_players crp ON crp.id = caller.round_player_id").
+		Where("target.round_player_id = ? AND crp.round_id = ? AND crp.user_id = ? AND crp.id <> target.round_player_id",
+			targetRoundPlayerID, roundID, callerID).
+		Count(&n).Error; err != nil {
+		return false, fmt.Errorf("check marker group: %w", err)
+	}
+	return n > 0, nil
+}
+
+// clearAttestation drops any signatures on a card after its scores or handicap
+// change, so a signature never covers numbers the signer did not see. When the
+// card was already fully attested (only an organizer or admin can reach this —
+// canModifyScores refuses group members), it stays "completed" so the card
+// remains closed to group edits, and is flagged for re-attestation.
+//
+// Hole stats are not part of the attested card, so UpsertHoleStats does not call this.
+func (s *ScoreService) clearAttestation(ctx context.Context, roundPlayerID uuid.UUID) error {
+	if err := s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).
+		Where("id = ? AND (player_attested_at IS NOT NULL OR marker_attested_at IS NOT NULL OR status = ?)",
+			roundPlayerID, models.RoundPlayerStatusCompleted).
+		Updates(map[string]any{
+			"player_attested_at":  nil,
+			"marker_attested_at":  nil,
+			"marker_id":           nil,
+			"needs_reattestation": gorm.Expr("status = ?", models.RoundPlayerStatusCompleted),
+		}).Error; err != nil {
+		return fmt.Errorf("clear attestation: %w", err)
+	}
+	return nil
+}
@@ -920,3 +920,131 @@ func TestScoreService_GetScorecard_RoundNotFound(t *testing.T) {
 	_, err := svc.GetScorecard(context.Background(), uuid.New(), uuid.New(), "user")
 	assert.True(t, errors.Is(err, services.ErrRoundNotFound))
 }
+
+// ─── AttestScorecard ──────────────────────────────────────────────────────────
+
+// attestFixture builds an active eventless round created by an organizer, with
+// alice and bob sharing group 1. Alice's card is complete; bob's is empty.
+func attestFixture(t *testing.T, db *gorm.DB) (round models.Round, organizer, alice, bob models.User, aliceRP, bobRP models.RoundPlayer) {
+	t.Helper()
+	organizer = seedUser(t, db, "attestOrganizer")
+	alice = seedUser(t, db, "attestAlice")
+	bob = seedUser(t, db, "attestBob")
+	course, tee := seedCourseWithTee(t, db, "Attest Course")
+	seedHoles(t, db, tee.ID)
+	round = seedEventlessRound(t, db, organizer.ID, course.ID, tee.ID)
+	aliceRP = addEventlessRoundPlayer(t, db, round.ID, alice.ID)
+	bobRP = addEventlessRoundPlayer(t, db, round.ID, bob.ID)
+	addGroupWithPlayer(t, db, round.ID, 1, aliceRP.ID)
+	addGroupWithPlayer(t, db, round.ID, 1, bobRP.ID)
+	enterCard(t, db, aliceRP.ID, alice.ID, nil)
+	return
+}
+
+func TestScoreService_AttestScorecard_PlayerAndMarkerCompleteCard(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	round, _, alice, bob, aliceRP, _ := attestFixture(t, db)
+
+	data, err := svc.AttestScorecard(context.Background(), round.ID, aliceRP.ID, alice.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, data.PlayerAttestedAt)
+	assert.Nil(t, data.MarkerAttestedAt)
+	assert.Equal(t, string(models.RoundPlayerStatusRegistered), data.Status)
+
+	data, err = svc.AttestScorecard(context.Background(), round.ID, aliceRP.ID, bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.RoundPlayerStatusCompleted), data.Status)
+	require.NotNil(t, data.MarkerID)
+	assert.Equal(t, bob.ID.String(), *data.MarkerID)
+}
+
+func TestScoreService_AttestScorecard_IncompleteCard(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	round, _, _, bob, _, bobRP := attestFixture(t, db)
+
+	_, err := svc.AttestScorecard(context.Background(), round.ID, bobRP.ID, bob.ID)
+	assert.ErrorIs(t, err, services.ErrScorecardIncomplete)
+}
+
+func TestScoreService_AttestScorecard_CountsOnlyPlayedHoles(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	round, _, _, bob, _, bobRP := attestFixture(t, db)
+	require.NoError(t, db.Model(&models.Round{}).Where("id = ?", round.ID).
+		Update("nine_hole_selection", "back").Error)
+	scoreHoles := func(from, to int) {
+		for h := from; h <= to; h++ {
+			sc := models.Score{RoundPlayerID: bobRP.ID, HoleNumber: h, GrossScore: 4, NetScore: 4, EnteredBy: bob.ID}
+			require.NoError(t, db.Omit(clause.Associations).Create(&sc).Error)
+		}
+	}
+
+	scoreHoles(1, 9)
+	_, err := svc.AttestScorecard(ctx, round.ID, bobRP.ID, bob.ID)
+	assert.ErrorIs(t, err, services.ErrScorecardIncomplete, "the front nine isn't being played")
+
+	scoreHoles(10, 18)
+	_, err = svc.AttestScorecard(ctx, round.ID, bobRP.ID, bob.ID)
+	require.NoError(t, err)
+}
+
+func TestScoreService_UpsertScores_PlayerFromAnotherRound(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	round, organizer, alice, _, _, _ := attestFixture(t, db)
+	other := seedEventlessRound(t, db, organizer.ID, round.CourseID, round.DefaultTeeID)
+	otherRP := addEventlessRoundPlayer(t, db, other.ID, seedUser(t, db, "attestOther").ID)
+
+	_, err := svc.UpsertScores(context.Background(), round.ID, otherRP.ID, alice.ID, "user",
+		[]services.ScoreInput{{HoleNumber: 1, GrossScore: 4}})
+	assert.ErrorIs(t, err, services.ErrRoundPlayerNotFound)
+}
+
+func TestScoreService_AttestScorecard_MarkerMustShareGroup(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	round, _, _, _, aliceRP, _ := attestFixture(t, db)
+	carol := seedUser(t, db, "attestCarol")
+	carolRP := addEventlessRoundPlayer(t, db, round.ID, carol.ID)
+	addGroupWithPlayer(t, db, round.ID, 2, carolRP.ID)
+
+	_, err := svc.AttestScorecard(context.Background(), round.ID, aliceRP.ID, carol.ID)
+	assert.ErrorIs(t, err, services.ErrAttestForbidden)
+}
+
+// TestScoreService_AttestedCard_OrganizerCorrectionFlagsReattestation verifies
+// that an attested card refuses group-member edits, while an organizer
+// correction clears both signatures and flags the card.
+func TestScoreService_AttestedCard_OrganizerCorrectionFlagsReattestation(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	round, organizer, alice, bob, aliceRP, _ := attestFixture(t, db)
+	_, err := svc.AttestScorecard(ctx, round.ID, aliceRP.ID, alice.ID)
+	require.NoError(t, err)
+	_, err = svc.AttestScorecard(ctx, round.ID, aliceRP.ID, bob.ID)
+	require.NoError(t, err)
+
+	edit := []services.ScoreInput{{HoleNumber: 1, GrossScore: 5}}
+	_, err = svc.UpsertScores(ctx, round.ID, aliceRP.ID, bob.ID, "user", edit)
+	assert.ErrorIs(t, err, services.ErrScorecardAttested)
+
+	_, err = svc.UpsertScores(ctx, round.ID, aliceRP.ID, organizer.ID, "user", edit)
+	require.NoError(t, err)
+
+	var stored models.RoundPlayer
+	require.NoError(t, db.First(&stored, "id = ?", aliceRP.ID).Error)
+	assert.Equal(t, models.RoundPlayerStatusCompleted, stored.Status, "card stays closed to group edits")
+	assert.True(t, stored.NeedsReattestation)
+	assert.Nil(t, stored.PlayerAttestedAt)
+	assert.Nil(t, stored.MarkerAttestedAt)
+
+	_, err = svc.AttestScorecard(ctx, round.ID, aliceRP.ID, alice.ID)
+	require.NoError(t, err)
+	data, err := svc.AttestScorecard(ctx, round.ID, aliceRP.ID, bob.ID)
+	require.NoError(t, err)
+	assert.False(t, data.NeedsReattestation)
+}
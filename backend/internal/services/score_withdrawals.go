@@ -0,0 +1,85 @@
+// services/score_withdrawals.go
+// Withdrawals, disqualifications and no-cards. An organizer takes a player out
+// of a round's results with a reason:
+//
+//   - withdrawn (WD):    stopped playing partway through.
+//   - disqualified (DQ): broke a rule, e.g. signed for a wrong score.
+//   - no_card (NC):      finished but didn't return a card.
+//
+// The player keeps their scores, but their card is never ranked (so it earns no
+// points), they list below the field on the round leaderboard, and the round is
+// left out of their handicap (see handicapDifferentials). Reinstating puts them
+// back in the results. Either way a completed round is re-ranked.
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+// PlayerStatusData is the response for taking a player out of, or back into,
+// a round's results.
+type PlayerStatusData struct {
+	RoundPlayerID string  `json:"round_player_id"`
+	Status        string  `json:"status"`
+	StatusReason  *string `json:"status_reason"` // nil once reinstated
+}
+
+// WithdrawPlayer sets a round player's status to withdrawn, disqualified or
+// no_card with the organizer's reason. Setting it again replaces the status and
+// reason. Organizer-only, and refused once the event is finalized.
+func (s *ScoreService) WithdrawPlayer(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole, status, reason string) (*PlayerStatusData, error) {
+	if !slices.Contains(models.RoundPlayerOutStatuses, models.RoundPlayerStatus(status)) {
+		return nil, &ValidationError{Field: "status", Message: "status must be one of: withdrawn, disqualified, no_card"}
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, &ValidationError{Field: "reason", Message: "reason is required"}
+	}
+	round, rp, err := s.loadCardTarget(ctx, roundID, roundPlayerID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).Where("id = ?", rp.ID).Updates(map[string]any{
+		"status":        status,
+		"status_reason": reason,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("withdraw player: %w", err)
+	}
+	if err := s.afterResultsChange(ctx, round); err != nil {
+		return nil, err
+	}
+	return &PlayerStatusData{RoundPlayerID: rp.ID.String(), Status: status, StatusReason: &reason}, nil
+}
+
+// ReinstatePlayer puts a withdrawn, disqualified or no_card player back in the
+// round's results: "completed" when both signatures are on their card, else
+// "registered". A no-op for a player who isn't out. Organizer-only.
+func (s *ScoreService) ReinstatePlayer(ctx context.Context, roundID, roundPlayerID, callerID uuid.UUID, callerRole string) (*PlayerStatusData, error) {
+	round, rp, err := s.loadCardTarget(ctx, roundID, roundPlayerID, callerID, callerRole)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(models.RoundPlayerOutStatuses, rp.Status) {
+		return &PlayerStatusData{RoundPlayerID: rp.ID.String(), Status: string(rp.Status)}, nil
+	}
+	status := models.RoundPlayerStatusRegistered
+	if rp.PlayerAttestedAt != nil && rp.MarkerAttestedAt != nil {
+		status = models.RoundPlayerStatusCompleted
+	}
+	if err := s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).Where("id = ?", rp.ID).Updates(map[string]any{
+		"status":        status,
+		"status_reason": nil,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("reinstate player: %w", err)
+	}
+	if err := s.afterResultsChange(ctx, round); err != nil {
+		return nil, err
+	}
+	return &PlayerStatusData{RoundPlayerID: rp.ID.String(), Status: string(status)}, nil
+}
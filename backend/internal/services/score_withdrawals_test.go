@@ -0,0 +1,129 @@
+// services/score_withdrawals_test.go
+// Integration tests for WD/DQ/NC (score_withdrawals.go): a player out of the
+// results loses their position and points and lists below the field until
+// reinstated. Uses testutil.NewTestDB — Docker must be running.
+//
+// Input validation paths are covered without a DB by handlers/withdrawals_test.go.
+//
+// Do NOT call t.Parallel() — TRUNCATE is global across the shared container.
+//
+// Run:
+//
+//	go test ./internal/services/ -run 'WithdrawPlayer|ReinstatePlayer' -v
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/clause"
+
+	"github.com/trentd187/golf-league/internal/models"
+	"github.com/trentd187/golf-league/internal/services"
+	"github.com/trentd187/golf-league/internal/testutil"
+)
+
+func TestScoreService_WithdrawPlayer_BelowTheFieldWithoutPoints(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	lb := newLeaderboardSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+	rule := models.EventPointsRule{EventID: event.ID, FinishPosition: 1, Points: 10}
+	require.NoError(t, db.Omit(clause.Associations).Create(&rule).Error)
+
+	// bob wins the card-off; disqualify him.
+	data, err := svc.WithdrawPlayer(ctx, roundID, bobRP.ID, orgID, "user", string(models.RoundPlayerStatusDisqualified), " wrong scorecard ")
+	require.NoError(t, err)
+	assert.Equal(t, string(models.RoundPlayerStatusDisqualified), data.Status)
+	require.NotNil(t, data.StatusReason)
+	assert.Equal(t, "wrong scorecard", *data.StatusReason)
+	completeRound(t, db, roundID)
+
+	board, err := lb.RoundLeaderboard(ctx, roundID)
+	require.NoError(t, err)
+	require.Len(t, board.Entries, 2)
+	assert.Equal(t, aliceRP.ID.String(), board.Entries[0].RoundPlayerID)
+	require.NotNil(t, board.Entries[0].Position)
+	assert.Equal(t, 1, *board.Entries[0].Position)
+	last := board.Entries[1]
+	assert.Equal(t, bobRP.ID.String(), last.RoundPlayerID)
+	assert.Nil(t, last.Position)
+	require.NotNil(t, last.StatusReason)
+	assert.Equal(t, "wrong scorecard", *last.StatusReason)
+
+	standings, err := lb.EventStandings(ctx, event.ID, orgID, "user")
+	require.NoError(t, err)
+	for _, e := range standings.Entries {
+		if e.EventPlayerID == bobRP.EventPlayerID.String() {
+			assert.Nil(t, e.Position)
+			assert.Equal(t, 0, e.RoundsPlayed)
+		}
+	}
+}
+
+func TestScoreService_ReinstatePlayer_BackInTheResults(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, event, _, bobRP := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+
+	_, err := svc.WithdrawPlayer(ctx, roundID, bobRP.ID, orgID, "user", string(models.RoundPlayerStatusWithdrawn), "injury")
+	require.NoError(t, err)
+	data, err := svc.ReinstatePlayer(ctx, roundID, bobRP.ID, orgID, "user")
+	require.NoError(t, err)
+	assert.Equal(t, string(models.RoundPlayerStatusRegistered), data.Status)
+	assert.Nil(t, data.StatusReason)
+
+	var stored models.RoundPlayer
+	require.NoError(t, db.First(&stored, "id = ?", bobRP.ID).Error)
+	assert.Nil(t, stored.StatusReason)
+
+	board, err := newLeaderboardSvc(db).RoundLeaderboard(ctx, roundID)
+	require.NoError(t, err)
+	assert.Equal(t, bobRP.ID.String(), board.Entries[0].RoundPlayerID)
+	require.NotNil(t, board.Entries[0].Position)
+	assert.Equal(t, 1, *board.Entries[0].Position)
+}
+
+func TestScoreService_WithdrawPlayer_CompletedRoundReranked(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+	completeViaService(t, db, roundID, orgID)
+
+	_, err := svc.WithdrawPlayer(ctx, roundID, bobRP.ID, orgID, "user", string(models.RoundPlayerStatusNoCard), "left early")
+	require.NoError(t, err)
+	var alice, bob models.RoundPlayer
+	require.NoError(t, db.First(&alice, "id = ?", aliceRP.ID).Error)
+	require.NoError(t, db.First(&bob, "id = ?", bobRP.ID).Error)
+	require.NotNil(t, alice.FinishPosition)
+	assert.Equal(t, 1, *alice.FinishPosition)
+	assert.Nil(t, bob.FinishPosition)
+
+	_, err = svc.ReinstatePlayer(ctx, roundID, bobRP.ID, orgID, "user")
+	require.NoError(t, err)
+	require.NoError(t, db.First(&bob, "id = ?", bobRP.ID).Error)
+	require.NotNil(t, bob.FinishPosition)
+	assert.Equal(t, 1, *bob.FinishPosition, "back to winning the card-off")
+}
+
+func TestScoreService_WithdrawPlayer_Rejections(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	svc := newScoreSvc(db)
+	ctx := context.Background()
+	roundID, event, aliceRP, bobRP := tiedRound(t, db)
+	orgID := organizerOf(t, db, event.ID)
+
+	_, err := svc.WithdrawPlayer(ctx, roundID, bobRP.ID, orgID, "user", string(models.RoundPlayerStatusCompleted), "done")
+	var ve *services.ValidationError
+	assert.ErrorAs(t, err, &ve)
+	_, err = svc.WithdrawPlayer(ctx, roundID, bobRP.ID, aliceRP.UserID, "user", string(models.RoundPlayerStatusNoCard), "lost it")
+	assert.ErrorIs(t, err, services.ErrScoreForbidden)
+}
@@ -0,0 +1,208 @@
+// services/tiebreak.go
+// Pure ranking + card-off tiebreak math shared by the round leaderboard and the
+// event standings (leaderboard_service.go).
+//
+// Kept free of DB access so the ordering rules can be unit-tested without a
+// database, mirroring handicap.go.
+package services
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+// Tiebreak criteria reported on a ranked entry. The card-off names describe the
+// segment of the card that first separated the player from their neighbour.
+const (
+	TiebreakBack9    = "back_9"
+	TiebreakBack6    = "back_6"
+	TiebreakBack3    = "back_3"
+	TiebreakLastHole = "last_hole" // the 18th on a full round, the 9th/18th on a nine
+	TiebreakShared   = "shared"    // still level (or policy is shared) — position is shared
+)
+
+// cardOffSegment is one step of the card-off: compare the net total over the
+// last Holes of the holes every tied card played.
+type cardOffSegment struct {
+	Name  string
+	Holes int
+}
+
+// cardOffSegments is the standard card-off order: back 9, back 6, back 3, last hole.
+var cardOffSegments = []cardOffSegment{
+	{TiebreakBack9, 9},
+	{TiebreakBack6, 6},
+	{TiebreakBack3, 3},
+	{TiebreakLastHole, 1},
+}
+
+// tiebreakCard is the scorecard a card-off is decided on.
+//
+// Holes lists the hole numbers played in ascending order; Gross maps each of
+// them to the gross score. Handicap is the effective course handicap for the
+// round, prorated per segment (e.g. back 6 of 18 uses 6/18 of it).
+type tiebreakCard struct {
+	Holes    []int
+	Gross    map[int]int
+	Handicap int
+}
+
+// segmentNet returns the prorated net total over the given holes as a fraction
+// num/den: gross(holes) − handicap·len(holes)/len(Holes). Kept as a fraction
+// so 1/3 and 1/6 handicap shares compare exactly.
+func (c tiebreakCard) segmentNet(holes []int) (num, den int) {
+	played := len(c.Holes)
+	sum := 0
+	for _, h := range holes {
+		sum += c.Gross[h]
+	}
+	return played*sum - c.Handicap*len(holes), played
+}
+
+// compareSegment returns -1, 0, or 1 as a's prorated net over holes is lower
+// than, equal to, or higher than b's.
+func compareSegment(a, b tiebreakCard, holes []int) int {
+	an, ad := a.segmentNet(holes)
+	bn, bd := b.segmentNet(holes)
+	// Denominators are positive hole counts, so cross-multiplying keeps the order.
+	lhs, rhs := an*bd, bn*ad
+	switch {
+	case lhs < rhs:
+		return -1
+	case lhs > rhs:
+		return 1
+	}
+	return 0
+}
+
+// rankInput is one player to rank: a primary total (lower is better) plus the
+// card used to break a tie on that total.
+type rankInput struct {
+	Total int
+	Card  tiebreakCard
+}
+
+// rankResult places one rankInput. Index points back into the input slice.
+// Tiebreak is nil when the player was not level with anyone on Total.
+type rankResult struct {
+	Index    int
+	Position int
+	Tiebreak *string
+}
+
+// rankWithTiebreak orders inputs by Total (ascending) and assigns standard
+// competition positions (1, 2, 2, 4). Players level on Total are separated per
+// policy: card_off walks cardOffSegments until the cards differ; shared (or a
+// card-off that never separates them) leaves the position shared.
+//
+// Results are returned in finishing order.
+func rankWithTiebreak(inputs []rankInput, policy models.TiebreakPolicy) []rankResult {
+	order := make([]int, len(inputs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return inputs[order[i]].Total < inputs[order[j]].Total
+	})
+
+	results := make([]rankResult, 0, len(inputs))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && inputs[order[end]].Total == inputs[order[start]].Total {
+			end++
+		}
+		tied := order[start:end]
+		if len(tied) == 1 {
+			results = append(results, rankResult{Index: tied[0], Position: start + 1})
+		} else {
+			results = append(results, breakTie(inputs, tied, start+1, policy)...)
+		}
+		start = end
+	}
+	return results
+}
+
+// breakTie ranks a group of players level on Total, the first of whom holds
+// position `first`.
+func breakTie(inputs []rankInput, tied []int, first int, policy models.TiebreakPolicy) []rankResult {
+	out := make([]rankResult, len(tied))
+	if policy == models.TiebreakPolicyShared {
+		for i, idx := range tied {
+			out[i] = rankResult{Index: idx, Position: first, Tiebreak: strPtr(TiebreakShared)}
+		}
+		return out
+	}
+
+	// Segments are taken by hole number from the holes every card in the group
+	// played, so "back 3" is the same three holes for everyone even when a card
+	// misses a hole or covers a different nine. A segment only applies when it
+	// is shorter than that — back 9 of a nine-hole card is the whole card,
+	// already known to be level.
+	holes := sharedHoles(inputs, tied)
+	segments := make([][]int, 0, len(cardOffSegments))
+	names := make([]string, 0, len(cardOffSegments))
+	for _, seg := range cardOffSegments {
+		if seg.Holes < len(holes) {
+			segments = append(segments, holes[len(holes)-seg.Holes:])
+			names = append(names, seg.Name)
+		}
+	}
+
+	// decider returns the first segment separating a and b, or "" when level on all.
+	decider := func(a, b int) (string, int) {
+		for i, seg := range segments {
+			if c := compareSegment(inputs[a].Card, inputs[b].Card, seg); c != 0 {
+				return names[i], c
+			}
+		}
+		return "", 0
+	}
+
+	sorted := append([]int(nil), tied...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		_, c := decider(sorted[i], sorted[j])
+		return c < 0
+	})
+
+	for i, idx := range sorted {
+		pos := first + i
+		var name string
+		if i > 0 {
+			name, _ = decider(sorted[i-1], idx)
+			if name == "" {
+				pos = out[i-1].Position // still level with the player above
+			}
+		} else if len(sorted) > 1 {
+			name, _ = decider(idx, sorted[1])
+		}
+		if name == "" {
+			name = TiebreakShared
+		}
+		out[i] = rankResult{Index: idx, Position: pos, Tiebreak: strPtr(name)}
+	}
+	return out
+}
+
+// sharedHoles returns, in ascending order, the hole numbers played on every
+// tied card.
+func sharedHoles(inputs []rankInput, tied []int) []int {
+	var out []int
+	for _, h := range inputs[tied[0]].Card.Holes {
+		shared := true
+		for _, idx := range tied[1:] {
+			if !slices.Contains(inputs[idx].Card.Holes, h) {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// strPtr returns a pointer to a copy of s.
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,231 @@
+// services/tiebreak_internal_test.go
+// White-box tests for the ranking + card-off helpers in tiebreak.go, plus the
+// per-flight ranking (leaderboard_service.go) and band lookup (event_flights.go)
+// built on them.
+// Uses package services (not services_test) so unexported functions are accessible.
+//
+// Run:
+//
+//	go test ./internal/services/ -run TestRankWithTiebreak -v
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trentd187/golf-league/internal/models"
+)
+
+// card builds a tiebreakCard over holes first..last with a gross of 4 on every
+// hole, then applies the per-hole overrides.
+func card(first, last, handicap int, overrides map[int]int) tiebreakCard {
+	c := tiebreakCard{Gross: map[int]int{}, Handicap: handicap}
+	for h := first; h <= last; h++ {
+		c.Holes = append(c.Holes, h)
+		c.Gross[h] = 4
+	}
+	for h, g := range overrides {
+		c.Gross[h] = g
+	}
+	return c
+}
+
+// positionsByIndex maps input index → (position, tiebreak) for easy assertions.
+func positionsByIndex(results []rankResult) map[int]rankResult {
+	m := make(map[int]rankResult, len(results))
+	for _, r := range results {
+		m[r.Index] = r
+	}
+	return m
+}
+
+// TestRankWithTiebreak_NoTies verifies plain ordering by total with no tiebreak reported.
+func TestRankWithTiebreak_NoTies(t *testing.T) {
+	results := rankWithTiebreak([]rankInput{
+		{Total: 74, Card: card(1, 18, 0, nil)},
+		{Total: 70, Card: card(1, 18, 0, nil)},
+		{Total: 72, Card: card(1, 18, 0, nil)},
+	}, models.TiebreakPolicyCardOff)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, []int{1, 2, 0}, []int{results[0].Index, results[1].Index, results[2].Index})
+	for i, r := range results {
+		assert.Equal(t, i+1, r.Position)
+		assert.Nil(t, r.Tiebreak)
+	}
+}
+
+// TestRankWithTiebreak_Back9 verifies a tie decided on the back nine.
+func TestRankWithTiebreak_Back9(t *testing.T) {
+	results := positionsByIndex(rankWithTiebreak([]rankInput{
+		{Total: 72, Card: card(1, 18, 0, nil)},
+		{Total: 72, Card: card(1, 18, 0, map[int]int{1: 5, 10: 3})}, // back 9 of 35
+	}, models.TiebreakPolicyCardOff))
+
+	assert.Equal(t, 1, results[1].Position)
+	assert.Equal(t, 2, results[0].Position)
+	assert.Equal(t, TiebreakBack9, *results[0].Tiebreak)
+	assert.Equal(t, TiebreakBack9, *results[1].Tiebreak)
+}
+
+// TestRankWithTiebreak_ProratedHandicap verifies that each segment subtracts the
+// prorated handicap: an 18-handicap's back 9 of 45 nets 36 (level with a scratch
+// 36), but their back 6 of 29 nets 23 and beats the scratch player's 24.
+func TestRankWithTiebreak_ProratedHandicap(t *testing.T) {
+	scratch := card(1, 18, 0, nil)
+	eighteen := card(1, 18, 18, map[int]int{
+		10: 5, 11: 5, 12: 6, // holes 10–12: 16
+		13: 5, 14: 5, 15: 5, 16: 5, 17: 5, 18: 4, // holes 13–18: 29
+	})
+	results := positionsByIndex(rankWithTiebreak([]rankInput{
+		{Total: 72, Card: scratch},
+		{Total: 72, Card: eighteen},
+	}, models.TiebreakPolicyCardOff))
+
+	assert.Equal(t, 1, results[1].Position)
+	assert.Equal(t, 2, results[0].Position)
+	assert.Equal(t, TiebreakBack6, *results[0].Tiebreak)
+}
+
+// TestRankWithTiebreak_LastHole verifies the final fallback to the last hole.
+func TestRankWithTiebreak_LastHole(t *testing.T) {
+	results := positionsByIndex(rankWithTiebreak([]rankInput{
+		{Total: 72, Card: card(1, 18, 0, nil)},
+		{Total: 72, Card: card(1, 18, 0, map[int]int{17: 5, 18: 3})},
+	}, models.TiebreakPolicyCardOff))
+
+	assert.Equal(t, 1, results[1].Position)
+	assert.Equal(t, TiebreakLastHole, *results[0].Tiebreak)
+}
+
+// TestRankWithTiebreak_IdenticalCardsShare verifies that cards level on every
+// segment share the position, and the next player skips accordingly.
+func TestRankWithTiebreak_IdenticalCardsShare(t *testing.T) {
+	results := positionsByIndex(rankWithTiebreak([]rankInput{
+		{Total: 72, Card: card(1, 18, 0, nil)},
+		{Total: 72, Card: card(1, 18, 0, nil)},
+		{Total: 72, Card: card(1, 18, 0, map[int]int{13: 3, 16: 5})}, // loses on back 3
+		{Total: 75, Card: card(1, 18, 0, nil)},
+	}, models.TiebreakPolicyCardOff))
+
+	assert.Equal(t, 1, results[0].Position)
+	assert.Equal(t, 1, results[1].Position)
+	assert.Equal(t, TiebreakShared, *results[0].Tiebreak)
+	assert.Equal(t, TiebreakShared, *results[1].Tiebreak)
+	assert.Equal(t, 3, results[2].Position)
+	assert.Equal(t, TiebreakBack3, *results[2].Tiebreak)
+	assert.Equal(t, 4, results[3].Position)
+	assert.Nil(t, results[3].Tiebreak)
+}
+
+// TestRankWithTiebreak_SharedPolicy verifies that the shared policy never runs a
+// card-off: tied players share the position (T1, T1, 3).
+func TestRankWithTiebreak_SharedPolicy(t *testing.T) {
+	results := positionsByIndex(rankWithTiebreak([]rankInput{
+		{Total: 72, Card: card(1, 18, 0, nil)},
+		{Total: 72, Card: card(1, 18, 0, map[int]int{1: 5, 10: 3})},
+		{Total: 73, Card: card(1, 18, 0, nil)},
+	}, models.TiebreakPolicyShared))
+
+	assert.Equal(t, 1, results[0].Position)
+	assert.Equal(t, 1, results[1].Position)
+	assert.Equal(t, TiebreakShared, *results[0].Tiebreak)
+	assert.Equal(t, 3, results[2].Position)
+}
+
+// TestRankWithTiebreak_NineHoleSkipsBack9 verifies that a nine-hole card starts
+// the card-off at back 6 (back 9 would be the whole, already-level card).
+func TestRankWithTiebreak_NineHoleSkipsBack9(t *testing.T) {
+	results := positionsByIndex(rankWithTiebreak([]rankInput{
+		{Total: 36, Card: card(10, 18, 0, map[int]int{10: 3, 18: 5})},
+		{Total: 36, Card: card(10, 18, 0, nil)},
+	}, models.TiebreakPolicyCardOff))
+
+	assert.Equal(t, 1, results[1].Position)
+	assert.Equal(t, TiebreakBack6, *results[0].Tiebreak)
+}
+
+// TestCompareSegment_Fractional verifies exact comparison of prorated shares
+// that are not whole strokes (13 × 3/18 = 2⅙).
+func TestCompareSegment_Fractional(t *testing.T) {
+	a := card(1, 18, 13, nil) // back 3: 12 − 2⅙ = 9⅚
+	b := card(1, 18, 12, nil) // back 3: 12 − 2   = 10
+	back3 := []int{16, 17, 18}
+	assert.Equal(t, -1, compareSegment(a, b, back3))
+	assert.Equal(t, 1, compareSegment(b, a, back3))
+	assert.Equal(t, 0, compareSegment(a, a, back3))
+}
+
+// TestRankWithTiebreak_UnequalHoleSets verifies the card-off compares the same
+// hole numbers on every card: with hole 14 missing from one card, the back 9
+// is holes 9–13 and 15–18 for both, not each card's last nine entries.
+func TestRankWithTiebreak_UnequalHoleSets(t *testing.T) {
+	full := card(1, 18, 0, map[int]int{14: 3}) // birdie on a hole the other card lacks
+	gap := card(1, 18, 0, map[int]int{12: 3})
+	gap.Holes = slices.DeleteFunc(gap.Holes, func(h int) bool { return h == 14 })
+	delete(gap.Gross, 14)
+
+	results := positionsByIndex(rankWithTiebreak([]rankInput{
+		{Total: 72, Card: full},
+		{Total: 72, Card: gap},
+	}, models.TiebreakPolicyCardOff))
+
+	assert.Equal(t, 1, results[1].Position, "back 9 of shared holes: 35 beats 36")
+	assert.Equal(t, 2, results[0].Position)
+	assert.Equal(t, TiebreakBack9, *results[0].Tiebreak)
+}
+
+// TestRankWithTiebreak_DifferentNinesShare verifies cards from different nines
+// have no holes in common, so the card-off can't separate them.
+func TestRankWithTiebreak_DifferentNinesShare(t *testing.T) {
+	results := positionsByIndex(rankWithTiebreak([]rankInput{
+		{Total: 36, Card: card(1, 9, 0, map[int]int{9: 3, 8: 5})},
+		{Total: 36, Card: card(10, 18, 0, nil)},
+	}, models.TiebreakPolicyCardOff))
+
+	assert.Equal(t, 1, results[0].Position)
+	assert.Equal(t, 1, results[1].Position)
+	assert.Equal(t, TiebreakShared, *results[0].Tiebreak)
+}
+
+// TestRankByFlight_PositionsRestartPerFlight verifies each flight (and the
+// unflighted group) is ranked on its own.
+func TestRankByFlight_PositionsRestartPerFlight(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	results := positionsByIndex(rankByFlight([]rankInput{
+		{Total: 70, Card: card(1, 18, 0, nil)},
+		{Total: 80, Card: card(1, 18, 0, nil)},
+		{Total: 75, Card: card(1, 18, 0, nil)},
+		{Total: 72, Card: card(1, 18, 0, nil)},
+		{Total: 90, Card: card(1, 18, 0, nil)},
+	}, []*uuid.UUID{&a, &b, &a, &b, nil}, models.TiebreakPolicyCardOff))
+
+	require.Len(t, results, 5)
+	assert.Equal(t, 1, results[0].Position) // A: 70
+	assert.Equal(t, 2, results[2].Position) // A: 75
+	assert.Equal(t, 1, results[3].Position) // B: 72
+	assert.Equal(t, 2, results[1].Position) // B: 80
+	assert.Equal(t, 1, results[4].Position) // unflighted
+}
+
+// TestFlightForIndex_FirstMatchingBand verifies inclusive bounds, open-ended
+// bands and the no-match case.
+func TestFlightForIndex_FirstMatchingBand(t *testing.T) {
+	lo, hi := 9.9, 10.0
+	flights := []models.EventFlight{
+		{ID: uuid.New(), MaxHandicapIndex: &lo},
+		{ID: uuid.New(), MinHandicapIndex: &hi, MaxHandicapIndex: ptrFloat(18)},
+	}
+	assert.Equal(t, flights[0].ID, *flightForIndex(flights, -2.1))
+	assert.Equal(t, flights[0].ID, *flightForIndex(flights, 9.9))
+	assert.Equal(t, flights[1].ID, *flightForIndex(flights, 10.0))
+	assert.Equal(t, flights[1].ID, *flightForIndex(flights, 18.0))
+	assert.Nil(t, flightForIndex(flights, 18.1))
+}
+
+// ptrFloat returns a pointer to a copy of f.
+func ptrFloat(f float64) *float64 { return &f }
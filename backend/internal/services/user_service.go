@@ -48,16 +48,20 @@ type MeData struct {
 	Role        string `json:"role"`
 }
 
-// UserSearchResult is one entry returned by SearchUsers.
+// UserSearchResult is one entry returned by SearchUsers. Email is nil when the
+// user hides it.
 type UserSearchResult struct {
 	ID          string  `json:"id"`
 	DisplayName string  `json:"display_name"`
-	Email       string  `json:"email"`
+	Email       *string `json:"email"`
 	AvatarURL   *string `json:"avatar_url,omitempty"`
 	IsFollowing bool    `json:"is_following"`
 }
 
-// UserProfileData is returned by GetUserProfile.
+// UserProfileData is returned by GetUserProfile. IsRestricted means the
+// profile's visibility doesn't include the caller: only the name, avatar and
+// follow state are filled in. StatsHidden means stats and scorecards aren't
+// available to the caller.
 type UserProfileData struct {
 	ID           string  `json:"id"`
 	DisplayName  string  `json:"display_name"`
@@ -66,6 +70,8 @@ type UserProfileData struct {
 	EventsPlayed int     `json:"events_played"`
 	IsFollowing  bool    `json:"is_following"`
 	IsMe         bool    `json:"is_me"`
+	IsRestricted bool    `json:"is_restricted"`
+	StatsHidden  bool    `json:"stats_hidden"`
 }
 
 // FollowingUserData is one entry returned by GetFollowing.
@@ -212,6 +218,88 @@ func ComputeHandicapPair(diffs []float64) (handicapIndex, antiHandicap *float64)
 	return &hi, &ah
 }
 
+// handicapDifferentials returns the score differentials from a user's last 20
+// completed event rounds on rated tees — the input to ComputeHandicapPair.
+// Rounds the user was marked absent for, or withdrew, was disqualified or
+// returned no card from, don't count.
+func handicapDifferentials(ctx context.Context, db *gorm.DB, userID uuid.UUID) ([]float64, error) {
+	byUser, err := handicapDifferentialsByUser(ctx, db, []uuid.UUID{userID})
+	if err != nil {
+		return nil, err
+	}
+	return byUser[userID], nil
+}
+
+// handicapDifferentialsByUser is handicapDifferentials for many users in two
+// queries, whatever their number. Users with no differentials are absent.
+func handicapDifferentialsByUser(ctx context.Context, db *gorm.DB, userIDs []uuid.UUID) (map[uuid.UUID][]float64, error) {
+	out := make(map[uuid.UUID][]float64)
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+	type hcRound struct {
+		UserID        uuid.UUID
+		RoundPlayerID uuid.UUID
+		CourseRating  *float64
+		SlopeRating   *int
+	}
+	var hcRows []hcRound
+	if err := db.WithContext(ctx).Raw(`
+		SELECT user_id, round_player_id, course_rating, slope_rating FROM (
+			SELECT ep.user_id, rp.id AS round_player_id, t.course_rating, t.slope_rating,
+			       ROW_NUMBER() OVER (PARTITION BY ep.user_id ORDER BY rp.created_at DESC) AS n
+			FROM round_players rp
+			JOIN event_players ep ON ep.id = rp.event_player_id
+			JOIN rounds r         ON r.id  = rp.round_id
+			LEFT JOIN tees t      ON t.id  = r.default_tee_id
+			WHERE ep.user_id IN ? AND r.status = ? AND rp.absent_at IS NULL AND rp.status NOT IN ?
+		) recent
+		WHERE n <= 20
+	`, userIDs, models.RoundStatusCompleted, models.RoundPlayerOutStatuses).Scan(&hcRows).Error; err != nil {
+		return nil, fmt.Errorf("load handicap rounds: %w", err)
+	}
+
+	type hcTee struct {
+		UserID uuid.UUID
+		Rating float64
+		Slope  int
+	}
+	hcRPIDs := make([]uuid.UUID, 0, len(hcRows))
+	hcTeeByRP := make(map[uuid.UUID]hcTee)
+	for _, r := range hcRows {
+		if r.CourseRating != nil && r.SlopeRating != nil && *r.SlopeRating > 0 {
+			hcRPIDs = append(hcRPIDs, r.RoundPlayerID)
+			hcTeeByRP[r.RoundPlayerID] = hcTee{UserID: r.UserID, Rating: *r.CourseRating, Slope: *r.SlopeRating}
+		}
+	}
+	if len(hcRPIDs) == 0 {
+		return out, nil
+	}
+
+	var hcScores []struct {
+		RoundPlayerID uuid.UUID
+		GrossScore    int
+	}
+	if err := db.WithContext(ctx).Model(&models.Score{}).
+		Select("round_player_id, gross_score").
+		Where("round_player_id IN ?", hcRPIDs).
+		Scan(&hcScores).Error; err != nil {
+		return nil, fmt.Errorf("load handicap scores: %w", err)
+	}
+
+	hcTotals := make(map[uuid.UUID]int)
+	for _, sc := range hcScores {
+		hcTotals[sc.RoundPlayerID] += sc.GrossScore
+	}
+	for rpID, gross := range hcTotals {
+		if tee, ok := hcTeeByRP[rpID]; ok {
+			diff := (float64(gross) - tee.Rating) * 113 / float64(tee.Slope)
+			out[tee.UserID] = append(out[tee.UserID], diff)
+		}
+	}
+	return out, nil
+}
+
 // ─── Methods ──────────────────────────────────────────────────────────────────
 
 // GetMe returns the authenticated caller's own profile including their platform role.
@@ -223,7 +311,6 @@ func (s *UserService) GetMe(ctx context.Context, callerID uuid.UUID) (*MeData, e
 		}
 		return nil, fmt.Errorf("user.get_me: %w", err)
 	}
-
 	avatarURL := ""
 	if user.AvatarURL != nil {
 		avatarURL = *user.AvatarURL
@@ -237,16 +324,35 @@ func (s *UserService) GetMe(ctx context.Context, callerID uuid.UUID) (*MeData, e
 	}, nil
 }
 
-// SearchUsers returns all users except the caller, filtered by the optional query string.
-// Each result includes is_following to indicate whether the caller follows that user.
+// SearchUsers returns users other than the caller, filtered by the optional
+// query string. Users blocked either way are left out, as are users hidden
+// from search unless q is their exact email. A hidden email is neither matched
+// (in part or exactly) nor returned. Each result includes is_following to
+// indicate whether the caller follows that user.
 func (s *UserService) SearchUsers(ctx context.Context, callerID uuid.UUID, q string) ([]UserSearchResult, error) {
-	var users []models.User
-	query := s.DB.WithContext(ctx).Where("id != ?", callerID).Order("display_name ASC")
+	type searchRow struct {
+		models.User
+		HideEmail bool
+	}
+	var users []searchRow
+	emailHidden := emailHiddenSQL("users.id")
+	query := s.DB.WithContext(ctx).Model(&models.User{}).
+		Select("users.*, ("+emailHidden+") AS hide_email", callerID).
+		Where("users.id != ?", callerID).
+		Where(notBlockedSQL("users.id"), callerID, callerID).
+		Order("display_name ASC")
+	visibleEmail := "NOT (" + emailHidden + ") AND "
+	exactEmail := visibleEmail + "LOWER(users.email) = LOWER(?)"
 	if q != "" {
 		like := "%" + q + "%"
-		query = query.Where("display_name ILIKE ? OR email ILIKE ?", like, like)
+		query = query.Where("users.display_name ILIKE ? OR ("+visibleEmail+"users.email ILIKE ?) OR ("+exactEmail+")",
+			like, callerID, like, callerID, q)
+	}
+	query = query.Where("NOT EXISTS (SELECT 1 FROM user_privacy_settings hs WHERE hs.user_id = users.id AND hs.hide_from_search) OR ("+
+		exactEmail+")", callerID, q)
+	if err := query.Scan(&users).Error; err != nil {
+		return nil, fmt.Errorf("user.search: %w", err)
 	}
-	query.Find(&users)
 
 	if len(users) == 0 {
 		return []UserSearchResult{}, nil
@@ -268,10 +374,14 @@ func (s *UserService) SearchUsers(ctx context.Context, callerID uuid.UUID, q str
 
 	results := make([]UserSearchResult, 0, len(users))
 	for _, u := range users {
+		var email *string
+		if !u.HideEmail {
+			email = &u.Email
+		}
 		results = append(results, UserSearchResult{
 			ID:          u.ID.String(),
 			DisplayName: u.DisplayName,
-			Email:       u.Email,
+			Email:       email,
 			AvatarURL:   u.AvatarURL,
 			IsFollowing: followSet[u.ID],
 		})
@@ -279,8 +389,10 @@ func (s *UserService) SearchUsers(ctx context.Context, callerID uuid.UUID, q str
 	return results, nil
 }
 
-// GetUserProfile returns a public profile: name, avatar, round/event counts, and
-// whether the caller follows the target.
+// GetUserProfile returns a profile: name, avatar, round/event counts, and
+// whether the caller follows the target. A user blocked either way reads as not
+// found; one whose visibility excludes the caller comes back restricted, without
+// counts.
 func (s *UserService) GetUserProfile(ctx context.Context, callerID, targetID uuid.UUID) (*UserProfileData, error) {
 	var target models.User
 	if err := s.DB.WithContext(ctx).First(&target, "id = ?", targetID).Error; err != nil {
@@ -289,12 +401,37 @@ func (s *UserService) GetUserProfile(ctx context.Context, callerID, targetID uui
 		}
 		return nil, fmt.Errorf("user.get_profile: %w", err)
 	}
+	access, err := loadProfileAccess(ctx, s.DB, callerID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if access.Blocked {
+		return nil, ErrUserNotFound
+	}
+
+	var followCount int64
+	s.DB.WithContext(ctx).Model(&models.Follow{}).
+		Where("follower_id = ? AND followee_id = ?", callerID, targetID).
+		Count(&followCount)
+
+	profile := &UserProfileData{
+		ID:           target.ID.String(),
+		DisplayName:  target.DisplayName,
+		AvatarURL:    target.AvatarURL,
+		IsFollowing:  followCount > 0,
+		IsMe:         callerID == targetID,
+		IsRestricted: !access.Profile,
+		StatsHidden:  !access.Stats,
+	}
+	if !access.Profile {
+		return profile, nil
+	}
 
 	var roundsPlayed int64
 	s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).
 		Joins("JOIN event_players ep ON ep.id = round_players.event_player_id").
 		Joins("JOIN rounds ON rounds.id = round_players.round_id").
-		Where("ep.user_id = ? AND rounds.status = ?", targetID, models.RoundStatusCompleted).
+		Where("ep.user_id = ? AND rounds.status = ? AND round_players.absent_at IS NULL", targetID, models.RoundStatusCompleted).
 		Count(&roundsPlayed)
 
 	var eventsPlayed int64
@@ -302,32 +439,30 @@ func (s *UserService) GetUserProfile(ctx context.Context, callerID, targetID uui
 		Where("user_id = ?", targetID).
 		Count(&eventsPlayed)
 
-	var followCount int64
-	s.DB.WithContext(ctx).Model(&models.Follow{}).
-		Where("follower_id = ? AND followee_id = ?", callerID, targetID).
-		Count(&followCount)
-
-	return &UserProfileData{
-		ID:           target.ID.String(),
-		DisplayName:  target.DisplayName,
-		AvatarURL:    target.AvatarURL,
-		RoundsPlayed: int(roundsPlayed),
-		EventsPlayed: int(eventsPlayed),
-		IsFollowing:  followCount > 0,
-		IsMe:         callerID == targetID,
-	}, nil
+	profile.RoundsPlayed = int(roundsPlayed)
+	profile.EventsPlayed = int(eventsPlayed)
+	return profile, nil
 }
 
 // FollowUser creates a follow relationship from caller to target.
-// Returns ErrFollowSelf if caller == target, ErrAlreadyFollowing on duplicate.
+// Returns ErrFollowSelf if caller == target, ErrUserNotFound if either has
+// blocked the other, ErrAlreadyFollowing on duplicate.
 func (s *UserService) FollowUser(ctx context.Context, callerID, targetID uuid.UUID) error {
 	if callerID == targetID {
 		return ErrFollowSelf
 	}
+	access, err := loadProfileAccess(ctx, s.DB, callerID, targetID)
+	if err != nil {
+		return err
+	}
+	if access.Blocked {
+		return ErrUserNotFound
+	}
 	follow := models.Follow{FollowerID: callerID, FolloweeID: targetID}
 	if err := s.DB.WithContext(ctx).Create(&follow).Error; err != nil {
 		return ErrAlreadyFollowing
 	}
+	notify(ctx, s.DB, models.Notification{Type: models.NotificationTypeNewFollower, ActorID: &callerID}, []uuid.UUID{targetID})
 	return nil
 }
 
@@ -352,7 +487,7 @@ func (s *UserService) GetFollowing(ctx context.Context, callerID uuid.UUID) ([]F
 		s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).
 			Joins("JOIN event_players ep ON ep.id = round_players.event_player_id").
 			Joins("JOIN rounds ON rounds.id = round_players.round_id").
-			Where("ep.user_id = ? AND rounds.status = ?", u.ID, models.RoundStatusCompleted).
+			Where("ep.user_id = ? AND rounds.status = ? AND round_players.absent_at IS NULL", u.ID, models.RoundStatusCompleted).
 			Count(&roundsPlayed)
 
 		results = append(results, FollowingUserData{
@@ -366,11 +501,16 @@ func (s *UserService) GetFollowing(ctx context.Context, callerID uuid.UUID) ([]F
 }
 
 // GetUserStats computes career scoring stats for the target user from completed rounds.
+// Rounds the user was marked absent for (generated cards) are left out.
 // filter must be "all_time" or "last_20"; any other value defaults to "all_time".
-func (s *UserService) GetUserStats(ctx context.Context, targetID uuid.UUID, filter string) (*UserStatsData, error) {
+// The target's privacy settings must let the caller see their stats.
+func (s *UserService) GetUserStats(ctx context.Context, callerID, targetID uuid.UUID, filter string) (*UserStatsData, error) {
 	if filter != "all_time" && filter != "last_20" {
 		filter = "all_time"
 	}
+	if err := requireProfileAccess(ctx, s.DB, callerID, targetID, true); err != nil {
+		return nil, err
+	}
 
 	type rpRow struct {
 		ID      uuid.UUID
@@ -380,7 +520,7 @@ func (s *UserService) GetUserStats(ctx context.Context, targetID uuid.UUID, filt
 		Select("round_players.id, round_players.round_id").
 		Joins("JOIN event_players ep ON ep.id = round_players.event_player_id").
 		Joins("JOIN rounds ON rounds.id = round_players.round_id").
-		Where("ep.user_id = ? AND rounds.status = ?", targetID, models.RoundStatusCompleted).
+		Where("ep.user_id = ? AND rounds.status = ? AND round_players.absent_at IS NULL", targetID, models.RoundStatusCompleted).
 		Order("round_players.created_at DESC")
 	if filter == "last_20" {
 		rpQuery = rpQuery.Limit(20)
@@ -538,58 +678,10 @@ func (s *UserService) GetUserStats(ctx context.Context, targetID uuid.UUID, filt
 	}
 
 	// Handicap pair always uses the last 20 completed rounds regardless of filter.
-	type hcRound struct {
-		RoundPlayerID uuid.UUID
-		CourseRating  *float64
-		SlopeRating   *int
-	}
-	var hcRows []hcRound
-	s.DB.WithContext(ctx).Raw(`
-		SELECT rp.id AS round_player_id, t.course_rating, t.slope_rating
-		FROM round_players rp
-		JOIN event_players ep ON ep.id = rp.event_player_id
-		JOIN rounds r         ON r.id  = rp.round_id
-		LEFT JOIN tees t      ON t.id  = r.default_tee_id
-		WHERE ep.user_id = ? AND r.status = ?
-		ORDER BY rp.created_at DESC
-		LIMIT 20
-	`, targetID, models.RoundStatusCompleted).Scan(&hcRows)
-
-	hcRPIDs := make([]uuid.UUID, 0, len(hcRows))
-	hcTeeByRP := make(map[uuid.UUID]struct {
-		Rating float64
-		Slope  int
-	})
-	for _, r := range hcRows {
-		if r.CourseRating != nil && r.SlopeRating != nil && *r.SlopeRating > 0 {
-			hcRPIDs = append(hcRPIDs, r.RoundPlayerID)
-			hcTeeByRP[r.RoundPlayerID] = struct {
-				Rating float64
-				Slope  int
-			}{Rating: *r.CourseRating, Slope: *r.SlopeRating}
-		}
-	}
-
-	var hcDiffs []float64
-	if len(hcRPIDs) > 0 {
-		var hcScores []scoreRow
-		s.DB.WithContext(ctx).Model(&models.Score{}).
-			Select("round_player_id, hole_number, gross_score").
-			Where("round_player_id IN ?", hcRPIDs).
-			Scan(&hcScores)
-
-		hcTotals := make(map[uuid.UUID]int)
-		for _, sc := range hcScores {
-			hcTotals[sc.RoundPlayerID] += sc.GrossScore
-		}
-		for rpID, gross := range hcTotals {
-			if tee, ok := hcTeeByRP[rpID]; ok {
-				diff := (float64(gross) - tee.Rating) * 113 / float64(tee.Slope)
-				hcDiffs = append(hcDiffs, diff)
-			}
-		}
+	hcDiffs, err := handicapDifferentials(ctx, s.DB, targetID)
+	if err != nil {
+		return nil, err
 	}
-
 	hcIndex, antiHC := ComputeHandicapPair(hcDiffs)
 
 	return &UserStatsData{
@@ -612,13 +704,17 @@ func (s *UserService) GetUserStats(ctx context.Context, targetID uuid.UUID, filt
 }
 
 // GetUserRounds returns the last 20 completed rounds the target user participated in.
-func (s *UserService) GetUserRounds(ctx context.Context, targetID uuid.UUID) ([]UserRoundRef, error) {
+// The target's profile must be visible to the caller.
+func (s *UserService) GetUserRounds(ctx context.Context, callerID, targetID uuid.UUID) ([]UserRoundRef, error) {
+	if err := requireProfileAccess(ctx, s.DB, callerID, targetID, false); err != nil {
+		return nil, err
+	}
 	var results []UserRoundRef
 	s.DB.WithContext(ctx).Model(&models.RoundPlayer{}).
 		Select("rounds.id, rounds.scheduled_date").
 		Joins("JOIN event_players ep ON ep.id = round_players.event_player_id").
 		Joins("JOIN rounds ON rounds.id = round_players.round_id").
-		Where("ep.user_id = ? AND rounds.status = ?", targetID, models.RoundStatusCompleted).
+		Where("ep.user_id = ? AND rounds.status = ? AND round_players.absent_at IS NULL", targetID, models.RoundStatusCompleted).
 		Order("rounds.scheduled_date DESC").
 		Limit(20).
 		Scan(&results)
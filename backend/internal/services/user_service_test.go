@@ -193,7 +193,7 @@ func TestUserService_GetUserStats_EmptyReturnsZero(t *testing.T) {
 
 	user := seedUser(t, db, "stats_empty")
 
-	data, err := svc.GetUserStats(context.Background(), user.ID, "all_time")
+	data, err := svc.GetUserStats(context.Background(), user.ID, user.ID, "all_time")
 	require.NoError(t, err)
 	require.NotNil(t, data)
 	assert.Equal(t, "all_time", data.Filter)
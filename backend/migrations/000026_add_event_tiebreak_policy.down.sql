@@ -0,0 +1,4 @@
+-- 000026_add_event_tiebreak_policy.down.sql
+-- Reverses 000026_add_event_tiebreak_policy.up.sql.
+
+ALTER TABLE events DROP COLUMN tiebreak_policy;
@@ -0,0 +1,12 @@
+-- 000026_add_event_tiebreak_policy.up.sql
+-- Adds a per-event tiebreak policy used by the round leaderboard and event standings
+-- when two or more players finish level on net.
+--
+--   card_off — break the tie by matching cards: back 9, back 6, back 3, then the 18th
+--              hole, each on net with a prorated share of the player's handicap.
+--   shared   — leave the tie standing; tied players share the position (T2, T2, 4).
+--
+-- Stored as TEXT (mirrors vegas_scoring_basis in 000021) so new policies can be added
+-- without an enum migration. Existing events default to card_off, the common default
+-- for club competitions.
+ALTER TABLE events ADD COLUMN tiebreak_policy TEXT NOT NULL DEFAULT 'card_off';
@@ -0,0 +1,6 @@
+-- 000027_add_event_finalize.down.sql
+-- Reverses 000027_add_event_finalize.up.sql.
+
+DROP TABLE IF EXISTS event_reopenings;
+ALTER TABLE events DROP COLUMN IF EXISTS finalized_by;
+ALTER TABLE events DROP COLUMN IF EXISTS finalized_at;
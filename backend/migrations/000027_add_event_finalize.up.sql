@@ -0,0 +1,21 @@
+-- 000027_add_event_finalize.up.sql
+-- Adds the event finalize/reopen workflow. Setting an event to "completed" via
+-- PATCH only flips the status; finalizing also writes final positions, totals and
+-- points onto event_players/round_players and locks score edits (admins excepted).
+--
+-- finalized_at / finalized_by: set on finalize, cleared on reopen. NULL = not
+-- finalized, so every existing event starts unlocked.
+ALTER TABLE events ADD COLUMN finalized_at TIMESTAMPTZ;
+ALTER TABLE events ADD COLUMN finalized_by UUID REFERENCES users(id);
+
+-- event_reopenings: append-only audit trail — one row each time an organizer
+-- reopens a finalized event, with the reason they gave.
+CREATE TABLE event_reopenings (
+    id          UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id    UUID        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    reopened_by UUID        NOT NULL REFERENCES users(id),
+    reason      TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_reopenings_event ON event_reopenings(event_id);
@@ -0,0 +1,7 @@
+-- 000028_add_scorecard_attestation.down.sql
+-- Reverses 000028_add_scorecard_attestation.up.sql.
+
+ALTER TABLE round_players DROP COLUMN IF EXISTS needs_reattestation;
+ALTER TABLE round_players DROP COLUMN IF EXISTS marker_id;
+ALTER TABLE round_players DROP COLUMN IF EXISTS marker_attested_at;
+ALTER TABLE round_players DROP COLUMN IF EXISTS player_attested_at;
@@ -0,0 +1,14 @@
+-- 000028_add_scorecard_attestation.up.sql
+-- Adds scorecard attestation: once a card is complete, the player and a marker
+-- from the same tee-time group both sign it off, which moves the round_player to
+-- status "completed". After that only organizers (or admins) may correct it, and
+-- a correction clears both signatures so the card must be attested again.
+--
+-- player_attested_at / marker_attested_at: NULL until the respective signature.
+-- marker_id: the group member who signed as marker.
+-- needs_reattestation: true after an organizer correction to an attested card,
+-- until both signatures are collected again.
+ALTER TABLE round_players ADD COLUMN player_attested_at  TIMESTAMPTZ;
+ALTER TABLE round_players ADD COLUMN marker_attested_at  TIMESTAMPTZ;
+ALTER TABLE round_players ADD COLUMN marker_id           UUID REFERENCES users(id);
+ALTER TABLE round_players ADD COLUMN needs_reattestation BOOLEAN NOT NULL DEFAULT FALSE;
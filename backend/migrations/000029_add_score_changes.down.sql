@@ -0,0 +1,4 @@
+-- 000029_add_score_changes.down.sql
+-- Reverses 000029_add_score_changes.up.sql.
+
+DROP TABLE IF EXISTS score_changes;
@@ -0,0 +1,25 @@
+-- 000029_add_score_changes.up.sql
+-- Append-only audit trail for scorecard edits. scores/hole_stats are upserted in
+-- place and only keep entered_by/updated_at, so a mid-round change left no record
+-- of who changed what from what. Every insert or update of a hole score, hole
+-- stat, or course handicap now writes one row here.
+--
+-- kind: 'score' | 'hole_stat' | 'handicap' (TEXT, not an enum, like tiebreak_policy).
+-- hole_number: NULL for handicap changes.
+-- old_value / new_value: the value before and after, as JSON. old_value is NULL
+-- when the row was first created; new_value is NULL when a revert removed it.
+-- revert_of: set when the row was written by an organizer revert, pointing at the
+-- change that was undone.
+CREATE TABLE score_changes (
+    id              UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    round_player_id UUID        NOT NULL REFERENCES round_players(id) ON DELETE CASCADE,
+    kind            TEXT        NOT NULL,
+    hole_number     INT,
+    old_value       JSONB,
+    new_value       JSONB,
+    changed_by      UUID        NOT NULL REFERENCES users(id),
+    revert_of       UUID        REFERENCES score_changes(id),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_score_changes_round_player ON score_changes(round_player_id, created_at);
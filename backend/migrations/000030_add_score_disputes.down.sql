@@ -0,0 +1,4 @@
+-- 000030_add_score_disputes.down.sql
+-- Reverses 000030_add_score_disputes.up.sql.
+
+DROP TABLE IF EXISTS score_disputes;
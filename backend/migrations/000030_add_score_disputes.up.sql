@@ -0,0 +1,25 @@
+-- 000030_add_score_disputes.up.sql
+-- Score disputes: any player in a round can flag one hole score on a card with a
+-- comment. Organizers list open disputes per round and resolve each one; until
+-- then the round leaderboard marks the card as disputed.
+--
+-- status: 'open' | 'accepted' (score stands) | 'corrected' (organizer changed the
+-- score) | 'dismissed' (dispute withdrawn or invalid). TEXT, not an enum.
+-- resolution_note / resolved_by / resolved_at: NULL while open.
+CREATE TABLE score_disputes (
+    id              UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    round_player_id UUID        NOT NULL REFERENCES round_players(id) ON DELETE CASCADE,
+    hole_number     INT         NOT NULL,
+    raised_by       UUID        NOT NULL REFERENCES users(id),
+    comment         TEXT        NOT NULL,
+    status          TEXT        NOT NULL DEFAULT 'open',
+    resolution_note TEXT,
+    resolved_by     UUID        REFERENCES users(id),
+    resolved_at     TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- At most one open dispute per hole on a card; resolved disputes are kept as history.
+CREATE UNIQUE INDEX idx_score_disputes_open_hole
+    ON score_disputes(round_player_id, hole_number) WHERE status = 'open';
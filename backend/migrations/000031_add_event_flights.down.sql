@@ -0,0 +1,13 @@
+-- 000031_add_event_flights.down.sql
+-- Reverses 000031_add_event_flights.up.sql. Flight-specific points rules are
+-- dropped so the original (event_id, finish_position) constraint can return.
+
+DELETE FROM event_points_rules WHERE flight_id IS NOT NULL;
+ALTER TABLE event_points_rules DROP CONSTRAINT IF EXISTS event_points_rules_event_flight_position_key;
+ALTER TABLE event_points_rules DROP COLUMN IF EXISTS flight_id;
+ALTER TABLE event_points_rules ADD CONSTRAINT event_points_rules_event_id_finish_position_key
+    UNIQUE (event_id, finish_position);
+ALTER TABLE round_players DROP COLUMN IF EXISTS flight_id;
+ALTER TABLE event_players DROP COLUMN IF EXISTS flight_id;
+ALTER TABLE events DROP COLUMN IF EXISTS flight_assignment;
+DROP TABLE IF EXISTS event_flights;
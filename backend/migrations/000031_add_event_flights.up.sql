@@ -0,0 +1,45 @@
+-- 000031_add_event_flights.up.sql
+-- Adds flights (A/B/C divisions) within an event. Each flight is ranked on its
+-- own, so the round leaderboard, standings and points each produce per-flight
+-- winners.
+--
+-- event_flights: one row per flight, ordered by sort_order (A first). The
+-- optional handicap-index band [min, max] (both inclusive, NULL = open-ended)
+-- drives automatic assignment; the first band (by sort_order) that contains a
+-- player's index wins.
+CREATE TABLE event_flights (
+    id                 UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id           UUID         NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name               TEXT         NOT NULL,
+    sort_order         INT          NOT NULL DEFAULT 0,
+    min_handicap_index DECIMAL(4,1),
+    max_handicap_index DECIMAL(4,1),
+    created_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, name)
+);
+
+-- flight_assignment: how event_players.flight_id is set.
+--   "manual"          — organizers assign each member.
+--   "handicap_season" — assigned from handicap bands when the organizer
+--                       recomputes (e.g. at the start of the season).
+--   "handicap_round"  — as handicap_season, but recomputed automatically each
+--                       time one of the event's rounds goes active.
+ALTER TABLE events ADD COLUMN flight_assignment TEXT NOT NULL DEFAULT 'manual';
+
+-- A member's current flight. NULL = unflighted (ranked in a group of their own
+-- when the event has flights). Deleting a flight unassigns its members.
+ALTER TABLE event_players ADD COLUMN flight_id UUID REFERENCES event_flights(id) ON DELETE SET NULL;
+
+-- The flight a player competed in for a round, snapshotted when the round goes
+-- active so later reassignments don't rewrite past leaderboards. NULL (rounds
+-- started before flights existed, late additions) falls back to the member's
+-- current event_players.flight_id.
+ALTER TABLE round_players ADD COLUMN flight_id UUID REFERENCES event_flights(id) ON DELETE SET NULL;
+
+-- Points tables may be set per flight. A rule with flight_id NULL is the
+-- event's default table, used by any flight without rules of its own.
+ALTER TABLE event_points_rules ADD COLUMN flight_id UUID REFERENCES event_flights(id) ON DELETE CASCADE;
+ALTER TABLE event_points_rules DROP CONSTRAINT event_points_rules_event_id_finish_position_key;
+ALTER TABLE event_points_rules ADD CONSTRAINT event_points_rules_event_flight_position_key
+    UNIQUE NULLS NOT DISTINCT (event_id, flight_id, finish_position);
@@ -0,0 +1,6 @@
+-- 000032_add_brackets.down.sql
+-- Reverses 000032_add_brackets.up.sql.
+
+DROP TABLE IF EXISTS bracket_matches;
+DROP TABLE IF EXISTS bracket_entries;
+DROP TABLE IF EXISTS brackets;
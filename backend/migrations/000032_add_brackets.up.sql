@@ -0,0 +1,60 @@
+-- 000032_add_brackets.up.sql
+-- Match play brackets for tournament events: one bracket per event, seeded into
+-- a single- or double-elimination tree of matches. Each match can be scheduled
+-- as a match_play round; completing that round advances the winner.
+
+-- brackets: one per event.
+--   format:  "single_elimination" or "double_elimination"
+--   seeding: "handicap" (lowest index = seed 1) or "manual" (organizer's order)
+--   size:    players rounded up to a power of two; the missing seeds are byes
+CREATE TABLE brackets (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id   UUID        NOT NULL UNIQUE REFERENCES events(id) ON DELETE CASCADE,
+    format     TEXT        NOT NULL,
+    seeding    TEXT        NOT NULL,
+    size       INT         NOT NULL,
+    created_by UUID        NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- bracket_entries: the seeded field.
+CREATE TABLE bracket_entries (
+    bracket_id      UUID NOT NULL REFERENCES brackets(id) ON DELETE CASCADE,
+    event_player_id UUID NOT NULL REFERENCES event_players(id) ON DELETE CASCADE,
+    seed            INT  NOT NULL,
+    PRIMARY KEY (bracket_id, event_player_id),
+    UNIQUE (bracket_id, seed)
+);
+
+-- bracket_matches: every match in the tree, created up front.
+--   side:   "winners", "losers" (double elimination) or "final" (grand final)
+--   status: "pending" (waiting or in play), "completed", or "bye" (walkover;
+--           winner_id NULL when both slots ended up empty)
+-- next_match_id/next_slot route the winner; loser_next_match_id/loser_next_slot
+-- route the loser into the losers bracket. The self-references are deferred so
+-- the whole tree can be inserted in one transaction in any order.
+-- round_id: the match_play round scheduled for this match; SET NULL if deleted.
+-- result:   match play score, e.g. "3&2" or "1 up".
+CREATE TABLE bracket_matches (
+    id                  UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    bracket_id          UUID        NOT NULL REFERENCES brackets(id) ON DELETE CASCADE,
+    side                TEXT        NOT NULL,
+    round_number        INT         NOT NULL,
+    match_number        INT         NOT NULL,
+    player1_id          UUID        REFERENCES event_players(id) ON DELETE SET NULL,
+    player2_id          UUID        REFERENCES event_players(id) ON DELETE SET NULL,
+    status              TEXT        NOT NULL DEFAULT 'pending',
+    winner_id           UUID        REFERENCES event_players(id) ON DELETE SET NULL,
+    result              TEXT,
+    round_id            UUID        REFERENCES rounds(id) ON DELETE SET NULL,
+    next_match_id       UUID        REFERENCES bracket_matches(id) DEFERRABLE INITIALLY DEFERRED,
+    next_slot           INT,
+    loser_next_match_id UUID        REFERENCES bracket_matches(id) DEFERRABLE INITIALLY DEFERRED,
+    loser_next_slot     INT,
+    completed_at        TIMESTAMPTZ,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (bracket_id, side, round_number, match_number)
+);
+
+CREATE INDEX idx_bracket_matches_round ON bracket_matches(round_id) WHERE round_id IS NOT NULL;
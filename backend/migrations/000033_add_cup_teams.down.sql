@@ -0,0 +1,8 @@
+-- 000033_add_cup_teams.down.sql
+-- Reverses 000033_add_cup_teams.up.sql.
+
+DROP TABLE IF EXISTS cup_match_players;
+DROP TABLE IF EXISTS cup_matches;
+DROP TABLE IF EXISTS cup_sessions;
+ALTER TABLE event_players DROP COLUMN IF EXISTS event_team_id;
+DROP TABLE IF EXISTS event_teams;
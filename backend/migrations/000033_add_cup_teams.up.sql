@@ -0,0 +1,64 @@
+-- 000033_add_cup_teams.up.sql
+-- Ryder Cup–style team competition within an event: two event-level teams
+-- (distinct from the per-round Las Vegas / best-ball teams in `teams`), and
+-- sessions of four-ball, foursomes or singles matches. A won match is worth one
+-- point to the winning team; a halved match is worth half a point to each.
+
+-- event_teams: the two sides of an event's cup. sort_order 0 is listed first.
+CREATE TABLE event_teams (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id   UUID        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name       TEXT        NOT NULL,
+    color      TEXT,
+    sort_order INT         NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, sort_order)
+);
+
+-- The member's cup team. SET NULL if the team is removed.
+ALTER TABLE event_players
+    ADD COLUMN event_team_id UUID REFERENCES event_teams(id) ON DELETE SET NULL;
+
+-- cup_sessions: a block of matches in one format, optionally played in a round.
+--   format: "four_ball", "foursomes" or "singles"
+-- round_id: the round the session is played in; completing it scores the
+--           session's matches. SET NULL if the round is deleted.
+CREATE TABLE cup_sessions (
+    id             UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id       UUID        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    round_id       UUID        REFERENCES rounds(id) ON DELETE SET NULL,
+    session_number INT         NOT NULL,
+    name           TEXT        NOT NULL,
+    format         TEXT        NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, session_number)
+);
+
+CREATE INDEX idx_cup_sessions_round ON cup_sessions(round_id) WHERE round_id IS NOT NULL;
+
+-- cup_matches: one match within a session.
+--   status: "pending" or "completed". A completed match with a NULL
+--           winner_team_id was halved.
+--   result: match play score, e.g. "3&2", "1 up", "A/S".
+CREATE TABLE cup_matches (
+    id             UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id     UUID        NOT NULL REFERENCES cup_sessions(id) ON DELETE CASCADE,
+    match_number   INT         NOT NULL,
+    status         TEXT        NOT NULL DEFAULT 'pending',
+    winner_team_id UUID        REFERENCES event_teams(id) ON DELETE SET NULL,
+    result         TEXT,
+    completed_at   TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (session_id, match_number)
+);
+
+-- cup_match_players: who plays each side of a match. event_team_id records the
+-- side, so moving a member to the other team later doesn't rewrite history.
+CREATE TABLE cup_match_players (
+    match_id        UUID NOT NULL REFERENCES cup_matches(id) ON DELETE CASCADE,
+    event_player_id UUID NOT NULL REFERENCES event_players(id) ON DELETE CASCADE,
+    event_team_id   UUID NOT NULL REFERENCES event_teams(id) ON DELETE CASCADE,
+    PRIMARY KEY (match_id, event_player_id)
+);
@@ -0,0 +1,6 @@
+-- 000034_add_event_schedules.down.sql
+-- Reverses 000034_add_event_schedules.up.sql.
+
+ALTER TABLE rounds DROP COLUMN IF EXISTS schedule_week;
+DROP TABLE IF EXISTS event_schedule_skips;
+DROP TABLE IF EXISTS event_schedules;
@@ -0,0 +1,34 @@
+-- 000034_add_event_schedules.up.sql
+-- Recurring league schedules. An event's schedule template describes a season
+-- of weekly rounds (weekday, date range, course and tee, which nine, skipped
+-- dates); saving it generates one round per week. Generated rounds carry their
+-- week number so the schedule can be regenerated without touching rounds that
+-- were scheduled by hand or have already been played.
+
+-- event_schedules: at most one template per event.
+--   weekday: 0 = Sunday … 6 = Saturday
+--   nines:   "full", "front", "back", "alternate_front" or "alternate_back"
+CREATE TABLE event_schedules (
+    event_id       UUID        PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    weekday        INT         NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    start_date     DATE        NOT NULL,
+    end_date       DATE        NOT NULL,
+    course_id      UUID        NOT NULL REFERENCES courses(id),
+    tee_id         UUID        NOT NULL REFERENCES tees(id),
+    nines          TEXT        NOT NULL DEFAULT 'full',
+    scoring_format TEXT,
+    created_by     UUID        NOT NULL REFERENCES users(id),
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (end_date >= start_date)
+);
+
+-- event_schedule_skips: weeks the league doesn't play.
+CREATE TABLE event_schedule_skips (
+    event_id  UUID NOT NULL REFERENCES event_schedules(event_id) ON DELETE CASCADE,
+    skip_date DATE NOT NULL,
+    PRIMARY KEY (event_id, skip_date)
+);
+
+-- The schedule week a round was generated for; NULL for rounds scheduled by hand.
+ALTER TABLE rounds ADD COLUMN schedule_week INT;
@@ -0,0 +1,5 @@
+-- Reverses 000035_add_substitutes.up.sql.
+
+ALTER TABLE round_players DROP COLUMN IF EXISTS replaced_event_player_id;
+ALTER TABLE event_players DROP COLUMN IF EXISTS is_substitute;
+ALTER TABLE events DROP COLUMN IF EXISTS sub_policy;
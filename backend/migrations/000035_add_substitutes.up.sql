@@ -0,0 +1,20 @@
+-- 000035_add_substitutes.up.sql
+-- League substitutes. An event keeps a roster of subs (event_players flagged
+-- is_substitute) who aren't part of the regular field, and an organizer can
+-- swap a sub into a round in place of a registered player. The round_players
+-- row is reused so the group slot and team membership carry over; it records
+-- the member whose spot the sub took.
+
+-- sub_policy: who a sub's round points count for in the event standings.
+--   "team" — the member they replaced (the roster spot keeps its points).
+--   "self" — the sub, who gets a standings line of their own.
+--   "none" — nobody; the sub's round still places, but earns no points.
+ALTER TABLE events ADD COLUMN sub_policy TEXT NOT NULL DEFAULT 'team';
+
+-- Subs are event members (so they can be put in rounds and enter scores) but
+-- are left out of the regular field: generated pairings and bracket seeding.
+ALTER TABLE event_players ADD COLUMN is_substitute BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The member a sub is playing for. NULL = the player is playing their own spot.
+ALTER TABLE round_players
+    ADD COLUMN replaced_event_player_id UUID REFERENCES event_players(id) ON DELETE SET NULL;
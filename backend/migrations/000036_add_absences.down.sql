@@ -0,0 +1,6 @@
+-- Reverses 000036_add_absences.up.sql.
+
+ALTER TABLE scores DROP COLUMN IF EXISTS generated;
+ALTER TABLE round_players DROP COLUMN IF EXISTS blind_draw_round_player_id;
+ALTER TABLE round_players DROP COLUMN IF EXISTS absent_at;
+ALTER TABLE events DROP COLUMN IF EXISTS absence_policy;
@@ -0,0 +1,23 @@
+-- 000036_add_absences.up.sql
+-- Absent players. When a member no-shows in a team format, an organizer marks
+-- their round_players row absent and the round fills in their card per the
+-- event's absence_policy, so the partner's team still has a score to play with.
+-- Generated scores are labeled, and absent rounds are left out of the absent
+-- player's stats and handicap.
+
+-- absence_policy: how an absent player's card is filled in.
+--   "blind_draw"      — a randomly drawn player's net score on each hole,
+--                       preferring players from other groups.
+--   "phantom_par"     — par plus the absent player's handicap strokes (net par).
+--   "phantom_average" — par plus the absent player's average over par, spread
+--                       over the holes by stroke index.
+ALTER TABLE events ADD COLUMN absence_policy TEXT NOT NULL DEFAULT 'phantom_par';
+
+-- absent_at: NULL = present. blind_draw_round_player_id is the card a blind
+-- draw copied.
+ALTER TABLE round_players ADD COLUMN absent_at TIMESTAMPTZ;
+ALTER TABLE round_players
+    ADD COLUMN blind_draw_round_player_id UUID REFERENCES round_players(id) ON DELETE SET NULL;
+
+-- generated: the absence_policy that produced the score; NULL = entered.
+ALTER TABLE scores ADD COLUMN generated TEXT;
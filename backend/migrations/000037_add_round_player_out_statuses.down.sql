@@ -0,0 +1,23 @@
+-- 000037_add_round_player_out_statuses.down.sql
+-- Reverses 000037. Drops the reason column, then removes the 'disqualified' and
+-- 'no_card' enum values. PostgreSQL cannot DROP a value from an enum directly —
+-- the type must be recreated. Players with either status are remapped to
+-- 'withdrawn' before removal.
+ALTER TABLE round_players DROP COLUMN IF EXISTS status_reason;
+
+CREATE TYPE round_player_status_new AS ENUM ('registered', 'active', 'withdrawn', 'completed');
+
+ALTER TABLE round_players ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE round_players
+    ALTER COLUMN status TYPE round_player_status_new
+    USING (
+        CASE status::text
+            WHEN 'disqualified' THEN 'withdrawn'::round_player_status_new
+            WHEN 'no_card' THEN 'withdrawn'::round_player_status_new
+            ELSE status::text::round_player_status_new
+        END
+    );
+ALTER TABLE round_players ALTER COLUMN status SET DEFAULT 'registered';
+
+DROP TYPE round_player_status;
+ALTER TYPE round_player_status_new RENAME TO round_player_status;
@@ -0,0 +1,15 @@
+-- 000037_add_round_player_out_statuses.up.sql
+-- Withdrawals, disqualifications and no-cards. An organizer takes a player out
+-- of a round's results with a reason: the player keeps their scores but is not
+-- ranked, earns no points, lists below the field, and the round is left out of
+-- their handicap.
+--
+-- NOTE: ADD VALUE cannot be used in the same transaction that references the new
+-- value (mirrors 000007/000021), so this migration only adds the values plus the
+-- reason column.
+ALTER TYPE round_player_status ADD VALUE 'disqualified';
+ALTER TYPE round_player_status ADD VALUE 'no_card';
+
+-- status_reason: why the player was withdrawn, disqualified or returned no card.
+-- NULL while the player is in the results.
+ALTER TABLE round_players ADD COLUMN status_reason TEXT;
@@ -0,0 +1,3 @@
+-- Reverses 000038_add_event_invites.up.sql.
+
+DROP TABLE IF EXISTS event_invites;
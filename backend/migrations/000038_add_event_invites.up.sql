@@ -0,0 +1,33 @@
+-- 000038_add_event_invites.up.sql
+-- Event invitations. An organizer creates an invite code to share as a link —
+-- optionally single-use or capped, and expiring — or an invite addressed to an
+-- email address. Redeeming a code joins the event as a registered player.
+-- An addressed invite turns into an 'invited' event_players row as soon as the
+-- email belongs to a user (immediately, or when that person first signs in),
+-- which they then accept or decline.
+
+-- event_invites: one row per code.
+--   email:           addressee (lowercased); NULL for a shareable code
+--   invited_user_id: the addressee's user once resolved
+--   max_uses:        NULL = unlimited; always 1 for an addressed invite
+--   expires_at:      NULL = never expires
+--   revoked_at / declined_at: set when the organizer revokes, or the addressee declines
+CREATE TABLE event_invites (
+    id              UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id        UUID        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    code            TEXT        NOT NULL UNIQUE,
+    email           TEXT,
+    invited_user_id UUID        REFERENCES users(id) ON DELETE SET NULL,
+    max_uses        INT,
+    use_count       INT         NOT NULL DEFAULT 0,
+    expires_at      TIMESTAMPTZ,
+    revoked_at      TIMESTAMPTZ,
+    declined_at     TIMESTAMPTZ,
+    created_by      UUID        NOT NULL REFERENCES users(id),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_invites_event ON event_invites(event_id);
+-- Sign-in looks up open addressed invites by email.
+CREATE INDEX idx_event_invites_email ON event_invites(email) WHERE email IS NOT NULL AND invited_user_id IS NULL;
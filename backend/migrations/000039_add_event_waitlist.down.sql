@@ -0,0 +1,25 @@
+-- 000039_add_event_waitlist.down.sql
+-- Reverses 000039. Drops the capacity and waitlist columns, then removes the
+-- 'waitlisted' enum value. PostgreSQL cannot DROP a value from an enum directly —
+-- the type must be recreated. Waitlisted players are turned back into pending
+-- join requests before removal.
+ALTER TABLE event_players DROP COLUMN IF EXISTS offer_expires_at;
+ALTER TABLE event_players DROP COLUMN IF EXISTS waitlisted_at;
+ALTER TABLE events DROP COLUMN IF EXISTS waitlist_offer_hours;
+ALTER TABLE events DROP COLUMN IF EXISTS max_players;
+
+CREATE TYPE event_player_status_new AS ENUM ('invited', 'registered', 'withdrawn', 'completed', 'pending');
+
+ALTER TABLE event_players ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE event_players
+    ALTER COLUMN status TYPE event_player_status_new
+    USING (
+        CASE status::text
+            WHEN 'waitlisted' THEN 'pending'::event_player_status_new
+            ELSE status::text::event_player_status_new
+        END
+    );
+ALTER TABLE event_players ALTER COLUMN status SET DEFAULT 'registered';
+
+DROP TYPE event_player_status;
+ALTER TYPE event_player_status_new RENAME TO event_player_status;
@@ -0,0 +1,22 @@
+-- 000039_add_event_waitlist.up.sql
+-- Event capacity and waitlist. An organizer caps the field with max_players;
+-- once it is full, a join request is queued as 'waitlisted' instead of pending.
+-- When a spot opens (a member withdraws or is removed, or the cap goes up) the
+-- longest-waiting player is promoted straight to registered, or — when
+-- waitlist_offer_hours is set — offered the spot until offer_expires_at.
+--
+-- NOTE: ADD VALUE cannot be used in the same transaction that references the new
+-- value (mirrors 000007/000021), so this migration only adds the value plus the
+-- new columns.
+ALTER TYPE event_player_status ADD VALUE 'waitlisted';
+
+-- max_players: registered members (substitutes aside) the event holds; NULL = no limit.
+-- waitlist_offer_hours: NULL = promote automatically; otherwise how long a
+-- promoted player has to accept the spot before it passes down the list.
+ALTER TABLE events ADD COLUMN max_players INT;
+ALTER TABLE events ADD COLUMN waitlist_offer_hours INT;
+
+-- waitlisted_at orders the queue. offer_expires_at is set while a spot is
+-- offered to a waitlisted player.
+ALTER TABLE event_players ADD COLUMN waitlisted_at TIMESTAMPTZ;
+ALTER TABLE event_players ADD COLUMN offer_expires_at TIMESTAMPTZ;
@@ -0,0 +1,6 @@
+-- Reverses 000040_add_purse.up.sql.
+
+DROP TABLE IF EXISTS fee_payments;
+DROP TABLE IF EXISTS payout_places;
+ALTER TABLE rounds DROP COLUMN IF EXISTS entry_fee_cents;
+ALTER TABLE events DROP COLUMN IF EXISTS entry_fee_cents;
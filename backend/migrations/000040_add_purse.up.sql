@@ -0,0 +1,40 @@
+-- 000040_add_purse.up.sql
+-- Entry fees, purse and payouts. An event (and each of its rounds) can carry an
+-- entry fee; organizers mark who has paid, the fees owed make up the purse, and
+-- a payout schedule splits it by finishing place. No money moves through the
+-- app — this is the record of who paid and who is owed what.
+
+-- entry_fee_cents: what each player in the field owes; 0 = free.
+ALTER TABLE events ADD COLUMN entry_fee_cents INT NOT NULL DEFAULT 0;
+ALTER TABLE rounds ADD COLUMN entry_fee_cents INT NOT NULL DEFAULT 0;
+
+-- payout_places: the share of the purse each finishing place earns.
+--   round_id: NULL = the event's schedule (paid on final standings); set = a
+--             round's schedule (paid on the round leaderboard)
+--   percent:  share of the purse; a schedule adds up to at most 100, and
+--             anything left over stays with the organizer
+CREATE TABLE payout_places (
+    id       UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID         NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    round_id UUID         REFERENCES rounds(id) ON DELETE CASCADE,
+    place    INT          NOT NULL CHECK (place >= 1),
+    percent  DECIMAL(5,2) NOT NULL CHECK (percent > 0 AND percent <= 100),
+    UNIQUE NULLS NOT DISTINCT (event_id, round_id, place)
+);
+
+-- fee_payments: an entry fee marked paid. One per player per event (round_id
+-- NULL) or round.
+--   amount_cents: what was paid; the fee at the time unless the organizer
+--                 recorded a different amount
+CREATE TABLE fee_payments (
+    id              UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id        UUID        NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    round_id        UUID        REFERENCES rounds(id) ON DELETE CASCADE,
+    event_player_id UUID        NOT NULL REFERENCES event_players(id) ON DELETE CASCADE,
+    amount_cents    INT         NOT NULL CHECK (amount_cents >= 0),
+    recorded_by     UUID        NOT NULL REFERENCES users(id),
+    paid_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE NULLS NOT DISTINCT (event_player_id, round_id)
+);
+
+CREATE INDEX idx_fee_payments_event ON fee_payments(event_id);
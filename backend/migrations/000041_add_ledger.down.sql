@@ -0,0 +1,3 @@
+-- Reverses 000041_add_ledger.up.sql.
+
+DROP TABLE IF EXISTS ledger_entries;
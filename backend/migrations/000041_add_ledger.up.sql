@@ -0,0 +1,31 @@
+-- 000041_add_ledger.up.sql
+-- Side-bet ledger. Players in a completed round post what their side games
+-- (Vegas, skins, Nassau, ...) came to, player to player; balances net between
+-- every pair of players across all their rounds and events, and a settle-up
+-- entry zeroes a balance once the money has changed hands off the app.
+
+-- ledger_entries: one amount between two players.
+--   kind:     "result"    — from_user lost amount_cents to to_user in a round's game
+--             "settle_up" — from_user paid to_user amount_cents to square up
+--   round_id: the round a result came from; NULL for a settle-up
+--   game:     the side game a result came from ("las_vegas", "skins", "nassau",
+--             "other"); NULL for a settle-up. A round's results for a game are
+--             replaced as a whole when re-posted.
+CREATE TABLE ledger_entries (
+    id           UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind         TEXT        NOT NULL CHECK (kind IN ('result', 'settle_up')),
+    round_id     UUID        REFERENCES rounds(id) ON DELETE CASCADE,
+    game         TEXT,
+    from_user_id UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user_id   UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount_cents INT         NOT NULL CHECK (amount_cents > 0),
+    note         TEXT,
+    recorded_by  UUID        NOT NULL REFERENCES users(id),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (from_user_id <> to_user_id),
+    CHECK ((kind = 'result') = (round_id IS NOT NULL AND game IS NOT NULL))
+);
+
+CREATE INDEX idx_ledger_entries_from ON ledger_entries(from_user_id);
+CREATE INDEX idx_ledger_entries_to ON ledger_entries(to_user_id);
+CREATE INDEX idx_ledger_entries_round ON ledger_entries(round_id, game);
@@ -0,0 +1,4 @@
+-- Reverses 000042_add_notifications.up.sql.
+
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
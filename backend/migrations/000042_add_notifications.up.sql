@@ -0,0 +1,34 @@
+-- 000042_add_notifications.up.sql
+-- In-app notification inbox. Services write a row per recipient when something
+-- happens to them (a join request answered, a group assignment, a round going
+-- live, a new follower, final results); the client lists them and marks them
+-- read. Each user can turn any type off.
+
+-- notifications: one inbox entry.
+--   type:     what happened — see models.NotificationType
+--   actor_id: who did it; NULL for system changes or a deleted user
+--   event_id / round_id: what it's about, when anything. Messages are rendered
+--             from these when listed, so they always show current names.
+--   read_at:  NULL = unread
+CREATE TABLE notifications (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       TEXT        NOT NULL,
+    actor_id   UUID        REFERENCES users(id) ON DELETE SET NULL,
+    event_id   UUID        REFERENCES events(id) ON DELETE CASCADE,
+    round_id   UUID        REFERENCES rounds(id) ON DELETE CASCADE,
+    read_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notifications_user ON notifications(user_id, created_at DESC);
+CREATE INDEX idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- notification_preferences: a type the user has turned on or off. No row =
+-- on, so only changed types are stored.
+CREATE TABLE notification_preferences (
+    user_id UUID    NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type    TEXT    NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type)
+);
@@ -0,0 +1,4 @@
+-- Reverses 000043_add_push.up.sql.
+
+DROP TABLE IF EXISTS push_outbox;
+DROP TABLE IF EXISTS device_tokens;
@@ -0,0 +1,39 @@
+-- 000043_add_push.up.sql
+-- Push notifications for the mobile app. Devices register their push tokens;
+-- every in-app notification written for a user also queues one outbox row per
+-- device, and a background worker delivers the queue, retrying with backoff
+-- and dropping tokens the push service reports as no longer registered.
+
+-- device_tokens: a device that receives pushes.
+--   token:    the Expo push token. Unique: if another account signs in on the
+--             device, registering moves the token to them.
+--   platform: 'ios' or 'android'
+CREATE TABLE device_tokens (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token      TEXT        NOT NULL UNIQUE,
+    platform   TEXT        NOT NULL CHECK (platform IN ('ios', 'android')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_device_tokens_user ON device_tokens(user_id);
+
+-- push_outbox: one notification waiting to go to one device. Rows are deleted
+-- once delivered, and with their token when it is pruned.
+--   attempts:        failed delivery attempts so far
+--   next_attempt_at: when the worker may try again
+--   last_error:      why the last attempt failed
+--   failed_at:       set once attempts run out; the row is kept for inspection
+CREATE TABLE push_outbox (
+    id              UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_token_id UUID        NOT NULL REFERENCES device_tokens(id) ON DELETE CASCADE,
+    notification_id UUID        NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    attempts        INT         NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error      TEXT,
+    failed_at       TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_push_outbox_due ON push_outbox(next_attempt_at) WHERE failed_at IS NULL;
@@ -0,0 +1,3 @@
+-- Reverses 000044_add_email_digests.up.sql.
+
+DROP TABLE IF EXISTS email_digests;
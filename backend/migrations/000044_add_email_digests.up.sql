@@ -0,0 +1,21 @@
+-- 000044_add_email_digests.up.sql
+-- Weekly email digest of league results. A background job emails each event
+-- member the past week's round results, how their standings moved, and their
+-- tee times for the week ahead. Everyone is subscribed until they opt out,
+-- either in the app or through the unsubscribe link in every digest.
+
+-- email_digests: one row per user the digest job has seen. Created the first
+-- time a digest is considered for the user.
+--   enabled:           FALSE once the user unsubscribes
+--   unsubscribe_token: secret in the digest's unsubscribe link, so the link
+--                      works without signing in
+--   last_sent_at:      when the user's digest was last considered; the next
+--                      one is due a week later
+CREATE TABLE email_digests (
+    user_id           UUID        PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled           BOOLEAN     NOT NULL DEFAULT TRUE,
+    unsubscribe_token UUID        NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    last_sent_at      TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
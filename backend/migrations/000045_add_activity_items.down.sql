@@ -0,0 +1,3 @@
+-- Reverses 000045_add_activity_items.up.sql.
+
+DROP TABLE IF EXISTS activity_items;
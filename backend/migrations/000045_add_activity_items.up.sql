@@ -0,0 +1,31 @@
+-- 000045_add_activity_items.up.sql
+-- Activity feed. When a round is completed, each player with a full card gets
+-- a completed-round item, plus a personal best and an item per eagle or ace
+-- when they made one; finalizing an event adds a win for each winner. GET /feed
+-- lists the items of the people the caller follows.
+
+-- activity_items: one thing a player did.
+--   kind:        see models.ActivityKind
+--   round_id:    the round it happened in; NULL for event wins
+--   event_id:    the event, for event rounds and wins
+--   hole_number: the hole of an eagle or ace
+--   score:       gross total for a round or personal best, strokes on the hole
+--                for an eagle or ace; NULL for wins
+--   to_par:      score relative to par, alongside score
+-- Items are rewritten when a round is completed again or an event is
+-- finalized again, so they always match the current scores.
+CREATE TABLE activity_items (
+    id          UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind        TEXT        NOT NULL CHECK (kind IN ('round_completed', 'personal_best', 'eagle', 'ace', 'event_won')),
+    round_id    UUID        REFERENCES rounds(id) ON DELETE CASCADE,
+    event_id    UUID        REFERENCES events(id) ON DELETE CASCADE,
+    hole_number INT,
+    score       INT,
+    to_par      INT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_activity_items_user ON activity_items(user_id, created_at DESC);
+CREATE INDEX idx_activity_items_round ON activity_items(round_id);
+CREATE INDEX idx_activity_items_event ON activity_items(event_id) WHERE kind = 'event_won';
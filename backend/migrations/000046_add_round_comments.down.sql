@@ -0,0 +1,4 @@
+-- Reverses 000046_add_round_comments.up.sql.
+
+DROP TABLE IF EXISTS round_reactions;
+DROP TABLE IF EXISTS round_comments;
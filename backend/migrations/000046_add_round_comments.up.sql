@@ -0,0 +1,38 @@
+-- 000046_add_round_comments.up.sql
+-- Comments and emoji reactions on completed rounds. Either can be on the round
+-- as a whole or on one of its holes (the ace, the 11 on the par 3). Comments
+-- thread one level deep: a reply points at the comment that started the
+-- thread. Authors delete their own comments and round organizers moderate
+-- anyone's; deletes are soft so a thread keeps its shape.
+
+-- round_comments: one comment.
+--   hole_number: NULL = about the round as a whole
+--   parent_id:   the thread's first comment; NULL for a thread starter
+--   deleted_at / deleted_by: set when the author or an organizer removes it.
+--               The body is kept for moderation but never listed again.
+CREATE TABLE round_comments (
+    id          UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    round_id    UUID        NOT NULL REFERENCES rounds(id) ON DELETE CASCADE,
+    hole_number INT         CHECK (hole_number BETWEEN 1 AND 18),
+    parent_id   UUID        REFERENCES round_comments(id) ON DELETE CASCADE,
+    author_id   UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body        TEXT        NOT NULL,
+    deleted_at  TIMESTAMPTZ,
+    deleted_by  UUID        REFERENCES users(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_round_comments_round ON round_comments(round_id, created_at);
+CREATE INDEX idx_round_comments_parent ON round_comments(parent_id) WHERE parent_id IS NOT NULL;
+
+-- round_reactions: one user's emoji on a round or hole. A user can leave
+-- several different emoji, each once.
+CREATE TABLE round_reactions (
+    id          UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    round_id    UUID        NOT NULL REFERENCES rounds(id) ON DELETE CASCADE,
+    hole_number INT         CHECK (hole_number BETWEEN 1 AND 18),
+    user_id     UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji       TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE NULLS NOT DISTINCT (round_id, hole_number, user_id, emoji)
+);
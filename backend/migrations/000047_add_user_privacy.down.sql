@@ -0,0 +1,4 @@
+-- Reverses 000047_add_user_privacy.up.sql.
+
+DROP TABLE IF EXISTS user_blocks;
+DROP TABLE IF EXISTS user_privacy_settings;
@@ -0,0 +1,38 @@
+-- 000047_add_user_privacy.up.sql
+-- Profile privacy settings and user blocking. Until now every signed-in user
+-- could search anyone (email included) and read any profile, stats and
+-- scorecards. Search, profiles, stats, a player's scorecards and the activity
+-- feed now check these.
+
+-- user_privacy_settings: one row per user who has changed a setting; a
+-- missing row means the defaults below (everything visible).
+--   profile_visibility: who can see the profile's rounds, stats and feed items.
+--                       public = anyone; followers = people who follow you;
+--                       private = only you
+--   hide_email:         leave the email off search results and don't match
+--                       on it except in full
+--   hide_stats:         keep stats, scorecards and score-bearing feed items to
+--                       yourself
+--   hide_from_search:   only an exact email match finds you
+CREATE TABLE user_privacy_settings (
+    user_id            UUID        PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    profile_visibility TEXT        NOT NULL DEFAULT 'public'
+                                   CHECK (profile_visibility IN ('public', 'followers', 'private')),
+    hide_email         BOOLEAN     NOT NULL DEFAULT FALSE,
+    hide_stats         BOOLEAN     NOT NULL DEFAULT FALSE,
+    hide_from_search   BOOLEAN     NOT NULL DEFAULT FALSE,
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- user_blocks: blocker_id blocked blocked_id. A block works both ways: neither
+-- user finds, follows or sees the other outside rounds they share. Blocking
+-- removes any follow between the two.
+CREATE TABLE user_blocks (
+    blocker_id UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_user_blocks_blocked ON user_blocks(blocked_id);
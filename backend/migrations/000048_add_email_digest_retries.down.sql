@@ -0,0 +1,5 @@
+-- Reverses 000048_add_email_digest_retries.up.sql.
+
+ALTER TABLE email_digests
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS failed_attempts;
@@ -0,0 +1,12 @@
+-- 000048_add_email_digest_retries.up.sql
+-- A digest that failed to send used to stay due, so the same addresses were
+-- picked first on every run and could crowd everyone else out of the batch.
+-- Failed sends now back off before they're retried, and a digest that keeps
+-- failing is given up on until the next week.
+
+-- email_digests:
+--   failed_attempts: sends that have failed since the last digest went out
+--   next_attempt_at: a failed digest isn't retried before this
+ALTER TABLE email_digests
+    ADD COLUMN failed_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN next_attempt_at TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+-- Reverses 000049_add_users_invites_resolved_email.up.sql.
+
+ALTER TABLE users DROP COLUMN IF EXISTS invites_resolved_email;
@@ -0,0 +1,10 @@
+-- 000049_add_users_invites_resolved_email.up.sql
+-- Invites addressed to an email become a user's once the email is theirs.
+-- That used to be checked on every GET /me; it now runs once per address.
+
+-- users:
+--   invites_resolved_email: the (lowercased) email whose addressed invites
+--                           have been turned into event rows. Invites made
+--                           later resolve when they're created, so this is
+--                           only checked again when the user's email changes.
+ALTER TABLE users ADD COLUMN invites_resolved_email TEXT;
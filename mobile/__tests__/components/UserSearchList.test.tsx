@@ -7,6 +7,7 @@
 //   - User row with avatar_url → Image rendered, no initials (bug fix: avatar_url
 //     was previously missing from UserSummary type and never rendered)
 //   - User row without avatar_url → initials rendered, no Image
+//   - Search filtering, including users whose email is hidden (null)
 
 import React from "react";
 import { Image } from "react-native";
@@ -121,3 +122,18 @@ it("filters user rows by the search query", () => {
   // Bob does not match "alice" — his row should not be present.
   expect(queryByText("Bob Jones")).toBeNull();
 });
+
+it("filters without crashing when a user hides their email", () => {
+  const hidden: UserSummary = { id: "u3", display_name: "Carol Hidden", email: null, avatar_url: null };
+  const { getByText, queryByText } = render(
+    <UserSearchList
+      users={[userWithAvatar, hidden]}
+      search="carol"
+      onSearchChange={noop}
+      onSelect={noop}
+      isPending={false}
+    />
+  );
+  expect(getByText("Carol Hidden")).toBeTruthy();
+  expect(queryByText("Alice Smith")).toBeNull();
+});
@@ -74,7 +74,7 @@ import StatsCards from "@/components/StatsCards";
 type MemberResponse = {
   user_id: string;
   display_name: string;
-  email: string;
+  email: string | null; // null when the member hides it from you
   avatar_url: string | null;
   role: "organizer" | "player";
   status: string;
@@ -368,15 +368,15 @@ export default function EventDetailScreen() {
     },
   });
 
-  // endEventMutation: marks the event as completed via PATCH /events/:id.
-  // The backend already accepts status: "completed" in UpdateEvent.
+  // endEventMutation: completes the event via POST /events/:id/finalize, which
+  // writes the final standings and locks scores. PATCH no longer accepts
+  // status: "completed".
   const endEventMutation = useMutation({
     mutationFn: async () => {
       const token = await getToken();
-      const res = await apiFetch(`${API_URL}/api/v1/events/${id}`, {
-        method: "PATCH",
-        headers: { Authorization: `Bearer ${token}`, "Content-Type": "application/json" },
-        body: JSON.stringify({ status: "completed" }),
+      const res = await apiFetch(`${API_URL}/api/v1/events/${id}/finalize`, {
+        method: "POST",
+        headers: { Authorization: `Bearer ${token}` },
       });
       if (!res.ok) {
         const body = await res.json().catch(() => ({}));
@@ -814,9 +814,11 @@ export default function EventDetailScreen() {
                       <Text className={`font-semibold text-sm ${t.textPrimary}`} numberOfLines={1}>
                         {member.display_name}
                       </Text>
-                      <Text className={`text-xs ${t.textTertiary}`} numberOfLines={1}>
-                        {member.email}
-                      </Text>
+                      {member.email ? (
+                        <Text className={`text-xs ${t.textTertiary}`} numberOfLines={1}>
+                          {member.email}
+                        </Text>
+                      ) : null}
                     </View>
                   </TouchableOpacity>
 
@@ -1127,9 +1129,11 @@ export default function EventDetailScreen() {
                       <Text className={`font-semibold text-sm ${t.textPrimary}`} numberOfLines={1}>
                         {req.display_name}
                       </Text>
-                      <Text className={`text-xs ${t.textTertiary}`} numberOfLines={1}>
-                        {req.email}
-                      </Text>
+                      {req.email ? (
+                        <Text className={`text-xs ${t.textTertiary}`} numberOfLines={1}>
+                          {req.email}
+                        </Text>
+                      ) : null}
                     </View>
                     <View className="flex-row gap-2">
                       <TouchableOpacity
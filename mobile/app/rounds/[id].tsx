@@ -85,7 +85,7 @@ type GroupMember = {
   user_id: string;
   round_player_id: string;
   display_name: string;
-  email: string;
+  email: string | null; // null when the player hides it from you
   avatar_url: string | null;
   // is_guest marks a score-only guest player (no account); UI hides the synthetic email.
   is_guest?: boolean;
@@ -117,7 +117,7 @@ type RoundDetail = {
 type EventMember = {
   user_id: string;
   display_name: string;
-  email: string;
+  email: string | null;
   avatar_url: string | null;
   role: "organizer" | "player";
   status: string;
@@ -991,7 +991,7 @@ export default function RoundDetailScreen() {
                             </View>
                             {/* Guests have only a synthetic email — show a label instead. */}
                             <Text className={`text-xs ${t.textTertiary}`} numberOfLines={1}>
-                              {player.is_guest ? "Guest player" : player.email}
+                              {player.is_guest ? "Guest player" : (player.email ?? "")}
                             </Text>
                           </View>
                           <Ionicons
@@ -1028,7 +1028,7 @@ export default function RoundDetailScreen() {
                             </View>
                             {/* Guests have only a synthetic email — show a label instead. */}
                             <Text className={`text-xs ${t.textTertiary}`} numberOfLines={1}>
-                              {player.is_guest ? "Guest player" : player.email}
+                              {player.is_guest ? "Guest player" : (player.email ?? "")}
                             </Text>
                           </View>
                         </TouchableOpacity>
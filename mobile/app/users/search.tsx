@@ -31,7 +31,7 @@ import UserAvatar from "@/components/UserAvatar";
 type UserSearchResult = {
   id: string;
   display_name: string;
-  email: string;
+  email: string | null; // null when the user hides it
   avatar_url: string | null;
   is_following: boolean;
 };
@@ -183,9 +183,11 @@ export default function UserSearchScreen() {
                 <Text className={`text-sm font-semibold ${t.textPrimary}`} numberOfLines={1}>
                   {item.display_name}
                 </Text>
-                <Text className={`text-xs ${t.textTertiary}`} numberOfLines={1}>
-                  {item.email}
-                </Text>
+                {item.email ? (
+                  <Text className={`text-xs ${t.textTertiary}`} numberOfLines={1}>
+                    {item.email}
+                  </Text>
+                ) : null}
               </View>
               {/* Follow / Unfollow button */}
               <TouchableOpacity
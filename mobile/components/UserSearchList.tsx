@@ -33,10 +33,11 @@ import UserAvatar from "@/components/UserAvatar";
 
 // UserSummary matches the shape returned by GET /api/v1/users.
 // Exported so parent screens can type their query data correctly.
+// email is null when the user hides it (privacy settings).
 export type UserSummary = {
   id: string;
   display_name: string;
-  email: string;
+  email: string | null;
   avatar_url?: string | null;
 };
 
@@ -79,7 +80,7 @@ export default function UserSearchList({
   const filtered = (users ?? []).filter((u) => {
     if (!search.trim()) return true;
     const q = search.toLowerCase();
-    return u.display_name.toLowerCase().includes(q) || u.email.toLowerCase().includes(q);
+    return u.display_name.toLowerCase().includes(q) || (u.email?.toLowerCase().includes(q) ?? false);
   });
 
   return (
@@ -137,9 +138,11 @@ export default function UserSearchList({
                 <Text className={`font-semibold text-sm ${t.textPrimary}`} numberOfLines={1}>
                   {item.display_name}
                 </Text>
-                <Text className={`text-xs ${t.textTertiary}`} numberOfLines={1}>
-                  {item.email}
-                </Text>
+                {item.email ? (
+                  <Text className={`text-xs ${t.textTertiary}`} numberOfLines={1}>
+                    {item.email}
+                  </Text>
+                ) : null}
               </View>
 
               {/* Spinner on all rows while pending (list is fully disabled anyway) */}